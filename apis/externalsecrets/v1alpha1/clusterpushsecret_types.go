@@ -0,0 +1,121 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// ClusterPushSecretSpec selects Secrets across namespaces and pushes each of
+// them with a PushSecret created in its namespace.
+type ClusterPushSecretSpec struct {
+	// NamespaceSelector selects the namespaces of the Secrets. Secrets of all
+	// namespaces are selected if not set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// SecretSelector selects the Secrets to push by their labels.
+	SecretSelector metav1.LabelSelector `json:"secretSelector"`
+
+	// PushSecretSpec is the spec of the PushSecrets created for the selected
+	// Secrets.
+	PushSecretSpec ClusterPushSecretTemplate `json:"pushSecretSpec"`
+
+	// The time in which the controller should reconcile its objects and recheck
+	// the namespaces and Secrets for labels.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshTime,omitempty"`
+}
+
+// ClusterPushSecretTemplate is the spec of a PushSecret without the selector,
+// which is set to the selected Secret. The remoteKey and property of the data
+// are Go templates, {{ .Namespace }} and {{ .Name }} are the namespace and
+// the name of the Secret.
+type ClusterPushSecretTemplate struct {
+	// The Interval to which External Secrets will try to push a secret definition
+	// +optional
+	RefreshInterval *metav1.Duration     `json:"refreshInterval,omitempty"`
+	SecretStoreRefs []PushSecretStoreRef `json:"secretStoreRefs"`
+	// Deletion Policy to handle Secrets in the provider. Possible Values: "Delete/None/Retain". Defaults to "None".
+	// +kubebuilder:default="None"
+	// +optional
+	DeletionPolicy PushSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// UpdatePolicy to handle Secrets that already exist in the provider. Possible Values: "Replace/IfNotExists/Merge". Defaults to "Replace".
+	// +kubebuilder:default="Replace"
+	// +optional
+	UpdatePolicy esv1beta1.PushSecretUpdatePolicy `json:"updatePolicy,omitempty"`
+	// ConflictPolicy decides which side wins if a pushed secret is also pulled into the source Secret by an ExternalSecret.
+	// Possible Values: "Fail/ProviderWins/ClusterWins". Defaults to "Fail".
+	// +kubebuilder:default="Fail"
+	// +optional
+	ConflictPolicy PushSecretConflictPolicy `json:"conflictPolicy,omitempty"`
+	// Secret Data that should be pushed to providers
+	Data []PushSecretData `json:"data,omitempty"`
+	// Template defines a blueprint for the created Secret resource.
+	// +optional
+	Template *esv1beta1.ExternalSecretTemplate `json:"template,omitempty"`
+}
+
+// ClusterPushSecretFailure is a selected Secret whose PushSecret could not be
+// created or updated.
+type ClusterPushSecretFailure struct {
+	// Namespace of the Secret.
+	Namespace string `json:"namespace"`
+	// Name of the Secret.
+	Name string `json:"name"`
+	// Reason is why the PushSecret could not be created or updated.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ClusterPushSecretStatus is the observed state of the ClusterPushSecret.
+type ClusterPushSecretStatus struct {
+	// PushSecrets is the number of PushSecrets created for the selected Secrets.
+	// +optional
+	PushSecrets int `json:"pushSecrets,omitempty"`
+	// FailedSecrets are the selected Secrets whose PushSecrets could not be
+	// created or updated.
+	// +optional
+	FailedSecrets []ClusterPushSecretFailure `json:"failedSecrets,omitempty"`
+	// +optional
+	Conditions []PushSecretStatusCondition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// ClusterPushSecret pushes the Secrets selected across namespaces.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="PushSecrets",type=integer,JSONPath=`.status.pushSecrets`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={pushsecrets},shortName=cps
+
+type ClusterPushSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterPushSecretSpec   `json:"spec,omitempty"`
+	Status ClusterPushSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// ClusterPushSecretList contains a list of ClusterPushSecret resources.
+type ClusterPushSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPushSecret `json:"items"`
+}
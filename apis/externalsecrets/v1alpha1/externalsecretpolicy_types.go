@@ -0,0 +1,68 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExternalSecretPolicySpec restricts the stores and remote keys the
+// ExternalSecrets of a namespace may use.
+type ExternalSecretPolicySpec struct {
+	// AllowedStores lists the stores ExternalSecrets may reference.
+	// All stores are allowed if empty.
+	// +optional
+	AllowedStores []ExternalSecretPolicyStore `json:"allowedStores,omitempty"`
+
+	// AllowedRemoteKeys lists glob patterns, e.g. team-a-*, the remote keys
+	// of data, dataFrom.extract and the path of dataFrom.find must match.
+	// dataFrom.find without a path is rejected if set. All remote keys are
+	// allowed if empty.
+	// +optional
+	AllowedRemoteKeys []string `json:"allowedRemoteKeys,omitempty"`
+}
+
+// ExternalSecretPolicyStore matches the stores referenced by ExternalSecrets.
+type ExternalSecretPolicyStore struct {
+	// Kind of the store, all kinds match if empty.
+	// +kubebuilder:validation:Enum=SecretStore;ClusterSecretStore
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name is a glob pattern the name of the store must match.
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// ExternalSecretPolicy restricts the ExternalSecrets of its namespace, they
+// are validated against all policies of the namespace on admission.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories={externalsecretpolicies}
+
+type ExternalSecretPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ExternalSecretPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// ExternalSecretPolicyList contains a list of ExternalSecretPolicy resources.
+type ExternalSecretPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExternalSecretPolicy `json:"items"`
+}
@@ -0,0 +1,193 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// ExternalSecretPolicyWebhookPath is the path of the webhook that validates
+// v1beta1 ExternalSecrets against the ExternalSecretPolicies of their namespace.
+const ExternalSecretPolicyWebhookPath = "/validate-external-secrets-io-v1beta1-externalsecret-policy"
+
+// SetupWebhookWithManager registers the webhook that enforces the
+// ExternalSecretPolicies.
+func (p *ExternalSecretPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(ExternalSecretPolicyWebhookPath,
+		admission.WithCustomValidator(mgr.GetScheme(), &esv1beta1.ExternalSecret{}, &ExternalSecretPolicyValidator{Reader: mgr.GetAPIReader()}))
+	return nil
+}
+
+// +kubebuilder:object:generate=false
+type ExternalSecretPolicyValidator struct {
+	// Reader lists the ExternalSecretPolicies of the namespace.
+	Reader client.Reader
+}
+
+func (v *ExternalSecretPolicyValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(ctx, obj)
+}
+
+func (v *ExternalSecretPolicyValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(ctx, newObj)
+}
+
+func (v *ExternalSecretPolicyValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ExternalSecretPolicyValidator) validate(ctx context.Context, obj runtime.Object) error {
+	es, ok := obj.(*esv1beta1.ExternalSecret)
+	if !ok {
+		return fmt.Errorf("unexpected type")
+	}
+	// the finalizers of ExternalSecrets that were created before a policy
+	// must still be removable.
+	if es.DeletionTimestamp != nil {
+		return nil
+	}
+	var policies ExternalSecretPolicyList
+	if err := v.Reader.List(ctx, &policies, client.InNamespace(es.Namespace)); err != nil {
+		return fmt.Errorf("could not list ExternalSecretPolicies: %w", err)
+	}
+	return CheckExternalSecretPolicies(policies.Items, es)
+}
+
+// CheckExternalSecretPolicies returns the reasons the policies do not allow
+// es. The webhook checks it on admission and the controller before every
+// sync.
+func CheckExternalSecretPolicies(policies []ExternalSecretPolicy, es *esv1beta1.ExternalSecret) error {
+	var errs error
+	for i := range policies {
+		if err := policies[i].check(es); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("denied by ExternalSecretPolicy %q: %w", policies[i].Name, err))
+		}
+	}
+	return errs
+}
+
+// check returns the reasons the policy does not allow es.
+func (p *ExternalSecretPolicy) check(es *esv1beta1.ExternalSecret) error {
+	var errs error
+	if len(p.Spec.AllowedStores) > 0 {
+		for _, ref := range policyStoreRefs(es) {
+			if !p.storeAllowed(ref) {
+				errs = errors.Join(errs, fmt.Errorf("%s %q is not allowed", ref.Kind, ref.Name))
+			}
+		}
+	}
+	if len(p.Spec.AllowedRemoteKeys) > 0 {
+		for i, data := range es.Spec.Data {
+			if data.SourceRef != nil && data.SourceRef.GeneratorRef != nil {
+				continue
+			}
+			if !p.keyAllowed(data.RemoteRef.Key) {
+				errs = errors.Join(errs, fmt.Errorf("data[%d]: remote key %q is not allowed", i, data.RemoteRef.Key))
+			}
+		}
+		for i, ref := range es.Spec.DataFrom {
+			if ref.Extract != nil && !p.keyAllowed(ref.Extract.Key) {
+				errs = errors.Join(errs, fmt.Errorf("dataFrom[%d]: remote key %q is not allowed", i, ref.Extract.Key))
+			}
+			if ref.SourceRef != nil && ref.SourceRef.GeneratorRef != nil {
+				for j, param := range ref.SourceRef.GeneratorRef.Parameters {
+					if !p.keyAllowed(param.RemoteRef.Key) {
+						errs = errors.Join(errs, fmt.Errorf("dataFrom[%d].sourceRef.generatorRef.parameters[%d]: remote key %q is not allowed", i, j, param.RemoteRef.Key))
+					}
+				}
+			}
+			if ref.Find == nil {
+				continue
+			}
+			if ref.Find.Path == nil {
+				errs = errors.Join(errs, fmt.Errorf("dataFrom[%d]: find requires a path", i))
+			} else if !p.keyAllowed(*ref.Find.Path) {
+				errs = errors.Join(errs, fmt.Errorf("dataFrom[%d]: find path %q is not allowed", i, *ref.Find.Path))
+			}
+		}
+	}
+	return errs
+}
+
+func (p *ExternalSecretPolicy) storeAllowed(ref esv1beta1.SecretStoreRef) bool {
+	for _, store := range p.Spec.AllowedStores {
+		if store.Kind != "" && store.Kind != ref.Kind {
+			continue
+		}
+		if ok, _ := path.Match(store.Name, ref.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ExternalSecretPolicy) keyAllowed(key string) bool {
+	for _, pattern := range p.Spec.AllowedRemoteKeys {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// policyStoreRefs returns the unique stores referenced by es, including the
+// stores of generator parameters, with the kind defaulted.
+func policyStoreRefs(es *esv1beta1.ExternalSecret) []esv1beta1.SecretStoreRef {
+	var refs []esv1beta1.SecretStoreRef
+	add := func(ref *esv1beta1.SecretStoreRef) {
+		if ref == nil || ref.Name == "" {
+			return
+		}
+		r := esv1beta1.SecretStoreRef{Name: ref.Name, Kind: ref.Kind}
+		if r.Kind == "" {
+			r.Kind = esv1beta1.SecretStoreKind
+		}
+		for _, seen := range refs {
+			if seen == r {
+				return
+			}
+		}
+		refs = append(refs, r)
+	}
+	add(&es.Spec.SecretStoreRef)
+	for i := range es.Spec.Data {
+		if es.Spec.Data[i].SourceRef != nil {
+			add(&es.Spec.Data[i].SourceRef.SecretStoreRef)
+		}
+	}
+	for i := range es.Spec.DataFrom {
+		sourceRef := es.Spec.DataFrom[i].SourceRef
+		if sourceRef == nil {
+			continue
+		}
+		add(sourceRef.SecretStoreRef)
+		if sourceRef.GeneratorRef != nil {
+			for j := range sourceRef.GeneratorRef.Parameters {
+				add(sourceRef.GeneratorRef.Parameters[j].StoreRef)
+			}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,166 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestValidateExternalSecretPolicies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&ExternalSecretPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "stores", Namespace: "team-a"},
+			Spec: ExternalSecretPolicySpec{AllowedStores: []ExternalSecretPolicyStore{
+				{Kind: esv1beta1.ClusterSecretStoreKind, Name: "chef-team-a"},
+				{Name: "local-*"},
+			}},
+		},
+		&ExternalSecretPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "databags", Namespace: "team-a"},
+			Spec:       ExternalSecretPolicySpec{AllowedRemoteKeys: []string{"team-a-*/*", "team-a-*"}},
+		},
+		&ExternalSecretPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-b"},
+			Spec:       ExternalSecretPolicySpec{AllowedRemoteKeys: []string{"team-b-*"}},
+		},
+	).Build()
+	clusterStore := func(name string) esv1beta1.SecretStoreRef {
+		return esv1beta1.SecretStoreRef{Name: name, Kind: esv1beta1.ClusterSecretStoreKind}
+	}
+	data := func(key string) []esv1beta1.ExternalSecretData {
+		return []esv1beta1.ExternalSecretData{{SecretKey: "key", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: key}}}
+	}
+	tests := []struct {
+		name        string
+		namespace   string
+		spec        esv1beta1.ExternalSecretSpec
+		deleted     bool
+		expectedErr string
+	}{
+		{
+			name: "allowed",
+			spec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef: clusterStore("chef-team-a"),
+				Data:           data("team-a-app/db"),
+				DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+					{Extract: &esv1beta1.ExternalSecretDataRemoteRef{Key: "team-a-app/api"}},
+					{Find: &esv1beta1.ExternalSecretFind{Path: ptr.To("team-a-app")}, SourceRef: &esv1beta1.StoreGeneratorSourceRef{
+						SecretStoreRef: &esv1beta1.SecretStoreRef{Name: "local-chef"},
+					}},
+				},
+			},
+		},
+		{
+			name:        "denied store",
+			spec:        esv1beta1.ExternalSecretSpec{SecretStoreRef: clusterStore("chef-team-b"), Data: data("team-a-app/db")},
+			expectedErr: `denied by ExternalSecretPolicy "stores": ClusterSecretStore "chef-team-b" is not allowed`,
+		},
+		{
+			name:        "denied kind",
+			spec:        esv1beta1.ExternalSecretSpec{SecretStoreRef: esv1beta1.SecretStoreRef{Name: "chef-team-a"}, Data: data("team-a-app/db")},
+			expectedErr: `denied by ExternalSecretPolicy "stores": SecretStore "chef-team-a" is not allowed`,
+		},
+		{
+			name:        "denied remote key",
+			spec:        esv1beta1.ExternalSecretSpec{SecretStoreRef: clusterStore("chef-team-a"), Data: data("team-b-app/db")},
+			expectedErr: `denied by ExternalSecretPolicy "databags": data[0]: remote key "team-b-app/db" is not allowed`,
+		},
+		{
+			name: "denied dataFrom",
+			spec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef: clusterStore("chef-team-a"),
+				DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+					{Extract: &esv1beta1.ExternalSecretDataRemoteRef{Key: "team-b-app/api"}},
+					{Find: &esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}}},
+				},
+			},
+			expectedErr: "denied by ExternalSecretPolicy \"databags\": dataFrom[0]: remote key \"team-b-app/api\" is not allowed\n" +
+				"dataFrom[1]: find requires a path",
+		},
+		{
+			name: "denied store of generator parameter",
+			spec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef: clusterStore("chef-team-a"),
+				DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{{SourceRef: &esv1beta1.StoreGeneratorSourceRef{GeneratorRef: &esv1beta1.GeneratorRef{
+					Kind: "Password",
+					Name: "password",
+					Parameters: []esv1beta1.GeneratorParameter{
+						{Path: "length", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "team-a-app/length"}, StoreRef: ptr.To(clusterStore("chef-team-b"))},
+					},
+				}}}},
+			},
+			expectedErr: `denied by ExternalSecretPolicy "stores": ClusterSecretStore "chef-team-b" is not allowed`,
+		},
+		{
+			name: "denied remote key of generator parameter",
+			spec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef: clusterStore("chef-team-a"),
+				DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{{SourceRef: &esv1beta1.StoreGeneratorSourceRef{GeneratorRef: &esv1beta1.GeneratorRef{
+					Kind: "Password",
+					Name: "password",
+					Parameters: []esv1beta1.GeneratorParameter{
+						{Path: "length", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "team-a-app/length"}},
+						{Path: "symbols", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "team-b-app/symbols"}},
+					},
+				}}}},
+			},
+			expectedErr: `denied by ExternalSecretPolicy "databags": dataFrom[0].sourceRef.generatorRef.parameters[1]: remote key "team-b-app/symbols" is not allowed`,
+		},
+		{
+			name:      "policies of other namespaces",
+			namespace: "team-c",
+			spec:      esv1beta1.ExternalSecretSpec{SecretStoreRef: clusterStore("chef-team-b"), Data: data("team-b-app/db")},
+		},
+		{
+			name:    "deleted",
+			spec:    esv1beta1.ExternalSecretSpec{SecretStoreRef: clusterStore("chef-team-b"), Data: data("team-b-app/db")},
+			deleted: true,
+		},
+	}
+	v := &ExternalSecretPolicyValidator{Reader: reader}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "team-a"}, Spec: tt.spec}
+			if tt.namespace != "" {
+				es.Namespace = tt.namespace
+			}
+			if tt.deleted {
+				es.DeletionTimestamp = ptr.To(metav1.Now())
+			}
+			_, err := v.ValidateUpdate(context.Background(), nil, es)
+			if err != nil {
+				if err.Error() != tt.expectedErr {
+					t.Fatalf("ValidateUpdate() returned an unexpected error: got: %v, expected: %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if tt.expectedErr != "" {
+				t.Errorf("ValidateUpdate() should have returned an error but got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProviderPluginSpec configures the connection to an out-of-tree provider plugin.
+type ProviderPluginSpec struct {
+	// Address of the gRPC server of the plugin, e.g. unix:///plugins/vendor.sock
+	// for a sidecar that shares a volume with the controller or dns:///vendor-plugin.plugins.svc:8080.
+	Address string `json:"address"`
+
+	// PEM encoded CA bundle used to validate the certificate of the plugin.
+	// The connection is only encrypted if set, use it for plugins that are
+	// not reached through a unix socket.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// ServerName overrides the name the certificate of the plugin is validated for.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// Timeout of a call to the plugin.
+	// +kubebuilder:default="30s"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// ProviderPlugin registers an out-of-tree provider plugin that stores refer to by name.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Address",type=string,JSONPath=`.spec.address`
+// +kubebuilder:resource:scope=Cluster,categories={providerplugins}
+
+type ProviderPlugin struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProviderPluginSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// ProviderPluginList contains a list of ProviderPlugin resources.
+type ProviderPluginList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderPlugin `json:"items"`
+}
@@ -23,8 +23,13 @@ import (
 )
 
 const (
-	ReasonSynced  = "Synced"
-	ReasonErrored = "Errored"
+	ReasonSynced   = "Synced"
+	ReasonErrored  = "Errored"
+	ReasonConflict = "Conflict"
+	ReasonDenied   = "Denied"
+	// ReasonSourceDeleted is set when the source Secret was deleted and the
+	// pushed secrets were removed from the providers.
+	ReasonSourceDeleted = "SourceDeleted"
 )
 
 type PushSecretStoreRef struct {
@@ -41,12 +46,33 @@ type PushSecretStoreRef struct {
 	Kind string `json:"kind,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=Delete;None
+// +kubebuilder:validation:Enum=Delete;None;Retain
 type PushSecretDeletionPolicy string
 
 const (
+	// PushSecretDeletionPolicyDelete deletes the pushed secrets from the provider
+	// when the PushSecret, its source Secret or one of its data entries is removed.
 	PushSecretDeletionPolicyDelete PushSecretDeletionPolicy = "Delete"
-	PushSecretDeletionPolicyNone   PushSecretDeletionPolicy = "None"
+	// PushSecretDeletionPolicyNone keeps the pushed secrets in the provider.
+	PushSecretDeletionPolicyNone PushSecretDeletionPolicy = "None"
+	// PushSecretDeletionPolicyRetain explicitly keeps the pushed secrets in the
+	// provider, e.g. in environments that must retain them for auditing.
+	PushSecretDeletionPolicyRetain PushSecretDeletionPolicy = "Retain"
+)
+
+// +kubebuilder:validation:Enum=Fail;ProviderWins;ClusterWins
+type PushSecretConflictPolicy string
+
+const (
+	// PushSecretConflictPolicyFail stops pushing if a pushed secret is pulled
+	// back into the source Secret by an ExternalSecret.
+	PushSecretConflictPolicyFail PushSecretConflictPolicy = "Fail"
+	// PushSecretConflictPolicyProviderWins skips pushing the conflicting data,
+	// the ExternalSecret keeps pulling the value from the provider.
+	PushSecretConflictPolicyProviderWins PushSecretConflictPolicy = "ProviderWins"
+	// PushSecretConflictPolicyClusterWins pushes the conflicting data, the
+	// ExternalSecret keeps the value of the source Secret instead of pulling it.
+	PushSecretConflictPolicyClusterWins PushSecretConflictPolicy = "ClusterWins"
 )
 
 // PushSecretSpec configures the behavior of the PushSecret.
@@ -54,10 +80,19 @@ type PushSecretSpec struct {
 	// The Interval to which External Secrets will try to push a secret definition
 	RefreshInterval *metav1.Duration     `json:"refreshInterval,omitempty"`
 	SecretStoreRefs []PushSecretStoreRef `json:"secretStoreRefs"`
-	// Deletion Policy to handle Secrets in the provider. Possible Values: "Delete/None". Defaults to "None".
+	// Deletion Policy to handle Secrets in the provider. Possible Values: "Delete/None/Retain". Defaults to "None".
 	// +kubebuilder:default="None"
 	// +optional
 	DeletionPolicy PushSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// UpdatePolicy to handle Secrets that already exist in the provider. Possible Values: "Replace/IfNotExists/Merge". Defaults to "Replace".
+	// +kubebuilder:default="Replace"
+	// +optional
+	UpdatePolicy esv1beta1.PushSecretUpdatePolicy `json:"updatePolicy,omitempty"`
+	// ConflictPolicy decides which side wins if a pushed secret is also pulled into the source Secret by an ExternalSecret.
+	// Possible Values: "Fail/ProviderWins/ClusterWins". Defaults to "Fail".
+	// +kubebuilder:default="Fail"
+	// +optional
+	ConflictPolicy PushSecretConflictPolicy `json:"conflictPolicy,omitempty"`
 	// The Secret Selector (k8s source) for the Push Secret
 	Selector PushSecretSelector `json:"selector"`
 	// Secret Data that should be pushed to providers
@@ -164,6 +199,21 @@ type PushSecretStatus struct {
 	SyncedPushSecrets SyncedPushSecretsMap `json:"syncedPushSecrets,omitempty"`
 	// +optional
 	Conditions []PushSecretStatusCondition `json:"conditions,omitempty"`
+	// Conflicts lists the ExternalSecret data that pulls secrets pushed by this PushSecret back into the source Secret.
+	// +optional
+	Conflicts []PushSecretConflict `json:"conflicts,omitempty"`
+}
+
+// PushSecretConflict is an ExternalSecret data entry that pulls a pushed secret.
+type PushSecretConflict struct {
+	// ExternalSecret is the name of the ExternalSecret pulling the pushed secret.
+	ExternalSecret string `json:"externalSecret"`
+	// SecretKey is the key of the source Secret written by the ExternalSecret.
+	SecretKey string `json:"secretKey"`
+	// Store is the secret store both resources refer to, in the format Kind/Name.
+	Store string `json:"store"`
+	// RemoteKey is the key of the secret in the provider.
+	RemoteKey string `json:"remoteKey"`
 }
 
 // +kubebuilder:object:root=true
@@ -67,9 +67,45 @@ var (
 	PushSecretGroupVersionKind = SchemeGroupVersion.WithKind(PushSecretKind)
 )
 
+// ClusterPushSecret type metadata.
+var (
+	ClusterPushSecretKind             = reflect.TypeOf(ClusterPushSecret{}).Name()
+	ClusterPushSecretGroupKind        = schema.GroupKind{Group: Group, Kind: ClusterPushSecretKind}.String()
+	ClusterPushSecretKindAPIVersion   = ClusterPushSecretKind + "." + SchemeGroupVersion.String()
+	ClusterPushSecretGroupVersionKind = SchemeGroupVersion.WithKind(ClusterPushSecretKind)
+)
+
+// ProviderPlugin type metadata.
+var (
+	ProviderPluginKind             = reflect.TypeOf(ProviderPlugin{}).Name()
+	ProviderPluginGroupKind        = schema.GroupKind{Group: Group, Kind: ProviderPluginKind}.String()
+	ProviderPluginKindAPIVersion   = ProviderPluginKind + "." + SchemeGroupVersion.String()
+	ProviderPluginGroupVersionKind = SchemeGroupVersion.WithKind(ProviderPluginKind)
+)
+
+// ExternalSecretPolicy type metadata.
+var (
+	ExternalSecretPolicyKind             = reflect.TypeOf(ExternalSecretPolicy{}).Name()
+	ExternalSecretPolicyGroupKind        = schema.GroupKind{Group: Group, Kind: ExternalSecretPolicyKind}.String()
+	ExternalSecretPolicyKindAPIVersion   = ExternalSecretPolicyKind + "." + SchemeGroupVersion.String()
+	ExternalSecretPolicyGroupVersionKind = SchemeGroupVersion.WithKind(ExternalSecretPolicyKind)
+)
+
+// SecretSyncPolicy type metadata.
+var (
+	SecretSyncPolicyKind             = reflect.TypeOf(SecretSyncPolicy{}).Name()
+	SecretSyncPolicyGroupKind        = schema.GroupKind{Group: Group, Kind: SecretSyncPolicyKind}.String()
+	SecretSyncPolicyKindAPIVersion   = SecretSyncPolicyKind + "." + SchemeGroupVersion.String()
+	SecretSyncPolicyGroupVersionKind = SchemeGroupVersion.WithKind(SecretSyncPolicyKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ExternalSecret{}, &ExternalSecretList{})
 	SchemeBuilder.Register(&SecretStore{}, &SecretStoreList{})
 	SchemeBuilder.Register(&ClusterSecretStore{}, &ClusterSecretStoreList{})
 	SchemeBuilder.Register(&PushSecret{}, &PushSecretList{})
+	SchemeBuilder.Register(&ClusterPushSecret{}, &ClusterPushSecretList{})
+	SchemeBuilder.Register(&ProviderPlugin{}, &ProviderPluginList{})
+	SchemeBuilder.Register(&ExternalSecretPolicy{}, &ExternalSecretPolicyList{})
+	SchemeBuilder.Register(&SecretSyncPolicy{}, &SecretSyncPolicyList{})
 }
@@ -0,0 +1,72 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// SecretSyncPolicySpec defines the defaults of the ExternalSecrets that do
+// not set the fields themselves.
+type SecretSyncPolicySpec struct {
+	// NamespaceSelector selects the namespaces of the ExternalSecrets the
+	// policy applies to. It applies to all namespaces if not set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// RefreshInterval of the ExternalSecrets that do not set one.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// DeletionPolicy of the ExternalSecrets that do not set one. It is not
+	// applied to ExternalSecrets whose creationPolicy does not allow it.
+	// +optional
+	DeletionPolicy esv1beta1.ExternalSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// TemplateEngineVersion of the templates that do not set one.
+	// +optional
+	TemplateEngineVersion esv1beta1.TemplateEngineVersion `json:"templateEngineVersion,omitempty"`
+
+	// PropagateLabels lists the labels of the ExternalSecrets that are copied
+	// to their Secrets if they use a template that does not set them. Without
+	// a template all labels are copied.
+	// +optional
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// SecretSyncPolicy defines defaults of the ExternalSecrets of the cluster.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Refresh Interval",type=string,JSONPath=`.spec.refreshInterval`
+// +kubebuilder:printcolumn:name="Deletion Policy",type=string,JSONPath=`.spec.deletionPolicy`
+// +kubebuilder:resource:scope=Cluster,categories={secretsyncpolicies}
+
+type SecretSyncPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SecretSyncPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// SecretSyncPolicyList contains a list of SecretSyncPolicy resources.
+type SecretSyncPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretSyncPolicy `json:"items"`
+}
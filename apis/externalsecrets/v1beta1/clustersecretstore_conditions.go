@@ -0,0 +1,48 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NamespaceAllowed reports whether ExternalSecrets in namespace ns may use a
+// ClusterSecretStore with the given conditions. A store without conditions
+// allows every namespace, otherwise ns has to match at least one condition.
+func NamespaceAllowed(conditions []ClusterSecretStoreCondition, ns *corev1.Namespace) (bool, error) {
+	if len(conditions) == 0 {
+		return true, nil
+	}
+	for _, condition := range conditions {
+		if slices.Contains(condition.Namespaces, ns.Name) {
+			return true, nil
+		}
+		if condition.NamespaceSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(condition.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -90,12 +90,20 @@ type ExternalSecretTemplate struct {
 	// EngineVersion specifies the template engine version
 	// that should be used to compile/execute the
 	// template specified in .data and .templateFrom[].
-	// +kubebuilder:default="v2"
+	// Defaults to the templateEngineVersion of a SecretSyncPolicy, or v2.
+	// +optional
 	EngineVersion TemplateEngineVersion `json:"engineVersion,omitempty"`
 	// +optional
 	Metadata ExternalSecretTemplateMetadata `json:"metadata,omitempty"`
 	// +kubebuilder:default="Replace"
 	MergePolicy TemplateMergePolicy `json:"mergePolicy,omitempty"`
+	// MissingKeyPolicy defines how the template engine handles references
+	// to keys that do not exist in the fetched data.
+	// `Default` renders `<no value>`, `Zero` renders an empty string and
+	// `Error` fails the sync. Only supported by engine version v2.
+	// +optional
+	// +kubebuilder:default="Default"
+	MissingKeyPolicy TemplateMissingKeyPolicy `json:"missingKeyPolicy,omitempty"`
 	// +optional
 	Data map[string]string `json:"data,omitempty"`
 	// +optional
@@ -110,6 +118,15 @@ const (
 	MergePolicyMerge   TemplateMergePolicy = "Merge"
 )
 
+// +kubebuilder:validation:Enum=Default;Zero;Error
+type TemplateMissingKeyPolicy string
+
+const (
+	MissingKeyPolicyDefault TemplateMissingKeyPolicy = "Default"
+	MissingKeyPolicyZero    TemplateMissingKeyPolicy = "Zero"
+	MissingKeyPolicyError   TemplateMissingKeyPolicy = "Error"
+)
+
 // +kubebuilder:validation:Enum=v1;v2
 type TemplateEngineVersion string
 
@@ -118,13 +135,21 @@ const (
 	TemplateEngineV2 TemplateEngineVersion = "v2"
 )
 
+// TemplateFrom specifies a source of templates. Exactly one of
+// ConfigMap, Secret or Literal must be set.
 type TemplateFrom struct {
+	// ConfigMap references templates stored in a ConfigMap.
+	// +optional
 	ConfigMap *TemplateRef `json:"configMap,omitempty"`
-	Secret    *TemplateRef `json:"secret,omitempty"`
+	// Secret references templates stored in a Secret.
 	// +optional
+	Secret *TemplateRef `json:"secret,omitempty"`
+	// Target defines where the rendered template is applied to.
 	// +optional
 	// +kubebuilder:default="Data"
 	Target TemplateTarget `json:"target,omitempty"`
+	// Literal is an inline template which is rendered as `key: value` pairs,
+	// so it does not need a separate ConfigMap or Secret.
 	// +optional
 	Literal *string `json:"literal,omitempty"`
 }
@@ -172,9 +197,8 @@ type ExternalSecretTarget struct {
 	// +kubebuilder:default="Owner"
 	CreationPolicy ExternalSecretCreationPolicy `json:"creationPolicy,omitempty"`
 	// DeletionPolicy defines rules on how to delete the resulting Secret
-	// Defaults to 'Retain'
+	// Defaults to the deletionPolicy of a SecretSyncPolicy, or 'Retain'
 	// +optional
-	// +kubebuilder:default="Retain"
 	DeletionPolicy ExternalSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
 	// Template defines a blueprint for the created Secret resource.
 	// +optional
@@ -183,13 +207,87 @@ type ExternalSecretTarget struct {
 	// Immutable defines if the final secret will be immutable
 	// +optional
 	Immutable bool `json:"immutable,omitempty"`
+
+	// Lazy defers the first sync until a Pod in the namespace references the
+	// Secret in a volume, a projected volume, envFrom or env, so that Secrets
+	// nobody consumes are not read from the provider. Once the Secret exists
+	// it is refreshed as usual. Requires creationPolicy Owner and the
+	// controller to run with --enable-lazy-sync.
+	// +optional
+	Lazy bool `json:"lazy,omitempty"`
+
+	// Reload restarts the workloads using the Secret when its data changes.
+	// Requires the controller to run with --enable-workload-reload.
+	// +optional
+	Reload *ExternalSecretReload `json:"reload,omitempty"`
+
+	// Oversize defines how data that exceeds the size limit of a Secret (1MiB) is handled.
+	// Defaults to failing the sync.
+	// +optional
+	Oversize *ExternalSecretOversize `json:"oversize,omitempty"`
+}
+
+// ExternalSecretOversizePolicy defines how data that exceeds the size limit of a Secret is handled.
+// +kubebuilder:validation:Enum=Fail;DropKeys;Split
+type ExternalSecretOversizePolicy string
+
+const (
+	// OversizePolicyFail fails the sync and lists the keys that exceed the limit.
+	OversizePolicyFail ExternalSecretOversizePolicy = "Fail"
+	// OversizePolicyDropKeys drops the keys of dropKeys, in the given order, until the data fits.
+	OversizePolicyDropKeys ExternalSecretOversizePolicy = "DropKeys"
+	// OversizePolicySplit moves the keys that do not fit to additional Secrets
+	// named <name>-part-1, <name>-part-2 and so on.
+	OversizePolicySplit ExternalSecretOversizePolicy = "Split"
+)
+
+// ExternalSecretOversize defines how data that exceeds the size limit of a Secret is handled.
+type ExternalSecretOversize struct {
+	// Policy is Fail, DropKeys or Split. Split requires creationPolicy Owner.
+	// +optional
+	// +kubebuilder:default="Fail"
+	Policy ExternalSecretOversizePolicy `json:"policy,omitempty"`
+
+	// DropKeys are the keys that are dropped with policy DropKeys, in the given order, until the data fits.
+	// +optional
+	DropKeys []string `json:"dropKeys,omitempty"`
+}
+
+// ExternalSecretReload defines the workloads that are restarted when the
+// data of the target Secret changes. Workloads are restarted by setting the
+// data hash of the Secret as annotation on their pod template.
+type ExternalSecretReload struct {
+	// Workloads to restart. If empty, all Deployments, StatefulSets and
+	// DaemonSets in the namespace of the ExternalSecret that use the Secret
+	// in a volume or an environment variable are restarted.
+	// +optional
+	Workloads []ReloadWorkloadRef `json:"workloads,omitempty"`
+}
+
+// ReloadWorkloadRef references a workload in the namespace of the ExternalSecret.
+type ReloadWorkloadRef struct {
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet;DaemonSet
+	Kind string `json:"kind"`
+	Name string `json:"name"`
 }
 
 // ExternalSecretData defines the connection between the Kubernetes Secret key (spec.data.<key>) and the Provider data.
 type ExternalSecretData struct {
 	// SecretKey defines the key in which the controller stores
-	// the value. This is the key in the Kind=Secret
-	SecretKey string `json:"secretKey"`
+	// the value. This is the key in the Kind=Secret.
+	// The key can be a template, e.g. {{ .remoteRef.property | upper }},
+	// with the key, property and version of the remoteRef available as .remoteRef.
+	// Required unless propertyKeys is set.
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+
+	// PropertyKeys maps properties of the remote secret to keys in the
+	// Kind=Secret, e.g. some_password: DB_PASSWORD. Every property is
+	// fetched from the same remoteRef and stored under its key. The keys can
+	// be templates like secretKey.
+	// Can not be used together with secretKey or remoteRef.property.
+	// +optional
+	PropertyKeys map[string]string `json:"propertyKeys,omitempty"`
 
 	// RemoteRef points to the remote secret and defines
 	// which secret (version/property/..) to fetch.
@@ -198,6 +296,19 @@ type ExternalSecretData struct {
 	// SourceRef allows you to override the source
 	// from which the value will pulled from.
 	SourceRef *StoreSourceRef `json:"sourceRef,omitempty"`
+
+	// TemplateBypass writes the value to the Secret as is, without passing it
+	// to the template. Use it for binary values like keystores or DER certificates.
+	// The value takes precedence over template output for the same key.
+	// +optional
+	TemplateBypass bool `json:"templateBypass,omitempty"`
+
+	// RefreshInterval is the amount of time before the value of this entry is read
+	// again from the provider. In between, the value is kept in the memory of the
+	// controller. Entries without a refreshInterval and dataFrom are read at the
+	// refreshInterval of the ExternalSecret.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 }
 
 // ExternalSecretDataRemoteRef defines Provider data location.
@@ -245,14 +356,16 @@ const (
 	ExternalSecretConversionUnicode ExternalSecretConversionStrategy = "Unicode"
 )
 
-// +kubebuilder:validation:Enum=Auto;Base64;Base64URL;None
+// +kubebuilder:validation:Enum=Auto;Base64;Base64URL;Base64Gzip;None
 type ExternalSecretDecodingStrategy string
 
 const (
 	ExternalSecretDecodeAuto      ExternalSecretDecodingStrategy = "Auto"
 	ExternalSecretDecodeBase64    ExternalSecretDecodingStrategy = "Base64"
 	ExternalSecretDecodeBase64URL ExternalSecretDecodingStrategy = "Base64URL"
-	ExternalSecretDecodeNone      ExternalSecretDecodingStrategy = "None"
+	// ExternalSecretDecodeBase64Gzip decodes base64 and decompresses the gzip stream.
+	ExternalSecretDecodeBase64Gzip ExternalSecretDecodingStrategy = "Base64Gzip"
+	ExternalSecretDecodeNone       ExternalSecretDecodingStrategy = "None"
 )
 
 type ExternalSecretDataFromRemoteRef struct {
@@ -338,16 +451,38 @@ type FindName struct {
 type ExternalSecretSpec struct {
 	// +optional
 	SecretStoreRef SecretStoreRef `json:"secretStoreRef,omitempty"`
-	// +kubebuilder:default={creationPolicy:Owner,deletionPolicy:Retain}
+	// +kubebuilder:default={creationPolicy:Owner}
 	// +optional
 	Target ExternalSecretTarget `json:"target,omitempty"`
 
 	// RefreshInterval is the amount of time before the values are read again from the SecretStore provider
 	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h"
-	// May be set to zero to fetch and create it once. Defaults to 1h.
-	// +kubebuilder:default="1h"
+	// May be set to zero to fetch and create it once. Defaults to the
+	// refreshInterval of a SecretSyncPolicy, or 1h.
+	// +optional
 	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 
+	// FreshnessThreshold is the maximum time since the last successful sync
+	// before the Stale condition is set to True. It should be larger than the
+	// refreshInterval. Defaults to the --freshness-threshold flag of the
+	// controller, a value of zero disables the check.
+	// +optional
+	FreshnessThreshold *metav1.Duration `json:"freshnessThreshold,omitempty"`
+
+	// RetrySettings overrides the retrySettings of the stores the ExternalSecret
+	// reads from, e.g. to retry latency-critical secrets more aggressively.
+	// Fields that are not set are taken from the store.
+	// +optional
+	RetrySettings *SecretStoreRetrySettings `json:"retrySettings,omitempty"`
+
+	// Priority orders the reconciliation of ExternalSecrets that are due at
+	// the same time, e.g. after a restart of the controller or when a store
+	// becomes ready again. ExternalSecrets with a higher priority are
+	// reconciled first. Defaults to 0, negative values are reconciled after
+	// the default.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
 	// Data defines the connection between the Kubernetes Secret keys and the Provider data
 	// +optional
 	Data []ExternalSecretData `json:"data,omitempty"`
@@ -356,8 +491,27 @@ type ExternalSecretSpec struct {
 	// If multiple entries are specified, the Secret keys are merged in the specified order
 	// +optional
 	DataFrom []ExternalSecretDataFromRemoteRef `json:"dataFrom,omitempty"`
+
+	// SyncPolicy decides how failures of single data entries are handled.
+	// AllOrNothing fails the sync if any entry fails. Partial writes the keys
+	// that were read and records the failed entries in status.unresolvedKeys.
+	// Defaults to AllOrNothing.
+	// +optional
+	SyncPolicy ExternalSecretSyncPolicy `json:"syncPolicy,omitempty"`
 }
 
+// ExternalSecretSyncPolicy decides how failures of single data entries are handled.
+// +kubebuilder:validation:Enum=AllOrNothing;Partial
+type ExternalSecretSyncPolicy string
+
+const (
+	// SyncPolicyAllOrNothing fails the sync if any data entry fails.
+	SyncPolicyAllOrNothing ExternalSecretSyncPolicy = "AllOrNothing"
+	// SyncPolicyPartial writes the keys of the data entries that were read
+	// and records the failed entries in the status.
+	SyncPolicyPartial ExternalSecretSyncPolicy = "Partial"
+)
+
 // StoreSourceRef allows you to override the SecretStore source
 // from which the secret will be pulled from.
 // You can define at maximum one property.
@@ -395,6 +549,68 @@ type GeneratorRef struct {
 	Kind string `json:"kind"`
 	// Specify the name of the generator resource
 	Name string `json:"name"`
+
+	// Rotation controls when the output of the generator is regenerated.
+	// Without rotation a new value is generated on every refresh of the ExternalSecret.
+	// +optional
+	Rotation *GeneratorRotation `json:"rotation,omitempty"`
+
+	// Parameters set fields of the generator spec to values read from a SecretStore,
+	// so that generated credentials can be derived from parameters managed in the provider.
+	// The values are read on every refresh, with rotation a changed value triggers a regeneration.
+	// +optional
+	Parameters []GeneratorParameter `json:"parameters,omitempty"`
+}
+
+// GeneratorParameterValueType decides how a parameter value is set in the generator spec.
+// +kubebuilder:validation:Enum=String;JSON
+type GeneratorParameterValueType string
+
+const (
+	// GeneratorParameterValueTypeString sets the value as string.
+	GeneratorParameterValueTypeString GeneratorParameterValueType = "String"
+	// GeneratorParameterValueTypeJSON decodes the value as JSON, e.g. to set numbers or objects.
+	GeneratorParameterValueTypeJSON GeneratorParameterValueType = "JSON"
+)
+
+// GeneratorParameter sets a field of the generator spec to a value read from a SecretStore.
+type GeneratorParameter struct {
+	// Path of the field in the generator spec, e.g. length or auth.username.
+	Path string `json:"path"`
+
+	// RemoteRef points to the value in the SecretStore.
+	RemoteRef ExternalSecretDataRemoteRef `json:"remoteRef"`
+
+	// StoreRef reads the value from another store than the SecretStore of the ExternalSecret.
+	// +optional
+	StoreRef *SecretStoreRef `json:"storeRef,omitempty"`
+
+	// ValueType is String to set the value as string, or JSON to decode it first.
+	// +optional
+	// +kubebuilder:default="String"
+	ValueType GeneratorParameterValueType `json:"valueType,omitempty"`
+}
+
+// GeneratorRotation controls when the output of a generator is regenerated.
+// The output is kept in a Secret owned by the ExternalSecret and is reused
+// on every refresh until one of the triggers fires.
+type GeneratorRotation struct {
+	// Interval after which the output is regenerated.
+	// The output is regenerated on the first refresh after the interval has elapsed.
+	// If not set the output is only regenerated when an input changes.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Inputs are Secrets in the namespace of the ExternalSecret,
+	// the output is regenerated when the data of one of them changes.
+	// Changes of the generator resource always trigger a regeneration.
+	// +optional
+	Inputs []corev1.LocalObjectReference `json:"inputs,omitempty"`
+
+	// KeepPrevious exposes the previous value of every generated key as <key>_previous
+	// until the next rotation, so applications can accept the old and the new value while rolling credentials.
+	// +optional
+	KeepPrevious bool `json:"keepPrevious,omitempty"`
 }
 
 type ExternalSecretConditionType string
@@ -402,6 +618,9 @@ type ExternalSecretConditionType string
 const (
 	ExternalSecretReady   ExternalSecretConditionType = "Ready"
 	ExternalSecretDeleted ExternalSecretConditionType = "Deleted"
+	// ExternalSecretStale is True if the secret was not synced successfully
+	// within the freshness threshold.
+	ExternalSecretStale ExternalSecretConditionType = "Stale"
 )
 
 type ExternalSecretStatusCondition struct {
@@ -425,6 +644,24 @@ const (
 	ConditionReasonSecretSyncedError = "SecretSyncedError"
 	// ConditionReasonSecretDeleted indicates that the secret has been deleted.
 	ConditionReasonSecretDeleted = "SecretDeleted"
+	// ConditionReasonSecretStale indicates that the last successful sync is older than the freshness threshold.
+	ConditionReasonSecretStale = "SecretStale"
+	// ConditionReasonSecretFresh indicates that the last successful sync is within the freshness threshold.
+	ConditionReasonSecretFresh = "SecretFresh"
+	// ConditionReasonSecretTooLarge indicates that the data exceeds the size limit of a Secret.
+	ConditionReasonSecretTooLarge = "SecretTooLarge"
+	// ConditionReasonChefItemSchemaViolation indicates that a Chef data bag item violates a schema of the store.
+	ConditionReasonChefItemSchemaViolation = "ChefItemSchemaViolation"
+	// ConditionReasonSecretDeferred indicates that the first sync waits for a Pod referencing the secret.
+	ConditionReasonSecretDeferred = "SecretDeferred"
+	// ConditionReasonSecretPartiallySynced indicates that the secret was synced without the unresolved keys.
+	ConditionReasonSecretPartiallySynced = "SecretPartiallySynced"
+	// ConditionReasonSecretSyncStopped indicates that the namespace of the ExternalSecret opted out of the sync.
+	ConditionReasonSecretSyncStopped = "SecretSyncStopped"
+	// ConditionReasonStoreOffline indicates that the secret was kept because a store is unreachable beyond the offline threshold.
+	ConditionReasonStoreOffline = "StoreOffline"
+	// ConditionReasonPolicyDenied indicates that an ExternalSecretPolicy of the namespace does not allow the ExternalSecret.
+	ConditionReasonPolicyDenied = "PolicyDenied"
 
 	ReasonInvalidStoreRef      = "InvalidStoreRef"
 	ReasonUnavailableStore     = "UnavailableStore"
@@ -433,6 +670,11 @@ const (
 	ReasonDeprecated           = "ParameterDeprecated"
 	ReasonUpdated              = "Updated"
 	ReasonDeleted              = "Deleted"
+	ReasonReloaded             = "Reloaded"
+	ReasonReloadFailed         = "ReloadFailed"
+	ReasonKeysDropped          = "KeysDropped"
+	ReasonKeysUnresolved       = "KeysUnresolved"
+	ReasonLazySyncIgnored      = "LazySyncIgnored"
 )
 
 type ExternalSecretStatus struct {
@@ -444,13 +686,95 @@ type ExternalSecretStatus struct {
 	// SyncedResourceVersion keeps track of the last synced version
 	SyncedResourceVersion string `json:"syncedResourceVersion,omitempty"`
 
+	// ObservedGeneration is the generation of the ExternalSecret the
+	// conditions were computed for. A Ready condition of an older generation
+	// does not reflect the current spec yet.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// +optional
 	Conditions []ExternalSecretStatusCondition `json:"conditions,omitempty"`
 
+	// UnresolvedKeys lists the data entries that could not be read during
+	// the last sync with syncPolicy Partial.
+	// +optional
+	UnresolvedKeys []UnresolvedKey `json:"unresolvedKeys,omitempty"`
+
+	// FetchDetails describes the entries read during the last sync, without
+	// their values. It is only set while the ExternalSecret has the
+	// debug.external-secrets.io/fetch-details annotation.
+	// +optional
+	FetchDetails []FetchDetail `json:"fetchDetails,omitempty"`
+
+	// Store lists the stores the ExternalSecret reads from, as kind/name,
+	// separated by commas.
+	// +optional
+	Store string `json:"store,omitempty"`
+
+	// KeyCount is the number of keys of the target secret after the last
+	// successful sync. With creationPolicy Merge only the keys of the
+	// ExternalSecret are counted.
+	// +optional
+	KeyCount int32 `json:"keyCount,omitempty"`
+
+	// SecretParts is the number of additional Secrets written with oversize
+	// policy Split during the last successful sync.
+	// +optional
+	SecretParts int32 `json:"secretParts,omitempty"`
+
 	// Binding represents a servicebinding.io Provisioned Service reference to the secret
 	Binding corev1.LocalObjectReference `json:"binding,omitempty"`
 }
 
+// UnresolvedKey is a data entry that could not be read.
+type UnresolvedKey struct {
+	// Key is the secretKey of the data entry. It is empty for entries with propertyKeys.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// RemoteKey is the key of the remoteRef of the data entry.
+	RemoteKey string `json:"remoteKey"`
+
+	// Reason is the error that occurred reading the entry.
+	Reason string `json:"reason"`
+}
+
+// FetchDetail describes a data or dataFrom entry read during a sync.
+type FetchDetail struct {
+	// Source is the entry, e.g. data[0] or dataFrom[1].
+	Source string `json:"source"`
+
+	// RemoteKey is the key of the remote secret, the find criteria or the
+	// generator of the entry.
+	// +optional
+	RemoteKey string `json:"remoteKey,omitempty"`
+
+	// +optional
+	Property string `json:"property,omitempty"`
+
+	// Duration is the time it took to read the entry.
+	Duration metav1.Duration `json:"duration"`
+
+	// Keys are the keys of the secret data the entry resolved to.
+	// +optional
+	Keys []FetchedKey `json:"keys,omitempty"`
+
+	// Error is the error that occurred reading the entry.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// FetchedKey describes the value of a key read from the provider.
+type FetchedKey struct {
+	Key string `json:"key"`
+
+	// Size is the size of the value in bytes.
+	Size int `json:"size"`
+
+	// Hash is the first 16 hex digits of the sha256 sum of the value.
+	Hash string `json:"hash"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // ExternalSecret is the Schema for the external-secrets API.
@@ -460,6 +784,10 @@ type ExternalSecretStatus struct {
 // +kubebuilder:printcolumn:name="Refresh Interval",type=string,JSONPath=`.spec.refreshInterval`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Keys",type=integer,JSONPath=`.status.keyCount`
+// +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.refreshTime`
+// +kubebuilder:printcolumn:name="Stale",type=string,JSONPath=`.status.conditions[?(@.type=="Stale")].status`
+// +kubebuilder:printcolumn:name="Stores",type=string,JSONPath=`.status.store`,priority=1
 type ExternalSecret struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -471,9 +799,27 @@ type ExternalSecret struct {
 const (
 	// AnnotationDataHash is used to ensure consistency.
 	AnnotationDataHash = "reconcile.external-secrets.io/data-hash"
+	// AnnotationChecksum holds the sha256 sum of the data of a target Secret,
+	// it is set if the controller runs with --enable-checksum-annotations.
+	AnnotationChecksum = "checksum.external-secrets.io/sha256"
+	// AnnotationKeyChecksums holds the sha256 sums of the values of a target
+	// Secret as JSON object by key.
+	AnnotationKeyChecksums = "checksum.external-secrets.io/sha256-keys"
+	// AnnotationReloadPrefix is the prefix of the pod template annotations
+	// that hold the data hash of the Secrets a workload is reloaded for.
+	AnnotationReloadPrefix = "reload.external-secrets.io/"
+	// AnnotationFetchDetails set to "true" reports the keys, sizes, hashes
+	// and timing of the entries read during a sync in status.fetchDetails.
+	AnnotationFetchDetails = "debug.external-secrets.io/fetch-details"
 	// LabelOwner points to the owning ExternalSecret resource
 	//  and is used to manage the lifecycle of a Secret
 	LabelOwner = "reconcile.external-secrets.io/created-by"
+	// LabelNamespaceOptOut on a Namespace stops the sync of all ExternalSecrets
+	// in it. The value NamespaceOptOutDelete also deletes the Secrets they own.
+	LabelNamespaceOptOut = "external-secrets.io/opt-out"
+	// NamespaceOptOutDelete is the value of LabelNamespaceOptOut that deletes
+	// the owned Secrets of the namespace.
+	NamespaceOptOutDelete = "delete"
 )
 
 // +kubebuilder:object:root=true
@@ -18,25 +18,115 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-type ExternalSecretValidator struct{}
+// +kubebuilder:object:generate=false
+type ExternalSecretValidator struct {
+	// Reader looks up ClusterSecretStores and namespaces to enforce the
+	// store conditions. The conditions are not checked if Reader is nil.
+	Reader client.Reader
+}
 
-func (esv *ExternalSecretValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return validateExternalSecret(obj)
+func (esv *ExternalSecretValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return esv.validate(ctx, obj)
 }
 
-func (esv *ExternalSecretValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
-	return validateExternalSecret(newObj)
+func (esv *ExternalSecretValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return esv.validate(ctx, newObj)
 }
 
 func (esv *ExternalSecretValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
 	return nil, nil
 }
 
+func (esv *ExternalSecretValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := validateExternalSecret(obj)
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, esv.validateStoreConditions(ctx, obj.(*ExternalSecret))
+}
+
+// validateStoreConditions rejects ExternalSecrets that reference a
+// ClusterSecretStore whose conditions do not match the namespace of the
+// ExternalSecret. Stores that do not exist yet are checked at reconcile time.
+func (esv *ExternalSecretValidator) validateStoreConditions(ctx context.Context, es *ExternalSecret) error {
+	if esv.Reader == nil {
+		return nil
+	}
+	var namespace *corev1.Namespace
+	var errs error
+	for _, name := range clusterStoreNames(es) {
+		store := &ClusterSecretStore{}
+		err := esv.Reader.Get(ctx, types.NamespacedName{Name: name}, store)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not get ClusterSecretStore %q: %w", name, err)
+		}
+		if len(store.Spec.Conditions) == 0 {
+			continue
+		}
+		if namespace == nil {
+			namespace = &corev1.Namespace{}
+			if err := esv.Reader.Get(ctx, types.NamespacedName{Name: es.Namespace}, namespace); err != nil {
+				return fmt.Errorf("could not get namespace %q: %w", es.Namespace, err)
+			}
+		}
+		allowed, err := NamespaceAllowed(store.Spec.Conditions, namespace)
+		if err != nil {
+			return fmt.Errorf("invalid conditions of ClusterSecretStore %q: %w", name, err)
+		}
+		if !allowed {
+			errs = errors.Join(errs, fmt.Errorf("using ClusterSecretStore %q is not allowed from namespace %q: denied by spec.conditions", name, es.Namespace))
+		}
+	}
+	return errs
+}
+
+// clusterStoreNames returns the unique names of the ClusterSecretStores
+// referenced by es, including the stores of generator parameters.
+func clusterStoreNames(es *ExternalSecret) []string {
+	var names []string
+	add := func(ref *SecretStoreRef) {
+		if ref == nil || ref.Kind != ClusterSecretStoreKind || ref.Name == "" || slices.Contains(names, ref.Name) {
+			return
+		}
+		names = append(names, ref.Name)
+	}
+	add(&es.Spec.SecretStoreRef)
+	for i := range es.Spec.Data {
+		if es.Spec.Data[i].SourceRef != nil {
+			add(&es.Spec.Data[i].SourceRef.SecretStoreRef)
+		}
+	}
+	for i := range es.Spec.DataFrom {
+		sourceRef := es.Spec.DataFrom[i].SourceRef
+		if sourceRef == nil {
+			continue
+		}
+		add(sourceRef.SecretStoreRef)
+		if sourceRef.GeneratorRef != nil {
+			for j := range sourceRef.GeneratorRef.Parameters {
+				add(sourceRef.GeneratorRef.Parameters[j].StoreRef)
+			}
+		}
+	}
+	return names
+}
+
 func validateExternalSecret(obj runtime.Object) (admission.Warnings, error) {
 	es, ok := obj.(*ExternalSecret)
 	if !ok {
@@ -53,31 +143,126 @@ func validateExternalSecret(obj runtime.Object) (admission.Warnings, error) {
 		errs = errors.Join(errs, fmt.Errorf("deletionPolicy=Merge must not be used with creationPolicy=None. There is no Secret to merge with"))
 	}
 
+	if es.Spec.Target.Oversize != nil && es.Spec.Target.Oversize.Policy == OversizePolicySplit && es.Spec.Target.CreationPolicy != CreatePolicyOwner {
+		errs = errors.Join(errs, fmt.Errorf("oversize.policy=Split must only be used with creationPolicy=Owner"))
+	}
+
+	if es.Spec.Target.Lazy && es.Spec.Target.CreationPolicy != CreatePolicyOwner {
+		errs = errors.Join(errs, fmt.Errorf("target.lazy must only be used with creationPolicy=Owner"))
+	}
+
 	if len(es.Spec.Data) == 0 && len(es.Spec.DataFrom) == 0 {
 		errs = errors.Join(errs, fmt.Errorf("either data or dataFrom should be specified"))
 	}
 
-	for _, ref := range es.Spec.DataFrom {
+	for i, ref := range es.Spec.DataFrom {
 		findOrExtract := ref.Find != nil || ref.Extract != nil
 		if findOrExtract && ref.SourceRef != nil && ref.SourceRef.GeneratorRef != nil {
 			errs = errors.Join(errs, fmt.Errorf("generator can not be used with find or extract"))
 		}
+		if ref.SourceRef != nil && ref.SourceRef.GeneratorRef != nil {
+			errs = validateGeneratorParameters(es, i, ref.SourceRef.GeneratorRef.Parameters, errs)
+		}
 	}
 
+	errs = validateData(es, errs)
+	errs = validateRetrySettings(es.Spec.RetrySettings, errs)
+	errs = validateTemplateFrom(es, errs)
 	errs = validateDuplicateKeys(es, errs)
 	return nil, errs
 }
 
+func validateData(es *ExternalSecret, errs error) error {
+	for i, data := range es.Spec.Data {
+		if (data.SecretKey == "") == (len(data.PropertyKeys) == 0) {
+			errs = errors.Join(errs, fmt.Errorf("data[%d]: exactly one of secretKey or propertyKeys must be specified", i))
+		}
+		if len(data.PropertyKeys) > 0 && data.RemoteRef.Property != "" {
+			errs = errors.Join(errs, fmt.Errorf("data[%d]: remoteRef.property can not be used with propertyKeys", i))
+		}
+	}
+	return errs
+}
+
+func validateRetrySettings(rs *SecretStoreRetrySettings, errs error) error {
+	if rs == nil {
+		return errs
+	}
+	if rs.MaxRetries != nil && *rs.MaxRetries < 0 {
+		errs = errors.Join(errs, fmt.Errorf("retrySettings.maxRetries must not be negative"))
+	}
+	if rs.RetryInterval != nil {
+		if _, err := time.ParseDuration(*rs.RetryInterval); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("retrySettings.retryInterval: %w", err))
+		}
+	}
+	return errs
+}
+
+func validateGeneratorParameters(es *ExternalSecret, i int, params []GeneratorParameter, errs error) error {
+	for j, param := range params {
+		if param.Path == "" {
+			errs = errors.Join(errs, fmt.Errorf("dataFrom[%d].sourceRef.generatorRef.parameters[%d]: path must be specified", i, j))
+		}
+		if param.StoreRef == nil && es.Spec.SecretStoreRef.Name == "" {
+			errs = errors.Join(errs, fmt.Errorf("dataFrom[%d].sourceRef.generatorRef.parameters[%d]: storeRef must be specified without secretStoreRef", i, j))
+		}
+	}
+	return errs
+}
+
+func validateTemplateFrom(es *ExternalSecret, errs error) error {
+	if es.Spec.Target.Template == nil {
+		return errs
+	}
+	for i, tpl := range es.Spec.Target.Template.TemplateFrom {
+		sources := 0
+		if tpl.ConfigMap != nil {
+			sources++
+		}
+		if tpl.Secret != nil {
+			sources++
+		}
+		if tpl.Literal != nil {
+			sources++
+		}
+		if sources != 1 {
+			errs = errors.Join(errs, fmt.Errorf("templateFrom[%d]: exactly one of configMap, secret or literal must be specified", i))
+		}
+	}
+	return errs
+}
+
 func validateDuplicateKeys(es *ExternalSecret, errs error) error {
-	if es.Spec.Target.DeletionPolicy == DeletionPolicyRetain {
+	// an unset deletionPolicy defaults to Retain, unless a SecretSyncPolicy
+	// sets another one.
+	if es.Spec.Target.DeletionPolicy == DeletionPolicyRetain || es.Spec.Target.DeletionPolicy == "" {
 		seenKeys := make(map[string]struct{})
 		for _, data := range es.Spec.Data {
-			secretKey := data.SecretKey
-			if _, exists := seenKeys[secretKey]; exists {
-				errs = errors.Join(errs, fmt.Errorf("duplicate secretKey found: %s", secretKey))
+			for _, secretKey := range dataSecretKeys(data) {
+				// templated keys are only known once rendered
+				if strings.Contains(secretKey, "{{") {
+					continue
+				}
+				if _, exists := seenKeys[secretKey]; exists {
+					errs = errors.Join(errs, fmt.Errorf("duplicate secretKey found: %s", secretKey))
+				}
+				seenKeys[secretKey] = struct{}{}
 			}
-			seenKeys[secretKey] = struct{}{}
 		}
 	}
 	return errs
 }
+
+// dataSecretKeys returns the keys a data entry writes to the Secret.
+func dataSecretKeys(data ExternalSecretData) []string {
+	if len(data.PropertyKeys) == 0 {
+		return []string{data.SecretKey}
+	}
+	keys := make([]string, 0, len(data.PropertyKeys))
+	for _, key := range data.PropertyKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
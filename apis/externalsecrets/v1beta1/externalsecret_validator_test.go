@@ -15,9 +15,14 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestValidateExternalSecret(t *testing.T) {
@@ -40,7 +45,7 @@ func TestValidateExternalSecret(t *testing.T) {
 						CreationPolicy: CreatePolicyMerge,
 					},
 					Data: []ExternalSecretData{
-						{},
+						{SecretKey: "key"},
 					},
 				},
 			},
@@ -55,12 +60,27 @@ func TestValidateExternalSecret(t *testing.T) {
 						CreationPolicy: CreatePolicyNone,
 					},
 					Data: []ExternalSecretData{
-						{},
+						{SecretKey: "key"},
 					},
 				},
 			},
 			expectedErr: "deletionPolicy=Merge must not be used with creationPolicy=None. There is no Secret to merge with",
 		},
+		{
+			name: "oversize policy split",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						CreationPolicy: CreatePolicyMerge,
+						Oversize:       &ExternalSecretOversize{Policy: OversizePolicySplit},
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "key"},
+					},
+				},
+			},
+			expectedErr: "oversize.policy=Split must only be used with creationPolicy=Owner",
+		},
 		{
 			name: "both data and data_from are empty",
 			obj: &ExternalSecret{
@@ -100,6 +120,44 @@ func TestValidateExternalSecret(t *testing.T) {
 			},
 			expectedErr: "generator can not be used with find or extract",
 		},
+		{
+			name: "generator parameters without path and store",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							SourceRef: &StoreGeneratorSourceRef{
+								GeneratorRef: &GeneratorRef{
+									Parameters: []GeneratorParameter{
+										{RemoteRef: ExternalSecretDataRemoteRef{Key: "app/issuer"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "dataFrom[0].sourceRef.generatorRef.parameters[0]: path must be specified\ndataFrom[0].sourceRef.generatorRef.parameters[0]: storeRef must be specified without secretStoreRef",
+		},
+		{
+			name: "generator parameters",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					SecretStoreRef: SecretStoreRef{Name: "chef"},
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							SourceRef: &StoreGeneratorSourceRef{
+								GeneratorRef: &GeneratorRef{
+									Parameters: []GeneratorParameter{
+										{Path: "length", RemoteRef: ExternalSecretDataRemoteRef{Key: "app/policy", Property: "length"}, ValueType: GeneratorParameterValueTypeJSON},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "multiple errors",
 			obj: &ExternalSecret{
@@ -123,6 +181,63 @@ either data or dataFrom should be specified`,
 				},
 			},
 		},
+		{
+			name: "templateFrom literal",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Template: &ExternalSecretTemplate{
+							TemplateFrom: []TemplateFrom{
+								{Literal: ptr.To("{{ .key }}: {{ .value }}")},
+							},
+						},
+					},
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{},
+					},
+				},
+			},
+		},
+		{
+			name: "templateFrom without source",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Template: &ExternalSecretTemplate{
+							TemplateFrom: []TemplateFrom{
+								{Target: TemplateTargetData},
+							},
+						},
+					},
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{},
+					},
+				},
+			},
+			expectedErr: "templateFrom[0]: exactly one of configMap, secret or literal must be specified",
+		},
+		{
+			name: "templateFrom with multiple sources",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Template: &ExternalSecretTemplate{
+							TemplateFrom: []TemplateFrom{
+								{Literal: ptr.To("foo: bar")},
+								{
+									ConfigMap: &TemplateRef{Name: "tpl"},
+									Literal:   ptr.To("foo: bar"),
+								},
+							},
+						},
+					},
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{},
+					},
+				},
+			},
+			expectedErr: "templateFrom[1]: exactly one of configMap, secret or literal must be specified",
+		},
 		{
 			name: "duplicate secretKeys",
 			obj: &ExternalSecret{
@@ -156,6 +271,109 @@ either data or dataFrom should be specified`,
 			},
 			expectedErr: "duplicate secretKey found: SERVICE_NAME",
 		},
+		{
+			name: "templated secretKeys",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						DeletionPolicy: DeletionPolicyRetain,
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "{{ .remoteRef.property | upper }}", RemoteRef: ExternalSecretDataRemoteRef{Key: "app/db", Property: "user"}},
+						{SecretKey: "{{ .remoteRef.property | upper }}", RemoteRef: ExternalSecretDataRemoteRef{Key: "app/db", Property: "password"}},
+					},
+				},
+			},
+		},
+		{
+			name: "propertyKeys",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{
+							PropertyKeys: map[string]string{"some_password": "DB_PASSWORD", "some_user": "DB_USER"},
+							RemoteRef:    ExternalSecretDataRemoteRef{Key: "databag/item"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid propertyKeys",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{},
+						{
+							SecretKey:    "DB_PASSWORD",
+							PropertyKeys: map[string]string{"some_password": "DB_PASSWORD"},
+						},
+						{
+							PropertyKeys: map[string]string{"some_password": "DB_PASSWORD"},
+							RemoteRef:    ExternalSecretDataRemoteRef{Key: "databag/item", Property: "db"},
+						},
+					},
+				},
+			},
+			expectedErr: `data[0]: exactly one of secretKey or propertyKeys must be specified
+data[1]: exactly one of secretKey or propertyKeys must be specified
+data[2]: remoteRef.property can not be used with propertyKeys
+duplicate secretKey found: DB_PASSWORD`,
+		},
+		{
+			name: "duplicate propertyKeys",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						DeletionPolicy: DeletionPolicyRetain,
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "DB_PASSWORD"},
+						{PropertyKeys: map[string]string{"some_password": "DB_PASSWORD", "some_user": "DB_USER"}},
+					},
+				},
+			},
+			expectedErr: "duplicate secretKey found: DB_PASSWORD",
+		},
+		{
+			name: "lazy without owner",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						CreationPolicy: CreatePolicyMerge,
+						Lazy:           true,
+					},
+					Data: []ExternalSecretData{{SecretKey: "key"}},
+				},
+			},
+			expectedErr: "target.lazy must only be used with creationPolicy=Owner",
+		},
+		{
+			name: "invalid retrySettings",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					RetrySettings: &SecretStoreRetrySettings{
+						MaxRetries:    ptr.To[int32](-1),
+						RetryInterval: ptr.To("often"),
+					},
+					Data: []ExternalSecretData{{SecretKey: "key"}},
+				},
+			},
+			expectedErr: `retrySettings.maxRetries must not be negative
+retrySettings.retryInterval: time: invalid duration "often"`,
+		},
+		{
+			name: "valid retrySettings",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					RetrySettings: &SecretStoreRetrySettings{
+						MaxRetries:    ptr.To[int32](10),
+						RetryInterval: ptr.To("100ms"),
+					},
+					Data: []ExternalSecretData{{SecretKey: "key"}},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -176,3 +394,132 @@ either data or dataFrom should be specified`,
 		})
 	}
 }
+
+func TestNamespaceAllowed(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}}
+	tests := []struct {
+		name        string
+		conditions  []ClusterSecretStoreCondition
+		allowed     bool
+		expectedErr bool
+	}{
+		{name: "no conditions", allowed: true},
+		{name: "namespace name", conditions: []ClusterSecretStoreCondition{{Namespaces: []string{"team-b", "team-a"}}}, allowed: true},
+		{name: "other namespace name", conditions: []ClusterSecretStoreCondition{{Namespaces: []string{"team-b"}}}},
+		{name: "matching selector", conditions: []ClusterSecretStoreCondition{
+			{Namespaces: []string{"team-b"}},
+			{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+		}, allowed: true},
+		{name: "other selector", conditions: []ClusterSecretStoreCondition{
+			{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}},
+		}},
+		{name: "invalid selector", conditions: []ClusterSecretStoreCondition{
+			{NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Invalid"}}}},
+		}, expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := NamespaceAllowed(tt.conditions, ns)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("NamespaceAllowed() returned an unexpected error: %v", err)
+			}
+			if allowed != tt.allowed {
+				t.Errorf("NamespaceAllowed() = %v, expected %v", allowed, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestValidateStoreConditions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		&ClusterSecretStore{ObjectMeta: metav1.ObjectMeta{Name: "shared"}},
+		&ClusterSecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "chef-team-a"},
+			Spec: SecretStoreSpec{Conditions: []ClusterSecretStoreCondition{
+				{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+			}},
+		},
+		&ClusterSecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "chef-team-b"},
+			Spec:       SecretStoreSpec{Conditions: []ClusterSecretStoreCondition{{Namespaces: []string{"team-b"}}}},
+		},
+	).Build()
+	clusterStore := func(name string) SecretStoreRef {
+		return SecretStoreRef{Name: name, Kind: ClusterSecretStoreKind}
+	}
+	tests := []struct {
+		name        string
+		spec        ExternalSecretSpec
+		expectedErr string
+	}{
+		{
+			name: "store without conditions",
+			spec: ExternalSecretSpec{SecretStoreRef: clusterStore("shared")},
+		},
+		{
+			name: "matching conditions",
+			spec: ExternalSecretSpec{SecretStoreRef: clusterStore("chef-team-a")},
+		},
+		{
+			name: "missing store",
+			spec: ExternalSecretSpec{SecretStoreRef: clusterStore("missing")},
+		},
+		{
+			name: "namespaced store",
+			spec: ExternalSecretSpec{SecretStoreRef: SecretStoreRef{Name: "chef-team-b", Kind: SecretStoreKind}},
+		},
+		{
+			name:        "denied store",
+			spec:        ExternalSecretSpec{SecretStoreRef: clusterStore("chef-team-b")},
+			expectedErr: `using ClusterSecretStore "chef-team-b" is not allowed from namespace "team-a": denied by spec.conditions`,
+		},
+		{
+			name: "denied store in data and dataFrom",
+			spec: ExternalSecretSpec{
+				SecretStoreRef: clusterStore("shared"),
+				Data:           []ExternalSecretData{{SourceRef: &StoreSourceRef{SecretStoreRef: clusterStore("chef-team-b")}}},
+				DataFrom:       []ExternalSecretDataFromRemoteRef{{SourceRef: &StoreGeneratorSourceRef{SecretStoreRef: ptr.To(clusterStore("chef-team-b"))}}},
+			},
+			expectedErr: `using ClusterSecretStore "chef-team-b" is not allowed from namespace "team-a": denied by spec.conditions`,
+		},
+		{
+			name: "denied store in generator parameters",
+			spec: ExternalSecretSpec{
+				SecretStoreRef: clusterStore("shared"),
+				DataFrom: []ExternalSecretDataFromRemoteRef{{SourceRef: &StoreGeneratorSourceRef{GeneratorRef: &GeneratorRef{
+					Kind: "Password",
+					Name: "password",
+					Parameters: []GeneratorParameter{
+						{Path: "length", RemoteRef: ExternalSecretDataRemoteRef{Key: "length"}},
+						{Path: "symbols", RemoteRef: ExternalSecretDataRemoteRef{Key: "symbols"}, StoreRef: ptr.To(clusterStore("chef-team-b"))},
+					},
+				}}}},
+			},
+			expectedErr: `using ClusterSecretStore "chef-team-b" is not allowed from namespace "team-a": denied by spec.conditions`,
+		},
+	}
+	esv := &ExternalSecretValidator{Reader: reader}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "team-a"}, Spec: tt.spec}
+			err := esv.validateStoreConditions(context.Background(), es)
+			if err != nil {
+				if err.Error() != tt.expectedErr {
+					t.Fatalf("validateStoreConditions() returned an unexpected error: got: %v, expected: %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if tt.expectedErr != "" {
+				t.Errorf("validateStoreConditions() should have returned an error but got nil")
+			}
+		})
+	}
+}
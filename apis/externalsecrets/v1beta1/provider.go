@@ -93,6 +93,54 @@ type SecretsClient interface {
 	Close(ctx context.Context) error
 }
 
+// PushSecretUpdatePolicy controls how a PushSecret updates a secret
+// that already exists in the provider.
+// +kubebuilder:validation:Enum=Replace;IfNotExists;Merge
+type PushSecretUpdatePolicy string
+
+const (
+	// PushSecretUpdatePolicyReplace overwrites the remote value with the pushed value.
+	PushSecretUpdatePolicyReplace PushSecretUpdatePolicy = "Replace"
+	// PushSecretUpdatePolicyIfNotExists only writes the value if it does not exist yet.
+	PushSecretUpdatePolicyIfNotExists PushSecretUpdatePolicy = "IfNotExists"
+	// PushSecretUpdatePolicyMerge merges the pushed value into the existing remote value.
+	PushSecretUpdatePolicyMerge PushSecretUpdatePolicy = "Merge"
+)
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// UpdatePolicyClient is implemented by SecretsClients that support
+// update policies other than Replace.
+type UpdatePolicyClient interface {
+	// PushSecretWithPolicy writes a single secret into the provider
+	// honoring the given update policy.
+	PushSecretWithPolicy(ctx context.Context, secret *corev1.Secret, data PushSecretData, policy PushSecretUpdatePolicy) error
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// OnlineValidator is implemented by Providers whose stores can opt in to
+// online validation. The admission webhook then creates a client and
+// validates it before the store is accepted.
+type OnlineValidator interface {
+	// OnlineValidationEnabled returns true if the store opted in.
+	OnlineValidationEnabled(store GenericStore) bool
+}
+
+// PushDenier is implemented by Providers whose stores can deny writes.
+// PushSecrets that target a denying store are rejected before anything
+// is pushed.
+type PushDenier interface {
+	// PushDenied returns true if the store must not be written to.
+	PushDenied(store GenericStore) bool
+}
+
 var NoSecretErr = NoSecretError{}
 
 // NoSecretError shall be returned when a GetSecret can not find the
@@ -102,3 +150,20 @@ type NoSecretError struct{}
 func (NoSecretError) Error() string {
 	return "Secret does not exist"
 }
+
+// +kubebuilder:object:generate:false
+
+// ReasonError can be returned by a SecretsClient to set a specific reason
+// on the Ready condition of the ExternalSecret instead of the generic one.
+type ReasonError struct {
+	Reason string
+	Err    error
+}
+
+func (e *ReasonError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ReasonError) Unwrap() error {
+	return e.Err
+}
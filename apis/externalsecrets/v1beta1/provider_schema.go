@@ -17,14 +17,20 @@ package v1beta1
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 )
 
 var builder map[string]Provider
 var buildlock sync.RWMutex
 
+// disabled holds the providers removed by EnableProviders.
+var disabled map[string]bool
+
 func init() {
 	builder = make(map[string]Provider)
+	disabled = make(map[string]bool)
 }
 
 // Register a store backend type. Register panics if a
@@ -58,6 +64,49 @@ func ForceRegister(s Provider, storeSpec *SecretStoreProvider) {
 	buildlock.Unlock()
 }
 
+// EnableProviders unregisters all providers except the named ones, e.g.
+// chef and kubernetes. Stores of other providers fail with an error
+// that the provider is disabled. All providers stay registered if names is
+// empty. It returns an error if a name is not a registered provider.
+func EnableProviders(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	buildlock.Lock()
+	defer buildlock.Unlock()
+	enabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if _, ok := builder[name]; !ok {
+			return fmt.Errorf("unknown provider %q, registered providers are %s", name, strings.Join(registeredProviders(), ","))
+		}
+		enabled[name] = true
+	}
+	for name := range builder {
+		if !enabled[name] {
+			disabled[name] = true
+			delete(builder, name)
+		}
+	}
+	return nil
+}
+
+// RegisteredProviders returns the sorted names of the registered providers.
+func RegisteredProviders() []string {
+	buildlock.RLock()
+	defer buildlock.RUnlock()
+	return registeredProviders()
+}
+
+func registeredProviders() []string {
+	names := make([]string, 0, len(builder))
+	for name := range builder {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // GetProviderByName returns the provider implementation by name.
 func GetProviderByName(name string) (Provider, bool) {
 	buildlock.RLock()
@@ -82,8 +131,12 @@ func GetProvider(s GenericStore) (Provider, error) {
 
 	buildlock.RLock()
 	f, ok := builder[storeName]
+	isDisabled := disabled[storeName]
 	buildlock.RUnlock()
 
+	if !ok && isDisabled {
+		return nil, fmt.Errorf("store backend %s is disabled in this controller, name: %s", storeName, s.GetName())
+	}
 	if !ok {
 		return nil, fmt.Errorf("failed to find registered store backend for type: %s, name: %s", storeName, s.GetName())
 	}
@@ -91,6 +144,16 @@ func GetProvider(s GenericStore) (Provider, error) {
 	return f, nil
 }
 
+// GetProviderName returns the name of the provider configured in the store,
+// e.g. `chef` or `vault`.
+func GetProviderName(s GenericStore) (string, error) {
+	spec := s.GetSpec()
+	if spec == nil {
+		return "", fmt.Errorf("no spec found in %#v", s)
+	}
+	return getProviderName(spec.Provider)
+}
+
 // getProviderName returns the name of the configured provider
 // or an error if the provider is not configured.
 func getProviderName(storeSpec *SecretStoreProvider) (string, error) {
@@ -199,3 +199,33 @@ func TestRegisterGCP(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, testProvider, p2)
 }
+
+func TestEnableProviders(t *testing.T) {
+	buildlock.Lock()
+	saved, savedDisabled := builder, disabled
+	builder, disabled = make(map[string]Provider), make(map[string]bool)
+	buildlock.Unlock()
+	t.Cleanup(func() {
+		buildlock.Lock()
+		builder, disabled = saved, savedDisabled
+		buildlock.Unlock()
+	})
+
+	chefStore := &SecretStore{Spec: SecretStoreSpec{Provider: &SecretStoreProvider{Chef: &ChefProvider{}}}}
+	vaultStore := &SecretStore{Spec: SecretStoreSpec{Provider: &SecretStoreProvider{Vault: &VaultProvider{}}}}
+	Register(&PP{}, chefStore.Spec.Provider)
+	Register(&PP{}, vaultStore.Spec.Provider)
+
+	assert.NoError(t, EnableProviders(nil))
+	assert.Equal(t, []string{"chef", "vault"}, RegisteredProviders())
+
+	assert.EqualError(t, EnableProviders([]string{"chef", "kubernetes"}), `unknown provider "kubernetes", registered providers are chef,vault`)
+	assert.Equal(t, []string{"chef", "vault"}, RegisteredProviders())
+
+	assert.NoError(t, EnableProviders([]string{" chef"}))
+	assert.Equal(t, []string{"chef"}, RegisteredProviders())
+	_, err := GetProvider(chefStore)
+	assert.NoError(t, err)
+	_, err = GetProvider(vaultStore)
+	assert.EqualError(t, err, "store backend vault is disabled in this controller, name: ")
+}
@@ -13,26 +13,145 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
+
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 // ChefAuth contains a secretRef for credentials.
 type ChefAuth struct {
+	// Type selects how requests to the chef server are authenticated.
+	// Key signs every request with the private key of the user. AutomateToken
+	// sends a Chef Automate API token in the api-token header, for chef
+	// servers that are only reachable through Chef Automate. KnifeConfig
+	// signs requests with the user, server url and key of a knife config.
+	// +optional
+	// +kubebuilder:default="Key"
+	Type      ChefAuthType      `json:"type,omitempty"`
 	SecretRef ChefAuthSecretRef `json:"secretRef"`
 }
 
+// ChefAuthType is the authentication method of a chef store.
+// +kubebuilder:validation:Enum=Key;AutomateToken;KnifeConfig
+type ChefAuthType string
+
+const (
+	ChefAuthTypeKey           ChefAuthType = "Key"
+	ChefAuthTypeAutomateToken ChefAuthType = "AutomateToken"
+	ChefAuthTypeKnifeConfig   ChefAuthType = "KnifeConfig"
+)
+
 // ChefAuthSecretRef holds secret references for chef server login credentials.
 type ChefAuthSecretRef struct {
 	// SecretKey is the Signing Key in PEM format, used for authentication.
-	SecretKey esmeta.SecretKeySelector `json:"privateKeySecretRef"`
+	// Required for the auth type Key.
+	// +optional
+	SecretKey esmeta.SecretKeySelector `json:"privateKeySecretRef,omitempty"`
+	// Token is the Chef Automate API token.
+	// Required for the auth type AutomateToken.
+	// +optional
+	Token *esmeta.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+	// KnifeConfig references a knife.rb or a credentials file. The user,
+	// server url and private key are read from client_name, chef_server_url
+	// and client_key. A client_key path is resolved to the key of the same
+	// Secret named like the file.
+	// Required for the auth type KnifeConfig.
+	// +optional
+	KnifeConfig *esmeta.SecretKeySelector `json:"knifeConfigSecretRef,omitempty"`
 }
 
 // ChefProvider configures a store to sync secrets using basic chef server connection credentials.
 type ChefProvider struct {
 	// Auth defines the information necessary to authenticate against chef Server
 	Auth *ChefAuth `json:"auth"`
-	// UserName should be the user ID on the chef server
-	UserName string `json:"username"`
-	// ServerURL is the chef server URL used to connect to. If using orgs you should include your org in the url and terminate the url with a "/"
-	ServerURL string `json:"serverUrl"`
+	// UserName should be the user ID on the chef server.
+	// Required unless the auth type is KnifeConfig.
+	// +optional
+	UserName string `json:"username,omitempty"`
+	// ServerURL is the chef server URL used to connect to. If using orgs you should include your org in the url and terminate the url with a "/".
+	// Required unless the auth type is KnifeConfig.
+	// +optional
+	ServerURL string `json:"serverUrl,omitempty"`
+	// PEM encoded CA bundle used to validate the certificate of the chef server.
+	// The system trust store is used if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// SHA-256 hashes of the SubjectPublicKeyInfo of certificates to pin, in the
+	// form sha256/<base64>. If set, the connection to the chef server is only accepted if
+	// the server certificate or a certificate of its verified chain matches
+	// one of the pins. The pins are checked in addition to the CA validation.
+	// +optional
+	CertificatePins []string `json:"certificatePins,omitempty"`
+	// ItemSchemas are checked against every data bag item that is fetched,
+	// before its data is returned. An item that violates a schema fails the
+	// sync with the ChefItemSchemaViolation reason.
+	// +optional
+	ItemSchemas []ChefItemSchema `json:"itemSchemas,omitempty"`
+	// Organizations other than the one of the serverUrl that ExternalSecrets
+	// may read from, by prefixing the remote key with organizations/<name>/.
+	// The store credentials are used for all organizations. Shell patterns
+	// like team_* are supported. If empty, keys are not checked for a prefix.
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+	// OnlineValidation makes the admission webhook authenticate against the
+	// chef server before the store is accepted, so that wrong urls and revoked
+	// keys are rejected right away. Requires online store validation to be
+	// enabled in the webhook.
+	// +optional
+	OnlineValidation bool `json:"onlineValidation,omitempty"`
+	// ReadOnlyStrict makes the store read only. PushSecrets that target the
+	// store are rejected with the Denied reason, nothing is written to or
+	// deleted from the chef server.
+	// +optional
+	ReadOnlyStrict bool `json:"readOnlyStrict,omitempty"`
+	// Name is the v1alpha1 name of username, used if username is not set.
+	// Deprecated: use username instead.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// BaseURL is the v1alpha1 name of serverUrl, used if serverUrl is not set.
+	// Deprecated: use serverUrl instead.
+	// +optional
+	BaseURL string `json:"baseUrl,omitempty"`
+}
+
+// UnmarshalJSON decodes the provider and fills username and serverUrl from
+// their deprecated v1alpha1 names, so that older manifests keep working.
+func (c *ChefProvider) UnmarshalJSON(data []byte) error {
+	type chefProvider ChefProvider
+	if err := json.Unmarshal(data, (*chefProvider)(c)); err != nil {
+		return err
+	}
+	if c.UserName == "" {
+		c.UserName = c.Name
+	}
+	if c.ServerURL == "" {
+		c.ServerURL = c.BaseURL
+	}
+	return nil
 }
+
+// ChefItemSchema describes the expected structure of the items of data bags.
+type ChefItemSchema struct {
+	// Databags are the names of the data bags the schema applies to.
+	// Shell patterns like app_* are supported.
+	Databags []string `json:"databags"`
+	// Required are the paths of properties that must exist, e.g. db.password.
+	// +optional
+	Required []string `json:"required,omitempty"`
+	// Properties maps the paths of properties to their expected type.
+	// Properties that do not exist are not checked, list them in required.
+	// +optional
+	Properties map[string]ChefItemPropertyType `json:"properties,omitempty"`
+}
+
+// ChefItemPropertyType is the JSON type of a property of a data bag item.
+// +kubebuilder:validation:Enum=string;number;boolean;object;array
+type ChefItemPropertyType string
+
+const (
+	ChefItemPropertyString  ChefItemPropertyType = "string"
+	ChefItemPropertyNumber  ChefItemPropertyType = "number"
+	ChefItemPropertyBoolean ChefItemPropertyType = "boolean"
+	ChefItemPropertyObject  ChefItemPropertyType = "object"
+	ChefItemPropertyArray   ChefItemPropertyType = "array"
+)
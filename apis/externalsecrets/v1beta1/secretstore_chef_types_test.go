@@ -0,0 +1,56 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChefProviderLegacyFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		wantUserName  string
+		wantServerURL string
+	}{
+		{
+			name:          "legacy fields",
+			spec:          `{"name":"legacy-user","baseUrl":"https://chef.example.com/organizations/org/"}`,
+			wantUserName:  "legacy-user",
+			wantServerURL: "https://chef.example.com/organizations/org/",
+		},
+		{
+			name:          "current fields take precedence",
+			spec:          `{"username":"user","name":"legacy-user","serverUrl":"https://chef.example.com/","baseUrl":"https://legacy.example.com/"}`,
+			wantUserName:  "user",
+			wantServerURL: "https://chef.example.com/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var provider SecretStoreProvider
+			if err := json.Unmarshal([]byte(`{"chef":`+tt.spec+`}`), &provider); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if provider.Chef.UserName != tt.wantUserName {
+				t.Errorf("UserName = %q, expected %q", provider.Chef.UserName, tt.wantUserName)
+			}
+			if provider.Chef.ServerURL != tt.wantServerURL {
+				t.Errorf("ServerURL = %q, expected %q", provider.Chef.ServerURL, tt.wantServerURL)
+			}
+		})
+	}
+}
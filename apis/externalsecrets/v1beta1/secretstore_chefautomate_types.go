@@ -0,0 +1,43 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// ChefAutomateProvider configures a store to sync node attributes and
+// compliance profile metadata from the Chef Automate API.
+type ChefAutomateProvider struct {
+	// URL of the Chef Automate server, e.g. https://automate.example.com
+	URL string `json:"url"`
+	// Auth configures how the operator authenticates with Chef Automate.
+	Auth ChefAutomateAuth `json:"auth"`
+	// PEM encoded CA bundle used to validate the certificate of Chef Automate.
+	// The system trust store is used if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// SHA-256 hashes of the SubjectPublicKeyInfo of certificates to pin, in the
+	// form sha256/<base64>. If set, the connection to Chef Automate is only accepted if
+	// the server certificate or a certificate of its verified chain matches
+	// one of the pins. The pins are checked in addition to the CA validation.
+	// +optional
+	CertificatePins []string `json:"certificatePins,omitempty"`
+}
+
+// ChefAutomateAuth contains the reference to an Automate API token.
+type ChefAutomateAuth struct {
+	// TokenSecretRef references the Automate API token. The token needs
+	// the infra:nodes:list, infra:nodes:get and compliance:profiles:list actions.
+	TokenSecretRef esmeta.SecretKeySelector `json:"tokenSecretRef"`
+}
@@ -0,0 +1,53 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// CloudantProvider configures a store to sync secrets from IBM Cloudant or
+// Apache CouchDB documents.
+type CloudantProvider struct {
+	// URL of the Cloudant or CouchDB instance, e.g. https://<account>.cloudantnosqldb.appdomain.cloud
+	URL string `json:"url"`
+	// Auth defines the information necessary to authenticate against Cloudant or CouchDB.
+	Auth CloudantAuth `json:"auth"`
+}
+
+// CloudantAuth configures the authentication, exactly one method must be set.
+type CloudantAuth struct {
+	// IAM authenticates with an IBM Cloud IAM API key.
+	// +optional
+	IAM *CloudantIAMAuth `json:"iam,omitempty"`
+	// Basic authenticates with a username and password, e.g. against CouchDB.
+	// +optional
+	Basic *CloudantBasicAuth `json:"basic,omitempty"`
+}
+
+// CloudantIAMAuth authenticates with an IBM Cloud IAM API key.
+type CloudantIAMAuth struct {
+	// APIKey references the IBM Cloud IAM API key.
+	APIKey esmeta.SecretKeySelector `json:"apiKeySecretRef"`
+	// URL of the IBM Cloud IAM token service, defaults to https://iam.cloud.ibm.com
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// CloudantBasicAuth authenticates with a username and password.
+type CloudantBasicAuth struct {
+	// Username references the username of the user.
+	Username esmeta.SecretKeySelector `json:"usernameSecretRef"`
+	// Password references the password of the user.
+	Password esmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
@@ -0,0 +1,26 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// COSProvider configures a store to sync objects from IBM Cloud Object Storage.
+type COSProvider struct {
+	// Auth configures how the operator authenticates with IBM Cloud IAM.
+	Auth IBMAuth `json:"auth"`
+	// Region of the buckets, e.g. us-south. It selects the public regional endpoint.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// ServiceURL overrides the endpoint derived from the region,
+	// e.g. https://s3.direct.us-south.cloud-object-storage.appdomain.cloud to use the direct endpoint.
+	// +optional
+	ServiceURL string `json:"serviceUrl,omitempty"`
+}
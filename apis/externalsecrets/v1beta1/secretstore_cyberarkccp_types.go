@@ -0,0 +1,53 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// CyberArkCCPProvider configures a store to sync accounts from the CyberArk
+// Central Credential Provider (CCP).
+type CyberArkCCPProvider struct {
+	// URL of the Central Credential Provider, e.g. https://ccp.example.com
+	URL string `json:"url"`
+	// ServicePath is the path of the CCP web service.
+	// +kubebuilder:default=AIMWebService
+	// +optional
+	ServicePath string `json:"servicePath,omitempty"`
+	// AppID is the application the operator requests accounts as.
+	AppID string `json:"appID"`
+	// Safe is used for keys that do not name a safe.
+	// +optional
+	Safe string `json:"safe,omitempty"`
+	// Reason is sent with every request and recorded in the audit log of the vault.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Auth configures the client certificate the application authenticates with.
+	// Without auth the application must be authenticated by other means,
+	// e.g. allowed machines.
+	// +optional
+	Auth *CyberArkCCPAuth `json:"auth,omitempty"`
+	// PEM encoded CA bundle used to validate the certificate of the CCP.
+	// The system trust store is used if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// CyberArkCCPAuth authenticates the application with a client certificate.
+type CyberArkCCPAuth struct {
+	// ClientCertSecretRef references the PEM encoded client certificate.
+	ClientCertSecretRef esmeta.SecretKeySelector `json:"clientCertSecretRef"`
+	// ClientKeySecretRef references the PEM encoded private key of the client certificate.
+	ClientKeySecretRef esmeta.SecretKeySelector `json:"clientKeySecretRef"`
+}
@@ -0,0 +1,223 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// endpointFields are the json names of the provider fields that hold the
+// URL or host of a server the provider connects to. TestEndpointFields
+// fails for new provider fields that look like an endpoint and are not
+// listed here. Endpoints built from other fields, e.g. the urlTemplate of
+// Delinea, are added by StoreEndpoints.
+var endpointFields = map[string]bool{
+	"akeylessGWApiURL": true,
+	"apiEndpoint":      true,
+	"apiUrl":           true,
+	"baseUrl":          true,
+	"connectHost":      true,
+	"host":             true,
+	"iamEndpoint":      true,
+	"server":           true,
+	"serverUrl":        true,
+	"serviceUrl":       true,
+	"tokenURL":         true,
+	"url":              true,
+	"vaultUrl":         true,
+}
+
+var (
+	allowedEndpoints     []string
+	allowedEndpointsLock sync.RWMutex
+
+	errTemplatedHost = errors.New("the host of a templated endpoint can not be checked against the allowed endpoints")
+)
+
+// SetAllowedProviderEndpoints restricts the endpoints SecretStores and
+// ClusterSecretStores may connect to. A pattern is a host with an optional
+// port, e.g. vault.example.com:8200. A leading *. matches any subdomain, a
+// pattern without port matches any port. All endpoints are allowed if
+// patterns is empty.
+func SetAllowedProviderEndpoints(patterns []string) error {
+	normalized := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		host, _, err := splitEndpoint(pattern)
+		if err != nil || strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			return fmt.Errorf("invalid allowed provider endpoint %q: must be a host with an optional port and leading *.", pattern)
+		}
+		normalized = append(normalized, pattern)
+	}
+	allowedEndpointsLock.Lock()
+	defer allowedEndpointsLock.Unlock()
+	allowedEndpoints = normalized
+	return nil
+}
+
+// ValidateStoreEndpoints returns an error if the provider of the store
+// connects to an endpoint that is not allowed by SetAllowedProviderEndpoints.
+func ValidateStoreEndpoints(store GenericStore) error {
+	if store == nil || store.GetSpec() == nil {
+		return nil
+	}
+	for _, endpoint := range StoreEndpoints(store) {
+		if err := ValidateEndpoint(endpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateEndpoint returns an error if the endpoint is not allowed by
+// SetAllowedProviderEndpoints. Providers use it for endpoints that are not
+// part of the store spec, e.g. read from a secret, and can not be checked
+// at admission.
+func ValidateEndpoint(endpoint string) error {
+	allowedEndpointsLock.RLock()
+	patterns := allowedEndpoints
+	allowedEndpointsLock.RUnlock()
+	if len(patterns) == 0 {
+		return nil
+	}
+	ok, err := endpointAllowed(patterns, endpoint)
+	if err != nil {
+		return fmt.Errorf("endpoint %q: %w", endpoint, err)
+	}
+	if !ok {
+		return fmt.Errorf("endpoint %q is not allowed, it must match one of the allowed provider endpoints %v", endpoint, patterns)
+	}
+	return nil
+}
+
+// StoreEndpoints returns the URLs and hosts configured in the provider of the store.
+func StoreEndpoints(store GenericStore) []string {
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil {
+		return nil
+	}
+	var endpoints []string
+	collectEndpoints(reflect.ValueOf(spec.Provider), &endpoints)
+	if spec.Provider.Delinea != nil {
+		endpoints = append(endpoints, delineaEndpoint(spec.Provider.Delinea))
+	}
+	return endpoints
+}
+
+// delineaEndpoint returns the URL the Delinea provider connects to, built
+// like the dsv-sdk-go does from the urlTemplate, the tenant and the tld.
+func delineaEndpoint(p *DelineaProvider) string {
+	urlTemplate, tld := p.URLTemplate, p.TLD
+	if urlTemplate == "" {
+		urlTemplate = "https://%s.secretsvaultcloud.%s/v1/%s%s"
+	}
+	if tld == "" {
+		tld = "com"
+	}
+	return fmt.Sprintf(urlTemplate, p.Tenant, tld, "", "")
+}
+
+func collectEndpoints(v reflect.Value, endpoints *[]string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectEndpoints(v.Elem(), endpoints)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			collectEndpoints(v.Index(i), endpoints)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := v.Field(i)
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if endpointFields[name] {
+				if field.Kind() == reflect.Pointer && !field.IsNil() {
+					field = field.Elem()
+				}
+				if field.Kind() == reflect.String {
+					if s := field.String(); s != "" {
+						*endpoints = append(*endpoints, s)
+					}
+					continue
+				}
+			}
+			collectEndpoints(field, endpoints)
+		}
+	}
+}
+
+func endpointAllowed(patterns []string, endpoint string) (bool, error) {
+	host, port, err := splitEndpoint(strings.ToLower(endpoint))
+	if err != nil {
+		return false, err
+	}
+	if strings.Contains(host, "{{") || strings.Contains(port, "{{") {
+		return false, errTemplatedHost
+	}
+	for _, pattern := range patterns {
+		patternHost, patternPort, _ := splitEndpoint(pattern)
+		if patternPort != "" && patternPort != port {
+			continue
+		}
+		if suffix, ok := strings.CutPrefix(patternHost, "*"); ok {
+			if strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return true, nil
+			}
+			continue
+		}
+		if host == patternHost {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// splitEndpoint returns the host and the port of an URL or a host with an
+// optional port. It also accepts scp-like git URLs, e.g. git@example.com:org/repo.
+func splitEndpoint(endpoint string) (string, string, error) {
+	rest := endpoint
+	if _, after, ok := strings.Cut(rest, "://"); ok {
+		rest = after
+	}
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		rest = rest[:i]
+	}
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		rest = rest[i+1:]
+	}
+	host, port := rest, ""
+	if h, p, err := net.SplitHostPort(rest); err == nil {
+		host, port = h, p
+	} else if h, p, ok := strings.Cut(rest, ":"); ok && !strings.HasPrefix(rest, "[") {
+		// scp-like git URLs separate the path with a colon
+		host, port = h, p
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); port != "" && err != nil {
+		port = ""
+	}
+	host = strings.Trim(host, "[]")
+	if host == "" {
+		return "", "", fmt.Errorf("no host in endpoint %q", endpoint)
+	}
+	return host, port, nil
+}
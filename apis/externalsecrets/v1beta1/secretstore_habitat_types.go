@@ -0,0 +1,40 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// HabitatProvider configures a store to sync origin secrets of Chef Habitat Builder.
+type HabitatProvider struct {
+	// URL of Habitat Builder.
+	// +kubebuilder:default="https://bldr.habitat.sh"
+	// +optional
+	URL string `json:"url,omitempty"`
+	// Origin whose secrets are synced.
+	Origin string `json:"origin"`
+	// Auth configures how the operator authenticates with Builder and decrypts origin secrets.
+	Auth HabitatAuth `json:"auth"`
+}
+
+// HabitatAuth contains the secret references used to access origin secrets.
+type HabitatAuth struct {
+	// TokenSecretRef references a Builder personal access token of a member of the origin.
+	TokenSecretRef esmeta.SecretKeySelector `json:"tokenSecretRef"`
+	// EncryptionKeySecretRef references the private encryption key of the origin
+	// (BOX-SEC-1). Builder never hands out this key, without it the store can
+	// only push secrets.
+	// +optional
+	EncryptionKeySecretRef *esmeta.SecretKeySelector `json:"encryptionKeySecretRef,omitempty"`
+}
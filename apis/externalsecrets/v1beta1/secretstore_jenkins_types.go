@@ -0,0 +1,48 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// JenkinsProvider configures a store to sync credentials from Jenkins.
+type JenkinsProvider struct {
+	// URL of Jenkins, e.g. https://jenkins.example.com
+	URL string `json:"url"`
+	// Folder is the full name of a folder, e.g. team-a/builds. Credentials are
+	// looked up like a job in the folder does: in the folder, its parent
+	// folders and the system scope. Only the system scope is used if not set.
+	// +optional
+	Folder string `json:"folder,omitempty"`
+	// Auth configures how the operator authenticates with Jenkins.
+	Auth JenkinsAuth `json:"auth"`
+	// PEM encoded CA bundle used to validate the certificate of Jenkins.
+	// The system trust store is used if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// SHA-256 hashes of the SubjectPublicKeyInfo of certificates to pin, in the
+	// form sha256/<base64>. If set, the connection to Jenkins is only accepted if
+	// the server certificate or a certificate of its verified chain matches
+	// one of the pins. The pins are checked in addition to the CA validation.
+	// +optional
+	CertificatePins []string `json:"certificatePins,omitempty"`
+}
+
+// JenkinsAuth authenticates with an API token of a Jenkins user.
+type JenkinsAuth struct {
+	// Username is the id of the user that owns the API token.
+	Username string `json:"username"`
+	// APITokenSecretRef references the API token of the user.
+	APITokenSecretRef esmeta.SecretKeySelector `json:"apiTokenSecretRef"`
+}
@@ -0,0 +1,73 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// KeycloakProvider configures a store to sync client secrets from Keycloak.
+type KeycloakProvider struct {
+	// URL of Keycloak, e.g. https://keycloak.example.com. Include the context
+	// path if Keycloak is not served at the root, e.g. https://keycloak.example.com/auth
+	URL string `json:"url"`
+	// Realm holding the clients.
+	Realm string `json:"realm"`
+	// Auth configures how the operator authenticates with the admin REST API.
+	Auth KeycloakAuth `json:"auth"`
+	// PEM encoded CA bundle used to validate the certificate of Keycloak.
+	// The system trust store is used if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// SHA-256 hashes of the SubjectPublicKeyInfo of certificates to pin, in the
+	// form sha256/<base64>. If set, the connection to Keycloak is only accepted if
+	// the server certificate or a certificate of its verified chain matches
+	// one of the pins. The pins are checked in addition to the CA validation.
+	// +optional
+	CertificatePins []string `json:"certificatePins,omitempty"`
+}
+
+// KeycloakAuth configures the credentials used to request an access token.
+// Exactly one of clientCredentials or password must be set.
+type KeycloakAuth struct {
+	// Realm the credentials belong to, e.g. master.
+	// The realm of the store is used if not set.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+	// ClientCredentials authenticates with a client that has a service account.
+	// +optional
+	ClientCredentials *KeycloakClientCredentials `json:"clientCredentials,omitempty"`
+	// Password authenticates as a user.
+	// +optional
+	Password *KeycloakPasswordAuth `json:"password,omitempty"`
+}
+
+// KeycloakClientCredentials holds the credentials of a client with a service account.
+type KeycloakClientCredentials struct {
+	// ClientID of the client.
+	ClientID string `json:"clientID"`
+	// ClientSecretRef references the secret of the client.
+	ClientSecretRef esmeta.SecretKeySelector `json:"clientSecretRef"`
+}
+
+// KeycloakPasswordAuth holds the credentials of a user.
+type KeycloakPasswordAuth struct {
+	// ClientID of the client the token is requested for.
+	// +kubebuilder:default=admin-cli
+	// +optional
+	ClientID string `json:"clientID,omitempty"`
+	// Username of the user.
+	Username string `json:"username"`
+	// PasswordSecretRef references the password of the user.
+	PasswordSecretRef esmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
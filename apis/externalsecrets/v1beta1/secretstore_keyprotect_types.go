@@ -0,0 +1,29 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// KeyProtectProvider configures a store to sync the payload of IBM Key
+// Protect standard keys.
+type KeyProtectProvider struct {
+	// Auth configures how the operator authenticates with IBM Cloud IAM.
+	Auth IBMAuth `json:"auth"`
+	// Region of the Key Protect instance, e.g. us-south.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// InstanceID is the GUID of the Key Protect instance.
+	InstanceID string `json:"instanceId"`
+	// ServiceURL overrides the endpoint derived from the region,
+	// e.g. https://private.us-south.kms.cloud.ibm.com to use the private endpoint.
+	// +optional
+	ServiceURL string `json:"serviceUrl,omitempty"`
+}
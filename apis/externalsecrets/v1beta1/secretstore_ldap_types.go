@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// LDAPProvider configures a store to sync attributes of entries from an
+// LDAP directory, e.g. Active Directory.
+type LDAPProvider struct {
+	// URL of the directory, e.g. ldaps://ldap.example.com or ldap://ldap.example.com:389
+	URL string `json:"url"`
+	// StartTLS upgrades ldap:// connections to TLS before binding.
+	// +optional
+	StartTLS bool `json:"startTLS,omitempty"`
+	// BaseDN restricts the entries that can be read to this subtree,
+	// e.g. ou=service-accounts,dc=example,dc=com.
+	// All entries the bind DN can read are allowed if not set.
+	// +optional
+	BaseDN string `json:"baseDN,omitempty"`
+	// Auth configures the credentials used to bind to the directory.
+	Auth LDAPAuth `json:"auth"`
+	// PEM encoded CA bundle used to validate the certificate of the directory.
+	// The system trust store is used if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// LDAPAuth holds the credentials of a simple bind.
+type LDAPAuth struct {
+	// BindDN is the DN to bind as, e.g. cn=external-secrets,ou=service-accounts,dc=example,dc=com.
+	// Active Directory also accepts a user principal name, e.g. external-secrets@example.com.
+	BindDN string `json:"bindDN"`
+	// BindPasswordSecretRef references the password of the bind DN.
+	BindPasswordSecretRef esmeta.SecretKeySelector `json:"bindPasswordSecretRef"`
+}
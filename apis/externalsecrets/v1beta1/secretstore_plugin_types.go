@@ -0,0 +1,45 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// PluginProvider configures a store to sync secrets through an out-of-tree
+// provider plugin that is registered with a ProviderPlugin resource.
+type PluginProvider struct {
+	// Name of the ProviderPlugin that serves the store.
+	Name string `json:"name"`
+
+	// Config is passed to the plugin with every call, its schema is defined by the plugin.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +optional
+	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+
+	// Secrets are read by the controller and passed to the plugin with every
+	// call under the given name, so plugins never need access to the Kubernetes API.
+	// +optional
+	Secrets []PluginSecret `json:"secrets,omitempty"`
+}
+
+type PluginSecret struct {
+	// Name of the secret in the calls to the plugin.
+	Name string `json:"name"`
+
+	// SecretRef references the key of a Kubernetes Secret that is passed to the plugin.
+	SecretRef esmeta.SecretKeySelector `json:"secretRef"`
+}
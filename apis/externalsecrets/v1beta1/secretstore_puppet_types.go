@@ -0,0 +1,82 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// PuppetProvider configures a store to sync hiera data, including values
+// encrypted with hiera-eyaml, from a git repository or an HTTP endpoint.
+// Exactly one of git or http must be set.
+type PuppetProvider struct {
+	// Git reads the hiera data from a git repository, e.g. a control repository.
+	// +optional
+	Git *PuppetGitSource `json:"git,omitempty"`
+	// HTTP reads the hiera data files from an HTTP endpoint.
+	// +optional
+	HTTP *PuppetHTTPSource `json:"http,omitempty"`
+	// EYAML configures the PKCS7 keypair used to decrypt hiera-eyaml values.
+	// +optional
+	EYAML *PuppetEYAML `json:"eyaml,omitempty"`
+}
+
+// PuppetGitSource is a git repository holding hiera data.
+type PuppetGitSource struct {
+	// URL of the repository, e.g. https://git.example.com/puppet/control-repo.git
+	URL string `json:"url"`
+	// Ref is the branch or tag to read, e.g. production or refs/tags/v1.0.0.
+	// The default branch of the repository is used if not set.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+	// DataDir is the hiera datadir inside the repository.
+	// +kubebuilder:default="data"
+	// +optional
+	DataDir string `json:"dataDir,omitempty"`
+	// Auth configures the credentials for repositories that require authentication.
+	// +optional
+	Auth *PuppetBasicAuth `json:"auth,omitempty"`
+}
+
+// PuppetHTTPSource is an HTTP endpoint serving the files of a hiera datadir.
+type PuppetHTTPSource struct {
+	// URL of the datadir, the path of the file is appended to it,
+	// e.g. https://puppet.example.com/hieradata
+	URL string `json:"url"`
+	// Auth configures the credentials for endpoints that require authentication.
+	// +optional
+	Auth *PuppetHTTPAuth `json:"auth,omitempty"`
+}
+
+// PuppetHTTPAuth configures either basic or bearer token authentication.
+type PuppetHTTPAuth struct {
+	// +optional
+	Basic *PuppetBasicAuth `json:"basic,omitempty"`
+	// TokenSecretRef references a token sent as bearer token.
+	// +optional
+	TokenSecretRef *esmeta.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// PuppetBasicAuth holds the references to a username and password, or access token.
+type PuppetBasicAuth struct {
+	UsernameSecretRef esmeta.SecretKeySelector `json:"usernameSecretRef"`
+	PasswordSecretRef esmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// PuppetEYAML holds the references to the PKCS7 keypair of hiera-eyaml.
+type PuppetEYAML struct {
+	// PublicKeySecretRef references the PEM encoded certificate, public_key.pkcs7.pem.
+	PublicKeySecretRef esmeta.SecretKeySelector `json:"publicKeySecretRef"`
+	// PrivateKeySecretRef references the PEM encoded private key, private_key.pkcs7.pem.
+	PrivateKeySecretRef esmeta.SecretKeySelector `json:"privateKeySecretRef"`
+}
@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// SaltProvider configures a store to sync pillar data of minions through salt-api.
+type SaltProvider struct {
+	// URL of salt-api (rest_cherrypy), e.g. https://salt.example.com:8000
+	URL string `json:"url"`
+	// Auth configures how the operator authenticates with salt-api.
+	Auth SaltAuth `json:"auth"`
+	// PEM encoded CA bundle used to validate the certificate of salt-api.
+	// The system trust store is used if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// SHA-256 hashes of the SubjectPublicKeyInfo of certificates to pin, in the
+	// form sha256/<base64>. If set, the connection to salt-api is only accepted if
+	// the server certificate or a certificate of its verified chain matches
+	// one of the pins. The pins are checked in addition to the CA validation.
+	// +optional
+	CertificatePins []string `json:"certificatePins,omitempty"`
+}
+
+// SaltAuth configures the external authentication of salt-api.
+type SaltAuth struct {
+	// Eauth is the external authentication system, e.g. pam, ldap or sharedsecret.
+	// +kubebuilder:default="pam"
+	// +optional
+	Eauth string `json:"eauth,omitempty"`
+	// UsernameSecretRef references the username.
+	UsernameSecretRef esmeta.SecretKeySelector `json:"usernameSecretRef"`
+	// PasswordSecretRef references the password, or the shared secret.
+	PasswordSecretRef esmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
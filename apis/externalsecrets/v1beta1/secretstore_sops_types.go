@@ -0,0 +1,83 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// SOPSProvider configures a store to sync values of SOPS encrypted files
+// from a git repository.
+type SOPSProvider struct {
+	// Git is the repository holding the encrypted files.
+	Git SOPSGitSource `json:"git"`
+	// Keys used to decrypt the data key of the files. A file can be decrypted
+	// if one of its keys is configured here.
+	Keys SOPSKeys `json:"keys"`
+}
+
+// SOPSGitSource is a git repository holding SOPS encrypted files.
+type SOPSGitSource struct {
+	// URL of the repository, e.g. https://git.example.com/platform/secrets.git
+	URL string `json:"url"`
+	// Ref is the branch or tag to read, e.g. main or refs/tags/v1.0.0.
+	// The default branch of the repository is used if not set.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+	// Path is the directory inside the repository the keys are relative to.
+	// The root of the repository is used if not set.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Auth configures the credentials for repositories that require authentication.
+	// +optional
+	Auth *SOPSGitAuth `json:"auth,omitempty"`
+}
+
+// SOPSGitAuth holds the references to a username and password, or access token.
+type SOPSGitAuth struct {
+	UsernameSecretRef esmeta.SecretKeySelector `json:"usernameSecretRef"`
+	PasswordSecretRef esmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// SOPSKeys holds the references to the keys used to decrypt SOPS files.
+// At least one key must be configured.
+type SOPSKeys struct {
+	// AgeSecretRef references one or more age identities,
+	// in the format of an age key file.
+	// +optional
+	AgeSecretRef *esmeta.SecretKeySelector `json:"ageSecretRef,omitempty"`
+	// PGP references an armored PGP private key.
+	// +optional
+	PGP *SOPSPGPKey `json:"pgp,omitempty"`
+	// KMS configures the AWS credentials used to decrypt with AWS KMS keys.
+	// +optional
+	KMS *SOPSKMSAuth `json:"kms,omitempty"`
+}
+
+// SOPSPGPKey holds the references to an armored PGP private key.
+type SOPSPGPKey struct {
+	// PrivateKeySecretRef references the armored private key.
+	PrivateKeySecretRef esmeta.SecretKeySelector `json:"privateKeySecretRef"`
+	// PassphraseSecretRef references the passphrase of the private key.
+	// +optional
+	PassphraseSecretRef *esmeta.SecretKeySelector `json:"passphraseSecretRef,omitempty"`
+}
+
+// SOPSKMSAuth holds the references to static AWS credentials.
+// If a file sets a role for the KMS key, the role is assumed with these credentials.
+type SOPSKMSAuth struct {
+	AccessKeyIDSecretRef     esmeta.SecretKeySelector `json:"accessKeyIDSecretRef"`
+	SecretAccessKeySecretRef esmeta.SecretKeySelector `json:"secretAccessKeySecretRef"`
+	// +optional
+	SessionTokenSecretRef *esmeta.SecretKeySelector `json:"sessionTokenSecretRef,omitempty"`
+}
@@ -0,0 +1,76 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// SQLDriver is the kind of database.
+// +kubebuilder:validation:Enum=postgres;mysql
+type SQLDriver string
+
+const (
+	SQLDriverPostgres SQLDriver = "postgres"
+	SQLDriverMySQL    SQLDriver = "mysql"
+)
+
+// SQLTLSMode configures whether and how the connection is encrypted.
+// +kubebuilder:validation:Enum=disable;require;verify-full
+type SQLTLSMode string
+
+const (
+	// SQLTLSModeDisable does not encrypt the connection.
+	SQLTLSModeDisable SQLTLSMode = "disable"
+	// SQLTLSModeRequire encrypts the connection without verifying the certificate of the server.
+	SQLTLSModeRequire SQLTLSMode = "require"
+	// SQLTLSModeVerifyFull encrypts the connection and verifies the certificate and host name of the server.
+	SQLTLSModeVerifyFull SQLTLSMode = "verify-full"
+)
+
+// SQLProvider configures a store to sync values returned by a query
+// against a PostgreSQL or MySQL database.
+type SQLProvider struct {
+	// Driver is the kind of database, postgres or mysql.
+	Driver SQLDriver `json:"driver"`
+	// Host of the database server.
+	Host string `json:"host"`
+	// Port of the database server, 5432 for postgres and 3306 for mysql if not set.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// Database to connect to.
+	Database string `json:"database"`
+	// Auth configures the credentials of the database user.
+	Auth SQLAuth `json:"auth"`
+	// TLSMode configures whether and how the connection is encrypted.
+	// +kubebuilder:default=verify-full
+	// +optional
+	TLSMode SQLTLSMode `json:"tlsMode,omitempty"`
+	// PEM encoded CA bundle used to validate the certificate of the server.
+	// The system trust store is used if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// Query is run in a read-only transaction for every key, with the key as
+	// its only parameter, e.g. SELECT username, password FROM credentials WHERE app = $1
+	// for postgres or ... WHERE app = ? for mysql. It must return at most one
+	// row, its columns are the properties of the secret.
+	Query string `json:"query"`
+}
+
+// SQLAuth holds the credentials of a database user.
+type SQLAuth struct {
+	// Username of the database user.
+	Username string `json:"username"`
+	// PasswordSecretRef references the password of the database user.
+	PasswordSecretRef esmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
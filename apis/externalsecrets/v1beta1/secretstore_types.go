@@ -40,6 +40,25 @@ type SecretStoreSpec struct {
 	// Used to constraint a ClusterSecretStore to specific namespaces. Relevant only to ClusterSecretStore
 	// +optional
 	Conditions []ClusterSecretStoreCondition `json:"conditions,omitempty"`
+
+	// HealthCheck configures periodic deep health checks of the store, in
+	// addition to the validation of the provider configuration.
+	// +optional
+	HealthCheck *SecretStoreHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// SecretStoreHealthCheck configures the health checks of a store. Every check
+// authenticates against the provider, validates the store and reads the
+// canary, the results are reported in status.healthCheck.
+type SecretStoreHealthCheck struct {
+	// Interval between two health checks. Defaults to 5m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// CanaryRef is a remote secret, e.g. a databag item, that is read on
+	// every check. Only authentication and validation are checked if not set.
+	// +optional
+	CanaryRef *ExternalSecretDataRemoteRef `json:"canaryRef,omitempty"`
 }
 
 // ClusterSecretStoreCondition describes a condition by which to choose namespaces to process ExternalSecrets in
@@ -145,6 +164,66 @@ type SecretStoreProvider struct {
 	// Chef configures this store to sync secrets with chef server
 	// +optional
 	Chef *ChefProvider `json:"chef,omitempty"`
+
+	// ChefAutomate configures this store to sync node attributes and compliance profile metadata from Chef Automate
+	// +optional
+	ChefAutomate *ChefAutomateProvider `json:"chefAutomate,omitempty"`
+
+	// Habitat configures this store to sync origin secrets of Chef Habitat Builder
+	// +optional
+	Habitat *HabitatProvider `json:"habitat,omitempty"`
+
+	// Puppet configures this store to sync hiera data, including hiera-eyaml encrypted values
+	// +optional
+	Puppet *PuppetProvider `json:"puppet,omitempty"`
+
+	// Salt configures this store to sync pillar data of SaltStack minions
+	// +optional
+	Salt *SaltProvider `json:"salt,omitempty"`
+
+	// Jenkins configures this store to sync credentials from Jenkins
+	// +optional
+	Jenkins *JenkinsProvider `json:"jenkins,omitempty"`
+
+	// SOPS configures this store to sync values of SOPS encrypted files from a git repository
+	// +optional
+	SOPS *SOPSProvider `json:"sops,omitempty"`
+
+	// CyberArkCCP configures this store to sync accounts from the CyberArk Central Credential Provider
+	// +optional
+	CyberArkCCP *CyberArkCCPProvider `json:"cyberarkccp,omitempty"`
+
+	// Keycloak configures this store to sync client secrets from Keycloak
+	// +optional
+	Keycloak *KeycloakProvider `json:"keycloak,omitempty"`
+
+	// SQL configures this store to sync values returned by a query against a PostgreSQL or MySQL database
+	// +optional
+	SQL *SQLProvider `json:"sql,omitempty"`
+
+	// LDAP configures this store to sync attributes of entries from an LDAP directory
+	// +optional
+	LDAP *LDAPProvider `json:"ldap,omitempty"`
+
+	// Plugin configures this store to sync secrets through an out-of-tree provider plugin
+	// +optional
+	Plugin *PluginProvider `json:"plugin,omitempty"`
+
+	// Cloudant configures this store to sync secrets from IBM Cloudant or Apache CouchDB documents
+	// +optional
+	Cloudant *CloudantProvider `json:"cloudant,omitempty"`
+
+	// KeyProtect configures this store to sync the payload of IBM Key Protect standard keys
+	// +optional
+	KeyProtect *KeyProtectProvider `json:"keyprotect,omitempty"`
+
+	// HPCS configures this store to sync the payload of standard keys from IBM Hyper Protect Crypto Services
+	// +optional
+	HPCS *HPCSProvider `json:"hpcs,omitempty"`
+
+	// COS configures this store to sync objects from IBM Cloud Object Storage
+	// +optional
+	COS *COSProvider `json:"cos,omitempty"`
 }
 
 type CAProviderType string
@@ -184,6 +263,8 @@ type SecretStoreConditionType string
 
 const (
 	SecretStoreReady SecretStoreConditionType = "Ready"
+	// SecretStoreDeprecated is set if the store uses deprecated fields.
+	SecretStoreDeprecated SecretStoreConditionType = "Deprecated"
 
 	ReasonInvalidStore          = "InvalidStoreConfiguration"
 	ReasonInvalidProviderConfig = "InvalidProviderConfig"
@@ -220,6 +301,37 @@ type SecretStoreStatus struct {
 	Conditions []SecretStoreStatusCondition `json:"conditions,omitempty"`
 	// +optional
 	Capabilities SecretStoreCapabilities `json:"capabilities,omitempty"`
+	// ObservedGeneration is the generation of the store that was last validated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Provider is the name of the provider the store is configured for,
+	// e.g. chef or kubernetes.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// HealthCheck is the result of the last health check of the store.
+	// +optional
+	HealthCheck *SecretStoreHealthCheckStatus `json:"healthCheck,omitempty"`
+}
+
+// SecretStoreHealthCheckStatus is the result of the last health check.
+type SecretStoreHealthCheckStatus struct {
+	// Healthy is true if all steps of the last check succeeded.
+	Healthy bool `json:"healthy"`
+	// Message describes the step of the last check that failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastCheckTime is the time of the last check.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// Latency is the duration of the last check.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
+	// LatencyP95 is the 95th percentile of the durations of the recent checks.
+	// +optional
+	LatencyP95 *metav1.Duration `json:"latencyP95,omitempty"`
+	// ConsecutiveFailures is the number of checks that failed in a row.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -230,6 +342,8 @@ type SecretStoreStatus struct {
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:printcolumn:name="Capabilities",type=string,JSONPath=`.status.capabilities`
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.status.provider`
+// +kubebuilder:printcolumn:name="Last Transition",type=date,JSONPath=`.status.conditions[?(@.type=="Ready")].lastTransitionTime`
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,categories={externalsecrets},shortName=ss
 type SecretStore struct {
@@ -257,6 +371,8 @@ type SecretStoreList struct {
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:printcolumn:name="Capabilities",type=string,JSONPath=`.status.capabilities`
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.status.provider`
+// +kubebuilder:printcolumn:name="Last Transition",type=date,JSONPath=`.status.conditions[?(@.type=="Ready")].lastTransitionTime`
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={externalsecrets},shortName=css
 type ClusterSecretStore struct {
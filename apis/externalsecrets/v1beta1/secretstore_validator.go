@@ -17,35 +17,46 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var _ admission.CustomValidator = &GenericStoreValidator{}
 
 const (
-	errInvalidStore = "invalid store"
+	errInvalidStore             = "invalid store"
+	errOnlineValidation         = "online validation failed: %w"
+	errOnlineValidationDisabled = "online validation is not enabled in the webhook"
 )
 
-type GenericStoreValidator struct{}
+// onlineValidationTimeout is below the timeout of the webhook configuration.
+var onlineValidationTimeout = 3 * time.Second
+
+type GenericStoreValidator struct {
+	// Client reads the credentials of stores that opt in to online
+	// validation. Online validation is disabled if nil.
+	Client client.Client
+}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (r *GenericStoreValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (r *GenericStoreValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	st, ok := obj.(GenericStore)
 	if !ok {
 		return nil, fmt.Errorf(errInvalidStore)
 	}
-	return validateStore(st)
+	return r.validateStore(ctx, st)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (r *GenericStoreValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+func (r *GenericStoreValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
 	st, ok := newObj.(GenericStore)
 	if !ok {
 		return nil, fmt.Errorf(errInvalidStore)
 	}
-	return validateStore(st)
+	return r.validateStore(ctx, st)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -53,10 +64,53 @@ func (r *GenericStoreValidator) ValidateDelete(_ context.Context, _ runtime.Obje
 	return nil, nil
 }
 
-func validateStore(store GenericStore) (admission.Warnings, error) {
+func (r *GenericStoreValidator) validateStore(ctx context.Context, store GenericStore) (admission.Warnings, error) {
 	provider, err := GetProvider(store)
 	if err != nil {
 		return nil, err
 	}
-	return provider.ValidateStore(store)
+	if err := ValidateStoreEndpoints(store); err != nil {
+		return nil, err
+	}
+	warnings, err := provider.ValidateStore(store)
+	if err != nil {
+		return warnings, err
+	}
+	if v, ok := provider.(OnlineValidator); ok && v.OnlineValidationEnabled(store) {
+		if r.Client == nil {
+			return warnings, fmt.Errorf(errOnlineValidationDisabled)
+		}
+		if err := validateOnline(ctx, provider, store, r.Client); err != nil {
+			return warnings, fmt.Errorf(errOnlineValidation, err)
+		}
+	}
+	return warnings, nil
+}
+
+// validateOnline creates a client for the store and validates it. Clients
+// do not all honor the context, so the validation is abandoned on timeout.
+func validateOnline(ctx context.Context, provider Provider, store GenericStore, kube client.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, onlineValidationTimeout)
+	defer cancel()
+	result := make(chan error, 1)
+	go func() {
+		cl, err := provider.NewClient(ctx, store, kube, store.GetNamespace())
+		if err != nil {
+			result <- err
+			return
+		}
+		defer cl.Close(context.Background())
+		validationResult, err := cl.Validate()
+		if err != nil && validationResult != ValidationResultUnknown {
+			result <- err
+			return
+		}
+		result <- nil
+	}()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-result:
+		return err
+	}
 }
@@ -0,0 +1,102 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// onlineProvider is a provider whose stores always opt in to online validation.
+type onlineProvider struct {
+	PP
+	validate func() (ValidationResult, error)
+}
+
+func (p *onlineProvider) NewClient(_ context.Context, _ GenericStore, _ client.Client, _ string) (SecretsClient, error) {
+	return p, nil
+}
+
+func (p *onlineProvider) Validate() (ValidationResult, error) {
+	return p.validate()
+}
+
+func (p *onlineProvider) OnlineValidationEnabled(_ GenericStore) bool {
+	return true
+}
+
+func TestValidateStoreOnline(t *testing.T) {
+	defer func(timeout time.Duration) { onlineValidationTimeout = timeout }(onlineValidationTimeout)
+	onlineValidationTimeout = 100 * time.Millisecond
+
+	tests := []struct {
+		name     string
+		validate func() (ValidationResult, error)
+		kube     client.Client
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			validate: func() (ValidationResult, error) { return ValidationResultReady, nil },
+			kube:     fake.NewFakeClient(),
+		},
+		{
+			name: "unknown",
+			validate: func() (ValidationResult, error) {
+				return ValidationResultUnknown, errors.New("no permission to validate")
+			},
+			kube: fake.NewFakeClient(),
+		},
+		{
+			name:     "invalid",
+			validate: func() (ValidationResult, error) { return ValidationResultError, errors.New("key revoked") },
+			kube:     fake.NewFakeClient(),
+			wantErr:  "online validation failed: key revoked",
+		},
+		{
+			name: "timeout",
+			validate: func() (ValidationResult, error) {
+				time.Sleep(time.Second)
+				return ValidationResultReady, nil
+			},
+			kube:    fake.NewFakeClient(),
+			wantErr: "online validation failed: context deadline exceeded",
+		},
+		{
+			name:     "disabled in webhook",
+			validate: func() (ValidationResult, error) { return ValidationResultReady, nil },
+			wantErr:  errOnlineValidationDisabled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ForceRegister(&onlineProvider{validate: tt.validate}, &SecretStoreProvider{Fake: &FakeProvider{}})
+			store := &SecretStore{Spec: SecretStoreSpec{Provider: &SecretStoreProvider{Fake: &FakeProvider{}}}}
+			validator := &GenericStoreValidator{Client: tt.kube}
+			_, err := validator.ValidateCreate(context.Background(), store)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -16,18 +16,23 @@ package v1beta1
 
 import (
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (c *SecretStore) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupWebhookWithManager registers the validating webhook. If kube is
+// not nil, stores that opt in are validated online.
+func (c *SecretStore) SetupWebhookWithManager(mgr ctrl.Manager, kube client.Client) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(c).
-		WithValidator(&GenericStoreValidator{}).
+		WithValidator(&GenericStoreValidator{Client: kube}).
 		Complete()
 }
 
-func (c *ClusterSecretStore) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupWebhookWithManager registers the validating webhook. If kube is
+// not nil, stores that opt in are validated online.
+func (c *ClusterSecretStore) SetupWebhookWithManager(mgr ctrl.Manager, kube client.Client) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(c).
-		WithValidator(&GenericStoreValidator{}).
+		WithValidator(&GenericStoreValidator{Client: kube}).
 		Complete()
 }
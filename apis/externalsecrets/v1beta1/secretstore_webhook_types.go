@@ -56,9 +56,25 @@ type WebhookProvider struct {
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
 
+	// SHA-256 hashes of the SubjectPublicKeyInfo of certificates to pin, in the
+	// form sha256/<base64>. If set, the connection to the webhook is only accepted if
+	// the server certificate or a certificate of its verified chain matches
+	// one of the pins. The pins are checked in addition to the CA validation.
+	// +optional
+	CertificatePins []string `json:"certificatePins,omitempty"`
+
 	// The provider for the CA bundle to use to validate webhook server certificate.
 	// +optional
 	CAProvider *WebhookCAProvider `json:"caProvider,omitempty"`
+
+	// Auth authenticates the requests to the webhook, in addition to the headers.
+	// +optional
+	Auth *WebhookAuth `json:"auth,omitempty"`
+
+	// Pagination follows paged responses and merges all pages before
+	// the result is extracted.
+	// +optional
+	Pagination *WebhookPagination `json:"pagination,omitempty"`
 }
 
 type WebhookCAProviderType string
@@ -99,3 +115,94 @@ type WebhookSecret struct {
 	// Secret ref to fill in credentials
 	SecretRef esmeta.SecretKeySelector `json:"secretRef"`
 }
+
+// WebhookAuth configures the authentication of webhook requests.
+// Exactly one method must be set.
+type WebhookAuth struct {
+	// OAuth2 requests an access token with the client credentials grant
+	// and sends it as bearer token.
+	// +optional
+	OAuth2 *WebhookOAuth2 `json:"oauth2,omitempty"`
+
+	// HMAC signs every request with a shared secret.
+	// +optional
+	HMAC *WebhookHMAC `json:"hmac,omitempty"`
+
+	// JWT sends a token read from a secret as bearer token.
+	// +optional
+	JWT *WebhookJWT `json:"jwt,omitempty"`
+}
+
+type WebhookOAuth2 struct {
+	// URL of the token endpoint of the authorization server.
+	TokenURL string `json:"tokenURL"`
+
+	// ClientID of the OAuth2 client.
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef references the secret of the OAuth2 client.
+	ClientSecretRef esmeta.SecretKeySelector `json:"clientSecretRef"`
+
+	// Scopes to request.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Audience to request, sent as audience parameter of the token request.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+}
+
+type WebhookHMACAlgorithm string
+
+const (
+	WebhookHMACSHA256 WebhookHMACAlgorithm = "SHA256"
+	WebhookHMACSHA512 WebhookHMACAlgorithm = "SHA512"
+)
+
+// WebhookHMAC signs requests with a HMAC of the timestamp, method, request URI
+// and body of the request, separated by newlines.
+type WebhookHMAC struct {
+	// SecretRef references the shared secret.
+	SecretRef esmeta.SecretKeySelector `json:"secretRef"`
+
+	// Algorithm of the HMAC.
+	// +kubebuilder:default=SHA256
+	// +kubebuilder:validation:Enum=SHA256;SHA512
+	// +optional
+	Algorithm WebhookHMACAlgorithm `json:"algorithm,omitempty"`
+
+	// Header of the hex encoded signature.
+	// +kubebuilder:default=X-Signature
+	// +optional
+	SignatureHeader string `json:"signatureHeader,omitempty"`
+
+	// Header of the signing time in unix seconds.
+	// +kubebuilder:default=X-Timestamp
+	// +optional
+	TimestampHeader string `json:"timestampHeader,omitempty"`
+}
+
+type WebhookJWT struct {
+	// TokenSecretRef references the token.
+	TokenSecretRef esmeta.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+// WebhookPagination follows paged responses. The items of all pages are
+// merged into one JSON document, arrays are concatenated and objects merged.
+type WebhookPagination struct {
+	// Json path of the items of a page, e.g. $.data. The whole page is used if not set.
+	// +optional
+	ItemsJSONPath string `json:"itemsJsonPath,omitempty"`
+
+	// Json path of the URL of the next page, e.g. $.links.next. Relative URLs
+	// are resolved against the URL of the page. The Link header with
+	// rel="next" is followed if not set.
+	// +optional
+	NextJSONPath string `json:"nextJsonPath,omitempty"`
+
+	// MaxPages limits the number of pages, more pages are an error.
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPages int `json:"maxPages,omitempty"`
+}
@@ -20,6 +20,8 @@ package v1beta1
 
 import (
 	metav1 "github.com/external-secrets/external-secrets/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -401,6 +403,22 @@ func (in *CAProvider) DeepCopy() *CAProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *COSProvider) DeepCopyInto(out *COSProvider) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new COSProvider.
+func (in *COSProvider) DeepCopy() *COSProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(COSProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertAuth) DeepCopyInto(out *CertAuth) {
 	*out = *in
@@ -438,6 +456,16 @@ func (in *ChefAuth) DeepCopy() *ChefAuth {
 func (in *ChefAuthSecretRef) DeepCopyInto(out *ChefAuthSecretRef) {
 	*out = *in
 	in.SecretKey.DeepCopyInto(&out.SecretKey)
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(metav1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KnifeConfig != nil {
+		in, out := &in.KnifeConfig, &out.KnifeConfig
+		*out = new(metav1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChefAuthSecretRef.
@@ -450,6 +478,80 @@ func (in *ChefAuthSecretRef) DeepCopy() *ChefAuthSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChefAutomateAuth) DeepCopyInto(out *ChefAutomateAuth) {
+	*out = *in
+	in.TokenSecretRef.DeepCopyInto(&out.TokenSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChefAutomateAuth.
+func (in *ChefAutomateAuth) DeepCopy() *ChefAutomateAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ChefAutomateAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChefAutomateProvider) DeepCopyInto(out *ChefAutomateProvider) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificatePins != nil {
+		in, out := &in.CertificatePins, &out.CertificatePins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChefAutomateProvider.
+func (in *ChefAutomateProvider) DeepCopy() *ChefAutomateProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ChefAutomateProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChefItemSchema) DeepCopyInto(out *ChefItemSchema) {
+	*out = *in
+	if in.Databags != nil {
+		in, out := &in.Databags, &out.Databags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]ChefItemPropertyType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChefItemSchema.
+func (in *ChefItemSchema) DeepCopy() *ChefItemSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(ChefItemSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChefProvider) DeepCopyInto(out *ChefProvider) {
 	*out = *in
@@ -458,6 +560,28 @@ func (in *ChefProvider) DeepCopyInto(out *ChefProvider) {
 		*out = new(ChefAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificatePins != nil {
+		in, out := &in.CertificatePins, &out.CertificatePins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ItemSchemas != nil {
+		in, out := &in.ItemSchemas, &out.ItemSchemas
+		*out = make([]ChefItemSchema, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChefProvider.
@@ -470,6 +594,80 @@ func (in *ChefProvider) DeepCopy() *ChefProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudantAuth) DeepCopyInto(out *CloudantAuth) {
+	*out = *in
+	if in.IAM != nil {
+		in, out := &in.IAM, &out.IAM
+		*out = new(CloudantIAMAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Basic != nil {
+		in, out := &in.Basic, &out.Basic
+		*out = new(CloudantBasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudantAuth.
+func (in *CloudantAuth) DeepCopy() *CloudantAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudantAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudantBasicAuth) DeepCopyInto(out *CloudantBasicAuth) {
+	*out = *in
+	in.Username.DeepCopyInto(&out.Username)
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudantBasicAuth.
+func (in *CloudantBasicAuth) DeepCopy() *CloudantBasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudantBasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudantIAMAuth) DeepCopyInto(out *CloudantIAMAuth) {
+	*out = *in
+	in.APIKey.DeepCopyInto(&out.APIKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudantIAMAuth.
+func (in *CloudantIAMAuth) DeepCopy() *CloudantIAMAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudantIAMAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudantProvider) DeepCopyInto(out *CloudantProvider) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudantProvider.
+func (in *CloudantProvider) DeepCopy() *CloudantProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudantProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterExternalSecret) DeepCopyInto(out *ClusterExternalSecret) {
 	*out = *in
@@ -801,6 +999,48 @@ func (in *ConjurProvider) DeepCopy() *ConjurProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CyberArkCCPAuth) DeepCopyInto(out *CyberArkCCPAuth) {
+	*out = *in
+	in.ClientCertSecretRef.DeepCopyInto(&out.ClientCertSecretRef)
+	in.ClientKeySecretRef.DeepCopyInto(&out.ClientKeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CyberArkCCPAuth.
+func (in *CyberArkCCPAuth) DeepCopy() *CyberArkCCPAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(CyberArkCCPAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CyberArkCCPProvider) DeepCopyInto(out *CyberArkCCPProvider) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(CyberArkCCPAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CyberArkCCPProvider.
+func (in *CyberArkCCPProvider) DeepCopy() *CyberArkCCPProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(CyberArkCCPProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DelineaProvider) DeepCopyInto(out *DelineaProvider) {
 	*out = *in
@@ -928,12 +1168,24 @@ func (in *ExternalSecret) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretData) DeepCopyInto(out *ExternalSecretData) {
 	*out = *in
+	if in.PropertyKeys != nil {
+		in, out := &in.PropertyKeys, &out.PropertyKeys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	out.RemoteRef = in.RemoteRef
 	if in.SourceRef != nil {
 		in, out := &in.SourceRef, &out.SourceRef
 		*out = new(StoreSourceRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretData.
@@ -1091,6 +1343,46 @@ func (in *ExternalSecretMetadata) DeepCopy() *ExternalSecretMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretOversize) DeepCopyInto(out *ExternalSecretOversize) {
+	*out = *in
+	if in.DropKeys != nil {
+		in, out := &in.DropKeys, &out.DropKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretOversize.
+func (in *ExternalSecretOversize) DeepCopy() *ExternalSecretOversize {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretOversize)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretReload) DeepCopyInto(out *ExternalSecretReload) {
+	*out = *in
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]ReloadWorkloadRef, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretReload.
+func (in *ExternalSecretReload) DeepCopy() *ExternalSecretReload {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretReload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretRewrite) DeepCopyInto(out *ExternalSecretRewrite) {
 	*out = *in
@@ -1156,6 +1448,16 @@ func (in *ExternalSecretSpec) DeepCopyInto(out *ExternalSecretSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.FreshnessThreshold != nil {
+		in, out := &in.FreshnessThreshold, &out.FreshnessThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetrySettings != nil {
+		in, out := &in.RetrySettings, &out.RetrySettings
+		*out = new(SecretStoreRetrySettings)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Data != nil {
 		in, out := &in.Data, &out.Data
 		*out = make([]ExternalSecretData, len(*in))
@@ -1193,6 +1495,18 @@ func (in *ExternalSecretStatus) DeepCopyInto(out *ExternalSecretStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UnresolvedKeys != nil {
+		in, out := &in.UnresolvedKeys, &out.UnresolvedKeys
+		*out = make([]UnresolvedKey, len(*in))
+		copy(*out, *in)
+	}
+	if in.FetchDetails != nil {
+		in, out := &in.FetchDetails, &out.FetchDetails
+		*out = make([]FetchDetail, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Binding = in.Binding
 }
 
@@ -1230,6 +1544,16 @@ func (in *ExternalSecretTarget) DeepCopyInto(out *ExternalSecretTarget) {
 		*out = new(ExternalSecretTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Reload != nil {
+		in, out := &in.Reload, &out.Reload
+		*out = new(ExternalSecretReload)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Oversize != nil {
+		in, out := &in.Oversize, &out.Oversize
+		*out = new(ExternalSecretOversize)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretTarget.
@@ -1301,21 +1625,6 @@ func (in *ExternalSecretTemplateMetadata) DeepCopy() *ExternalSecretTemplateMeta
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalSecretValidator) DeepCopyInto(out *ExternalSecretValidator) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretValidator.
-func (in *ExternalSecretValidator) DeepCopy() *ExternalSecretValidator {
-	if in == nil {
-		return nil
-	}
-	out := new(ExternalSecretValidator)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FakeProvider) DeepCopyInto(out *FakeProvider) {
 	*out = *in
@@ -1361,49 +1670,85 @@ func (in *FakeProviderData) DeepCopy() *FakeProviderData {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FindName) DeepCopyInto(out *FindName) {
+func (in *FetchDetail) DeepCopyInto(out *FetchDetail) {
 	*out = *in
+	out.Duration = in.Duration
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]FetchedKey, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FindName.
-func (in *FindName) DeepCopy() *FindName {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FetchDetail.
+func (in *FetchDetail) DeepCopy() *FetchDetail {
 	if in == nil {
 		return nil
 	}
-	out := new(FindName)
+	out := new(FetchDetail)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPSMAuth) DeepCopyInto(out *GCPSMAuth) {
+func (in *FetchedKey) DeepCopyInto(out *FetchedKey) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(GCPSMAuthSecretRef)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.WorkloadIdentity != nil {
-		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
-		*out = new(GCPWorkloadIdentity)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSMAuth.
-func (in *GCPSMAuth) DeepCopy() *GCPSMAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FetchedKey.
+func (in *FetchedKey) DeepCopy() *FetchedKey {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPSMAuth)
+	out := new(FetchedKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPSMAuthSecretRef) DeepCopyInto(out *GCPSMAuthSecretRef) {
+func (in *FindName) DeepCopyInto(out *FindName) {
 	*out = *in
-	in.SecretAccessKey.DeepCopyInto(&out.SecretAccessKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FindName.
+func (in *FindName) DeepCopy() *FindName {
+	if in == nil {
+		return nil
+	}
+	out := new(FindName)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPSMAuth) DeepCopyInto(out *GCPSMAuth) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(GCPSMAuthSecretRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(GCPWorkloadIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSMAuth.
+func (in *GCPSMAuth) DeepCopy() *GCPSMAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPSMAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPSMAuthSecretRef) DeepCopyInto(out *GCPSMAuthSecretRef) {
+	*out = *in
+	in.SecretAccessKey.DeepCopyInto(&out.SecretAccessKey)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSMAuthSecretRef.
@@ -1448,9 +1793,42 @@ func (in *GCPWorkloadIdentity) DeepCopy() *GCPWorkloadIdentity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratorParameter) DeepCopyInto(out *GeneratorParameter) {
+	*out = *in
+	out.RemoteRef = in.RemoteRef
+	if in.StoreRef != nil {
+		in, out := &in.StoreRef, &out.StoreRef
+		*out = new(SecretStoreRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratorParameter.
+func (in *GeneratorParameter) DeepCopy() *GeneratorParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratorParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GeneratorRef) DeepCopyInto(out *GeneratorRef) {
 	*out = *in
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(GeneratorRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]GeneratorParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratorRef.
@@ -1463,6 +1841,31 @@ func (in *GeneratorRef) DeepCopy() *GeneratorRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratorRotation) DeepCopyInto(out *GeneratorRotation) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratorRotation.
+func (in *GeneratorRotation) DeepCopy() *GeneratorRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratorRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GenericStoreValidator) DeepCopyInto(out *GenericStoreValidator) {
 	*out = *in
@@ -1531,6 +1934,59 @@ func (in *GitlabSecretRef) DeepCopy() *GitlabSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HPCSProvider) DeepCopyInto(out *HPCSProvider) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HPCSProvider.
+func (in *HPCSProvider) DeepCopy() *HPCSProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(HPCSProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HabitatAuth) DeepCopyInto(out *HabitatAuth) {
+	*out = *in
+	in.TokenSecretRef.DeepCopyInto(&out.TokenSecretRef)
+	if in.EncryptionKeySecretRef != nil {
+		in, out := &in.EncryptionKeySecretRef, &out.EncryptionKeySecretRef
+		*out = new(metav1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HabitatAuth.
+func (in *HabitatAuth) DeepCopy() *HabitatAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(HabitatAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HabitatProvider) DeepCopyInto(out *HabitatProvider) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HabitatProvider.
+func (in *HabitatProvider) DeepCopy() *HabitatProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(HabitatProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IBMAuth) DeepCopyInto(out *IBMAuth) {
 	*out = *in
@@ -1608,6 +2064,48 @@ func (in *IBMProvider) DeepCopy() *IBMProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAuth) DeepCopyInto(out *JenkinsAuth) {
+	*out = *in
+	in.APITokenSecretRef.DeepCopyInto(&out.APITokenSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAuth.
+func (in *JenkinsAuth) DeepCopy() *JenkinsAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsProvider) DeepCopyInto(out *JenkinsProvider) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificatePins != nil {
+		in, out := &in.CertificatePins, &out.CertificatePins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsProvider.
+func (in *JenkinsProvider) DeepCopy() *JenkinsProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeeperSecurityProvider) DeepCopyInto(out *KeeperSecurityProvider) {
 	*out = *in
@@ -1624,6 +2122,105 @@ func (in *KeeperSecurityProvider) DeepCopy() *KeeperSecurityProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyProtectProvider) DeepCopyInto(out *KeyProtectProvider) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyProtectProvider.
+func (in *KeyProtectProvider) DeepCopy() *KeyProtectProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyProtectProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakAuth) DeepCopyInto(out *KeycloakAuth) {
+	*out = *in
+	if in.ClientCredentials != nil {
+		in, out := &in.ClientCredentials, &out.ClientCredentials
+		*out = new(KeycloakClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(KeycloakPasswordAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuth.
+func (in *KeycloakAuth) DeepCopy() *KeycloakAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientCredentials) DeepCopyInto(out *KeycloakClientCredentials) {
+	*out = *in
+	in.ClientSecretRef.DeepCopyInto(&out.ClientSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientCredentials.
+func (in *KeycloakClientCredentials) DeepCopy() *KeycloakClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakPasswordAuth) DeepCopyInto(out *KeycloakPasswordAuth) {
+	*out = *in
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakPasswordAuth.
+func (in *KeycloakPasswordAuth) DeepCopy() *KeycloakPasswordAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakPasswordAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakProvider) DeepCopyInto(out *KeycloakProvider) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificatePins != nil {
+		in, out := &in.CertificatePins, &out.CertificatePins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakProvider.
+func (in *KeycloakProvider) DeepCopy() *KeycloakProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesAuth) DeepCopyInto(out *KubernetesAuth) {
 	*out = *in
@@ -1661,173 +2258,604 @@ func (in *KubernetesProvider) DeepCopyInto(out *KubernetesProvider) {
 	in.Auth.DeepCopyInto(&out.Auth)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesProvider.
-func (in *KubernetesProvider) DeepCopy() *KubernetesProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesProvider.
+func (in *KubernetesProvider) DeepCopy() *KubernetesProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesServer) DeepCopyInto(out *KubernetesServer) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CAProvider != nil {
+		in, out := &in.CAProvider, &out.CAProvider
+		*out = new(CAProvider)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServer.
+func (in *KubernetesServer) DeepCopy() *KubernetesServer {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPAuth) DeepCopyInto(out *LDAPAuth) {
+	*out = *in
+	in.BindPasswordSecretRef.DeepCopyInto(&out.BindPasswordSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPAuth.
+func (in *LDAPAuth) DeepCopy() *LDAPAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPProvider) DeepCopyInto(out *LDAPProvider) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPProvider.
+func (in *LDAPProvider) DeepCopy() *LDAPProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NoSecretError) DeepCopyInto(out *NoSecretError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoSecretError.
+func (in *NoSecretError) DeepCopy() *NoSecretError {
+	if in == nil {
+		return nil
+	}
+	out := new(NoSecretError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnePasswordAuth) DeepCopyInto(out *OnePasswordAuth) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(OnePasswordAuthSecretRef)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnePasswordAuth.
+func (in *OnePasswordAuth) DeepCopy() *OnePasswordAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(OnePasswordAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnePasswordAuthSecretRef) DeepCopyInto(out *OnePasswordAuthSecretRef) {
+	*out = *in
+	in.ConnectToken.DeepCopyInto(&out.ConnectToken)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnePasswordAuthSecretRef.
+func (in *OnePasswordAuthSecretRef) DeepCopy() *OnePasswordAuthSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(OnePasswordAuthSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnePasswordProvider) DeepCopyInto(out *OnePasswordProvider) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(OnePasswordAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vaults != nil {
+		in, out := &in.Vaults, &out.Vaults
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnePasswordProvider.
+func (in *OnePasswordProvider) DeepCopy() *OnePasswordProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(OnePasswordProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleAuth) DeepCopyInto(out *OracleAuth) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleAuth.
+func (in *OracleAuth) DeepCopy() *OracleAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleProvider) DeepCopyInto(out *OracleProvider) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(OracleAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountRef != nil {
+		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
+		*out = new(metav1.ServiceAccountSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleProvider.
+func (in *OracleProvider) DeepCopy() *OracleProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleSecretRef) DeepCopyInto(out *OracleSecretRef) {
+	*out = *in
+	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+	in.Fingerprint.DeepCopyInto(&out.Fingerprint)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleSecretRef.
+func (in *OracleSecretRef) DeepCopy() *OracleSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginProvider) DeepCopyInto(out *PluginProvider) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]PluginSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginProvider.
+func (in *PluginProvider) DeepCopy() *PluginProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSecret) DeepCopyInto(out *PluginSecret) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSecret.
+func (in *PluginSecret) DeepCopy() *PluginSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PuppetBasicAuth) DeepCopyInto(out *PuppetBasicAuth) {
+	*out = *in
+	in.UsernameSecretRef.DeepCopyInto(&out.UsernameSecretRef)
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PuppetBasicAuth.
+func (in *PuppetBasicAuth) DeepCopy() *PuppetBasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(PuppetBasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PuppetEYAML) DeepCopyInto(out *PuppetEYAML) {
+	*out = *in
+	in.PublicKeySecretRef.DeepCopyInto(&out.PublicKeySecretRef)
+	in.PrivateKeySecretRef.DeepCopyInto(&out.PrivateKeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PuppetEYAML.
+func (in *PuppetEYAML) DeepCopy() *PuppetEYAML {
+	if in == nil {
+		return nil
+	}
+	out := new(PuppetEYAML)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PuppetGitSource) DeepCopyInto(out *PuppetGitSource) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(PuppetBasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PuppetGitSource.
+func (in *PuppetGitSource) DeepCopy() *PuppetGitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PuppetGitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PuppetHTTPAuth) DeepCopyInto(out *PuppetHTTPAuth) {
+	*out = *in
+	if in.Basic != nil {
+		in, out := &in.Basic, &out.Basic
+		*out = new(PuppetBasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(metav1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PuppetHTTPAuth.
+func (in *PuppetHTTPAuth) DeepCopy() *PuppetHTTPAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(PuppetHTTPAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PuppetHTTPSource) DeepCopyInto(out *PuppetHTTPSource) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(PuppetHTTPAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PuppetHTTPSource.
+func (in *PuppetHTTPSource) DeepCopy() *PuppetHTTPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PuppetHTTPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PuppetProvider) DeepCopyInto(out *PuppetProvider) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(PuppetGitSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(PuppetHTTPSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EYAML != nil {
+		in, out := &in.EYAML, &out.EYAML
+		*out = new(PuppetEYAML)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PuppetProvider.
+func (in *PuppetProvider) DeepCopy() *PuppetProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(PuppetProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReloadWorkloadRef) DeepCopyInto(out *ReloadWorkloadRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReloadWorkloadRef.
+func (in *ReloadWorkloadRef) DeepCopy() *ReloadWorkloadRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ReloadWorkloadRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SOPSGitAuth) DeepCopyInto(out *SOPSGitAuth) {
+	*out = *in
+	in.UsernameSecretRef.DeepCopyInto(&out.UsernameSecretRef)
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOPSGitAuth.
+func (in *SOPSGitAuth) DeepCopy() *SOPSGitAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(SOPSGitAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SOPSGitSource) DeepCopyInto(out *SOPSGitSource) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(SOPSGitAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOPSGitSource.
+func (in *SOPSGitSource) DeepCopy() *SOPSGitSource {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesProvider)
+	out := new(SOPSGitSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesServer) DeepCopyInto(out *KubernetesServer) {
+func (in *SOPSKMSAuth) DeepCopyInto(out *SOPSKMSAuth) {
 	*out = *in
-	if in.CABundle != nil {
-		in, out := &in.CABundle, &out.CABundle
-		*out = make([]byte, len(*in))
-		copy(*out, *in)
-	}
-	if in.CAProvider != nil {
-		in, out := &in.CAProvider, &out.CAProvider
-		*out = new(CAProvider)
+	in.AccessKeyIDSecretRef.DeepCopyInto(&out.AccessKeyIDSecretRef)
+	in.SecretAccessKeySecretRef.DeepCopyInto(&out.SecretAccessKeySecretRef)
+	if in.SessionTokenSecretRef != nil {
+		in, out := &in.SessionTokenSecretRef, &out.SessionTokenSecretRef
+		*out = new(metav1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServer.
-func (in *KubernetesServer) DeepCopy() *KubernetesServer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOPSKMSAuth.
+func (in *SOPSKMSAuth) DeepCopy() *SOPSKMSAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesServer)
+	out := new(SOPSKMSAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NoSecretError) DeepCopyInto(out *NoSecretError) {
+func (in *SOPSKeys) DeepCopyInto(out *SOPSKeys) {
 	*out = *in
+	if in.AgeSecretRef != nil {
+		in, out := &in.AgeSecretRef, &out.AgeSecretRef
+		*out = new(metav1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PGP != nil {
+		in, out := &in.PGP, &out.PGP
+		*out = new(SOPSPGPKey)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(SOPSKMSAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoSecretError.
-func (in *NoSecretError) DeepCopy() *NoSecretError {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOPSKeys.
+func (in *SOPSKeys) DeepCopy() *SOPSKeys {
 	if in == nil {
 		return nil
 	}
-	out := new(NoSecretError)
+	out := new(SOPSKeys)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OnePasswordAuth) DeepCopyInto(out *OnePasswordAuth) {
+func (in *SOPSPGPKey) DeepCopyInto(out *SOPSPGPKey) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(OnePasswordAuthSecretRef)
+	in.PrivateKeySecretRef.DeepCopyInto(&out.PrivateKeySecretRef)
+	if in.PassphraseSecretRef != nil {
+		in, out := &in.PassphraseSecretRef, &out.PassphraseSecretRef
+		*out = new(metav1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnePasswordAuth.
-func (in *OnePasswordAuth) DeepCopy() *OnePasswordAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOPSPGPKey.
+func (in *SOPSPGPKey) DeepCopy() *SOPSPGPKey {
 	if in == nil {
 		return nil
 	}
-	out := new(OnePasswordAuth)
+	out := new(SOPSPGPKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OnePasswordAuthSecretRef) DeepCopyInto(out *OnePasswordAuthSecretRef) {
+func (in *SOPSProvider) DeepCopyInto(out *SOPSProvider) {
 	*out = *in
-	in.ConnectToken.DeepCopyInto(&out.ConnectToken)
+	in.Git.DeepCopyInto(&out.Git)
+	in.Keys.DeepCopyInto(&out.Keys)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnePasswordAuthSecretRef.
-func (in *OnePasswordAuthSecretRef) DeepCopy() *OnePasswordAuthSecretRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOPSProvider.
+func (in *SOPSProvider) DeepCopy() *SOPSProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(OnePasswordAuthSecretRef)
+	out := new(SOPSProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OnePasswordProvider) DeepCopyInto(out *OnePasswordProvider) {
+func (in *SQLAuth) DeepCopyInto(out *SQLAuth) {
 	*out = *in
-	if in.Auth != nil {
-		in, out := &in.Auth, &out.Auth
-		*out = new(OnePasswordAuth)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Vaults != nil {
-		in, out := &in.Vaults, &out.Vaults
-		*out = make(map[string]int, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnePasswordProvider.
-func (in *OnePasswordProvider) DeepCopy() *OnePasswordProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLAuth.
+func (in *SQLAuth) DeepCopy() *SQLAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(OnePasswordProvider)
+	out := new(SQLAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleAuth) DeepCopyInto(out *OracleAuth) {
+func (in *SQLProvider) DeepCopyInto(out *SQLProvider) {
 	*out = *in
-	in.SecretRef.DeepCopyInto(&out.SecretRef)
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleAuth.
-func (in *OracleAuth) DeepCopy() *OracleAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLProvider.
+func (in *SQLProvider) DeepCopy() *SQLProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(OracleAuth)
+	out := new(SQLProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleProvider) DeepCopyInto(out *OracleProvider) {
+func (in *SaltAuth) DeepCopyInto(out *SaltAuth) {
 	*out = *in
-	if in.Auth != nil {
-		in, out := &in.Auth, &out.Auth
-		*out = new(OracleAuth)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceAccountRef != nil {
-		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
-		*out = new(metav1.ServiceAccountSelector)
-		(*in).DeepCopyInto(*out)
-	}
+	in.UsernameSecretRef.DeepCopyInto(&out.UsernameSecretRef)
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleProvider.
-func (in *OracleProvider) DeepCopy() *OracleProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SaltAuth.
+func (in *SaltAuth) DeepCopy() *SaltAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(OracleProvider)
+	out := new(SaltAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleSecretRef) DeepCopyInto(out *OracleSecretRef) {
+func (in *SaltProvider) DeepCopyInto(out *SaltProvider) {
 	*out = *in
-	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
-	in.Fingerprint.DeepCopyInto(&out.Fingerprint)
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificatePins != nil {
+		in, out := &in.CertificatePins, &out.CertificatePins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleSecretRef.
-func (in *OracleSecretRef) DeepCopy() *OracleSecretRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SaltProvider.
+func (in *SaltProvider) DeepCopy() *SaltProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(OracleSecretRef)
+	out := new(SaltProvider)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1904,6 +2932,60 @@ func (in *SecretStore) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStoreHealthCheck) DeepCopyInto(out *SecretStoreHealthCheck) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CanaryRef != nil {
+		in, out := &in.CanaryRef, &out.CanaryRef
+		*out = new(ExternalSecretDataRemoteRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreHealthCheck.
+func (in *SecretStoreHealthCheck) DeepCopy() *SecretStoreHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStoreHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStoreHealthCheckStatus) DeepCopyInto(out *SecretStoreHealthCheckStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LatencyP95 != nil {
+		in, out := &in.LatencyP95, &out.LatencyP95
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreHealthCheckStatus.
+func (in *SecretStoreHealthCheckStatus) DeepCopy() *SecretStoreHealthCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStoreHealthCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretStoreList) DeepCopyInto(out *SecretStoreList) {
 	*out = *in
@@ -2049,6 +3131,81 @@ func (in *SecretStoreProvider) DeepCopyInto(out *SecretStoreProvider) {
 		*out = new(ChefProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ChefAutomate != nil {
+		in, out := &in.ChefAutomate, &out.ChefAutomate
+		*out = new(ChefAutomateProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Habitat != nil {
+		in, out := &in.Habitat, &out.Habitat
+		*out = new(HabitatProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Puppet != nil {
+		in, out := &in.Puppet, &out.Puppet
+		*out = new(PuppetProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Salt != nil {
+		in, out := &in.Salt, &out.Salt
+		*out = new(SaltProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jenkins != nil {
+		in, out := &in.Jenkins, &out.Jenkins
+		*out = new(JenkinsProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SOPS != nil {
+		in, out := &in.SOPS, &out.SOPS
+		*out = new(SOPSProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CyberArkCCP != nil {
+		in, out := &in.CyberArkCCP, &out.CyberArkCCP
+		*out = new(CyberArkCCPProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keycloak != nil {
+		in, out := &in.Keycloak, &out.Keycloak
+		*out = new(KeycloakProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(SQLProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(LDAPProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cloudant != nil {
+		in, out := &in.Cloudant, &out.Cloudant
+		*out = new(CloudantProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyProtect != nil {
+		in, out := &in.KeyProtect, &out.KeyProtect
+		*out = new(KeyProtectProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HPCS != nil {
+		in, out := &in.HPCS, &out.HPCS
+		*out = new(HPCSProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.COS != nil {
+		in, out := &in.COS, &out.COS
+		*out = new(COSProvider)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreProvider.
@@ -2121,6 +3278,11 @@ func (in *SecretStoreSpec) DeepCopyInto(out *SecretStoreSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(SecretStoreHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreSpec.
@@ -2143,6 +3305,11 @@ func (in *SecretStoreStatus) DeepCopyInto(out *SecretStoreStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(SecretStoreHealthCheckStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreStatus.
@@ -2229,7 +3396,7 @@ func (in *StoreGeneratorSourceRef) DeepCopyInto(out *StoreGeneratorSourceRef) {
 	if in.GeneratorRef != nil {
 		in, out := &in.GeneratorRef, &out.GeneratorRef
 		*out = new(GeneratorRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -2250,7 +3417,7 @@ func (in *StoreSourceRef) DeepCopyInto(out *StoreSourceRef) {
 	if in.GeneratorRef != nil {
 		in, out := &in.GeneratorRef, &out.GeneratorRef
 		*out = new(GeneratorRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -2360,6 +3527,21 @@ func (in *TokenAuth) DeepCopy() *TokenAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnresolvedKey) DeepCopyInto(out *UnresolvedKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnresolvedKey.
+func (in *UnresolvedKey) DeepCopy() *UnresolvedKey {
+	if in == nil {
+		return nil
+	}
+	out := new(UnresolvedKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultAppRole) DeepCopyInto(out *VaultAppRole) {
 	*out = *in
@@ -2719,6 +3901,36 @@ func (in *VaultUserPassAuth) DeepCopy() *VaultUserPassAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAuth) DeepCopyInto(out *WebhookAuth) {
+	*out = *in
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(WebhookOAuth2)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HMAC != nil {
+		in, out := &in.HMAC, &out.HMAC
+		*out = new(WebhookHMAC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(WebhookJWT)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAuth.
+func (in *WebhookAuth) DeepCopy() *WebhookAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookCAProvider) DeepCopyInto(out *WebhookCAProvider) {
 	*out = *in
@@ -2739,6 +3951,74 @@ func (in *WebhookCAProvider) DeepCopy() *WebhookCAProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookHMAC) DeepCopyInto(out *WebhookHMAC) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookHMAC.
+func (in *WebhookHMAC) DeepCopy() *WebhookHMAC {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookHMAC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookJWT) DeepCopyInto(out *WebhookJWT) {
+	*out = *in
+	in.TokenSecretRef.DeepCopyInto(&out.TokenSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookJWT.
+func (in *WebhookJWT) DeepCopy() *WebhookJWT {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookJWT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookOAuth2) DeepCopyInto(out *WebhookOAuth2) {
+	*out = *in
+	in.ClientSecretRef.DeepCopyInto(&out.ClientSecretRef)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookOAuth2.
+func (in *WebhookOAuth2) DeepCopy() *WebhookOAuth2 {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookOAuth2)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookPagination) DeepCopyInto(out *WebhookPagination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookPagination.
+func (in *WebhookPagination) DeepCopy() *WebhookPagination {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookPagination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookProvider) DeepCopyInto(out *WebhookProvider) {
 	*out = *in
@@ -2767,11 +4047,26 @@ func (in *WebhookProvider) DeepCopyInto(out *WebhookProvider) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.CertificatePins != nil {
+		in, out := &in.CertificatePins, &out.CertificatePins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.CAProvider != nil {
 		in, out := &in.CAProvider, &out.CAProvider
 		*out = new(WebhookCAProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(WebhookAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pagination != nil {
+		in, out := &in.Pagination, &out.Pagination
+		*out = new(WebhookPagination)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookProvider.
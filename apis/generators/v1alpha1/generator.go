@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"context"
+	"time"
 
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,3 +34,50 @@ type Generator interface {
 		namespace string,
 	) (map[string][]byte, error)
 }
+
+// StatefulGenerator is implemented by generators that create artifacts outside
+// of the cluster which have to be removed once the generated secret is no
+// longer used, e.g. a client registered at a Chef server.
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+type StatefulGenerator interface {
+	Generator
+
+	// GenerateWithState generates a secret like Generate and returns the state
+	// that is required to clean up afterwards. The state is nil if there is
+	// nothing to clean up.
+	GenerateWithState(
+		ctx context.Context,
+		obj *apiextensions.JSON,
+		kube client.Client,
+		namespace string,
+	) (map[string][]byte, *apiextensions.JSON, error)
+
+	// Cleanup removes the artifacts described by state.
+	Cleanup(
+		ctx context.Context,
+		obj *apiextensions.JSON,
+		state *apiextensions.JSON,
+		kube client.Client,
+		namespace string,
+	) error
+}
+
+// RenewingGenerator is implemented by generators whose output expires,
+// e.g. a certificate. Rotated output of these generators is renewed once
+// the time returned by RenewAt is reached, in addition to the rotation interval.
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+type RenewingGenerator interface {
+	Generator
+
+	// RenewAt returns the time at which data, as generated from obj, has to be renewed.
+	RenewAt(
+		obj *apiextensions.JSON,
+		data map[string][]byte,
+	) (time.Time, error)
+}
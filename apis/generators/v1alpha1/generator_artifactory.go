@@ -0,0 +1,114 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// ArtifactoryAccessTokenSpec configures which credentials to return.
+type ArtifactoryAccessTokenSpec struct {
+	// URL of the JFrog Platform, e.g. https://example.jfrog.io
+	URL string `json:"url"`
+
+	// Registry is the host of the docker registry in the generated output.
+	// Defaults to the host of url.
+	// +optional
+	Registry string `json:"registry,omitempty"`
+
+	// Auth configures how to authenticate with the JFrog Platform.
+	// The secrets are read from the namespace of the generator.
+	Auth ArtifactoryAuth `json:"auth"`
+
+	// Token configures the access token to create.
+	// If not set, the encrypted password of the user configured in auth.basic
+	// is returned, which repository clients accept instead of the password.
+	// +optional
+	Token *ArtifactoryTokenSpec `json:"token,omitempty"`
+
+	// PEM encoded CA bundle used to validate the certificate of the JFrog Platform.
+	// The system trust store is used if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// ArtifactoryAuth configures the credentials used to call the JFrog Platform.
+// Exactly one of accessTokenSecretRef or basic must be set.
+type ArtifactoryAuth struct {
+	// AccessTokenSecretRef references an access token or identity token.
+	// +optional
+	AccessTokenSecretRef *esmeta.SecretKeySelector `json:"accessTokenSecretRef,omitempty"`
+
+	// Basic authenticates with username and password.
+	// +optional
+	Basic *ArtifactoryBasicAuth `json:"basic,omitempty"`
+}
+
+// ArtifactoryBasicAuth authenticates with username and password.
+type ArtifactoryBasicAuth struct {
+	Username string `json:"username"`
+
+	// PasswordSecretRef references the password of the user.
+	PasswordSecretRef esmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// ArtifactoryTokenSpec configures the access token to create.
+type ArtifactoryTokenSpec struct {
+	// Username is the subject of the token. Creating tokens for other users
+	// than the authenticated one requires an admin.
+	Username string `json:"username"`
+
+	// Scope of the token, e.g. applied-permissions/groups:readers.
+	// +kubebuilder:default="applied-permissions/user"
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
+	// ExpiresIn is the lifetime of the token. Defaults to one hour.
+	// +optional
+	ExpiresIn *metav1.Duration `json:"expiresIn,omitempty"`
+
+	// Audience of the token, e.g. jfrt@* for all Artifactory instances.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// Description of the token shown in the JFrog Platform.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// ArtifactoryAccessToken returns credentials for repositories of JFrog
+// Artifactory: a scoped, short-lived access token, or the encrypted password
+// of an existing user.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={artifactoryaccesstoken},shortName=artifactoryaccesstoken
+type ArtifactoryAccessToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ArtifactoryAccessTokenSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ArtifactoryAccessTokenList contains a list of ArtifactoryAccessToken resources.
+type ArtifactoryAccessTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArtifactoryAccessToken `json:"items"`
+}
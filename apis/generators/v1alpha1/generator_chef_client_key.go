@@ -0,0 +1,60 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// ChefClientKeySpec defines which client to register on the Chef server.
+type ChefClientKeySpec struct {
+	// Provider configures how to connect to the Chef server.
+	// The user needs permissions to create clients and to update their keys.
+	// The private key is read from the namespace of the generator.
+	Provider *esv1beta1.ChefProvider `json:"provider"`
+
+	// ClientName is the name of the client to register.
+	// If the client already exists its default key is replaced.
+	ClientName string `json:"clientName"`
+
+	// Validator registers the client as validator client.
+	// +optional
+	Validator bool `json:"validator,omitempty"`
+}
+
+// ChefClientKey registers a client on the Chef server
+// and returns its private key.
+// Every generation replaces the key of an existing client.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={chefclientkey},shortName=chefclientkey
+type ChefClientKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ChefClientKeySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ChefClientKeyList contains a list of ChefClientKey resources.
+type ChefClientKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChefClientKey `json:"items"`
+}
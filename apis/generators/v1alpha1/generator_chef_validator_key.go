@@ -0,0 +1,64 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// ChefValidatorKeySpec defines which validator key to rotate.
+type ChefValidatorKeySpec struct {
+	// Provider configures how to connect to the Chef server.
+	// The user needs permissions to manage the keys of the validator client.
+	// The private key is read from the namespace of the generator.
+	Provider *esv1beta1.ChefProvider `json:"provider"`
+
+	// ValidatorName is the name of the validator client.
+	// Defaults to `<organization>-validator`, the organization is taken from the serverUrl.
+	// +optional
+	ValidatorName string `json:"validatorName,omitempty"`
+
+	// KeepPrevious is the number of previously generated keys that stay valid,
+	// so bootstrap runs that still use an older key do not fail.
+	// Keys that were not created by the generator are never removed.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepPrevious *int `json:"keepPrevious,omitempty"`
+}
+
+// ChefValidatorKey adds a new key to the validator client of a Chef organization
+// and returns it. Older keys created by the generator are removed.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={chefvalidatorkey},shortName=chefvalidatorkey
+type ChefValidatorKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ChefValidatorKeySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ChefValidatorKeyList contains a list of ChefValidatorKey resources.
+type ChefValidatorKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChefValidatorKey `json:"items"`
+}
@@ -0,0 +1,102 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CSRSpec controls the behavior of the private key and CSR generator.
+type CSRSpec struct {
+	// KeyAlgorithm specifies the algorithm of the generated private key.
+	// Defaults to rsa
+	// +kubebuilder:default=rsa
+	// +optional
+	KeyAlgorithm CSRKeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// KeySize specifies the size of the private key.
+	// For rsa this is the number of bits and defaults to 2048,
+	// for ecdsa this is the curve size (256, 384 or 521) and defaults to 256.
+	// It is ignored for ed25519 keys.
+	// +optional
+	KeySize *int `json:"keySize,omitempty"`
+
+	// Subject of the certificate signing request.
+	// +optional
+	Subject CSRSubject `json:"subject,omitempty"`
+
+	// DNSNames to be requested as subject alternative names.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// IPAddresses to be requested as subject alternative names.
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// EmailAddresses to be requested as subject alternative names.
+	// +optional
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// URIs to be requested as subject alternative names.
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+}
+
+// CSRSubject is the distinguished name of the certificate signing request.
+type CSRSubject struct {
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+	// +optional
+	OrganizationalUnits []string `json:"organizationalUnits,omitempty"`
+	// +optional
+	Countries []string `json:"countries,omitempty"`
+	// +optional
+	Provinces []string `json:"provinces,omitempty"`
+	// +optional
+	Localities []string `json:"localities,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=rsa;ecdsa;ed25519
+type CSRKeyAlgorithm string
+
+const (
+	CSRKeyAlgorithmRSA     CSRKeyAlgorithm = "rsa"
+	CSRKeyAlgorithmECDSA   CSRKeyAlgorithm = "ecdsa"
+	CSRKeyAlgorithmEd25519 CSRKeyAlgorithm = "ed25519"
+)
+
+// CSR generates a new private key and a certificate signing request for it.
+// Both are returned PEM encoded, the CSR can be signed by an external CA.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={csr},shortName=csr
+type CSR struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CSRSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CSRList contains a list of CSR resources.
+type CSRList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CSR `json:"items"`
+}
@@ -0,0 +1,81 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GPGKeySpec controls the behavior of the gpg key generator.
+type GPGKeySpec struct {
+	// Name of the user id of the key, e.g. Release Signing.
+	Name string `json:"name"`
+
+	// Email of the user id of the key.
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// Comment of the user id of the key.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+
+	// KeyAlgorithm specifies the algorithm of the generated key.
+	// ed25519 creates an EdDSA signing key with a Curve25519 encryption subkey.
+	// Defaults to ed25519
+	// +kubebuilder:default=ed25519
+	// +optional
+	KeyAlgorithm GPGKeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// KeySize specifies the size of RSA keys in bits.
+	// It is ignored for ed25519 keys. Defaults to 4096
+	// +optional
+	KeySize *int `json:"keySize,omitempty"`
+
+	// Expiry is the lifetime of the key after its creation.
+	// The key does not expire if it is not set.
+	// +optional
+	Expiry *metav1.Duration `json:"expiry,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=ed25519;rsa
+type GPGKeyAlgorithm string
+
+const (
+	GPGKeyAlgorithmEd25519 GPGKeyAlgorithm = "ed25519"
+	GPGKeyAlgorithmRSA     GPGKeyAlgorithm = "rsa"
+)
+
+// GPGKey generates a new gpg keypair.
+// The private and public key are returned ASCII armored,
+// together with the fingerprint of the primary key.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={gpgkey},shortName=gpgkey
+type GPGKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GPGKeySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GPGKeyList contains a list of GPGKey resources.
+type GPGKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GPGKey `json:"items"`
+}
@@ -0,0 +1,63 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PassphraseSpec controls the behavior of the passphrase generator.
+type PassphraseSpec struct {
+	// Words is the number of words of the passphrase.
+	// Every word adds 11 bits of entropy.
+	// Defaults to 7
+	// +kubebuilder:default=7
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	Words int `json:"words,omitempty"`
+
+	// Separators specifies the characters the separator between two
+	// words is chosen from, at random for every separator.
+	// The words are concatenated if it is empty. Defaults to -
+	// +optional
+	Separators *string `json:"separators,omitempty"`
+
+	// Set Capitalize to start every word with an uppercase letter.
+	// +kubebuilder:default=false
+	// +optional
+	Capitalize bool `json:"capitalize,omitempty"`
+}
+
+// Passphrase generates a random passphrase of words from a wordlist,
+// diceware style, that is easy to remember and type.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={passphrase},shortName=passphrase
+type Passphrase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PassphraseSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PassphraseList contains a list of Passphrase resources.
+type PassphraseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Passphrase `json:"items"`
+}
@@ -23,14 +23,17 @@ type PasswordSpec struct {
 	// Length of the password to be generated.
 	// Defaults to 24
 	// +kubebuilder:default=24
+	// +kubebuilder:validation:Minimum=1
 	Length int `json:"length"`
 
 	// Digits specifies the number of digits in the generated
 	// password. If omitted it defaults to 25% of the length of the password
+	// +kubebuilder:validation:Minimum=0
 	Digits *int `json:"digits,omitempty"`
 
 	// Symbols specifies the number of symbol characters in the generated
 	// password. If omitted it defaults to 25% of the length of the password
+	// +kubebuilder:validation:Minimum=0
 	Symbols *int `json:"symbols,omitempty"`
 
 	// SymbolCharacters specifies the special characters that should be used
@@ -0,0 +1,110 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SelfSignedCertificateSpec controls the behavior of the self-signed certificate generator.
+type SelfSignedCertificateSpec struct {
+	// KeyAlgorithm specifies the algorithm of the generated private key.
+	// Defaults to rsa
+	// +kubebuilder:default=rsa
+	// +optional
+	KeyAlgorithm CSRKeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// KeySize specifies the size of the private key.
+	// For rsa this is the number of bits and defaults to 2048,
+	// for ecdsa this is the curve size (256, 384 or 521) and defaults to 256.
+	// It is ignored for ed25519 keys.
+	// +optional
+	KeySize *int `json:"keySize,omitempty"`
+
+	// Subject of the certificate.
+	// +optional
+	Subject CSRSubject `json:"subject,omitempty"`
+
+	// DNSNames of the subject alternative names.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// IPAddresses of the subject alternative names.
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// EmailAddresses of the subject alternative names.
+	// +optional
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// URIs of the subject alternative names.
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+
+	// Duration is the validity of the certificate. Defaults to 24h.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is the time before the expiry of the certificate at which
+	// a rotation of the generator output renews it.
+	// Defaults to a third of the duration.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// Usages of the certificate.
+	// Defaults to digital signature, key encipherment and server auth.
+	// +optional
+	Usages []CertificateUsage `json:"usages,omitempty"`
+
+	// IsCA marks the certificate as certificate authority,
+	// so that it can sign other certificates.
+	// +optional
+	IsCA bool `json:"isCA,omitempty"`
+}
+
+// CertificateUsage is a key usage or extended key usage of a certificate.
+// +kubebuilder:validation:Enum=digital signature;key encipherment;server auth;client auth;code signing;email protection
+type CertificateUsage string
+
+const (
+	CertificateUsageDigitalSignature CertificateUsage = "digital signature"
+	CertificateUsageKeyEncipherment  CertificateUsage = "key encipherment"
+	CertificateUsageServerAuth       CertificateUsage = "server auth"
+	CertificateUsageClientAuth       CertificateUsage = "client auth"
+	CertificateUsageCodeSigning      CertificateUsage = "code signing"
+	CertificateUsageEmailProtection  CertificateUsage = "email protection"
+)
+
+// SelfSignedCertificate generates a new private key and a certificate for it
+// that is signed by the key itself. Both are returned PEM encoded.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={selfsignedcertificate},shortName=selfsignedcert
+type SelfSignedCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SelfSignedCertificateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SelfSignedCertificateList contains a list of SelfSignedCertificate resources.
+type SelfSignedCertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SelfSignedCertificate `json:"items"`
+}
@@ -0,0 +1,88 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SSHKeySpec controls the behavior of the ssh key generator.
+type SSHKeySpec struct {
+	// KeyType specifies the type of the generated key.
+	// Defaults to ed25519
+	// +kubebuilder:default=ed25519
+	// +optional
+	KeyType SSHKeyType `json:"keyType,omitempty"`
+
+	// KeySize specifies the size of the key.
+	// For rsa this is the number of bits, at least 2048, and defaults to 4096,
+	// for ecdsa this is the curve size (256, 384 or 521) and defaults to 256.
+	// It is ignored for ed25519 keys.
+	// +optional
+	KeySize *int `json:"keySize,omitempty"`
+
+	// PrivateKeyFormat specifies the encoding of the private key.
+	// Defaults to openssh
+	// +kubebuilder:default=openssh
+	// +optional
+	PrivateKeyFormat SSHKeyFormat `json:"privateKeyFormat,omitempty"`
+
+	// Comment is appended to the public key, e.g. user@host.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=ed25519;rsa;ecdsa
+type SSHKeyType string
+
+const (
+	SSHKeyTypeED25519 SSHKeyType = "ed25519"
+	SSHKeyTypeRSA     SSHKeyType = "rsa"
+	SSHKeyTypeECDSA   SSHKeyType = "ecdsa"
+)
+
+// +kubebuilder:validation:Enum=openssh;pkcs8
+type SSHKeyFormat string
+
+const (
+	// SSHKeyFormatOpenSSH encodes the private key in the OpenSSH format.
+	SSHKeyFormatOpenSSH SSHKeyFormat = "openssh"
+	// SSHKeyFormatPKCS8 encodes the private key as PEM encoded PKCS#8,
+	// the comment is not part of it.
+	SSHKeyFormatPKCS8 SSHKeyFormat = "pkcs8"
+)
+
+// SSHKey generates a new ssh keypair.
+// The private key is returned in OpenSSH or PKCS#8 format,
+// the public key in authorized_keys format.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={sshkey},shortName=sshkey
+type SSHKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SSHKeySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SSHKeyList contains a list of SSHKey resources.
+type SSHKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SSHKey `json:"items"`
+}
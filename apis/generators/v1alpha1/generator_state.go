@@ -0,0 +1,69 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GeneratorStateFinalizer is set on every GeneratorState and removed once
+	// the artifacts described by the state have been cleaned up.
+	GeneratorStateFinalizer = "generatorstate.generators.external-secrets.io/finalizer"
+
+	// LabelGeneratorStateOwner is set on every GeneratorState and contains the
+	// name of the ExternalSecret that consumes the generated secret.
+	LabelGeneratorStateOwner = "generators.external-secrets.io/owner"
+)
+
+// GeneratorStateSpec records what a generator produced.
+type GeneratorStateSpec struct {
+	// Resource is the generator manifest that produced the state.
+	// It is used to clean up the state.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Resource *apiextensions.JSON `json:"resource"`
+
+	// State is the generator specific state, e.g. the name of a Chef client
+	// that was created by the generator.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	State *apiextensions.JSON `json:"state"`
+}
+
+// GeneratorState tracks the artifacts a generator created outside of the cluster.
+// It is owned by the ExternalSecret that references the generator,
+// the artifacts are cleaned up when the GeneratorState is deleted.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={generatorstate},shortName=gs
+// +kubebuilder:printcolumn:name="Owner",type=string,JSONPath=`.metadata.labels.generators\.external-secrets\.io/owner`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type GeneratorState struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GeneratorStateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GeneratorStateList contains a list of GeneratorState resources.
+type GeneratorStateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GeneratorState `json:"items"`
+}
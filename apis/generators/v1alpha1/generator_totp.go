@@ -0,0 +1,89 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// TOTPSpec defines where to fetch the seed from and how to compute the code.
+type TOTPSpec struct {
+	// Used to select the correct ESO controller (think: ingress.ingressClassName)
+	// The ESO controller is instantiated with a specific controller name and filters VDS based on this property
+	// +optional
+	Controller string `json:"controller,omitempty"`
+
+	// SecretStoreRef references the store that contains the seed.
+	SecretStoreRef esv1beta1.SecretStoreRef `json:"secretStoreRef"`
+
+	// SeedRef references the base32 encoded seed in the store,
+	// e.g. a property of a Chef databag item.
+	// An otpauth:// URI is accepted as well.
+	SeedRef esv1beta1.ExternalSecretDataRemoteRef `json:"seedRef"`
+
+	// Algorithm is the HMAC algorithm used to compute the code.
+	// Defaults to SHA1
+	// +kubebuilder:default=SHA1
+	// +optional
+	Algorithm TOTPAlgorithm `json:"algorithm,omitempty"`
+
+	// Digits is the number of digits of the code.
+	// Defaults to 6
+	// +kubebuilder:default=6
+	// +kubebuilder:validation:Minimum=6
+	// +kubebuilder:validation:Maximum=8
+	// +optional
+	Digits int `json:"digits,omitempty"`
+
+	// Period is the number of seconds a code is valid.
+	// Defaults to 30
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Period int `json:"period,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=SHA1;SHA256;SHA512
+type TOTPAlgorithm string
+
+const (
+	TOTPAlgorithmSHA1   TOTPAlgorithm = "SHA1"
+	TOTPAlgorithmSHA256 TOTPAlgorithm = "SHA256"
+	TOTPAlgorithmSHA512 TOTPAlgorithm = "SHA512"
+)
+
+// TOTP computes a time-based one-time password (RFC 6238)
+// from a seed that is stored in a SecretStore.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={totp},shortName=totp
+type TOTP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TOTPSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TOTPList contains a list of TOTP resources.
+type TOTPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TOTP `json:"items"`
+}
@@ -84,6 +84,93 @@ var (
 	VaultDynamicSecretGroupVersionKind = SchemeGroupVersion.WithKind(VaultDynamicSecretKind)
 )
 
+// SSHKey type metadata.
+var (
+	SSHKeyKind             = reflect.TypeOf(SSHKey{}).Name()
+	SSHKeyGroupKind        = schema.GroupKind{Group: Group, Kind: SSHKeyKind}.String()
+	SSHKeyKindAPIVersion   = SSHKeyKind + "." + SchemeGroupVersion.String()
+	SSHKeyGroupVersionKind = SchemeGroupVersion.WithKind(SSHKeyKind)
+)
+
+// CSR type metadata.
+var (
+	CSRKind             = reflect.TypeOf(CSR{}).Name()
+	CSRGroupKind        = schema.GroupKind{Group: Group, Kind: CSRKind}.String()
+	CSRKindAPIVersion   = CSRKind + "." + SchemeGroupVersion.String()
+	CSRGroupVersionKind = SchemeGroupVersion.WithKind(CSRKind)
+)
+
+// ChefClientKey type metadata.
+var (
+	ChefClientKeyKind             = reflect.TypeOf(ChefClientKey{}).Name()
+	ChefClientKeyGroupKind        = schema.GroupKind{Group: Group, Kind: ChefClientKeyKind}.String()
+	ChefClientKeyKindAPIVersion   = ChefClientKeyKind + "." + SchemeGroupVersion.String()
+	ChefClientKeyGroupVersionKind = SchemeGroupVersion.WithKind(ChefClientKeyKind)
+)
+
+// ChefValidatorKey type metadata.
+var (
+	ChefValidatorKeyKind             = reflect.TypeOf(ChefValidatorKey{}).Name()
+	ChefValidatorKeyGroupKind        = schema.GroupKind{Group: Group, Kind: ChefValidatorKeyKind}.String()
+	ChefValidatorKeyKindAPIVersion   = ChefValidatorKeyKind + "." + SchemeGroupVersion.String()
+	ChefValidatorKeyGroupVersionKind = SchemeGroupVersion.WithKind(ChefValidatorKeyKind)
+)
+
+// TOTP type metadata.
+var (
+	TOTPKind             = reflect.TypeOf(TOTP{}).Name()
+	TOTPGroupKind        = schema.GroupKind{Group: Group, Kind: TOTPKind}.String()
+	TOTPKindAPIVersion   = TOTPKind + "." + SchemeGroupVersion.String()
+	TOTPGroupVersionKind = SchemeGroupVersion.WithKind(TOTPKind)
+)
+
+// Webhook type metadata.
+var (
+	WebhookKind             = reflect.TypeOf(Webhook{}).Name()
+	WebhookGroupKind        = schema.GroupKind{Group: Group, Kind: WebhookKind}.String()
+	WebhookKindAPIVersion   = WebhookKind + "." + SchemeGroupVersion.String()
+	WebhookGroupVersionKind = SchemeGroupVersion.WithKind(WebhookKind)
+)
+
+// GPGKey type metadata.
+var (
+	GPGKeyKind             = reflect.TypeOf(GPGKey{}).Name()
+	GPGKeyGroupKind        = schema.GroupKind{Group: Group, Kind: GPGKeyKind}.String()
+	GPGKeyKindAPIVersion   = GPGKeyKind + "." + SchemeGroupVersion.String()
+	GPGKeyGroupVersionKind = SchemeGroupVersion.WithKind(GPGKeyKind)
+)
+
+// SelfSignedCertificate type metadata.
+var (
+	SelfSignedCertificateKind             = reflect.TypeOf(SelfSignedCertificate{}).Name()
+	SelfSignedCertificateGroupKind        = schema.GroupKind{Group: Group, Kind: SelfSignedCertificateKind}.String()
+	SelfSignedCertificateKindAPIVersion   = SelfSignedCertificateKind + "." + SchemeGroupVersion.String()
+	SelfSignedCertificateGroupVersionKind = SchemeGroupVersion.WithKind(SelfSignedCertificateKind)
+)
+
+// Passphrase type metadata.
+var (
+	PassphraseKind             = reflect.TypeOf(Passphrase{}).Name()
+	PassphraseGroupKind        = schema.GroupKind{Group: Group, Kind: PassphraseKind}.String()
+	PassphraseKindAPIVersion   = PassphraseKind + "." + SchemeGroupVersion.String()
+	PassphraseGroupVersionKind = SchemeGroupVersion.WithKind(PassphraseKind)
+)
+
+// GeneratorState type metadata.
+var (
+	ArtifactoryAccessTokenKind             = reflect.TypeOf(ArtifactoryAccessToken{}).Name()
+	ArtifactoryAccessTokenGroupKind        = schema.GroupKind{Group: Group, Kind: ArtifactoryAccessTokenKind}.String()
+	ArtifactoryAccessTokenKindAPIVersion   = ArtifactoryAccessTokenKind + "." + SchemeGroupVersion.String()
+	ArtifactoryAccessTokenGroupVersionKind = SchemeGroupVersion.WithKind(ArtifactoryAccessTokenKind)
+)
+
+var (
+	GeneratorStateKind             = reflect.TypeOf(GeneratorState{}).Name()
+	GeneratorStateGroupKind        = schema.GroupKind{Group: Group, Kind: GeneratorStateKind}.String()
+	GeneratorStateKindAPIVersion   = GeneratorStateKind + "." + SchemeGroupVersion.String()
+	GeneratorStateGroupVersionKind = SchemeGroupVersion.WithKind(GeneratorStateKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ECRAuthorizationToken{}, &ECRAuthorizationToken{})
 	SchemeBuilder.Register(&GCRAccessToken{}, &GCRAccessTokenList{})
@@ -91,4 +178,15 @@ func init() {
 	SchemeBuilder.Register(&Fake{}, &FakeList{})
 	SchemeBuilder.Register(&VaultDynamicSecret{}, &VaultDynamicSecretList{})
 	SchemeBuilder.Register(&Password{}, &PasswordList{})
+	SchemeBuilder.Register(&SSHKey{}, &SSHKeyList{})
+	SchemeBuilder.Register(&CSR{}, &CSRList{})
+	SchemeBuilder.Register(&ChefClientKey{}, &ChefClientKeyList{})
+	SchemeBuilder.Register(&ChefValidatorKey{}, &ChefValidatorKeyList{})
+	SchemeBuilder.Register(&TOTP{}, &TOTPList{})
+	SchemeBuilder.Register(&Webhook{}, &WebhookList{})
+	SchemeBuilder.Register(&ArtifactoryAccessToken{}, &ArtifactoryAccessTokenList{})
+	SchemeBuilder.Register(&GPGKey{}, &GPGKeyList{})
+	SchemeBuilder.Register(&SelfSignedCertificate{}, &SelfSignedCertificateList{})
+	SchemeBuilder.Register(&Passphrase{}, &PassphraseList{})
+	SchemeBuilder.Register(&GeneratorState{}, &GeneratorStateList{})
 }
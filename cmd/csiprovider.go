@@ -0,0 +1,121 @@
+/*
+Copyright © 2022 ESO Maintainer team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/csi"
+	"github.com/external-secrets/external-secrets/pkg/csi/api/v1alpha1"
+)
+
+var csiEndpoint string
+
+var csiProviderCmd = &cobra.Command{
+	Use:   "csi-provider",
+	Short: "Provider for the Secrets Store CSI driver backed by SecretStores",
+	Long: `Provider for the Secrets Store CSI driver that mounts secrets of SecretStores and ClusterSecretStores into pods.
+	For more information visit https://external-secrets.io`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var lvl zapcore.Level
+		var enc zapcore.TimeEncoder
+		lvlErr := lvl.UnmarshalText([]byte(loglevel))
+		if lvlErr != nil {
+			setupLog.Error(lvlErr, "error unmarshalling loglevel")
+			os.Exit(1)
+		}
+		encErr := enc.UnmarshalText([]byte(zapTimeEncoding))
+		if encErr != nil {
+			setupLog.Error(encErr, "error unmarshalling timeEncoding")
+			os.Exit(1)
+		}
+		opts := zap.Options{
+			Level:       lvl,
+			TimeEncoder: enc,
+		}
+		logger := zap.New(zap.UseFlagOptions(&opts))
+		ctrl.SetLogger(logger)
+		if err := esv1beta1.SetAllowedProviderEndpoints(allowedProviderEndpoints); err != nil {
+			setupLog.Error(err, "invalid allowed provider endpoints")
+			os.Exit(1)
+		}
+		if err := esv1beta1.EnableProviders(enabledProviders); err != nil {
+			setupLog.Error(err, "invalid providers")
+			os.Exit(1)
+		}
+		config := ctrl.GetConfigOrDie()
+		config.QPS = clientQPS
+		config.Burst = clientBurst
+		// mounts are rare compared to reconciles, an uncached client
+		// avoids watching all stores and secrets on every node.
+		kubeClient, err := client.New(config, client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create client")
+			os.Exit(1)
+		}
+
+		// the socket of a previous run is left behind if the pod was killed.
+		if err := os.Remove(csiEndpoint); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			setupLog.Error(err, "unable to remove socket", "endpoint", csiEndpoint)
+			os.Exit(1)
+		}
+		lis, err := net.Listen("unix", csiEndpoint)
+		if err != nil {
+			setupLog.Error(err, "unable to listen", "endpoint", csiEndpoint)
+			os.Exit(1)
+		}
+		srv := grpc.NewServer()
+		v1alpha1.RegisterCSIDriverProviderServer(srv, csi.NewServer(kubeClient, controllerClass, enableFloodGate))
+
+		ctx := ctrl.SetupSignalHandler()
+		go func(ctx context.Context) {
+			<-ctx.Done()
+			srv.GracefulStop()
+		}(ctx)
+		setupLog.Info("starting csi provider", "endpoint", csiEndpoint)
+		if err := srv.Serve(lis); err != nil {
+			setupLog.Error(err, "problem running csi provider")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(csiProviderCmd)
+
+	csiProviderCmd.Flags().StringVar(&csiEndpoint, "endpoint", "/provider/external-secrets.sock", "Unix socket the provider listens on. The driver expects it in its providers directory, /etc/kubernetes/secrets-store-csi-providers by default.")
+	csiProviderCmd.Flags().StringVar(&controllerClass, "controller-class", "default", "Only stores of this controller class are used")
+	csiProviderCmd.Flags().BoolVar(&enableFloodGate, "enable-flood-gate", true, "Only use stores that have a healthy or unknown state.")
+	csiProviderCmd.Flags().StringSliceVar(&allowedProviderEndpoints, "allowed-provider-endpoints", nil, "Comma separated hosts SecretStores and ClusterSecretStores may connect to, e.g. *.chef.internal.example.com,vault.example.com:8200. A leading *. matches any subdomain. All endpoints are allowed if not set.")
+	csiProviderCmd.Flags().StringSliceVar(&enabledProviders, "providers", nil, "Comma separated providers registered in the controller, e.g. chef,kubernetes. Stores of other providers fail as disabled. All providers compiled into the binary are registered if not set.")
+	csiProviderCmd.Flags().Float32Var(&clientQPS, "client-qps", 0, "QPS configuration to be passed to rest.Client")
+	csiProviderCmd.Flags().IntVar(&clientBurst, "client-burst", 0, "Maximum Burst allowed to be passed to rest.Client")
+	csiProviderCmd.Flags().StringVar(&loglevel, "loglevel", "info", "loglevel to use, one of: debug, info, warn, error, dpanic, panic, fatal")
+	csiProviderCmd.Flags().StringVar(&zapTimeEncoding, "zap-time-encoding", "epoch", "Zap time encoding (one of 'epoch', 'millis', 'nano', 'iso8601', 'rfc3339' or 'rfc3339nano')")
+}
@@ -0,0 +1,341 @@
+/*
+Copyright © 2022 ESO Maintainer team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chef/chef"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	chefprovider "github.com/external-secrets/external-secrets/pkg/provider/chef"
+)
+
+const fieldOwner = "kubectl-eso"
+
+var (
+	errNoChefStore        = errors.New("no SecretStore or ClusterSecretStore with the chef provider found in the manifests")
+	errMultipleChefStores = errors.New("more than one store with the chef provider found in the manifests, select one with --store")
+	errMissingKeyNS       = errors.New("the private key of a ClusterSecretStore must set a namespace")
+
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+	invalidKeyChars  = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+	// gjson path characters that must be escaped in a property name.
+	gjsonSpecialChars = strings.NewReplacer(`\`, `\\`, `.`, `\.`, `*`, `\*`, `?`, `\?`, `|`, `\|`, `#`, `\#`, `@`, `\@`)
+)
+
+// databagAPI is the subset of the Chef data bags API used to walk an
+// organization.
+type databagAPI interface {
+	List() (*chef.DataBagListResult, error)
+	ListItems(name string) (*chef.DataBagListResult, error)
+	GetItem(databagName string, databagItem string) (chef.DataBagItem, error)
+}
+
+type chefImportOptions struct {
+	files           []string
+	namespace       string
+	store           string
+	databags        []string
+	refreshInterval time.Duration
+	withSecrets     bool
+	apply           bool
+	output          string
+}
+
+func newChefImportCmd() *cobra.Command {
+	o := &chefImportOptions{}
+	cmd := &cobra.Command{
+		Use:   "chef-import -f MANIFEST...",
+		Short: "Generate ExternalSecrets for the data bags of a Chef organization",
+		Long: `Walk the data bags of the Chef organization of a store and generate an ExternalSecret for every
+data bag item, with a key for every property of the item.
+
+The manifests must contain the SecretStore or ClusterSecretStore with the chef provider and the Secret
+with the private key of its user. The ExternalSecrets are printed, or applied to the cluster of the
+current kubeconfig context with --apply.`,
+		Example: `  kubectl eso chef-import -f chef-store.yaml -f chef-user-secret.yaml --databag app --databag db > externalsecrets.yaml`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctrl.SetLogger(zap.New(zap.WriteTo(cmd.ErrOrStderr())))
+			return o.run(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	cmd.Flags().StringArrayVarP(&o.files, "filename", "f", nil, "Manifests to read, - reads from stdin")
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "default", "Namespace of the manifests that do not set one and of the generated ExternalSecrets if the store is a ClusterSecretStore")
+	cmd.Flags().StringVar(&o.store, "store", "", "Name of the store to use if the manifests contain more than one chef store")
+	cmd.Flags().StringArrayVar(&o.databags, "databag", nil, "Data bags to import, all data bags of the organization if not set")
+	cmd.Flags().DurationVar(&o.refreshInterval, "refresh-interval", time.Hour, "refreshInterval of the generated ExternalSecrets")
+	cmd.Flags().BoolVar(&o.withSecrets, "with-secrets", false, "Also generate the target Secrets with the current data, so workloads can use them before the ExternalSecrets are synced")
+	cmd.Flags().BoolVar(&o.apply, "apply", false, "Apply the generated resources to the cluster instead of printing them")
+	cmd.Flags().StringVarP(&o.output, "output", "o", outputYAML, "Output format, one of: yaml, json")
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+func (o *chefImportOptions) run(ctx context.Context, stdin io.Reader, out, errOut io.Writer) error {
+	if o.output != outputYAML && o.output != outputJSON {
+		return fmt.Errorf("unknown output format %q", o.output)
+	}
+	scheme := newScheme()
+	objs, err := readFiles(scheme, stdin, o.files, o.namespace)
+	if err != nil {
+		return err
+	}
+	store, err := findChefStore(objs, o.store)
+	if err != nil {
+		return err
+	}
+	keyNamespace := store.GetNamespace()
+	chefSpec := store.GetSpec().Provider.Chef
+	if store.GetKind() == esv1beta1.ClusterSecretStoreKind {
+		if chefSpec.Auth == nil || chefprovider.CredentialsRef(chefSpec.Auth).Namespace == nil {
+			return errMissingKeyNS
+		}
+		keyNamespace = *chefprovider.CredentialsRef(chefSpec.Auth).Namespace
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	chefClient, err := chefprovider.NewGeneratorClient(ctx, kube, chefSpec, keyNamespace)
+	if err != nil {
+		return err
+	}
+
+	// a SecretStore can only be used by ExternalSecrets of its namespace.
+	namespace := o.namespace
+	if store.GetKind() == esv1beta1.SecretStoreKind {
+		namespace = store.GetNamespace()
+	}
+	storeRef := esv1beta1.SecretStoreRef{Name: store.GetName(), Kind: store.GetKind()}
+	generated, err := o.generate(chefClient.DataBags, storeRef, namespace, errOut)
+	if err != nil {
+		return err
+	}
+	if o.apply {
+		cfg, err := ctrl.GetConfig()
+		if err != nil {
+			return err
+		}
+		target, err := client.New(cfg, client.Options{Scheme: scheme})
+		if err != nil {
+			return err
+		}
+		return applyObjects(ctx, target, generated, out)
+	}
+	for i, obj := range generated {
+		if err := printObject(out, o.output, obj, i > 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findChefStore(objs []client.Object, name string) (esv1beta1.GenericStore, error) {
+	var found []esv1beta1.GenericStore
+	for _, obj := range objs {
+		store, ok := obj.(esv1beta1.GenericStore)
+		if !ok || store.GetSpec().Provider == nil || store.GetSpec().Provider.Chef == nil {
+			continue
+		}
+		if name == "" || store.GetName() == name {
+			found = append(found, store)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, errNoChefStore
+	case 1:
+		return found[0], nil
+	default:
+		return nil, errMultipleChefStores
+	}
+}
+
+// generate returns an ExternalSecret, and the Secret if requested, for every
+// item of the data bags. Items and properties that can not be fetched with
+// the chef provider are skipped with a warning.
+func (o *chefImportOptions) generate(api databagAPI, storeRef esv1beta1.SecretStoreRef, namespace string, errOut io.Writer) ([]client.Object, error) {
+	databags := o.databags
+	if len(databags) == 0 {
+		list, err := api.List()
+		if err != nil {
+			return nil, fmt.Errorf("could not list data bags: %w", err)
+		}
+		databags = sortedKeys(*list)
+	}
+
+	var objs []client.Object
+	names := make(map[string]string)
+	for _, databag := range databags {
+		items, err := api.ListItems(databag)
+		if err != nil {
+			return nil, fmt.Errorf("could not list items of data bag %s: %w", databag, err)
+		}
+		for _, item := range sortedKeys(*items) {
+			key := databag + "/" + item
+			raw, err := api.GetItem(databag, item)
+			if err != nil {
+				return nil, fmt.Errorf("could not get data bag item %s: %w", key, err)
+			}
+			values, ok := raw.(map[string]interface{})
+			if !ok {
+				fmt.Fprintf(errOut, "skipping %s: item is not an object\n", key)
+				continue
+			}
+			if isEncrypted(values) {
+				fmt.Fprintf(errOut, "skipping %s: encrypted data bag items are not supported\n", key)
+				continue
+			}
+			name := resourceName(databag, item)
+			if other, ok := names[name]; ok {
+				return nil, fmt.Errorf("data bag items %s and %s both map to the name %s", other, key, name)
+			}
+			names[name] = key
+
+			es, secret := o.itemResources(name, namespace, key, values, storeRef, errOut)
+			if len(es.Spec.Data) == 0 {
+				fmt.Fprintf(errOut, "skipping %s: item has no string properties\n", key)
+				continue
+			}
+			objs = append(objs, es)
+			if o.withSecrets {
+				objs = append(objs, secret)
+			}
+		}
+	}
+	return objs, nil
+}
+
+func (o *chefImportOptions) itemResources(name, namespace, key string, values map[string]interface{}, storeRef esv1beta1.SecretStoreRef, errOut io.Writer) (*esv1beta1.ExternalSecret, *corev1.Secret) {
+	es := &esv1beta1.ExternalSecret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: esv1beta1.SchemeGroupVersion.String(),
+			Kind:       esv1beta1.ExtSecretKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: esv1beta1.ExternalSecretSpec{
+			RefreshInterval: &metav1.Duration{Duration: o.refreshInterval},
+			SecretStoreRef:  storeRef,
+			Target: esv1beta1.ExternalSecretTarget{
+				Name:           name,
+				CreationPolicy: esv1beta1.CreatePolicyOwner,
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: make(map[string][]byte),
+	}
+	for _, property := range sortedKeys(values) {
+		// the id is the name of the item, not a secret.
+		if property == "id" {
+			continue
+		}
+		value, ok := values[property].(string)
+		if !ok {
+			fmt.Fprintf(errOut, "skipping property %s of %s: only string properties can be fetched\n", property, key)
+			continue
+		}
+		secretKey := invalidKeyChars.ReplaceAllString(property, "_")
+		if _, ok := secret.Data[secretKey]; ok {
+			fmt.Fprintf(errOut, "skipping property %s of %s: key %s is already used\n", property, key, secretKey)
+			continue
+		}
+		es.Spec.Data = append(es.Spec.Data, esv1beta1.ExternalSecretData{
+			SecretKey: secretKey,
+			RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{
+				Key:      key,
+				Property: gjsonSpecialChars.Replace(property),
+			},
+		})
+		secret.Data[secretKey] = []byte(value)
+	}
+	return es, secret
+}
+
+// applyObjects applies the objects with server-side apply.
+func applyObjects(ctx context.Context, c client.Client, objs []client.Object, out io.Writer) error {
+	for _, obj := range objs {
+		if err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldOwner), client.ForceOwnership); err != nil {
+			return fmt.Errorf("could not apply %s %s/%s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName(), err)
+		}
+		fmt.Fprintf(out, "%s/%s applied\n", strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind), obj.GetName())
+	}
+	return nil
+}
+
+// isEncrypted reports whether the item was encrypted with knife, its values
+// are then objects with the encrypted data.
+func isEncrypted(values map[string]interface{}) bool {
+	for k, v := range values {
+		if k == "id" {
+			continue
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m["encrypted_data"]; !ok {
+			return false
+		}
+	}
+	return len(values) > 1
+}
+
+// resourceName returns a DNS-1123 name for a data bag item, data bag and
+// item names may contain underscores and upper case characters.
+func resourceName(databag, item string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(databag+"-"+item), "-")
+	name = strings.Trim(name, "-")
+	if len(name) > validation.DNS1123SubdomainMaxLength {
+		name = strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength], "-")
+	}
+	return name
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
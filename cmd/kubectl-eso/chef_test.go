@@ -0,0 +1,170 @@
+/*
+Copyright © 2022 ESO Maintainer team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-chef/chef"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+type fakeDatabags map[string]map[string]chef.DataBagItem
+
+func (f fakeDatabags) List() (*chef.DataBagListResult, error) {
+	res := chef.DataBagListResult{}
+	for name := range f {
+		res[name] = "https://chef.example.com/data/" + name
+	}
+	return &res, nil
+}
+
+func (f fakeDatabags) ListItems(name string) (*chef.DataBagListResult, error) {
+	items, ok := f[name]
+	if !ok {
+		return nil, errors.New("404 not found")
+	}
+	res := chef.DataBagListResult{}
+	for item := range items {
+		res[item] = "https://chef.example.com/data/" + name + "/" + item
+	}
+	return &res, nil
+}
+
+func (f fakeDatabags) GetItem(name, item string) (chef.DataBagItem, error) {
+	return f[name][item], nil
+}
+
+func TestChefImportGenerate(t *testing.T) {
+	databags := fakeDatabags{
+		"app_secrets": {
+			"Database": map[string]interface{}{
+				"id":         "Database",
+				"password":   "hunter2",
+				"user.name":  "app",
+				"port":       float64(5432),
+				"tls config": "on",
+			},
+			"vault": map[string]interface{}{
+				"id": "vault",
+				"token": map[string]interface{}{
+					"encrypted_data": "abc",
+					"iv":             "def",
+					"version":        float64(1),
+					"cipher":         "aes-256-cbc",
+				},
+			},
+		},
+		"empty": {
+			"numbers": map[string]interface{}{"id": "numbers", "count": float64(3)},
+		},
+	}
+	storeRef := esv1beta1.SecretStoreRef{Name: "chef", Kind: esv1beta1.ClusterSecretStoreKind}
+	o := &chefImportOptions{refreshInterval: time.Hour, withSecrets: true}
+
+	var warnings bytes.Buffer
+	objs, err := o.generate(databags, storeRef, "apps", &warnings)
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+
+	es := objs[0].(*esv1beta1.ExternalSecret)
+	assert.Equal(t, "app-secrets-database", es.Name)
+	assert.Equal(t, "apps", es.Namespace)
+	assert.Equal(t, storeRef, es.Spec.SecretStoreRef)
+	assert.Equal(t, &metav1.Duration{Duration: time.Hour}, es.Spec.RefreshInterval)
+	assert.Equal(t, []esv1beta1.ExternalSecretData{
+		{SecretKey: "password", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "app_secrets/Database", Property: "password"}},
+		{SecretKey: "tls_config", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "app_secrets/Database", Property: "tls config"}},
+		{SecretKey: "user.name", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "app_secrets/Database", Property: `user\.name`}},
+	}, es.Spec.Data)
+
+	secret := objs[1].(*corev1.Secret)
+	assert.Equal(t, "app-secrets-database", secret.Name)
+	assert.Equal(t, map[string][]byte{
+		"password":   []byte("hunter2"),
+		"tls_config": []byte("on"),
+		"user.name":  []byte("app"),
+	}, secret.Data)
+
+	assert.Contains(t, warnings.String(), "skipping property port of app_secrets/Database")
+	assert.Contains(t, warnings.String(), "skipping app_secrets/vault: encrypted data bag items are not supported")
+	assert.Contains(t, warnings.String(), "skipping empty/numbers: item has no string properties")
+}
+
+func TestChefImportGenerateSelectedDatabags(t *testing.T) {
+	databags := fakeDatabags{
+		"a": {"x": map[string]interface{}{"id": "x", "k": "v"}},
+		"b": {"y": map[string]interface{}{"id": "y", "k": "v"}},
+	}
+	o := &chefImportOptions{databags: []string{"b"}}
+	objs, err := o.generate(databags, esv1beta1.SecretStoreRef{Name: "chef"}, "default", &bytes.Buffer{})
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, "b-y", objs[0].GetName())
+
+	o.databags = []string{"missing"}
+	_, err = o.generate(databags, esv1beta1.SecretStoreRef{Name: "chef"}, "default", &bytes.Buffer{})
+	assert.ErrorContains(t, err, "could not list items of data bag missing")
+}
+
+func TestChefImportNameCollision(t *testing.T) {
+	databags := fakeDatabags{
+		"app": {
+			"db_main": map[string]interface{}{"id": "db_main", "k": "v"},
+			"db-main": map[string]interface{}{"id": "db-main", "k": "v"},
+		},
+	}
+	o := &chefImportOptions{}
+	_, err := o.generate(databags, esv1beta1.SecretStoreRef{Name: "chef"}, "default", &bytes.Buffer{})
+	assert.ErrorContains(t, err, "both map to the name app-db-main")
+}
+
+func TestFindChefStore(t *testing.T) {
+	chefStore := func(name string) *esv1beta1.SecretStore {
+		return &esv1beta1.SecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Chef: &esv1beta1.ChefProvider{}}},
+		}
+	}
+	vault := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault"},
+		Spec:       esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Vault: &esv1beta1.VaultProvider{}}},
+	}
+
+	_, err := findChefStore([]client.Object{vault}, "")
+	assert.ErrorIs(t, err, errNoChefStore)
+
+	store, err := findChefStore([]client.Object{vault, chefStore("a")}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "a", store.GetName())
+
+	_, err = findChefStore([]client.Object{chefStore("a"), chefStore("b")}, "")
+	assert.ErrorIs(t, err, errMultipleChefStores)
+
+	store, err = findChefStore([]client.Object{chefStore("a"), chefStore("b")}, "b")
+	require.NoError(t, err)
+	assert.Equal(t, "b", store.GetName())
+}
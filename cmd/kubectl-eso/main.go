@@ -0,0 +1,38 @@
+/*
+Copyright © 2022 ESO Maintainer team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-eso is a kubectl plugin to debug ExternalSecrets locally.
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:          "kubectl-eso",
+		Short:        "Debug ExternalSecrets locally",
+		Long:         `kubectl plugin to debug ExternalSecrets locally. For more information visit https://external-secrets.io`,
+		SilenceUsage: true,
+	}
+	rootCmd.AddCommand(newRenderCmd())
+	rootCmd.AddCommand(newChefImportCmd())
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
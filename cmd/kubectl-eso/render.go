@@ -0,0 +1,238 @@
+/*
+Copyright © 2022 ESO Maintainer team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
+)
+
+const (
+	outputYAML = "yaml"
+	outputJSON = "json"
+)
+
+var errNoExternalSecrets = errors.New("no ExternalSecret found in the manifests")
+
+// clusterScoped are the kinds that are not defaulted to the namespace.
+var clusterScoped = map[string]bool{
+	"Namespace":                      true,
+	esv1beta1.ClusterSecretStoreKind: true,
+	esv1beta1.ClusterExtSecretKind:   true,
+}
+
+type renderOptions struct {
+	files           []string
+	namespace       string
+	controllerClass string
+	output          string
+	decode          bool
+}
+
+func newRenderCmd() *cobra.Command {
+	o := &renderOptions{}
+	cmd := &cobra.Command{
+		Use:   "render -f MANIFEST...",
+		Short: "Fetch the data of ExternalSecrets and print the resulting Secrets",
+		Long: `Fetch the data of the ExternalSecrets in the manifests from their stores and print the Secrets
+the controller would write, without applying anything to the cluster.
+
+The manifests must contain the ExternalSecrets, their SecretStores or ClusterSecretStores and the
+Secrets and ConfigMaps the stores and templates reference. Nothing is read from the cluster.`,
+		Example: `  kubectl eso render -f externalsecret.yaml -f secretstore.yaml --decode`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctrl.SetLogger(zap.New(zap.WriteTo(cmd.ErrOrStderr())))
+			return o.run(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringArrayVarP(&o.files, "filename", "f", nil, "Manifests to read, - reads from stdin")
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "default", "Namespace of the manifests that do not set one")
+	cmd.Flags().StringVar(&o.controllerClass, "controller-class", "", "Controller class of the stores to use")
+	cmd.Flags().StringVarP(&o.output, "output", "o", outputYAML, "Output format, one of: yaml, json")
+	cmd.Flags().BoolVar(&o.decode, "decode", false, "Print the data of the Secrets as stringData if it is valid UTF-8")
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+func (o *renderOptions) run(ctx context.Context, stdin io.Reader, out io.Writer) error {
+	if o.output != outputYAML && o.output != outputJSON {
+		return fmt.Errorf("unknown output format %q", o.output)
+	}
+	scheme := newScheme()
+	objs, err := readFiles(scheme, stdin, o.files, o.namespace)
+	if err != nil {
+		return err
+	}
+
+	var externalSecrets []*esv1beta1.ExternalSecret
+	for _, obj := range objs {
+		if es, ok := obj.(*esv1beta1.ExternalSecret); ok {
+			externalSecrets = append(externalSecrets, es)
+		}
+	}
+	if len(externalSecrets) == 0 {
+		return errNoExternalSecrets
+	}
+
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	for i, es := range externalSecrets {
+		secret, err := externalsecret.Render(ctx, kube, o.controllerClass, es)
+		if err != nil {
+			return fmt.Errorf("could not render ExternalSecret %s/%s: %w", es.Namespace, es.Name, err)
+		}
+		if o.decode {
+			decodeData(secret)
+		}
+		if err := printObject(out, o.output, secret, i > 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printObject prints obj in the given output format. YAML documents are
+// separated with --- if separate is set.
+func printObject(out io.Writer, output string, obj runtime.Object, separate bool) error {
+	var raw []byte
+	var err error
+	if output == outputJSON {
+		raw, err = json.MarshalIndent(obj, "", "    ")
+		raw = append(raw, '\n')
+	} else {
+		raw, err = yaml.Marshal(obj)
+		if separate {
+			raw = append([]byte("---\n"), raw...)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(raw)
+	return err
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+	return scheme
+}
+
+// readFiles reads the objects of the manifests, - reads from stdin.
+// Objects without a namespace are put in the given namespace.
+func readFiles(scheme *runtime.Scheme, stdin io.Reader, files []string, namespace string) ([]client.Object, error) {
+	var objs []client.Object
+	for _, name := range files {
+		fileObjs, err := readFile(scheme, stdin, name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", name, err)
+		}
+		objs = append(objs, fileObjs...)
+	}
+	return objs, nil
+}
+
+func readFile(scheme *runtime.Scheme, stdin io.Reader, name, namespace string) ([]client.Object, error) {
+	if name == "-" {
+		return readObjects(scheme, stdin, namespace)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readObjects(scheme, f, namespace)
+}
+
+// readObjects decodes the YAML or JSON documents of r. Documents of kinds
+// that are not known are skipped.
+func readObjects(scheme *runtime.Scheme, r io.Reader, namespace string) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	var objs []client.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		doc, err = utilyaml.ToJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+		if string(doc) == "null" {
+			continue
+		}
+		obj, gvk, err := decoder.Decode(doc, nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			fmt.Fprintf(os.Stderr, "skipping %s\n", gvk)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		cobj, ok := obj.(client.Object)
+		if !ok {
+			continue
+		}
+		if cobj.GetNamespace() == "" && !clusterScoped[gvk.Kind] {
+			cobj.SetNamespace(namespace)
+		}
+		// the fake client refuses to create objects with a resource version.
+		cobj.SetResourceVersion("")
+		objs = append(objs, cobj)
+	}
+}
+
+// decodeData moves the values of the secret that are valid UTF-8 to
+// stringData, so they are printed in plain text.
+func decodeData(secret *corev1.Secret) {
+	for k, v := range secret.Data {
+		if !utf8.Valid(v) {
+			continue
+		}
+		if secret.StringData == nil {
+			secret.StringData = make(map[string]string)
+		}
+		secret.StringData[k] = string(v)
+		delete(secret.Data, k)
+	}
+}
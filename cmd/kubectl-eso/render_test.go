@@ -0,0 +1,85 @@
+/*
+Copyright © 2022 ESO Maintainer team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const manifests = `
+apiVersion: external-secrets.io/v1beta1
+kind: SecretStore
+metadata:
+  name: fake
+spec:
+  provider:
+    fake:
+      data:
+        - key: credentials/chef-client
+          value: '{"key":"client-key","user":"eso"}'
+---
+apiVersion: external-secrets.io/v1beta1
+kind: ExternalSecret
+metadata:
+  name: chef-client
+spec:
+  secretStoreRef:
+    name: fake
+  target:
+    template:
+      engineVersion: v2
+      data:
+        client.rb: 'node_name "{{ .user }}"'
+  data:
+    - secretKey: user
+      remoteRef:
+        key: credentials/chef-client
+        property: user
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unrelated
+`
+
+func TestRender(t *testing.T) {
+	var out bytes.Buffer
+	o := &renderOptions{files: []string{"-"}, namespace: "chef", output: outputYAML, decode: true}
+	require.NoError(t, o.run(context.Background(), strings.NewReader(manifests), &out))
+	assert.Contains(t, out.String(), "namespace: chef\n")
+	assert.Contains(t, out.String(), "client.rb: node_name \"eso\"\n")
+}
+
+func TestRenderErrors(t *testing.T) {
+	o := &renderOptions{files: []string{"-"}, namespace: "default", output: outputYAML}
+	err := o.run(context.Background(), strings.NewReader("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: x\n"), &bytes.Buffer{})
+	assert.ErrorIs(t, err, errNoExternalSecrets)
+
+	missingStore := manifests[strings.Index(manifests, "---")+4:]
+	err = o.run(context.Background(), strings.NewReader(missingStore), &bytes.Buffer{})
+	assert.ErrorContains(t, err, "could not render ExternalSecret default/chef-client")
+
+	o.output = "table"
+	err = o.run(context.Background(), strings.NewReader(manifests), &bytes.Buffer{})
+	assert.ErrorContains(t, err, "unknown output format")
+}
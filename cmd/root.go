@@ -39,10 +39,13 @@ import (
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret/cesmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/generatorstate"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret/psmetrics"
@@ -63,6 +66,7 @@ var (
 	enableLeaderElection                  bool
 	enableSecretsCache                    bool
 	enableConfigMapsCache                 bool
+	enableManagedSecretsCache             bool
 	concurrent                            int
 	port                                  int
 	clientQPS                             float32
@@ -73,8 +77,31 @@ var (
 	enableClusterStoreReconciler          bool
 	enableClusterExternalSecretReconciler bool
 	enablePushSecretReconciler            bool
+	enableClusterPushSecretReconciler     bool
 	enableFloodGate                       bool
 	enableExtendedMetricLabels            bool
+	externalSecretMetricsCardinality      string
+	enableAuditLog                        bool
+	auditOTLPEndpoint                     string
+	allowedProviderEndpoints              []string
+	enabledProviders                      []string
+	enableOnlineStoreValidation           bool
+	enableWorkloadReload                  bool
+	enableLazySync                        bool
+	enableNamespaceOptOut                 bool
+	enableChecksumAnnotations             bool
+	enableSyncPolicies                    bool
+	enableStoreHealthChecks               bool
+	startupSyncQPS                        float32
+	startupSyncBurst                      int
+	freshnessThreshold                    time.Duration
+	offlineThreshold                      time.Duration
+	eventAggregationInterval              time.Duration
+	providerBatchWindow                   time.Duration
+	providerCacheDir                      string
+	providerCacheKeyFile                  string
+	providerCacheMaxAge                   time.Duration
+	refreshJitter                         float64
 	storeRequeueInterval                  time.Duration
 	serviceName, serviceNamespace         string
 	secretName, secretNamespace           string
@@ -134,7 +161,19 @@ var rootCmd = &cobra.Command{
 		}
 		logger := zap.New(zap.UseFlagOptions(&opts))
 		ctrl.SetLogger(logger)
+		if err := esv1beta1.SetAllowedProviderEndpoints(allowedProviderEndpoints); err != nil {
+			setupLog.Error(err, "invalid allowed provider endpoints")
+			os.Exit(1)
+		}
+		if err := esv1beta1.EnableProviders(enabledProviders); err != nil {
+			setupLog.Error(err, "invalid providers")
+			os.Exit(1)
+		}
 		ctrlmetrics.SetUpLabelNames(enableExtendedMetricLabels)
+		if err := esmetrics.SetCardinality(externalSecretMetricsCardinality); err != nil {
+			setupLog.Error(err, "invalid externalsecret metrics cardinality")
+			os.Exit(1)
+		}
 		esmetrics.SetUpMetrics()
 		config := ctrl.GetConfigOrDie()
 		config.QPS = clientQPS
@@ -155,6 +194,18 @@ var rootCmd = &cobra.Command{
 			LeaderElection:   enableLeaderElection,
 			LeaderElectionID: "external-secrets-controller",
 		}
+		// the Secrets are read without cache, so only the owned Secrets
+		// the controller watches need to be cached.
+		if !enableSecretsCache && enableManagedSecretsCache {
+			managedSecrets, err := externalsecret.ManagedSecretsCache()
+			if err != nil {
+				setupLog.Error(err, "unable to set up secrets cache")
+				os.Exit(1)
+			}
+			ctrlOpts.Cache.ByObject = map[client.Object]cache.ByObject{
+				&v1.Secret{}: managedSecrets,
+			}
+		}
 		if namespace != "" {
 			ctrlOpts.Cache.DefaultNamespaces = map[string]cache.Config{
 				namespace: {},
@@ -179,6 +230,17 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, errCreateController, "controller", "SecretStore")
 			os.Exit(1)
 		}
+		if enableStoreHealthChecks {
+			if err = (&secretstore.HealthCheckReconciler{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("controllers").WithName("SecretStoreHealthCheck"),
+				ControllerClass: controllerClass,
+				Kind:            esv1beta1.SecretStoreKind,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, errCreateController, "controller", "SecretStoreHealthCheck")
+				os.Exit(1)
+			}
+		}
 		if enableClusterStoreReconciler {
 			cssmetrics.SetUpMetrics()
 			if err = (&secretstore.ClusterStoreReconciler{
@@ -191,6 +253,56 @@ var rootCmd = &cobra.Command{
 				setupLog.Error(err, errCreateController, "controller", "ClusterSecretStore")
 				os.Exit(1)
 			}
+			if enableStoreHealthChecks {
+				if err = (&secretstore.HealthCheckReconciler{
+					Client:          mgr.GetClient(),
+					Log:             ctrl.Log.WithName("controllers").WithName("ClusterSecretStoreHealthCheck"),
+					ControllerClass: controllerClass,
+					Kind:            esv1beta1.ClusterSecretStoreKind,
+				}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, errCreateController, "controller", "ClusterSecretStoreHealthCheck")
+					os.Exit(1)
+				}
+			}
+		}
+		var auditor *audit.Auditor
+		if enableAuditLog {
+			var sink audit.Sink
+			if auditOTLPEndpoint != "" {
+				headers, err := audit.OTLPHeadersFromEnv()
+				if err != nil {
+					setupLog.Error(err, "unable to create audit sink")
+					os.Exit(1)
+				}
+				otlpSink, err := audit.NewOTLPSink(auditOTLPEndpoint, headers, ctrl.Log.WithName("audit"))
+				if err != nil {
+					setupLog.Error(err, "unable to create audit sink")
+					os.Exit(1)
+				}
+				if err := mgr.Add(otlpSink); err != nil {
+					setupLog.Error(err, "unable to add audit sink")
+					os.Exit(1)
+				}
+				sink = otlpSink
+			}
+			auditor = audit.New(ctrl.Log.WithName("audit"), sink)
+		}
+		var batcher *secretstore.Batcher
+		if providerBatchWindow > 0 {
+			batcher = secretstore.NewBatcher(providerBatchWindow)
+		}
+		var diskCache *secretstore.DiskCache
+		if providerCacheDir != "" {
+			key, err := secretstore.ReadDiskCacheKey(providerCacheKeyFile)
+			if err != nil {
+				setupLog.Error(err, "unable to read provider cache key")
+				os.Exit(1)
+			}
+			diskCache, err = secretstore.NewDiskCache(providerCacheDir, key, providerCacheMaxAge)
+			if err != nil {
+				setupLog.Error(err, "unable to create provider cache")
+				os.Exit(1)
+			}
 		}
 		if err = (&externalsecret.Reconciler{
 			Client:                    mgr.GetClient(),
@@ -201,12 +313,34 @@ var rootCmd = &cobra.Command{
 			RequeueInterval:           time.Hour,
 			ClusterSecretStoreEnabled: enableClusterStoreReconciler,
 			EnableFloodGate:           enableFloodGate,
+			EnableWorkloadReload:      enableWorkloadReload,
+			EnableLazySync:            enableLazySync,
+			EnableNamespaceOptOut:     enableNamespaceOptOut,
+			EnableChecksumAnnotations: enableChecksumAnnotations,
+			EnableSyncPolicies:        enableSyncPolicies,
+			FreshnessThreshold:        freshnessThreshold,
+			OfflineThreshold:          offlineThreshold,
+			EventAggregationInterval:  eventAggregationInterval,
+			Batcher:                   batcher,
+			DiskCache:                 diskCache,
+			RefreshJitter:             refreshJitter,
+			APIReader:                 mgr.GetAPIReader(),
+			Auditor:                   auditor,
+			StartupLimiter:            externalsecret.NewStartupLimiter(startupSyncQPS, startupSyncBurst),
 		}).SetupWithManager(mgr, controller.Options{
 			MaxConcurrentReconciles: concurrent,
 		}); err != nil {
 			setupLog.Error(err, errCreateController, "controller", "ExternalSecret")
 			os.Exit(1)
 		}
+		if err = (&generatorstate.Reconciler{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("controllers").WithName("GeneratorState"),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, errCreateController, "controller", "GeneratorState")
+			os.Exit(1)
+		}
 		if enablePushSecretReconciler {
 			psmetrics.SetUpMetrics()
 			if err = (&pushsecret.Reconciler{
@@ -235,6 +369,19 @@ var rootCmd = &cobra.Command{
 				os.Exit(1)
 			}
 		}
+		if enableClusterPushSecretReconciler {
+			if err = (&clusterpushsecret.Reconciler{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("controllers").WithName("ClusterPushSecret"),
+				Scheme:          mgr.GetScheme(),
+				RequeueInterval: time.Hour,
+			}).SetupWithManager(mgr, controller.Options{
+				MaxConcurrentReconciles: concurrent,
+			}); err != nil {
+				setupLog.Error(err, errCreateController, "controller", "ClusterPushSecret")
+				os.Exit(1)
+			}
+		}
 
 		fs := feature.Features()
 		for _, f := range fs {
@@ -270,11 +417,34 @@ func init() {
 	rootCmd.Flags().BoolVar(&enableClusterStoreReconciler, "enable-cluster-store-reconciler", true, "Enable cluster store reconciler.")
 	rootCmd.Flags().BoolVar(&enableClusterExternalSecretReconciler, "enable-cluster-external-secret-reconciler", true, "Enable cluster external secret reconciler.")
 	rootCmd.Flags().BoolVar(&enablePushSecretReconciler, "enable-push-secret-reconciler", true, "Enable push secret reconciler.")
+	rootCmd.Flags().BoolVar(&enableClusterPushSecretReconciler, "enable-cluster-push-secret-reconciler", false, "Enable cluster push secret reconciler.")
 	rootCmd.Flags().BoolVar(&enableSecretsCache, "enable-secrets-caching", false, "Enable secrets caching for external-secrets pod.")
+	rootCmd.Flags().BoolVar(&enableManagedSecretsCache, "enable-managed-secrets-caching", false, "Only watch the Secrets owned by ExternalSecrets instead of all Secrets of the cluster. Ignored with --enable-secrets-caching.")
 	rootCmd.Flags().BoolVar(&enableConfigMapsCache, "enable-configmaps-caching", false, "Enable secrets caching for external-secrets pod.")
 	rootCmd.Flags().DurationVar(&storeRequeueInterval, "store-requeue-interval", time.Minute*5, "Default Time duration between reconciling (Cluster)SecretStores")
+	rootCmd.Flags().BoolVar(&enableStoreHealthChecks, "enable-store-health-checks", false, "Enable the periodic health checks of the SecretStores and ClusterSecretStores that set spec.healthCheck.")
 	rootCmd.Flags().BoolVar(&enableFloodGate, "enable-flood-gate", true, "Enable flood gate. External secret will be reconciled only if the ClusterStore or Store have an healthy or unknown state.")
+	rootCmd.Flags().BoolVar(&enableAuditLog, "enable-audit-log", false, "Emit an audit record for every read of secret data from a provider and every sync of an ExternalSecret.")
+	rootCmd.Flags().StringVar(&auditOTLPEndpoint, "audit-otlp-endpoint", "", "OTLP/HTTP endpoint the audit records are sent to in addition to the log, e.g. http://otel-collector:4318. Headers are read from OTEL_EXPORTER_OTLP_HEADERS. Requires --enable-audit-log.")
+	rootCmd.Flags().StringSliceVar(&allowedProviderEndpoints, "allowed-provider-endpoints", nil, "Comma separated hosts SecretStores and ClusterSecretStores may connect to, e.g. *.chef.internal.example.com,vault.example.com:8200. A leading *. matches any subdomain. All endpoints are allowed if not set.")
+	rootCmd.Flags().StringSliceVar(&enabledProviders, "providers", nil, "Comma separated providers registered in the controller, e.g. chef,kubernetes. Stores of other providers fail as disabled. All providers compiled into the binary are registered if not set.")
+	rootCmd.Flags().BoolVar(&enableWorkloadReload, "enable-workload-reload", false, "Enable restarting the workloads of spec.target.reload when the data of the target secret changes. Requires permission to patch Deployments, StatefulSets and DaemonSets.")
+	rootCmd.Flags().BoolVar(&enableLazySync, "enable-lazy-sync", false, "Enable deferring the first sync of ExternalSecrets with spec.target.lazy until a Pod references the target secret. Requires permission to watch Pods.")
+	rootCmd.Flags().BoolVar(&enableNamespaceOptOut, "enable-namespace-opt-out", false, "Enable stopping the sync of ExternalSecrets in namespaces with the label external-secrets.io/opt-out. The value delete also deletes their owned secrets. Requires permission to watch Namespaces.")
+	rootCmd.Flags().BoolVar(&enableChecksumAnnotations, "enable-checksum-annotations", false, "Enable setting the sha256 sums of the data and of every key as annotations on the target secrets, so drift can be detected without reading the values.")
+	rootCmd.Flags().BoolVar(&enableSyncPolicies, "enable-secret-sync-policies", false, "Enable applying the defaults of the cluster scoped SecretSyncPolicies to the ExternalSecrets that do not set the fields. Requires permission to watch SecretSyncPolicies.")
+	rootCmd.Flags().DurationVar(&freshnessThreshold, "freshness-threshold", 0, "Default maximum time since the last successful sync of an ExternalSecret before its Stale condition is set to True. Zero disables the check.")
+	rootCmd.Flags().DurationVar(&offlineThreshold, "offline-threshold", 0, "Time a SecretStore or ClusterSecretStore has to fail its validation before failed syncs of the ExternalSecrets using it keep their existing secrets and set them stale, without failing or emitting warning events. Zero disables the offline mode.")
+	rootCmd.Flags().DurationVar(&eventAggregationInterval, "event-aggregation-interval", 0, "Interval at which repeated identical warning events of an ExternalSecret are emitted, the suppressed events are counted in the next one. Zero emits every event.")
+	rootCmd.Flags().DurationVar(&providerBatchWindow, "provider-batch-window", 0, "Duration for which the results of GetSecret and GetSecretMap calls are shared between ExternalSecrets using the same store. Zero disables the batching.")
+	rootCmd.Flags().StringVar(&providerCacheDir, "provider-cache-dir", "", "Directory the values read by ExternalSecrets from the providers are stored in, encrypted with AES-GCM, to serve them while a provider or its store fails, also after a restart of the controller. Requires --provider-cache-key-file. Empty disables the cache.")
+	rootCmd.Flags().StringVar(&providerCacheKeyFile, "provider-cache-key-file", "", "File with the base64 encoded AES key of 16, 24 or 32 bytes the provider cache is encrypted with, e.g. a key of a mounted Secret.")
+	rootCmd.Flags().DurationVar(&providerCacheMaxAge, "provider-cache-max-age", 0, "Maximum age of the values that are served from the provider cache. Zero serves them regardless of their age.")
+	rootCmd.Flags().Float32Var(&startupSyncQPS, "startup-sync-qps", 0, "Maximum rate per second of the first syncs of ExternalSecrets after the controller starts, so the ExternalSecrets that are due do not read from the providers at once. Zero disables the limit.")
+	rootCmd.Flags().IntVar(&startupSyncBurst, "startup-sync-burst", 10, "Number of first syncs after the controller starts that may read from the providers at once, before --startup-sync-qps applies.")
+	rootCmd.Flags().Float64Var(&refreshJitter, "refresh-jitter", 0, "Maximum fraction of the refreshInterval that is added to it, derived from the UID of each ExternalSecret, to spread the refreshes of ExternalSecrets created at the same time. Zero disables the jitter.")
 	rootCmd.Flags().BoolVar(&enableExtendedMetricLabels, "enable-extended-metric-labels", false, "Enable recommended kubernetes annotations as labels in metrics.")
+	rootCmd.Flags().StringVar(&externalSecretMetricsCardinality, "externalsecret-metrics-cardinality", esmetrics.CardinalityFull, "Labels of the ExternalSecret metrics, one of: full (per ExternalSecret), namespace (aggregated per namespace), none (disabled). Store and provider metrics are not affected.")
 	fs := feature.Features()
 	for _, f := range fs {
 		rootCmd.Flags().AddFlagSet(f.Flags)
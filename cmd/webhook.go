@@ -31,6 +31,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -81,6 +82,15 @@ var webhookCmd = &cobra.Command{
 		logger := zap.New(zap.UseFlagOptions(&opts))
 		ctrl.SetLogger(logger)
 
+		if err := esv1beta1.SetAllowedProviderEndpoints(allowedProviderEndpoints); err != nil {
+			setupLog.Error(err, "invalid allowed provider endpoints")
+			os.Exit(1)
+		}
+		if err := esv1beta1.EnableProviders(enabledProviders); err != nil {
+			setupLog.Error(err, "invalid providers")
+			os.Exit(1)
+		}
+
 		err := waitForCerts(c, time.Minute*2)
 		if err != nil {
 			setupLog.Error(err, "unable to validate certificates")
@@ -142,11 +152,24 @@ var webhookCmd = &cobra.Command{
 			setupLog.Error(err, errCreateWebhook, "webhook", "ExternalSecret-v1beta1")
 			os.Exit(1)
 		}
-		if err = (&esv1beta1.SecretStore{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&esv1alpha1.ExternalSecretPolicy{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, errCreateWebhook, "webhook", "ExternalSecretPolicy-v1alpha1")
+			os.Exit(1)
+		}
+		var storeClient client.Client
+		if enableOnlineStoreValidation {
+			// not cached, only the credentials of stores that opt in are read
+			storeClient, err = client.New(mgr.GetConfig(), client.Options{Scheme: scheme})
+			if err != nil {
+				setupLog.Error(err, "unable to create client for online store validation")
+				os.Exit(1)
+			}
+		}
+		if err = (&esv1beta1.SecretStore{}).SetupWebhookWithManager(mgr, storeClient); err != nil {
 			setupLog.Error(err, errCreateWebhook, "webhook", "SecretStore-v1beta1")
 			os.Exit(1)
 		}
-		if err = (&esv1beta1.ClusterSecretStore{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&esv1beta1.ClusterSecretStore{}).SetupWebhookWithManager(mgr, storeClient); err != nil {
 			setupLog.Error(err, errCreateWebhook, "webhook", "ClusterSecretStore-v1beta1")
 			os.Exit(1)
 		}
@@ -258,5 +281,8 @@ func init() {
 		" The order of this list does not give preference to the ciphers, the ordering is done automatically."+
 		" Full lists of available ciphers can be found at https://pkg.go.dev/crypto/tls#pkg-constants."+
 		" E.g. 'TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256'")
+	webhookCmd.Flags().StringSliceVar(&allowedProviderEndpoints, "allowed-provider-endpoints", nil, "Comma separated hosts SecretStores and ClusterSecretStores may connect to, e.g. *.chef.internal.example.com,vault.example.com:8200. A leading *. matches any subdomain. All endpoints are allowed if not set.")
+	webhookCmd.Flags().StringSliceVar(&enabledProviders, "providers", nil, "Comma separated providers registered in the controller, e.g. chef,kubernetes. Stores of other providers fail as disabled. All providers compiled into the binary are registered if not set.")
 	webhookCmd.Flags().StringVar(&tlsMinVersion, "tls-min-version", "1.2", "minimum version of TLS supported.")
+	webhookCmd.Flags().BoolVar(&enableOnlineStoreValidation, "enable-online-store-validation", false, "Validate the connection of SecretStores and ClusterSecretStores that opt in to online validation before they are admitted. The webhook needs to read Secrets.")
 }
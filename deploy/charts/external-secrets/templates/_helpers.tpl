@@ -77,6 +77,18 @@ app.kubernetes.io/managed-by: {{ .Release.Service }}
 {{- end }}
 {{- end }}
 
+{{- define "external-secrets-csi-provider.labels" -}}
+helm.sh/chart: {{ include "external-secrets.chart" . }}
+{{ include "external-secrets-csi-provider.selectorLabels" . }}
+{{- if .Chart.AppVersion }}
+app.kubernetes.io/version: {{ .Chart.AppVersion | quote }}
+{{- end }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- with .Values.commonLabels }}
+{{ toYaml . }}
+{{- end }}
+{{- end }}
+
 {{- define "external-secrets-cert-controller-metrics.labels" -}}
 {{ include "external-secrets-cert-controller.selectorLabels" . }}
 app.kubernetes.io/metrics: "cert-controller"
@@ -100,6 +112,10 @@ app.kubernetes.io/instance: {{ .Release.Name }}
 app.kubernetes.io/name: {{ include "external-secrets.name" . }}-cert-controller
 app.kubernetes.io/instance: {{ .Release.Name }}
 {{- end }}
+{{- define "external-secrets-csi-provider.selectorLabels" -}}
+app.kubernetes.io/name: {{ include "external-secrets.name" . }}-csi-provider
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end }}
 {{/*
 Create the name of the service account to use
 */}}
@@ -133,6 +149,17 @@ Create the name of the service account to use
 {{- end }}
 {{- end }}
 
+{{/*
+Create the name of the service account to use for the csi provider
+*/}}
+{{- define "external-secrets-csi-provider.serviceAccountName" -}}
+{{- if .Values.csiProvider.serviceAccount.create }}
+{{- default "external-secrets-csi-provider" .Values.csiProvider.serviceAccount.name }}
+{{- else }}
+{{- default "default" .Values.csiProvider.serviceAccount.name }}
+{{- end }}
+{{- end }}
+
 {{/*
 Determine the image to use, including if using a flavour.
 */}}
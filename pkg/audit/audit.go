@@ -0,0 +1,215 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records every read of secret data from a provider and every
+// sync of an ExternalSecret, so it can be answered which ExternalSecret
+// accessed which remote secret and when, and whether the sync succeeded.
+package audit
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/exp/maps"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	OperationGetSecret     = "GetSecret"
+	OperationGetSecretMap  = "GetSecretMap"
+	OperationGetAllSecrets = "GetAllSecrets"
+	OperationSync          = "Sync"
+
+	ResultSuccess  = "Success"
+	ResultNotFound = "NotFound"
+	ResultError    = "Error"
+)
+
+// Record describes a single read of secret data from a provider or a sync
+// of an ExternalSecret.
+type Record struct {
+	Time time.Time
+	// Namespace, ExternalSecret and UID identify the ExternalSecret on whose
+	// behalf the secret was read.
+	Namespace      string
+	ExternalSecret string
+	UID            string
+	StoreKind      string
+	StoreName      string
+	Operation      string
+	// RemoteKey and Property identify the remote secret. For GetAllSecrets
+	// RemoteKey describes the find criteria and Keys lists the secrets that
+	// were returned.
+	RemoteKey string
+	Property  string
+	Keys      []string
+	// Secret is the target Secret of Sync records.
+	Secret string
+	Result string
+	Error  string
+	// Latency is the duration of the provider call or of the sync.
+	Latency time.Duration
+}
+
+// Sink receives audit records in addition to the log, e.g. to ship them to
+// an external system. Write must not block.
+type Sink interface {
+	Write(Record)
+}
+
+// Auditor emits audit records to the log and to an optional sink. A nil
+// Auditor discards all records.
+type Auditor struct {
+	log  logr.Logger
+	sink Sink
+}
+
+// New returns an Auditor that logs to log and, if sink is not nil, writes
+// every record to sink.
+func New(log logr.Logger, sink Sink) *Auditor {
+	return &Auditor{log: log, sink: sink}
+}
+
+// Read records a read of secret data on behalf of es from the store ref
+// points to. err is the error returned by the provider, if any.
+func (a *Auditor) Read(es *esv1beta1.ExternalSecret, ref esv1beta1.SecretStoreRef, operation, remoteKey, property string, keys []string, latency time.Duration, err error) {
+	if a == nil {
+		return
+	}
+	rec := Record{
+		Time:           time.Now().UTC(),
+		Namespace:      es.Namespace,
+		ExternalSecret: es.Name,
+		UID:            string(es.UID),
+		StoreKind:      ref.Kind,
+		StoreName:      ref.Name,
+		Operation:      operation,
+		RemoteKey:      remoteKey,
+		Property:       property,
+		Keys:           keys,
+		Result:         ResultSuccess,
+		Latency:        latency,
+	}
+	if rec.StoreKind == "" {
+		rec.StoreKind = esv1beta1.SecretStoreKind
+	}
+	if err != nil {
+		rec.Result = ResultError
+		if errors.Is(err, esv1beta1.NoSecretErr) {
+			rec.Result = ResultNotFound
+		}
+		rec.Error = err.Error()
+	}
+	a.write(rec)
+}
+
+// Sync records the outcome of a sync of es to the Secret secretName.
+// failure is the reason of a failed sync, it is empty if the sync succeeded.
+func (a *Auditor) Sync(es *esv1beta1.ExternalSecret, secretName, failure string, latency time.Duration) {
+	if a == nil {
+		return
+	}
+	rec := Record{
+		Time:           time.Now().UTC(),
+		Namespace:      es.Namespace,
+		ExternalSecret: es.Name,
+		UID:            string(es.UID),
+		StoreKind:      es.Spec.SecretStoreRef.Kind,
+		StoreName:      es.Spec.SecretStoreRef.Name,
+		Operation:      OperationSync,
+		Secret:         secretName,
+		Result:         ResultSuccess,
+		Latency:        latency,
+	}
+	if rec.StoreName != "" && rec.StoreKind == "" {
+		rec.StoreKind = esv1beta1.SecretStoreKind
+	}
+	if failure != "" {
+		rec.Result = ResultError
+		rec.Error = failure
+	}
+	a.write(rec)
+}
+
+func (a *Auditor) write(rec Record) {
+	a.log.Info(rec.message(), rec.keysAndValues()...)
+	if a.sink != nil {
+		a.sink.Write(rec)
+	}
+}
+
+// DescribeFind returns a description of the find criteria to be used as
+// remote key of GetAllSecrets records.
+func DescribeFind(find esv1beta1.ExternalSecretFind) string {
+	var parts []string
+	if find.Path != nil {
+		parts = append(parts, "path="+*find.Path)
+	}
+	if find.Name != nil {
+		parts = append(parts, "name="+find.Name.RegExp)
+	}
+	tags := maps.Keys(find.Tags)
+	sort.Strings(tags)
+	for _, k := range tags {
+		parts = append(parts, "tag:"+k+"="+find.Tags[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+// Keys returns the sorted keys of a secret map.
+func Keys(secretMap map[string][]byte) []string {
+	keys := maps.Keys(secretMap)
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *Record) message() string {
+	if r.Operation == OperationSync {
+		return "secret synced"
+	}
+	return "secret read"
+}
+
+// keysAndValues returns the fields of r as logr key/value pairs, empty
+// fields are omitted.
+func (r *Record) keysAndValues() []any {
+	kv := []any{
+		"namespace", r.Namespace,
+		"externalSecret", r.ExternalSecret,
+		"uid", r.UID,
+		"storeKind", r.StoreKind,
+		"storeName", r.StoreName,
+		"operation", r.Operation,
+	}
+	if r.Operation == OperationSync {
+		kv = append(kv, "secret", r.Secret)
+	} else {
+		kv = append(kv, "remoteKey", r.RemoteKey)
+	}
+	kv = append(kv, "result", r.Result, "latencyMs", r.Latency.Milliseconds())
+	if r.Property != "" {
+		kv = append(kv, "property", r.Property)
+	}
+	if len(r.Keys) > 0 {
+		kv = append(kv, "keys", r.Keys)
+	}
+	if r.Error != "" {
+		kv = append(kv, "error", r.Error)
+	}
+	return kv
+}
@@ -0,0 +1,144 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+type fakeSink struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+func (s *fakeSink) Write(r Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, r)
+}
+
+func TestRead(t *testing.T) {
+	var lines []string
+	log := funcr.New(func(_, args string) { lines = append(lines, args) }, funcr.Options{})
+	sink := &fakeSink{}
+	a := New(log, sink)
+	es := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-a", UID: "1234"}}
+
+	a.Read(es, esv1beta1.SecretStoreRef{Name: "vault"}, OperationGetSecret, "db/creds", "password", nil, 42*time.Millisecond, nil)
+	a.Read(es, esv1beta1.SecretStoreRef{Name: "shared", Kind: esv1beta1.ClusterSecretStoreKind}, OperationGetSecretMap, "db/missing", "", nil, 0, esv1beta1.NoSecretErr)
+	a.Read(es, esv1beta1.SecretStoreRef{Name: "vault"}, OperationGetAllSecrets, "path=db", "", []string{"a", "b"}, 0, errors.New("permission denied"))
+
+	require.Len(t, sink.records, 3)
+	rec := sink.records[0]
+	assert.False(t, rec.Time.IsZero())
+	assert.Equal(t, Record{
+		Time:           rec.Time,
+		Namespace:      "team-a",
+		ExternalSecret: "db",
+		UID:            "1234",
+		StoreKind:      esv1beta1.SecretStoreKind,
+		StoreName:      "vault",
+		Operation:      OperationGetSecret,
+		RemoteKey:      "db/creds",
+		Property:       "password",
+		Result:         ResultSuccess,
+		Latency:        42 * time.Millisecond,
+	}, rec)
+	assert.Equal(t, ResultNotFound, sink.records[1].Result)
+	assert.Equal(t, esv1beta1.ClusterSecretStoreKind, sink.records[1].StoreKind)
+	assert.Equal(t, ResultError, sink.records[2].Result)
+	assert.Equal(t, "permission denied", sink.records[2].Error)
+	assert.Equal(t, []string{"a", "b"}, sink.records[2].Keys)
+
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"msg"="secret read" "namespace"="team-a" "externalSecret"="db"`)
+	assert.Contains(t, lines[0], `"remoteKey"="db/creds" "result"="Success" "latencyMs"=42 "property"="password"`)
+	assert.Contains(t, lines[2], `"keys"=["a" "b"] "error"="permission denied"`)
+}
+
+func TestSync(t *testing.T) {
+	var lines []string
+	log := funcr.New(func(_, args string) { lines = append(lines, args) }, funcr.Options{})
+	sink := &fakeSink{}
+	a := New(log, sink)
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-a", UID: "1234"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: esv1beta1.SecretStoreRef{Name: "vault"},
+		},
+	}
+
+	a.Sync(es, "db-creds", "", 1500*time.Millisecond)
+	a.Sync(es, "db-creds", "could not get secret data from provider", time.Second)
+	a.Sync(&esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "generated"}}, "generated", "", 0)
+
+	require.Len(t, sink.records, 3)
+	rec := sink.records[0]
+	assert.Equal(t, Record{
+		Time:           rec.Time,
+		Namespace:      "team-a",
+		ExternalSecret: "db",
+		UID:            "1234",
+		StoreKind:      esv1beta1.SecretStoreKind,
+		StoreName:      "vault",
+		Operation:      OperationSync,
+		Secret:         "db-creds",
+		Result:         ResultSuccess,
+		Latency:        1500 * time.Millisecond,
+	}, rec)
+	assert.Equal(t, ResultError, sink.records[1].Result)
+	assert.Equal(t, "could not get secret data from provider", sink.records[1].Error)
+	// the store kind is only set if the ExternalSecret refers to a store.
+	assert.Empty(t, sink.records[2].StoreKind)
+
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"msg"="secret synced"`)
+	assert.Contains(t, lines[0], `"operation"="Sync" "secret"="db-creds" "result"="Success" "latencyMs"=1500`)
+	assert.NotContains(t, lines[0], "remoteKey")
+	assert.Contains(t, lines[1], `"error"="could not get secret data from provider"`)
+}
+
+func TestReadDisabled(t *testing.T) {
+	var a *Auditor
+	a.Read(&esv1beta1.ExternalSecret{}, esv1beta1.SecretStoreRef{}, OperationGetSecret, "key", "", nil, 0, nil)
+	a.Sync(&esv1beta1.ExternalSecret{}, "secret", "", 0)
+	New(funcr.New(func(_, _ string) {}, funcr.Options{}), nil).
+		Read(&esv1beta1.ExternalSecret{}, esv1beta1.SecretStoreRef{}, OperationGetSecret, "key", "", nil, 0, nil)
+}
+
+func TestDescribeFind(t *testing.T) {
+	assert.Equal(t, "", DescribeFind(esv1beta1.ExternalSecretFind{}))
+	assert.Equal(t, "path=db name=^prod- tag:env=prod tag:team=a", DescribeFind(esv1beta1.ExternalSecretFind{
+		Path: ptr.To("db"),
+		Name: &esv1beta1.FindName{RegExp: "^prod-"},
+		Tags: map[string]string{"team": "a", "env": "prod"},
+	}))
+}
+
+func TestKeys(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, Keys(map[string][]byte{"c": nil, "a": nil, "b": nil}))
+	assert.Empty(t, Keys(nil))
+}
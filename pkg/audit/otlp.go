@@ -0,0 +1,309 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	otlpLogsPath      = "/v1/logs"
+	otlpBufferSize    = 4096
+	otlpBatchSize     = 512
+	otlpFlushInterval = 5 * time.Second
+	otlpTimeout       = 10 * time.Second
+	// severity numbers of INFO and ERROR, see the OpenTelemetry logs data model.
+	otlpSeverityInfo  = 9
+	otlpSeverityError = 17
+
+	// the environment variables of the OpenTelemetry SDKs that configure
+	// the headers sent to the collector, e.g. to authenticate.
+	otlpLogsHeadersEnv = "OTEL_EXPORTER_OTLP_LOGS_HEADERS"
+	otlpHeadersEnv     = "OTEL_EXPORTER_OTLP_HEADERS"
+)
+
+// OTLPSink ships audit records as OTLP log records over HTTP with the JSON
+// encoding. Records are buffered and sent in batches, records are dropped if
+// the buffer is full, so a slow collector does not block reconciliation.
+//
+// OTLPSink implements manager.Runnable, it has to be added to the manager to
+// send records.
+type OTLPSink struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+	log      logr.Logger
+	records  chan Record
+	dropped  atomic.Int64
+	interval time.Duration
+}
+
+// NewOTLPSink returns a sink that sends records to the OTLP/HTTP endpoint,
+// e.g. http://otel-collector:4318. The logs path /v1/logs is used if the
+// endpoint has no path. headers are sent with every request.
+func NewOTLPSink(endpoint string, headers map[string]string, log logr.Logger) (*OTLPSink, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit endpoint: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid audit endpoint %q: must be a http or https url", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpLogsPath
+	}
+	return &OTLPSink{
+		endpoint: u.String(),
+		headers:  headers,
+		client:   &http.Client{Timeout: otlpTimeout},
+		log:      log,
+		records:  make(chan Record, otlpBufferSize),
+		interval: otlpFlushInterval,
+	}, nil
+}
+
+// OTLPHeadersFromEnv returns the headers configured with the
+// OTEL_EXPORTER_OTLP_LOGS_HEADERS or OTEL_EXPORTER_OTLP_HEADERS environment
+// variables, a comma separated list of key=value pairs with url encoded
+// values, like the OpenTelemetry SDKs do.
+func OTLPHeadersFromEnv() (map[string]string, error) {
+	env := otlpHeadersEnv
+	value, ok := os.LookupEnv(otlpLogsHeadersEnv)
+	if ok {
+		env = otlpLogsHeadersEnv
+	} else {
+		value = os.Getenv(otlpHeadersEnv)
+	}
+	headers, err := parseOTLPHeaders(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", env, err)
+	}
+	return headers, nil
+}
+
+func parseOTLPHeaders(value string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("header %q is not a key=value pair", strings.TrimSpace(pair))
+		}
+		decoded, err := url.PathUnescape(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("header %q: %w", key, err)
+		}
+		headers[key] = decoded
+	}
+	return headers, nil
+}
+
+// Write queues r to be sent with the next batch.
+func (s *OTLPSink) Write(r Record) {
+	select {
+	case s.records <- r:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Start sends the queued records until ctx is done, then sends the remaining
+// records.
+func (s *OTLPSink) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	batch := make([]Record, 0, otlpBatchSize)
+	for {
+		select {
+		case r := <-s.records:
+			batch = append(batch, r)
+			if len(batch) < otlpBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		case <-ctx.Done():
+			for len(s.records) > 0 {
+				batch = append(batch, <-s.records)
+			}
+			flushCtx, cancel := context.WithTimeout(context.Background(), otlpTimeout)
+			s.flush(flushCtx, batch)
+			cancel()
+			return nil
+		}
+		s.flush(ctx, batch)
+		batch = batch[:0]
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, records are
+// sent by every replica.
+func (s *OTLPSink) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *OTLPSink) flush(ctx context.Context, batch []Record) {
+	if dropped := s.dropped.Swap(0); dropped > 0 {
+		s.log.Error(nil, "audit buffer full, dropped records", "count", dropped)
+	}
+	if len(batch) == 0 {
+		return
+	}
+	if err := s.send(ctx, batch); err != nil {
+		s.log.Error(err, "unable to send audit records", "count", len(batch))
+	}
+}
+
+func (s *OTLPSink) send(ctx context.Context, batch []Record) error {
+	body, err := json.Marshal(newExportLogsRequest(batch))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// The types below are the subset of the OTLP JSON encoding of
+// ExportLogsServiceRequest that is needed to send audit records.
+
+type otlpExportLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           otlpAnyValue   `json:"body"`
+	Attributes     []otlpKeyValue `json:"attributes"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	// IntValue is a string, int64 values are encoded as decimal strings.
+	IntValue   *string         `json:"intValue,omitempty"`
+	ArrayValue *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+func stringValue(s string) otlpAnyValue {
+	return otlpAnyValue{StringValue: &s}
+}
+
+func newExportLogsRequest(batch []Record) *otlpExportLogsRequest {
+	records := make([]otlpLogRecord, 0, len(batch))
+	for i := range batch {
+		records = append(records, newLogRecord(&batch[i]))
+	}
+	return &otlpExportLogsRequest{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: otlpResource{Attributes: []otlpKeyValue{
+				{Key: "service.name", Value: stringValue("external-secrets")},
+			}},
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: "external-secrets.io/audit"},
+				LogRecords: records,
+			}},
+		}},
+	}
+}
+
+func newLogRecord(r *Record) otlpLogRecord {
+	kv := r.keysAndValues()
+	attrs := make([]otlpKeyValue, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key := kv[i].(string)
+		switch v := kv[i+1].(type) {
+		case string:
+			attrs = append(attrs, otlpKeyValue{Key: key, Value: stringValue(v)})
+		case int64:
+			i := strconv.FormatInt(v, 10)
+			attrs = append(attrs, otlpKeyValue{Key: key, Value: otlpAnyValue{IntValue: &i}})
+		case []string:
+			values := make([]otlpAnyValue, 0, len(v))
+			for _, s := range v {
+				values = append(values, stringValue(s))
+			}
+			attrs = append(attrs, otlpKeyValue{Key: key, Value: otlpAnyValue{ArrayValue: &otlpArrayValue{Values: values}}})
+		}
+	}
+	rec := otlpLogRecord{
+		TimeUnixNano:   strconv.FormatInt(r.Time.UnixNano(), 10),
+		SeverityNumber: otlpSeverityInfo,
+		SeverityText:   "INFO",
+		Body:           stringValue(r.message()),
+		Attributes:     attrs,
+	}
+	if r.Result == ResultError {
+		rec.SeverityNumber = otlpSeverityError
+		rec.SeverityText = "ERROR"
+	}
+	return rec
+}
@@ -0,0 +1,159 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOTLPSink(t *testing.T) {
+	s, err := NewOTLPSink("http://collector:4318", nil, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "http://collector:4318/v1/logs", s.endpoint)
+
+	s, err = NewOTLPSink("https://collector.example.com/custom/logs", nil, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "https://collector.example.com/custom/logs", s.endpoint)
+
+	for _, endpoint := range []string{"collector:4318", "ftp://collector", "http://", ":"} {
+		_, err = NewOTLPSink(endpoint, nil, logr.Discard())
+		assert.Error(t, err, endpoint)
+	}
+}
+
+func TestOTLPSink(t *testing.T) {
+	var mu sync.Mutex
+	var requests []otlpExportLogsRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		var req otlpExportLogsRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	s, err := NewOTLPSink(srv.URL, map[string]string{"Authorization": "Bearer token"}, logr.Discard())
+	require.NoError(t, err)
+	s.interval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Start(ctx) }()
+
+	s.Write(Record{
+		Time:           time.Unix(1700000000, 5),
+		Namespace:      "team-a",
+		ExternalSecret: "db",
+		StoreKind:      "SecretStore",
+		StoreName:      "vault",
+		Operation:      OperationGetAllSecrets,
+		RemoteKey:      "path=db",
+		Keys:           []string{"a", "b"},
+		Result:         ResultSuccess,
+		Latency:        250 * time.Millisecond,
+	})
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(requests) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// records that are queued when the sink stops are sent before Start returns
+	s.Write(Record{Namespace: "team-b"})
+	cancel()
+	require.NoError(t, <-done)
+
+	mu.Lock()
+	defer mu.Unlock()
+	records := requests[0].ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, records, 1)
+	assert.Equal(t, "1700000000000000005", records[0].TimeUnixNano)
+	assert.Equal(t, "secret read", *records[0].Body.StringValue)
+	assert.Equal(t, "INFO", records[0].SeverityText)
+	attrs := map[string]otlpAnyValue{}
+	for _, kv := range records[0].Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	assert.Equal(t, "team-a", *attrs["namespace"].StringValue)
+	assert.Equal(t, "path=db", *attrs["remoteKey"].StringValue)
+	require.NotNil(t, attrs["keys"].ArrayValue)
+	assert.Len(t, attrs["keys"].ArrayValue.Values, 2)
+	require.NotNil(t, attrs["latencyMs"].IntValue)
+	assert.Equal(t, "250", *attrs["latencyMs"].IntValue)
+	assert.NotContains(t, attrs, "error")
+
+	var total int
+	for _, req := range requests {
+		total += len(req.ResourceLogs[0].ScopeLogs[0].LogRecords)
+	}
+	assert.Equal(t, 2, total)
+}
+
+func TestOTLPSinkDropsRecords(t *testing.T) {
+	s, err := NewOTLPSink("http://collector:4318", nil, logr.Discard())
+	require.NoError(t, err)
+	for i := 0; i < otlpBufferSize+3; i++ {
+		s.Write(Record{})
+	}
+	assert.Equal(t, int64(3), s.dropped.Load())
+}
+
+func TestNewLogRecordSync(t *testing.T) {
+	rec := newLogRecord(&Record{
+		Operation: OperationSync,
+		Secret:    "db-creds",
+		Result:    ResultError,
+		Error:     "could not get secret data from provider",
+	})
+	assert.Equal(t, "secret synced", *rec.Body.StringValue)
+	assert.Equal(t, otlpSeverityError, rec.SeverityNumber)
+	assert.Equal(t, "ERROR", rec.SeverityText)
+	attrs := map[string]otlpAnyValue{}
+	for _, kv := range rec.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	assert.Equal(t, "db-creds", *attrs["secret"].StringValue)
+	assert.NotContains(t, attrs, "remoteKey")
+}
+
+func TestOTLPHeadersFromEnv(t *testing.T) {
+	t.Setenv(otlpHeadersEnv, "api-key=secret, x-scope-orgid=tenant%201")
+	headers, err := OTLPHeadersFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"api-key": "secret", "x-scope-orgid": "tenant 1"}, headers)
+
+	// the logs specific variable takes precedence.
+	t.Setenv(otlpLogsHeadersEnv, "authorization=Basic%20dXNlcg==")
+	headers, err = OTLPHeadersFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Basic dXNlcg=="}, headers)
+
+	t.Setenv(otlpLogsHeadersEnv, "no-value")
+	_, err = OTLPHeadersFromEnv()
+	assert.ErrorContains(t, err, `invalid OTEL_EXPORTER_OTLP_LOGS_HEADERS: header "no-value" is not a key=value pair`)
+}
@@ -76,8 +76,84 @@ const (
 	CallIBMSMListSecrets         = "ListSecrets"
 	CallIBMSMGetSecretByNameType = "GetSecretByNameType"
 
-	ProviderWebhook    = "Webhook"
-	CallWebhookHTTPReq = "HTTPRequest"
+	ProviderChefAutomate             = "ChefAutomate"
+	CallChefAutomateListNodes        = "ListNodes"
+	CallChefAutomateGetNodeAttribute = "GetNodeAttribute"
+	CallChefAutomateSearchProfiles   = "SearchProfiles"
+	CallChefAutomateIntrospect       = "Introspect"
+
+	ProviderHabitat             = "Habitat"
+	CallHabitatListSecrets      = "ListOriginSecrets"
+	CallHabitatCreateSecret     = "CreateOriginSecret"
+	CallHabitatDeleteSecret     = "DeleteOriginSecret"
+	CallHabitatGetEncryptionKey = "GetOriginEncryptionKey"
+
+	ProviderPuppet     = "Puppet"
+	CallPuppetGitClone = "GitClone"
+	CallPuppetGitList  = "GitListRemote"
+	CallPuppetHTTPGet  = "HTTPGet"
+
+	ProviderSalt       = "Salt"
+	CallSaltShowPillar = "ShowPillar"
+	CallSaltLogin      = "Login"
+
+	ProviderJenkins       = "Jenkins"
+	CallJenkinsScriptText = "ScriptText"
+	CallJenkinsWhoAmI     = "WhoAmI"
+
+	ProviderArtifactory                 = "JFrog/Artifactory"
+	CallArtifactoryCreateToken          = "CreateToken"
+	CallArtifactoryGetEncryptedPassword = "GetEncryptedPassword"
+
+	ProviderSOPS       = "SOPS"
+	CallSOPSGitClone   = "GitClone"
+	CallSOPSGitList    = "GitList"
+	CallSOPSKMSDecrypt = "KMSDecrypt"
+
+	ProviderCyberArkCCP       = "CyberArk/CCP"
+	CallCyberArkCCPGetAccount = "GetAccount"
+
+	ProviderKeycloak                   = "Keycloak"
+	CallKeycloakToken                  = "Token"
+	CallKeycloakGetClients             = "GetClients"
+	CallKeycloakGetClient              = "GetClient"
+	CallKeycloakGetClientSecret        = "GetClientSecret"
+	CallKeycloakUpdateClient           = "UpdateClient"
+	CallKeycloakRegenerateClientSecret = "RegenerateClientSecret"
+
+	ProviderSQL  = "SQL"
+	CallSQLQuery = "Query"
+	CallSQLPing  = "Ping"
+
+	ProviderLDAP   = "LDAP"
+	CallLDAPBind   = "Bind"
+	CallLDAPSearch = "Search"
+
+	ProviderPlugin          = "Plugin"
+	CallPluginGetSecret     = "GetSecret"
+	CallPluginGetSecretMap  = "GetSecretMap"
+	CallPluginGetAllSecrets = "GetAllSecrets"
+	CallPluginPushSecret    = "PushSecret"
+	CallPluginDeleteSecret  = "DeleteSecret"
+	CallPluginValidate      = "Validate"
+
+	ProviderCloudant          = "IBM/Cloudant"
+	CallCloudantGetDocument   = "GetDocument"
+	CallCloudantGetSession    = "GetSession"
+	CallCloudantListDocuments = "ListDocuments"
+
+	ProviderIBMKP     = "IBM/KeyProtect"
+	CallIBMKPGetKey   = "GetKey"
+	CallIBMKPListKeys = "ListKeys"
+
+	ProviderIBMHPCS = "IBM/HPCS"
+
+	ProviderIBMCOS      = "IBM/COS"
+	CallIBMCOSGetObject = "GetObject"
+
+	ProviderWebhook        = "Webhook"
+	CallWebhookHTTPReq     = "HTTPRequest"
+	CallWebhookOAuth2Token = "OAuth2Token"
 
 	ProviderGitLab                 = "GitLab"
 	CallGitLabListProjectsGroups   = "ListProjectsGroups"
@@ -0,0 +1,394 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpushsecret
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+// Reconciler reconciles a ClusterPushSecret object.
+type Reconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	RequeueInterval time.Duration
+}
+
+const (
+	errGetCPS               = "could not get ClusterPushSecret"
+	errPatchStatus          = "unable to patch status"
+	errConvertLabelSelector = "unable to convert labelselector"
+	errNamespaces           = "could not get namespaces from selector"
+	errSecrets              = "could not get secrets from selector"
+	errListPushSecrets      = "could not list PushSecrets"
+	errSecretsFailed        = "one or more PushSecrets could not be created or updated"
+	errPushSecretExists     = "PushSecret already exists"
+
+	// maxNameLength is the maximum length of the name of a PushSecret.
+	maxNameLength = 253
+)
+
+// templateData is the data the remoteKey and the property of the data are
+// rendered with.
+type templateData struct {
+	Namespace string
+	Name      string
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("ClusterPushSecret", req.NamespacedName)
+
+	var cps esv1alpha1.ClusterPushSecret
+	if err := r.Get(ctx, req.NamespacedName, &cps); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, errGetCPS)
+		return ctrl.Result{}, err
+	}
+
+	// the PushSecrets are deleted by the garbage collector.
+	if cps.DeletionTimestamp != nil {
+		log.Info("skipping as it is in deletion")
+		return ctrl.Result{}, nil
+	}
+
+	p := client.MergeFrom(cps.DeepCopy())
+	defer r.deferPatch(ctx, log, &cps, p)
+
+	refreshInt := r.RequeueInterval
+	if cps.Spec.RefreshInterval != nil {
+		refreshInt = cps.Spec.RefreshInterval.Duration
+	}
+
+	secrets, err := r.selectedSecrets(ctx, &cps)
+	if err != nil {
+		log.Error(err, errSecrets)
+		return ctrl.Result{}, err
+	}
+
+	failures := []esv1alpha1.ClusterPushSecretFailure{}
+	desired := make(map[types.NamespacedName]struct{}, len(secrets))
+	for i := range secrets {
+		secret := &secrets[i]
+		name := types.NamespacedName{Namespace: secret.Namespace, Name: pushSecretName(cps.Name, secret.Name)}
+		desired[name] = struct{}{}
+		if err := r.createOrUpdatePushSecret(ctx, &cps, secret, name); err != nil {
+			log.Error(err, "failed to create or update push secret", "secret", client.ObjectKeyFromObject(secret))
+			failures = append(failures, esv1alpha1.ClusterPushSecretFailure{
+				Namespace: secret.Namespace,
+				Name:      secret.Name,
+				Reason:    err.Error(),
+			})
+		}
+	}
+
+	if err := r.deleteOutdatedPushSecrets(ctx, cps.Name, desired); err != nil {
+		log.Error(err, "unable to delete push secrets")
+		return ctrl.Result{}, err
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Namespace != failures[j].Namespace {
+			return failures[i].Namespace < failures[j].Namespace
+		}
+		return failures[i].Name < failures[j].Name
+	})
+	cps.Status.PushSecrets = len(secrets) - len(failures)
+	cps.Status.FailedSecrets = failures
+	setClusterPushSecretCondition(&cps, newClusterPushSecretCondition(failures))
+
+	return ctrl.Result{RequeueAfter: refreshInt}, nil
+}
+
+// selectedSecrets returns the metadata of the Secrets that match the
+// secretSelector in the namespaces that match the namespaceSelector.
+func (r *Reconciler) selectedSecrets(ctx context.Context, cps *esv1alpha1.ClusterPushSecret) ([]metav1.PartialObjectMetadata, error) {
+	namespaceSelector := labels.Everything()
+	if cps.Spec.NamespaceSelector != nil {
+		var err error
+		namespaceSelector, err = metav1.LabelSelectorAsSelector(cps.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errConvertLabelSelector, err)
+		}
+	}
+	secretSelector, err := metav1.LabelSelectorAsSelector(&cps.Spec.SecretSelector)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errConvertLabelSelector, err)
+	}
+
+	var namespaces v1.NamespaceList
+	if err := r.List(ctx, &namespaces, &client.ListOptions{LabelSelector: namespaceSelector}); err != nil {
+		return nil, fmt.Errorf("%s: %w", errNamespaces, err)
+	}
+
+	// only the metadata is read, the secrets are not cached.
+	var secrets []metav1.PartialObjectMetadata
+	for _, namespace := range namespaces.Items {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("SecretList"))
+		if err := r.List(ctx, list, client.InNamespace(namespace.Name), client.MatchingLabelsSelector{Selector: secretSelector}); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, list.Items...)
+	}
+	return secrets, nil
+}
+
+func (r *Reconciler) createOrUpdatePushSecret(ctx context.Context, cps *esv1alpha1.ClusterPushSecret, secret *metav1.PartialObjectMetadata, name types.NamespacedName) error {
+	var existing esv1alpha1.PushSecret
+	err := r.Get(ctx, name, &existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("could not get existing PushSecret: %w", err)
+	}
+	if err == nil && !isPushSecretOwnedBy(&existing, cps.Name) {
+		return errors.New(errPushSecretExists)
+	}
+
+	spec, err := renderSpec(&cps.Spec.PushSecretSpec, secret)
+	if err != nil {
+		return err
+	}
+
+	pushSecret := &esv1alpha1.PushSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+	}
+	mutateFunc := func() error {
+		pushSecret.Spec = *spec
+		if err := controllerutil.SetControllerReference(cps, pushSecret, r.Scheme); err != nil {
+			return fmt.Errorf("could not set the controller owner reference %w", err)
+		}
+		return nil
+	}
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, pushSecret, mutateFunc); err != nil {
+		return fmt.Errorf("could not create or update PushSecret: %w", err)
+	}
+	return nil
+}
+
+// renderSpec returns the spec of the PushSecret of the Secret, with the
+// remoteKey and the property of the data rendered for the Secret.
+func renderSpec(tpl *esv1alpha1.ClusterPushSecretTemplate, secret *metav1.PartialObjectMetadata) (*esv1alpha1.PushSecretSpec, error) {
+	tpl = tpl.DeepCopy()
+	spec := &esv1alpha1.PushSecretSpec{
+		RefreshInterval: tpl.RefreshInterval,
+		SecretStoreRefs: tpl.SecretStoreRefs,
+		DeletionPolicy:  tpl.DeletionPolicy,
+		UpdatePolicy:    tpl.UpdatePolicy,
+		ConflictPolicy:  tpl.ConflictPolicy,
+		Selector: esv1alpha1.PushSecretSelector{
+			Secret: esv1alpha1.PushSecretSecret{Name: secret.Name},
+		},
+		Data:     tpl.Data,
+		Template: tpl.Template,
+	}
+	data := templateData{Namespace: secret.Namespace, Name: secret.Name}
+	for i := range spec.Data {
+		ref := &spec.Data[i].Match.RemoteRef
+		var err error
+		if ref.RemoteKey, err = render(ref.RemoteKey, data); err != nil {
+			return nil, fmt.Errorf("could not render remoteKey: %w", err)
+		}
+		if ref.Property, err = render(ref.Property, data); err != nil {
+			return nil, fmt.Errorf("could not render property: %w", err)
+		}
+	}
+	return spec, nil
+}
+
+func render(text string, data templateData) (string, error) {
+	t, err := template.New("remoteRef").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// deleteOutdatedPushSecrets deletes the PushSecrets of the ClusterPushSecret
+// whose Secret is not selected anymore.
+func (r *Reconciler) deleteOutdatedPushSecrets(ctx context.Context, cpsName string, desired map[types.NamespacedName]struct{}) error {
+	var pushSecrets esv1alpha1.PushSecretList
+	if err := r.List(ctx, &pushSecrets); err != nil {
+		return fmt.Errorf("%s: %w", errListPushSecrets, err)
+	}
+	var errs []error
+	for i := range pushSecrets.Items {
+		ps := &pushSecrets.Items[i]
+		if !isPushSecretOwnedBy(ps, cpsName) {
+			continue
+		}
+		if _, ok := desired[client.ObjectKeyFromObject(ps)]; ok {
+			continue
+		}
+		if err := r.Delete(ctx, ps); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("push secret %s could not be deleted: %w", client.ObjectKeyFromObject(ps), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Reconciler) deferPatch(ctx context.Context, log logr.Logger, cps *esv1alpha1.ClusterPushSecret, p client.Patch) {
+	if err := r.Status().Patch(ctx, cps, p); err != nil {
+		log.Error(err, errPatchStatus)
+	}
+}
+
+func isPushSecretOwnedBy(ps *esv1alpha1.PushSecret, cpsName string) bool {
+	owner := metav1.GetControllerOf(ps)
+	return owner != nil && owner.APIVersion == esv1alpha1.SchemeGroupVersion.String() && owner.Kind == esv1alpha1.ClusterPushSecretKind && owner.Name == cpsName
+}
+
+// pushSecretName returns the name of the PushSecret of the Secret. Names
+// that would be too long are truncated and suffixed with a hash of the full
+// name, so they stay unique.
+func pushSecretName(cpsName, secretName string) string {
+	name := cpsName + "-" + secretName
+	if len(name) <= maxNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(sum[:])[:8]
+	return name[:maxNameLength-len(suffix)-1] + "-" + suffix
+}
+
+func newClusterPushSecretCondition(failures []esv1alpha1.ClusterPushSecretFailure) esv1alpha1.PushSecretStatusCondition {
+	if len(failures) == 0 {
+		return esv1alpha1.PushSecretStatusCondition{
+			Type:   esv1alpha1.PushSecretReady,
+			Status: v1.ConditionTrue,
+			Reason: esv1alpha1.ReasonSynced,
+		}
+	}
+	return esv1alpha1.PushSecretStatusCondition{
+		Type:    esv1alpha1.PushSecretReady,
+		Status:  v1.ConditionFalse,
+		Reason:  esv1alpha1.ReasonErrored,
+		Message: errSecretsFailed,
+	}
+}
+
+func setClusterPushSecretCondition(cps *esv1alpha1.ClusterPushSecret, condition esv1alpha1.PushSecretStatusCondition) {
+	conditions := make([]esv1alpha1.PushSecretStatusCondition, 0, len(cps.Status.Conditions))
+	for _, c := range cps.Status.Conditions {
+		if c.Type != condition.Type {
+			conditions = append(conditions, c)
+			continue
+		}
+		// Do not update lastTransitionTime if the status of the condition doesn't change.
+		if c.Status == condition.Status {
+			condition.LastTransitionTime = c.LastTransitionTime
+		}
+	}
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	cps.Status.Conditions = append(conditions, condition)
+}
+
+// SetupWithManager sets up the controller with the Manager. The Secrets are
+// not watched, newly labeled Secrets are picked up after the refreshTime.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(opts).
+		For(&esv1alpha1.ClusterPushSecret{}).
+		Owns(&esv1alpha1.PushSecret{}).
+		Watches(
+			&v1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForNamespace),
+			builder.WithPredicates(namespacePredicate()),
+		).
+		Complete(r)
+}
+
+func (r *Reconciler) findObjectsForNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	var clusterPushSecrets esv1alpha1.ClusterPushSecretList
+	if err := r.List(ctx, &clusterPushSecrets); err != nil {
+		r.Log.Error(err, errGetCPS)
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range clusterPushSecrets.Items {
+		cps := &clusterPushSecrets.Items[i]
+		if cps.Spec.NamespaceSelector != nil {
+			labelSelector, err := metav1.LabelSelectorAsSelector(cps.Spec.NamespaceSelector)
+			if err != nil {
+				r.Log.Error(err, errConvertLabelSelector)
+				continue
+			}
+			// the namespace may have lost the labels, so the PushSecrets
+			// of the ClusterPushSecrets that have some are checked as well.
+			if !labelSelector.Matches(labels.Set(namespace.GetLabels())) && cps.Status.PushSecrets == 0 {
+				continue
+			}
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: cps.GetName()},
+		})
+	}
+	return requests
+}
+
+func namespacePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return true
+		},
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpushsecret
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+	require.NoError(t, esv1alpha1.AddToScheme(scheme))
+
+	pushed := map[string]string{"push": "true"}
+	cps := &esv1alpha1.ClusterPushSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup"},
+		Spec: esv1alpha1.ClusterPushSecretSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			SecretSelector:    metav1.LabelSelector{MatchLabels: pushed},
+			PushSecretSpec: esv1alpha1.ClusterPushSecretTemplate{
+				SecretStoreRefs: []esv1alpha1.PushSecretStoreRef{{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind}},
+				Data: []esv1alpha1.PushSecretData{{
+					Match: esv1alpha1.PushSecretMatch{
+						SecretKey: "password",
+						RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "backup/{{ .Namespace }}/{{ .Name }}", Property: "password"},
+					},
+				}},
+			},
+			RefreshInterval: &metav1.Duration{Duration: time.Minute},
+		},
+	}
+	kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		cps,
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev"}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "prod", Labels: pushed}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod", Labels: pushed}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "prod"}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "dev", Labels: pushed}},
+		&esv1alpha1.PushSecret{ObjectMeta: metav1.ObjectMeta{Name: "backup-api", Namespace: "prod"}},
+	).WithStatusSubresource(cps).Build()
+	r := &Reconciler{Client: kube, Log: logr.Discard(), Scheme: scheme, RequeueInterval: time.Hour}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "backup"}}
+	reconcile := func() *esv1alpha1.ClusterPushSecret {
+		res, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, res.RequeueAfter)
+		var got esv1alpha1.ClusterPushSecret
+		require.NoError(t, kube.Get(context.Background(), req.NamespacedName, &got))
+		return &got
+	}
+
+	got := reconcile()
+	assert.Equal(t, 1, got.Status.PushSecrets)
+	assert.Equal(t, []esv1alpha1.ClusterPushSecretFailure{{Namespace: "prod", Name: "api", Reason: errPushSecretExists}}, got.Status.FailedSecrets)
+	require.Len(t, got.Status.Conditions, 1)
+	assert.Equal(t, v1.ConditionFalse, got.Status.Conditions[0].Status)
+	assert.Equal(t, esv1alpha1.ReasonErrored, got.Status.Conditions[0].Reason)
+
+	var ps esv1alpha1.PushSecret
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: "backup-db", Namespace: "prod"}, &ps))
+	assert.Equal(t, "db", ps.Spec.Selector.Secret.Name)
+	assert.Equal(t, "backup/prod/db", ps.Spec.Data[0].Match.RemoteRef.RemoteKey)
+	assert.Equal(t, "password", ps.Spec.Data[0].Match.RemoteRef.Property)
+	assert.Equal(t, cps.Spec.PushSecretSpec.SecretStoreRefs, ps.Spec.SecretStoreRefs)
+	assert.True(t, isPushSecretOwnedBy(&ps, "backup"))
+	assert.Error(t, kube.Get(context.Background(), types.NamespacedName{Name: "backup-db", Namespace: "dev"}, &ps))
+
+	// the secret is not selected anymore.
+	var secret v1.Secret
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: "db", Namespace: "prod"}, &secret))
+	secret.Labels = nil
+	require.NoError(t, kube.Update(context.Background(), &secret))
+	got = reconcile()
+	assert.Zero(t, got.Status.PushSecrets)
+	assert.Error(t, kube.Get(context.Background(), types.NamespacedName{Name: "backup-db", Namespace: "prod"}, &ps))
+
+	var existing esv1alpha1.PushSecret
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: "backup-api", Namespace: "prod"}, &existing))
+	require.NoError(t, kube.Delete(context.Background(), &existing))
+	got = reconcile()
+	assert.Equal(t, 1, got.Status.PushSecrets)
+	assert.Empty(t, got.Status.FailedSecrets)
+	assert.Equal(t, v1.ConditionTrue, got.Status.Conditions[0].Status)
+	assert.Equal(t, esv1alpha1.ReasonSynced, got.Status.Conditions[0].Reason)
+}
+
+func TestRenderSpec(t *testing.T) {
+	secret := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "prod"}}
+	tpl := &esv1alpha1.ClusterPushSecretTemplate{
+		Data: []esv1alpha1.PushSecretData{{
+			Match: esv1alpha1.PushSecretMatch{RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "{{ .Namespace }}-{{ .Name }}"}},
+		}},
+	}
+	spec, err := renderSpec(tpl, secret)
+	require.NoError(t, err)
+	assert.Equal(t, "prod-db", spec.Data[0].Match.RemoteRef.RemoteKey)
+	assert.Equal(t, "{{ .Namespace }}-{{ .Name }}", tpl.Data[0].Match.RemoteRef.RemoteKey, "the template must not be changed")
+
+	tpl.Data[0].Match.RemoteRef.RemoteKey = "{{ .Labels }}"
+	_, err = renderSpec(tpl, secret)
+	assert.ErrorContains(t, err, "could not render remoteKey")
+}
+
+func TestPushSecretName(t *testing.T) {
+	assert.Equal(t, "backup-db", pushSecretName("backup", "db"))
+	long := pushSecretName("backup", strings.Repeat("a", 253))
+	assert.Len(t, long, maxNameLength)
+	assert.NotEqual(t, long, pushSecretName("backup", strings.Repeat("a", 254)))
+}
@@ -0,0 +1,62 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package esmetrics
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// CardinalityFull labels the metrics with every External Secret.
+	CardinalityFull = "full"
+	// CardinalityNamespace aggregates the metrics of all External Secrets of a namespace.
+	// Metrics that can not be aggregated, like the status condition, are not exported.
+	CardinalityNamespace = "namespace"
+	// CardinalityNone disables the metrics of the External Secrets.
+	CardinalityNone = "none"
+)
+
+var cardinality = CardinalityFull
+
+// SetCardinality configures the labels of the External Secret metrics.
+// It must be called before SetUpMetrics.
+func SetCardinality(c string) error {
+	switch c {
+	case CardinalityFull, CardinalityNamespace, CardinalityNone:
+		cardinality = c
+		return nil
+	default:
+		return fmt.Errorf("invalid metrics cardinality %q, must be one of %s, %s or %s", c, CardinalityFull, CardinalityNamespace, CardinalityNone)
+	}
+}
+
+// AggregateLabels clears all labels but the namespace if the metrics
+// are not exported per External Secret, so that the time series of
+// the External Secrets in a namespace are summed up.
+func AggregateLabels(labels prometheus.Labels) prometheus.Labels {
+	if cardinality == CardinalityFull {
+		return labels
+	}
+	aggregated := prometheus.Labels{}
+	for k, v := range labels {
+		if k != "namespace" {
+			v = ""
+		}
+		aggregated[k] = v
+	}
+	return aggregated
+}
@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package esmetrics
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestAggregateLabels(t *testing.T) {
+	labels := prometheus.Labels{
+		"name":                   "foo",
+		"namespace":              "bar",
+		"app_kubernetes_io_name": "baz",
+	}
+	testCases := []struct {
+		cardinality string
+		expected    prometheus.Labels
+	}{
+		{
+			cardinality: CardinalityFull,
+			expected:    labels,
+		},
+		{
+			cardinality: CardinalityNamespace,
+			expected: prometheus.Labels{
+				"name":                   "",
+				"namespace":              "bar",
+				"app_kubernetes_io_name": "",
+			},
+		},
+	}
+	defer func() { cardinality = CardinalityFull }()
+	for _, tc := range testCases {
+		t.Run(tc.cardinality, func(t *testing.T) {
+			if err := SetCardinality(tc.cardinality); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(AggregateLabels(labels), tc.expected); diff != "" {
+				t.Errorf("AggregateLabels does not match the expected value. (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetCardinalityInvalid(t *testing.T) {
+	if err := SetCardinality("per-key"); err == nil {
+		t.Error("expected an error for an invalid cardinality")
+	}
+	if cardinality != CardinalityFull {
+		t.Errorf("cardinality was changed to %q", cardinality)
+	}
+}
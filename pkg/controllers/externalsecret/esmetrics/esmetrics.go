@@ -63,7 +63,17 @@ func SetUpMetrics() {
 		Help:      "The duration time to reconcile the External Secret",
 	}, ctrlmetrics.NonConditionMetricLabelNames)
 
-	metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, externalSecretCondition, externalSecretReconcileDuration)
+	switch cardinality {
+	case CardinalityFull:
+		syncAge = newSyncAgeCollector()
+		metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, externalSecretCondition, externalSecretReconcileDuration, syncAge)
+	case CardinalityNamespace:
+		// the conditions and durations of the External Secrets can not be summed up.
+		syncAge = newSyncAgeCollector()
+		metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, syncAge)
+	case CardinalityNone:
+		// the vectors are still created, so that they can be used without being exported.
+	}
 
 	counterVecMetrics = map[string]*prometheus.CounterVec{
 		SyncCallsKey:      syncCallsTotal,
@@ -77,6 +87,9 @@ func SetUpMetrics() {
 }
 
 func UpdateExternalSecretCondition(es *esv1beta1.ExternalSecret, condition *esv1beta1.ExternalSecretStatusCondition, value float64) {
+	if cardinality != CardinalityFull {
+		return
+	}
 	esInfo := make(map[string]string)
 	esInfo["name"] = es.Name
 	esInfo["namespace"] = es.Namespace
@@ -88,18 +101,20 @@ func UpdateExternalSecretCondition(es *esv1beta1.ExternalSecret, condition *esv1
 
 	switch condition.Type {
 	case esv1beta1.ExternalSecretDeleted:
-		// Remove condition=Ready metrics when the object gets deleted.
-		externalSecretCondition.Delete(ctrlmetrics.RefineLabels(conditionLabels,
-			map[string]string{
-				"condition": string(esv1beta1.ExternalSecretReady),
-				"status":    string(v1.ConditionFalse),
-			}))
+		// Remove condition=Ready and condition=Stale metrics when the object gets deleted.
+		for _, t := range []esv1beta1.ExternalSecretConditionType{esv1beta1.ExternalSecretReady, esv1beta1.ExternalSecretStale} {
+			externalSecretCondition.Delete(ctrlmetrics.RefineLabels(conditionLabels,
+				map[string]string{
+					"condition": string(t),
+					"status":    string(v1.ConditionFalse),
+				}))
 
-		externalSecretCondition.Delete(ctrlmetrics.RefineLabels(conditionLabels,
-			map[string]string{
-				"condition": string(esv1beta1.ExternalSecretReady),
-				"status":    string(v1.ConditionTrue),
-			}))
+			externalSecretCondition.Delete(ctrlmetrics.RefineLabels(conditionLabels,
+				map[string]string{
+					"condition": string(t),
+					"status":    string(v1.ConditionTrue),
+				}))
+		}
 
 	case esv1beta1.ExternalSecretReady:
 		// Remove condition=Deleted metrics when the object gets ready.
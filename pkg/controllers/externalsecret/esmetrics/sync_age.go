@@ -0,0 +1,108 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package esmetrics
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+)
+
+const ExternalSecretSyncAgeKey = "sync_age_seconds"
+
+// syncAgeCollector reports the seconds since the last successful sync of
+// every ExternalSecret. The age is computed when the metric is collected,
+// so it keeps growing while an ExternalSecret is not synced, even if it is
+// not reconciled at all. If the labels of several ExternalSecrets are
+// aggregated, the oldest sync is reported.
+type syncAgeCollector struct {
+	desc *prometheus.Desc
+	now  func() time.Time
+
+	mu       sync.RWMutex
+	lastSync map[types.NamespacedName]syncAgeEntry
+}
+
+type syncAgeEntry struct {
+	labelValues []string
+	time        time.Time
+}
+
+var syncAge *syncAgeCollector
+
+func newSyncAgeCollector() *syncAgeCollector {
+	return &syncAgeCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName("", ExternalSecretSubsystem, ExternalSecretSyncAgeKey),
+			"Seconds since the last successful sync of the External Secret",
+			ctrlmetrics.NonConditionMetricLabelNames, nil,
+		),
+		now:      time.Now,
+		lastSync: make(map[types.NamespacedName]syncAgeEntry),
+	}
+}
+
+func (c *syncAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *syncAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := c.now()
+	oldest := make(map[string]syncAgeEntry, len(c.lastSync))
+	for _, e := range c.lastSync {
+		key := strings.Join(e.labelValues, "\xff")
+		if o, ok := oldest[key]; ok && o.time.Before(e.time) {
+			continue
+		}
+		oldest[key] = e
+	}
+	for _, e := range oldest {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, now.Sub(e.time).Seconds(), e.labelValues...)
+	}
+}
+
+// SetLastSync records the time of the last successful sync of the External Secret.
+func SetLastSync(es *esv1beta1.ExternalSecret, t time.Time) {
+	if syncAge == nil {
+		return
+	}
+	labels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": es.Name, "namespace": es.Namespace})
+	labels = AggregateLabels(ctrlmetrics.RefineLabels(labels, es.Labels))
+	values := make([]string, 0, len(ctrlmetrics.NonConditionMetricLabelNames))
+	for _, name := range ctrlmetrics.NonConditionMetricLabelNames {
+		values = append(values, labels[name])
+	}
+	syncAge.mu.Lock()
+	defer syncAge.mu.Unlock()
+	syncAge.lastSync[types.NamespacedName{Namespace: es.Namespace, Name: es.Name}] = syncAgeEntry{labelValues: values, time: t}
+}
+
+// DeleteLastSync removes the External Secret from the sync age metric.
+func DeleteLastSync(namespace, name string) {
+	if syncAge == nil {
+		return
+	}
+	syncAge.mu.Lock()
+	defer syncAge.mu.Unlock()
+	delete(syncAge.lastSync, types.NamespacedName{Namespace: namespace, Name: name})
+}
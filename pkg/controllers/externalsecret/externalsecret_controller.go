@@ -17,12 +17,16 @@ package externalsecret
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/maps"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -36,16 +40,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
 	// Metrics.
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 	// Loading registered generators.
 	_ "github.com/external-secrets/external-secrets/pkg/generator/register"
 	// Loading registered providers.
 	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
 	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/redact"
 )
 
 const (
@@ -54,13 +63,16 @@ const (
 	errConvert              = "could not apply conversion strategy to keys: %v"
 	errDecode               = "could not apply decoding strategy to %v[%d]: %v"
 	errGenerate             = "could not generate [%d]: %w"
+	errGeneratorState       = "could not store generator state [%d]: %w"
 	errRewrite              = "could not rewrite spec.dataFrom[%d]: %v"
 	errInvalidKeys          = "secret keys from spec.dataFrom.%v[%d] can only have alphanumeric,'-', '_' or '.' characters. Convert them using rewrite (https://external-secrets.io/latest/guides-datafrom-rewrite)"
 	errUpdateSecret         = "could not update Secret"
+	errReloadWorkloads      = "could not reload workloads"
 	errPatchStatus          = "unable to patch status"
 	errGetExistingSecret    = "could not get existing secret: %w"
 	errSetCtrlReference     = "could not set ExternalSecret controller reference: %w"
 	errFetchTplFrom         = "error fetching templateFrom data: %w"
+	errGetTemplateStore     = "could not get store %s for template metadata: %w"
 	errGetSecretData        = "could not get secret data from provider"
 	errDeleteSecret         = "could not delete secret"
 	errApplyTemplate        = "could not apply template: %w"
@@ -82,7 +94,52 @@ type Reconciler struct {
 	RequeueInterval           time.Duration
 	ClusterSecretStoreEnabled bool
 	EnableFloodGate           bool
-	recorder                  record.EventRecorder
+	EnableWorkloadReload      bool
+	// EnableLazySync watches Pods to start the first sync of ExternalSecrets
+	// with spec.target.lazy once their Secret is referenced.
+	EnableLazySync bool
+	// EnableNamespaceOptOut stops the sync of the ExternalSecrets in
+	// namespaces with the label external-secrets.io/opt-out.
+	EnableNamespaceOptOut bool
+	// EnableChecksumAnnotations sets the sha256 sums of the data on the
+	// target Secrets.
+	EnableChecksumAnnotations bool
+	// EnableSyncPolicies applies the defaults of the SecretSyncPolicies to
+	// the ExternalSecrets that do not set the fields.
+	EnableSyncPolicies bool
+	// FreshnessThreshold is the default maximum age of the last successful
+	// sync before an ExternalSecret is marked as stale. Zero disables it.
+	FreshnessThreshold time.Duration
+	// OfflineThreshold is the time a store has to fail its validation before
+	// failed syncs of the ExternalSecrets using it keep their secrets
+	// without failing. Zero disables the offline mode.
+	OfflineThreshold time.Duration
+	// EventAggregationInterval is the interval repeated identical warning
+	// events of an ExternalSecret are emitted at. Zero disables the aggregation.
+	EventAggregationInterval time.Duration
+	// RefreshJitter is the maximum fraction of the refresh interval that is
+	// added to it, so that ExternalSecrets created at the same time are not
+	// refreshed at the same time. Zero disables the jitter.
+	RefreshJitter float64
+	// Batcher shares the provider calls of ExternalSecrets that reference
+	// the same store. Nil disables the batching.
+	Batcher *secretstore.Batcher
+	// DiskCache serves the last values read from the providers while they
+	// fail. Nil disables the cache.
+	DiskCache *secretstore.DiskCache
+	// APIReader lists the workloads to reload without caching them.
+	APIReader client.Reader
+	Auditor   *audit.Auditor
+	// StartupLimiter limits the rate of the first syncs after the start.
+	// Nil does not limit them.
+	StartupLimiter *StartupLimiter
+	recorder       record.EventRecorder
+	priority       priorityGate
+	// entryCache keeps the values of the entries that are not due yet.
+	entryCache entryCache
+	// disableDataCache reads every data entry from the provider, regardless
+	// of its refreshInterval, without caching the values.
+	disableDataCache bool
 }
 
 // Reconcile implements the main reconciliation loop
@@ -91,7 +148,12 @@ type Reconciler struct {
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("ExternalSecret", req.NamespacedName)
 
-	resourceLabels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": req.Name, "namespace": req.Namespace})
+	if r.waitForPriority(ctx, req.NamespacedName) {
+		log.V(1).Info("waiting for ExternalSecrets with a higher priority")
+		return ctrl.Result{RequeueAfter: priorityRequeueDelay}, nil
+	}
+
+	resourceLabels := esmetrics.AggregateLabels(ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": req.Name, "namespace": req.Namespace}))
 	start := time.Now()
 
 	syncCallsError := esmetrics.GetCounterVec(esmetrics.SyncCallsErrorKey)
@@ -114,6 +176,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 					Namespace: req.Namespace,
 				},
 			}, *conditionSynced)
+			esmetrics.DeleteLastSync(req.Namespace, req.Name)
+			if a, ok := r.recorder.(*aggregatingRecorder); ok {
+				a.forget(req.NamespacedName)
+			}
+			r.forgetEntries(req.NamespacedName)
+			r.StartupLimiter.forget(req.NamespacedName)
 
 			return ctrl.Result{}, nil
 		}
@@ -124,6 +192,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	firstReconcile := r.StartupLimiter.firstReconcile(req.NamespacedName, externalSecret.UID)
+
 	timeSinceLastRefresh := 0 * time.Second
 	if !externalSecret.Status.RefreshTime.IsZero() {
 		timeSinceLastRefresh = time.Since(externalSecret.Status.RefreshTime.Time)
@@ -136,7 +206,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	// if extended metrics is enabled, refine the time series vector
-	resourceLabels = ctrlmetrics.RefineLabels(resourceLabels, externalSecret.Labels)
+	resourceLabels = esmetrics.AggregateLabels(ctrlmetrics.RefineLabels(resourceLabels, externalSecret.Labels))
+
+	// restore the sync age after a restart of the controller
+	if !externalSecret.Status.RefreshTime.IsZero() {
+		esmetrics.SetLastSync(&externalSecret, externalSecret.Status.RefreshTime.Time)
+	}
 
 	if shouldSkipClusterSecretStore(r, externalSecret) {
 		log.Info("skipping cluster secret store as it is disabled")
@@ -150,11 +225,23 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
-	refreshInt := r.RequeueInterval
-	if externalSecret.Spec.RefreshInterval != nil {
-		refreshInt = externalSecret.Spec.RefreshInterval.Duration
+	if err := r.applyDefaults(ctx, &externalSecret); err != nil {
+		log.Error(err, errApplyDefaults)
+		syncCallsError.With(resourceLabels).Inc()
+		return ctrl.Result{}, err
+	}
+
+	optOut, optedOut, err := r.namespaceOptOut(ctx, &externalSecret)
+	if err != nil {
+		log.Error(err, errCheckOptOut)
+		syncCallsError.With(resourceLabels).Inc()
+		return ctrl.Result{}, err
 	}
 
+	interval := shortestRefreshInterval(&externalSecret, r.RequeueInterval)
+	jitter := r.refreshJitter(&externalSecret, interval)
+	refreshInt := interval + jitter
+
 	// Target Secret Name should default to the ExternalSecret name if not explicitly specified
 	secretName := externalSecret.Spec.Target.Name
 	if secretName == "" {
@@ -176,12 +263,24 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	// 1. resource generation hasn't changed
 	// 2. refresh interval is 0
 	// 3. if we're still within refresh-interval
-	if !shouldRefresh(externalSecret) && isSecretValid(existingSecret) {
-		refreshInt = (externalSecret.Spec.RefreshInterval.Duration - timeSinceLastRefresh) + 5*time.Second
+	// 4. if none of the data entries is due
+	refresh := shouldRefresh(externalSecret)
+	nextEntry, entriesCached := r.nextEntryRefresh(&externalSecret)
+	if entriesCached && !specChanged(externalSecret) {
+		refresh = !nextEntry.IsZero() && !time.Now().Before(nextEntry)
+	}
+	if !optedOut && !refresh && isSecretValid(existingSecret) {
+		refreshInt = (interval - timeSinceLastRefresh) + jitter + 5*time.Second
+		if entriesCached {
+			refreshInt = time.Until(nextEntry) + jitter + 5*time.Second
+			if nextEntry.IsZero() {
+				refreshInt = 0
+			}
+		}
 		log.V(1).Info("skipping refresh", "rv", getResourceVersion(externalSecret), "nr", refreshInt.Seconds())
 		return ctrl.Result{RequeueAfter: refreshInt}, nil
 	}
-	if !shouldReconcile(externalSecret) {
+	if !optedOut && !shouldReconcile(externalSecret) {
 		log.V(1).Info("stopping reconciling", "rv", getResourceVersion(externalSecret))
 		return ctrl.Result{}, nil
 	}
@@ -189,12 +288,54 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	// patch status when done processing
 	p := client.MergeFrom(externalSecret.DeepCopy())
 	defer func() {
+		// the conditions describe the current generation, also if the sync failed.
+		externalSecret.Status.ObservedGeneration = externalSecret.Generation
+		externalSecret.Status.Store = storeList(&externalSecret)
+		r.setStaleCondition(&externalSecret, time.Now())
 		err = r.Status().Patch(ctx, &externalSecret, p)
 		if err != nil {
 			log.Error(err, errPatchStatus)
 		}
 	}()
 
+	// the sync stops until the namespace opts back in, the namespace is watched.
+	if optedOut {
+		log.Info("skipping as the namespace opted out")
+		if err := r.stopSync(ctx, &externalSecret, optOut); err != nil {
+			r.markAsFailed(log, errDeleteSecret, err, &externalSecret, syncCallsError.With(resourceLabels))
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// the policies are checked on admission, ExternalSecrets that do not
+	// comply stop syncing and keep their secret.
+	policies, err := r.externalSecretPolicies(ctx, externalSecret.Namespace)
+	if err != nil {
+		r.markAsFailed(log, errListPolicies, err, &externalSecret, syncCallsError.With(resourceLabels))
+		return ctrl.Result{}, err
+	}
+	if denied := esv1alpha1.CheckExternalSecretPolicies(policies, &externalSecret); denied != nil {
+		r.markAsFailedWithReason(log, esv1beta1.ConditionReasonPolicyDenied, fmt.Sprintf(msgPolicyDenied, denied), denied, &externalSecret, syncCallsError.With(resourceLabels))
+		return ctrl.Result{RequeueAfter: refreshInt}, nil
+	}
+
+	deferred, err := r.deferSync(ctx, &externalSecret, &existingSecret)
+	if err != nil {
+		r.markAsFailed(log, errGetSecretData, err, &externalSecret, syncCallsError.With(resourceLabels))
+		return ctrl.Result{}, err
+	}
+	if deferred {
+		conditionDeferred := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonSecretDeferred, fmt.Sprintf(msgSyncDeferred, secretName))
+		SetExternalSecretCondition(&externalSecret, *conditionDeferred)
+		return ctrl.Result{RequeueAfter: refreshInt}, nil
+	}
+
+	// record the outcome of the sync once the conditions are set.
+	defer func() {
+		r.Auditor.Sync(&externalSecret, secretName, syncFailure(&externalSecret), time.Since(start))
+	}()
+
 	secret := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      secretName,
@@ -204,8 +345,36 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		Data:      make(map[string][]byte),
 	}
 
-	dataMap, err := r.getProviderSecretData(ctx, &externalSecret)
+	// the first syncs after a start are limited, so the providers are not
+	// called for all ExternalSecrets that are due at once.
+	if firstReconcile {
+		if err := r.StartupLimiter.wait(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	dataMap, unresolved, err := r.getProviderSecretData(ctx, &externalSecret)
 	if err != nil {
+		err = redact.Error(err)
+		// the existing secret is kept without failing while the store is offline.
+		if existingSecret.UID != "" {
+			if msg := r.offlineStore(ctx, &externalSecret, time.Now()); msg != "" {
+				r.markAsOffline(log, &externalSecret, msg, err)
+				return ctrl.Result{RequeueAfter: refreshInt}, nil
+			}
+		}
+		// providers may report a more specific reason than the generic one.
+		var reasonErr *esv1beta1.ReasonError
+		if errors.As(err, &reasonErr) {
+			r.markAsFailedWithReason(log, reasonErr.Reason, err.Error(), err, &externalSecret, syncCallsError.With(resourceLabels))
+			return ctrl.Result{}, err
+		}
+		r.markAsFailed(log, errGetSecretData, err, &externalSecret, syncCallsError.With(resourceLabels))
+		return ctrl.Result{}, err
+	}
+	externalSecret.Status.UnresolvedKeys = unresolved
+	if err := r.keepPushedData(ctx, &externalSecret, &existingSecret, dataMap); err != nil {
+		err = redact.Error(err, maps.Values(dataMap)...)
 		r.markAsFailed(log, errGetSecretData, err, &externalSecret, syncCallsError.With(resourceLabels))
 		return ctrl.Result{}, err
 	}
@@ -228,6 +397,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 				return ctrl.Result{}, err
 			}
 
+			externalSecret.Status.KeyCount = 0
 			conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionTrue, esv1beta1.ConditionReasonSecretDeleted, "secret deleted due to DeletionPolicy")
 			SetExternalSecretCondition(&externalSecret, *conditionSynced)
 			return ctrl.Result{RequeueAfter: refreshInt}, nil
@@ -240,6 +410,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
+	var droppedKeys []string
+	var secretParts []map[string][]byte
 	mutationFunc := func() error {
 		if externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
 			err = controllerutil.SetControllerReference(&externalSecret, &secret.ObjectMeta, r.Scheme)
@@ -267,12 +439,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		if err != nil {
 			return fmt.Errorf(errApplyTemplate, err)
 		}
+		droppedKeys, secretParts, err = applyOversizePolicy(&externalSecret, secret)
+		if err != nil {
+			return err
+		}
 		if externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
 			lblValue := utils.ObjectHash(fmt.Sprintf("%v/%v", externalSecret.Namespace, externalSecret.Name))
 			secret.Labels[esv1beta1.LabelOwner] = lblValue
 		}
 
 		secret.Annotations[esv1beta1.AnnotationDataHash] = r.computeDataHashAnnotation(&existingSecret, secret)
+		if r.EnableChecksumAnnotations {
+			setChecksumAnnotations(secret, mergedData(&existingSecret, secret))
+		}
 
 		return nil
 	}
@@ -292,6 +471,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		if err == nil {
 			externalSecret.Status.Binding = v1.LocalObjectReference{Name: secret.Name}
 		}
+		// the parts are only listed while the ExternalSecret splits its data
+		// or parts of an earlier sync are left to clean up.
+		if err == nil && externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner && (isSplit(&externalSecret) || externalSecret.Status.SecretParts > 0) {
+			err = r.syncSecretParts(ctx, &externalSecret, secret.Name, secretParts)
+			if err == nil {
+				externalSecret.Status.SecretParts = int32(len(secretParts))
+			}
+		}
 		// cleanup orphaned secrets
 		if created {
 			delErr := deleteOrphanedSecrets(ctx, r.Client, &externalSecret)
@@ -303,24 +490,75 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
+	var tooLarge *secretTooLargeError
+	if errors.As(err, &tooLarge) {
+		r.markAsFailedWithReason(log, esv1beta1.ConditionReasonSecretTooLarge, tooLarge.Error(), err, &externalSecret, syncCallsError.With(resourceLabels))
+		return ctrl.Result{}, err
+	}
 	if err != nil {
+		// templating errors may contain the values of the secret.
+		err = redact.Error(err, maps.Values(dataMap)...)
 		r.markAsFailed(log, errUpdateSecret, err, &externalSecret, syncCallsError.With(resourceLabels))
 		return ctrl.Result{}, err
 	}
 
+	if externalSecret.Spec.Target.CreationPolicy != esv1beta1.CreatePolicyNone {
+		hash := secret.Annotations[esv1beta1.AnnotationDataHash]
+		changed := existingSecret.UID != "" && existingSecret.Annotations[esv1beta1.AnnotationDataHash] != hash
+		// the secret was synced, a failed reload does not fail the sync.
+		if err := r.reloadWorkloads(ctx, &externalSecret, secret.Name, hash, changed); err != nil {
+			log.Error(err, errReloadWorkloads)
+			r.recorder.Event(&externalSecret, v1.EventTypeWarning, esv1beta1.ReasonReloadFailed, err.Error())
+		}
+	}
+
+	if externalSecret.Spec.Target.CreationPolicy != esv1beta1.CreatePolicyNone {
+		externalSecret.Status.KeyCount = int32(len(secret.Data))
+	}
+
+	if len(droppedKeys) > 0 {
+		r.recorder.Eventf(&externalSecret, v1.EventTypeWarning, esv1beta1.ReasonKeysDropped, msgKeysDropped, strings.Join(droppedKeys, ", "))
+	}
+
 	r.markAsDone(&externalSecret, start, log)
 
+	// only the entries that are due are read on the next refresh.
+	if nextEntry, ok := r.nextEntryRefresh(&externalSecret); ok {
+		refreshInt = 0
+		if !nextEntry.IsZero() {
+			refreshInt = time.Until(nextEntry) + jitter
+		}
+	}
+
 	return ctrl.Result{
 		RequeueAfter: refreshInt,
 	}, nil
 }
 
+// refreshJitter returns the delay that is added to the refresh interval of the
+// ExternalSecret. It is derived from the UID, so that it is the same for every
+// reconcile and the refreshes stay spread.
+func (r *Reconciler) refreshJitter(es *esv1beta1.ExternalSecret, interval time.Duration) time.Duration {
+	if r.RefreshJitter <= 0 || interval <= 0 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(es.UID))
+	fraction := float64(h.Sum32()) / math.MaxUint32
+	return time.Duration(fraction * r.RefreshJitter * float64(interval))
+}
+
 func (r *Reconciler) markAsDone(externalSecret *esv1beta1.ExternalSecret, start time.Time, log logr.Logger) {
 	r.recorder.Event(externalSecret, v1.EventTypeNormal, esv1beta1.ReasonUpdated, "Updated Secret")
 	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionTrue, esv1beta1.ConditionReasonSecretSynced, "Secret was synced")
+	if unresolved := externalSecret.Status.UnresolvedKeys; len(unresolved) > 0 {
+		r.recorder.Eventf(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonKeysUnresolved, msgKeysUnresolved, describeUnresolved(unresolved))
+		conditionSynced = NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionTrue, esv1beta1.ConditionReasonSecretPartiallySynced, fmt.Sprintf(msgPartiallySynced, len(unresolved)))
+	}
 	currCond := GetExternalSecretCondition(externalSecret.Status, esv1beta1.ExternalSecretReady)
 	SetExternalSecretCondition(externalSecret, *conditionSynced)
 	externalSecret.Status.RefreshTime = metav1.NewTime(start)
+	esmetrics.SetLastSync(externalSecret, start)
 	externalSecret.Status.SyncedResourceVersion = getResourceVersion(*externalSecret)
 	if currCond == nil || currCond.Status != conditionSynced.Status {
 		log.Info("reconciled secret") // Log once if on success in any verbosity
@@ -330,13 +568,33 @@ func (r *Reconciler) markAsDone(externalSecret *esv1beta1.ExternalSecret, start
 }
 
 func (r *Reconciler) markAsFailed(log logr.Logger, msg string, err error, externalSecret *esv1beta1.ExternalSecret, counter prometheus.Counter) {
+	r.markAsFailedWithReason(log, esv1beta1.ConditionReasonSecretSyncedError, msg, err, externalSecret, counter)
+}
+
+func (r *Reconciler) markAsFailedWithReason(log logr.Logger, reason, msg string, err error, externalSecret *esv1beta1.ExternalSecret, counter prometheus.Counter) {
 	log.Error(err, msg)
 	r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, err.Error())
-	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonSecretSyncedError, msg)
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, reason, msg)
 	SetExternalSecretCondition(externalSecret, *conditionSynced)
 	counter.Inc()
 }
 
+// syncFailure returns the message of the Ready condition of es if it is not
+// true, it is empty if the last sync succeeded.
+func syncFailure(es *esv1beta1.ExternalSecret) string {
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+	if cond == nil {
+		return "no ready condition"
+	}
+	if cond.Status == v1.ConditionTrue && cond.Reason != esv1beta1.ConditionReasonStoreOffline {
+		return ""
+	}
+	if cond.Message == "" {
+		return cond.Reason
+	}
+	return cond.Message
+}
+
 func deleteOrphanedSecrets(ctx context.Context, cl client.Client, externalSecret *esv1beta1.ExternalSecret) error {
 	secretList := v1.SecretList{}
 	lblValue := utils.ObjectHash(fmt.Sprintf("%v/%v", externalSecret.Namespace, externalSecret.Name))
@@ -354,7 +612,7 @@ func deleteOrphanedSecrets(ctx context.Context, cl client.Client, externalSecret
 		return err
 	}
 	for key, secret := range secretList.Items {
-		if externalSecret.Spec.Target.Name != "" && secret.Name != externalSecret.Spec.Target.Name {
+		if externalSecret.Spec.Target.Name != "" && secret.Name != externalSecret.Spec.Target.Name && !isSecretPart(secret.Name, externalSecret.Spec.Target.Name) {
 			err = cl.Delete(ctx, &secretList.Items[key])
 			if err != nil {
 				return err
@@ -566,19 +824,30 @@ func shouldSkipUnmanagedStore(ctx context.Context, namespace string, r *Reconcil
 }
 
 func shouldRefresh(es esv1beta1.ExternalSecret) bool {
-	// refresh if resource version changed
-	if es.Status.SyncedResourceVersion != getResourceVersion(es) {
+	if specChanged(es) {
 		return true
 	}
 
+	interval := shortestRefreshInterval(&es, 0)
 	// skip refresh if refresh interval is 0
-	if es.Spec.RefreshInterval.Duration == 0 && es.Status.SyncedResourceVersion != "" {
+	if interval == 0 && es.Status.SyncedResourceVersion != "" {
 		return false
 	}
 	if es.Status.RefreshTime.IsZero() {
 		return true
 	}
-	return es.Status.RefreshTime.Add(es.Spec.RefreshInterval.Duration).Before(time.Now())
+	return es.Status.RefreshTime.Add(interval).Before(time.Now())
+}
+
+// specChanged reports whether the ExternalSecret must be synced regardless
+// of its refresh interval.
+func specChanged(es esv1beta1.ExternalSecret) bool {
+	// refresh if resource version changed
+	if es.Status.SyncedResourceVersion != getResourceVersion(es) {
+		return true
+	}
+	// resume the sync after the namespace opted back in
+	return isSyncStopped(&es)
 }
 
 func shouldReconcile(es esv1beta1.ExternalSecret) bool {
@@ -613,6 +882,11 @@ func isSecretValid(existingSecret v1.Secret) bool {
 
 // computeDataHashAnnotation generate a hash of the secret data combining the old key with the new keys to add or override.
 func (r *Reconciler) computeDataHashAnnotation(existing, secret *v1.Secret) string {
+	return utils.ObjectHash(mergedData(existing, secret))
+}
+
+// mergedData returns the data of the existing secret updated with the data of secret.
+func mergedData(existing, secret *v1.Secret) map[string][]byte {
 	data := make(map[string][]byte)
 	for k, v := range existing.Data {
 		data[k] = v
@@ -620,16 +894,29 @@ func (r *Reconciler) computeDataHashAnnotation(existing, secret *v1.Secret) stri
 	for k, v := range secret.Data {
 		data[k] = v
 	}
-	return utils.ObjectHash(data)
+	return data
 }
 
 // SetupWithManager returns a new controller builder that will be started by the provided Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	r.recorder = mgr.GetEventRecorderFor("external-secrets")
+	if r.EventAggregationInterval > 0 {
+		r.recorder = newAggregatingRecorder(r.recorder, r.EventAggregationInterval)
+	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
-		For(&esv1beta1.ExternalSecret{}).
+		For(&esv1beta1.ExternalSecret{}, builder.WithPredicates(r.trackPriority())).
 		Owns(&v1.Secret{}, builder.OnlyMetadata).
-		Complete(r)
+		Watches(&esv1beta1.SecretStore{}, handler.EnqueueRequestsFromMapFunc(r.externalSecretsForStore), builder.WithPredicates(storeBecameReady))
+	if r.ClusterSecretStoreEnabled {
+		b = b.Watches(&esv1beta1.ClusterSecretStore{}, handler.EnqueueRequestsFromMapFunc(r.externalSecretsForStore), builder.WithPredicates(storeBecameReady))
+	}
+	if r.EnableLazySync {
+		b = b.Watches(&v1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.externalSecretsForPod), builder.WithPredicates(podCreated))
+	}
+	if r.EnableNamespaceOptOut {
+		b = b.Watches(&v1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.externalSecretsForNamespace), builder.WithPredicates(optOutChanged))
+	}
+	return b.Complete(r)
 }
@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// ManagedSecretsCache restricts the Secret informer to the Secrets that are
+// owned by an ExternalSecret, so that the memory usage of the controller does
+// not grow with the number of Secrets in the cluster. Only the owned Secrets
+// are watched by the controller, all other Secrets must be read without cache.
+func ManagedSecretsCache() (cache.ByObject, error) {
+	owned, err := labels.NewRequirement(esv1beta1.LabelOwner, selection.Exists, nil)
+	if err != nil {
+		return cache.ByObject{}, err
+	}
+	return cache.ByObject{
+		Label:     labels.NewSelector().Add(*owned),
+		Transform: stripManagedFields,
+	}, nil
+}
+
+// stripManagedFields drops the managed fields, the controller does not use them.
+func stripManagedFields(in any) (any, error) {
+	if obj, ok := in.(metav1.Object); ok {
+		obj.SetManagedFields(nil)
+	}
+	return in, nil
+}
@@ -0,0 +1,45 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestManagedSecretsCache(t *testing.T) {
+	byObject, err := ManagedSecretsCache()
+	require.NoError(t, err)
+
+	assert.True(t, byObject.Label.Matches(labels.Set{esv1beta1.LabelOwner: "hash"}))
+	assert.False(t, byObject.Label.Matches(labels.Set{"app": "foo"}))
+
+	obj := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "foo",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "external-secrets"}},
+		},
+	}
+	out, err := byObject.Transform(obj)
+	require.NoError(t, err)
+	assert.Empty(t, out.(*metav1.PartialObjectMetadata).ManagedFields)
+	assert.Equal(t, "foo", out.(*metav1.PartialObjectMetadata).Name)
+}
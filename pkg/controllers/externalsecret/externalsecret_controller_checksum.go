@@ -0,0 +1,52 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// setChecksumAnnotations sets the sha256 sums of the values of data and of
+// data as a whole on the secret, so tools can detect drift without reading
+// the values. The sum of the whole data is the sum of the lines
+// <key>:<sum of the value> sorted by key, so it can be computed from the sums
+// of the keys.
+func setChecksumAnnotations(secret *v1.Secret, data map[string][]byte) {
+	sums := make(map[string]string, len(data))
+	keys := make([]string, 0, len(data))
+	for k, v := range data {
+		sum := sha256.Sum256(v)
+		sums[k] = hex.EncodeToString(sum[:])
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var lines strings.Builder
+	for _, k := range keys {
+		lines.WriteString(k + ":" + sums[k] + "\n")
+	}
+	// json.Marshal sorts the keys, the annotation only changes with the data.
+	keySums, _ := json.Marshal(sums)
+	sum := sha256.Sum256([]byte(lines.String()))
+	secret.Annotations[esv1beta1.AnnotationChecksum] = hex.EncodeToString(sum[:])
+	secret.Annotations[esv1beta1.AnnotationKeyChecksums] = string(keySums)
+}
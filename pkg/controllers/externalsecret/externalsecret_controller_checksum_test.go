@@ -0,0 +1,45 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestSetChecksumAnnotations(t *testing.T) {
+	secret := &v1.Secret{}
+	secret.Annotations = map[string]string{}
+	setChecksumAnnotations(secret, map[string][]byte{
+		"user":     []byte("admin"),
+		"password": []byte("s3cr3t"),
+	})
+
+	// the sums can be verified with sha256sum, e.g.
+	// printf 'password:%s\nuser:%s\n' "$(printf s3cr3t | sha256sum | cut -d' ' -f1)" "$(printf admin | sha256sum | cut -d' ' -f1)" | sha256sum
+	assert.Equal(t, map[string]string{
+		esv1beta1.AnnotationChecksum: "83b733927d63a7300188c148b854f5b58ae4552492197131f71d102b7c327340",
+		esv1beta1.AnnotationKeyChecksums: `{"password":"4e738ca5563c06cfd0018299933d58db1dd8bf97f6973dc99bf6cdc64b5550bd",` +
+			`"user":"8c6976e5b5410415bde908bd4dee15dfb167a9c873fc4bb8a81f6f2ab448a918"}`,
+	}, secret.Annotations)
+
+	setChecksumAnnotations(secret, nil)
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", secret.Annotations[esv1beta1.AnnotationChecksum])
+	assert.Equal(t, "{}", secret.Annotations[esv1beta1.AnnotationKeyChecksums])
+}
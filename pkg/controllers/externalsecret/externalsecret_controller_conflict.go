@@ -0,0 +1,60 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const errListPushSecrets = "could not list PushSecrets: %w"
+
+// keepPushedData keeps the values of the existing secret for keys that a
+// PushSecret with conflictPolicy ClusterWins pushes from the secret to the
+// provider. Pulling them would oscillate values between the cluster and the
+// provider.
+func (r *Reconciler) keepPushedData(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, existingSecret *v1.Secret, dataMap map[string][]byte) error {
+	if existingSecret.Data == nil {
+		return nil
+	}
+	var psList esv1alpha1.PushSecretList
+	if err := r.List(ctx, &psList, client.InNamespace(externalSecret.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf(errListPushSecrets, err)
+	}
+	for _, ps := range psList.Items {
+		if ps.Spec.ConflictPolicy != esv1alpha1.PushSecretConflictPolicyClusterWins {
+			continue
+		}
+		for _, conflict := range ps.Status.Conflicts {
+			if conflict.ExternalSecret != externalSecret.Name {
+				continue
+			}
+			if value, ok := existingSecret.Data[conflict.SecretKey]; ok {
+				dataMap[conflict.SecretKey] = value
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestKeepPushedData(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+	require.NoError(t, esv1alpha1.AddToScheme(scheme))
+	conflicts := []esv1alpha1.PushSecretConflict{
+		{ExternalSecret: "es", SecretKey: "password", Store: "SecretStore/chef", RemoteKey: "app/db"},
+	}
+	existing := &corev1.Secret{
+		Data: map[string][]byte{"password": []byte("cluster"), "username": []byte("app")},
+	}
+	tests := []struct {
+		name     string
+		policy   esv1alpha1.PushSecretConflictPolicy
+		existing *corev1.Secret
+		want     map[string][]byte
+	}{
+		{
+			name:     "cluster wins",
+			policy:   esv1alpha1.PushSecretConflictPolicyClusterWins,
+			existing: existing,
+			want:     map[string][]byte{"password": []byte("cluster"), "username": []byte("provider")},
+		},
+		{
+			name:     "provider wins",
+			policy:   esv1alpha1.PushSecretConflictPolicyProviderWins,
+			existing: existing,
+			want:     map[string][]byte{"password": []byte("provider"), "username": []byte("provider")},
+		},
+		{
+			name:     "missing secret is pulled",
+			policy:   esv1alpha1.PushSecretConflictPolicyClusterWins,
+			existing: &corev1.Secret{},
+			want:     map[string][]byte{"password": []byte("provider"), "username": []byte("provider")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &esv1alpha1.PushSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "default"},
+				Spec:       esv1alpha1.PushSecretSpec{ConflictPolicy: tt.policy},
+				Status:     esv1alpha1.PushSecretStatus{Conflicts: conflicts},
+			}
+			kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(ps).Build()
+			r := &Reconciler{Client: kube, Scheme: scheme}
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+			}
+			dataMap := map[string][]byte{"password": []byte("provider"), "username": []byte("provider")}
+			require.NoError(t, r.keepPushedData(context.Background(), es, tt.existing, dataMap))
+			assert.Equal(t, tt.want, dataMap)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	// defaultRefreshInterval is the refreshInterval of the ExternalSecrets
+	// that neither set one nor get one from a SecretSyncPolicy.
+	defaultRefreshInterval = time.Hour
+
+	errApplyDefaults      = "could not apply defaults"
+	errListSyncPolicies   = "could not list SecretSyncPolicies: %w"
+	errSyncPolicySelector = "invalid namespaceSelector of SecretSyncPolicy %s: %w"
+)
+
+// applyDefaults sets the fields the ExternalSecret does not set to the
+// defaults of the SecretSyncPolicies of its namespace, then to the defaults
+// of the controller. Only the copy of the reconciler is changed, the
+// resource keeps the fields unset so that changed defaults apply to it.
+func (r *Reconciler) applyDefaults(ctx context.Context, es *esv1beta1.ExternalSecret) error {
+	policies, err := r.syncPolicies(ctx, es.Namespace)
+	if err != nil {
+		return err
+	}
+	for i := range policies {
+		applySyncPolicy(es, &policies[i].Spec)
+	}
+	if es.Spec.RefreshInterval == nil {
+		es.Spec.RefreshInterval = &metav1.Duration{Duration: defaultRefreshInterval}
+	}
+	if es.Spec.Target.DeletionPolicy == "" {
+		es.Spec.Target.DeletionPolicy = esv1beta1.DeletionPolicyRetain
+	}
+	if tpl := es.Spec.Target.Template; tpl != nil && tpl.EngineVersion == "" {
+		tpl.EngineVersion = esv1beta1.TemplateEngineV2
+	}
+	return nil
+}
+
+// syncPolicies returns the SecretSyncPolicies that select the namespace,
+// ordered by their name.
+func (r *Reconciler) syncPolicies(ctx context.Context, namespace string) ([]esv1alpha1.SecretSyncPolicy, error) {
+	if !r.EnableSyncPolicies {
+		return nil, nil
+	}
+	var list esv1alpha1.SecretSyncPolicyList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf(errListSyncPolicies, err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].Name < list.Items[j].Name
+	})
+	var ns *v1.Namespace
+	policies := make([]esv1alpha1.SecretSyncPolicy, 0, len(list.Items))
+	for _, policy := range list.Items {
+		if policy.Spec.NamespaceSelector == nil {
+			policies = append(policies, policy)
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf(errSyncPolicySelector, policy.Name, err)
+		}
+		if ns == nil {
+			ns = &v1.Namespace{}
+			if err := r.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+				return nil, fmt.Errorf(errGetNamespace, err)
+			}
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+// applySyncPolicy sets the fields that are still unset, so the first policy
+// that sets a field wins.
+func applySyncPolicy(es *esv1beta1.ExternalSecret, policy *esv1alpha1.SecretSyncPolicySpec) {
+	if es.Spec.RefreshInterval == nil && policy.RefreshInterval != nil {
+		es.Spec.RefreshInterval = policy.RefreshInterval.DeepCopy()
+	}
+	if es.Spec.Target.DeletionPolicy == "" && deletionPolicyAllowed(es.Spec.Target.CreationPolicy, policy.DeletionPolicy) {
+		es.Spec.Target.DeletionPolicy = policy.DeletionPolicy
+	}
+	tpl := es.Spec.Target.Template
+	if tpl == nil {
+		// all labels are copied to the secret without a template.
+		return
+	}
+	if tpl.EngineVersion == "" {
+		tpl.EngineVersion = policy.TemplateEngineVersion
+	}
+	for _, key := range policy.PropagateLabels {
+		value, ok := es.Labels[key]
+		if !ok {
+			continue
+		}
+		if _, ok := tpl.Metadata.Labels[key]; ok {
+			continue
+		}
+		if tpl.Metadata.Labels == nil {
+			tpl.Metadata.Labels = make(map[string]string)
+		}
+		tpl.Metadata.Labels[key] = value
+	}
+}
+
+// deletionPolicyAllowed returns false for the combinations the webhook
+// rejects, so a default does not break ExternalSecrets that do not own
+// their secret.
+func deletionPolicyAllowed(creation esv1beta1.ExternalSecretCreationPolicy, deletion esv1beta1.ExternalSecretDeletionPolicy) bool {
+	switch deletion {
+	case "":
+		return false
+	case esv1beta1.DeletionPolicyDelete:
+		return creation != esv1beta1.CreatePolicyMerge && creation != esv1beta1.CreatePolicyNone
+	case esv1beta1.DeletionPolicyMerge:
+		return creation != esv1beta1.CreatePolicyNone
+	default:
+		return true
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+	require.NoError(t, esv1alpha1.AddToScheme(scheme))
+	kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev"}},
+		&esv1alpha1.SecretSyncPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-cluster"},
+			Spec: esv1alpha1.SecretSyncPolicySpec{
+				RefreshInterval:       &metav1.Duration{Duration: 10 * time.Minute},
+				DeletionPolicy:        esv1beta1.DeletionPolicyMerge,
+				TemplateEngineVersion: esv1beta1.TemplateEngineV1,
+				PropagateLabels:       []string{"team"},
+			},
+		},
+		&esv1alpha1.SecretSyncPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-prod"},
+			Spec: esv1alpha1.SecretSyncPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				RefreshInterval:   &metav1.Duration{Duration: 5 * time.Minute},
+				DeletionPolicy:    esv1beta1.DeletionPolicyDelete,
+			},
+		},
+	).Build()
+	newES := func(namespace string) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: namespace, Labels: map[string]string{"team": "a", "app": "db"}},
+			Spec: esv1beta1.ExternalSecretSpec{Target: esv1beta1.ExternalSecretTarget{
+				CreationPolicy: esv1beta1.CreatePolicyOwner,
+				Template:       &esv1beta1.ExternalSecretTemplate{},
+			}},
+		}
+	}
+	r := &Reconciler{Client: kube, EnableSyncPolicies: true}
+
+	t.Run("policies in name order", func(t *testing.T) {
+		es := newES("prod")
+		require.NoError(t, r.applyDefaults(context.Background(), es))
+		assert.Equal(t, 5*time.Minute, es.Spec.RefreshInterval.Duration)
+		assert.Equal(t, esv1beta1.DeletionPolicyDelete, es.Spec.Target.DeletionPolicy)
+		assert.Equal(t, esv1beta1.TemplateEngineV1, es.Spec.Target.Template.EngineVersion)
+		assert.Equal(t, map[string]string{"team": "a"}, es.Spec.Target.Template.Metadata.Labels)
+	})
+
+	t.Run("namespace selector", func(t *testing.T) {
+		es := newES("dev")
+		require.NoError(t, r.applyDefaults(context.Background(), es))
+		assert.Equal(t, 10*time.Minute, es.Spec.RefreshInterval.Duration)
+		assert.Equal(t, esv1beta1.DeletionPolicyMerge, es.Spec.Target.DeletionPolicy)
+	})
+
+	t.Run("set fields are kept", func(t *testing.T) {
+		es := newES("prod")
+		es.Spec.RefreshInterval = &metav1.Duration{Duration: time.Minute}
+		es.Spec.Target.DeletionPolicy = esv1beta1.DeletionPolicyRetain
+		es.Spec.Target.Template.EngineVersion = esv1beta1.TemplateEngineV2
+		es.Spec.Target.Template.Metadata.Labels = map[string]string{"team": "b"}
+		require.NoError(t, r.applyDefaults(context.Background(), es))
+		assert.Equal(t, time.Minute, es.Spec.RefreshInterval.Duration)
+		assert.Equal(t, esv1beta1.DeletionPolicyRetain, es.Spec.Target.DeletionPolicy)
+		assert.Equal(t, esv1beta1.TemplateEngineV2, es.Spec.Target.Template.EngineVersion)
+		assert.Equal(t, map[string]string{"team": "b"}, es.Spec.Target.Template.Metadata.Labels)
+	})
+
+	t.Run("incompatible deletion policy", func(t *testing.T) {
+		es := newES("prod")
+		es.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyNone
+		require.NoError(t, r.applyDefaults(context.Background(), es))
+		assert.Equal(t, esv1beta1.DeletionPolicyRetain, es.Spec.Target.DeletionPolicy)
+	})
+
+	t.Run("built-in defaults", func(t *testing.T) {
+		r := &Reconciler{Client: kube}
+		es := newES("prod")
+		require.NoError(t, r.applyDefaults(context.Background(), es))
+		assert.Equal(t, defaultRefreshInterval, es.Spec.RefreshInterval.Duration)
+		assert.Equal(t, esv1beta1.DeletionPolicyRetain, es.Spec.Target.DeletionPolicy)
+		assert.Equal(t, esv1beta1.TemplateEngineV2, es.Spec.Target.Template.EngineVersion)
+		assert.Empty(t, es.Spec.Target.Template.Metadata.Labels)
+	})
+}
@@ -0,0 +1,103 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+const msgSuppressedEvents = "%s (%d identical events suppressed since %s)"
+
+// aggregatingRecorder emits repeated identical warning events of an
+// ExternalSecret only once per interval. The suppressed events are counted
+// and reported with the next event that is emitted after the interval.
+// A normal event resets the aggregation, so that a new failure is reported
+// right away.
+type aggregatingRecorder struct {
+	record.EventRecorder
+	interval time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	warnings map[types.NamespacedName]*aggregatedWarning
+}
+
+type aggregatedWarning struct {
+	reason    string
+	message   string
+	emittedAt time.Time
+	count     int
+}
+
+func newAggregatingRecorder(recorder record.EventRecorder, interval time.Duration) *aggregatingRecorder {
+	return &aggregatingRecorder{
+		EventRecorder: recorder,
+		interval:      interval,
+		now:           time.Now,
+		warnings:      make(map[types.NamespacedName]*aggregatedWarning),
+	}
+}
+
+func (a *aggregatingRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	obj, err := meta.Accessor(object)
+	if err != nil {
+		a.EventRecorder.Event(object, eventtype, reason, message)
+		return
+	}
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+
+	a.mu.Lock()
+	if eventtype != v1.EventTypeWarning {
+		delete(a.warnings, key)
+		a.mu.Unlock()
+		a.EventRecorder.Event(object, eventtype, reason, message)
+		return
+	}
+	now := a.now()
+	emit := message
+	if w, ok := a.warnings[key]; ok && w.reason == reason && w.message == message {
+		if now.Before(w.emittedAt.Add(a.interval)) {
+			w.count++
+			a.mu.Unlock()
+			return
+		}
+		if w.count > 0 {
+			emit = fmt.Sprintf(msgSuppressedEvents, message, w.count, w.emittedAt.UTC().Format(time.RFC3339))
+		}
+	}
+	a.warnings[key] = &aggregatedWarning{reason: reason, message: message, emittedAt: now}
+	a.mu.Unlock()
+
+	a.EventRecorder.Event(object, eventtype, reason, emit)
+}
+
+func (a *aggregatingRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	a.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// forget drops the aggregation state of a deleted ExternalSecret.
+func (a *aggregatingRecorder) forget(key types.NamespacedName) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.warnings, key)
+}
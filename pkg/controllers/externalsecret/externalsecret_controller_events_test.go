@@ -0,0 +1,77 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestAggregatingRecorder(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	fake := record.NewFakeRecorder(10)
+	recorder := newAggregatingRecorder(fake, time.Minute)
+	recorder.now = func() time.Time { return now }
+
+	es := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}}
+	other := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "baz", Namespace: "bar"}}
+
+	recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "server down")
+	recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "server down")
+	recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "server down")
+	recorder.Event(other, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "server down")
+	assert.Equal(t, []string{
+		"Warning UpdateFailed server down",
+		"Warning UpdateFailed server down",
+	}, drainEvents(fake))
+
+	// a different message is emitted right away.
+	recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "permission denied")
+	recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "permission denied")
+	now = now.Add(time.Minute)
+	recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "permission denied")
+	assert.Equal(t, []string{
+		"Warning UpdateFailed permission denied",
+		"Warning UpdateFailed permission denied (1 identical events suppressed since 2024-01-01T00:00:00Z)",
+	}, drainEvents(fake))
+
+	// a normal event resets the aggregation.
+	recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonUpdated, "Updated Secret")
+	recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "permission denied")
+	assert.Equal(t, []string{
+		"Normal Updated Updated Secret",
+		"Warning UpdateFailed permission denied",
+	}, drainEvents(fake))
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
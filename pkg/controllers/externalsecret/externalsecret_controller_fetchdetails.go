@@ -0,0 +1,117 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
+	"github.com/external-secrets/external-secrets/pkg/utils/redact"
+)
+
+// fetchedKeyHashLength is the number of hex digits of the sha256 sum of a
+// value that are reported, enough to compare values without revealing them.
+const fetchedKeyHashLength = 16
+
+// fetchDetails collects the details of the entries read during a sync. It
+// is nil if the ExternalSecret does not report them.
+type fetchDetails struct {
+	details []esv1beta1.FetchDetail
+}
+
+// newFetchDetails returns the collector of es, or nil if es does not have
+// the fetch-details annotation.
+func newFetchDetails(es *esv1beta1.ExternalSecret) *fetchDetails {
+	if es.Annotations[esv1beta1.AnnotationFetchDetails] != "true" {
+		return nil
+	}
+	return &fetchDetails{details: []esv1beta1.FetchDetail{}}
+}
+
+// addDataFrom records the result of reading dataFrom[i].
+func (f *fetchDetails) addDataFrom(i int, ref esv1beta1.ExternalSecretDataFromRemoteRef, duration time.Duration, values map[string][]byte, err error) {
+	if f == nil {
+		return
+	}
+	var remoteKey, property string
+	switch {
+	case ref.Find != nil:
+		remoteKey = audit.DescribeFind(*ref.Find)
+	case ref.Extract != nil:
+		remoteKey, property = ref.Extract.Key, ref.Extract.Property
+	case ref.SourceRef != nil && ref.SourceRef.GeneratorRef != nil:
+		remoteKey = ref.SourceRef.GeneratorRef.Kind + "/" + ref.SourceRef.GeneratorRef.Name
+	}
+	f.add(fmt.Sprintf("dataFrom[%d]", i), remoteKey, property, duration, values, err)
+}
+
+// addData records the result of reading data[i].
+func (f *fetchDetails) addData(i int, ref esv1beta1.ExternalSecretData, duration time.Duration, values map[string][]byte, err error) {
+	if f == nil {
+		return
+	}
+	f.add(fmt.Sprintf("data[%d]", i), ref.RemoteRef.Key, ref.RemoteRef.Property, duration, values, err)
+}
+
+func (f *fetchDetails) add(source, remoteKey, property string, duration time.Duration, values map[string][]byte, err error) {
+	detail := esv1beta1.FetchDetail{
+		Source:    source,
+		RemoteKey: remoteKey,
+		Property:  property,
+		Duration:  metav1.Duration{Duration: duration.Round(time.Millisecond)},
+		Keys:      fetchedKeys(values),
+	}
+	if err != nil {
+		detail.Error = redact.Error(err).Error()
+	}
+	f.details = append(f.details, detail)
+}
+
+// store sets the status of es to the collected details, it removes the
+// details of an earlier sync if es no longer reports them.
+func (f *fetchDetails) store(es *esv1beta1.ExternalSecret) {
+	if f == nil {
+		es.Status.FetchDetails = nil
+		return
+	}
+	es.Status.FetchDetails = f.details
+}
+
+// fetchedKeys describes the values sorted by key.
+func fetchedKeys(values map[string][]byte) []esv1beta1.FetchedKey {
+	if len(values) == 0 {
+		return nil
+	}
+	keys := make([]esv1beta1.FetchedKey, 0, len(values))
+	for k, v := range values {
+		sum := sha256.Sum256(v)
+		keys = append(keys, esv1beta1.FetchedKey{
+			Key:  k,
+			Size: len(v),
+			Hash: hex.EncodeToString(sum[:])[:fetchedKeyHashLength],
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Key < keys[j].Key
+	})
+	return keys
+}
@@ -0,0 +1,115 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestGetProviderSecretDataFetchDetails(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+
+	t.Cleanup(fakeProvider.Reset)
+	fakeProvider.GetSecretFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		if ref.Key == "app/user" {
+			return []byte("admin"), nil
+		}
+		return nil, errors.New("access denied")
+	}
+	fakeProvider.GetSecretMapFn = func(context.Context, esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+		return map[string][]byte{"port": []byte("5432"), "host": {}}, nil
+	}
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "store", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+			AWS: &esv1beta1.AWSProvider{Service: esv1beta1.AWSServiceSecretsManager},
+		}},
+	}
+	r := &Reconciler{
+		Client:                    clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build(),
+		ClusterSecretStoreEnabled: true,
+		recorder:                  &record.FakeRecorder{},
+	}
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: map[string]string{esv1beta1.AnnotationFetchDetails: "true"},
+		},
+		Spec: esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: esv1beta1.SecretStoreRef{Name: "store"},
+			SyncPolicy:     esv1beta1.SyncPolicyPartial,
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{Extract: &esv1beta1.ExternalSecretDataRemoteRef{Key: "app/db"}},
+			},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "user", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "app/user"}},
+				{SecretKey: "password", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "app/password", Property: "value"}},
+			},
+		},
+	}
+
+	data, _, err := r.getProviderSecretData(context.Background(), es)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"user": []byte("admin"), "port": []byte("5432"), "host": {}}, data)
+
+	details := es.Status.FetchDetails
+	require.Len(t, details, 3)
+	for i := range details {
+		details[i].Duration = metav1.Duration{}
+	}
+	assert.Equal(t, []esv1beta1.FetchDetail{
+		{
+			Source:    "dataFrom[0]",
+			RemoteKey: "app/db",
+			Keys: []esv1beta1.FetchedKey{
+				// empty values are listed with size 0, missing keys are not listed.
+				{Key: "host", Size: 0, Hash: "e3b0c44298fc1c14"},
+				{Key: "port", Size: 4, Hash: "4aeb7ad6d5d37a04"},
+			},
+		},
+		{
+			Source:    "data[0]",
+			RemoteKey: "app/user",
+			Keys:      []esv1beta1.FetchedKey{{Key: "user", Size: 5, Hash: "8c6976e5b5410415"}},
+		},
+		{
+			Source:    "data[1]",
+			RemoteKey: "app/password",
+			Property:  "value",
+			Error:     "access denied",
+		},
+	}, details)
+
+	// the details of the last sync are removed with the annotation.
+	delete(es.Annotations, esv1beta1.AnnotationFetchDetails)
+	_, _, err = r.getProviderSecretData(context.Background(), es)
+	require.NoError(t, err)
+	assert.Nil(t, es.Status.FetchDetails)
+}
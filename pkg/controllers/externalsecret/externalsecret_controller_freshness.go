@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// freshnessThreshold returns the threshold of the ExternalSecret,
+// falling back to the default of the controller.
+func (r *Reconciler) freshnessThreshold(es *esv1beta1.ExternalSecret) time.Duration {
+	if es.Spec.FreshnessThreshold != nil {
+		return es.Spec.FreshnessThreshold.Duration
+	}
+	return r.FreshnessThreshold
+}
+
+// isStale reports whether the last successful sync is older than the threshold.
+// ExternalSecrets that are fetched once (refreshInterval=0) never become stale.
+func isStale(es *esv1beta1.ExternalSecret, threshold time.Duration, now time.Time) bool {
+	if threshold <= 0 {
+		return false
+	}
+	if es.Spec.RefreshInterval != nil && es.Spec.RefreshInterval.Duration == 0 {
+		return false
+	}
+	// lazy ExternalSecrets are not stale before the Secret is used, and
+	// stopped ExternalSecrets are not expected to sync.
+	if isDeferred(es) || isSyncStopped(es) {
+		return false
+	}
+	if es.Status.RefreshTime.IsZero() {
+		return es.CreationTimestamp.Add(threshold).Before(now)
+	}
+	return es.Status.RefreshTime.Add(threshold).Before(now)
+}
+
+// setStaleCondition sets the Stale condition of the ExternalSecret.
+// The condition is removed if the freshness check is disabled, unless the
+// store of the ExternalSecret is offline.
+func (r *Reconciler) setStaleCondition(es *esv1beta1.ExternalSecret, now time.Time) {
+	if isOffline(es) {
+		ready := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+		SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1beta1.ExternalSecretStale, v1.ConditionTrue, esv1beta1.ConditionReasonStoreOffline, ready.Message))
+		return
+	}
+	threshold := r.freshnessThreshold(es)
+	if threshold <= 0 {
+		es.Status.Conditions = filterOutCondition(es.Status.Conditions, esv1beta1.ExternalSecretStale)
+		return
+	}
+	if isStale(es, threshold, now) {
+		msg := fmt.Sprintf("Secret was not synced within %s", threshold)
+		SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1beta1.ExternalSecretStale, v1.ConditionTrue, esv1beta1.ConditionReasonSecretStale, msg))
+		return
+	}
+	SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1beta1.ExternalSecretStale, v1.ConditionFalse, esv1beta1.ConditionReasonSecretFresh, "Secret is fresh"))
+}
@@ -0,0 +1,111 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestIsStale(t *testing.T) {
+	syncedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name            string
+		refreshInterval *metav1.Duration
+		refreshTime     time.Time
+		threshold       time.Duration
+		now             time.Time
+		want            bool
+	}{
+		{
+			name:        "disabled",
+			refreshTime: syncedAt,
+			now:         syncedAt.Add(24 * time.Hour),
+			want:        false,
+		},
+		{
+			name:        "within threshold",
+			refreshTime: syncedAt,
+			threshold:   time.Hour,
+			now:         syncedAt.Add(59 * time.Minute),
+			want:        false,
+		},
+		{
+			name:        "threshold exceeded",
+			refreshTime: syncedAt,
+			threshold:   time.Hour,
+			now:         syncedAt.Add(61 * time.Minute),
+			want:        true,
+		},
+		{
+			name:      "never synced",
+			threshold: time.Hour,
+			now:       syncedAt.Add(61 * time.Minute),
+			want:      true,
+		},
+		{
+			name:            "fetched once",
+			refreshInterval: &metav1.Duration{},
+			refreshTime:     syncedAt,
+			threshold:       time.Hour,
+			now:             syncedAt.Add(24 * time.Hour),
+			want:            false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(syncedAt)},
+				Spec:       esv1beta1.ExternalSecretSpec{RefreshInterval: tt.refreshInterval},
+			}
+			if !tt.refreshTime.IsZero() {
+				es.Status.RefreshTime = metav1.NewTime(tt.refreshTime)
+			}
+			assert.Equal(t, tt.want, isStale(es, tt.threshold, tt.now))
+		})
+	}
+}
+
+func TestSetStaleCondition(t *testing.T) {
+	syncedAt := time.Now()
+	r := &Reconciler{FreshnessThreshold: time.Hour}
+	es := &esv1beta1.ExternalSecret{
+		Status: esv1beta1.ExternalSecretStatus{RefreshTime: metav1.NewTime(syncedAt)},
+	}
+
+	r.setStaleCondition(es, syncedAt.Add(time.Minute))
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretStale)
+	require.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+
+	// the threshold of the ExternalSecret overrides the default.
+	es.Spec.FreshnessThreshold = &metav1.Duration{Duration: 30 * time.Second}
+	r.setStaleCondition(es, syncedAt.Add(time.Minute))
+	cond = GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretStale)
+	require.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Equal(t, esv1beta1.ConditionReasonSecretStale, cond.Reason)
+
+	es.Spec.FreshnessThreshold = &metav1.Duration{}
+	r.setStaleCondition(es, syncedAt.Add(time.Minute))
+	assert.Nil(t, GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretStale))
+}
@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errListPods       = "could not list pods: %w"
+	errLazyDisabled   = "spec.target.lazy is ignored, the controller runs without --enable-lazy-sync"
+	msgSyncDeferred   = "sync is deferred until a Pod references Secret %s"
+	errListLazyTarget = "could not list ExternalSecrets for pod"
+)
+
+// targetSecretName returns the name of the Secret of the ExternalSecret.
+func targetSecretName(es *esv1beta1.ExternalSecret) string {
+	if es.Spec.Target.Name != "" {
+		return es.Spec.Target.Name
+	}
+	return es.Name
+}
+
+// isDeferred reports whether the first sync of the ExternalSecret waits for a Pod.
+func isDeferred(es *esv1beta1.ExternalSecret) bool {
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+	return cond != nil && cond.Reason == esv1beta1.ConditionReasonSecretDeferred
+}
+
+// deferSync reports whether the first sync of a lazy ExternalSecret has to
+// wait, because no Pod in its namespace references the Secret yet. Secrets
+// that exist are always refreshed.
+func (r *Reconciler) deferSync(ctx context.Context, es *esv1beta1.ExternalSecret, existingSecret *v1.Secret) (bool, error) {
+	if !es.Spec.Target.Lazy || existingSecret.UID != "" {
+		return false, nil
+	}
+	if !r.EnableLazySync {
+		r.recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonLazySyncIgnored, errLazyDisabled)
+		return false, nil
+	}
+	pods := &v1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(es.Namespace)); err != nil {
+		return false, fmt.Errorf(errListPods, err)
+	}
+	name := targetSecretName(es)
+	for i := range pods.Items {
+		if usesSecret(&pods.Items[i].Spec, name) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// externalSecretsForPod returns the deferred ExternalSecrets whose Secret
+// is referenced by the pod.
+func (r *Reconciler) externalSecretsForPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return nil
+	}
+	var list esv1beta1.ExternalSecretList
+	if err := r.List(ctx, &list, client.InNamespace(pod.Namespace)); err != nil {
+		r.Log.Error(err, errListLazyTarget, "pod", client.ObjectKeyFromObject(pod))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range list.Items {
+		es := &list.Items[i]
+		if !es.Spec.Target.Lazy || !isDeferred(es) || !usesSecret(&pod.Spec, targetSecretName(es)) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: es.Name, Namespace: es.Namespace},
+		})
+	}
+	return requests
+}
+
+// podCreated passes the creation of pods only, the references to Secrets of
+// a pod can not change after it was created.
+var podCreated = predicate.Funcs{
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
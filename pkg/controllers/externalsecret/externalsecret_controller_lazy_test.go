@@ -0,0 +1,159 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func lazyPod(name, secretName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:    "app",
+			EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}}}},
+		}}},
+	}
+}
+
+func TestDeferSync(t *testing.T) {
+	tests := []struct {
+		name     string
+		lazy     bool
+		disabled bool
+		existing bool
+		pods     []*corev1.Pod
+		want     bool
+		event    string
+	}{
+		{
+			name: "no pod references the secret",
+			lazy: true,
+			pods: []*corev1.Pod{lazyPod("other", "other")},
+			want: true,
+		},
+		{
+			name: "pod references the secret",
+			lazy: true,
+			pods: []*corev1.Pod{lazyPod("other", "other"), lazyPod("app", "db")},
+			want: false,
+		},
+		{
+			name:     "secret exists",
+			lazy:     true,
+			existing: true,
+			want:     false,
+		},
+		{
+			name: "not lazy",
+			want: false,
+		},
+		{
+			name:     "disabled",
+			lazy:     true,
+			disabled: true,
+			want:     false,
+			event:    esv1beta1.ReasonLazySyncIgnored,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := clientfake.NewClientBuilder()
+			for _, p := range tt.pods {
+				builder = builder.WithObjects(p)
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{Client: builder.Build(), EnableLazySync: !tt.disabled, recorder: recorder}
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+				Spec:       esv1beta1.ExternalSecretSpec{Target: esv1beta1.ExternalSecretTarget{Lazy: tt.lazy}},
+			}
+			existing := &corev1.Secret{}
+			if tt.existing {
+				existing.UID = "uid"
+			}
+			got, err := r.deferSync(context.Background(), es, existing)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if tt.event == "" {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, tt.event)
+		})
+	}
+}
+
+func TestExternalSecretsForPod(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+
+	deferred := esv1beta1.ExternalSecretStatus{Conditions: []esv1beta1.ExternalSecretStatusCondition{{
+		Type:   esv1beta1.ExternalSecretReady,
+		Status: corev1.ConditionFalse,
+		Reason: esv1beta1.ConditionReasonSecretDeferred,
+	}}}
+	newES := func(name, target string, lazy bool, status esv1beta1.ExternalSecretStatus) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       esv1beta1.ExternalSecretSpec{Target: esv1beta1.ExternalSecretTarget{Name: target, Lazy: lazy}},
+			Status:     status,
+		}
+	}
+	kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newES("db", "", true, deferred),
+		newES("named", "db", true, deferred),
+		newES("synced", "db", true, esv1beta1.ExternalSecretStatus{}),
+		newES("eager", "db", false, deferred),
+		newES("other", "other", true, deferred),
+	).Build()
+	r := &Reconciler{Client: kube}
+
+	got := r.externalSecretsForPod(context.Background(), lazyPod("app", "db"))
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "db", Namespace: "default"}},
+		{NamespacedName: types.NamespacedName{Name: "named", Namespace: "default"}},
+	}, got)
+}
+
+func TestIsStaleDeferred(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(createdAt)},
+		Status: esv1beta1.ExternalSecretStatus{Conditions: []esv1beta1.ExternalSecretStatusCondition{{
+			Type:   esv1beta1.ExternalSecretReady,
+			Status: corev1.ConditionFalse,
+			Reason: esv1beta1.ConditionReasonSecretDeferred,
+		}}},
+	}
+	assert.False(t, isStale(es, time.Hour, createdAt.Add(24*time.Hour)))
+}
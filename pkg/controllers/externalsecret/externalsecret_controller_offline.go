@@ -0,0 +1,83 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+)
+
+const msgStoreOffline = "%s %s is unreachable since %s, the secret was kept"
+
+// offlineStore returns a message naming a store of the ExternalSecret that
+// failed its validation for longer than the OfflineThreshold, or an empty
+// string if there is none or the offline mode is disabled.
+func (r *Reconciler) offlineStore(ctx context.Context, es *esv1beta1.ExternalSecret, now time.Time) string {
+	if r.OfflineThreshold <= 0 {
+		return ""
+	}
+	for _, ref := range storeRefs(es) {
+		var store esv1beta1.GenericStore = &esv1beta1.SecretStore{}
+		key := types.NamespacedName{Name: ref.Name, Namespace: es.Namespace}
+		if ref.Kind == esv1beta1.ClusterSecretStoreKind {
+			store = &esv1beta1.ClusterSecretStore{}
+			key.Namespace = ""
+		}
+		if err := r.Get(ctx, key, store); err != nil {
+			continue
+		}
+		cond := secretstore.GetSecretStoreCondition(store.GetStatus(), esv1beta1.SecretStoreReady)
+		if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != esv1beta1.ReasonValidationFailed {
+			continue
+		}
+		if now.Sub(cond.LastTransitionTime.Time) < r.OfflineThreshold {
+			continue
+		}
+		kind := ref.Kind
+		if kind == "" {
+			kind = esv1beta1.SecretStoreKind
+		}
+		return fmt.Sprintf(msgStoreOffline, kind, ref.Name, cond.LastTransitionTime.UTC().Format(time.RFC3339))
+	}
+	return ""
+}
+
+// markAsOffline keeps the secret of an ExternalSecret whose store is offline
+// without failing the sync, so there are no warning events and errors while
+// the network is isolated. The Stale condition is set by setStaleCondition.
+func (r *Reconciler) markAsOffline(log logr.Logger, es *esv1beta1.ExternalSecret, msg string, err error) {
+	if isOffline(es) {
+		log.V(1).Info("store is offline, keeping the secret", "error", err.Error())
+	} else {
+		log.Info("store is offline, keeping the secret", "error", err.Error())
+	}
+	cond := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionTrue, esv1beta1.ConditionReasonStoreOffline, msg)
+	SetExternalSecretCondition(es, *cond)
+}
+
+// isOffline returns true if the secret of the ExternalSecret is kept because
+// its store is offline.
+func isOffline(es *esv1beta1.ExternalSecret) bool {
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+	return cond != nil && cond.Reason == esv1beta1.ConditionReasonStoreOffline
+}
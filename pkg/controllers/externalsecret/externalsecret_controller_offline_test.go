@@ -0,0 +1,120 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestOfflineStore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+
+	failedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := func(reason string) esv1beta1.SecretStoreStatus {
+		return esv1beta1.SecretStoreStatus{Conditions: []esv1beta1.SecretStoreStatusCondition{{
+			Type:               esv1beta1.SecretStoreReady,
+			Status:             v1.ConditionFalse,
+			Reason:             reason,
+			LastTransitionTime: metav1.NewTime(failedAt),
+		}}}
+	}
+	objects := []runtime.Object{
+		&esv1beta1.SecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "unreachable", Namespace: "default"},
+			Status:     status(esv1beta1.ReasonValidationFailed),
+		},
+		&esv1beta1.SecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "misconfigured", Namespace: "default"},
+			Status:     status(esv1beta1.ReasonInvalidProviderConfig),
+		},
+		&esv1beta1.ClusterSecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "unreachable"},
+			Status:     status(esv1beta1.ReasonValidationFailed),
+		},
+	}
+	r := &Reconciler{
+		Client:           clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build(),
+		OfflineThreshold: 10 * time.Minute,
+	}
+	es := func(ref esv1beta1.SecretStoreRef) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       esv1beta1.ExternalSecretSpec{SecretStoreRef: ref},
+		}
+	}
+	ctx := context.Background()
+	after := failedAt.Add(11 * time.Minute)
+
+	assert.Equal(t, "SecretStore unreachable is unreachable since 2024-01-01T00:00:00Z, the secret was kept",
+		r.offlineStore(ctx, es(esv1beta1.SecretStoreRef{Name: "unreachable"}), after))
+	assert.Equal(t, "ClusterSecretStore unreachable is unreachable since 2024-01-01T00:00:00Z, the secret was kept",
+		r.offlineStore(ctx, es(esv1beta1.SecretStoreRef{Name: "unreachable", Kind: esv1beta1.ClusterSecretStoreKind}), after))
+	// within the threshold.
+	assert.Empty(t, r.offlineStore(ctx, es(esv1beta1.SecretStoreRef{Name: "unreachable"}), failedAt.Add(time.Minute)))
+	// invalid configurations are not outages.
+	assert.Empty(t, r.offlineStore(ctx, es(esv1beta1.SecretStoreRef{Name: "misconfigured"}), after))
+	assert.Empty(t, r.offlineStore(ctx, es(esv1beta1.SecretStoreRef{Name: "missing"}), after))
+
+	// stores referenced by the entries.
+	withSource := es(esv1beta1.SecretStoreRef{Name: "misconfigured"})
+	withSource.Spec.Data = []esv1beta1.ExternalSecretData{{
+		SourceRef: &esv1beta1.StoreSourceRef{SecretStoreRef: esv1beta1.SecretStoreRef{Name: "unreachable"}},
+	}}
+	assert.NotEmpty(t, r.offlineStore(ctx, withSource, after))
+
+	r.OfflineThreshold = 0
+	assert.Empty(t, r.offlineStore(ctx, es(esv1beta1.SecretStoreRef{Name: "unreachable"}), after))
+}
+
+func TestMarkAsOffline(t *testing.T) {
+	r := &Reconciler{}
+	es := &esv1beta1.ExternalSecret{}
+	SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonSecretSyncedError, "could not get secret data from provider"))
+
+	msg := "SecretStore chef is unreachable since 2024-01-01T00:00:00Z, the secret was kept"
+	r.markAsOffline(logr.Discard(), es, msg, errors.New("connection refused"))
+	assert.True(t, isOffline(es))
+	ready := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+	assert.Equal(t, v1.ConditionTrue, ready.Status)
+	assert.Equal(t, msg, syncFailure(es))
+
+	// offline ExternalSecrets are stale, also without a freshness threshold.
+	r.setStaleCondition(es, time.Now())
+	stale := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretStale)
+	require.NotNil(t, stale)
+	assert.Equal(t, v1.ConditionTrue, stale.Status)
+	assert.Equal(t, esv1beta1.ConditionReasonStoreOffline, stale.Reason)
+	assert.Equal(t, msg, stale.Message)
+
+	// a successful sync ends the offline mode.
+	SetExternalSecretCondition(es, *NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionTrue, esv1beta1.ConditionReasonSecretSynced, "Secret was synced"))
+	r.setStaleCondition(es, time.Now())
+	assert.False(t, isOffline(es))
+	assert.Nil(t, GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretStale))
+}
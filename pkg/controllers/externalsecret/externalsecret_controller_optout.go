@@ -0,0 +1,120 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errGetNamespace        = "could not get namespace: %w"
+	errCheckOptOut         = "could not check namespace opt-out"
+	errListOwnedSecrets    = "could not list owned secrets: %w"
+	errDeleteOwnedSecret   = "could not delete owned secret %s: %w"
+	errListOptOutTarget    = "could not list ExternalSecrets for namespace"
+	msgSyncStopped         = "sync is stopped, namespace %s opted out"
+	msgSyncStoppedDeleted  = "sync is stopped and owned secrets were deleted, namespace %s opted out"
+	msgOwnedSecretsDeleted = "deleted owned secrets %v, namespace %s opted out"
+)
+
+// namespaceOptOut returns the value of the opt-out label of the namespace of
+// the ExternalSecret, ok is false if the namespace did not opt out.
+func (r *Reconciler) namespaceOptOut(ctx context.Context, es *esv1beta1.ExternalSecret) (value string, ok bool, err error) {
+	if !r.EnableNamespaceOptOut {
+		return "", false, nil
+	}
+	var ns v1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: es.Namespace}, &ns); err != nil {
+		return "", false, fmt.Errorf(errGetNamespace, err)
+	}
+	value, ok = ns.Labels[esv1beta1.LabelNamespaceOptOut]
+	return value, ok, nil
+}
+
+// isSyncStopped reports whether the sync was stopped by the namespace.
+func isSyncStopped(es *esv1beta1.ExternalSecret) bool {
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+	return cond != nil && cond.Reason == esv1beta1.ConditionReasonSecretSyncStopped
+}
+
+// stopSync marks the ExternalSecret as stopped and, if requested by the
+// namespace, deletes all Secrets the ExternalSecret is the controller of.
+// Secrets that are not owned, e.g. of creationPolicy Merge, are kept.
+func (r *Reconciler) stopSync(ctx context.Context, es *esv1beta1.ExternalSecret, value string) error {
+	msg := fmt.Sprintf(msgSyncStopped, es.Namespace)
+	if value == esv1beta1.NamespaceOptOutDelete {
+		var secretList v1.SecretList
+		if err := r.List(ctx, &secretList, client.InNamespace(es.Namespace)); err != nil {
+			return fmt.Errorf(errListOwnedSecrets, err)
+		}
+		var deleted []string
+		for i := range secretList.Items {
+			secret := &secretList.Items[i]
+			if !metav1.IsControlledBy(secret, es) {
+				continue
+			}
+			if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf(errDeleteOwnedSecret, secret.Name, err)
+			}
+			deleted = append(deleted, secret.Name)
+		}
+		if len(deleted) > 0 {
+			r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonDeleted, fmt.Sprintf(msgOwnedSecretsDeleted, deleted, es.Namespace))
+		}
+		msg = fmt.Sprintf(msgSyncStoppedDeleted, es.Namespace)
+	}
+	cond := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonSecretSyncStopped, msg)
+	SetExternalSecretCondition(es, *cond)
+	return nil
+}
+
+// externalSecretsForNamespace returns all ExternalSecrets of the namespace.
+func (r *Reconciler) externalSecretsForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list esv1beta1.ExternalSecretList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetName())); err != nil {
+		r.Log.Error(err, errListOptOutTarget, "namespace", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: list.Items[i].Name, Namespace: list.Items[i].Namespace},
+		})
+	}
+	return requests
+}
+
+// optOutChanged passes the updates of namespaces that change the opt-out label.
+var optOutChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldValue, oldOK := e.ObjectOld.GetLabels()[esv1beta1.LabelNamespaceOptOut]
+		newValue, newOK := e.ObjectNew.GetLabels()[esv1beta1.LabelNamespaceOptOut]
+		return oldOK != newOK || oldValue != newValue
+	},
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
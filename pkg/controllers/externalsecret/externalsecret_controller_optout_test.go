@@ -0,0 +1,144 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestNamespaceOptOut(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "decommissioned", Labels: map[string]string{esv1beta1.LabelNamespaceOptOut: esv1beta1.NamespaceOptOutDelete}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	).Build()
+	es := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "decommissioned"}}
+
+	r := &Reconciler{Client: kube, EnableNamespaceOptOut: true}
+	value, ok, err := r.namespaceOptOut(context.Background(), es)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, esv1beta1.NamespaceOptOutDelete, value)
+
+	_, ok, err = r.namespaceOptOut(context.Background(), &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}})
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	r.EnableNamespaceOptOut = false
+	_, ok, err = r.namespaceOptOut(context.Background(), es)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestStopSync(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", UID: "es-uid"}}
+	ownedBy := func(uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "external-secrets.io/v1beta1", Kind: "ExternalSecret", Name: "db", UID: uid, Controller: ptr.To(true)}}
+	}
+	newObjects := func() []*corev1.Secret {
+		return []*corev1.Secret{
+			{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", OwnerReferences: ownedBy("es-uid")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "db-refresh-cache", Namespace: "default", OwnerReferences: ownedBy("es-uid")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", OwnerReferences: ownedBy("other-uid")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "merged", Namespace: "default"}},
+		}
+	}
+	tests := []struct {
+		name    string
+		value   string
+		deleted []string
+		kept    []string
+	}{
+		{
+			name:  "stop keeps secrets",
+			value: "true",
+			kept:  []string{"db", "db-refresh-cache", "other", "merged"},
+		},
+		{
+			name:    "delete removes owned secrets",
+			value:   esv1beta1.NamespaceOptOutDelete,
+			deleted: []string{"db", "db-refresh-cache"},
+			kept:    []string{"other", "merged"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := clientfake.NewClientBuilder()
+			for _, s := range newObjects() {
+				builder = builder.WithObjects(s)
+			}
+			kube := builder.Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{Client: kube, recorder: recorder}
+			es := es.DeepCopy()
+			require.NoError(t, r.stopSync(context.Background(), es, tt.value))
+
+			assert.True(t, isSyncStopped(es))
+			for _, name := range tt.deleted {
+				err := kube.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &corev1.Secret{})
+				assert.True(t, apierrors.IsNotFound(err), name)
+			}
+			for _, name := range tt.kept {
+				assert.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &corev1.Secret{}), name)
+			}
+			if len(tt.deleted) == 0 {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, esv1beta1.ReasonDeleted)
+		})
+	}
+}
+
+func TestOptOutChanged(t *testing.T) {
+	ns := func(labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: labels}}
+	}
+	optOut := map[string]string{esv1beta1.LabelNamespaceOptOut: "true"}
+	assert.True(t, optOutChanged.Update(event.UpdateEvent{ObjectOld: ns(nil), ObjectNew: ns(optOut)}))
+	assert.True(t, optOutChanged.Update(event.UpdateEvent{ObjectOld: ns(optOut), ObjectNew: ns(nil)}))
+	assert.True(t, optOutChanged.Update(event.UpdateEvent{
+		ObjectOld: ns(optOut),
+		ObjectNew: ns(map[string]string{esv1beta1.LabelNamespaceOptOut: esv1beta1.NamespaceOptOutDelete}),
+	}))
+	assert.False(t, optOutChanged.Update(event.UpdateEvent{ObjectOld: ns(optOut), ObjectNew: ns(map[string]string{esv1beta1.LabelNamespaceOptOut: "true", "team": "a"})}))
+}
+
+func TestShouldRefreshStopped(t *testing.T) {
+	es := esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+		Spec:       esv1beta1.ExternalSecretSpec{RefreshInterval: &metav1.Duration{}},
+		Status:     esv1beta1.ExternalSecretStatus{RefreshTime: metav1.Now()},
+	}
+	es.Status.SyncedResourceVersion = getResourceVersion(es)
+	assert.False(t, shouldRefresh(es))
+
+	SetExternalSecretCondition(&es, *NewExternalSecretCondition(esv1beta1.ExternalSecretReady, corev1.ConditionFalse, esv1beta1.ConditionReasonSecretSyncStopped, ""))
+	assert.True(t, shouldRefresh(es))
+}
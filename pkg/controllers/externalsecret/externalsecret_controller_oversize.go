@@ -0,0 +1,233 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	secretPartSuffix    = "-part-"
+	errSecretTooLarge   = "secret data of %d bytes exceeds the limit of %d bytes, drop or split the keys: %s"
+	errValueTooLarge    = "the value of key %s has %d bytes and exceeds the limit of %d bytes of a Secret"
+	errSecretPartOwner  = "secret %s already exists and is not managed by this ExternalSecret"
+	errListSecretParts  = "could not list secret parts: %w"
+	errUpdateSecretPart = "could not update secret part %s: %w"
+	errDeleteSecretPart = "could not delete secret part %s: %w"
+	msgKeysDropped      = "dropped keys %s to fit the size limit of a Secret"
+)
+
+// maxSecretSize is the size limit of the data of a Secret, enforced by the apiserver.
+var maxSecretSize = v1.MaxSecretSize
+
+// secretTooLargeError is returned when the data of a Secret exceeds the size limit.
+// It lists the keys that must be removed for the data to fit.
+type secretTooLargeError struct {
+	msg  string
+	keys []string
+}
+
+func (e *secretTooLargeError) Error() string {
+	return e.msg
+}
+
+// secretDataSize returns the size of the data as computed by the apiserver.
+func secretDataSize(data map[string][]byte) int {
+	size := 0
+	for _, v := range data {
+		size += len(v)
+	}
+	return size
+}
+
+// oversizedKeys returns the largest keys that must be removed for the data to fit.
+func oversizedKeys(data map[string][]byte) []string {
+	keys := maps.Keys(data)
+	sort.Slice(keys, func(i, j int) bool {
+		if len(data[keys[i]]) != len(data[keys[j]]) {
+			return len(data[keys[i]]) > len(data[keys[j]])
+		}
+		return keys[i] < keys[j]
+	})
+	size := secretDataSize(data)
+	var offending []string
+	for _, k := range keys {
+		if size <= maxSecretSize {
+			break
+		}
+		size -= len(data[k])
+		offending = append(offending, k)
+	}
+	return offending
+}
+
+func newSecretTooLargeError(data map[string][]byte) error {
+	keys := oversizedKeys(data)
+	return &secretTooLargeError{
+		msg:  fmt.Sprintf(errSecretTooLarge, secretDataSize(data), maxSecretSize, strings.Join(keys, ", ")),
+		keys: keys,
+	}
+}
+
+// applyOversizePolicy makes the data of the secret fit the size limit according to
+// the oversize policy of the ExternalSecret. It returns the keys that were dropped
+// and, for policy Split, the data of the additional Secrets.
+func applyOversizePolicy(es *esv1beta1.ExternalSecret, secret *v1.Secret) ([]string, []map[string][]byte, error) {
+	if secretDataSize(secret.Data) <= maxSecretSize {
+		return nil, nil, nil
+	}
+	policy := esv1beta1.OversizePolicyFail
+	if es.Spec.Target.Oversize != nil && es.Spec.Target.Oversize.Policy != "" {
+		policy = es.Spec.Target.Oversize.Policy
+	}
+	switch policy {
+	case esv1beta1.OversizePolicyDropKeys:
+		dropped, err := dropKeys(secret.Data, es.Spec.Target.Oversize.DropKeys)
+		return dropped, nil, err
+	case esv1beta1.OversizePolicySplit:
+		parts, err := splitData(secret)
+		return nil, parts, err
+	default:
+		return nil, nil, newSecretTooLargeError(secret.Data)
+	}
+}
+
+// dropKeys deletes the given keys, in order, until the data fits.
+func dropKeys(data map[string][]byte, keys []string) ([]string, error) {
+	var dropped []string
+	for _, k := range keys {
+		if secretDataSize(data) <= maxSecretSize {
+			break
+		}
+		if _, ok := data[k]; !ok {
+			continue
+		}
+		delete(data, k)
+		dropped = append(dropped, k)
+	}
+	if secretDataSize(data) > maxSecretSize {
+		return dropped, newSecretTooLargeError(data)
+	}
+	return dropped, nil
+}
+
+// splitData keeps the keys, ordered by name, in the secret as long as they fit
+// and moves the others to as many parts as needed.
+func splitData(secret *v1.Secret) ([]map[string][]byte, error) {
+	keys := maps.Keys(secret.Data)
+	sort.Strings(keys)
+	bins := []map[string][]byte{{}}
+	size := 0
+	for _, k := range keys {
+		v := secret.Data[k]
+		if len(v) > maxSecretSize {
+			return nil, &secretTooLargeError{
+				msg:  fmt.Sprintf(errValueTooLarge, k, len(v), maxSecretSize),
+				keys: []string{k},
+			}
+		}
+		if size+len(v) > maxSecretSize {
+			bins = append(bins, map[string][]byte{})
+			size = 0
+		}
+		bins[len(bins)-1][k] = v
+		size += len(v)
+	}
+	secret.Data = bins[0]
+	return bins[1:], nil
+}
+
+// isSplit returns true if the ExternalSecret splits data that exceeds the
+// size limit into additional Secrets.
+func isSplit(es *esv1beta1.ExternalSecret) bool {
+	return es.Spec.Target.Oversize != nil && es.Spec.Target.Oversize.Policy == esv1beta1.OversizePolicySplit
+}
+
+func secretPartName(secretName string, i int) string {
+	return secretName + secretPartSuffix + strconv.Itoa(i+1)
+}
+
+// isSecretPart returns true if name is the name of a part of the secret.
+func isSecretPart(name, secretName string) bool {
+	suffix, ok := strings.CutPrefix(name, secretName+secretPartSuffix)
+	if !ok {
+		return false
+	}
+	n, err := strconv.Atoi(suffix)
+	return err == nil && n > 0 && strconv.Itoa(n) == suffix
+}
+
+// syncSecretParts writes the additional Secrets of policy Split and deletes
+// the parts that are no longer needed.
+func (r *Reconciler) syncSecretParts(ctx context.Context, es *esv1beta1.ExternalSecret, secretName string, parts []map[string][]byte) error {
+	lblValue := utils.ObjectHash(fmt.Sprintf("%v/%v", es.Namespace, es.Name))
+	keep := make(map[string]bool, len(parts))
+	for i, data := range parts {
+		part := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretPartName(secretName, i),
+				Namespace: es.Namespace,
+			},
+		}
+		keep[part.Name] = true
+		mutate := func() error {
+			if part.ResourceVersion != "" && part.Labels[esv1beta1.LabelOwner] != lblValue {
+				return fmt.Errorf(errSecretPartOwner, part.Name)
+			}
+			if err := controllerutil.SetControllerReference(es, &part.ObjectMeta, r.Scheme); err != nil {
+				return fmt.Errorf(errSetCtrlReference, err)
+			}
+			if part.Labels == nil {
+				part.Labels = make(map[string]string)
+			}
+			part.Labels[esv1beta1.LabelOwner] = lblValue
+			part.Immutable = &es.Spec.Target.Immutable
+			part.Data = data
+			return nil
+		}
+		if _, err := createOrUpdate(ctx, r.Client, part, mutate, es.Name); err != nil {
+			return fmt.Errorf(errUpdateSecretPart, part.Name, err)
+		}
+	}
+
+	var secretList v1.SecretList
+	err := r.List(ctx, &secretList, client.InNamespace(es.Namespace), client.MatchingLabels{esv1beta1.LabelOwner: lblValue})
+	if err != nil {
+		return fmt.Errorf(errListSecretParts, err)
+	}
+	for i := range secretList.Items {
+		part := &secretList.Items[i]
+		if !isSecretPart(part.Name, secretName) || keep[part.Name] {
+			continue
+		}
+		if err := r.Delete(ctx, part); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf(errDeleteSecretPart, part.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+func TestApplyOversizePolicy(t *testing.T) {
+	defer func(size int) { maxSecretSize = size }(maxSecretSize)
+	maxSecretSize = 10
+
+	tests := []struct {
+		name        string
+		oversize    *esv1beta1.ExternalSecretOversize
+		data        map[string][]byte
+		wantData    map[string][]byte
+		wantDropped []string
+		wantParts   []map[string][]byte
+		wantKeys    []string
+	}{
+		{
+			name:     "fits",
+			data:     map[string][]byte{"a": []byte("12345"), "b": []byte("12345")},
+			wantData: map[string][]byte{"a": []byte("12345"), "b": []byte("12345")},
+		},
+		{
+			name:     "fail lists the largest keys",
+			data:     map[string][]byte{"a": []byte("123"), "b": []byte("123456"), "c": []byte("1234")},
+			wantKeys: []string{"b"},
+		},
+		{
+			name:        "drop keys in order",
+			oversize:    &esv1beta1.ExternalSecretOversize{Policy: esv1beta1.OversizePolicyDropKeys, DropKeys: []string{"x", "a", "c"}},
+			data:        map[string][]byte{"a": []byte("123"), "b": []byte("123456"), "c": []byte("1234")},
+			wantData:    map[string][]byte{"b": []byte("123456"), "c": []byte("1234")},
+			wantDropped: []string{"a"},
+		},
+		{
+			name:     "drop keys does not fit",
+			oversize: &esv1beta1.ExternalSecretOversize{Policy: esv1beta1.OversizePolicyDropKeys, DropKeys: []string{"a"}},
+			data:     map[string][]byte{"a": []byte("1"), "b": []byte("123456"), "c": []byte("123456")},
+			wantKeys: []string{"b"},
+		},
+		{
+			name:     "split",
+			oversize: &esv1beta1.ExternalSecretOversize{Policy: esv1beta1.OversizePolicySplit},
+			data:     map[string][]byte{"a": []byte("123"), "b": []byte("123456"), "c": []byte("1234"), "d": []byte("12345678")},
+			wantData: map[string][]byte{"a": []byte("123"), "b": []byte("123456")},
+			wantParts: []map[string][]byte{
+				{"c": []byte("1234")},
+				{"d": []byte("12345678")},
+			},
+		},
+		{
+			name:     "split value too large",
+			oversize: &esv1beta1.ExternalSecretOversize{Policy: esv1beta1.OversizePolicySplit},
+			data:     map[string][]byte{"a": []byte("123"), "b": []byte("12345678901")},
+			wantKeys: []string{"b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &esv1beta1.ExternalSecret{}
+			es.Spec.Target.Oversize = tt.oversize
+			secret := &corev1.Secret{Data: tt.data}
+			dropped, parts, err := applyOversizePolicy(es, secret)
+			if tt.wantKeys != nil {
+				var tooLarge *secretTooLargeError
+				require.True(t, errors.As(err, &tooLarge))
+				assert.Equal(t, tt.wantKeys, tooLarge.keys)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantData, secret.Data)
+			assert.Equal(t, tt.wantDropped, dropped)
+			assert.Equal(t, tt.wantParts, parts)
+		})
+	}
+}
+
+func TestIsSplit(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{}
+	assert.False(t, isSplit(es))
+	es.Spec.Target.Oversize = &esv1beta1.ExternalSecretOversize{Policy: esv1beta1.OversizePolicyDropKeys}
+	assert.False(t, isSplit(es))
+	es.Spec.Target.Oversize.Policy = esv1beta1.OversizePolicySplit
+	assert.True(t, isSplit(es))
+}
+
+func TestIsSecretPart(t *testing.T) {
+	assert.True(t, isSecretPart("db-part-1", "db"))
+	assert.True(t, isSecretPart("db-part-12", "db"))
+	assert.False(t, isSecretPart("db", "db"))
+	assert.False(t, isSecretPart("db-part-0", "db"))
+	assert.False(t, isSecretPart("db-part-01", "db"))
+	assert.False(t, isSecretPart("db-part-x", "db"))
+	assert.False(t, isSecretPart("other-part-1", "db"))
+}
+
+func TestSyncSecretParts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default", UID: "uid"},
+	}
+	owner := map[string]string{esv1beta1.LabelOwner: utils.ObjectHash("default/es")}
+	secret := func(name string, labels map[string]string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+	}
+
+	t.Run("writes parts and deletes stale parts", func(t *testing.T) {
+		kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			secret("db-part-1", owner),
+			secret("db-part-2", owner),
+			secret("db-backup", owner),
+		).Build()
+		r := &Reconciler{Client: kube, Scheme: scheme}
+		parts := []map[string][]byte{{"c": []byte("1234")}}
+		require.NoError(t, r.syncSecretParts(context.Background(), es, "db", parts))
+
+		var part corev1.Secret
+		require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "db-part-1"}, &part))
+		assert.Equal(t, parts[0], part.Data)
+		assert.Equal(t, owner[esv1beta1.LabelOwner], part.Labels[esv1beta1.LabelOwner])
+		require.Len(t, part.OwnerReferences, 1)
+		assert.Equal(t, "es", part.OwnerReferences[0].Name)
+
+		var list corev1.SecretList
+		require.NoError(t, kube.List(context.Background(), &list))
+		names := []string{}
+		for _, s := range list.Items {
+			names = append(names, s.Name)
+		}
+		assert.ElementsMatch(t, []string{"db-part-1", "db-backup"}, names)
+	})
+
+	t.Run("does not take over foreign secrets", func(t *testing.T) {
+		kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(secret("db-part-1", nil)).Build()
+		r := &Reconciler{Client: kube, Scheme: scheme}
+		err := r.syncSecretParts(context.Background(), es, "db", []map[string][]byte{{"c": []byte("1234")}})
+		assert.ErrorContains(t, err, "is not managed by this ExternalSecret")
+	})
+}
@@ -0,0 +1,82 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tidwall/sjson"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errGeneratorParameter     = "could not set generator parameter %s of [%d]: %w"
+	errGeneratorParameterJSON = "value is not valid JSON"
+)
+
+// applyGeneratorParameters sets the parameters of the generatorRef in the
+// spec of the generator definition to the values read from the stores.
+// The generator resource itself is not changed.
+func (r *Reconciler) applyGeneratorParameters(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, generatorRef *esv1beta1.GeneratorRef, genDef *apiextensions.JSON, mgr *secretstore.Manager, i int) (*apiextensions.JSON, error) {
+	if len(generatorRef.Parameters) == 0 {
+		return genDef, nil
+	}
+	raw := genDef.Raw
+	for _, param := range generatorRef.Parameters {
+		value, err := r.getGeneratorParameter(ctx, externalSecret, param, mgr)
+		if err != nil {
+			return nil, fmt.Errorf(errGeneratorParameter, param.Path, i, err)
+		}
+		raw, err = sjson.SetRawBytes(raw, "spec."+param.Path, value)
+		if err != nil {
+			return nil, fmt.Errorf(errGeneratorParameter, param.Path, i, err)
+		}
+	}
+	return &apiextensions.JSON{Raw: raw}, nil
+}
+
+// getGeneratorParameter reads the value of a parameter and returns it as JSON.
+func (r *Reconciler) getGeneratorParameter(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, param esv1beta1.GeneratorParameter, mgr *secretstore.Manager) ([]byte, error) {
+	sourceRef := &esv1beta1.StoreGeneratorSourceRef{SecretStoreRef: param.StoreRef}
+	client, err := mgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, sourceRef)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	value, err := client.GetSecret(ctx, param.RemoteRef)
+	r.Auditor.Read(externalSecret, storeRefFor(externalSecret, sourceRef), audit.OperationGetSecret, param.RemoteRef.Key, param.RemoteRef.Property, nil, time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+	value, err = utils.Decode(param.RemoteRef.DecodingStrategy, value)
+	if err != nil {
+		return nil, err
+	}
+	if param.ValueType == esv1beta1.GeneratorParameterValueTypeJSON {
+		if !json.Valid(value) {
+			return nil, fmt.Errorf(errGeneratorParameterJSON)
+		}
+		return value, nil
+	}
+	return json.Marshal(string(value))
+}
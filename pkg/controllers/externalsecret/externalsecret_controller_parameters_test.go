@@ -0,0 +1,89 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+)
+
+func TestApplyGeneratorParameters(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "store", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+			AWS: &esv1beta1.AWSProvider{Service: esv1beta1.AWSServiceSecretsManager},
+		}},
+	}
+	kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build()
+
+	getSecretFn := fakeProvider.GetSecretFn
+	t.Cleanup(func() { fakeProvider.GetSecretFn = getSecretFn })
+	values := map[string]string{
+		"policy/length": "24",
+		"policy/issuer": "https://issuer.example.com",
+		"policy/broken": "{",
+	}
+	fakeProvider.GetSecretFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		return []byte(values[ref.Key]), nil
+	}
+
+	r := &Reconciler{Client: kube, Scheme: scheme}
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+		Spec:       esv1beta1.ExternalSecretSpec{SecretStoreRef: esv1beta1.SecretStoreRef{Name: "store"}},
+	}
+	genDef := &apiextensions.JSON{Raw: []byte(`{"kind":"Password","spec":{"length":32,"symbols":0}}`)}
+	ref := &esv1beta1.GeneratorRef{
+		Kind: "Password",
+		Name: "password",
+		Parameters: []esv1beta1.GeneratorParameter{
+			{Path: "length", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "policy/length"}, ValueType: esv1beta1.GeneratorParameterValueTypeJSON},
+			{Path: "auth.issuer", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "policy/issuer"}},
+		},
+	}
+	mgr := secretstore.NewManager(kube, "", false)
+	defer mgr.Close(context.Background())
+
+	got, err := r.applyGeneratorParameters(context.Background(), es, ref, genDef, mgr, 0)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"kind":"Password","spec":{"length":24,"symbols":0,"auth":{"issuer":"https://issuer.example.com"}}}`, string(got.Raw))
+	assert.JSONEq(t, `{"kind":"Password","spec":{"length":32,"symbols":0}}`, string(genDef.Raw), "the generator definition must not be changed")
+
+	ref.Parameters = []esv1beta1.GeneratorParameter{
+		{Path: "length", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "policy/broken"}, ValueType: esv1beta1.GeneratorParameterValueTypeJSON},
+	}
+	_, err = r.applyGeneratorParameters(context.Background(), es, ref, genDef, mgr, 0)
+	assert.EqualError(t, err, "could not set generator parameter length of [0]: value is not valid JSON")
+
+	ref.Parameters = []esv1beta1.GeneratorParameter{
+		{Path: "length", StoreRef: &esv1beta1.SecretStoreRef{Name: "missing"}, RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "policy/length"}},
+	}
+	_, err = r.applyGeneratorParameters(context.Background(), es, ref, genDef, mgr, 0)
+	assert.ErrorContains(t, err, "could not set generator parameter length of [0]")
+}
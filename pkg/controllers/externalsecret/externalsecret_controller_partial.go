@@ -0,0 +1,57 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"strings"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils/redact"
+)
+
+const (
+	errSecretData      = "error retrieving secret at .data[%d], key: %s, err: %w"
+	msgPartiallySynced = "Secret was synced, %d data entries are unresolved"
+	msgKeysUnresolved  = "could not read data entries %s, see status.unresolvedKeys"
+)
+
+// isPartialSync reports whether the ExternalSecret writes the data entries
+// that were read when others fail.
+func isPartialSync(es *esv1beta1.ExternalSecret) bool {
+	return es.Spec.SyncPolicy == esv1beta1.SyncPolicyPartial
+}
+
+// newUnresolvedKey records a data entry that could not be read.
+func newUnresolvedKey(secretRef esv1beta1.ExternalSecretData, err error) esv1beta1.UnresolvedKey {
+	return esv1beta1.UnresolvedKey{
+		Key:       secretRef.SecretKey,
+		RemoteKey: secretRef.RemoteRef.Key,
+		Reason:    redact.Error(err).Error(),
+	}
+}
+
+// describeUnresolved lists the unresolved entries by their secretKey, or
+// their remote key if they use propertyKeys.
+func describeUnresolved(keys []esv1beta1.UnresolvedKey) string {
+	names := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k.Key != "" {
+			names = append(names, k.Key)
+			continue
+		}
+		names = append(names, k.RemoteKey)
+	}
+	return strings.Join(names, ", ")
+}
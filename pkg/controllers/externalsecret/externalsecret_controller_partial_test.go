@@ -0,0 +1,116 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestGetProviderSecretDataPartial(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+
+	t.Cleanup(fakeProvider.Reset)
+	fakeProvider.GetSecretFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		if ref.Key == "app/user" {
+			return []byte("admin"), nil
+		}
+		return nil, errors.New("access denied")
+	}
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "store", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+			AWS: &esv1beta1.AWSProvider{Service: esv1beta1.AWSServiceSecretsManager},
+		}},
+	}
+	r := &Reconciler{
+		Client:                    clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build(),
+		ClusterSecretStoreEnabled: true,
+		recorder:                  &record.FakeRecorder{},
+	}
+	newES := func(policy esv1beta1.ExternalSecretSyncPolicy, data ...esv1beta1.ExternalSecretData) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef: esv1beta1.SecretStoreRef{Name: "store"},
+				SyncPolicy:     policy,
+				Data:           data,
+			},
+		}
+	}
+	user := esv1beta1.ExternalSecretData{SecretKey: "user", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "app/user"}}
+	password := esv1beta1.ExternalSecretData{SecretKey: "password", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "app/password"}}
+	properties := esv1beta1.ExternalSecretData{
+		RemoteRef:    esv1beta1.ExternalSecretDataRemoteRef{Key: "app/config"},
+		PropertyKeys: map[string]string{"host": "HOST"},
+	}
+
+	t.Run("all or nothing", func(t *testing.T) {
+		_, _, err := r.getProviderSecretData(context.Background(), newES("", user, password))
+		assert.EqualError(t, err, "error retrieving secret at .data[1], key: app/password, err: access denied")
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		data, unresolved, err := r.getProviderSecretData(context.Background(), newES(esv1beta1.SyncPolicyPartial, user, password, properties))
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"user": []byte("admin")}, data)
+		assert.Equal(t, []esv1beta1.UnresolvedKey{
+			{Key: "password", RemoteKey: "app/password", Reason: "access denied"},
+			{RemoteKey: "app/config", Reason: "access denied"},
+		}, unresolved)
+		assert.Equal(t, "password, app/config", describeUnresolved(unresolved))
+	})
+
+	t.Run("partial without resolved entries", func(t *testing.T) {
+		_, _, err := r.getProviderSecretData(context.Background(), newES(esv1beta1.SyncPolicyPartial, password))
+		assert.EqualError(t, err, "error retrieving secret at .data[0], key: app/password, err: access denied")
+	})
+}
+
+func TestMarkAsDonePartial(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{recorder: recorder}
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Status: esv1beta1.ExternalSecretStatus{UnresolvedKeys: []esv1beta1.UnresolvedKey{
+			{Key: "password", RemoteKey: "app/password", Reason: "access denied"},
+		}},
+	}
+	r.markAsDone(es, time.Now(), logr.Discard())
+
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Equal(t, esv1beta1.ConditionReasonSecretPartiallySynced, cond.Reason)
+	assert.Equal(t, "Secret was synced, 1 data entries are unresolved", cond.Message)
+	assert.Contains(t, <-recorder.Events, "Updated Secret")
+	assert.Equal(t, "Warning KeysUnresolved could not read data entries password, see status.unresolvedKeys", <-recorder.Events)
+}
@@ -0,0 +1,44 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+const (
+	errListPolicies = "could not list ExternalSecretPolicies"
+	msgPolicyDenied = "the secret was kept: %v"
+)
+
+// externalSecretPolicies returns the ExternalSecretPolicies of the namespace.
+// The webhook only checks them on admission, the ExternalSecrets that existed
+// before a policy or were changed while the webhook was unavailable are
+// checked before every sync. Without the CRD there are no policies.
+func (r *Reconciler) externalSecretPolicies(ctx context.Context, namespace string) ([]esv1alpha1.ExternalSecretPolicy, error) {
+	var list esv1alpha1.ExternalSecretPolicyList
+	if err := r.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,64 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestExternalSecretPolicies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, esv1alpha1.AddToScheme(scheme))
+	policy := func(name, namespace string) *esv1alpha1.ExternalSecretPolicy {
+		return &esv1alpha1.ExternalSecretPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       esv1alpha1.ExternalSecretPolicySpec{AllowedRemoteKeys: []string{namespace + "/*"}},
+		}
+	}
+	r := &Reconciler{Client: clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		policy("keys", "team-a"),
+		policy("keys", "team-b"),
+	).Build()}
+
+	policies, err := r.externalSecretPolicies(context.Background(), "team-a")
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, "team-a", policies[0].Namespace)
+
+	// an ExternalSecret that was created before the policy is denied.
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "team-a"},
+		Spec: esv1beta1.ExternalSecretSpec{Data: []esv1beta1.ExternalSecretData{
+			{SecretKey: "a", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "team-a/db"}},
+			{SecretKey: "b", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "team-b/db"}},
+		}},
+	}
+	assert.EqualError(t, esv1alpha1.CheckExternalSecretPolicies(policies, es),
+		`denied by ExternalSecretPolicy "keys": data[1]: remote key "team-b/db" is not allowed`)
+
+	policies, err = r.externalSecretPolicies(context.Background(), "team-c")
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+}
@@ -0,0 +1,239 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+)
+
+const (
+	errListStoreTarget = "could not list ExternalSecrets for store"
+
+	// priorityRequeueDelay is the delay an ExternalSecret is requeued with
+	// while ExternalSecrets with a higher priority are pending.
+	priorityRequeueDelay = 2 * time.Second
+	// maxPriorityWait is the longest time a pending ExternalSecret holds back
+	// the ones with a lower priority, so a lost event does not block them.
+	maxPriorityWait = time.Minute
+)
+
+// priorityGate orders the reconciliation of ExternalSecrets by priority.
+// The controller-runtime queue is FIFO, so the gate tracks the
+// ExternalSecrets that were enqueued by a watch event, e.g. all of them at
+// startup, and requeues the reconciliation of ExternalSecrets with a lower
+// priority until the pending ones are reconciled. The zero value is ready
+// to use.
+type priorityGate struct {
+	mu sync.Mutex
+	// pending holds the time the pending ExternalSecrets were enqueued at
+	// by priority, and priorities the priority of each.
+	pending    map[int32]map[types.NamespacedName]time.Time
+	priorities map[types.NamespacedName]int32
+	now        func() time.Time
+}
+
+// add marks es as pending.
+func (g *priorityGate) add(es *esv1beta1.ExternalSecret) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.pending == nil {
+		g.pending = make(map[int32]map[types.NamespacedName]time.Time)
+		g.priorities = make(map[types.NamespacedName]int32)
+	}
+	name := types.NamespacedName{Namespace: es.Namespace, Name: es.Name}
+	g.remove(name)
+	priority := es.Spec.Priority
+	if g.pending[priority] == nil {
+		g.pending[priority] = make(map[types.NamespacedName]time.Time)
+	}
+	g.pending[priority][name] = g.clock()
+	g.priorities[name] = priority
+}
+
+// wait returns true if ExternalSecrets with a higher priority than es are
+// pending, otherwise es is no longer pending.
+func (g *priorityGate) wait(es *esv1beta1.ExternalSecret) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	name := types.NamespacedName{Namespace: es.Namespace, Name: es.Name}
+	now := g.clock()
+	for priority, names := range g.pending {
+		if priority <= es.Spec.Priority {
+			continue
+		}
+		for n, since := range names {
+			if now.Sub(since) < maxPriorityWait {
+				return true
+			}
+			g.remove(n)
+		}
+	}
+	g.remove(name)
+	return false
+}
+
+// forget removes the ExternalSecret from the pending ones.
+func (g *priorityGate) forget(name types.NamespacedName) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.remove(name)
+}
+
+// empty returns true if no ExternalSecret is pending.
+func (g *priorityGate) empty() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.priorities) == 0
+}
+
+func (g *priorityGate) remove(name types.NamespacedName) {
+	priority, ok := g.priorities[name]
+	if !ok {
+		return
+	}
+	delete(g.priorities, name)
+	delete(g.pending[priority], name)
+	if len(g.pending[priority]) == 0 {
+		delete(g.pending, priority)
+	}
+}
+
+func (g *priorityGate) clock() time.Time {
+	if g.now != nil {
+		return g.now()
+	}
+	return time.Now()
+}
+
+// waitForPriority returns true if the reconciliation of the ExternalSecret
+// has to wait for ExternalSecrets with a higher priority.
+func (r *Reconciler) waitForPriority(ctx context.Context, name types.NamespacedName) bool {
+	if r.priority.empty() {
+		return false
+	}
+	var es esv1beta1.ExternalSecret
+	err := r.Get(ctx, name, &es)
+	if apierrors.IsNotFound(err) {
+		r.priority.forget(name)
+		return false
+	}
+	if err != nil {
+		return false
+	}
+	return r.priority.wait(&es)
+}
+
+// trackPriority marks ExternalSecrets that are created or whose spec
+// changes as pending. It passes all events.
+func (r *Reconciler) trackPriority() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			if es, ok := e.Object.(*esv1beta1.ExternalSecret); ok {
+				r.priority.add(es)
+			}
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			es, ok := e.ObjectNew.(*esv1beta1.ExternalSecret)
+			if ok && e.ObjectOld.GetGeneration() != es.GetGeneration() {
+				r.priority.add(es)
+			}
+			return true
+		},
+	}
+}
+
+// externalSecretsForStore returns the ExternalSecrets that use the store
+// and are not ready or offline, so they are synced by priority once the
+// store is ready.
+func (r *Reconciler) externalSecretsForStore(ctx context.Context, obj client.Object) []reconcile.Request {
+	store, ok := obj.(esv1beta1.GenericStore)
+	if !ok {
+		return nil
+	}
+	var opts []client.ListOption
+	if store.GetKind() == esv1beta1.SecretStoreKind {
+		opts = append(opts, client.InNamespace(store.GetNamespace()))
+	}
+	var list esv1beta1.ExternalSecretList
+	if err := r.List(ctx, &list, opts...); err != nil {
+		r.Log.Error(err, errListStoreTarget, "kind", store.GetKind(), "name", store.GetName(), "namespace", store.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range list.Items {
+		es := &list.Items[i]
+		if !usesStore(es, store.GetKind(), store.GetName()) {
+			continue
+		}
+		if cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady); cond != nil && cond.Status == v1.ConditionTrue && !isOffline(es) {
+			continue
+		}
+		r.priority.add(es)
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: es.Name, Namespace: es.Namespace},
+		})
+	}
+	return requests
+}
+
+// usesStore returns true if es reads from the store of the kind and name.
+func usesStore(es *esv1beta1.ExternalSecret, kind, name string) bool {
+	matches := func(ref esv1beta1.SecretStoreRef) bool {
+		refKind := ref.Kind
+		if refKind == "" {
+			refKind = esv1beta1.SecretStoreKind
+		}
+		return ref.Name == name && refKind == kind
+	}
+	for _, ref := range storeRefs(es) {
+		if matches(ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// storeBecameReady passes the updates of stores that become ready.
+var storeBecameReady = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !storeReady(e.ObjectOld) && storeReady(e.ObjectNew)
+	},
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+func storeReady(obj client.Object) bool {
+	store, ok := obj.(esv1beta1.GenericStore)
+	if !ok {
+		return false
+	}
+	cond := secretstore.GetSecretStoreCondition(store.GetStatus(), esv1beta1.SecretStoreReady)
+	return cond != nil && cond.Status == v1.ConditionTrue
+}
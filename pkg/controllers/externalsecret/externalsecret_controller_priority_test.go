@@ -0,0 +1,170 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func priorityES(name string, priority int32) *esv1beta1.ExternalSecret {
+	return &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       esv1beta1.ExternalSecretSpec{Priority: priority},
+	}
+}
+
+func TestPriorityGate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := &priorityGate{now: func() time.Time { return now }}
+	assert.True(t, g.empty())
+
+	critical, normal, low := priorityES("ingress-cert", 100), priorityES("app", 0), priorityES("batch", -10)
+	g.add(critical)
+	g.add(normal)
+	g.add(low)
+
+	// only the ExternalSecret with the highest priority may proceed.
+	assert.True(t, g.wait(low))
+	assert.True(t, g.wait(normal))
+	assert.False(t, g.wait(critical))
+
+	assert.True(t, g.wait(low))
+	assert.False(t, g.wait(normal))
+	assert.False(t, g.wait(low))
+	assert.True(t, g.empty())
+
+	// a pending ExternalSecret that is never reconciled stops blocking.
+	g.add(critical)
+	assert.True(t, g.wait(normal))
+	now = now.Add(maxPriorityWait)
+	assert.False(t, g.wait(normal))
+	assert.True(t, g.empty())
+
+	// a change of the priority moves the ExternalSecret.
+	g.add(critical)
+	g.add(priorityES("ingress-cert", -100))
+	assert.False(t, g.wait(normal))
+	g.forget(types.NamespacedName{Namespace: "default", Name: "ingress-cert"})
+	assert.True(t, g.empty())
+}
+
+func TestTrackPriority(t *testing.T) {
+	r := &Reconciler{}
+	p := r.trackPriority()
+	es := priorityES("app", 10)
+	es.Generation = 1
+
+	assert.True(t, p.Create(event.CreateEvent{Object: es}))
+	assert.False(t, r.priority.empty())
+	r.priority.forget(types.NamespacedName{Namespace: "default", Name: "app"})
+
+	// status updates do not change the generation.
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: es, ObjectNew: es.DeepCopy()}))
+	assert.True(t, r.priority.empty())
+
+	changed := es.DeepCopy()
+	changed.Generation = 2
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: es, ObjectNew: changed}))
+	assert.False(t, r.priority.empty())
+}
+
+func TestExternalSecretsForStore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+
+	ready := esv1beta1.ExternalSecretStatus{Conditions: []esv1beta1.ExternalSecretStatusCondition{
+		{Type: esv1beta1.ExternalSecretReady, Status: v1.ConditionTrue},
+	}}
+	failing := func(name, namespace string, spec esv1beta1.ExternalSecretSpec) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: spec}
+	}
+	storeRef := esv1beta1.SecretStoreRef{Name: "chef"}
+	objects := []runtime.Object{
+		failing("uses-store", "default", esv1beta1.ExternalSecretSpec{SecretStoreRef: storeRef}),
+		failing("uses-store-in-data", "default", esv1beta1.ExternalSecretSpec{
+			Data: []esv1beta1.ExternalSecretData{{SourceRef: &esv1beta1.StoreSourceRef{SecretStoreRef: storeRef}}},
+		}),
+		failing("uses-store-in-generator-parameters", "default", esv1beta1.ExternalSecretSpec{
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{{SourceRef: &esv1beta1.StoreGeneratorSourceRef{
+				GeneratorRef: &esv1beta1.GeneratorRef{Name: "password", Parameters: []esv1beta1.GeneratorParameter{{Path: "length", StoreRef: &storeRef}}},
+			}}},
+		}),
+		failing("other-namespace", "other", esv1beta1.ExternalSecretSpec{SecretStoreRef: storeRef}),
+		failing("other-store", "default", esv1beta1.ExternalSecretSpec{SecretStoreRef: esv1beta1.SecretStoreRef{Name: "vault"}}),
+		failing("cluster-store", "default", esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: esv1beta1.SecretStoreRef{Name: "chef", Kind: esv1beta1.ClusterSecretStoreKind},
+		}),
+		&esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "default"},
+			Spec:       esv1beta1.ExternalSecretSpec{SecretStoreRef: storeRef},
+			Status:     ready,
+		},
+		&esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "offline", Namespace: "default"},
+			Spec:       esv1beta1.ExternalSecretSpec{SecretStoreRef: storeRef},
+			Status: esv1beta1.ExternalSecretStatus{Conditions: []esv1beta1.ExternalSecretStatusCondition{
+				{Type: esv1beta1.ExternalSecretReady, Status: v1.ConditionTrue, Reason: esv1beta1.ConditionReasonStoreOffline},
+			}},
+		},
+	}
+	r := &Reconciler{Client: clientfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()}
+
+	requests := r.externalSecretsForStore(context.Background(), &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "chef", Namespace: "default"},
+	})
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "uses-store"}},
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "uses-store-in-data"}},
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "uses-store-in-generator-parameters"}},
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "offline"}},
+	}, requests)
+	assert.False(t, r.priority.empty())
+
+	requests = r.externalSecretsForStore(context.Background(), &esv1beta1.ClusterSecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "chef"},
+	})
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "cluster-store"}},
+	}, requests)
+}
+
+func TestStoreBecameReady(t *testing.T) {
+	store := func(status v1.ConditionStatus) *esv1beta1.SecretStore {
+		return &esv1beta1.SecretStore{Status: esv1beta1.SecretStoreStatus{Conditions: []esv1beta1.SecretStoreStatusCondition{
+			{Type: esv1beta1.SecretStoreReady, Status: status},
+		}}}
+	}
+	assert.True(t, storeBecameReady.Update(event.UpdateEvent{ObjectOld: store(v1.ConditionFalse), ObjectNew: store(v1.ConditionTrue)}))
+	assert.True(t, storeBecameReady.Update(event.UpdateEvent{ObjectOld: &esv1beta1.SecretStore{}, ObjectNew: store(v1.ConditionTrue)}))
+	assert.False(t, storeBecameReady.Update(event.UpdateEvent{ObjectOld: store(v1.ConditionTrue), ObjectNew: store(v1.ConditionTrue)}))
+	assert.False(t, storeBecameReady.Update(event.UpdateEvent{ObjectOld: store(v1.ConditionTrue), ObjectNew: store(v1.ConditionFalse)}))
+	assert.False(t, storeBecameReady.Create(event.CreateEvent{Object: store(v1.ConditionTrue)}))
+}
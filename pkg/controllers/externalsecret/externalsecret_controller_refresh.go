@@ -0,0 +1,265 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/guarded"
+)
+
+// refreshInterval returns the interval of the ExternalSecret, the entries
+// without their own refreshInterval and spec.dataFrom are read at.
+func refreshInterval(es *esv1beta1.ExternalSecret, defaultInterval time.Duration) time.Duration {
+	if es.Spec.RefreshInterval != nil {
+		return es.Spec.RefreshInterval.Duration
+	}
+	return defaultInterval
+}
+
+// shortestRefreshInterval returns the shortest of the interval of the
+// ExternalSecret and the refreshIntervals of its data entries. It schedules
+// the refreshes while the controller did not read the entries yet, e.g.
+// after a restart.
+func shortestRefreshInterval(es *esv1beta1.ExternalSecret, defaultInterval time.Duration) time.Duration {
+	interval := refreshInterval(es, defaultInterval)
+	for _, data := range es.Spec.Data {
+		if data.RefreshInterval == nil || data.RefreshInterval.Duration <= 0 {
+			continue
+		}
+		if interval <= 0 || data.RefreshInterval.Duration < interval {
+			interval = data.RefreshInterval.Duration
+		}
+	}
+	return interval
+}
+
+// hasEntryRefreshIntervals reports whether a data entry sets its own refreshInterval.
+func hasEntryRefreshIntervals(es *esv1beta1.ExternalSecret) bool {
+	for _, data := range es.Spec.Data {
+		if data.RefreshInterval != nil && data.RefreshInterval.Duration > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// entryCache keeps in memory the values of the entries of the
+// ExternalSecrets whose data entries set their own refreshInterval, so that
+// every entry is only read from the provider once its interval elapsed.
+// The values are not written anywhere, after a restart all entries are
+// read again. They are kept in guarded memory and wiped when the entry is
+// dropped.
+type entryCache struct {
+	mu      sync.Mutex
+	secrets map[types.NamespacedName]*cachedEntries
+}
+
+// cachedEntries are the entries of one ExternalSecret. They are only used by
+// the reconcile of the ExternalSecret, which never runs concurrently.
+type cachedEntries struct {
+	uid     types.UID
+	entries map[string]*cachedEntry
+	// used holds the hashes of the entries read by the current sync, the
+	// other entries are dropped when it is done.
+	used map[string]bool
+}
+
+type cachedEntry struct {
+	values      map[string]*guarded.Bytes
+	refreshedAt time.Time
+	interval    time.Duration
+	// failed entries are read again on the next sync, but do not schedule
+	// one before their interval elapsed.
+	failed bool
+}
+
+// entries returns the cached entries of the ExternalSecret, or nil if none of
+// its data entries sets a refreshInterval.
+func (r *Reconciler) entries(es *esv1beta1.ExternalSecret) *cachedEntries {
+	if r.disableDataCache {
+		return nil
+	}
+	key := types.NamespacedName{Name: es.Name, Namespace: es.Namespace}
+	r.entryCache.mu.Lock()
+	defer r.entryCache.mu.Unlock()
+	if !hasEntryRefreshIntervals(es) {
+		r.entryCache.secrets[key].destroy()
+		delete(r.entryCache.secrets, key)
+		return nil
+	}
+	if r.entryCache.secrets == nil {
+		r.entryCache.secrets = make(map[types.NamespacedName]*cachedEntries)
+	}
+	c, ok := r.entryCache.secrets[key]
+	if !ok || c.uid != es.UID {
+		c.destroy()
+		c = &cachedEntries{uid: es.UID, entries: make(map[string]*cachedEntry)}
+		r.entryCache.secrets[key] = c
+	}
+	c.used = make(map[string]bool)
+	return c
+}
+
+// forgetEntries drops the cached entries of a deleted ExternalSecret.
+func (r *Reconciler) forgetEntries(name types.NamespacedName) {
+	r.entryCache.mu.Lock()
+	r.entryCache.secrets[name].destroy()
+	delete(r.entryCache.secrets, name)
+	r.entryCache.mu.Unlock()
+}
+
+// nextEntryRefresh returns when the next entry of the ExternalSecret is due.
+// It returns false if the entries are not cached, then the ExternalSecret is
+// refreshed at its shortestRefreshInterval. A zero time means that none of
+// the entries is read again.
+func (r *Reconciler) nextEntryRefresh(es *esv1beta1.ExternalSecret) (time.Time, bool) {
+	r.entryCache.mu.Lock()
+	c, ok := r.entryCache.secrets[types.NamespacedName{Name: es.Name, Namespace: es.Namespace}]
+	r.entryCache.mu.Unlock()
+	if !ok || c.uid != es.UID || !hasEntryRefreshIntervals(es) {
+		return time.Time{}, false
+	}
+	var next time.Time
+	due := func(hash string, err error) bool {
+		entry, ok := c.entries[hash]
+		if err != nil || !ok {
+			// the entry was not read yet.
+			return true
+		}
+		if entry.interval <= 0 {
+			return false
+		}
+		if at := entry.refreshedAt.Add(entry.interval); next.IsZero() || at.Before(next) {
+			next = at
+		}
+		return false
+	}
+	for _, ref := range es.Spec.DataFrom {
+		if due(dataFromHash(es, ref)) {
+			return time.Now(), true
+		}
+	}
+	for _, ref := range es.Spec.Data {
+		if due(entryHash(es, ref)) {
+			return time.Now(), true
+		}
+	}
+	return next, true
+}
+
+// entryHash identifies the cached values of a data entry. A change of the
+// entry or of the store of the ExternalSecret invalidates them.
+func entryHash(es *esv1beta1.ExternalSecret, secretRef esv1beta1.ExternalSecretData) (string, error) {
+	return hashEntry("data", es.Spec.SecretStoreRef, secretRef)
+}
+
+// dataFromHash identifies the cached values of a dataFrom entry.
+func dataFromHash(es *esv1beta1.ExternalSecret, ref esv1beta1.ExternalSecretDataFromRemoteRef) (string, error) {
+	return hashEntry("dataFrom", es.Spec.SecretStoreRef, ref)
+}
+
+func hashEntry(kind string, storeRef esv1beta1.SecretStoreRef, ref any) (string, error) {
+	raw, err := json.Marshal([]any{kind, storeRef, ref})
+	if err != nil {
+		return "", err
+	}
+	return utils.ObjectHash(string(raw))[:8], nil
+}
+
+// fetch returns the cached values of the entry if its interval did not
+// elapse, otherwise it reads them and caches them. Without a cache the
+// values are always read.
+func (c *cachedEntries) fetch(hash string, hashErr error, interval time.Duration, now time.Time, read func() (map[string][]byte, error)) (map[string][]byte, error) {
+	if c == nil || hashErr != nil {
+		return read()
+	}
+	c.used[hash] = true
+	entry, ok := c.entries[hash]
+	if ok && !entry.failed && (entry.interval <= 0 || now.Before(entry.refreshedAt.Add(entry.interval))) {
+		return entry.copyValues(), nil
+	}
+	if ok {
+		entry.destroy()
+	}
+	values, err := read()
+	c.entries[hash] = &cachedEntry{values: guardValues(values), refreshedAt: now, interval: interval, failed: err != nil}
+	return values, err
+}
+
+// prune drops the entries that are no longer part of the ExternalSecret.
+func (c *cachedEntries) prune() {
+	if c == nil {
+		return
+	}
+	for hash, entry := range c.entries {
+		if !c.used[hash] {
+			entry.destroy()
+			delete(c.entries, hash)
+		}
+	}
+}
+
+// destroy wipes the values of all entries.
+func (c *cachedEntries) destroy() {
+	if c == nil {
+		return
+	}
+	for _, entry := range c.entries {
+		entry.destroy()
+	}
+}
+
+func guardValues(values map[string][]byte) map[string]*guarded.Bytes {
+	if values == nil {
+		return nil
+	}
+	guardedValues := make(map[string]*guarded.Bytes, len(values))
+	for k, v := range values {
+		guardedValues[k] = guarded.New(v)
+	}
+	return guardedValues
+}
+
+func (e *cachedEntry) copyValues() map[string][]byte {
+	if e.values == nil {
+		return nil
+	}
+	values := make(map[string][]byte, len(e.values))
+	for k, v := range e.values {
+		values[k] = v.Copy()
+	}
+	return values
+}
+
+func (e *cachedEntry) destroy() {
+	for _, v := range e.values {
+		v.Destroy()
+	}
+}
+
+// entryInterval returns the interval the data entry is read at.
+func entryInterval(es *esv1beta1.ExternalSecret, secretRef esv1beta1.ExternalSecretData, defaultInterval time.Duration) time.Duration {
+	if secretRef.RefreshInterval != nil && secretRef.RefreshInterval.Duration > 0 {
+		return secretRef.RefreshInterval.Duration
+	}
+	return refreshInterval(es, defaultInterval)
+}
@@ -0,0 +1,224 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestRefreshJitter(t *testing.T) {
+	r := &Reconciler{RefreshJitter: 0.1}
+	es := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{UID: "1"}}
+
+	assert.Zero(t, (&Reconciler{}).refreshJitter(es, time.Hour))
+	assert.Zero(t, r.refreshJitter(es, 0))
+	// the jitter is the same for every reconcile.
+	assert.Equal(t, r.refreshJitter(es, time.Hour), r.refreshJitter(es, time.Hour))
+
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		es := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{UID: types.UID(fmt.Sprintf("uid-%d", i))}}
+		jitter := r.refreshJitter(es, time.Hour)
+		assert.GreaterOrEqual(t, jitter, time.Duration(0))
+		assert.LessOrEqual(t, jitter, 6*time.Minute)
+		seen[jitter] = true
+	}
+	assert.Greater(t, len(seen), 90)
+}
+
+func TestRefreshInterval(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{}
+	assert.Equal(t, time.Hour, refreshInterval(es, time.Hour))
+	assert.Equal(t, time.Hour, shortestRefreshInterval(es, time.Hour))
+
+	es.Spec.RefreshInterval = &metav1.Duration{Duration: 10 * time.Minute}
+	assert.Equal(t, 10*time.Minute, refreshInterval(es, time.Hour))
+
+	es.Spec.Data = []esv1beta1.ExternalSecretData{
+		{SecretKey: "tls.crt", RefreshInterval: &metav1.Duration{Duration: 24 * time.Hour}},
+		{SecretKey: "password", RefreshInterval: &metav1.Duration{Duration: 5 * time.Minute}},
+		{SecretKey: "user"},
+	}
+	assert.Equal(t, 10*time.Minute, refreshInterval(es, time.Hour))
+	assert.Equal(t, 5*time.Minute, shortestRefreshInterval(es, time.Hour))
+	assert.Equal(t, 24*time.Hour, entryInterval(es, es.Spec.Data[0], time.Hour))
+	assert.Equal(t, 10*time.Minute, entryInterval(es, es.Spec.Data[2], time.Hour))
+
+	// entries are refreshed even if the ExternalSecret is fetched once.
+	es.Spec.RefreshInterval = &metav1.Duration{}
+	assert.Equal(t, 5*time.Minute, shortestRefreshInterval(es, time.Hour))
+}
+
+func TestGetProviderSecretDataRefreshInterval(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+
+	calls := map[string]int{}
+	t.Cleanup(fakeProvider.Reset)
+	fakeProvider.GetSecretFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		calls[ref.Key]++
+		return []byte(fmt.Sprintf("%s-%d", ref.Key, calls[ref.Key])), nil
+	}
+	fakeProvider.GetSecretMapFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+		calls[ref.Key]++
+		return map[string][]byte{"extra": []byte(fmt.Sprintf("%s-%d", ref.Key, calls[ref.Key]))}, nil
+	}
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "store", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+			AWS: &esv1beta1.AWSProvider{Service: esv1beta1.AWSServiceSecretsManager},
+		}},
+	}
+	kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build()
+	r := &Reconciler{Client: kube, Scheme: scheme, recorder: &record.FakeRecorder{}, RequeueInterval: time.Hour}
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "uid"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "store"},
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+			Data: []esv1beta1.ExternalSecretData{
+				{
+					SecretKey:       "password",
+					RemoteRef:       esv1beta1.ExternalSecretDataRemoteRef{Key: "password"},
+					RefreshInterval: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+				{SecretKey: "user", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "user"}},
+			},
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{Extract: &esv1beta1.ExternalSecretDataRemoteRef{Key: "bundle"}},
+			},
+		},
+	}
+	start := time.Now()
+
+	_, ok := r.nextEntryRefresh(es)
+	assert.False(t, ok, "the entries were not read yet")
+	data, _, err := r.getProviderSecretData(context.Background(), es)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"password": []byte("password-1"), "user": []byte("user-1"), "extra": []byte("bundle-1")}, data)
+
+	// the next refresh is due when the password is.
+	next, ok := r.nextEntryRefresh(es)
+	require.True(t, ok)
+	assert.WithinDuration(t, start.Add(5*time.Minute), next, time.Minute)
+
+	// the entries that are not due are served from memory.
+	data, _, err = r.getProviderSecretData(context.Background(), es)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"password": []byte("password-1"), "user": []byte("user-1"), "extra": []byte("bundle-1")}, data)
+
+	// only the password is read once its interval elapsed.
+	entries := r.entryCache.secrets[types.NamespacedName{Name: "app", Namespace: "default"}]
+	for _, entry := range entries.entries {
+		if entry.interval == 5*time.Minute {
+			entry.refreshedAt = entry.refreshedAt.Add(-5 * time.Minute)
+		}
+	}
+	next, _ = r.nextEntryRefresh(es)
+	assert.False(t, time.Now().Before(next))
+	data, _, err = r.getProviderSecretData(context.Background(), es)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"password": []byte("password-2"), "user": []byte("user-1"), "extra": []byte("bundle-1")}, data)
+
+	// a changed entry is read again, the old one is dropped.
+	es.Spec.Data[1].RemoteRef.Version = "2"
+	data, _, err = r.getProviderSecretData(context.Background(), es)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("user-2"), data["user"])
+	assert.Len(t, entries.entries, 3)
+
+	// nothing is written to the namespace.
+	var secrets corev1.SecretList
+	require.NoError(t, kube.List(context.Background(), &secrets))
+	assert.Empty(t, secrets.Items)
+
+	r.forgetEntries(types.NamespacedName{Name: "app", Namespace: "default"})
+	_, ok = r.nextEntryRefresh(es)
+	assert.False(t, ok)
+}
+
+func TestEntryCacheFailedEntry(t *testing.T) {
+	now := time.Now()
+	c := &cachedEntries{entries: map[string]*cachedEntry{}, used: map[string]bool{}}
+	_, err := c.fetch("abc", nil, time.Hour, now, func() (map[string][]byte, error) {
+		return nil, errors.New("unavailable")
+	})
+	assert.Error(t, err)
+
+	// a failed entry is read again on the next sync.
+	values, err := c.fetch("abc", nil, time.Hour, now.Add(time.Minute), func() (map[string][]byte, error) {
+		return map[string][]byte{"key": []byte("value")}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key": []byte("value")}, values)
+	values, err = c.fetch("abc", nil, time.Hour, now.Add(59*time.Minute), func() (map[string][]byte, error) {
+		return nil, errors.New("must not be read")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key": []byte("value")}, values)
+}
+
+func TestEntryCacheDestroy(t *testing.T) {
+	now := time.Now()
+	c := &cachedEntries{entries: map[string]*cachedEntry{}, used: map[string]bool{}}
+	read := func(value string) func() (map[string][]byte, error) {
+		return func() (map[string][]byte, error) {
+			return map[string][]byte{"key": []byte(value)}, nil
+		}
+	}
+	_, err := c.fetch("old", nil, time.Minute, now, read("old"))
+	require.NoError(t, err)
+	_, err = c.fetch("kept", nil, time.Minute, now, read("kept"))
+	require.NoError(t, err)
+	old := c.entries["old"].values["key"]
+	kept := c.entries["kept"].values["key"]
+	assert.Equal(t, []byte("old"), old.Copy())
+
+	// a refreshed entry wipes its previous values.
+	_, err = c.fetch("kept", nil, time.Minute, now.Add(time.Hour), read("new"))
+	require.NoError(t, err)
+	assert.Nil(t, kept.Copy())
+
+	// dropped entries are wiped.
+	c.used = map[string]bool{"kept": true}
+	c.prune()
+	assert.Nil(t, old.Copy())
+	refreshed := c.entries["kept"].values["key"]
+	assert.Equal(t, []byte("new"), refreshed.Copy())
+
+	// the entries of a deleted ExternalSecret are wiped.
+	name := types.NamespacedName{Name: "app", Namespace: "default"}
+	r := &Reconciler{}
+	r.entryCache.secrets = map[types.NamespacedName]*cachedEntries{name: c}
+	r.forgetEntries(name)
+	assert.Nil(t, refreshed.Copy())
+}
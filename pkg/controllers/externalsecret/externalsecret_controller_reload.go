@@ -0,0 +1,202 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errGetWorkload    = "could not get %s %s: %w"
+	errListWorkloads  = "could not list %s: %w"
+	errReloadWorkload = "could not reload %s %s: %w"
+	errReloadDisabled = "spec.target.reload is ignored, the controller runs without --enable-workload-reload"
+)
+
+// workload is a Deployment, StatefulSet or DaemonSet with its pod template.
+type workload struct {
+	kind     string
+	obj      client.Object
+	template *v1.PodTemplateSpec
+}
+
+// reloadWorkloads restarts the workloads of spec.target.reload by setting the
+// data hash of the Secret as annotation on their pod template. Workloads that
+// were never reloaded for the Secret are only restarted if the data changed
+// in this reconcile, so that configuring reload does not restart them.
+func (r *Reconciler) reloadWorkloads(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, secretName, hash string, changed bool) error {
+	reload := externalSecret.Spec.Target.Reload
+	if reload == nil || hash == "" {
+		return nil
+	}
+	if !r.EnableWorkloadReload {
+		if changed {
+			r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonReloadFailed, errReloadDisabled)
+		}
+		return nil
+	}
+	workloads, err := r.reloadTargets(ctx, externalSecret.Namespace, secretName, reload)
+	if err != nil {
+		return err
+	}
+	key := reloadAnnotation(secretName)
+	var errs []error
+	for _, w := range workloads {
+		current, reloaded := w.template.Annotations[key]
+		if current == hash || (!reloaded && !changed) {
+			continue
+		}
+		patch := client.MergeFrom(w.obj.DeepCopyObject().(client.Object))
+		if w.template.Annotations == nil {
+			w.template.Annotations = make(map[string]string)
+		}
+		w.template.Annotations[key] = hash
+		if err := r.Patch(ctx, w.obj, patch); err != nil {
+			errs = append(errs, fmt.Errorf(errReloadWorkload, w.kind, w.obj.GetName(), err))
+			continue
+		}
+		r.recorder.Eventf(externalSecret, v1.EventTypeNormal, esv1beta1.ReasonReloaded, "restarted %s %s", w.kind, w.obj.GetName())
+	}
+	return errors.Join(errs...)
+}
+
+// reloadTargets returns the workloads listed in reload, or all workloads of
+// the namespace that use the Secret if none are listed.
+func (r *Reconciler) reloadTargets(ctx context.Context, namespace, secretName string, reload *esv1beta1.ExternalSecretReload) ([]workload, error) {
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	if len(reload.Workloads) > 0 {
+		workloads := make([]workload, 0, len(reload.Workloads))
+		for _, ref := range reload.Workloads {
+			w, ok := newWorkload(ref.Kind)
+			if !ok {
+				continue
+			}
+			err := reader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, w.obj)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf(errGetWorkload, ref.Kind, ref.Name, err)
+			}
+			workloads = append(workloads, w)
+		}
+		return workloads, nil
+	}
+
+	var workloads []workload
+	deployments := &appsv1.DeploymentList{}
+	if err := reader.List(ctx, deployments, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf(errListWorkloads, "deployments", err)
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		workloads = append(workloads, workload{kind: "Deployment", obj: d, template: &d.Spec.Template})
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := reader.List(ctx, statefulSets, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf(errListWorkloads, "statefulsets", err)
+	}
+	for i := range statefulSets.Items {
+		s := &statefulSets.Items[i]
+		workloads = append(workloads, workload{kind: "StatefulSet", obj: s, template: &s.Spec.Template})
+	}
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := reader.List(ctx, daemonSets, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf(errListWorkloads, "daemonsets", err)
+	}
+	for i := range daemonSets.Items {
+		d := &daemonSets.Items[i]
+		workloads = append(workloads, workload{kind: "DaemonSet", obj: d, template: &d.Spec.Template})
+	}
+
+	using := workloads[:0]
+	for _, w := range workloads {
+		if w.obj.GetDeletionTimestamp() == nil && usesSecret(&w.template.Spec, secretName) {
+			using = append(using, w)
+		}
+	}
+	return using, nil
+}
+
+func newWorkload(kind string) (workload, bool) {
+	switch kind {
+	case "Deployment":
+		d := &appsv1.Deployment{}
+		return workload{kind: kind, obj: d, template: &d.Spec.Template}, true
+	case "StatefulSet":
+		s := &appsv1.StatefulSet{}
+		return workload{kind: kind, obj: s, template: &s.Spec.Template}, true
+	case "DaemonSet":
+		d := &appsv1.DaemonSet{}
+		return workload{kind: kind, obj: d, template: &d.Spec.Template}, true
+	}
+	return workload{}, false
+}
+
+// usesSecret returns true if a volume or an environment variable of the pod
+// references the Secret.
+func usesSecret(spec *v1.PodSpec, name string) bool {
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == name {
+			return true
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.Secret != nil && source.Secret.Name == name {
+				return true
+			}
+		}
+	}
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil && envFrom.SecretRef.Name == name {
+				return true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reloadAnnotation returns the pod template annotation holding the data hash
+// of the Secret. Names that are too long for an annotation key are hashed.
+func reloadAnnotation(secretName string) string {
+	if len(secretName) > validation.DNS1123LabelMaxLength {
+		secretName = utils.ObjectHash(secretName)
+	}
+	return esv1beta1.AnnotationReloadPrefix + secretName
+}
@@ -0,0 +1,146 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReloadWorkloads(t *testing.T) {
+	key := esv1beta1.AnnotationReloadPrefix + "db"
+	envFrom := corev1.PodSpec{Containers: []corev1.Container{{
+		Name:    "app",
+		EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}}}},
+	}}}
+	volume := corev1.PodSpec{Volumes: []corev1.Volume{{
+		Name:         "db",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "db"}},
+	}}}
+	other := corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}
+
+	newObjects := func() []*appsv1.Deployment {
+		return []*appsv1.Deployment{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "env", Namespace: "default"},
+				Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: envFrom}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "reloaded", Namespace: "default"},
+				Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{key: "old"}},
+					Spec:       volume,
+				}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+				Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: other}},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		reload    *esv1beta1.ExternalSecretReload
+		disabled  bool
+		changed   bool
+		reloaded  []string
+		untouched []string
+	}{
+		{
+			name:      "changed data reloads all workloads using the secret",
+			reload:    &esv1beta1.ExternalSecretReload{},
+			changed:   true,
+			reloaded:  []string{"env", "reloaded"},
+			untouched: []string{"other"},
+		},
+		{
+			name:      "retries workloads that were reloaded before",
+			reload:    &esv1beta1.ExternalSecretReload{},
+			reloaded:  []string{"reloaded"},
+			untouched: []string{"env", "other"},
+		},
+		{
+			name: "only listed workloads",
+			reload: &esv1beta1.ExternalSecretReload{Workloads: []esv1beta1.ReloadWorkloadRef{
+				{Kind: "Deployment", Name: "other"},
+				{Kind: "StatefulSet", Name: "missing"},
+			}},
+			changed:   true,
+			reloaded:  []string{"other"},
+			untouched: []string{"env", "reloaded"},
+		},
+		{
+			name:      "disabled",
+			reload:    &esv1beta1.ExternalSecretReload{},
+			disabled:  true,
+			changed:   true,
+			untouched: []string{"env", "reloaded", "other"},
+		},
+		{
+			name:      "no reload",
+			changed:   true,
+			untouched: []string{"env", "reloaded", "other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := clientfake.NewClientBuilder()
+			for _, d := range newObjects() {
+				builder = builder.WithObjects(d)
+			}
+			kube := builder.Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{Client: kube, EnableWorkloadReload: !tt.disabled, recorder: recorder}
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+				Spec:       esv1beta1.ExternalSecretSpec{Target: esv1beta1.ExternalSecretTarget{Reload: tt.reload}},
+			}
+			require.NoError(t, r.reloadWorkloads(context.Background(), es, "db", "new", tt.changed))
+
+			for _, name := range tt.reloaded {
+				d := &appsv1.Deployment{}
+				require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, d))
+				assert.Equal(t, "new", d.Spec.Template.Annotations[key], name)
+			}
+			for _, name := range tt.untouched {
+				d := &appsv1.Deployment{}
+				require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, d))
+				assert.NotEqual(t, "new", d.Spec.Template.Annotations[key], name)
+			}
+			if tt.disabled {
+				require.Len(t, recorder.Events, 1)
+				assert.Contains(t, <-recorder.Events, esv1beta1.ReasonReloadFailed)
+			}
+		})
+	}
+}
+
+func TestReloadAnnotation(t *testing.T) {
+	assert.Equal(t, esv1beta1.AnnotationReloadPrefix+"db", reloadAnnotation("db"))
+	long := reloadAnnotation(strings.Repeat("a", 64))
+	assert.Len(t, strings.TrimPrefix(long, esv1beta1.AnnotationReloadPrefix), 32)
+}
@@ -0,0 +1,81 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const errRenderGenerator = "generators are not supported when rendering, spec.dataFrom[%d] uses a generatorRef"
+
+// Render fetches the data of an ExternalSecret and returns the secret the
+// controller would write, without writing it. It is used to debug
+// ExternalSecrets before applying them: c must serve the stores and the
+// resources they and the templates reference.
+//
+// Stores are used regardless of their status and data entries are read
+// regardless of their refreshInterval. Generators are not supported because
+// they may create resources at the provider.
+func Render(ctx context.Context, c client.Client, controllerClass string, es *esv1beta1.ExternalSecret) (*v1.Secret, error) {
+	for i, remoteRef := range es.Spec.DataFrom {
+		if remoteRef.SourceRef != nil && remoteRef.SourceRef.GeneratorRef != nil {
+			return nil, fmt.Errorf(errRenderGenerator, i)
+		}
+	}
+	r := &Reconciler{
+		Client:                    c,
+		Log:                       ctrl.Log.WithName("render"),
+		ControllerClass:           controllerClass,
+		ClusterSecretStoreEnabled: true,
+		recorder:                  &record.FakeRecorder{},
+		disableDataCache:          true,
+	}
+	if err := r.applyDefaults(ctx, es); err != nil {
+		return nil, err
+	}
+	dataMap, _, err := r.getProviderSecretData(ctx, es)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetSecretData, err)
+	}
+	secretName := es.Spec.Target.Name
+	if secretName == "" {
+		secretName = es.Name
+	}
+	secret := &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: es.Namespace,
+		},
+		Immutable: &es.Spec.Target.Immutable,
+		Data:      make(map[string][]byte),
+	}
+	if err := r.applyTemplate(ctx, es, secret, dataMap); err != nil {
+		return nil, fmt.Errorf(errApplyTemplate, err)
+	}
+	return secret, nil
+}
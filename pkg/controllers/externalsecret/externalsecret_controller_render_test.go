@@ -0,0 +1,154 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestRender(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+
+	// a JKS magic number followed by bytes that are not valid UTF-8
+	keystore := []byte{0xfe, 0xed, 0xfe, 0xed, 0x00, 0xff, 0xc3, 0x28}
+	t.Cleanup(fakeProvider.Reset)
+	fakeProvider.GetSecretFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		if ref.Key == "credentials/chef-client" && ref.Property == "key" {
+			return []byte("client-key"), nil
+		}
+		if ref.Key == "credentials/chef-client" && ref.Property == "name" {
+			return []byte("chef-client"), nil
+		}
+		if ref.Key == "credentials/keystore" {
+			return keystore, nil
+		}
+		return nil, esv1beta1.NoSecretError{}
+	}
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "chef", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+			AWS: &esv1beta1.AWSProvider{Service: esv1beta1.AWSServiceSecretsManager},
+		}},
+	}
+	kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build()
+
+	newES := func() *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "chef-client", Namespace: "default"},
+			Spec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef: esv1beta1.SecretStoreRef{Name: "chef"},
+				Target:         esv1beta1.ExternalSecretTarget{Name: "client"},
+				Data: []esv1beta1.ExternalSecretData{{
+					SecretKey: "client.pem",
+					RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/chef-client", Property: "key"},
+				}},
+			},
+		}
+	}
+
+	t.Run("data", func(t *testing.T) {
+		secret, err := Render(context.Background(), kube, "", newES())
+		require.NoError(t, err)
+		assert.Equal(t, "client", secret.Name)
+		assert.Equal(t, "default", secret.Namespace)
+		assert.Equal(t, map[string][]byte{"client.pem": []byte("client-key")}, secret.Data)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		es := newES()
+		es.Spec.Target.Template = &esv1beta1.ExternalSecretTemplate{
+			Type:          corev1.SecretTypeOpaque,
+			EngineVersion: esv1beta1.TemplateEngineV2,
+			Data:          map[string]string{"knife.rb": `client_key_contents "{{ index . "client.pem" }}"`},
+		}
+		secret, err := Render(context.Background(), kube, "", es)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"knife.rb": []byte(`client_key_contents "client-key"`)}, secret.Data)
+	})
+
+	t.Run("template bypass", func(t *testing.T) {
+		es := newES()
+		es.Spec.Data = append(es.Spec.Data, esv1beta1.ExternalSecretData{
+			SecretKey:      "keystore.jks",
+			RemoteRef:      esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/keystore"},
+			TemplateBypass: true,
+		})
+		es.Spec.Target.Template = &esv1beta1.ExternalSecretTemplate{
+			Type:          corev1.SecretTypeOpaque,
+			EngineVersion: esv1beta1.TemplateEngineV2,
+			Data: map[string]string{
+				"knife.rb":     `client_key_contents "{{ index . "client.pem" }}"`,
+				"keystore.jks": `{{ index . "keystore.jks" | b64enc }}`,
+			},
+		}
+		secret, err := Render(context.Background(), kube, "", es)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{
+			"knife.rb":     []byte(`client_key_contents "client-key"`),
+			"keystore.jks": keystore,
+		}, secret.Data)
+	})
+
+	t.Run("property keys", func(t *testing.T) {
+		es := newES()
+		es.Spec.Data[0].SecretKey = ""
+		es.Spec.Data[0].RemoteRef.Property = ""
+		es.Spec.Data[0].PropertyKeys = map[string]string{"key": "client.pem", "name": "CLIENT_NAME"}
+		secret, err := Render(context.Background(), kube, "", es)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"client.pem": []byte("client-key"), "CLIENT_NAME": []byte("chef-client")}, secret.Data)
+	})
+
+	t.Run("wrong property", func(t *testing.T) {
+		es := newES()
+		es.Spec.Data[0].RemoteRef.Property = "cert"
+		es.Spec.Target.DeletionPolicy = esv1beta1.DeletionPolicyRetain
+		_, err := Render(context.Background(), kube, "", es)
+		assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+	})
+
+	t.Run("generator", func(t *testing.T) {
+		es := newES()
+		es.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{{
+			SourceRef: &esv1beta1.StoreGeneratorSourceRef{GeneratorRef: &esv1beta1.GeneratorRef{Kind: "Password", Name: "pw"}},
+		}}
+		_, err := Render(context.Background(), kube, "", es)
+		assert.ErrorContains(t, err, "generators are not supported")
+	})
+
+	t.Run("controller class", func(t *testing.T) {
+		classStore := store.DeepCopy()
+		classStore.ResourceVersion = ""
+		classStore.Spec.Controller = "dev"
+		kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(classStore).Build()
+		_, err := Render(context.Background(), kube, "", newES())
+		assert.ErrorContains(t, err, "unmanaged store")
+		_, err = Render(context.Background(), kube, "dev", newES())
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,195 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	// annotationGeneratorInputHash records the hash of the generator spec and
+	// the rotation inputs the cached output was generated from.
+	annotationGeneratorInputHash = "generators.external-secrets.io/input-hash"
+	// annotationGeneratorRotatedAt records when the cached output was generated.
+	annotationGeneratorRotatedAt = "generators.external-secrets.io/rotated-at"
+	// annotationGeneratorRenewAt records when the cached output expires and
+	// has to be renewed, it is only set for generators whose output expires.
+	annotationGeneratorRenewAt = "generators.external-secrets.io/renew-at"
+
+	currentKeyPrefix  = "current."
+	previousKeyPrefix = "previous."
+	previousKeySuffix = "_previous"
+
+	errGetRotationInput = "could not get rotation input %s: %w"
+	errGetRotationCache = "could not get generator output of [%d]: %w"
+	errSetRotationCache = "could not store generator output of [%d]: %w"
+	errRenewAt          = "could not get renewal time of generator output of [%d]: %w"
+)
+
+// generateWithRotation returns the output of the generator that is kept in a
+// Secret owned by the ExternalSecret. The generator is only called when the
+// rotation interval elapsed or one of the inputs changed, the output it
+// replaces is kept as previous value.
+func (r *Reconciler) generateWithRotation(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, generatorRef *esv1beta1.GeneratorRef, genDef *apiextensions.JSON, i int) (map[string][]byte, error) {
+	rotation := generatorRef.Rotation
+	inputHash, err := r.generatorInputHash(ctx, externalSecret.Namespace, genDef, rotation.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generatorCacheName(externalSecret.Name, generatorRef),
+			Namespace: externalSecret.Namespace,
+		},
+	}
+	err = r.Get(ctx, types.NamespacedName{Name: cache.Name, Namespace: cache.Namespace}, cache)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf(errGetRotationCache, i, err)
+	}
+	exists := err == nil
+	now := time.Now()
+	if exists && !rotationDue(cache, rotation, inputHash, now) {
+		return generatorOutput(cache.Data, rotation.KeepPrevious), nil
+	}
+
+	secretMap, err := r.generate(ctx, externalSecret, genDef, i)
+	if err != nil {
+		return nil, err
+	}
+	renewAt, err := generatorRenewAt(genDef, secretMap)
+	if err != nil {
+		return nil, fmt.Errorf(errRenewAt, i, err)
+	}
+	data := make(map[string][]byte, len(secretMap))
+	for k, v := range secretMap {
+		data[currentKeyPrefix+k] = v
+	}
+	if exists {
+		for k, v := range cache.Data {
+			if key, ok := strings.CutPrefix(k, currentKeyPrefix); ok {
+				data[previousKeyPrefix+key] = v
+			}
+		}
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, cache, func() error {
+		if cache.Annotations == nil {
+			cache.Annotations = make(map[string]string)
+		}
+		cache.Annotations[annotationGeneratorInputHash] = inputHash
+		cache.Annotations[annotationGeneratorRotatedAt] = now.UTC().Format(time.RFC3339)
+		if renewAt.IsZero() {
+			delete(cache.Annotations, annotationGeneratorRenewAt)
+		} else {
+			cache.Annotations[annotationGeneratorRenewAt] = renewAt.UTC().Format(time.RFC3339)
+		}
+		cache.Type = v1.SecretTypeOpaque
+		cache.Data = data
+		return controllerutil.SetControllerReference(externalSecret, cache, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errSetRotationCache, i, err)
+	}
+	return generatorOutput(data, rotation.KeepPrevious), nil
+}
+
+// generatorInputHash hashes the spec of the generator and the data of the
+// input Secrets, a change of any of them triggers a rotation.
+func (r *Reconciler) generatorInputHash(ctx context.Context, namespace string, genDef *apiextensions.JSON, inputs []v1.LocalObjectReference) (string, error) {
+	var gen struct {
+		Spec json.RawMessage `json:"spec"`
+	}
+	if err := json.Unmarshal(genDef.Raw, &gen); err != nil {
+		return "", err
+	}
+	values := []string{string(gen.Spec)}
+	for _, input := range inputs {
+		var secret v1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Name: input.Name, Namespace: namespace}, &secret); err != nil {
+			return "", fmt.Errorf(errGetRotationInput, input.Name, err)
+		}
+		values = append(values, utils.ObjectHash(secret.Data))
+	}
+	return utils.ObjectHash(values), nil
+}
+
+// generatorRenewAt returns when the output of the generator has to be
+// renewed, or the zero time if the output does not expire.
+func generatorRenewAt(genDef *apiextensions.JSON, secretMap map[string][]byte) (time.Time, error) {
+	gen, err := genv1alpha1.GetGenerator(genDef)
+	if err != nil {
+		return time.Time{}, err
+	}
+	renewing, ok := gen.(genv1alpha1.RenewingGenerator)
+	if !ok {
+		return time.Time{}, nil
+	}
+	return renewing.RenewAt(genDef, secretMap)
+}
+
+// rotationDue reports whether the cached output has to be regenerated.
+func rotationDue(cache *v1.Secret, rotation *esv1beta1.GeneratorRotation, inputHash string, now time.Time) bool {
+	if cache.Annotations[annotationGeneratorInputHash] != inputHash {
+		return true
+	}
+	if value, ok := cache.Annotations[annotationGeneratorRenewAt]; ok {
+		renewAt, err := time.Parse(time.RFC3339, value)
+		if err != nil || !now.Before(renewAt) {
+			return true
+		}
+	}
+	if rotation.Interval == nil || rotation.Interval.Duration <= 0 {
+		return false
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, cache.Annotations[annotationGeneratorRotatedAt])
+	if err != nil {
+		return true
+	}
+	return !now.Before(rotatedAt.Add(rotation.Interval.Duration))
+}
+
+// generatorOutput maps the cached data to the keys of the generator,
+// previous values are exposed as <key>_previous if requested.
+func generatorOutput(data map[string][]byte, keepPrevious bool) map[string][]byte {
+	out := make(map[string][]byte)
+	for k, v := range data {
+		if key, ok := strings.CutPrefix(k, currentKeyPrefix); ok {
+			out[key] = v
+		} else if key, ok := strings.CutPrefix(k, previousKeyPrefix); ok && keepPrevious {
+			out[key+previousKeySuffix] = v
+		}
+	}
+	return out
+}
+
+func generatorCacheName(esName string, generatorRef *esv1beta1.GeneratorRef) string {
+	ref := fmt.Sprintf("%s/%s/%s", generatorRef.APIVersion, generatorRef.Kind, generatorRef.Name)
+	return fmt.Sprintf("%s-generated-%s", esName, utils.ObjectHash(ref)[:8])
+}
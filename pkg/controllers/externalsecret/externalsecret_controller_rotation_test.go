@@ -0,0 +1,154 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestRotationDue(t *testing.T) {
+	rotatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				annotationGeneratorInputHash: "hash",
+				annotationGeneratorRotatedAt: rotatedAt.Format(time.RFC3339),
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		rotation  esv1beta1.GeneratorRotation
+		inputHash string
+		now       time.Time
+		want      bool
+	}{
+		{
+			name:      "changed input",
+			inputHash: "other",
+			now:       rotatedAt,
+			want:      true,
+		},
+		{
+			name:      "no interval",
+			inputHash: "hash",
+			now:       rotatedAt.Add(24 * time.Hour),
+			want:      false,
+		},
+		{
+			name:      "interval not elapsed",
+			rotation:  esv1beta1.GeneratorRotation{Interval: &metav1.Duration{Duration: time.Hour}},
+			inputHash: "hash",
+			now:       rotatedAt.Add(59 * time.Minute),
+			want:      false,
+		},
+		{
+			name:      "interval elapsed",
+			rotation:  esv1beta1.GeneratorRotation{Interval: &metav1.Duration{Duration: time.Hour}},
+			inputHash: "hash",
+			now:       rotatedAt.Add(time.Hour),
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rotationDue(cache, &tt.rotation, tt.inputHash, tt.now))
+		})
+	}
+}
+
+func TestRotationDueRenewAt(t *testing.T) {
+	rotatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				annotationGeneratorInputHash: "hash",
+				annotationGeneratorRotatedAt: rotatedAt.Format(time.RFC3339),
+				annotationGeneratorRenewAt:   rotatedAt.Add(16 * time.Hour).Format(time.RFC3339),
+			},
+		},
+	}
+	rotation := &esv1beta1.GeneratorRotation{}
+	assert.False(t, rotationDue(cache, rotation, "hash", rotatedAt.Add(15*time.Hour)))
+	assert.True(t, rotationDue(cache, rotation, "hash", rotatedAt.Add(16*time.Hour)))
+
+	// the interval still applies before the output has to be renewed.
+	rotation.Interval = &metav1.Duration{Duration: time.Hour}
+	assert.True(t, rotationDue(cache, rotation, "hash", rotatedAt.Add(time.Hour)))
+
+	cache.Annotations[annotationGeneratorRenewAt] = "invalid"
+	assert.True(t, rotationDue(cache, &esv1beta1.GeneratorRotation{}, "hash", rotatedAt))
+}
+
+func TestGenerateWithRotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+	input := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "input", Namespace: "default"},
+		Data:       map[string][]byte{"seed": []byte("1")},
+	}
+	kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(input).Build()
+	r := &Reconciler{Client: kube, Scheme: scheme}
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default", UID: "uid"},
+	}
+	ref := &esv1beta1.GeneratorRef{
+		Kind: "Password",
+		Name: "password",
+		Rotation: &esv1beta1.GeneratorRotation{
+			Inputs:       []corev1.LocalObjectReference{{Name: "input"}},
+			KeepPrevious: true,
+		},
+	}
+	genDef := &apiextensions.JSON{Raw: []byte(`{"kind":"Password","spec":{"length":32}}`)}
+	ctx := context.Background()
+
+	first, err := r.generateWithRotation(ctx, es, ref, genDef, 0)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	require.Len(t, first["password"], 32)
+
+	again, err := r.generateWithRotation(ctx, es, ref, genDef, 0)
+	require.NoError(t, err)
+	assert.Equal(t, first, again, "output must be reused while no rotation is due")
+
+	input.Data["seed"] = []byte("2")
+	require.NoError(t, kube.Update(ctx, input))
+	rotated, err := r.generateWithRotation(ctx, es, ref, genDef, 0)
+	require.NoError(t, err)
+	assert.NotEqual(t, first["password"], rotated["password"])
+	assert.Equal(t, first["password"], rotated["password"+previousKeySuffix])
+
+	genDef = &apiextensions.JSON{Raw: []byte(`{"kind":"Password","spec":{"length":16}}`)}
+	ref.Rotation.KeepPrevious = false
+	changed, err := r.generateWithRotation(ctx, es, ref, genDef, 0)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.Len(t, changed["password"], 16)
+}
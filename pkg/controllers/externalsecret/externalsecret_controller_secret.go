@@ -19,6 +19,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -27,9 +29,11 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
 	// Loading registered providers.
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 	// Loading registered generators.
@@ -39,26 +43,45 @@ import (
 )
 
 // getProviderSecretData returns the provider's secret data with the provided ExternalSecret.
-func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *esv1beta1.ExternalSecret) (map[string][]byte, error) {
+// With syncPolicy Partial the data entries that could not be read are returned
+// as unresolved keys instead of failing, unless none of the entries were read.
+func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *esv1beta1.ExternalSecret) (map[string][]byte, []esv1beta1.UnresolvedKey, error) {
 	// We MUST NOT create multiple instances of a provider client (mostly due to limitations with GCP)
 	// Clientmanager keeps track of the client instances
 	// that are created during the fetching process and closes clients
 	// if needed.
-	mgr := secretstore.NewManager(r.Client, r.ControllerClass, r.EnableFloodGate)
+	mgr := secretstore.NewManager(r.Client, r.ControllerClass, r.EnableFloodGate).
+		WithRetrySettings(externalSecret.Spec.RetrySettings)
+	if r.Batcher != nil {
+		mgr.WithBatcher(r.Batcher)
+	}
+	if r.DiskCache != nil {
+		mgr.WithDiskCache(r.DiskCache)
+	}
 	defer mgr.Close(ctx)
+	details := newFetchDetails(externalSecret)
+	defer details.store(externalSecret)
+
+	// the entries that are not due are served from the cache.
+	cache := r.entries(externalSecret)
+	now := time.Now()
+	interval := refreshInterval(externalSecret, r.RequeueInterval)
 
 	providerData := make(map[string][]byte)
 	for i, remoteRef := range externalSecret.Spec.DataFrom {
-		var secretMap map[string][]byte
-		var err error
-
-		if remoteRef.Find != nil {
-			secretMap, err = r.handleFindAllSecrets(ctx, externalSecret, remoteRef, mgr, i)
-		} else if remoteRef.Extract != nil {
-			secretMap, err = r.handleExtractSecrets(ctx, externalSecret, remoteRef, mgr, i)
-		} else if remoteRef.SourceRef != nil && remoteRef.SourceRef.GeneratorRef != nil {
-			secretMap, err = r.handleGenerateSecrets(ctx, externalSecret.Namespace, remoteRef, i)
-		}
+		start := time.Now()
+		hash, hashErr := dataFromHash(externalSecret, remoteRef)
+		secretMap, err := cache.fetch(hash, hashErr, interval, now, func() (map[string][]byte, error) {
+			if remoteRef.Find != nil {
+				return r.handleFindAllSecrets(ctx, externalSecret, remoteRef, mgr, i)
+			} else if remoteRef.Extract != nil {
+				return r.handleExtractSecrets(ctx, externalSecret, remoteRef, mgr, i)
+			} else if remoteRef.SourceRef != nil && remoteRef.SourceRef.GeneratorRef != nil {
+				return r.handleGenerateSecrets(ctx, externalSecret, remoteRef, mgr, i)
+			}
+			return nil, nil
+		})
+		details.addDataFrom(i, remoteRef, time.Since(start), secretMap, err)
 		if errors.Is(err, esv1beta1.NoSecretErr) && externalSecret.Spec.Target.DeletionPolicy != esv1beta1.DeletionPolicyRetain {
 			r.recorder.Event(
 				externalSecret,
@@ -69,23 +92,45 @@ func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *
 			continue
 		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		providerData = utils.MergeByteMap(providerData, secretMap)
 	}
 
+	var unresolved []esv1beta1.UnresolvedKey
+	var firstErr error
 	for i, secretRef := range externalSecret.Spec.Data {
-		err := r.handleSecretData(ctx, i, *externalSecret, secretRef, providerData, mgr)
+		start := time.Now()
+		hash, hashErr := entryHash(externalSecret, secretRef)
+		values, err := cache.fetch(hash, hashErr, entryInterval(externalSecret, secretRef, r.RequeueInterval), now, func() (map[string][]byte, error) {
+			values := make(map[string][]byte)
+			err := r.handleSecretData(ctx, i, *externalSecret, secretRef, values, mgr)
+			return values, err
+		})
+		details.addData(i, secretRef, time.Since(start), values, err)
+		utils.MergeByteMap(providerData, values)
 		if errors.Is(err, esv1beta1.NoSecretErr) && externalSecret.Spec.Target.DeletionPolicy != esv1beta1.DeletionPolicyRetain {
 			r.recorder.Event(externalSecret, v1.EventTypeNormal, esv1beta1.ReasonDeleted, fmt.Sprintf("secret does not exist at provider using .data[%d] key=%s", i, secretRef.RemoteRef.Key))
 			continue
 		}
-		if err != nil {
-			return nil, fmt.Errorf("error retrieving secret at .data[%d], key: %s, err: %w", i, secretRef.RemoteRef.Key, err)
+		if err == nil {
+			continue
 		}
+		if !isPartialSync(externalSecret) {
+			return nil, nil, fmt.Errorf(errSecretData, i, secretRef.RemoteRef.Key, err)
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf(errSecretData, i, secretRef.RemoteRef.Key, err)
+		}
+		unresolved = append(unresolved, newUnresolvedKey(secretRef, err))
+	}
+	cache.prune()
+	// a partial sync needs at least one entry, otherwise the secret would be emptied.
+	if len(unresolved) > 0 && len(unresolved) == len(externalSecret.Spec.Data) && len(externalSecret.Spec.DataFrom) == 0 {
+		return nil, nil, firstErr
 	}
 
-	return providerData, nil
+	return providerData, unresolved, nil
 }
 
 func (r *Reconciler) handleSecretData(ctx context.Context, i int, externalSecret esv1beta1.ExternalSecret, secretRef esv1beta1.ExternalSecretData, providerData map[string][]byte, cmgr *secretstore.Manager) error {
@@ -93,15 +138,42 @@ func (r *Reconciler) handleSecretData(ctx context.Context, i int, externalSecret
 	if err != nil {
 		return err
 	}
-	secretData, err := client.GetSecret(ctx, secretRef.RemoteRef)
+	storeRef := storeRefFor(&externalSecret, toStoreGenSourceRef(secretRef.SourceRef))
+	if len(secretRef.PropertyKeys) == 0 {
+		return r.getSecretData(ctx, i, &externalSecret, client, storeRef, secretRef.RemoteRef, secretRef.SecretKey, providerData)
+	}
+	properties := make([]string, 0, len(secretRef.PropertyKeys))
+	for property := range secretRef.PropertyKeys {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+	for _, property := range properties {
+		ref := secretRef.RemoteRef
+		ref.Property = property
+		if err := r.getSecretData(ctx, i, &externalSecret, client, storeRef, ref, secretRef.PropertyKeys[property], providerData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSecretData reads the remote secret and stores the decoded value in secretKey.
+func (r *Reconciler) getSecretData(ctx context.Context, i int, externalSecret *esv1beta1.ExternalSecret, client esv1beta1.SecretsClient, storeRef esv1beta1.SecretStoreRef, ref esv1beta1.ExternalSecretDataRemoteRef, secretKey string, providerData map[string][]byte) error {
+	start := time.Now()
+	secretData, err := client.GetSecret(ctx, ref)
+	r.Auditor.Read(externalSecret, storeRef, audit.OperationGetSecret, ref.Key, ref.Property, nil, time.Since(start), err)
 	if err != nil {
 		return err
 	}
-	secretData, err = utils.Decode(secretRef.RemoteRef.DecodingStrategy, secretData)
+	secretData, err = utils.Decode(ref.DecodingStrategy, secretData)
 	if err != nil {
 		return fmt.Errorf(errDecode, "spec.data", i, err)
 	}
-	providerData[secretRef.SecretKey] = secretData
+	secretKey, err = utils.RenderSecretKey(secretKey, ref)
+	if err != nil {
+		return err
+	}
+	providerData[secretKey] = secretData
 	return nil
 }
 
@@ -114,18 +186,32 @@ func toStoreGenSourceRef(ref *esv1beta1.StoreSourceRef) *esv1beta1.StoreGenerato
 	}
 }
 
-func (r *Reconciler) handleGenerateSecrets(ctx context.Context, namespace string, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, i int) (map[string][]byte, error) {
-	genDef, err := r.getGeneratorDefinition(ctx, namespace, remoteRef.SourceRef.GeneratorRef)
+// storeRefFor returns the reference to the store a secret is read from.
+func storeRefFor(externalSecret *esv1beta1.ExternalSecret, sourceRef *esv1beta1.StoreGeneratorSourceRef) esv1beta1.SecretStoreRef {
+	if sourceRef != nil && sourceRef.SecretStoreRef != nil {
+		return *sourceRef.SecretStoreRef
+	}
+	return externalSecret.Spec.SecretStoreRef
+}
+
+func (r *Reconciler) handleGenerateSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, mgr *secretstore.Manager, i int) (map[string][]byte, error) {
+	generatorRef := remoteRef.SourceRef.GeneratorRef
+	genDef, err := r.getGeneratorDefinition(ctx, externalSecret.Namespace, generatorRef)
 	if err != nil {
 		return nil, err
 	}
-	gen, err := genv1alpha1.GetGenerator(genDef)
+	genDef, err = r.applyGeneratorParameters(ctx, externalSecret, generatorRef, genDef, mgr, i)
 	if err != nil {
 		return nil, err
 	}
-	secretMap, err := gen.Generate(ctx, genDef, r.Client, namespace)
+	var secretMap map[string][]byte
+	if generatorRef.Rotation != nil {
+		secretMap, err = r.generateWithRotation(ctx, externalSecret, generatorRef, genDef, i)
+	} else {
+		secretMap, err = r.generate(ctx, externalSecret, genDef, i)
+	}
 	if err != nil {
-		return nil, fmt.Errorf(errGenerate, i, err)
+		return nil, err
 	}
 	secretMap, err = utils.RewriteMap(remoteRef.Rewrite, secretMap)
 	if err != nil {
@@ -137,6 +223,57 @@ func (r *Reconciler) handleGenerateSecrets(ctx context.Context, namespace string
 	return secretMap, err
 }
 
+// generate calls the generator and records its state, if any.
+func (r *Reconciler) generate(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, genDef *apiextensions.JSON, i int) (map[string][]byte, error) {
+	gen, err := genv1alpha1.GetGenerator(genDef)
+	if err != nil {
+		return nil, err
+	}
+	stateful, ok := gen.(genv1alpha1.StatefulGenerator)
+	if !ok {
+		secretMap, err := gen.Generate(ctx, genDef, r.Client, externalSecret.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerate, i, err)
+		}
+		return secretMap, nil
+	}
+	secretMap, state, err := stateful.GenerateWithState(ctx, genDef, r.Client, externalSecret.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errGenerate, i, err)
+	}
+	if err := r.storeGeneratorState(ctx, externalSecret, genDef, state); err != nil {
+		return nil, fmt.Errorf(errGeneratorState, i, err)
+	}
+	return secretMap, nil
+}
+
+// storeGeneratorState records the state returned by a generator in a
+// GeneratorState owned by the ExternalSecret. The artifacts described by the
+// state are cleaned up by the GeneratorState controller once the
+// ExternalSecret is deleted. Every distinct state gets its own GeneratorState.
+func (r *Reconciler) storeGeneratorState(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, genDef, state *apiextensions.JSON) error {
+	if state == nil {
+		return nil
+	}
+	gs := &genv1alpha1.GeneratorState{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", externalSecret.Name, utils.ObjectHash(string(state.Raw))[:8]),
+			Namespace: externalSecret.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, gs, func() error {
+		if gs.Labels == nil {
+			gs.Labels = make(map[string]string)
+		}
+		gs.Labels[genv1alpha1.LabelGeneratorStateOwner] = externalSecret.Name
+		controllerutil.AddFinalizer(gs, genv1alpha1.GeneratorStateFinalizer)
+		gs.Spec.Resource = genDef
+		gs.Spec.State = state
+		return controllerutil.SetControllerReference(externalSecret, gs, r.Scheme)
+	})
+	return err
+}
+
 // getGeneratorDefinition returns the generator JSON for a given sourceRef
 // when it uses a generatorRef it fetches the resource and returns the JSON.
 func (r *Reconciler) getGeneratorDefinition(ctx context.Context, namespace string, generatorRef *esv1beta1.GeneratorRef) (*apiextensions.JSON, error) {
@@ -185,7 +322,9 @@ func (r *Reconciler) handleExtractSecrets(ctx context.Context, externalSecret *e
 	if err != nil {
 		return nil, err
 	}
+	start := time.Now()
 	secretMap, err := client.GetSecretMap(ctx, *remoteRef.Extract)
+	r.Auditor.Read(externalSecret, storeRefFor(externalSecret, remoteRef.SourceRef), audit.OperationGetSecretMap, remoteRef.Extract.Key, remoteRef.Extract.Property, nil, time.Since(start), err)
 	if err != nil {
 		return nil, err
 	}
@@ -214,7 +353,9 @@ func (r *Reconciler) handleFindAllSecrets(ctx context.Context, externalSecret *e
 	if err != nil {
 		return nil, err
 	}
+	start := time.Now()
 	secretMap, err := client.GetAllSecrets(ctx, *remoteRef.Find)
+	r.Auditor.Read(externalSecret, storeRefFor(externalSecret, remoteRef.SourceRef), audit.OperationGetAllSecrets, audit.DescribeFind(*remoteRef.Find), "", audit.Keys(secretMap), time.Since(start), err)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,70 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// StartupLimiter limits the rate of the first syncs after the controller
+// starts, so the ExternalSecrets that are due are not read from the
+// providers at once. Later syncs of an ExternalSecret are not limited.
+// A nil StartupLimiter does not limit the syncs.
+type StartupLimiter struct {
+	limiter flowcontrol.RateLimiter
+	// reconciled holds the UIDs of the ExternalSecrets that were reconciled
+	// since the start by their name. Deleted ExternalSecrets are forgotten.
+	reconciled sync.Map
+}
+
+// NewStartupLimiter returns a limiter that allows burst syncs at once and
+// qps syncs per second after that. It returns nil if qps is not positive.
+func NewStartupLimiter(qps float32, burst int) *StartupLimiter {
+	if qps <= 0 {
+		return nil
+	}
+	return &StartupLimiter{limiter: flowcontrol.NewTokenBucketRateLimiter(qps, max(burst, 1))}
+}
+
+// firstReconcile returns true on the first call for the ExternalSecret. An
+// ExternalSecret that is recreated with the same name is reconciled for the
+// first time again.
+func (l *StartupLimiter) firstReconcile(name types.NamespacedName, uid types.UID) bool {
+	if l == nil {
+		return false
+	}
+	prev, seen := l.reconciled.Swap(name, uid)
+	return !seen || prev.(types.UID) != uid
+}
+
+// forget drops a deleted ExternalSecret.
+func (l *StartupLimiter) forget(name types.NamespacedName) {
+	if l == nil {
+		return
+	}
+	l.reconciled.Delete(name)
+}
+
+// wait blocks until the sync may read from the providers.
+func (l *StartupLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	return l.limiter.Wait(ctx)
+}
@@ -0,0 +1,53 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestStartupLimiter(t *testing.T) {
+	var disabled *StartupLimiter
+	assert.Nil(t, NewStartupLimiter(0, 10))
+	a := types.NamespacedName{Namespace: "default", Name: "a"}
+	b := types.NamespacedName{Namespace: "default", Name: "b"}
+	assert.False(t, disabled.firstReconcile(a, "uid"))
+	assert.NoError(t, disabled.wait(context.Background()))
+	disabled.forget(a)
+
+	l := NewStartupLimiter(0.001, 2)
+	assert.True(t, l.firstReconcile(a, "a"))
+	assert.False(t, l.firstReconcile(a, "a"))
+	assert.True(t, l.firstReconcile(b, "b"))
+
+	// a recreated ExternalSecret is new, a deleted one is forgotten.
+	assert.True(t, l.firstReconcile(a, "a2"))
+	l.forget(b)
+	_, ok := l.reconciled.Load(b)
+	assert.False(t, ok)
+	assert.True(t, l.firstReconcile(b, "b"))
+
+	// the burst passes at once, further syncs wait for the rate.
+	assert.NoError(t, l.wait(context.Background()))
+	assert.NoError(t, l.wait(context.Background()))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, l.wait(ctx))
+}
@@ -17,8 +17,12 @@ package externalsecret
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/controllers/templating"
@@ -47,15 +51,26 @@ func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSe
 			secret.Data[k] = v
 		}
 	}
-	execute, err := template.EngineForVersion(es.Spec.Target.Template.EngineVersion)
+	metadata, err := r.templateMetadata(ctx, es)
+	if err != nil {
+		return err
+	}
+	execute, err := template.EngineForTemplate(es.Spec.Target.Template, metadata)
 	if err != nil {
 		return err
 	}
 
+	bypass := templateBypassData(es, dataMap)
+	templateData := make(map[string][]byte, len(dataMap))
+	for k, v := range dataMap {
+		if _, ok := bypass[k]; !ok {
+			templateData[k] = v
+		}
+	}
 	p := templating.Parser{
 		Client:       r.Client,
 		TargetSecret: secret,
-		DataMap:      dataMap,
+		DataMap:      templateData,
 		Exec:         execute,
 	}
 	// apply templates defined in template.templateFrom
@@ -84,9 +99,89 @@ func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSe
 	if len(es.Spec.Target.Template.Data) == 0 && len(es.Spec.Target.Template.TemplateFrom) == 0 {
 		secret.Data = dataMap
 	}
+	for k, v := range bypass {
+		secret.Data[k] = v
+	}
 	return nil
 }
 
+// templateBypassData returns the values of data entries with templateBypass.
+// They are not passed to the template, so binary values are written as is.
+func templateBypassData(es *esv1beta1.ExternalSecret, dataMap map[string][]byte) map[string][]byte {
+	bypass := make(map[string][]byte)
+	for _, d := range es.Spec.Data {
+		if !d.TemplateBypass {
+			continue
+		}
+		keys := map[string]esv1beta1.ExternalSecretDataRemoteRef{d.SecretKey: d.RemoteRef}
+		if len(d.PropertyKeys) > 0 {
+			keys = make(map[string]esv1beta1.ExternalSecretDataRemoteRef, len(d.PropertyKeys))
+			for property, key := range d.PropertyKeys {
+				ref := d.RemoteRef
+				ref.Property = property
+				keys[key] = ref
+			}
+		}
+		for key, ref := range keys {
+			key, err := utils.RenderSecretKey(key, ref)
+			if err != nil {
+				continue
+			}
+			if v, ok := dataMap[key]; ok {
+				bypass[key] = v
+			}
+		}
+	}
+	return bypass
+}
+
+// templateMetadata returns information about where the data was fetched from,
+// so templates can embed it e.g. as annotations.
+func (r *Reconciler) templateMetadata(ctx context.Context, es *esv1beta1.ExternalSecret) (map[string]string, error) {
+	remoteKeys := make([]string, 0, len(es.Spec.Data)+len(es.Spec.DataFrom))
+	for _, d := range es.Spec.Data {
+		remoteKeys = append(remoteKeys, d.RemoteRef.Key)
+	}
+	for _, d := range es.Spec.DataFrom {
+		if d.Extract != nil {
+			remoteKeys = append(remoteKeys, d.Extract.Key)
+		}
+	}
+	metadata := map[string]string{
+		template.MetadataStoreName:  es.Spec.SecretStoreRef.Name,
+		template.MetadataStoreKind:  es.Spec.SecretStoreRef.Kind,
+		template.MetadataRemoteKeys: strings.Join(remoteKeys, ","),
+		template.MetadataFetchedAt:  time.Now().UTC().Format(time.RFC3339),
+	}
+	if es.Spec.SecretStoreRef.Name == "" {
+		return metadata, nil
+	}
+	if metadata[template.MetadataStoreKind] == "" {
+		metadata[template.MetadataStoreKind] = esv1beta1.SecretStoreKind
+	}
+
+	var store esv1beta1.GenericStore = &esv1beta1.SecretStore{}
+	ref := types.NamespacedName{Name: es.Spec.SecretStoreRef.Name, Namespace: es.Namespace}
+	if es.Spec.SecretStoreRef.Kind == esv1beta1.ClusterSecretStoreKind {
+		store = &esv1beta1.ClusterSecretStore{}
+		ref.Namespace = ""
+	}
+	err := r.Get(ctx, ref, store)
+	if apierrors.IsNotFound(err) {
+		// data may be fetched from generators or stores referenced by sourceRef only
+		return metadata, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errGetTemplateStore, ref.Name, err)
+	}
+	provider, err := esv1beta1.GetProviderName(store)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTemplateStore, ref.Name, err)
+	}
+	metadata[template.MetadataProvider] = provider
+	return metadata, nil
+}
+
 // setMetadata sets Labels and Annotations to the given secret.
 func setMetadata(secret *v1.Secret, es *esv1beta1.ExternalSecret) error {
 	if secret.Labels == nil {
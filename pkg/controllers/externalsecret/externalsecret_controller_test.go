@@ -233,7 +233,7 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 				if cond == nil || cond.Status != v1.ConditionTrue {
 					return false
 				}
-				return true
+				return es.Status.ObservedGeneration == es.Generation
 			},
 			checkExternalSecret: func(es *esv1beta1.ExternalSecret) {
 				// noop by default
@@ -961,6 +961,29 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	syncTemplateWithMetadata := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.Template = &esv1beta1.ExternalSecretTemplate{
+			EngineVersion: esv1beta1.TemplateEngineV2,
+			Metadata: esv1beta1.ExternalSecretTemplateMetadata{
+				Annotations: map[string]string{
+					"source/store":    `{{ metadata "storeName" }}`,
+					"source/provider": `{{ metadata "provider" }}`,
+					"source/keys":     `{{ metadata "remoteKeys" }}`,
+				},
+			},
+			Data: map[string]string{
+				targetProp: `{{ .targetProperty }}`,
+			},
+		}
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal(secretVal))
+			Expect(secret.ObjectMeta.Annotations["source/store"]).To(Equal(ExternalSecretStore))
+			Expect(secret.ObjectMeta.Annotations["source/provider"]).To(Equal("aws"))
+			Expect(secret.ObjectMeta.Annotations["source/keys"]).To(Equal(remoteKey))
+		}
+	}
+
 	syncTemplateFromLiteral := func(tc *testCase) {
 		tplDataVal := "{{ .targetKey }}-literal: {{ .targetValue }}"
 		tplAnnotationsVal := "{{ .targetKey }}-annotations: {{ .targetValue }}"
@@ -2237,6 +2260,7 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		Entry("should sync template with correct value precedence", syncWithTemplatePrecedence),
 		Entry("should sync template from keys and values", syncTemplateFromKeysAndValues),
 		Entry("should sync template from literal", syncTemplateFromLiteral),
+		Entry("should sync template with metadata", syncTemplateWithMetadata),
 		Entry("should update template if ExternalSecret is updated", templateShouldRewrite),
 		Entry("should keep data with templates if MergePolicy=Merge", templateShouldMerge),
 		Entry("should refresh secret from template", refreshWithTemplate),
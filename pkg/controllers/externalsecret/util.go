@@ -15,6 +15,8 @@ limitations under the License.
 package externalsecret
 
 import (
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -79,3 +81,52 @@ func filterOutCondition(conditions []esv1beta1.ExternalSecretStatusCondition, co
 	}
 	return newConditions
 }
+
+// storeRefs returns the stores the ExternalSecret reads from, including the
+// stores generator parameters are read from.
+func storeRefs(es *esv1beta1.ExternalSecret) []esv1beta1.SecretStoreRef {
+	var refs []esv1beta1.SecretStoreRef
+	if es.Spec.SecretStoreRef.Name != "" {
+		refs = append(refs, es.Spec.SecretStoreRef)
+	}
+	for _, data := range es.Spec.Data {
+		if data.SourceRef != nil && data.SourceRef.SecretStoreRef.Name != "" {
+			refs = append(refs, data.SourceRef.SecretStoreRef)
+		}
+	}
+	for _, dataFrom := range es.Spec.DataFrom {
+		if dataFrom.SourceRef == nil {
+			continue
+		}
+		if dataFrom.SourceRef.SecretStoreRef != nil {
+			refs = append(refs, *dataFrom.SourceRef.SecretStoreRef)
+		}
+		if dataFrom.SourceRef.GeneratorRef != nil {
+			for _, param := range dataFrom.SourceRef.GeneratorRef.Parameters {
+				if param.StoreRef != nil && param.StoreRef.Name != "" {
+					refs = append(refs, *param.StoreRef)
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// storeList returns the stores the ExternalSecret reads from as kind/name,
+// separated by commas.
+func storeList(es *esv1beta1.ExternalSecret) string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, ref := range storeRefs(es) {
+		kind := ref.Kind
+		if kind == "" {
+			kind = esv1beta1.SecretStoreKind
+		}
+		name := kind + "/" + ref.Name
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ",")
+}
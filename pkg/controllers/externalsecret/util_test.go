@@ -223,3 +223,31 @@ func TestSetExternalSecretCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreList(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: esv1beta1.SecretStoreRef{Name: "chef"},
+			Data: []esv1beta1.ExternalSecretData{
+				{SourceRef: &esv1beta1.StoreSourceRef{SecretStoreRef: esv1beta1.SecretStoreRef{Name: "chef", Kind: esv1beta1.SecretStoreKind}}},
+				{SourceRef: &esv1beta1.StoreSourceRef{SecretStoreRef: esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind}}},
+			},
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{SourceRef: &esv1beta1.StoreGeneratorSourceRef{GeneratorRef: &esv1beta1.GeneratorRef{Name: "password"}}},
+				{SourceRef: &esv1beta1.StoreGeneratorSourceRef{GeneratorRef: &esv1beta1.GeneratorRef{
+					Name: "password",
+					Parameters: []esv1beta1.GeneratorParameter{
+						{Path: "length"},
+						{Path: "symbols", StoreRef: &esv1beta1.SecretStoreRef{Name: "params", Kind: esv1beta1.ClusterSecretStoreKind}},
+					},
+				}}},
+			},
+		},
+	}
+	if got, want := storeList(es), "SecretStore/chef,ClusterSecretStore/vault,ClusterSecretStore/params"; got != want {
+		t.Errorf("storeList() = %q, want %q", got, want)
+	}
+	if got := storeList(&esv1beta1.ExternalSecret{}); got != "" {
+		t.Errorf("storeList() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generatorstate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	// Loading registered generators.
+	_ "github.com/external-secrets/external-secrets/pkg/generator/register"
+)
+
+const (
+	errGetState         = "unable to get GeneratorState"
+	errGetGenerator     = "unable to get generator: %w"
+	errCleanup          = "unable to clean up generator state: %w"
+	errUpdateFinalizers = "could not update finalizers: %w"
+
+	reasonCleanupFailed = "CleanupFailed"
+	reasonCleanedUp     = "CleanedUp"
+)
+
+// Reconciler cleans up the artifacts recorded in a GeneratorState
+// once the GeneratorState is deleted.
+type Reconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor("generatorstate")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&genv1alpha1.GeneratorState{}).
+		Complete(r)
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("generatorstate", req.NamespacedName)
+
+	var gs genv1alpha1.GeneratorState
+	if err := r.Get(ctx, req.NamespacedName, &gs); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, errGetState)
+		return ctrl.Result{}, fmt.Errorf("get resource: %w", err)
+	}
+
+	if gs.ObjectMeta.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(&gs, genv1alpha1.GeneratorStateFinalizer) {
+			controllerutil.AddFinalizer(&gs, genv1alpha1.GeneratorStateFinalizer)
+			if err := r.Client.Update(ctx, &gs, &client.UpdateOptions{}); err != nil {
+				return ctrl.Result{}, fmt.Errorf(errUpdateFinalizers, err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(&gs, genv1alpha1.GeneratorStateFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	if err := r.cleanup(ctx, &gs); err != nil {
+		r.recorder.Event(&gs, v1.EventTypeWarning, reasonCleanupFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+	r.recorder.Event(&gs, v1.EventTypeNormal, reasonCleanedUp, "generator state cleaned up")
+	log.V(1).Info("generator state cleaned up")
+
+	controllerutil.RemoveFinalizer(&gs, genv1alpha1.GeneratorStateFinalizer)
+	if err := r.Client.Update(ctx, &gs, &client.UpdateOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf(errUpdateFinalizers, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// cleanup removes the artifacts recorded in the state. States of generators
+// that do not keep state (anymore) are released without further action.
+func (r *Reconciler) cleanup(ctx context.Context, gs *genv1alpha1.GeneratorState) error {
+	if gs.Spec.Resource == nil || gs.Spec.State == nil {
+		return nil
+	}
+	gen, err := genv1alpha1.GetGenerator(gs.Spec.Resource)
+	if err != nil {
+		return fmt.Errorf(errGetGenerator, err)
+	}
+	stateful, ok := gen.(genv1alpha1.StatefulGenerator)
+	if !ok {
+		return nil
+	}
+	if err := stateful.Cleanup(ctx, gs.Spec.Resource, gs.Spec.State, r.Client, gs.Namespace); err != nil {
+		return fmt.Errorf(errCleanup, err)
+	}
+	return nil
+}
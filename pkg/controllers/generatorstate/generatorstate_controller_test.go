@@ -0,0 +1,142 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generatorstate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+const testKind = "StatefulTestGenerator"
+
+type statefulGenerator struct {
+	cleanupErr error
+	cleaned    []string
+}
+
+func (g *statefulGenerator) Generate(_ context.Context, _ *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, error) {
+	return nil, nil
+}
+
+func (g *statefulGenerator) GenerateWithState(_ context.Context, _ *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, *apiextensions.JSON, error) {
+	return nil, nil, nil
+}
+
+func (g *statefulGenerator) Cleanup(_ context.Context, _, state *apiextensions.JSON, _ client.Client, _ string) error {
+	if g.cleanupErr != nil {
+		return g.cleanupErr
+	}
+	g.cleaned = append(g.cleaned, string(state.Raw))
+	return nil
+}
+
+func newState(deleting bool, finalizers ...string) *genv1alpha1.GeneratorState {
+	gs := &genv1alpha1.GeneratorState{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "es-state",
+			Namespace:  "default",
+			Finalizers: finalizers,
+		},
+		Spec: genv1alpha1.GeneratorStateSpec{
+			Resource: &apiextensions.JSON{Raw: []byte(`{"kind":"` + testKind + `"}`)},
+			State:    &apiextensions.JSON{Raw: []byte(`{"clientName":"bootstrap"}`)},
+		},
+	}
+	if deleting {
+		now := metav1.Now()
+		gs.DeletionTimestamp = &now
+	}
+	return gs
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, genv1alpha1.AddToScheme(scheme))
+
+	tests := []struct {
+		name        string
+		state       *genv1alpha1.GeneratorState
+		cleanupErr  error
+		wantErr     string
+		wantCleaned []string
+		wantGone    bool
+		wantFinal   bool
+	}{
+		{
+			name:      "finalizer is added",
+			state:     newState(false),
+			wantFinal: true,
+		},
+		{
+			name:        "state is cleaned up on deletion",
+			state:       newState(true, genv1alpha1.GeneratorStateFinalizer),
+			wantCleaned: []string{`{"clientName":"bootstrap"}`},
+			wantGone:    true,
+		},
+		{
+			name:       "failed cleanup keeps the finalizer",
+			state:      newState(true, genv1alpha1.GeneratorStateFinalizer),
+			cleanupErr: errors.New("forbidden"),
+			wantErr:    "unable to clean up generator state: forbidden",
+			wantFinal:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := &statefulGenerator{cleanupErr: tt.cleanupErr}
+			genv1alpha1.ForceRegister(testKind, gen)
+			kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.state).Build()
+			r := &Reconciler{
+				Client:   kube,
+				Log:      logr.Discard(),
+				Scheme:   scheme,
+				recorder: record.NewFakeRecorder(10),
+			}
+			key := types.NamespacedName{Name: tt.state.Name, Namespace: tt.state.Namespace}
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCleaned, gen.cleaned)
+
+			var got genv1alpha1.GeneratorState
+			err = kube.Get(context.Background(), key, &got)
+			if tt.wantGone {
+				assert.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFinal, len(got.Finalizers) == 1 && got.Finalizers[0] == genv1alpha1.GeneratorStateFinalizer)
+		})
+	}
+}
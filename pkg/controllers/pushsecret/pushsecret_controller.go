@@ -41,13 +41,15 @@ import (
 )
 
 const (
-	errFailedGetSecret       = "could not get source secret"
-	errPatchStatus           = "error merging"
-	errGetSecretStore        = "could not get SecretStore %q, %w"
-	errGetClusterSecretStore = "could not get ClusterSecretStore %q, %w"
-	errSetSecretFailed       = "could not write remote ref %v to target secretstore %v: %v"
-	errFailedSetSecret       = "set secret failed: %v"
-	pushSecretFinalizer      = "pushsecret.externalsecrets.io/finalizer"
+	errFailedGetSecret         = "could not get source secret"
+	msgSourceDeleted           = "source secret was deleted, the pushed secrets were removed from the providers"
+	errPatchStatus             = "error merging"
+	errGetSecretStore          = "could not get SecretStore %q, %w"
+	errGetClusterSecretStore   = "could not get ClusterSecretStore %q, %w"
+	errSetSecretFailed         = "could not write remote ref %v to target secretstore %v: %v"
+	errFailedSetSecret         = "set secret failed: %v"
+	errUpdatePolicyUnsupported = "secret store does not support updatePolicy %q"
+	pushSecretFinalizer        = "pushsecret.externalsecrets.io/finalizer"
 )
 
 type Reconciler struct {
@@ -134,12 +136,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 				return ctrl.Result{}, nil
 			}
 		}
-	case esapi.PushSecretDeletionPolicyNone:
+	case esapi.PushSecretDeletionPolicyNone, esapi.PushSecretDeletionPolicyRetain:
 	default:
 	}
 
 	secret, err := r.GetSecret(ctx, ps)
 	if err != nil {
+		// the source secret is gone, remove the pushed secrets as well
+		if apierrors.IsNotFound(err) && ps.Spec.DeletionPolicy == esapi.PushSecretDeletionPolicyDelete {
+			badState, derr := r.DeleteSecretFromProviders(ctx, &ps, esapi.SyncedPushSecretsMap{}, mgr)
+			if derr != nil {
+				msg := fmt.Sprintf("Failed to Delete Secrets from Provider: %v", derr)
+				r.markAsFailed(msg, &ps, badState)
+
+				return ctrl.Result{}, derr
+			}
+			r.markAsSourceDeleted(&ps)
+
+			return ctrl.Result{RequeueAfter: refreshInt}, nil
+		}
 		r.markAsFailed(errFailedGetSecret, &ps, nil)
 
 		return ctrl.Result{}, err
@@ -151,10 +166,40 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	if denied := deniedStores(secretStores); len(denied) > 0 {
+		msg := fmt.Sprintf(errDenied, strings.Join(denied, ", "))
+		cond := newPushSecretCondition(esapi.PushSecretReady, v1.ConditionFalse, esapi.ReasonDenied, msg)
+		setPushSecretCondition(&ps, *cond)
+		r.recorder.Event(&ps, v1.EventTypeWarning, esapi.ReasonDenied, msg)
+
+		return ctrl.Result{RequeueAfter: refreshInt}, nil
+	}
+
 	if err := r.applyTemplate(ctx, &ps, secret); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	conflicts, err := r.findConflicts(ctx, &ps, secretStores)
+	if err != nil {
+		r.markAsFailed(err.Error(), &ps, nil)
+
+		return ctrl.Result{}, err
+	}
+	ps.Status.Conflicts = conflicts
+	if len(conflicts) > 0 {
+		switch ps.Spec.ConflictPolicy {
+		case esapi.PushSecretConflictPolicyProviderWins, esapi.PushSecretConflictPolicyClusterWins:
+			r.recorder.Event(&ps, v1.EventTypeWarning, esapi.ReasonConflict, fmt.Sprintf(errConflict, conflictingExternalSecrets(conflicts)))
+		default:
+			msg := fmt.Sprintf(errConflict, conflictingExternalSecrets(conflicts))
+			cond := newPushSecretCondition(esapi.PushSecretReady, v1.ConditionFalse, esapi.ReasonConflict, msg)
+			setPushSecretCondition(&ps, *cond)
+			r.recorder.Event(&ps, v1.EventTypeWarning, esapi.ReasonConflict, msg)
+
+			return ctrl.Result{RequeueAfter: refreshInt}, nil
+		}
+	}
+
 	syncedSecrets, err := r.PushSecretToProviders(ctx, secretStores, ps, secret, mgr)
 	if err != nil {
 		if errors.Is(err, locks.ErrConflict) {
@@ -176,7 +221,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			r.markAsFailed(msg, &ps, badSyncState)
 			return ctrl.Result{}, err
 		}
-	case esapi.PushSecretDeletionPolicyNone:
+	case esapi.PushSecretDeletionPolicyNone, esapi.PushSecretDeletionPolicyRetain:
 	default:
 	}
 
@@ -202,6 +247,13 @@ func (r *Reconciler) markAsDone(ps *esapi.PushSecret, syncedSecrets esapi.Synced
 	r.recorder.Event(ps, v1.EventTypeNormal, esapi.ReasonSynced, msg)
 }
 
+func (r *Reconciler) markAsSourceDeleted(ps *esapi.PushSecret) {
+	cond := newPushSecretCondition(esapi.PushSecretReady, v1.ConditionFalse, esapi.ReasonSourceDeleted, msgSourceDeleted)
+	setPushSecretCondition(ps, *cond)
+	r.setSyncedSecrets(ps, esapi.SyncedPushSecretsMap{})
+	r.recorder.Event(ps, v1.EventTypeNormal, esapi.ReasonSourceDeleted, msgSourceDeleted)
+}
+
 func (r *Reconciler) setSyncedSecrets(ps *esapi.PushSecret, status esapi.SyncedPushSecretsMap) {
 	ps.Status.SyncedPushSecrets = status
 }
@@ -288,7 +340,15 @@ func (r *Reconciler) PushSecretToProviders(ctx context.Context, stores map[esapi
 				}
 			}
 
-			if err := secretClient.PushSecret(ctx, secret, data); err != nil {
+			// the provider wins, keep the data managed without overwriting it
+			if ps.Spec.ConflictPolicy == esapi.PushSecretConflictPolicyProviderWins && isConflicting(ps.Status.Conflicts, storeKey, data) {
+				if synced, ok := ps.Status.SyncedPushSecrets[storeKey][statusRef(data)]; ok {
+					out[storeKey][statusRef(data)] = synced
+				}
+				continue
+			}
+
+			if err := pushSecret(ctx, secretClient, secret, data, ps.Spec.UpdatePolicy); err != nil {
 				return out, fmt.Errorf(errSetSecretFailed, data.Match.SecretKey, store.GetName(), err)
 			}
 
@@ -432,3 +492,17 @@ func statusRef(ref v1beta1.PushSecretData) string {
 	}
 	return ref.GetRemoteKey()
 }
+
+// pushSecret writes the data using the given update policy.
+// Replace is supported by every provider, other policies require the
+// client to implement v1beta1.UpdatePolicyClient.
+func pushSecret(ctx context.Context, client v1beta1.SecretsClient, secret *v1.Secret, data esapi.PushSecretData, policy v1beta1.PushSecretUpdatePolicy) error {
+	if policy == "" || policy == v1beta1.PushSecretUpdatePolicyReplace {
+		return client.PushSecret(ctx, secret, data)
+	}
+	policyClient, ok := client.(v1beta1.UpdatePolicyClient)
+	if !ok {
+		return fmt.Errorf(errUpdatePolicyUnsupported, policy)
+	}
+	return policyClient.PushSecretWithPolicy(ctx, secret, data, policy)
+}
@@ -0,0 +1,296 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errListExternalSecrets = "could not list ExternalSecrets: %w"
+	errConflict            = "secrets pushed by this PushSecret are pulled back into the source Secret by ExternalSecret %v"
+)
+
+// findConflicts returns the ExternalSecret data and dataFrom entries that
+// pull a secret pushed by the PushSecret back into its source Secret. Pushing
+// and pulling the same secret would otherwise oscillate values between the
+// cluster and the provider.
+func (r *Reconciler) findConflicts(ctx context.Context, ps *esapi.PushSecret, stores map[esapi.PushSecretStoreRef]v1beta1.GenericStore) ([]esapi.PushSecretConflict, error) {
+	var esList v1beta1.ExternalSecretList
+	if err := r.List(ctx, &esList, client.InNamespace(ps.Namespace)); err != nil {
+		return nil, fmt.Errorf(errListExternalSecrets, err)
+	}
+	var conflicts []esapi.PushSecretConflict
+	for i := range esList.Items {
+		es := &esList.Items[i]
+		if targetName(es) != ps.Spec.Selector.Secret.Name {
+			continue
+		}
+		for _, esData := range expandPropertyKeys(es.Spec.Data) {
+			esStore := esDataStoreKey(es, esData)
+			if !pushesToStore(stores, esStore) {
+				continue
+			}
+			for _, psData := range ps.Spec.Data {
+				if !pullsPushedData(esData, psData) {
+					continue
+				}
+				conflicts = append(conflicts, esapi.PushSecretConflict{
+					ExternalSecret: es.Name,
+					SecretKey:      esData.SecretKey,
+					Store:          esStore,
+					RemoteKey:      psData.GetRemoteKey(),
+				})
+			}
+		}
+		for _, dataFrom := range es.Spec.DataFrom {
+			esStore, ok := esDataFromStoreKey(es, dataFrom)
+			if !ok || !pushesToStore(stores, esStore) {
+				continue
+			}
+			for _, psData := range ps.Spec.Data {
+				if !pullsPushedDataFrom(dataFrom, psData) {
+					continue
+				}
+				conflicts = append(conflicts, esapi.PushSecretConflict{
+					ExternalSecret: es.Name,
+					SecretKey:      psData.GetSecretKey(),
+					Store:          esStore,
+					RemoteKey:      psData.GetRemoteKey(),
+				})
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+func pushesToStore(stores map[esapi.PushSecretStoreRef]v1beta1.GenericStore, storeKey string) bool {
+	for ref, store := range stores {
+		if storeRefKey(ref.Kind, store.GetName()) == storeKey {
+			return true
+		}
+	}
+	return false
+}
+
+// isConflicting returns true if the data pushed to the store is pulled by an ExternalSecret.
+func isConflicting(conflicts []esapi.PushSecretConflict, store string, data esapi.PushSecretData) bool {
+	for _, c := range conflicts {
+		if c.Store == store && c.RemoteKey == data.GetRemoteKey() &&
+			(data.GetSecretKey() == "" || data.GetSecretKey() == c.SecretKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func conflictingExternalSecrets(conflicts []esapi.PushSecretConflict) string {
+	names := make([]string, 0, len(conflicts))
+	seen := make(map[string]bool)
+	for _, c := range conflicts {
+		if !seen[c.ExternalSecret] {
+			seen[c.ExternalSecret] = true
+			names = append(names, c.ExternalSecret)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// pullsPushedData returns true if the ExternalSecret data reads the remote
+// secret written by the PushSecret data into the same secret key.
+func pullsPushedData(esData v1beta1.ExternalSecretData, psData esapi.PushSecretData) bool {
+	if esData.RemoteRef.Key != psData.GetRemoteKey() {
+		return false
+	}
+	if psData.GetSecretKey() != "" && psData.GetSecretKey() != esData.SecretKey {
+		return false
+	}
+	return propertiesOverlap(esData.RemoteRef.Property, psData.GetProperty())
+}
+
+// pullsPushedDataFrom returns true if the ExternalSecret dataFrom entry reads
+// the remote secret written by the PushSecret data back into the pushed
+// secret key. Entries of find that only select tags can not be matched
+// without asking the provider and are skipped.
+func pullsPushedDataFrom(dataFrom v1beta1.ExternalSecretDataFromRemoteRef, psData esapi.PushSecretData) bool {
+	remoteKey := psData.GetRemoteKey()
+	var keys []string
+	var strategy v1beta1.ExternalSecretConversionStrategy
+	switch {
+	case dataFrom.Extract != nil:
+		if dataFrom.Extract.Key != remoteKey || !propertiesOverlap(dataFrom.Extract.Property, psData.GetProperty()) {
+			return false
+		}
+		keys = extractedKeys(dataFrom.Extract.Property, psData.GetProperty())
+		strategy = dataFrom.Extract.ConversionStrategy
+	case dataFrom.Find != nil && (dataFrom.Find.Path != nil || dataFrom.Find.Name != nil):
+		if dataFrom.Find.Path != nil && !strings.HasPrefix(remoteKey, *dataFrom.Find.Path) {
+			return false
+		}
+		if dataFrom.Find.Name != nil {
+			matcher, err := find.New(*dataFrom.Find.Name)
+			if err != nil || !matcher.MatchName(remoteKey) {
+				return false
+			}
+		}
+		keys = foundKeys(remoteKey, dataFrom.Find.Path)
+		strategy = dataFrom.Find.ConversionStrategy
+	default:
+		return false
+	}
+	return writesSecretKey(dataFrom.Rewrite, strategy, keys, psData.GetSecretKey())
+}
+
+// extractedKeys returns the key extract writes the pushed property to, nil
+// if the whole pushed secret or a parent of the extracted property is
+// pushed, as then every extracted key may be written.
+func extractedKeys(extracted, pushed string) []string {
+	if pushed == "" || pushed == extracted || strings.HasPrefix(extracted, pushed+".") {
+		return nil
+	}
+	rel := pushed
+	if extracted != "" {
+		rel = strings.TrimPrefix(pushed, extracted+".")
+	}
+	key, _, _ := strings.Cut(rel, ".")
+	return []string{key}
+}
+
+// foundKeys returns the keys find may write the remote secret to. Providers
+// name found secrets by their full key, the key relative to the path or the
+// last segment of the key.
+func foundKeys(remoteKey string, path *string) []string {
+	keys := []string{remoteKey}
+	if path != nil {
+		keys = append(keys, strings.TrimPrefix(strings.TrimPrefix(remoteKey, *path), "/"))
+	}
+	if i := strings.LastIndex(remoteKey, "/"); i >= 0 {
+		keys = append(keys, remoteKey[i+1:])
+	}
+	return keys
+}
+
+// writesSecretKey returns true if one of the keys is written to the secret
+// key after the rewrite or, without a rewrite, the conversion of the keys.
+// Keys that can not be rewritten are assumed to be written.
+func writesSecretKey(rewrite []v1beta1.ExternalSecretRewrite, strategy v1beta1.ExternalSecretConversionStrategy, keys []string, secretKey string) bool {
+	if secretKey == "" || keys == nil {
+		return true
+	}
+	for _, key := range keys {
+		written := map[string][]byte{key: nil}
+		var err error
+		if len(rewrite) > 0 {
+			written, err = utils.RewriteMap(rewrite, written)
+		} else {
+			written, err = utils.ConvertKeys(strategy, written)
+		}
+		if err != nil {
+			return true
+		}
+		if _, ok := written[secretKey]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// propertiesOverlap returns true if one property contains the other, an
+// empty property refers to the whole secret.
+func propertiesOverlap(a, b string) bool {
+	return a == "" || b == "" || a == b ||
+		strings.HasPrefix(a, b+".") || strings.HasPrefix(b, a+".")
+}
+
+// expandPropertyKeys returns one data entry per property of entries with
+// propertyKeys, so that each secret key is matched with its property.
+// Templated secret keys are rendered, keys that fail to render are kept.
+func expandPropertyKeys(data []v1beta1.ExternalSecretData) []v1beta1.ExternalSecretData {
+	out := make([]v1beta1.ExternalSecretData, 0, len(data))
+	for _, d := range data {
+		if len(d.PropertyKeys) == 0 {
+			d.SecretKey = renderSecretKey(d.SecretKey, d.RemoteRef)
+			out = append(out, d)
+			continue
+		}
+		properties := make([]string, 0, len(d.PropertyKeys))
+		for property := range d.PropertyKeys {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+		for _, property := range properties {
+			e := d
+			e.PropertyKeys = nil
+			e.RemoteRef.Property = property
+			e.SecretKey = renderSecretKey(d.PropertyKeys[property], e.RemoteRef)
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func renderSecretKey(secretKey string, ref v1beta1.ExternalSecretDataRemoteRef) string {
+	if key, err := utils.RenderSecretKey(secretKey, ref); err == nil {
+		return key
+	}
+	return secretKey
+}
+
+func esDataStoreKey(es *v1beta1.ExternalSecret, data v1beta1.ExternalSecretData) string {
+	ref := es.Spec.SecretStoreRef
+	if data.SourceRef != nil && data.SourceRef.SecretStoreRef.Name != "" {
+		ref = data.SourceRef.SecretStoreRef
+	}
+	return storeRefKey(ref.Kind, ref.Name)
+}
+
+// esDataFromStoreKey returns the store of a dataFrom entry, entries that read
+// from a generator have none.
+func esDataFromStoreKey(es *v1beta1.ExternalSecret, dataFrom v1beta1.ExternalSecretDataFromRemoteRef) (string, bool) {
+	ref := es.Spec.SecretStoreRef
+	if dataFrom.SourceRef != nil {
+		if dataFrom.SourceRef.GeneratorRef != nil {
+			return "", false
+		}
+		if dataFrom.SourceRef.SecretStoreRef != nil && dataFrom.SourceRef.SecretStoreRef.Name != "" {
+			ref = *dataFrom.SourceRef.SecretStoreRef
+		}
+	}
+	return storeRefKey(ref.Kind, ref.Name), true
+}
+
+func storeRefKey(kind, name string) string {
+	if kind == "" {
+		kind = v1beta1.SecretStoreKind
+	}
+	return fmt.Sprintf("%v/%v", kind, name)
+}
+
+func targetName(es *v1beta1.ExternalSecret) string {
+	if es.Spec.Target.Name != "" {
+		return es.Spec.Target.Name
+	}
+	return es.Name
+}
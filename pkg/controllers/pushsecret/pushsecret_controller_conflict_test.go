@@ -0,0 +1,308 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestFindConflicts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, esapi.AddToScheme(scheme))
+
+	ps := &esapi.PushSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "default"},
+		Spec: esapi.PushSecretSpec{
+			Selector: esapi.PushSecretSelector{Secret: esapi.PushSecretSecret{Name: "db"}},
+			Data: []esapi.PushSecretData{
+				{Match: esapi.PushSecretMatch{SecretKey: "password", RemoteRef: esapi.PushSecretRemoteRef{RemoteKey: "app/db", Property: "credentials.password"}}},
+			},
+		},
+	}
+	stores := map[esapi.PushSecretStoreRef]v1beta1.GenericStore{
+		{Name: "chef", Kind: v1beta1.SecretStoreKind}: &v1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: "chef", Namespace: "default"}},
+	}
+	newES := func(name, target, store, secretKey, key, property string) *v1beta1.ExternalSecret {
+		return &v1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1beta1.ExternalSecretSpec{
+				SecretStoreRef: v1beta1.SecretStoreRef{Name: store},
+				Target:         v1beta1.ExternalSecretTarget{Name: target},
+				Data: []v1beta1.ExternalSecretData{
+					{SecretKey: secretKey, RemoteRef: v1beta1.ExternalSecretDataRemoteRef{Key: key, Property: property}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name string
+		es   *v1beta1.ExternalSecret
+		want []esapi.PushSecretConflict
+	}{
+		{
+			name: "pulls the pushed property",
+			es:   newES("es", "db", "chef", "password", "app/db", "credentials.password"),
+			want: []esapi.PushSecretConflict{
+				{ExternalSecret: "es", SecretKey: "password", Store: "SecretStore/chef", RemoteKey: "app/db"},
+			},
+		},
+		{
+			name: "pulls the parent of the pushed property into the ExternalSecret name",
+			es:   newES("db", "", "chef", "password", "app/db", "credentials"),
+			want: []esapi.PushSecretConflict{
+				{ExternalSecret: "db", SecretKey: "password", Store: "SecretStore/chef", RemoteKey: "app/db"},
+			},
+		},
+		{
+			name: "other target secret",
+			es:   newES("es", "other", "chef", "password", "app/db", "credentials.password"),
+		},
+		{
+			name: "other store",
+			es:   newES("es", "db", "vault", "password", "app/db", "credentials.password"),
+		},
+		{
+			name: "other secret key",
+			es:   newES("es", "db", "chef", "username", "app/db", "credentials.password"),
+		},
+		{
+			name: "other property",
+			es:   newES("es", "db", "chef", "password", "app/db", "credentials.passwords"),
+		},
+		{
+			name: "pulls the pushed property into a templated secret key",
+			es:   newES("es", "db", "chef", `{{ .remoteRef.property | trimPrefix "credentials." }}`, "app/db", "credentials.password"),
+			want: []esapi.PushSecretConflict{
+				{ExternalSecret: "es", SecretKey: "password", Store: "SecretStore/chef", RemoteKey: "app/db"},
+			},
+		},
+		{
+			name: "pulls the pushed property with propertyKeys",
+			es: &v1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+				Spec: v1beta1.ExternalSecretSpec{
+					SecretStoreRef: v1beta1.SecretStoreRef{Name: "chef"},
+					Target:         v1beta1.ExternalSecretTarget{Name: "db"},
+					Data: []v1beta1.ExternalSecretData{{
+						PropertyKeys: map[string]string{"credentials.password": "password", "credentials.username": "username"},
+						RemoteRef:    v1beta1.ExternalSecretDataRemoteRef{Key: "app/db"},
+					}},
+				},
+			},
+			want: []esapi.PushSecretConflict{
+				{ExternalSecret: "es", SecretKey: "password", Store: "SecretStore/chef", RemoteKey: "app/db"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.es).Build()
+			r := &Reconciler{Client: kube}
+			got, err := r.findConflicts(context.Background(), ps, stores)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindConflictsDataFrom(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, esapi.AddToScheme(scheme))
+
+	newPS := func(secretKey, property string) *esapi.PushSecret {
+		return &esapi.PushSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "default"},
+			Spec: esapi.PushSecretSpec{
+				Selector: esapi.PushSecretSelector{Secret: esapi.PushSecretSecret{Name: "db"}},
+				Data: []esapi.PushSecretData{
+					{Match: esapi.PushSecretMatch{SecretKey: secretKey, RemoteRef: esapi.PushSecretRemoteRef{RemoteKey: "app/db", Property: property}}},
+				},
+			},
+		}
+	}
+	// pushes the password property, pulled back by extract.
+	pushProperty := newPS("password", "password")
+	// pushes the whole secret, pulled back by find into a key named after it.
+	pushSecret := newPS("db", "")
+	stores := map[esapi.PushSecretStoreRef]v1beta1.GenericStore{
+		{Name: "chef", Kind: v1beta1.SecretStoreKind}: &v1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: "chef", Namespace: "default"}},
+	}
+	newES := func(dataFrom v1beta1.ExternalSecretDataFromRemoteRef) *v1beta1.ExternalSecret {
+		return &v1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+			Spec: v1beta1.ExternalSecretSpec{
+				SecretStoreRef: v1beta1.SecretStoreRef{Name: "chef"},
+				Target:         v1beta1.ExternalSecretTarget{Name: "db"},
+				DataFrom:       []v1beta1.ExternalSecretDataFromRemoteRef{dataFrom},
+			},
+		}
+	}
+	conflict := func(secretKey string) []esapi.PushSecretConflict {
+		return []esapi.PushSecretConflict{
+			{ExternalSecret: "es", SecretKey: secretKey, Store: "SecretStore/chef", RemoteKey: "app/db"},
+		}
+	}
+	path := func(p string) *string { return &p }
+	rewrite := func(source, target string) []v1beta1.ExternalSecretRewrite {
+		return []v1beta1.ExternalSecretRewrite{
+			{Regexp: &v1beta1.ExternalSecretRewriteRegexp{Source: source, Target: target}},
+		}
+	}
+	tests := []struct {
+		name     string
+		ps       *esapi.PushSecret
+		dataFrom v1beta1.ExternalSecretDataFromRemoteRef
+		want     []esapi.PushSecretConflict
+	}{
+		{
+			name:     "extracts the pushed secret",
+			ps:       pushProperty,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Extract: &v1beta1.ExternalSecretDataRemoteRef{Key: "app/db"}},
+			want:     conflict("password"),
+		},
+		{
+			name:     "extracts the pushed whole secret",
+			ps:       pushSecret,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Extract: &v1beta1.ExternalSecretDataRemoteRef{Key: "app/db"}},
+			want:     conflict("db"),
+		},
+		{
+			name:     "extracts the pushed property into another key",
+			ps:       newPS("password", "db_password"),
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Extract: &v1beta1.ExternalSecretDataRemoteRef{Key: "app/db"}},
+		},
+		{
+			name: "rewrites the extracted key",
+			ps:   pushProperty,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{
+				Extract: &v1beta1.ExternalSecretDataRemoteRef{Key: "app/db"},
+				Rewrite: rewrite("^password$", "db_password"),
+			},
+		},
+		{
+			name:     "extracts another property",
+			ps:       pushProperty,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Extract: &v1beta1.ExternalSecretDataRemoteRef{Key: "app/db", Property: "tls"}},
+		},
+		{
+			name:     "extracts another secret",
+			ps:       pushProperty,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Extract: &v1beta1.ExternalSecretDataRemoteRef{Key: "app/cache"}},
+		},
+		{
+			name: "extracts from another store",
+			ps:   pushProperty,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{
+				Extract:   &v1beta1.ExternalSecretDataRemoteRef{Key: "app/db"},
+				SourceRef: &v1beta1.StoreGeneratorSourceRef{SecretStoreRef: &v1beta1.SecretStoreRef{Name: "vault"}},
+			},
+		},
+		{
+			name:     "finds the pushed secret by path",
+			ps:       pushSecret,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Find: &v1beta1.ExternalSecretFind{Path: path("app/")}},
+			want:     conflict("db"),
+		},
+		{
+			name:     "finds the pushed secret by name",
+			ps:       pushSecret,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Find: &v1beta1.ExternalSecretFind{Path: path("app/"), Name: &v1beta1.FindName{RegExp: "db$"}}},
+			want:     conflict("db"),
+		},
+		{
+			name:     "finds the pushed secret by its converted name",
+			ps:       newPS("app_db", ""),
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Find: &v1beta1.ExternalSecretFind{Name: &v1beta1.FindName{RegExp: "^app/"}, ConversionStrategy: v1beta1.ExternalSecretConversionDefault}},
+			want:     conflict("app_db"),
+		},
+		{
+			name: "finds the pushed secret rewritten to the pushed key",
+			ps:   pushProperty,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{
+				Find:    &v1beta1.ExternalSecretFind{Path: path("app/")},
+				Rewrite: rewrite("^app/db$", "password"),
+			},
+			want: conflict("password"),
+		},
+		{
+			name:     "finds the pushed secret into another key",
+			ps:       pushProperty,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Find: &v1beta1.ExternalSecretFind{Path: path("app/")}},
+		},
+		{
+			name:     "finds other names",
+			ps:       pushSecret,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Find: &v1beta1.ExternalSecretFind{Name: &v1beta1.FindName{RegExp: "^cache"}}},
+		},
+		{
+			name:     "finds other names in the path",
+			ps:       pushSecret,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Find: &v1beta1.ExternalSecretFind{Path: path("app/"), Name: &v1beta1.FindName{RegExp: "cache$"}}},
+		},
+		{
+			name:     "finds by an invalid name",
+			ps:       pushSecret,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Find: &v1beta1.ExternalSecretFind{Name: &v1beta1.FindName{RegExp: "("}}},
+		},
+		{
+			name:     "finds another path",
+			ps:       pushSecret,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Find: &v1beta1.ExternalSecretFind{Path: path("infra/")}},
+		},
+		{
+			name:     "finds by tags",
+			ps:       pushSecret,
+			dataFrom: v1beta1.ExternalSecretDataFromRemoteRef{Find: &v1beta1.ExternalSecretFind{Tags: map[string]string{"app": "db"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(newES(tt.dataFrom)).Build()
+			r := &Reconciler{Client: kube}
+			got, err := r.findConflicts(context.Background(), tt.ps, stores)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsConflicting(t *testing.T) {
+	conflicts := []esapi.PushSecretConflict{
+		{ExternalSecret: "es", SecretKey: "password", Store: "SecretStore/chef", RemoteKey: "app/db"},
+	}
+	data := func(secretKey, remoteKey string) esapi.PushSecretData {
+		return esapi.PushSecretData{Match: esapi.PushSecretMatch{SecretKey: secretKey, RemoteRef: esapi.PushSecretRemoteRef{RemoteKey: remoteKey}}}
+	}
+	assert.True(t, isConflicting(conflicts, "SecretStore/chef", data("password", "app/db")))
+	assert.True(t, isConflicting(conflicts, "SecretStore/chef", data("", "app/db")))
+	assert.False(t, isConflicting(conflicts, "SecretStore/chef", data("username", "app/db")))
+	assert.False(t, isConflicting(conflicts, "SecretStore/vault", data("password", "app/db")))
+	assert.False(t, isConflicting(conflicts, "SecretStore/chef", data("password", "app/cache")))
+}
@@ -0,0 +1,43 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"fmt"
+	"sort"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const errDenied = "pushing secrets is denied by read only store %v"
+
+// deniedStores returns the stores that deny writes, as Kind/name. A
+// PushSecret that targets any of them is rejected as a whole, so that
+// nothing is pushed to the other stores either.
+func deniedStores(stores map[esapi.PushSecretStoreRef]v1beta1.GenericStore) []string {
+	var denied []string
+	for ref, store := range stores {
+		provider, err := v1beta1.GetProvider(store)
+		if err != nil {
+			continue
+		}
+		if denier, ok := provider.(v1beta1.PushDenier); ok && denier.PushDenied(store) {
+			denied = append(denied, fmt.Sprintf("%v/%v", ref.Kind, store.GetName()))
+		}
+	}
+	sort.Strings(denied)
+	return denied
+}
@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestDeniedStores(t *testing.T) {
+	chefStore := func(name string, readOnly bool) *v1beta1.SecretStore {
+		return &v1beta1.SecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1beta1.SecretStoreSpec{Provider: &v1beta1.SecretStoreProvider{
+				Chef: &v1beta1.ChefProvider{ReadOnlyStrict: readOnly},
+			}},
+		}
+	}
+	stores := map[esapi.PushSecretStoreRef]v1beta1.GenericStore{
+		{Name: "prod", Kind: v1beta1.SecretStoreKind}:    chefStore("prod", true),
+		{Name: "dev", Kind: v1beta1.SecretStoreKind}:     chefStore("dev", false),
+		{Name: "shared", Kind: v1beta1.SecretStoreKind}:  chefStore("shared", true),
+		{Name: "invalid", Kind: v1beta1.SecretStoreKind}: &v1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: "invalid"}},
+	}
+	assert.Equal(t, []string{"SecretStore/prod", "SecretStore/shared"}, deniedStores(stores))
+
+	delete(stores, esapi.PushSecretStoreRef{Name: "prod", Kind: v1beta1.SecretStoreKind})
+	delete(stores, esapi.PushSecretStoreRef{Name: "shared", Kind: v1beta1.SecretStoreKind})
+	assert.Empty(t, deniedStores(stores))
+}
@@ -0,0 +1,103 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReconcileSourceSecretDeleted(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, esapi.AddToScheme(scheme))
+
+	store := &v1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-store", Namespace: "default"},
+		Spec: v1beta1.SecretStoreSpec{
+			Provider: &v1beta1.SecretStoreProvider{Fake: &v1beta1.FakeProvider{}},
+		},
+	}
+	data := esapi.PushSecretData{
+		Match: esapi.PushSecretMatch{SecretKey: "key", RemoteRef: esapi.PushSecretRemoteRef{RemoteKey: "path/to/key"}},
+	}
+	ps := &esapi.PushSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "ps",
+			Namespace:  "default",
+			Finalizers: []string{pushSecretFinalizer},
+		},
+		Spec: esapi.PushSecretSpec{
+			DeletionPolicy:  esapi.PushSecretDeletionPolicyDelete,
+			SecretStoreRefs: []esapi.PushSecretStoreRef{{Name: "test-store", Kind: v1beta1.SecretStoreKind}},
+			Selector:        esapi.PushSecretSelector{Secret: esapi.PushSecretSecret{Name: "deleted"}},
+			Data:            []esapi.PushSecretData{data},
+		},
+		Status: esapi.PushSecretStatus{
+			SyncedPushSecrets: esapi.SyncedPushSecretsMap{
+				"SecretStore/test-store": {"path/to/key": data},
+			},
+		},
+	}
+	kube := clientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(store, ps).
+		WithStatusSubresource(ps).
+		Build()
+
+	deleted := false
+	fakeProvider.Reset()
+	fakeProvider.DeleteSecretFn = func() error {
+		deleted = true
+		return nil
+	}
+	r := &Reconciler{
+		Client:          kube,
+		Log:             logr.Discard(),
+		Scheme:          scheme,
+		recorder:        record.NewFakeRecorder(10),
+		RequeueInterval: time.Hour,
+	}
+	key := types.NamespacedName{Name: "ps", Namespace: "default"}
+	res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: time.Hour}, res)
+	assert.True(t, deleted)
+
+	var got esapi.PushSecret
+	require.NoError(t, kube.Get(context.Background(), key, &got))
+	assert.Empty(t, got.Status.SyncedPushSecrets)
+	cond := getPushSecretCondition(got.Status, esapi.PushSecretReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, v1.ConditionFalse, cond.Status)
+	assert.Equal(t, esapi.ReasonSourceDeleted, cond.Reason)
+}
@@ -49,7 +49,10 @@ func (r *Reconciler) applyTemplate(ctx context.Context, ps *v1alpha1.PushSecret,
 		return err
 	}
 
-	execute, err := template.EngineForVersion(esv1beta1.TemplateEngineV2)
+	// PushSecret templates always use engine v2.
+	tpl := *ps.Spec.Template
+	tpl.EngineVersion = esv1beta1.TemplateEngineV2
+	execute, err := template.EngineForTemplate(&tpl, nil)
 	if err != nil {
 		return err
 	}
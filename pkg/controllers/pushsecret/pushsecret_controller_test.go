@@ -382,6 +382,37 @@ var _ = Describe("ExternalSecret controller", func() {
 			return true
 		}
 	}
+	deleteSourceSecret := func(tc *testCase) {
+		deleted := false
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		fakeProvider.DeleteSecretFn = func() error {
+			deleted = true
+			return nil
+		}
+		tc.pushsecret.Spec.DeletionPolicy = v1alpha1.PushSecretDeletionPolicyDelete
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			Expect(k8sClient.Delete(context.Background(), secret, &client.DeleteOptions{})).Should(Succeed())
+			updatedPS := &v1alpha1.PushSecret{}
+			Eventually(func() bool {
+				psKey := types.NamespacedName{Name: PushSecretName, Namespace: PushSecretNamespace}
+				By("checking if the pushed secret got deleted")
+				err := k8sClient.Get(context.Background(), psKey, updatedPS)
+				if err != nil {
+					return false
+				}
+				expected := v1alpha1.PushSecretStatusCondition{
+					Type:    v1alpha1.PushSecretReady,
+					Status:  v1.ConditionFalse,
+					Reason:  v1alpha1.ReasonSourceDeleted,
+					Message: "source secret was deleted, the pushed secrets were removed from the providers",
+				}
+				return deleted && len(updatedPS.Status.SyncedPushSecrets) == 0 && checkCondition(updatedPS.Status, expected)
+			}, time.Second*10, time.Second).Should(BeTrue())
+			return true
+		}
+	}
 	failDeleteStore := func(tc *testCase) {
 		fakeProvider.SetSecretFn = func() error {
 			return nil
@@ -772,6 +803,7 @@ var _ = Describe("ExternalSecret controller", func() {
 		Entry("should delete if DeletionPolicy=Delete", syncAndDeleteSuccessfully),
 		Entry("should track deletion tasks if Delete fails", failDelete),
 		Entry("should track deleted stores if Delete fails", failDeleteStore),
+		Entry("should delete if the source Secret is deleted and DeletionPolicy=Delete", deleteSourceSecret),
 		Entry("should delete all secrets if SecretStore changes", deleteWholeStore),
 		Entry("should sync to stores matching labels", syncMatchingLabels),
 		Entry("should sync with ClusterStore", syncWithClusterStore),
@@ -0,0 +1,181 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	batchOpGetSecret    = "GetSecret"
+	batchOpGetSecretMap = "GetSecretMap"
+)
+
+// Batcher shares the results of provider calls between the ExternalSecrets
+// that reference the same store and are refreshed at about the same time.
+// Identical calls that are in flight are sent to the provider only once and
+// their results are reused for the duration of the window. Failed calls are
+// not reused once they completed.
+type Batcher struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	calls     map[batchKey]*batchCall
+	lastSweep time.Time
+}
+
+// batchKey identifies a call. The namespace of the ExternalSecret is part of
+// the key because providers may authenticate with it for ClusterSecretStores.
+type batchKey struct {
+	kind       string
+	store      types.NamespacedName
+	generation int64
+	namespace  string
+	op         string
+	ref        esv1beta1.ExternalSecretDataRemoteRef
+}
+
+type batchCall struct {
+	done       chan struct{}
+	finishedAt time.Time
+
+	secret    []byte
+	secretMap map[string][]byte
+	err       error
+}
+
+// NewBatcher returns a Batcher that reuses results for the given window.
+func NewBatcher(window time.Duration) *Batcher {
+	return &Batcher{
+		window: window,
+		now:    time.Now,
+		calls:  make(map[batchKey]*batchCall),
+	}
+}
+
+// Client wraps the client of the store, so that its GetSecret and
+// GetSecretMap calls are batched.
+func (b *Batcher) Client(c esv1beta1.SecretsClient, store esv1beta1.GenericStore, namespace string) esv1beta1.SecretsClient {
+	return &batchedClient{
+		SecretsClient: c,
+		batcher:       b,
+		key: batchKey{
+			kind:       store.GetKind(),
+			store:      types.NamespacedName{Namespace: store.GetNamespace(), Name: store.GetName()},
+			generation: store.GetGeneration(),
+			namespace:  namespace,
+		},
+	}
+}
+
+// do runs fn unless an identical call is in flight or completed
+// successfully within the window, in which case its result is returned.
+func (b *Batcher) do(key batchKey, fn func(*batchCall)) *batchCall {
+	b.mu.Lock()
+	now := b.now()
+	b.sweep(now)
+	if c, ok := b.calls[key]; ok && !b.expired(c, now) {
+		b.mu.Unlock()
+		<-c.done
+		return c
+	}
+	c := &batchCall{done: make(chan struct{})}
+	b.calls[key] = c
+	b.mu.Unlock()
+
+	fn(c)
+
+	b.mu.Lock()
+	c.finishedAt = b.now()
+	if c.err != nil {
+		delete(b.calls, key)
+	}
+	b.mu.Unlock()
+	close(c.done)
+	return c
+}
+
+func (b *Batcher) expired(c *batchCall, now time.Time) bool {
+	select {
+	case <-c.done:
+		return c.err != nil || !now.Before(c.finishedAt.Add(b.window))
+	default:
+		return false
+	}
+}
+
+// sweep removes the expired calls at most once per window.
+func (b *Batcher) sweep(now time.Time) {
+	if now.Before(b.lastSweep.Add(b.window)) {
+		return
+	}
+	for key, c := range b.calls {
+		if b.expired(c, now) {
+			delete(b.calls, key)
+		}
+	}
+	b.lastSweep = now
+}
+
+type batchedClient struct {
+	esv1beta1.SecretsClient
+	batcher *Batcher
+	key     batchKey
+}
+
+func (c *batchedClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	key := c.key
+	key.op = batchOpGetSecret
+	key.ref = ref
+	call := c.batcher.do(key, func(call *batchCall) {
+		call.secret, call.err = c.SecretsClient.GetSecret(ctx, ref)
+	})
+	if call.err != nil {
+		return nil, call.err
+	}
+	return copyBytes(call.secret), nil
+}
+
+func (c *batchedClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	key := c.key
+	key.op = batchOpGetSecretMap
+	key.ref = ref
+	call := c.batcher.do(key, func(call *batchCall) {
+		call.secretMap, call.err = c.SecretsClient.GetSecretMap(ctx, ref)
+	})
+	if call.err != nil {
+		return nil, call.err
+	}
+	// the callers may modify the map, so every caller gets its own copy.
+	secretMap := make(map[string][]byte, len(call.secretMap))
+	for k, v := range call.secretMap {
+		secretMap[k] = copyBytes(v)
+	}
+	return secretMap, nil
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
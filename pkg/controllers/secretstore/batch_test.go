@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+type countingClient struct {
+	MockFakeClient
+	calls int
+	err   error
+}
+
+func (c *countingClient) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	c.calls++
+	return []byte(ref.Key), c.err
+}
+
+func (c *countingClient) GetSecretMap(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	c.calls++
+	return map[string][]byte{"key": []byte(ref.Key)}, c.err
+}
+
+func TestBatcher(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBatcher(time.Minute)
+	b.now = func() time.Time { return now }
+
+	store := &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "chef", Namespace: "foo", Generation: 1},
+	}
+	provider := &countingClient{}
+	ref := esv1beta1.ExternalSecretDataRemoteRef{Key: "databag/item"}
+
+	// two ExternalSecrets refreshed at the same time share the call.
+	for i := 0; i < 2; i++ {
+		secretMap, err := b.Client(provider, store, "foo").GetSecretMap(ctx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"key": []byte("databag/item")}, secretMap)
+		secretMap["key"] = []byte("modified")
+	}
+	assert.Equal(t, 1, provider.calls)
+
+	// GetSecret is batched separately.
+	secret, err := b.Client(provider, store, "foo").GetSecret(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("databag/item"), secret)
+	assert.Equal(t, 2, provider.calls)
+
+	// other namespaces and store generations do not share the call.
+	_, err = b.Client(provider, store, "bar").GetSecretMap(ctx, ref)
+	require.NoError(t, err)
+	store.Generation = 2
+	_, err = b.Client(provider, store, "foo").GetSecretMap(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, 4, provider.calls)
+
+	// the result is reused within the window only.
+	now = now.Add(time.Minute)
+	_, err = b.Client(provider, store, "foo").GetSecretMap(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, 5, provider.calls)
+
+	// failed calls are not reused.
+	provider.err = errors.New("chef server down")
+	ref.Key = "databag/other"
+	for i := 0; i < 2; i++ {
+		_, err = b.Client(provider, store, "foo").GetSecret(ctx, ref)
+		require.Error(t, err)
+	}
+	assert.Equal(t, 7, provider.calls)
+}
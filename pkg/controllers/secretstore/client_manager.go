@@ -20,14 +20,13 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
-	"golang.org/x/exp/slices"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils/redact"
 )
 
 const (
@@ -47,6 +46,9 @@ type Manager struct {
 	client          client.Client
 	controllerClass string
 	enableFloodgate bool
+	batcher         *Batcher
+	diskCache       *DiskCache
+	retrySettings   *esv1beta1.SecretStoreRetrySettings
 
 	// store clients by provider type
 	clientMap map[clientKey]*clientVal
@@ -73,14 +75,41 @@ func NewManager(ctrlClient client.Client, controllerClass string, enableFloodgat
 	}
 }
 
+// WithBatcher batches the GetSecret and GetSecretMap calls of the clients
+// returned by the manager with the calls of other managers using b.
+func (m *Manager) WithBatcher(b *Batcher) *Manager {
+	m.batcher = b
+	return m
+}
+
+// WithDiskCache stores the values read by the clients returned by the
+// manager in c, and serves them while the provider or the store fail.
+func (m *Manager) WithDiskCache(c *DiskCache) *Manager {
+	m.diskCache = c
+	return m
+}
+
+// WithRetrySettings overrides the retrySettings of the stores the clients
+// are created for. Fields that are not set are taken from the store.
+func (m *Manager) WithRetrySettings(rs *esv1beta1.SecretStoreRetrySettings) *Manager {
+	m.retrySettings = rs
+	return m
+}
+
 func (m *Manager) GetFromStore(ctx context.Context, store esv1beta1.GenericStore, namespace string) (esv1beta1.SecretsClient, error) {
+	store = overrideRetrySettings(store, m.retrySettings)
 	storeProvider, err := esv1beta1.GetProvider(store)
 	if err != nil {
 		return nil, err
 	}
+	// stores created before the allowlist was configured, or while the
+	// webhook was unavailable, must not be used to reach other endpoints.
+	if err := esv1beta1.ValidateStoreEndpoints(store); err != nil {
+		return nil, err
+	}
 	secretClient := m.getStoredClient(ctx, storeProvider, store)
 	if secretClient != nil {
-		return secretClient, nil
+		return m.wrap(secretClient, store, namespace), nil
 	}
 	m.log.V(1).Info("creating new client",
 		"provider", fmt.Sprintf("%T", storeProvider),
@@ -89,14 +118,37 @@ func (m *Manager) GetFromStore(ctx context.Context, store esv1beta1.GenericStore
 	// this skip an unnecessary check/request in the case we are not going to do anything
 	secretClient, err = storeProvider.NewClient(ctx, store, m.client, namespace)
 	if err != nil {
-		return nil, err
+		return m.unavailable(store, namespace, redact.Error(err))
 	}
 	idx := storeKey(storeProvider)
 	m.clientMap[idx] = &clientVal{
 		client: secretClient,
 		store:  store,
 	}
-	return secretClient, nil
+	return m.wrap(secretClient, store, namespace), nil
+}
+
+// wrap scrubs the errors of provider clients, they may contain secret
+// material that must not end up in events, conditions and logs.
+func (m *Manager) wrap(secretClient esv1beta1.SecretsClient, store esv1beta1.GenericStore, namespace string) esv1beta1.SecretsClient {
+	secretClient = redact.Client(secretClient)
+	if m.diskCache != nil {
+		secretClient = m.diskCache.Client(secretClient, store, namespace)
+	}
+	// calls must not wait for batched calls of clients that retry differently.
+	if m.batcher != nil && m.retrySettings == nil {
+		secretClient = m.batcher.Client(secretClient, store, namespace)
+	}
+	return secretClient
+}
+
+// unavailable returns the error of a store that can not be used, or with a
+// disk cache a client that serves the cached values of the store only.
+func (m *Manager) unavailable(store esv1beta1.GenericStore, namespace string, err error) (esv1beta1.SecretsClient, error) {
+	if m.diskCache == nil {
+		return nil, err
+	}
+	return m.wrap(&unavailableClient{err: err}, store, namespace), nil
 }
 
 // Get returns a provider client from the given storeRef or sourceRef.secretStoreRef
@@ -126,12 +178,34 @@ func (m *Manager) Get(ctx context.Context, storeRef esv1beta1.SecretStoreRef, na
 	if m.enableFloodgate {
 		err := assertStoreIsUsable(store)
 		if err != nil {
-			return nil, err
+			return m.unavailable(store, namespace, err)
 		}
 	}
 	return m.GetFromStore(ctx, store, namespace)
 }
 
+// overrideRetrySettings returns a copy of the store with the retry settings
+// of the store replaced by the fields set in rs.
+func overrideRetrySettings(store esv1beta1.GenericStore, rs *esv1beta1.SecretStoreRetrySettings) esv1beta1.GenericStore {
+	if rs == nil {
+		return store
+	}
+	store = store.Copy()
+	spec := store.GetSpec()
+	merged := &esv1beta1.SecretStoreRetrySettings{}
+	if spec.RetrySettings != nil {
+		merged = spec.RetrySettings.DeepCopy()
+	}
+	if rs.MaxRetries != nil {
+		merged.MaxRetries = rs.MaxRetries
+	}
+	if rs.RetryInterval != nil {
+		merged.RetryInterval = rs.RetryInterval
+	}
+	spec.RetrySettings = merged
+	return store
+}
+
 // returns a previously stored client from the cache if store and store-version match
 // if a client exists for the same provider which points to a different store or store version
 // it will be cleaned up.
@@ -216,34 +290,11 @@ func (m *Manager) shouldProcessSecret(store esv1beta1.GenericStore, ns string) (
 		return true, nil
 	}
 
-	namespaceList := &v1.NamespaceList{}
-
-	for _, condition := range store.GetSpec().Conditions {
-		if condition.NamespaceSelector != nil {
-			namespaceSelector, err := metav1.LabelSelectorAsSelector(condition.NamespaceSelector)
-			if err != nil {
-				return false, err
-			}
-
-			if err := m.client.List(context.Background(), namespaceList, client.MatchingLabelsSelector{Selector: namespaceSelector}); err != nil {
-				return false, err
-			}
-
-			for _, namespace := range namespaceList.Items {
-				if namespace.GetName() == ns {
-					return true, nil // namespace matches the labelselector
-				}
-			}
-		}
-
-		if condition.Namespaces != nil {
-			if slices.Contains(condition.Namespaces, ns) {
-				return true, nil // namespace in the namespaces list
-			}
-		}
+	namespace := &v1.Namespace{}
+	if err := m.client.Get(context.Background(), types.NamespacedName{Name: ns}, namespace); err != nil {
+		return false, err
 	}
-
-	return false, nil
+	return esv1beta1.NamespaceAllowed(store.GetSpec().Conditions, namespace)
 }
 
 // assertStoreIsUsable assert that the store is ready to use.
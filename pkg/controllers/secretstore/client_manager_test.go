@@ -26,11 +26,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils/redact"
 )
 
 func TestManagerGet(t *testing.T) {
@@ -231,7 +233,7 @@ func TestManagerGet(t *testing.T) {
 				c, ok := mgr.clientMap[provKey]
 				assert.True(t, ok)
 				assert.Same(t, c.client, clientA)
-				assert.Same(t, sc, clientA)
+				assert.Same(t, redact.Unwrap(sc), clientA)
 			},
 
 			afterClose: func() {
@@ -276,7 +278,7 @@ func TestManagerGet(t *testing.T) {
 				c, ok := mgr.clientMap[provKey]
 				assert.True(t, ok)
 				assert.Same(t, c.client, clientB)
-				assert.Same(t, sc, clientB)
+				assert.Same(t, redact.Unwrap(sc), clientB)
 				assert.True(t, clientA.closeCalled)
 			},
 			afterClose: func() {
@@ -310,6 +312,85 @@ func TestManagerGet(t *testing.T) {
 	}
 }
 
+func TestManagerGetFromStoreAllowedEndpoints(t *testing.T) {
+	require.NoError(t, esv1beta1.SetAllowedProviderEndpoints([]string{"*.internal.example.com"}))
+	t.Cleanup(func() {
+		_ = esv1beta1.SetAllowedProviderEndpoints(nil)
+	})
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: "foo"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Webhook: &esv1beta1.WebhookProvider{URL: "https://attacker.example.org/{{ .remoteRef.key }}"},
+			},
+		},
+	}
+	mgr := NewManager(fakeclient.NewClientBuilder().Build(), "", false)
+	defer mgr.Close(context.Background())
+	_, err := mgr.GetFromStore(context.Background(), store, "foo")
+	assert.ErrorContains(t, err, "is not allowed")
+
+	store.Spec.Provider.Webhook.URL = "https://secrets.internal.example.com/{{ .remoteRef.key }}"
+	_, err = mgr.GetFromStore(context.Background(), store, "foo")
+	assert.NoError(t, err)
+}
+
+func TestManagerGetUnavailableStore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+	store := &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "chef", Namespace: "foo"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Chef: &esv1beta1.ChefProvider{}},
+		},
+	}
+	kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build()
+	storeRef := esv1beta1.SecretStoreRef{Name: "chef", Kind: esv1beta1.SecretStoreKind}
+	ref := esv1beta1.ExternalSecretDataRemoteRef{Key: "databag/item"}
+
+	mgr := NewManager(kube, "", true)
+	_, err := mgr.Get(context.Background(), storeRef, "foo", nil)
+	assert.EqualError(t, err, "the desired SecretStore chef is not ready")
+
+	// with a disk cache the cached values of the store are served.
+	cache, err := NewDiskCache(t.TempDir(), make([]byte, 32), 0)
+	require.NoError(t, err)
+	_, err = cache.Client(&countingClient{}, store, "foo").GetSecret(context.Background(), ref)
+	require.NoError(t, err)
+
+	mgr = NewManager(kube, "", true).WithDiskCache(cache)
+	c, err := mgr.Get(context.Background(), storeRef, "foo", nil)
+	require.NoError(t, err)
+	secret, err := c.GetSecret(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("databag/item"), secret)
+	_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "databag/other"})
+	assert.EqualError(t, err, "the desired SecretStore chef is not ready")
+}
+
+func TestOverrideRetrySettings(t *testing.T) {
+	store := &esv1beta1.SecretStore{
+		Spec: esv1beta1.SecretStoreSpec{
+			RetrySettings: &esv1beta1.SecretStoreRetrySettings{
+				MaxRetries:    ptr.To[int32](3),
+				RetryInterval: ptr.To("10s"),
+			},
+		},
+	}
+	assert.Same(t, store, overrideRetrySettings(store, nil))
+
+	got := overrideRetrySettings(store, &esv1beta1.SecretStoreRetrySettings{RetryInterval: ptr.To("100ms")})
+	assert.Equal(t, &esv1beta1.SecretStoreRetrySettings{
+		MaxRetries:    ptr.To[int32](3),
+		RetryInterval: ptr.To("100ms"),
+	}, got.GetSpec().RetrySettings)
+	assert.Equal(t, "10s", *store.Spec.RetrySettings.RetryInterval)
+
+	got = overrideRetrySettings(&esv1beta1.ClusterSecretStore{}, &esv1beta1.SecretStoreRetrySettings{MaxRetries: ptr.To[int32](10)})
+	assert.Equal(t, &esv1beta1.SecretStoreRetrySettings{MaxRetries: ptr.To[int32](10)}, got.GetSpec().RetrySettings)
+}
+
 type WrapProvider struct {
 	newClientFunc func(
 		context.Context,
@@ -28,6 +28,7 @@ import (
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/cssmetrics"
+	providermetrics "github.com/external-secrets/external-secrets/pkg/metrics"
 	// Loading registered providers.
 	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
 )
@@ -55,6 +56,7 @@ func (r *ClusterStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	err := r.Get(ctx, req.NamespacedName, &css)
 	if apierrors.IsNotFound(err) {
 		cssmetrics.RemoveMetrics(req.Namespace, req.Name)
+		providermetrics.RemoveStoreMetrics(esapi.ClusterSecretStoreKind, req.Namespace, req.Name)
 		return ctrl.Result{}, nil
 	} else if err != nil {
 		log.Error(err, "unable to get ClusterSecretStore")
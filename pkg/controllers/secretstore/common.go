@@ -17,6 +17,7 @@ package secretstore
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -24,6 +25,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/metrics"
@@ -61,6 +63,13 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 		}
 	}()
 
+	// the conditions set below describe the current generation,
+	// also if the validation fails.
+	status := ss.GetStatus()
+	status.ObservedGeneration = ss.GetGeneration()
+	status.Provider, _ = esapi.GetProviderName(ss)
+	ss.SetStatus(status)
+
 	// validateStore modifies the store conditions
 	// we have to patch the status
 	log.V(1).Info("validating")
@@ -73,16 +82,18 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	capStatus := esapi.SecretStoreStatus{
-		Capabilities: storeProvider.Capabilities(),
-		Conditions:   ss.GetStatus().Conditions,
-	}
+	capStatus := ss.GetStatus()
+	capStatus.Capabilities = storeProvider.Capabilities()
 	ss.SetStatus(capStatus)
 
 	recorder.Event(ss, v1.EventTypeNormal, esapi.ReasonStoreValid, msgStoreValidated)
 	cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionTrue, esapi.ReasonStoreValid, msgStoreValidated)
 	SetExternalSecretCondition(ss, *cond, gaugeVecGetter)
 
+	// the webhook already rejects invalid stores, only the warnings are needed
+	warnings, _ := storeProvider.ValidateStore(ss)
+	setDeprecatedCondition(ss, warnings, gaugeVecGetter, recorder)
+
 	return ctrl.Result{
 		RequeueAfter: requeueInterval,
 	}, err
@@ -112,6 +123,22 @@ func validateStore(ctx context.Context, namespace, controllerClass string, store
 	return nil
 }
 
+// setDeprecatedCondition reports the admission warnings of the provider about
+// deprecated fields with the Deprecated condition. The condition is removed
+// once the store does not use deprecated fields anymore.
+func setDeprecatedCondition(store esapi.GenericStore, warnings admission.Warnings, gaugeVecGetter metrics.GaugeVevGetter, recorder record.EventRecorder) {
+	if len(warnings) == 0 {
+		status := store.GetStatus()
+		status.Conditions = filterOutCondition(status.Conditions, esapi.SecretStoreDeprecated)
+		store.SetStatus(status)
+		return
+	}
+	msg := strings.Join(warnings, "; ")
+	recorder.Event(store, v1.EventTypeWarning, esapi.ReasonDeprecated, msg)
+	cond := NewSecretStoreCondition(esapi.SecretStoreDeprecated, v1.ConditionTrue, esapi.ReasonDeprecated, msg)
+	SetExternalSecretCondition(store, *cond, gaugeVecGetter)
+}
+
 // ShouldProcessStore returns true if the store should be processed.
 func ShouldProcessStore(store esapi.GenericStore, class string) bool {
 	if store == nil || store.GetSpec().Controller == "" || store.GetSpec().Controller == class {
@@ -117,6 +117,7 @@ var _ = Describe("SecretStore reconcile", func() {
 				return ss.GetStatus().Conditions[0].Reason == esapi.ReasonStoreValid &&
 					ss.GetStatus().Conditions[0].Type == esapi.SecretStoreReady &&
 					ss.GetStatus().Conditions[0].Status == corev1.ConditionTrue &&
+					ss.GetStatus().ObservedGeneration == ss.GetGeneration() &&
 					hasEvent(tc.store.GetTypeMeta().Kind, ss.GetName(), esapi.ReasonStoreValid)
 			}).
 				WithTimeout(time.Second * 10).
@@ -148,7 +149,7 @@ var _ = Describe("SecretStore reconcile", func() {
 					return false
 				}
 
-				return true
+				return ss.GetStatus().Provider == "fake"
 			}).
 				WithTimeout(time.Second * 10).
 				WithPolling(time.Second).
@@ -0,0 +1,309 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	errDiskCacheKey = "invalid provider cache key: %w"
+	errDiskCacheDir = "could not create provider cache directory: %w"
+)
+
+// DiskCache persists the values read from the providers to a directory,
+// encrypted with AES-GCM, and serves them while the provider of a store can
+// not be reached, e.g. when the controller restarts during an outage of the
+// provider. Values the provider reports as deleted are removed from it.
+type DiskCache struct {
+	dir    string
+	aead   cipher.AEAD
+	maxAge time.Duration
+	now    func() time.Time
+	log    logr.Logger
+}
+
+// diskCacheKey identifies a value. Unlike batchKey it does not contain the
+// generation of the store, so the values survive changes of the store.
+type diskCacheKey struct {
+	Kind           string                                `json:"kind"`
+	StoreNamespace string                                `json:"storeNamespace"`
+	Store          string                                `json:"store"`
+	Namespace      string                                `json:"namespace"`
+	Op             string                                `json:"op"`
+	Ref            esv1beta1.ExternalSecretDataRemoteRef `json:"ref"`
+}
+
+type diskCacheEntry struct {
+	StoredAt  time.Time         `json:"storedAt"`
+	Secret    []byte            `json:"secret,omitempty"`
+	SecretMap map[string][]byte `json:"secretMap,omitempty"`
+}
+
+// NewDiskCache returns a DiskCache that stores the values in dir, encrypted
+// with the AES key of 16, 24 or 32 bytes. Values older than maxAge are not
+// served, zero serves them regardless of their age.
+func NewDiskCache(dir string, key []byte, maxAge time.Duration) (*DiskCache, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf(errDiskCacheKey, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf(errDiskCacheKey, err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf(errDiskCacheDir, err)
+	}
+	return &DiskCache{
+		dir:    dir,
+		aead:   aead,
+		maxAge: maxAge,
+		now:    time.Now,
+		log:    ctrl.Log.WithName("providercache"),
+	}, nil
+}
+
+// ReadDiskCacheKey reads the base64 encoded key of a DiskCache from the file,
+// e.g. a key of a mounted Secret.
+func ReadDiskCacheKey(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, fmt.Errorf(errDiskCacheKey, err)
+	}
+	return key, nil
+}
+
+// Client wraps the client of the store, so that the results of its
+// GetSecret and GetSecretMap calls are stored, and served if the calls fail.
+func (d *DiskCache) Client(c esv1beta1.SecretsClient, store esv1beta1.GenericStore, namespace string) esv1beta1.SecretsClient {
+	provider, _ := esv1beta1.GetProviderName(store)
+	return &diskCachedClient{
+		SecretsClient: c,
+		cache:         d,
+		provider:      provider,
+		key: diskCacheKey{
+			Kind:           store.GetKind(),
+			StoreNamespace: store.GetNamespace(),
+			Store:          store.GetName(),
+			Namespace:      namespace,
+		},
+	}
+}
+
+// path returns the file of the value, its name is also authenticated with
+// the value so files can not be swapped.
+func (d *DiskCache) path(key diskCacheKey) (string, error) {
+	b, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])), nil
+}
+
+func (d *DiskCache) store(key diskCacheKey, entry *diskCacheEntry) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	entry.StoredAt = d.now()
+	plain, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, d.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	sealed := d.aead.Seal(nonce, nonce, plain, []byte(filepath.Base(path)))
+
+	// write to a temporary file first, so a crash does not leave a
+	// truncated value behind.
+	f, err := os.CreateTemp(d.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(sealed); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// load returns the value of the key, or nil if it is not cached, expired or
+// can not be decrypted, e.g. because the key was rotated.
+func (d *DiskCache) load(key diskCacheKey) *diskCacheEntry {
+	path, err := d.path(key)
+	if err != nil {
+		return nil
+	}
+	sealed, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			d.log.Error(err, "could not read cached value")
+		}
+		return nil
+	}
+	nonceSize := d.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil
+	}
+	plain, err := d.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(filepath.Base(path)))
+	if err != nil {
+		d.log.V(1).Info("could not decrypt cached value", "error", err.Error())
+		return nil
+	}
+	var entry diskCacheEntry
+	if err := json.Unmarshal(plain, &entry); err != nil {
+		return nil
+	}
+	if d.maxAge > 0 && d.now().Sub(entry.StoredAt) > d.maxAge {
+		return nil
+	}
+	return &entry
+}
+
+func (d *DiskCache) remove(key diskCacheKey) {
+	path, err := d.path(key)
+	if err != nil {
+		return
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		d.log.Error(err, "could not remove cached value")
+	}
+}
+
+type diskCachedClient struct {
+	esv1beta1.SecretsClient
+	cache    *DiskCache
+	provider string
+	key      diskCacheKey
+}
+
+func (c *diskCachedClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	key := c.key
+	key.Op = batchOpGetSecret
+	key.Ref = ref
+	secret, err := c.SecretsClient.GetSecret(ctx, ref)
+	entry := c.update(key, err, func() *diskCacheEntry {
+		return &diskCacheEntry{Secret: secret}
+	})
+	if entry == nil {
+		return secret, err
+	}
+	return entry.Secret, nil
+}
+
+func (c *diskCachedClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	key := c.key
+	key.Op = batchOpGetSecretMap
+	key.Ref = ref
+	secretMap, err := c.SecretsClient.GetSecretMap(ctx, ref)
+	entry := c.update(key, err, func() *diskCacheEntry {
+		return &diskCacheEntry{SecretMap: secretMap}
+	})
+	if entry == nil {
+		return secretMap, err
+	}
+	return entry.SecretMap, nil
+}
+
+// update stores the result of a successful call and removes the value of a
+// deleted secret. It returns the cached value if the call failed otherwise.
+func (c *diskCachedClient) update(key diskCacheKey, err error, entry func() *diskCacheEntry) *diskCacheEntry {
+	if err == nil {
+		if err := c.cache.store(key, entry()); err != nil {
+			c.cache.log.Error(err, "could not cache value", "kind", key.Kind, "store", key.Store, "namespace", key.StoreNamespace)
+		}
+		return nil
+	}
+	if errors.Is(err, esv1beta1.NoSecretErr) {
+		c.cache.remove(key)
+		return nil
+	}
+	cached := c.cache.load(key)
+	if cached == nil {
+		return nil
+	}
+	c.cache.log.Info("serving cached value, the provider failed",
+		"kind", key.Kind, "store", key.Store, "namespace", key.StoreNamespace,
+		"key", key.Ref.Key, "age", c.cache.now().Sub(cached.StoredAt).Round(time.Second).String(), "error", err.Error())
+	metrics.ObserveCacheFallback(c.provider, key.Kind, key.StoreNamespace, key.Store)
+	return cached
+}
+
+// unavailableClient is the client of a store that can not be used, its
+// calls fail with the reason.
+type unavailableClient struct {
+	err error
+}
+
+func (c *unavailableClient) GetSecret(context.Context, esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	return nil, c.err
+}
+
+func (c *unavailableClient) GetSecretMap(context.Context, esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	return nil, c.err
+}
+
+func (c *unavailableClient) GetAllSecrets(context.Context, esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, c.err
+}
+
+func (c *unavailableClient) PushSecret(context.Context, *corev1.Secret, esv1beta1.PushSecretData) error {
+	return c.err
+}
+
+func (c *unavailableClient) DeleteSecret(context.Context, esv1beta1.PushSecretRemoteRef) error {
+	return c.err
+}
+
+func (c *unavailableClient) Validate() (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultError, c.err
+}
+
+func (c *unavailableClient) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestDiskCache(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	key := bytes.Repeat([]byte{1}, 32)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newCache := func(key []byte) *DiskCache {
+		c, err := NewDiskCache(dir, key, time.Hour)
+		require.NoError(t, err)
+		c.now = func() time.Time { return now }
+		return c
+	}
+	cache := newCache(key)
+
+	store := &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "chef", Namespace: "foo"},
+	}
+	provider := &countingClient{}
+	ref := esv1beta1.ExternalSecretDataRemoteRef{Key: "databag/item"}
+
+	_, err := cache.Client(provider, store, "foo").GetSecret(ctx, ref)
+	require.NoError(t, err)
+	_, err = cache.Client(provider, store, "foo").GetSecretMap(ctx, ref)
+	require.NoError(t, err)
+
+	// the values are encrypted.
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	for _, f := range files {
+		b, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), "databag/item")
+	}
+
+	// a restarted controller serves the values while the provider fails.
+	cache = newCache(key)
+	provider.err = errors.New("connection refused")
+	secret, err := cache.Client(provider, store, "foo").GetSecret(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("databag/item"), secret)
+	secretMap, err := cache.Client(provider, store, "foo").GetSecretMap(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key": []byte("databag/item")}, secretMap)
+
+	// the values of other namespaces are not served.
+	_, err = cache.Client(provider, store, "bar").GetSecret(ctx, ref)
+	assert.EqualError(t, err, "connection refused")
+
+	// expired values are not served.
+	now = now.Add(2 * time.Hour)
+	_, err = cache.Client(provider, store, "foo").GetSecret(ctx, ref)
+	assert.Error(t, err)
+	now = now.Add(-2 * time.Hour)
+
+	// values encrypted with another key are not served.
+	_, err = newCache(bytes.Repeat([]byte{2}, 32)).Client(provider, store, "foo").GetSecret(ctx, ref)
+	assert.Error(t, err)
+
+	// deleted secrets are removed.
+	provider.err = esv1beta1.NoSecretErr
+	_, err = cache.Client(provider, store, "foo").GetSecret(ctx, ref)
+	assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+	provider.err = errors.New("connection refused")
+	_, err = cache.Client(provider, store, "foo").GetSecret(ctx, ref)
+	assert.Error(t, err)
+
+	// stores that can not be used are served from the cache.
+	client := cache.Client(&unavailableClient{err: errors.New("store not ready")}, store, "foo")
+	secretMap, err = client.GetSecretMap(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key": []byte("databag/item")}, secretMap)
+	_, err = client.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{})
+	assert.EqualError(t, err, "store not ready")
+}
+
+func TestNewDiskCacheKey(t *testing.T) {
+	_, err := NewDiskCache(t.TempDir(), []byte("too short"), 0)
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte("AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=\n"), 0o600))
+	key, err := ReadDiskCacheKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{1}, 32), key)
+}
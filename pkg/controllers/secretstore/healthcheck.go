@@ -0,0 +1,232 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	providermetrics "github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	// defaultHealthCheckInterval is used if spec.healthCheck.interval is not set.
+	defaultHealthCheckInterval = 5 * time.Minute
+	// healthCheckSamples is the number of recent checks of a store the
+	// latency percentile is computed from.
+	healthCheckSamples = 20
+
+	healthCheckStepAuth     = "auth"
+	healthCheckStepValidate = "validate"
+	healthCheckStepCanary   = "canary"
+)
+
+// HealthCheckReconciler runs the health checks of the SecretStores or
+// ClusterSecretStores that configure spec.healthCheck. It only writes
+// status.healthCheck, the Ready condition is owned by the store reconcilers.
+type HealthCheckReconciler struct {
+	client.Client
+	Log             logr.Logger
+	ControllerClass string
+	// Kind is the kind of the reconciled stores, SecretStore or
+	// ClusterSecretStore.
+	Kind string
+
+	mu        sync.Mutex
+	latencies map[types.NamespacedName][]time.Duration
+}
+
+func (r *HealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues(strings.ToLower(r.Kind), req.NamespacedName)
+
+	store := r.newStore()
+	err := r.Get(ctx, req.NamespacedName, store)
+	if apierrors.IsNotFound(err) {
+		r.forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		log.Error(err, "unable to get store")
+		return ctrl.Result{}, err
+	}
+
+	spec := store.GetSpec().HealthCheck
+	if spec == nil || !ShouldProcessStore(store, r.ControllerClass) {
+		r.forget(req.NamespacedName)
+		status := store.GetStatus()
+		if status.HealthCheck == nil {
+			return ctrl.Result{}, nil
+		}
+		// the result of the last check would be stale forever.
+		p := client.MergeFrom(store.Copy())
+		status.HealthCheck = nil
+		store.SetStatus(status)
+		return ctrl.Result{}, r.Status().Patch(ctx, store, p)
+	}
+
+	interval := defaultHealthCheckInterval
+	if spec.Interval != nil && spec.Interval.Duration > 0 {
+		interval = spec.Interval.Duration
+	}
+
+	p := client.MergeFrom(store.Copy())
+	result := r.check(ctx, store, req.Namespace)
+	status := store.GetStatus()
+	if !result.Healthy {
+		result.ConsecutiveFailures = 1
+		if status.HealthCheck != nil {
+			result.ConsecutiveFailures += status.HealthCheck.ConsecutiveFailures
+		}
+	}
+	result.LatencyP95 = &metav1.Duration{Duration: r.observe(req.NamespacedName, result.Latency.Duration)}
+	status.HealthCheck = result
+	store.SetStatus(status)
+	if err := r.Status().Patch(ctx, store, p); err != nil {
+		log.Error(err, errPatchStatus)
+		return ctrl.Result{}, err
+	}
+	if !result.Healthy {
+		log.Info("health check failed", "message", result.Message, "consecutiveFailures", result.ConsecutiveFailures)
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// check authenticates against the provider of the store, validates the store
+// and reads the canary. It stops at the first step that fails.
+func (r *HealthCheckReconciler) check(ctx context.Context, store esapi.GenericStore, namespace string) *esapi.SecretStoreHealthCheckStatus {
+	provider, _ := esapi.GetProviderName(store)
+	start := time.Now()
+	result := &esapi.SecretStoreHealthCheckStatus{
+		LastCheckTime: &metav1.Time{Time: start},
+	}
+	done := func(err error) *esapi.SecretStoreHealthCheckStatus {
+		result.Latency = &metav1.Duration{Duration: time.Since(start)}
+		result.Healthy = err == nil
+		if err != nil {
+			result.Message = err.Error()
+		}
+		providermetrics.SetHealthy(provider, r.Kind, store.GetNamespace(), store.GetName(), result.Healthy)
+		return result
+	}
+	step := func(name string, f func() error) error {
+		stepStart := time.Now()
+		err := f()
+		providermetrics.ObserveHealthCheckStep(provider, r.Kind, store.GetNamespace(), store.GetName(), name, time.Since(stepStart), err)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	}
+
+	mgr := NewManager(r.Client, r.ControllerClass, false)
+	defer mgr.Close(ctx)
+	var cl esapi.SecretsClient
+	err := step(healthCheckStepAuth, func() error {
+		var err error
+		cl, err = mgr.GetFromStore(ctx, store, namespace)
+		return err
+	})
+	if err != nil {
+		return done(err)
+	}
+	err = step(healthCheckStepValidate, func() error {
+		validationResult, err := cl.Validate()
+		if err != nil && validationResult != esapi.ValidationResultUnknown {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return done(err)
+	}
+	canary := store.GetSpec().HealthCheck.CanaryRef
+	if canary == nil {
+		return done(nil)
+	}
+	return done(step(healthCheckStepCanary, func() error {
+		_, err := cl.GetSecret(ctx, *canary)
+		if err != nil {
+			return fmt.Errorf("%q: %w", canary.Key, err)
+		}
+		return nil
+	}))
+}
+
+// observe adds the latency of a check of the store to its recent checks and
+// returns their 95th percentile.
+func (r *HealthCheckReconciler) observe(name types.NamespacedName, latency time.Duration) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.latencies == nil {
+		r.latencies = make(map[types.NamespacedName][]time.Duration)
+	}
+	samples := append(r.latencies[name], latency)
+	if len(samples) > healthCheckSamples {
+		samples = samples[len(samples)-healthCheckSamples:]
+	}
+	r.latencies[name] = samples
+	return percentile(samples, 0.95)
+}
+
+// forget drops the latencies and the metrics of a store that is not checked
+// anymore.
+func (r *HealthCheckReconciler) forget(name types.NamespacedName) {
+	r.mu.Lock()
+	delete(r.latencies, name)
+	r.mu.Unlock()
+	providermetrics.RemoveHealthCheckMetrics(r.Kind, name.Namespace, name.Name)
+}
+
+func (r *HealthCheckReconciler) newStore() esapi.GenericStore {
+	if r.Kind == esapi.ClusterSecretStoreKind {
+		return &esapi.ClusterSecretStore{}
+	}
+	return &esapi.SecretStore{}
+}
+
+// percentile returns the nearest-rank percentile p of the samples.
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[int(math.Ceil(p*float64(len(sorted))))-1]
+}
+
+// SetupWithManager returns a new controller builder that will be started by the provided Manager.
+func (r *HealthCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the checks write the status, only spec changes trigger a new check
+	// before the interval elapsed.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(r.Kind)+"-healthcheck").
+		For(r.newStore(), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
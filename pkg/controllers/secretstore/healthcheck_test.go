@@ -0,0 +1,131 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+type canaryClient struct {
+	MockFakeClient
+	err error
+}
+
+func (c *canaryClient) GetSecret(_ context.Context, _ esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	return []byte("ok"), c.err
+}
+
+func TestHealthCheckReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+
+	provider := &WrapProvider{}
+	esv1beta1.ForceRegister(provider, &esv1beta1.SecretStoreProvider{
+		AWS: &esv1beta1.AWSProvider{},
+	})
+	canary := &canaryClient{}
+	var authErr error
+	provider.newClientFunc = func(context.Context, esv1beta1.GenericStore, client.Client, string) (esv1beta1.SecretsClient, error) {
+		if authErr != nil {
+			return nil, authErr
+		}
+		return canary, nil
+	}
+
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "chef", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{AWS: &esv1beta1.AWSProvider{}},
+			HealthCheck: &esv1beta1.SecretStoreHealthCheck{
+				Interval:  &metav1.Duration{Duration: time.Minute},
+				CanaryRef: &esv1beta1.ExternalSecretDataRemoteRef{Key: "canary/item"},
+			},
+		},
+	}
+	kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(store).WithStatusSubresource(store).Build()
+	r := &HealthCheckReconciler{Client: kube, Log: logr.Discard(), Kind: esv1beta1.SecretStoreKind}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "chef", Namespace: "default"}}
+	reconcile := func() *esv1beta1.SecretStoreHealthCheckStatus {
+		res, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		var got esv1beta1.SecretStore
+		require.NoError(t, kube.Get(context.Background(), req.NamespacedName, &got))
+		if got.Spec.HealthCheck != nil {
+			assert.Equal(t, time.Minute, res.RequeueAfter)
+		}
+		return got.Status.HealthCheck
+	}
+
+	status := reconcile()
+	require.NotNil(t, status)
+	assert.True(t, status.Healthy)
+	assert.Empty(t, status.Message)
+	assert.Zero(t, status.ConsecutiveFailures)
+	assert.NotNil(t, status.LastCheckTime)
+	assert.NotNil(t, status.LatencyP95)
+
+	canary.err = errors.New("item not found")
+	status = reconcile()
+	assert.False(t, status.Healthy)
+	assert.Equal(t, `canary: "canary/item": item not found`, status.Message)
+	assert.Equal(t, int32(1), status.ConsecutiveFailures)
+
+	authErr = errors.New("invalid key")
+	status = reconcile()
+	assert.False(t, status.Healthy)
+	assert.Equal(t, "auth: invalid key", status.Message)
+	assert.Equal(t, int32(2), status.ConsecutiveFailures)
+
+	authErr, canary.err = nil, nil
+	status = reconcile()
+	assert.True(t, status.Healthy)
+	assert.Zero(t, status.ConsecutiveFailures)
+
+	var current esv1beta1.SecretStore
+	require.NoError(t, kube.Get(context.Background(), req.NamespacedName, &current))
+	current.Spec.HealthCheck = nil
+	require.NoError(t, kube.Update(context.Background(), &current))
+	assert.Nil(t, reconcile())
+	assert.NotContains(t, r.latencies, req.NamespacedName)
+}
+
+func TestPercentile(t *testing.T) {
+	samples := make([]time.Duration, 0, 20)
+	for i := 20; i > 0; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, 19*time.Millisecond, percentile(samples, 0.95))
+	assert.Equal(t, 10*time.Millisecond, percentile(samples, 0.5))
+	assert.Equal(t, 3*time.Millisecond, percentile([]time.Duration{3 * time.Millisecond}, 0.95))
+	assert.Zero(t, percentile(nil, 0.95))
+	assert.Equal(t, 20*time.Millisecond, samples[0], "the samples must not be sorted in place")
+}
@@ -0,0 +1,215 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package csi implements a provider for the Secrets Store CSI driver that
+// fetches the contents of volumes from SecretStores and ClusterSecretStores.
+// Pods can mount secrets as files this way without a Kubernetes Secret
+// being created.
+package csi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/pkg/csi/api/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	// ProviderName is the name of the provider in SecretProviderClasses.
+	ProviderName = "external-secrets"
+
+	// parameters of the SecretProviderClass.
+	paramStoreName = "secretStoreName"
+	paramStoreKind = "secretStoreKind"
+	paramObjects   = "objects"
+
+	// attributes added by the driver.
+	attrPodName      = "csi.storage.k8s.io/pod.name"
+	attrPodNamespace = "csi.storage.k8s.io/pod.namespace"
+
+	protocolVersion = "v1alpha1"
+
+	errParseAttributes = "unable to parse attributes: %v"
+	errParsePermission = "unable to parse permission: %v"
+	errParseObjects    = "unable to parse objects: %v"
+	errMissingParam    = "missing parameter %q"
+	errMissingAttr     = "missing attribute %q, is podInfoOnMount enabled for the driver?"
+	errInvalidKind     = "invalid secretStoreKind %q"
+	errInvalidPath     = "invalid path %q of object %d"
+	errDuplicatePath   = "duplicate path %q of object %d"
+	errMissingKey      = "missing remoteRef.key of object %d"
+	errGetSecret       = "could not get secret %q of object %d: %v"
+	errDecode          = "could not decode secret %q of object %d: %v"
+)
+
+// Object is a file of a volume.
+type Object struct {
+	// Path of the file relative to the mount path of the volume.
+	Path string `json:"path"`
+	// RemoteRef is the secret to fetch from the store.
+	RemoteRef esv1beta1.ExternalSecretDataRemoteRef `json:"remoteRef"`
+}
+
+// Server implements the CSIDriverProvider service.
+type Server struct {
+	v1alpha1.UnimplementedCSIDriverProviderServer
+	client          client.Client
+	controllerClass string
+	enableFloodgate bool
+}
+
+// NewServer returns a provider server that resolves stores with the given
+// client. Like the ExternalSecret controller it only uses stores of its
+// controller class and, if floodgate is enabled, stores that are ready.
+func NewServer(c client.Client, controllerClass string, enableFloodgate bool) *Server {
+	return &Server{
+		client:          c,
+		controllerClass: controllerClass,
+		enableFloodgate: enableFloodgate,
+	}
+}
+
+// Version returns the protocol version and the version of ESO.
+func (s *Server) Version(_ context.Context, _ *v1alpha1.VersionRequest) (*v1alpha1.VersionResponse, error) {
+	version := "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		version = info.Main.Version
+	}
+	return &v1alpha1.VersionResponse{
+		Version:        protocolVersion,
+		RuntimeName:    ProviderName,
+		RuntimeVersion: version,
+	}, nil
+}
+
+// Mount fetches the objects of the volume from the store in the namespace
+// of the pod. The driver writes the returned files to the volume.
+func (s *Server) Mount(ctx context.Context, req *v1alpha1.MountRequest) (*v1alpha1.MountResponse, error) {
+	var attrs map[string]string
+	if err := json.Unmarshal([]byte(req.GetAttributes()), &attrs); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, errParseAttributes, err)
+	}
+	var mode int32
+	if err := json.Unmarshal([]byte(req.GetPermission()), &mode); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, errParsePermission, err)
+	}
+	namespace := attrs[attrPodNamespace]
+	if namespace == "" {
+		return nil, status.Errorf(codes.InvalidArgument, errMissingAttr, attrPodNamespace)
+	}
+	storeRef, err := parseStoreRef(attrs)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	objects, err := parseObjects(attrs[paramObjects])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log := ctrl.Log.WithName("csi").WithValues("pod", namespace+"/"+attrs[attrPodName], "store", storeRef.Name)
+	mgr := secretstore.NewManager(s.client, s.controllerClass, s.enableFloodgate)
+	defer func() {
+		if err := mgr.Close(ctx); err != nil {
+			log.Error(err, "could not close clients")
+		}
+	}()
+	secretClient, err := mgr.Get(ctx, storeRef, namespace, nil)
+	if err != nil {
+		log.Error(err, "could not get store client")
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	resp := &v1alpha1.MountResponse{}
+	for i, obj := range objects {
+		data, err := secretClient.GetSecret(ctx, obj.RemoteRef)
+		if err != nil {
+			log.Error(err, "could not get secret", "key", obj.RemoteRef.Key)
+			return nil, status.Errorf(codes.Unavailable, errGetSecret, obj.RemoteRef.Key, i, err)
+		}
+		data, err = utils.Decode(obj.RemoteRef.DecodingStrategy, data)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, errDecode, obj.RemoteRef.Key, i, err)
+		}
+		resp.Files = append(resp.Files, &v1alpha1.File{
+			Path:     obj.Path,
+			Mode:     mode,
+			Contents: data,
+		})
+		// the driver only reports the versions, a hash of the contents
+		// makes rotations visible in the SecretProviderClassPodStatus.
+		resp.ObjectVersion = append(resp.ObjectVersion, &v1alpha1.ObjectVersion{
+			Id:      obj.Path,
+			Version: utils.ObjectHash(data),
+		})
+	}
+	log.V(1).Info("mounted objects", "count", len(objects))
+	return resp, nil
+}
+
+func parseStoreRef(attrs map[string]string) (esv1beta1.SecretStoreRef, error) {
+	ref := esv1beta1.SecretStoreRef{
+		Name: attrs[paramStoreName],
+		Kind: attrs[paramStoreKind],
+	}
+	if ref.Name == "" {
+		return ref, fmt.Errorf(errMissingParam, paramStoreName)
+	}
+	switch ref.Kind {
+	case "":
+		ref.Kind = esv1beta1.SecretStoreKind
+	case esv1beta1.SecretStoreKind, esv1beta1.ClusterSecretStoreKind:
+	default:
+		return ref, fmt.Errorf(errInvalidKind, ref.Kind)
+	}
+	return ref, nil
+}
+
+func parseObjects(in string) ([]Object, error) {
+	if in == "" {
+		return nil, fmt.Errorf(errMissingParam, paramObjects)
+	}
+	var objects []Object
+	if err := yaml.UnmarshalStrict([]byte(in), &objects); err != nil {
+		return nil, fmt.Errorf(errParseObjects, err)
+	}
+	paths := make(map[string]struct{}, len(objects))
+	for i, obj := range objects {
+		// the driver rejects paths outside of the volume as well, but the
+		// error is easier to find if it names the object.
+		p := filepath.Clean(obj.Path)
+		if obj.Path == "" || filepath.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") {
+			return nil, fmt.Errorf(errInvalidPath, obj.Path, i)
+		}
+		if _, ok := paths[p]; ok {
+			return nil, fmt.Errorf(errDuplicatePath, obj.Path, i)
+		}
+		paths[p] = struct{}{}
+		if obj.RemoteRef.Key == "" {
+			return nil, fmt.Errorf(errMissingKey, i)
+		}
+		objects[i].Path = p
+	}
+	return objects, nil
+}
@@ -0,0 +1,305 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactory
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+type Generator struct{}
+
+const (
+	defaultScope     = "applied-permissions/user"
+	defaultExpiresIn = time.Hour
+	requestTimeout   = 30 * time.Second
+
+	errNoSpec             = "no config spec provided"
+	errParseSpec          = "unable to parse spec: %w"
+	errNoURL              = "no url in spec"
+	errInvalidURL         = "url must be an absolute http or https url"
+	errInvalidCABundle    = "caBundle does not contain a PEM encoded certificate"
+	errInvalidAuth        = "exactly one of auth.accessTokenSecretRef or auth.basic must be set"
+	errNoBasicAuth        = "auth.basic is required without token"
+	errNoTokenUsername    = "no token.username in spec"
+	errInvalidExpiresIn   = "token.expiresIn must be positive"
+	errResolveSecret      = "unable to resolve secret: %w"
+	errCreateToken        = "unable to create access token: %w"
+	errEncryptedPassword  = "unable to get encrypted password: %w"
+	errDecodeResponse     = "unable to decode response: %w"
+	errEmptyAccessToken   = "response does not contain an access token"
+	errEmptyEncryptedPass = "response does not contain an encrypted password"
+)
+
+type tokenRequest struct {
+	Username    string `json:"username"`
+	Scope       string `json:"scope"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Refreshable bool   `json:"refreshable"`
+	Audience    string `json:"audience,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type tokenResponse struct {
+	TokenID     string `json:"token_id"`
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type dockerConfig struct {
+	Auths map[string]dockerAuth `json:"auths"`
+}
+
+type dockerAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+// apiClient performs the requests against the JFrog Platform.
+type apiClient struct {
+	url string
+	// setAuth sets the Authorization header of a request.
+	setAuth func(req *http.Request)
+	client  *http.Client
+}
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	spec := &res.Spec
+	if err := validateSpec(spec); err != nil {
+		return nil, err
+	}
+	api, err := newAPIClient(ctx, kube, spec, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		username, password string
+		expiresAt          time.Time
+	)
+	if spec.Token != nil {
+		expiresIn := defaultExpiresIn
+		if spec.Token.ExpiresIn != nil {
+			expiresIn = spec.Token.ExpiresIn.Duration
+		}
+		scope := spec.Token.Scope
+		if scope == "" {
+			scope = defaultScope
+		}
+		// the expiry is taken before the request, so it is never later than the actual one
+		expiresAt = time.Now().Add(expiresIn)
+		username = spec.Token.Username
+		password, err = api.createToken(ctx, &tokenRequest{
+			Username:    spec.Token.Username,
+			Scope:       scope,
+			ExpiresIn:   int64(expiresIn.Seconds()),
+			Audience:    spec.Token.Audience,
+			Description: spec.Token.Description,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(errCreateToken, err)
+		}
+	} else {
+		username = spec.Auth.Basic.Username
+		password, err = api.encryptedPassword(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(errEncryptedPassword, err)
+		}
+	}
+
+	registry := spec.Registry
+	if registry == "" {
+		u, _ := url.Parse(spec.URL)
+		registry = u.Host
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	config, err := json.Marshal(dockerConfig{Auths: map[string]dockerAuth{
+		registry: {Username: username, Password: password, Auth: auth},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	secretMap := map[string][]byte{
+		"username":          []byte(username),
+		"password":          []byte(password),
+		"registry":          []byte(registry),
+		".dockerconfigjson": config,
+	}
+	if !expiresAt.IsZero() {
+		secretMap["expires_at"] = []byte(strconv.FormatInt(expiresAt.Unix(), 10))
+	}
+	return secretMap, nil
+}
+
+func validateSpec(spec *genv1alpha1.ArtifactoryAccessTokenSpec) error {
+	if spec.URL == "" {
+		return fmt.Errorf(errNoURL)
+	}
+	u, err := url.ParseRequestURI(spec.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf(errInvalidURL)
+	}
+	if len(spec.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(spec.CABundle) {
+		return fmt.Errorf(errInvalidCABundle)
+	}
+	if (spec.Auth.AccessTokenSecretRef == nil) == (spec.Auth.Basic == nil) {
+		return fmt.Errorf(errInvalidAuth)
+	}
+	if spec.Token == nil {
+		if spec.Auth.Basic == nil {
+			return fmt.Errorf(errNoBasicAuth)
+		}
+		return nil
+	}
+	if spec.Token.Username == "" {
+		return fmt.Errorf(errNoTokenUsername)
+	}
+	if spec.Token.ExpiresIn != nil && spec.Token.ExpiresIn.Duration < time.Second {
+		return fmt.Errorf(errInvalidExpiresIn)
+	}
+	return nil
+}
+
+func newAPIClient(ctx context.Context, kube client.Client, spec *genv1alpha1.ArtifactoryAccessTokenSpec, namespace string) (*apiClient, error) {
+	api := &apiClient{
+		url:    strings.TrimSuffix(spec.URL, "/"),
+		client: &http.Client{Timeout: requestTimeout},
+	}
+	if spec.Auth.AccessTokenSecretRef != nil {
+		token, err := resolvers.SecretKeyRef(ctx, kube, esv1beta1.SecretStoreKind, namespace, spec.Auth.AccessTokenSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf(errResolveSecret, err)
+		}
+		api.setAuth = func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	} else {
+		password, err := resolvers.SecretKeyRef(ctx, kube, esv1beta1.SecretStoreKind, namespace, &spec.Auth.Basic.PasswordSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf(errResolveSecret, err)
+		}
+		username := spec.Auth.Basic.Username
+		api.setAuth = func(req *http.Request) {
+			req.SetBasicAuth(username, password)
+		}
+	}
+	if len(spec.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(spec.CABundle)
+		api.client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		}
+	}
+	return api, nil
+}
+
+// createToken creates an access token through the Access API and returns it.
+func (a *apiClient) createToken(ctx context.Context, in *tokenRequest) (string, error) {
+	token, err := a.doCreateToken(ctx, in)
+	metrics.ObserveAPICall(constants.ProviderArtifactory, constants.CallArtifactoryCreateToken, err)
+	return token, err
+}
+
+func (a *apiClient) doCreateToken(ctx context.Context, in *tokenRequest) (string, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url+"/access/api/v1/tokens", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	data, err := a.do(req)
+	if err != nil {
+		return "", err
+	}
+	var resp tokenResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return "", fmt.Errorf(errDecodeResponse, err)
+	}
+	if resp.AccessToken == "" {
+		return "", fmt.Errorf(errEmptyAccessToken)
+	}
+	return resp.AccessToken, nil
+}
+
+// encryptedPassword returns the encrypted password of the authenticated user.
+func (a *apiClient) encryptedPassword(ctx context.Context) (string, error) {
+	password, err := a.doEncryptedPassword(ctx)
+	metrics.ObserveAPICall(constants.ProviderArtifactory, constants.CallArtifactoryGetEncryptedPassword, err)
+	return password, err
+}
+
+func (a *apiClient) doEncryptedPassword(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.url+"/artifactory/api/security/encryptedPassword", http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	data, err := a.do(req)
+	if err != nil {
+		return "", err
+	}
+	password := strings.TrimSpace(string(data))
+	if password == "" {
+		return "", fmt.Errorf(errEmptyEncryptedPass)
+	}
+	return password, nil
+}
+
+func (a *apiClient) do(req *http.Request) ([]byte, error) {
+	a.setAuth(req)
+	return utils.DoHTTP(a.client, req)
+}
+
+func parseSpec(data []byte) (*genv1alpha1.ArtifactoryAccessToken, error) {
+	var spec genv1alpha1.ArtifactoryAccessToken
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.ArtifactoryAccessTokenKind, &Generator{})
+}
@@ -0,0 +1,185 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGenerate(t *testing.T) {
+	var lastRequest tokenRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/access/api/v1/tokens":
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer admin-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			lastRequest = tokenRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if lastRequest.Username == "forbidden" {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"errors":[{"code":"FORBIDDEN","message":"Only an admin user can create a token for another user"}]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"token_id":"1","access_token":"minted","expires_in":%d,"scope":"%s","token_type":"Bearer"}`, lastRequest.ExpiresIn, lastRequest.Scope)
+		case "/artifactory/api/security/encryptedPassword":
+			if username, password, ok := r.BasicAuth(); !ok || username != "ci" || password != "s3cr3t" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, "AP6xyz")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "artifactory",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"token":    []byte("admin-token"),
+			"password": []byte("s3cr3t"),
+		},
+	}).Build()
+
+	spec := func(s string) *apiextensions.JSON {
+		return &apiextensions.JSON{Raw: []byte(fmt.Sprintf(`{"spec":%s}`, s))}
+	}
+	tokenAuth := `{"accessTokenSecretRef":{"name":"artifactory","key":"token"}}`
+	basicAuth := `{"basic":{"username":"ci","passwordSecretRef":{"name":"artifactory","key":"password"}}}`
+
+	tests := []struct {
+		name    string
+		spec    *apiextensions.JSON
+		want    map[string]string
+		wantReq *tokenRequest
+		wantErr string
+	}{
+		{
+			name:    "no spec",
+			wantErr: errNoSpec,
+		},
+		{
+			name:    "no url",
+			spec:    spec(`{}`),
+			wantErr: errNoURL,
+		},
+		{
+			name:    "invalid url",
+			spec:    spec(`{"url":"example.jfrog.io"}`),
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "both auth methods",
+			spec:    spec(fmt.Sprintf(`{"url":%q,"auth":{"accessTokenSecretRef":{"name":"artifactory","key":"token"},"basic":{"username":"ci","passwordSecretRef":{"name":"artifactory","key":"password"}}}}`, srv.URL)),
+			wantErr: errInvalidAuth,
+		},
+		{
+			name:    "encrypted password requires basic auth",
+			spec:    spec(fmt.Sprintf(`{"url":%q,"auth":%s}`, srv.URL, tokenAuth)),
+			wantErr: errNoBasicAuth,
+		},
+		{
+			name:    "token without username",
+			spec:    spec(fmt.Sprintf(`{"url":%q,"auth":%s,"token":{}}`, srv.URL, tokenAuth)),
+			wantErr: errNoTokenUsername,
+		},
+		{
+			name: "encrypted password",
+			spec: spec(fmt.Sprintf(`{"url":%q,"registry":"docker.example.jfrog.io","auth":%s}`, srv.URL, basicAuth)),
+			want: map[string]string{
+				"username":          "ci",
+				"password":          "AP6xyz",
+				"registry":          "docker.example.jfrog.io",
+				".dockerconfigjson": `{"auths":{"docker.example.jfrog.io":{"username":"ci","password":"AP6xyz","auth":"Y2k6QVA2eHl6"}}}`,
+			},
+		},
+		{
+			name: "access token",
+			spec: spec(fmt.Sprintf(`{"url":%q,"auth":%s,"token":{"username":"puller","scope":"applied-permissions/groups:readers","expiresIn":"10m","audience":"jfrt@*"}}`, srv.URL, tokenAuth)),
+			want: map[string]string{
+				"username": "puller",
+				"password": "minted",
+			},
+			wantReq: &tokenRequest{Username: "puller", Scope: "applied-permissions/groups:readers", ExpiresIn: 600, Audience: "jfrt@*"},
+		},
+		{
+			name: "access token defaults",
+			spec: spec(fmt.Sprintf(`{"url":%q,"auth":%s,"token":{"username":"puller"}}`, srv.URL, tokenAuth)),
+			want: map[string]string{
+				"username": "puller",
+				"password": "minted",
+			},
+			wantReq: &tokenRequest{Username: "puller", Scope: defaultScope, ExpiresIn: 3600},
+		},
+		{
+			name:    "access token denied",
+			spec:    spec(fmt.Sprintf(`{"url":%q,"auth":%s,"token":{"username":"forbidden"}}`, srv.URL, tokenAuth)),
+			wantErr: "unexpected status code 403",
+		},
+		{
+			name:    "missing secret",
+			spec:    spec(fmt.Sprintf(`{"url":%q,"auth":{"accessTokenSecretRef":{"name":"missing","key":"token"}},"token":{"username":"puller"}}`, srv.URL)),
+			wantErr: "unable to resolve secret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			got, err := g.Generate(context.Background(), tt.spec, kube, "default")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for k, v := range tt.want {
+				assert.Equal(t, v, string(got[k]), k)
+			}
+			if tt.wantReq == nil {
+				assert.NotContains(t, got, "expires_at")
+				return
+			}
+			assert.Equal(t, *tt.wantReq, lastRequest)
+			expiresAt, err := strconv.ParseInt(string(got["expires_at"]), 10, 64)
+			require.NoError(t, err)
+			assert.InDelta(t, time.Now().Unix()+tt.wantReq.ExpiresIn, expiresAt, 5)
+			var config dockerConfig
+			require.NoError(t, json.Unmarshal(got[".dockerconfigjson"], &config))
+			assert.Equal(t, "minted", config.Auths[string(got["registry"])].Password)
+		})
+	}
+}
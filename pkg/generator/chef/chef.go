@@ -0,0 +1,244 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chef/chef"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	provider "github.com/external-secrets/external-secrets/pkg/provider/chef"
+)
+
+type Generator struct{}
+
+var _ genv1alpha1.StatefulGenerator = &Generator{}
+
+const (
+	defaultKeyName = "default"
+	keySize        = 2048
+
+	errNoSpec         = "no config spec provided"
+	errParseSpec      = "unable to parse spec: %w"
+	errNoProvider     = "no Chef provider config in spec"
+	errNoClientName   = "no clientName in spec"
+	errChefClient     = "unable to setup Chef client: %w"
+	errGetClient      = "unable to get client %s: %w"
+	errCreateClient   = "unable to create client %s: %w"
+	errUpdateKey      = "unable to update key of client %s: %w"
+	errGenerateKey    = "unable to generate key: %w"
+	errMarshalPubKey  = "unable to marshal public key: %w"
+	errEmptyClientKey = "chef server did not return a private key for client %s"
+	errDeleteClient   = "unable to delete client %s: %w"
+	errParseState     = "unable to parse state: %w"
+)
+
+// ClientService is the subset of the Chef clients API used by the generator.
+type ClientService interface {
+	Get(name string) (chef.ApiClient, error)
+	Create(client chef.ApiNewClient) (*chef.ApiClientCreateResult, error)
+	UpdateKey(name string, keyname string, keyupd chef.AccessKey) (chef.AccessKey, error)
+	AddKey(name string, keyadd chef.AccessKey) (chef.KeyItem, error)
+	ListKeys(name string) ([]chef.KeyItem, error)
+	DeleteKey(name string, keyname string) (chef.AccessKey, error)
+	Delete(name string) error
+}
+
+// clientState is recorded in a GeneratorState when the generator registered
+// a new client. The client is deleted once the ExternalSecret is deleted.
+// Clients that existed before are left untouched.
+type clientState struct {
+	ClientName string `json:"clientName"`
+}
+
+type clientServiceFunc func(ctx context.Context, kube client.Client, spec *esv1beta1.ChefProvider, namespace string) (ClientService, error)
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	secretMap, _, err := g.generate(ctx, jsonSpec, kube, namespace, newClientService)
+	return secretMap, err
+}
+
+func (g *Generator) GenerateWithState(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, *apiextensions.JSON, error) {
+	return g.generate(ctx, jsonSpec, kube, namespace, newClientService)
+}
+
+func (g *Generator) Cleanup(ctx context.Context, jsonSpec, state *apiextensions.JSON, kube client.Client, namespace string) error {
+	return g.cleanup(ctx, jsonSpec, state, kube, namespace, newClientService)
+}
+
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string, newClients clientServiceFunc) (map[string][]byte, *apiextensions.JSON, error) {
+	if jsonSpec == nil {
+		return nil, nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errParseSpec, err)
+	}
+	if res.Spec.Provider == nil {
+		return nil, nil, fmt.Errorf(errNoProvider)
+	}
+	if res.Spec.ClientName == "" {
+		return nil, nil, fmt.Errorf(errNoClientName)
+	}
+	clients, err := newClients(ctx, kube, res.Spec.Provider, namespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errChefClient, err)
+	}
+
+	privateKey, created, err := clientKey(clients, res.Spec.ClientName, res.Spec.Validator)
+	if err != nil {
+		return nil, nil, err
+	}
+	var state *apiextensions.JSON
+	if created {
+		raw, err := json.Marshal(clientState{ClientName: res.Spec.ClientName})
+		if err != nil {
+			return nil, nil, err
+		}
+		state = &apiextensions.JSON{Raw: raw}
+	}
+	return map[string][]byte{
+		"privateKey": []byte(privateKey),
+		"clientName": []byte(res.Spec.ClientName),
+		"serverUrl":  []byte(res.Spec.Provider.ServerURL),
+	}, state, nil
+}
+
+func (g *Generator) cleanup(ctx context.Context, jsonSpec, state *apiextensions.JSON, kube client.Client, namespace string, newClients clientServiceFunc) error {
+	if jsonSpec == nil {
+		return fmt.Errorf(errNoSpec)
+	}
+	if state == nil {
+		return nil
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return fmt.Errorf(errParseSpec, err)
+	}
+	if res.Spec.Provider == nil {
+		return fmt.Errorf(errNoProvider)
+	}
+	var cs clientState
+	if err := json.Unmarshal(state.Raw, &cs); err != nil {
+		return fmt.Errorf(errParseState, err)
+	}
+	if cs.ClientName == "" {
+		return nil
+	}
+	clients, err := newClients(ctx, kube, res.Spec.Provider, namespace)
+	if err != nil {
+		return fmt.Errorf(errChefClient, err)
+	}
+	err = clients.Delete(cs.ClientName)
+	metrics.ObserveAPICall(provider.ProviderChef, provider.CallChefDeleteClient, err)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf(errDeleteClient, cs.ClientName, err)
+	}
+	return nil
+}
+
+// clientKey registers the client and returns the private key created by the
+// Chef server. If the client already exists a new key is generated locally and
+// replaces the default key of the client.
+// The returned bool reports whether the client was created.
+func clientKey(clients ClientService, name string, validator bool) (string, bool, error) {
+	_, err := clients.Get(name)
+	metrics.ObserveAPICall(provider.ProviderChef, provider.CallChefGetClient, err)
+	if isNotFound(err) {
+		created, err := clients.Create(chef.ApiNewClient{
+			Name:      name,
+			Validator: validator,
+			CreateKey: true,
+		})
+		metrics.ObserveAPICall(provider.ProviderChef, provider.CallChefCreateClient, err)
+		if err != nil {
+			return "", false, fmt.Errorf(errCreateClient, name, err)
+		}
+		if created == nil || created.ChefKey.PrivateKey == "" {
+			return "", false, fmt.Errorf(errEmptyClientKey, name)
+		}
+		return created.ChefKey.PrivateKey, true, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf(errGetClient, name, err)
+	}
+
+	privateKey, publicKey, err := generateKeyPair()
+	if err != nil {
+		return "", false, err
+	}
+	_, err = clients.UpdateKey(name, defaultKeyName, chef.AccessKey{
+		Name:           defaultKeyName,
+		PublicKey:      publicKey,
+		ExpirationDate: "infinity",
+	})
+	metrics.ObserveAPICall(provider.ProviderChef, provider.CallChefUpdateClientKey, err)
+	if err != nil {
+		return "", false, fmt.Errorf(errUpdateKey, name, err)
+	}
+	return privateKey, false, nil
+}
+
+// generateKeyPair returns a new PEM encoded RSA private and public key.
+func generateKeyPair() (string, string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return "", "", fmt.Errorf(errGenerateKey, err)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", "", fmt.Errorf(errMarshalPubKey, err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub})
+	return string(privateKey), string(publicKey), nil
+}
+
+func isNotFound(err error) bool {
+	var cerr *chef.ErrorResponse
+	return errors.As(err, &cerr) && cerr.Response != nil && cerr.StatusCode() == http.StatusNotFound
+}
+
+func newClientService(ctx context.Context, kube client.Client, spec *esv1beta1.ChefProvider, namespace string) (ClientService, error) {
+	c, err := provider.NewGeneratorClient(ctx, kube, spec, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return c.Clients, nil
+}
+
+func parseSpec(data []byte) (*genv1alpha1.ChefClientKey, error) {
+	var spec genv1alpha1.ChefClientKey
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.ChefClientKeyKind, &Generator{})
+}
@@ -0,0 +1,269 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-chef/chef"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const testSpec = `{"spec":{"clientName":"bootstrap","provider":{"serverUrl":"https://chef.example.com/organizations/dev/","username":"admin","auth":{"secretRef":{"privateKeySecretRef":{"name":"chef","key":"key"}}}}}}`
+
+type fakeClients struct {
+	getErr     error
+	createRes  *chef.ApiClientCreateResult
+	createErr  error
+	updateErr  error
+	created    *chef.ApiNewClient
+	updatedKey *chef.AccessKey
+
+	keys      []chef.KeyItem
+	addErr    error
+	listErr   error
+	deleteErr error
+	addedKey  *chef.AccessKey
+	deleted   []string
+
+	deleteClientErr error
+	deletedClient   string
+}
+
+func (f *fakeClients) Get(_ string) (chef.ApiClient, error) {
+	return chef.ApiClient{}, f.getErr
+}
+
+func (f *fakeClients) Create(c chef.ApiNewClient) (*chef.ApiClientCreateResult, error) {
+	f.created = &c
+	return f.createRes, f.createErr
+}
+
+func (f *fakeClients) UpdateKey(_, _ string, key chef.AccessKey) (chef.AccessKey, error) {
+	f.updatedKey = &key
+	return key, f.updateErr
+}
+
+func (f *fakeClients) AddKey(_ string, key chef.AccessKey) (chef.KeyItem, error) {
+	f.addedKey = &key
+	if f.addErr == nil {
+		f.keys = append(f.keys, chef.KeyItem{Name: key.Name})
+	}
+	return chef.KeyItem{Name: key.Name}, f.addErr
+}
+
+func (f *fakeClients) ListKeys(_ string) ([]chef.KeyItem, error) {
+	return f.keys, f.listErr
+}
+
+func (f *fakeClients) DeleteKey(_, keyname string) (chef.AccessKey, error) {
+	f.deleted = append(f.deleted, keyname)
+	return chef.AccessKey{}, f.deleteErr
+}
+
+func (f *fakeClients) Delete(name string) error {
+	f.deletedClient = name
+	return f.deleteClientErr
+}
+
+func notFound() error {
+	return &chef.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      string
+		clients   *fakeClients
+		wantKey   string
+		wantState string
+		wantErr   string
+		checkFake func(t *testing.T, f *fakeClients, privateKey []byte)
+	}{
+		{
+			name:    "invalid json spec should result in error",
+			spec:    `no json`,
+			wantErr: "unable to parse spec",
+		},
+		{
+			name:    "missing provider should result in error",
+			spec:    `{"spec":{"clientName":"bootstrap"}}`,
+			wantErr: errNoProvider,
+		},
+		{
+			name:    "missing client name should result in error",
+			spec:    `{"spec":{"provider":{"serverUrl":"https://chef.example.com/"}}}`,
+			wantErr: errNoClientName,
+		},
+		{
+			name: "new client is created with a server side key",
+			spec: testSpec,
+			clients: &fakeClients{
+				getErr:    notFound(),
+				createRes: &chef.ApiClientCreateResult{ChefKey: chef.ChefKey{PrivateKey: "private-key"}},
+			},
+			wantKey:   "private-key",
+			wantState: `{"clientName":"bootstrap"}`,
+			checkFake: func(t *testing.T, f *fakeClients, _ []byte) {
+				require.NotNil(t, f.created)
+				assert.Equal(t, "bootstrap", f.created.Name)
+				assert.True(t, f.created.CreateKey)
+				assert.Nil(t, f.updatedKey)
+			},
+		},
+		{
+			name: "creating the client fails",
+			spec: testSpec,
+			clients: &fakeClients{
+				getErr:    notFound(),
+				createErr: errors.New("forbidden"),
+			},
+			wantErr: "unable to create client bootstrap: forbidden",
+		},
+		{
+			name:    "existing client gets a new default key",
+			spec:    testSpec,
+			clients: &fakeClients{},
+			checkFake: func(t *testing.T, f *fakeClients, privateKey []byte) {
+				assert.Nil(t, f.created)
+				require.NotNil(t, f.updatedKey)
+				assert.Equal(t, defaultKeyName, f.updatedKey.Name)
+
+				privBlock, _ := pem.Decode(privateKey)
+				require.NotNil(t, privBlock)
+				priv, err := x509.ParsePKCS1PrivateKey(privBlock.Bytes)
+				require.NoError(t, err)
+				pubBlock, _ := pem.Decode([]byte(f.updatedKey.PublicKey))
+				require.NotNil(t, pubBlock)
+				pub, err := x509.ParsePKIXPublicKey(pubBlock.Bytes)
+				require.NoError(t, err)
+				assert.True(t, priv.PublicKey.Equal(pub))
+			},
+		},
+		{
+			name: "updating the key fails",
+			spec: testSpec,
+			clients: &fakeClients{
+				updateErr: errors.New("forbidden"),
+			},
+			wantErr: "unable to update key of client bootstrap: forbidden",
+		},
+		{
+			name: "getting the client fails",
+			spec: testSpec,
+			clients: &fakeClients{
+				getErr: errors.New("timeout"),
+			},
+			wantErr: "unable to get client bootstrap: timeout",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			newClients := func(_ context.Context, _ client.Client, _ *esv1beta1.ChefProvider, _ string) (ClientService, error) {
+				return tt.clients, nil
+			}
+			got, state, err := g.generate(context.Background(), &apiextensions.JSON{Raw: []byte(tt.spec)}, nil, "default", newClients)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantState == "" {
+				assert.Nil(t, state)
+			} else {
+				require.NotNil(t, state)
+				assert.JSONEq(t, tt.wantState, string(state.Raw))
+			}
+			assert.Equal(t, "bootstrap", string(got["clientName"]))
+			assert.Equal(t, "https://chef.example.com/organizations/dev/", string(got["serverUrl"]))
+			if tt.wantKey != "" {
+				assert.Equal(t, tt.wantKey, string(got["privateKey"]))
+			}
+			if tt.checkFake != nil {
+				tt.checkFake(t, tt.clients, got["privateKey"])
+			}
+		})
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	tests := []struct {
+		name          string
+		state         *apiextensions.JSON
+		clients       *fakeClients
+		wantDeleted   string
+		wantErr       string
+		wantNoClients bool
+	}{
+		{
+			name:          "no state is a no-op",
+			clients:       &fakeClients{},
+			wantNoClients: true,
+		},
+		{
+			name:        "created client is deleted",
+			state:       &apiextensions.JSON{Raw: []byte(`{"clientName":"bootstrap"}`)},
+			clients:     &fakeClients{},
+			wantDeleted: "bootstrap",
+		},
+		{
+			name:        "client that is already gone is ignored",
+			state:       &apiextensions.JSON{Raw: []byte(`{"clientName":"bootstrap"}`)},
+			clients:     &fakeClients{deleteClientErr: notFound()},
+			wantDeleted: "bootstrap",
+		},
+		{
+			name:    "deleting the client fails",
+			state:   &apiextensions.JSON{Raw: []byte(`{"clientName":"bootstrap"}`)},
+			clients: &fakeClients{deleteClientErr: errors.New("forbidden")},
+			wantErr: "unable to delete client bootstrap: forbidden",
+		},
+		{
+			name:    "invalid state should result in error",
+			state:   &apiextensions.JSON{Raw: []byte(`no json`)},
+			clients: &fakeClients{},
+			wantErr: "unable to parse state",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			called := false
+			newClients := func(_ context.Context, _ client.Client, _ *esv1beta1.ChefProvider, _ string) (ClientService, error) {
+				called = true
+				return tt.clients, nil
+			}
+			err := g.cleanup(context.Background(), &apiextensions.JSON{Raw: []byte(testSpec)}, tt.state, nil, "default", newClients)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, !tt.wantNoClients, called)
+			assert.Equal(t, tt.wantDeleted, tt.clients.deletedClient)
+		})
+	}
+}
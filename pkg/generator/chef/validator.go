@@ -0,0 +1,161 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chef/chef"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	provider "github.com/external-secrets/external-secrets/pkg/provider/chef"
+)
+
+// ValidatorKeyGenerator rotates the key of an organization validator client.
+type ValidatorKeyGenerator struct{}
+
+const (
+	// validatorKeyPrefix marks keys created by the generator,
+	// other keys of the validator are never removed.
+	validatorKeyPrefix  = "eso-"
+	defaultKeepPrevious = 1
+
+	errNoValidatorName = "no validatorName in spec and no organization found in serverUrl %q"
+	errAddKey          = "unable to add key to client %s: %w"
+	errListKeys        = "unable to list keys of client %s: %w"
+	errDeleteKey       = "unable to delete key %s of client %s: %w"
+)
+
+func (g *ValidatorKeyGenerator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	return g.generate(ctx, jsonSpec, kube, namespace, newClientService, time.Now)
+}
+
+func (g *ValidatorKeyGenerator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string, newClients clientServiceFunc, now func() time.Time) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseValidatorSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	if res.Spec.Provider == nil {
+		return nil, fmt.Errorf(errNoProvider)
+	}
+	name := res.Spec.ValidatorName
+	if name == "" {
+		org := organization(res.Spec.Provider.ServerURL)
+		if org == "" {
+			return nil, fmt.Errorf(errNoValidatorName, res.Spec.Provider.ServerURL)
+		}
+		name = org + "-validator"
+	}
+	keepPrevious := defaultKeepPrevious
+	if res.Spec.KeepPrevious != nil {
+		keepPrevious = *res.Spec.KeepPrevious
+	}
+	clients, err := newClients(ctx, kube, res.Spec.Provider, namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errChefClient, err)
+	}
+
+	privateKey, publicKey, err := generateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	keyName := validatorKeyPrefix + strconv.FormatInt(now().UnixNano(), 10)
+	_, err = clients.AddKey(name, chef.AccessKey{
+		Name:           keyName,
+		PublicKey:      publicKey,
+		ExpirationDate: "infinity",
+	})
+	metrics.ObserveAPICall(provider.ProviderChef, provider.CallChefAddClientKey, err)
+	if err != nil {
+		return nil, fmt.Errorf(errAddKey, name, err)
+	}
+	if err := removePreviousKeys(clients, name, keyName, keepPrevious); err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		"privateKey": []byte(privateKey),
+		"clientName": []byte(name),
+		"keyName":    []byte(keyName),
+		"serverUrl":  []byte(res.Spec.Provider.ServerURL),
+	}, nil
+}
+
+// removePreviousKeys deletes all keys created by the generator
+// except the current one and the latest keepPrevious keys.
+func removePreviousKeys(clients ClientService, name, current string, keepPrevious int) error {
+	keys, err := clients.ListKeys(name)
+	metrics.ObserveAPICall(provider.ProviderChef, provider.CallChefListClientKeys, err)
+	if err != nil {
+		return fmt.Errorf(errListKeys, name, err)
+	}
+	previous := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k.Name != current && strings.HasPrefix(k.Name, validatorKeyPrefix) {
+			previous = append(previous, k.Name)
+		}
+	}
+	// key names contain the creation time, newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(previous)))
+	if len(previous) <= keepPrevious {
+		return nil
+	}
+	for _, k := range previous[keepPrevious:] {
+		_, err := clients.DeleteKey(name, k)
+		metrics.ObserveAPICall(provider.ProviderChef, provider.CallChefDeleteClientKey, err)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf(errDeleteKey, k, name, err)
+		}
+	}
+	return nil
+}
+
+// organization returns the organization name of a Chef server URL
+// like https://chef.example.com/organizations/dev/.
+func organization(serverURL string) string {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "organizations" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+func parseValidatorSpec(data []byte) (*genv1alpha1.ChefValidatorKey, error) {
+	var spec genv1alpha1.ChefValidatorKey
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.ChefValidatorKeyKind, &ValidatorKeyGenerator{})
+}
@@ -0,0 +1,152 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-chef/chef"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const testValidatorSpec = `{"spec":{"provider":{"serverUrl":"https://chef.example.com/organizations/dev/","username":"admin","auth":{"secretRef":{"privateKeySecretRef":{"name":"chef","key":"key"}}}}}}`
+
+func TestGenerateValidatorKey(t *testing.T) {
+	now := time.Unix(0, 3000)
+	tests := []struct {
+		name        string
+		spec        string
+		clients     *fakeClients
+		wantClient  string
+		wantDeleted []string
+		wantErr     string
+	}{
+		{
+			name:    "invalid json spec should result in error",
+			spec:    `no json`,
+			wantErr: "unable to parse spec",
+		},
+		{
+			name:    "missing provider should result in error",
+			spec:    `{"spec":{}}`,
+			wantErr: errNoProvider,
+		},
+		{
+			name:    "server url without organization and no validator name should result in error",
+			spec:    `{"spec":{"provider":{"serverUrl":"https://chef.example.com/"}}}`,
+			wantErr: "no validatorName in spec",
+		},
+		{
+			name:       "first rotation keeps existing keys",
+			spec:       testValidatorSpec,
+			clients:    &fakeClients{keys: []chef.KeyItem{{Name: "default"}}},
+			wantClient: "dev-validator",
+		},
+		{
+			name:       "explicit validator name",
+			spec:       `{"spec":{"validatorName":"my-validator","provider":{"serverUrl":"https://chef.example.com/"}}}`,
+			clients:    &fakeClients{},
+			wantClient: "my-validator",
+		},
+		{
+			name: "previous generated keys beyond keepPrevious are removed",
+			spec: testValidatorSpec,
+			clients: &fakeClients{keys: []chef.KeyItem{
+				{Name: "default"},
+				{Name: "eso-1000"},
+				{Name: "eso-2000"},
+			}},
+			wantClient:  "dev-validator",
+			wantDeleted: []string{"eso-1000"},
+		},
+		{
+			name: "keepPrevious zero removes all previous generated keys",
+			spec: `{"spec":{"keepPrevious":0,"provider":{"serverUrl":"https://chef.example.com/organizations/dev/"}}}`,
+			clients: &fakeClients{keys: []chef.KeyItem{
+				{Name: "default"},
+				{Name: "eso-1000"},
+				{Name: "eso-2000"},
+			}},
+			wantClient:  "dev-validator",
+			wantDeleted: []string{"eso-2000", "eso-1000"},
+		},
+		{
+			name:    "adding the key fails",
+			spec:    testValidatorSpec,
+			clients: &fakeClients{addErr: errors.New("forbidden")},
+			wantErr: "unable to add key to client dev-validator: forbidden",
+		},
+		{
+			name:    "listing the keys fails",
+			spec:    testValidatorSpec,
+			clients: &fakeClients{listErr: errors.New("timeout")},
+			wantErr: "unable to list keys of client dev-validator: timeout",
+		},
+		{
+			name: "deleting a key fails",
+			spec: testValidatorSpec,
+			clients: &fakeClients{
+				keys:      []chef.KeyItem{{Name: "eso-1000"}, {Name: "eso-2000"}},
+				deleteErr: errors.New("forbidden"),
+			},
+			wantErr: "unable to delete key eso-1000 of client dev-validator: forbidden",
+		},
+		{
+			name: "deleting a key that is already gone is ignored",
+			spec: testValidatorSpec,
+			clients: &fakeClients{
+				keys:      []chef.KeyItem{{Name: "eso-1000"}, {Name: "eso-2000"}},
+				deleteErr: notFound(),
+			},
+			wantClient:  "dev-validator",
+			wantDeleted: []string{"eso-1000"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &ValidatorKeyGenerator{}
+			newClients := func(_ context.Context, _ client.Client, _ *esv1beta1.ChefProvider, _ string) (ClientService, error) {
+				return tt.clients, nil
+			}
+			got, err := g.generate(context.Background(), &apiextensions.JSON{Raw: []byte(tt.spec)}, nil, "default", newClients, func() time.Time { return now })
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantClient, string(got["clientName"]))
+			assert.Equal(t, "eso-3000", string(got["keyName"]))
+			assert.Contains(t, string(got["privateKey"]), "RSA PRIVATE KEY")
+			require.NotNil(t, tt.clients.addedKey)
+			assert.Equal(t, "eso-3000", tt.clients.addedKey.Name)
+			assert.Contains(t, tt.clients.addedKey.PublicKey, "PUBLIC KEY")
+			assert.Equal(t, tt.wantDeleted, tt.clients.deleted)
+		})
+	}
+}
+
+func TestOrganization(t *testing.T) {
+	assert.Equal(t, "dev", organization("https://chef.example.com/organizations/dev/"))
+	assert.Equal(t, "dev", organization("https://chef.example.com/organizations/dev"))
+	assert.Equal(t, "", organization("https://chef.example.com/"))
+}
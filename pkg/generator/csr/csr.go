@@ -0,0 +1,224 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csr
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+type Generator struct{}
+
+const (
+	defaultRSAKeySize   = 2048
+	minRSAKeySize       = 2048
+	defaultECDSAKeySize = 256
+
+	errNoSpec         = "no config spec provided"
+	errParseSpec      = "unable to parse spec: %w"
+	errKeyAlgorithm   = "unsupported key algorithm: %s"
+	errRSAKeySize     = "rsa key size must be at least %d bits, got %d"
+	errECDSAKeySize   = "unsupported ecdsa key size %d, must be one of 256, 384 or 521"
+	errGenerateKey    = "unable to generate key: %w"
+	errInvalidIP      = "invalid ip address %q"
+	errInvalidURI     = "invalid uri %q: %w"
+	errCreateCSR      = "unable to create certificate signing request: %w"
+	errMarshalPrivKey = "unable to marshal private key: %w"
+)
+
+type generateFunc func(algorithm genv1alpha1.CSRKeyAlgorithm, keySize int) (crypto.Signer, error)
+
+func (g *Generator) Generate(_ context.Context, jsonSpec *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, error) {
+	return g.generate(jsonSpec, generateKey)
+}
+
+func (g *Generator) generate(jsonSpec *apiextensions.JSON, keyGen generateFunc) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	algorithm := res.Spec.KeyAlgorithm
+	if algorithm == "" {
+		algorithm = genv1alpha1.CSRKeyAlgorithmRSA
+	}
+	keySize, err := keySizeFor(algorithm, res.Spec.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := requestTemplate(&res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keyGen(algorithm, keySize)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, tpl, key)
+	if err != nil {
+		return nil, fmt.Errorf(errCreateCSR, err)
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf(errMarshalPrivKey, err)
+	}
+	return map[string][]byte{
+		"privateKey": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}),
+		"csr":        pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+	}, nil
+}
+
+func keySizeFor(algorithm genv1alpha1.CSRKeyAlgorithm, keySize *int) (int, error) {
+	switch algorithm {
+	case genv1alpha1.CSRKeyAlgorithmRSA:
+		if keySize == nil {
+			return defaultRSAKeySize, nil
+		}
+		if *keySize < minRSAKeySize {
+			return 0, fmt.Errorf(errRSAKeySize, minRSAKeySize, *keySize)
+		}
+		return *keySize, nil
+	case genv1alpha1.CSRKeyAlgorithmECDSA:
+		if keySize == nil {
+			return defaultECDSAKeySize, nil
+		}
+		if _, err := curve(*keySize); err != nil {
+			return 0, err
+		}
+		return *keySize, nil
+	case genv1alpha1.CSRKeyAlgorithmEd25519:
+		// the size of ed25519 keys is fixed.
+		return 0, nil
+	default:
+		return 0, fmt.Errorf(errKeyAlgorithm, algorithm)
+	}
+}
+
+func requestTemplate(spec *genv1alpha1.CSRSpec) (*x509.CertificateRequest, error) {
+	ips, uris, err := parseSANs(spec.IPAddresses, spec.URIs)
+	if err != nil {
+		return nil, err
+	}
+	return &x509.CertificateRequest{
+		Subject:        subjectName(&spec.Subject),
+		DNSNames:       spec.DNSNames,
+		EmailAddresses: spec.EmailAddresses,
+		IPAddresses:    ips,
+		URIs:           uris,
+	}, nil
+}
+
+func subjectName(subject *genv1alpha1.CSRSubject) pkix.Name {
+	return pkix.Name{
+		CommonName:         subject.CommonName,
+		Organization:       subject.Organizations,
+		OrganizationalUnit: subject.OrganizationalUnits,
+		Country:            subject.Countries,
+		Province:           subject.Provinces,
+		Locality:           subject.Localities,
+	}
+}
+
+// parseSANs parses the ip address and uri subject alternative names.
+func parseSANs(ipAddresses, uris []string) ([]net.IP, []*url.URL, error) {
+	var parsedIPs []net.IP
+	for _, ip := range ipAddresses {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return nil, nil, fmt.Errorf(errInvalidIP, ip)
+		}
+		parsedIPs = append(parsedIPs, parsed)
+	}
+	var parsedURIs []*url.URL
+	for _, uri := range uris {
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return nil, nil, fmt.Errorf(errInvalidURI, uri, err)
+		}
+		parsedURIs = append(parsedURIs, parsed)
+	}
+	return parsedIPs, parsedURIs, nil
+}
+
+func generateKey(algorithm genv1alpha1.CSRKeyAlgorithm, keySize int) (crypto.Signer, error) {
+	switch algorithm {
+	case genv1alpha1.CSRKeyAlgorithmRSA:
+		key, err := rsa.GenerateKey(rand.Reader, keySize)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerateKey, err)
+		}
+		return key, nil
+	case genv1alpha1.CSRKeyAlgorithmECDSA:
+		c, err := curve(keySize)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ecdsa.GenerateKey(c, rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerateKey, err)
+		}
+		return key, nil
+	case genv1alpha1.CSRKeyAlgorithmEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerateKey, err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf(errKeyAlgorithm, algorithm)
+	}
+}
+
+func curve(keySize int) (elliptic.Curve, error) {
+	switch keySize {
+	case 256:
+		return elliptic.P256(), nil
+	case 384:
+		return elliptic.P384(), nil
+	case 521:
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf(errECDSAKeySize, keySize)
+	}
+}
+
+func parseSpec(data []byte) (*genv1alpha1.CSR, error) {
+	var spec genv1alpha1.CSR
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.CSRKind, &Generator{})
+}
@@ -0,0 +1,154 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csr
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		keyGen   generateFunc
+		checkCSR func(t *testing.T, csr *x509.CertificateRequest)
+		wantErr  string
+	}{
+		{
+			name:    "invalid json spec should result in error",
+			spec:    `no json`,
+			wantErr: "unable to parse spec",
+		},
+		{
+			name:   "empty spec should generate rsa key",
+			spec:   `{}`,
+			keyGen: generateKey,
+			checkCSR: func(t *testing.T, csr *x509.CertificateRequest) {
+				pub, ok := csr.PublicKey.(*rsa.PublicKey)
+				require.True(t, ok)
+				assert.Equal(t, defaultRSAKeySize, pub.N.BitLen())
+			},
+		},
+		{
+			name: "ecdsa key with subject and sans",
+			spec: `{"spec":{"keyAlgorithm":"ecdsa","keySize":384,
+				"subject":{"commonName":"app.example.com","organizations":["example"]},
+				"dnsNames":["app.example.com","www.example.com"],
+				"ipAddresses":["10.0.0.1"],
+				"emailAddresses":["ops@example.com"],
+				"uris":["spiffe://example.com/app"]}}`,
+			keyGen: generateKey,
+			checkCSR: func(t *testing.T, csr *x509.CertificateRequest) {
+				pub, ok := csr.PublicKey.(*ecdsa.PublicKey)
+				require.True(t, ok)
+				assert.Equal(t, 384, pub.Curve.Params().BitSize)
+				assert.Equal(t, "app.example.com", csr.Subject.CommonName)
+				assert.Equal(t, []string{"example"}, csr.Subject.Organization)
+				assert.Equal(t, []string{"app.example.com", "www.example.com"}, csr.DNSNames)
+				require.Len(t, csr.IPAddresses, 1)
+				assert.Equal(t, "10.0.0.1", csr.IPAddresses[0].String())
+				assert.Equal(t, []string{"ops@example.com"}, csr.EmailAddresses)
+				require.Len(t, csr.URIs, 1)
+				assert.Equal(t, "spiffe://example.com/app", csr.URIs[0].String())
+			},
+		},
+		{
+			name:   "ed25519 key ignores key size",
+			spec:   `{"spec":{"keyAlgorithm":"ed25519","keySize":4096,"subject":{"commonName":"app.example.com"}}}`,
+			keyGen: generateKey,
+			checkCSR: func(t *testing.T, csr *x509.CertificateRequest) {
+				_, ok := csr.PublicKey.(ed25519.PublicKey)
+				require.True(t, ok)
+				assert.Equal(t, x509.PureEd25519, csr.SignatureAlgorithm)
+				assert.Equal(t, "app.example.com", csr.Subject.CommonName)
+			},
+		},
+		{
+			name:    "rsa key size below minimum should result in error",
+			spec:    `{"spec":{"keyAlgorithm":"rsa","keySize":1024}}`,
+			wantErr: "rsa key size must be at least 2048 bits",
+		},
+		{
+			name:    "unsupported ecdsa key size should result in error",
+			spec:    `{"spec":{"keyAlgorithm":"ecdsa","keySize":224}}`,
+			wantErr: "unsupported ecdsa key size 224",
+		},
+		{
+			name:    "unsupported key algorithm should result in error",
+			spec:    `{"spec":{"keyAlgorithm":"dsa"}}`,
+			wantErr: "unsupported key algorithm: dsa",
+		},
+		{
+			name:    "invalid ip address should result in error",
+			spec:    `{"spec":{"ipAddresses":["not-an-ip"]}}`,
+			wantErr: `invalid ip address "not-an-ip"`,
+		},
+		{
+			name: "generator error should be returned",
+			spec: `{}`,
+			keyGen: func(algorithm genv1alpha1.CSRKeyAlgorithm, keySize int) (crypto.Signer, error) {
+				return nil, fmt.Errorf("boom")
+			},
+			wantErr: "boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			got, err := g.generate(&apiextensions.JSON{Raw: []byte(tt.spec)}, tt.keyGen)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			csrBlock, _ := pem.Decode(got["csr"])
+			require.NotNil(t, csrBlock)
+			assert.Equal(t, "CERTIFICATE REQUEST", csrBlock.Type)
+			csr, err := x509.ParseCertificateRequest(csrBlock.Bytes)
+			require.NoError(t, err)
+			require.NoError(t, csr.CheckSignature())
+
+			keyBlock, _ := pem.Decode(got["privateKey"])
+			require.NotNil(t, keyBlock)
+			key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+			require.NoError(t, err)
+			signer, ok := key.(crypto.Signer)
+			require.True(t, ok)
+			assert.True(t, signer.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(csr.PublicKey))
+
+			tt.checkCSR(t, csr)
+		})
+	}
+}
+
+func TestGenerateNoSpec(t *testing.T) {
+	g := &Generator{}
+	_, err := g.generate(nil, generateKey)
+	assert.EqualError(t, err, errNoSpec)
+}
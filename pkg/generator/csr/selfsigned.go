@@ -0,0 +1,209 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csr
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+// SelfSignedGenerator creates a private key and a certificate signed by it.
+type SelfSignedGenerator struct{}
+
+const (
+	defaultDuration = 24 * time.Hour
+	caCertKey       = "ca.crt"
+
+	errDuration      = "duration must be positive, got %s"
+	errRenewBefore   = "renewBefore must be positive and less than the duration %s, got %s"
+	errUsage         = "unsupported usage: %s"
+	errSerialNumber  = "unable to generate serial number: %w"
+	errCreateCert    = "unable to create certificate: %w"
+	errNoCertificate = "no certificate found in %s"
+	errParseCert     = "unable to parse certificate: %w"
+)
+
+var (
+	defaultUsages = []genv1alpha1.CertificateUsage{
+		genv1alpha1.CertificateUsageDigitalSignature,
+		genv1alpha1.CertificateUsageKeyEncipherment,
+		genv1alpha1.CertificateUsageServerAuth,
+	}
+	// serialNumberLimit bounds the random serial numbers to 128 bits.
+	serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
+)
+
+func (g *SelfSignedGenerator) Generate(_ context.Context, jsonSpec *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, error) {
+	return g.generate(jsonSpec, generateKey, time.Now)
+}
+
+// RenewAt returns the time renewBefore the expiry of the generated certificate.
+func (g *SelfSignedGenerator) RenewAt(jsonSpec *apiextensions.JSON, data map[string][]byte) (time.Time, error) {
+	if jsonSpec == nil {
+		return time.Time{}, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSelfSignedSpec(jsonSpec.Raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(errParseSpec, err)
+	}
+	_, renewBefore, err := validity(&res.Spec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	block, _ := pem.Decode(data[corev1.TLSCertKey])
+	if block == nil {
+		return time.Time{}, fmt.Errorf(errNoCertificate, corev1.TLSCertKey)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(errParseCert, err)
+	}
+	return cert.NotAfter.Add(-renewBefore), nil
+}
+
+func (g *SelfSignedGenerator) generate(jsonSpec *apiextensions.JSON, keyGen generateFunc, now func() time.Time) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSelfSignedSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	algorithm := res.Spec.KeyAlgorithm
+	if algorithm == "" {
+		algorithm = genv1alpha1.CSRKeyAlgorithmRSA
+	}
+	keySize, err := keySizeFor(algorithm, res.Spec.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	duration, _, err := validity(&res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := certificateTemplate(&res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	tpl.NotBefore = now()
+	tpl.NotAfter = tpl.NotBefore.Add(duration)
+	tpl.SerialNumber, err = rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, fmt.Errorf(errSerialNumber, err)
+	}
+	key, err := keyGen(algorithm, keySize)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf(errCreateCert, err)
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf(errMarshalPrivKey, err)
+	}
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return map[string][]byte{
+		corev1.TLSCertKey:       cert,
+		corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}),
+		// the certificate is its own issuer.
+		caCertKey: cert,
+	}, nil
+}
+
+// validity returns the duration of the certificate and the time before
+// its expiry at which it is renewed.
+func validity(spec *genv1alpha1.SelfSignedCertificateSpec) (time.Duration, time.Duration, error) {
+	duration := defaultDuration
+	if spec.Duration != nil {
+		duration = spec.Duration.Duration
+	}
+	if duration <= 0 {
+		return 0, 0, fmt.Errorf(errDuration, duration)
+	}
+	renewBefore := duration / 3
+	if spec.RenewBefore != nil {
+		renewBefore = spec.RenewBefore.Duration
+	}
+	if renewBefore <= 0 || renewBefore >= duration {
+		return 0, 0, fmt.Errorf(errRenewBefore, duration, renewBefore)
+	}
+	return duration, renewBefore, nil
+}
+
+func certificateTemplate(spec *genv1alpha1.SelfSignedCertificateSpec) (*x509.Certificate, error) {
+	ips, uris, err := parseSANs(spec.IPAddresses, spec.URIs)
+	if err != nil {
+		return nil, err
+	}
+	tpl := &x509.Certificate{
+		Subject:               subjectName(&spec.Subject),
+		DNSNames:              spec.DNSNames,
+		EmailAddresses:        spec.EmailAddresses,
+		IPAddresses:           ips,
+		URIs:                  uris,
+		BasicConstraintsValid: true,
+		IsCA:                  spec.IsCA,
+	}
+	usages := spec.Usages
+	if len(usages) == 0 {
+		usages = defaultUsages
+	}
+	for _, usage := range usages {
+		switch usage {
+		case genv1alpha1.CertificateUsageDigitalSignature:
+			tpl.KeyUsage |= x509.KeyUsageDigitalSignature
+		case genv1alpha1.CertificateUsageKeyEncipherment:
+			tpl.KeyUsage |= x509.KeyUsageKeyEncipherment
+		case genv1alpha1.CertificateUsageServerAuth:
+			tpl.ExtKeyUsage = append(tpl.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+		case genv1alpha1.CertificateUsageClientAuth:
+			tpl.ExtKeyUsage = append(tpl.ExtKeyUsage, x509.ExtKeyUsageClientAuth)
+		case genv1alpha1.CertificateUsageCodeSigning:
+			tpl.ExtKeyUsage = append(tpl.ExtKeyUsage, x509.ExtKeyUsageCodeSigning)
+		case genv1alpha1.CertificateUsageEmailProtection:
+			tpl.ExtKeyUsage = append(tpl.ExtKeyUsage, x509.ExtKeyUsageEmailProtection)
+		default:
+			return nil, fmt.Errorf(errUsage, usage)
+		}
+	}
+	if spec.IsCA {
+		tpl.KeyUsage |= x509.KeyUsageCertSign
+	}
+	return tpl, nil
+}
+
+func parseSelfSignedSpec(data []byte) (*genv1alpha1.SelfSignedCertificate, error) {
+	var spec genv1alpha1.SelfSignedCertificate
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.SelfSignedCertificateKind, &SelfSignedGenerator{})
+}
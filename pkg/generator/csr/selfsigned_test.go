@@ -0,0 +1,171 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csr
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestGenerateSelfSigned(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		spec      string
+		checkCert func(t *testing.T, cert *x509.Certificate)
+		wantErr   string
+	}{
+		{
+			name:    "invalid json spec should result in error",
+			spec:    `no json`,
+			wantErr: "unable to parse spec",
+		},
+		{
+			name: "empty spec should generate rsa server certificate valid for a day",
+			spec: `{}`,
+			checkCert: func(t *testing.T, cert *x509.Certificate) {
+				pub, ok := cert.PublicKey.(*rsa.PublicKey)
+				require.True(t, ok)
+				assert.Equal(t, defaultRSAKeySize, pub.N.BitLen())
+				assert.Equal(t, now, cert.NotBefore)
+				assert.Equal(t, now.Add(24*time.Hour), cert.NotAfter)
+				assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment, cert.KeyUsage)
+				assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, cert.ExtKeyUsage)
+				assert.False(t, cert.IsCA)
+			},
+		},
+		{
+			name: "ecdsa client certificate with subject and sans",
+			spec: `{"spec":{"keyAlgorithm":"ecdsa","duration":"1h",
+				"subject":{"commonName":"webhook.default.svc"},
+				"dnsNames":["webhook.default.svc","webhook.default.svc.cluster.local"],
+				"ipAddresses":["10.0.0.1"],
+				"uris":["spiffe://cluster.local/ns/default/sa/webhook"],
+				"usages":["digital signature","client auth"]}}`,
+			checkCert: func(t *testing.T, cert *x509.Certificate) {
+				_, ok := cert.PublicKey.(*ecdsa.PublicKey)
+				require.True(t, ok)
+				assert.Equal(t, now.Add(time.Hour), cert.NotAfter)
+				assert.Equal(t, "webhook.default.svc", cert.Subject.CommonName)
+				assert.Equal(t, []string{"webhook.default.svc", "webhook.default.svc.cluster.local"}, cert.DNSNames)
+				require.Len(t, cert.IPAddresses, 1)
+				assert.Equal(t, "10.0.0.1", cert.IPAddresses[0].String())
+				require.Len(t, cert.URIs, 1)
+				assert.Equal(t, x509.KeyUsageDigitalSignature, cert.KeyUsage)
+				assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+			},
+		},
+		{
+			name: "ed25519 ca certificate can sign certificates",
+			spec: `{"spec":{"keyAlgorithm":"ed25519","isCA":true,"subject":{"commonName":"dev ca"},"usages":["digital signature"]}}`,
+			checkCert: func(t *testing.T, cert *x509.Certificate) {
+				_, ok := cert.PublicKey.(ed25519.PublicKey)
+				require.True(t, ok)
+				assert.True(t, cert.IsCA)
+				assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageCertSign, cert.KeyUsage)
+			},
+		},
+		{
+			name:    "invalid ip address should result in error",
+			spec:    `{"spec":{"ipAddresses":["not-an-ip"]}}`,
+			wantErr: `invalid ip address "not-an-ip"`,
+		},
+		{
+			name:    "unsupported usage should result in error",
+			spec:    `{"spec":{"usages":["crl sign"]}}`,
+			wantErr: "unsupported usage: crl sign",
+		},
+		{
+			name:    "non positive duration should result in error",
+			spec:    `{"spec":{"duration":"0s"}}`,
+			wantErr: "duration must be positive",
+		},
+		{
+			name:    "renewBefore not less than duration should result in error",
+			spec:    `{"spec":{"duration":"1h","renewBefore":"1h"}}`,
+			wantErr: "renewBefore must be positive and less than the duration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &SelfSignedGenerator{}
+			got, err := g.generate(&apiextensions.JSON{Raw: []byte(tt.spec)}, generateKey, func() time.Time { return now })
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, got["tls.crt"], got["ca.crt"])
+
+			block, _ := pem.Decode(got["tls.crt"])
+			require.NotNil(t, block)
+			assert.Equal(t, "CERTIFICATE", block.Type)
+			cert, err := x509.ParseCertificate(block.Bytes)
+			require.NoError(t, err)
+			assert.NoError(t, cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature))
+			assert.Equal(t, 1, cert.SerialNumber.Sign())
+
+			block, _ = pem.Decode(got["tls.key"])
+			require.NotNil(t, block)
+			assert.Equal(t, "PRIVATE KEY", block.Type)
+			_, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+			require.NoError(t, err)
+			tt.checkCert(t, cert)
+		})
+	}
+}
+
+func TestSelfSignedRenewAt(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := &SelfSignedGenerator{}
+	tests := []struct {
+		name string
+		spec string
+		want time.Time
+	}{
+		{
+			name: "renews after two thirds of the duration by default",
+			spec: `{"spec":{"duration":"3h"}}`,
+			want: now.Add(2 * time.Hour),
+		},
+		{
+			name: "renews renewBefore the expiry",
+			spec: `{"spec":{"duration":"24h","renewBefore":"1h"}}`,
+			want: now.Add(23 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &apiextensions.JSON{Raw: []byte(tt.spec)}
+			data, err := g.generate(spec, generateKey, func() time.Time { return now })
+			require.NoError(t, err)
+			got, err := g.RenewAt(spec, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := g.RenewAt(&apiextensions.JSON{Raw: []byte(`{}`)}, map[string][]byte{})
+	assert.ErrorContains(t, err, "no certificate found in tls.crt")
+}
@@ -0,0 +1,162 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpgkey
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+type Generator struct{}
+
+const (
+	defaultRSAKeySize = 4096
+	minRSAKeySize     = 2048
+
+	errNoSpec          = "no config spec provided"
+	errParseSpec       = "unable to parse spec: %w"
+	errNoName          = "name must be set"
+	errInvalidUserID   = "name, email and comment must not contain any of ()<> or NUL"
+	errKeyAlgorithm    = "unsupported key algorithm: %s"
+	errKeySize         = "rsa key size must be at least %d bits, got %d"
+	errExpiry          = "expiry must be at least 1s and at most %d seconds, got %s"
+	errGenerateKey     = "unable to generate key: %w"
+	errMarshalPrivKey  = "unable to marshal private key: %w"
+	errMarshalPubKey   = "unable to marshal public key: %w"
+	invalidUserIDChars = "()<>\x00"
+)
+
+type nowFunc func() time.Time
+
+func (g *Generator) Generate(_ context.Context, jsonSpec *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, error) {
+	return g.generate(jsonSpec, time.Now)
+}
+
+func (g *Generator) generate(jsonSpec *apiextensions.JSON, now nowFunc) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	if res.Spec.Name == "" {
+		return nil, fmt.Errorf(errNoName)
+	}
+	for _, field := range []string{res.Spec.Name, res.Spec.Email, res.Spec.Comment} {
+		if strings.ContainsAny(field, invalidUserIDChars) {
+			return nil, fmt.Errorf(errInvalidUserID)
+		}
+	}
+	config, err := keyConfig(&res.Spec, now)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := openpgp.NewEntity(res.Spec.Name, res.Spec.Comment, res.Spec.Email, config)
+	if err != nil {
+		return nil, fmt.Errorf(errGenerateKey, err)
+	}
+
+	privateKey, err := armored(openpgp.PrivateKeyType, func(w *bytes.Buffer) error {
+		return entity.SerializePrivate(w, config)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errMarshalPrivKey, err)
+	}
+	publicKey, err := armored(openpgp.PublicKeyType, func(w *bytes.Buffer) error {
+		return entity.Serialize(w)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errMarshalPubKey, err)
+	}
+	return map[string][]byte{
+		"privateKey":  privateKey,
+		"publicKey":   publicKey,
+		"fingerprint": []byte(fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint)),
+	}, nil
+}
+
+// keyConfig translates the spec into the configuration of the key.
+func keyConfig(spec *genv1alpha1.GPGKeySpec, now nowFunc) (*packet.Config, error) {
+	config := &packet.Config{Time: now}
+	switch spec.KeyAlgorithm {
+	case "", genv1alpha1.GPGKeyAlgorithmEd25519:
+		config.Algorithm = packet.PubKeyAlgoEdDSA
+		config.Curve = packet.Curve25519
+	case genv1alpha1.GPGKeyAlgorithmRSA:
+		config.Algorithm = packet.PubKeyAlgoRSA
+		config.RSABits = defaultRSAKeySize
+		if spec.KeySize != nil {
+			config.RSABits = *spec.KeySize
+		}
+		if config.RSABits < minRSAKeySize {
+			return nil, fmt.Errorf(errKeySize, minRSAKeySize, config.RSABits)
+		}
+	default:
+		return nil, fmt.Errorf(errKeyAlgorithm, spec.KeyAlgorithm)
+	}
+	if spec.Expiry != nil {
+		secs := spec.Expiry.Duration / time.Second
+		if secs < 1 || secs > math.MaxUint32 {
+			return nil, fmt.Errorf(errExpiry, uint32(math.MaxUint32), spec.Expiry.Duration)
+		}
+		config.KeyLifetimeSecs = uint32(secs)
+	}
+	return config, nil
+}
+
+// armored returns the ASCII armored output of serialize.
+func armored(blockType string, serialize func(w *bytes.Buffer) error) ([]byte, error) {
+	var raw bytes.Buffer
+	if err := serialize(&raw); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	w, err := armor.Encode(&out, blockType, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(raw.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+func parseSpec(data []byte) (*genv1alpha1.GPGKey, error) {
+	var spec genv1alpha1.GPGKey
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.GPGKeyKind, &Generator{})
+}
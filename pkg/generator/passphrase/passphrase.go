@@ -0,0 +1,112 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package passphrase
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+type Generator struct{}
+
+const (
+	defaultWords      = 7
+	maxWords          = 64
+	defaultSeparators = "-"
+
+	errNoSpec    = "no config spec provided"
+	errParseSpec = "unable to parse spec: %w"
+	errWords     = "words must be between 1 and %d, got %d"
+	errRandom    = "unable to generate random number: %w"
+)
+
+// randIntFunc returns a uniform random number in [0, n).
+type randIntFunc func(n int) (int, error)
+
+func (g *Generator) Generate(_ context.Context, jsonSpec *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, error) {
+	return g.generate(jsonSpec, randInt)
+}
+
+func (g *Generator) generate(jsonSpec *apiextensions.JSON, randN randIntFunc) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	words := defaultWords
+	if res.Spec.Words != 0 {
+		words = res.Spec.Words
+	}
+	if words < 1 || words > maxWords {
+		return nil, fmt.Errorf(errWords, maxWords, words)
+	}
+	separators := []rune(defaultSeparators)
+	if res.Spec.Separators != nil {
+		separators = []rune(*res.Spec.Separators)
+	}
+
+	var pass strings.Builder
+	for i := 0; i < words; i++ {
+		if i > 0 && len(separators) > 0 {
+			n, err := randN(len(separators))
+			if err != nil {
+				return nil, fmt.Errorf(errRandom, err)
+			}
+			pass.WriteRune(separators[n])
+		}
+		n, err := randN(len(wordlist))
+		if err != nil {
+			return nil, fmt.Errorf(errRandom, err)
+		}
+		word := wordlist[n]
+		if res.Spec.Capitalize {
+			// the words of the list are lowercase ascii.
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		pass.WriteString(word)
+	}
+	return map[string][]byte{
+		"passphrase": []byte(pass.String()),
+	}, nil
+}
+
+func randInt(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(i.Int64()), nil
+}
+
+func parseSpec(data []byte) (*genv1alpha1.Passphrase, error) {
+	var spec genv1alpha1.Passphrase
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.PassphraseKind, &Generator{})
+}
@@ -0,0 +1,125 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package passphrase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// sequence returns the given numbers in order, modulo the requested bound.
+func sequence(numbers ...int) randIntFunc {
+	i := 0
+	return func(n int) (int, error) {
+		v := numbers[i%len(numbers)] % n
+		i++
+		return v, nil
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *apiextensions.JSON
+		randN   randIntFunc
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "no json spec should result in error",
+			spec:    nil,
+			wantErr: errNoSpec,
+		},
+		{
+			name:    "invalid json spec should result in error",
+			spec:    &apiextensions.JSON{Raw: []byte(`no json`)},
+			wantErr: "unable to parse spec",
+		},
+		{
+			name:  "empty spec should return seven words separated by dashes",
+			spec:  &apiextensions.JSON{Raw: []byte(`{}`)},
+			randN: sequence(0),
+			want:  "abandon-abandon-abandon-abandon-abandon-abandon-abandon",
+		},
+		{
+			name:  "separators are picked at random",
+			spec:  &apiextensions.JSON{Raw: []byte(`{"spec":{"words":3,"separators":" .","capitalize":true}}`)},
+			randN: sequence(1, 1, 0, 0, 2047),
+			want:  "Ability.Abandon Zoo",
+		},
+		{
+			name:  "empty separators concatenate the words",
+			spec:  &apiextensions.JSON{Raw: []byte(`{"spec":{"words":2,"separators":""}}`)},
+			randN: sequence(2, 3),
+			want:  "ableabout",
+		},
+		{
+			name:    "too many words should result in error",
+			spec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"words":65}}`)},
+			wantErr: "words must be between 1 and 64, got 65",
+		},
+		{
+			name:    "negative words should result in error",
+			spec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"words":-1}}`)},
+			wantErr: "words must be between 1 and 64, got -1",
+		},
+		{
+			name: "random error should be returned",
+			spec: &apiextensions.JSON{Raw: []byte(`{}`)},
+			randN: func(int) (int, error) {
+				return 0, errors.New("boom")
+			},
+			wantErr: "unable to generate random number: boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			got, err := g.generate(tt.spec, tt.randN)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]byte{"passphrase": []byte(tt.want)}, got)
+		})
+	}
+}
+
+func TestGenerateRandom(t *testing.T) {
+	g := &Generator{}
+	got, err := g.generate(&apiextensions.JSON{Raw: []byte(`{"spec":{"words":5,"separators":"_"}}`)}, randInt)
+	require.NoError(t, err)
+	words := strings.Split(string(got["passphrase"]), "_")
+	require.Len(t, words, 5)
+	for _, word := range words {
+		assert.Contains(t, wordlist, word)
+	}
+}
+
+func TestWordlist(t *testing.T) {
+	require.Len(t, wordlist, 2048)
+	seen := make(map[string]bool, len(wordlist))
+	for _, word := range wordlist {
+		assert.False(t, seen[word], word)
+		seen[word] = true
+		assert.Equal(t, strings.ToLower(word), word)
+	}
+}
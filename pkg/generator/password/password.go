@@ -37,6 +37,8 @@ const (
 	errNoSpec    = "no config spec provided"
 	errParseSpec = "unable to parse spec: %w"
 	errGetToken  = "unable to get authorization token: %w"
+	errPolicy    = "number of digits (%d) and symbols (%d) must not exceed the length (%d)"
+	errNoSymbols = "symbolCharacters must not be empty when symbols are requested"
 )
 
 type generateFunc func(
@@ -79,6 +81,12 @@ func (g *Generator) generate(jsonSpec *apiextensions.JSON, passGen generateFunc)
 	if res.Spec.Symbols != nil {
 		symbols = *res.Spec.Symbols
 	}
+	if digits < 0 || symbols < 0 || digits+symbols > passLen {
+		return nil, fmt.Errorf(errPolicy, digits, symbols, passLen)
+	}
+	if symbols > 0 && symbolCharacters == "" {
+		return nil, fmt.Errorf(errNoSymbols)
+	}
 	pass, err := passGen(
 		passLen,
 		symbols,
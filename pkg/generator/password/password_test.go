@@ -95,6 +95,41 @@ func TestGenerate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "digits and symbols exceeding the length should result in error",
+			args: args{
+				jsonSpec: &apiextensions.JSON{
+					Raw: []byte(`{"spec":{"length":8,"digits":5,"symbols":5}}`),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty symbol characters with symbols should result in error",
+			args: args{
+				jsonSpec: &apiextensions.JSON{
+					Raw: []byte(`{"spec":{"length":8,"symbols":2,"symbolCharacters":""}}`),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty symbol characters without symbols should be accepted",
+			args: args{
+				jsonSpec: &apiextensions.JSON{
+					Raw: []byte(`{"spec":{"length":8,"symbols":0,"symbolCharacters":""}}`),
+				},
+				passGen: func(len int, symbols int, symbolCharacters string, digits int, noUpper bool, allowRepeat bool,
+				) (string, error) {
+					assert.Equal(t, 0, symbols)
+					assert.Equal(t, "", symbolCharacters)
+					return "foobar", nil
+				},
+			},
+			want: map[string][]byte{
+				"password": []byte(`foobar`),
+			},
+		},
 		{
 			name: "generator error should be returned",
 			args: args{
@@ -12,16 +12,21 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package register registers the generators to the controller schema.
+// Generators that use the client of a provider are imported in their own
+// file and are only compiled in with the provider, see
+// pkg/provider/register. The other generators are always registered.
+//
 //nolint:revive
 package register
 
-// packages imported here are registered to the controller schema.
-
 import (
-	_ "github.com/external-secrets/external-secrets/pkg/generator/acr"
-	_ "github.com/external-secrets/external-secrets/pkg/generator/ecr"
+	_ "github.com/external-secrets/external-secrets/pkg/generator/artifactory"
+	_ "github.com/external-secrets/external-secrets/pkg/generator/csr"
 	_ "github.com/external-secrets/external-secrets/pkg/generator/fake"
-	_ "github.com/external-secrets/external-secrets/pkg/generator/gcr"
+	_ "github.com/external-secrets/external-secrets/pkg/generator/gpgkey"
+	_ "github.com/external-secrets/external-secrets/pkg/generator/passphrase"
 	_ "github.com/external-secrets/external-secrets/pkg/generator/password"
-	_ "github.com/external-secrets/external-secrets/pkg/generator/vault"
+	_ "github.com/external-secrets/external-secrets/pkg/generator/sshkey"
+	_ "github.com/external-secrets/external-secrets/pkg/generator/totp"
 )
@@ -0,0 +1,55 @@
+//go:build select_providers && provider_chef && provider_kubernetes
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package register_test
+
+import (
+	"slices"
+	"testing"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	_ "github.com/external-secrets/external-secrets/pkg/generator/register"
+	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
+)
+
+// TestSelectProviders checks that a build with selected providers registers
+// neither other providers nor the generators that use them. Run it with
+//
+//	go test -tags select_providers,provider_chef,provider_kubernetes ./pkg/generator/register/
+func TestSelectProviders(t *testing.T) {
+	got := esv1beta1.RegisteredProviders()
+	want := []string{"chef", "kubernetes"}
+	if !slices.Equal(got, want) {
+		t.Errorf("RegisteredProviders() = %v, want %v", got, want)
+	}
+	for _, kind := range []string{genv1alpha1.ChefClientKeyKind, genv1alpha1.ChefValidatorKeyKind, genv1alpha1.PasswordKind, genv1alpha1.TOTPKind} {
+		if _, ok := genv1alpha1.GetGeneratorByName(kind); !ok {
+			t.Errorf("generator %s is not registered", kind)
+		}
+	}
+	for _, kind := range []string{
+		genv1alpha1.ACRAccessTokenKind,
+		genv1alpha1.ECRAuthorizationTokenKind,
+		genv1alpha1.GCRAccessTokenKind,
+		genv1alpha1.VaultDynamicSecretKind,
+		genv1alpha1.WebhookKind,
+	} {
+		if _, ok := genv1alpha1.GetGeneratorByName(kind); ok {
+			t.Errorf("generator %s of a provider that is not selected is registered", kind)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshkey
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+type Generator struct{}
+
+const (
+	defaultRSAKeySize   = 4096
+	minRSAKeySize       = 2048
+	defaultECDSAKeySize = 256
+
+	errNoSpec         = "no config spec provided"
+	errParseSpec      = "unable to parse spec: %w"
+	errKeyType        = "unsupported key type: %s"
+	errKeySize        = "rsa key size must be at least %d bits, got %d"
+	errECDSAKeySize   = "unsupported ecdsa key size %d, must be one of 256, 384 or 521"
+	errKeyFormat      = "unsupported private key format: %s"
+	errGenerateKey    = "unable to generate key: %w"
+	errMarshalPrivKey = "unable to marshal private key: %w"
+	errMarshalPubKey  = "unable to marshal public key: %w"
+)
+
+type generateFunc func(keyType genv1alpha1.SSHKeyType, keySize int) (crypto.Signer, error)
+
+func (g *Generator) Generate(_ context.Context, jsonSpec *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, error) {
+	return g.generate(jsonSpec, generateKey)
+}
+
+func (g *Generator) generate(jsonSpec *apiextensions.JSON, keyGen generateFunc) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	keyType := res.Spec.KeyType
+	if keyType == "" {
+		keyType = genv1alpha1.SSHKeyTypeED25519
+	}
+	keySize, err := keySizeFor(keyType, res.Spec.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	format := res.Spec.PrivateKeyFormat
+	if format == "" {
+		format = genv1alpha1.SSHKeyFormatOpenSSH
+	}
+	if format != genv1alpha1.SSHKeyFormatOpenSSH && format != genv1alpha1.SSHKeyFormatPKCS8 {
+		return nil, fmt.Errorf(errKeyFormat, format)
+	}
+	key, err := keyGen(keyType, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	privBlock, err := marshalPrivateKey(key, format, res.Spec.Comment)
+	if err != nil {
+		return nil, fmt.Errorf(errMarshalPrivKey, err)
+	}
+	pub, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf(errMarshalPubKey, err)
+	}
+	return map[string][]byte{
+		"privateKey": pem.EncodeToMemory(privBlock),
+		"publicKey":  authorizedKey(pub, res.Spec.Comment),
+	}, nil
+}
+
+// authorizedKey returns the public key in authorized_keys format
+// with the comment appended.
+func authorizedKey(pub ssh.PublicKey, comment string) []byte {
+	line := ssh.MarshalAuthorizedKey(pub)
+	if comment == "" {
+		return line
+	}
+	// MarshalAuthorizedKey terminates the line with a newline
+	return append(append(line[:len(line)-1], ' '), comment+"\n"...)
+}
+
+// marshalPrivateKey encodes the private key in the given format. The comment
+// is only part of the OpenSSH format.
+func marshalPrivateKey(key crypto.Signer, format genv1alpha1.SSHKeyFormat, comment string) (*pem.Block, error) {
+	if format == genv1alpha1.SSHKeyFormatPKCS8 {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "PRIVATE KEY", Bytes: der}, nil
+	}
+	return ssh.MarshalPrivateKey(key, comment)
+}
+
+func keySizeFor(keyType genv1alpha1.SSHKeyType, keySize *int) (int, error) {
+	switch keyType {
+	case genv1alpha1.SSHKeyTypeRSA:
+		if keySize == nil {
+			return defaultRSAKeySize, nil
+		}
+		if *keySize < minRSAKeySize {
+			return 0, fmt.Errorf(errKeySize, minRSAKeySize, *keySize)
+		}
+		return *keySize, nil
+	case genv1alpha1.SSHKeyTypeECDSA:
+		if keySize == nil {
+			return defaultECDSAKeySize, nil
+		}
+		if _, err := curve(*keySize); err != nil {
+			return 0, err
+		}
+		return *keySize, nil
+	default:
+		// the size of ed25519 keys is fixed, unknown types fail in generateKey.
+		return 0, nil
+	}
+}
+
+func generateKey(keyType genv1alpha1.SSHKeyType, keySize int) (crypto.Signer, error) {
+	switch keyType {
+	case genv1alpha1.SSHKeyTypeED25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerateKey, err)
+		}
+		return key, nil
+	case genv1alpha1.SSHKeyTypeRSA:
+		key, err := rsa.GenerateKey(rand.Reader, keySize)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerateKey, err)
+		}
+		return key, nil
+	case genv1alpha1.SSHKeyTypeECDSA:
+		c, err := curve(keySize)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ecdsa.GenerateKey(c, rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerateKey, err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf(errKeyType, keyType)
+	}
+}
+
+func curve(keySize int) (elliptic.Curve, error) {
+	switch keySize {
+	case 256:
+		return elliptic.P256(), nil
+	case 384:
+		return elliptic.P384(), nil
+	case 521:
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf(errECDSAKeySize, keySize)
+	}
+}
+
+func parseSpec(data []byte) (*genv1alpha1.SSHKey, error) {
+	var spec genv1alpha1.SSHKey
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.SSHKeyKind, &Generator{})
+}
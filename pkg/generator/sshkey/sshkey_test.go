@@ -0,0 +1,164 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshkey
+
+import (
+	"crypto"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        *apiextensions.JSON
+		keyGen      generateFunc
+		wantType    string
+		wantComment string
+		wantPEMType string
+		wantErr     string
+	}{
+		{
+			name:    "no json spec should result in error",
+			spec:    nil,
+			wantErr: errNoSpec,
+		},
+		{
+			name:    "invalid json spec should result in error",
+			spec:    &apiextensions.JSON{Raw: []byte(`no json`)},
+			wantErr: "unable to parse spec",
+		},
+		{
+			name:     "empty spec should generate ed25519 key",
+			spec:     &apiextensions.JSON{Raw: []byte(`{}`)},
+			keyGen:   generateKey,
+			wantType: ssh.KeyAlgoED25519,
+		},
+		{
+			name:        "rsa key with comment",
+			spec:        &apiextensions.JSON{Raw: []byte(`{"spec":{"keyType":"rsa","keySize":2048,"comment":"deploy@example.com"}}`)},
+			keyGen:      generateKey,
+			wantType:    ssh.KeyAlgoRSA,
+			wantComment: "deploy@example.com",
+		},
+		{
+			name:    "rsa key size below minimum should result in error",
+			spec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"keyType":"rsa","keySize":1024}}`)},
+			wantErr: "rsa key size must be at least 2048 bits",
+		},
+		{
+			name: "default rsa key size",
+			spec: &apiextensions.JSON{Raw: []byte(`{"spec":{"keyType":"rsa"}}`)},
+			keyGen: func(keyType genv1alpha1.SSHKeyType, keySize int) (crypto.Signer, error) {
+				assert.Equal(t, genv1alpha1.SSHKeyTypeRSA, keyType)
+				assert.Equal(t, defaultRSAKeySize, keySize)
+				return generateKey(keyType, minRSAKeySize)
+			},
+			wantType: ssh.KeyAlgoRSA,
+		},
+		{
+			name:     "ecdsa key with default curve",
+			spec:     &apiextensions.JSON{Raw: []byte(`{"spec":{"keyType":"ecdsa"}}`)},
+			keyGen:   generateKey,
+			wantType: ssh.KeyAlgoECDSA256,
+		},
+		{
+			name:     "ecdsa key with p384 curve",
+			spec:     &apiextensions.JSON{Raw: []byte(`{"spec":{"keyType":"ecdsa","keySize":384}}`)},
+			keyGen:   generateKey,
+			wantType: ssh.KeyAlgoECDSA384,
+		},
+		{
+			name:    "unsupported ecdsa key size should result in error",
+			spec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"keyType":"ecdsa","keySize":2048}}`)},
+			wantErr: "unsupported ecdsa key size 2048",
+		},
+		{
+			name:        "ed25519 key in pkcs8 format",
+			spec:        &apiextensions.JSON{Raw: []byte(`{"spec":{"privateKeyFormat":"pkcs8","comment":"deploy@example.com"}}`)},
+			keyGen:      generateKey,
+			wantType:    ssh.KeyAlgoED25519,
+			wantComment: "deploy@example.com",
+			wantPEMType: "PRIVATE KEY",
+		},
+		{
+			name:        "ecdsa key in pkcs8 format",
+			spec:        &apiextensions.JSON{Raw: []byte(`{"spec":{"keyType":"ecdsa","privateKeyFormat":"pkcs8"}}`)},
+			keyGen:      generateKey,
+			wantType:    ssh.KeyAlgoECDSA256,
+			wantPEMType: "PRIVATE KEY",
+		},
+		{
+			name:    "unsupported private key format should result in error",
+			spec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"privateKeyFormat":"pem"}}`)},
+			wantErr: "unsupported private key format: pem",
+		},
+		{
+			name:    "unsupported key type should result in error",
+			spec:    &apiextensions.JSON{Raw: []byte(`{"spec":{"keyType":"dsa"}}`)},
+			keyGen:  generateKey,
+			wantErr: "unsupported key type: dsa",
+		},
+		{
+			name: "generator error should be returned",
+			spec: &apiextensions.JSON{Raw: []byte(`{}`)},
+			keyGen: func(keyType genv1alpha1.SSHKeyType, keySize int) (crypto.Signer, error) {
+				return nil, fmt.Errorf("boom")
+			},
+			wantErr: "boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			got, err := g.generate(tt.spec, tt.keyGen)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			pub, comment, _, rest, err := ssh.ParseAuthorizedKey(got["publicKey"])
+			require.NoError(t, err)
+			assert.Empty(t, rest)
+			assert.Equal(t, tt.wantType, pub.Type())
+			assert.Equal(t, tt.wantComment, comment)
+
+			wantPEMType := tt.wantPEMType
+			if wantPEMType == "" {
+				wantPEMType = "OPENSSH PRIVATE KEY"
+			}
+			block, _ := pem.Decode(got["privateKey"])
+			require.NotNil(t, block)
+			assert.Equal(t, wantPEMType, block.Type)
+
+			priv, err := ssh.ParseRawPrivateKey(got["privateKey"])
+			require.NoError(t, err)
+			signer, ok := priv.(crypto.Signer)
+			require.True(t, ok)
+			privPub, err := ssh.NewPublicKey(signer.Public())
+			require.NoError(t, err)
+			assert.Equal(t, pub.Marshal(), privPub.Marshal())
+		})
+	}
+}
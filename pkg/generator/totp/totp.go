@@ -0,0 +1,220 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package totp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // SHA1 is the default algorithm of RFC 6238
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+)
+
+type Generator struct{}
+
+const (
+	defaultDigits = 6
+	defaultPeriod = 30
+
+	errNoSpec       = "no config spec provided"
+	errParseSpec    = "unable to parse spec: %w"
+	errNoStoreRef   = "no secretStoreRef in spec"
+	errNoSeedRef    = "no seedRef key in spec"
+	errGetStore     = "unable to get store client: %w"
+	errGetSeed      = "unable to get seed: %w"
+	errDecodeSeed   = "unable to decode seed: %w"
+	errEmptySeed    = "seed is empty"
+	errAlgorithm    = "unsupported algorithm %s"
+	errDigits       = "digits must be between 6 and 8, got %d"
+	errPeriod       = "period must be positive, got %d"
+	errOTPAuthURI   = "unable to parse otpauth uri: %w"
+	errOTPAuthParam = "invalid otpauth %s parameter %q"
+)
+
+type seedFetchFunc func(ctx context.Context, kube client.Client, spec *genv1alpha1.TOTPSpec, namespace string) ([]byte, error)
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	return g.generate(ctx, jsonSpec, kube, namespace, fetchSeed, time.Now)
+}
+
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string, fetch seedFetchFunc, now func() time.Time) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	if res.Spec.SecretStoreRef.Name == "" {
+		return nil, fmt.Errorf(errNoStoreRef)
+	}
+	if res.Spec.SeedRef.Key == "" {
+		return nil, fmt.Errorf(errNoSeedRef)
+	}
+	seed, err := fetch(ctx, kube, &res.Spec, namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errGetSeed, err)
+	}
+	params, err := parseSeed(string(seed), res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	t := now()
+	code, err := params.code(t)
+	if err != nil {
+		return nil, err
+	}
+	timeLeft := params.period - int(t.Unix()%int64(params.period))
+	return map[string][]byte{
+		"token":    []byte(code),
+		"timeLeft": []byte(strconv.Itoa(timeLeft)),
+	}, nil
+}
+
+// params are the inputs of the TOTP computation.
+type params struct {
+	key       []byte
+	algorithm genv1alpha1.TOTPAlgorithm
+	digits    int
+	period    int
+}
+
+// parseSeed decodes a base32 seed or an otpauth:// URI.
+// Parameters of the URI take precedence over the spec.
+func parseSeed(seed string, spec genv1alpha1.TOTPSpec) (*params, error) {
+	p := &params{
+		algorithm: spec.Algorithm,
+		digits:    spec.Digits,
+		period:    spec.Period,
+	}
+	secret := strings.TrimSpace(seed)
+	if strings.HasPrefix(secret, "otpauth://") {
+		u, err := url.Parse(secret)
+		if err != nil {
+			return nil, fmt.Errorf(errOTPAuthURI, err)
+		}
+		q := u.Query()
+		secret = q.Get("secret")
+		if v := q.Get("algorithm"); v != "" {
+			p.algorithm = genv1alpha1.TOTPAlgorithm(strings.ToUpper(v))
+		}
+		if v := q.Get("digits"); v != "" {
+			if p.digits, err = strconv.Atoi(v); err != nil {
+				return nil, fmt.Errorf(errOTPAuthParam, "digits", v)
+			}
+		}
+		if v := q.Get("period"); v != "" {
+			if p.period, err = strconv.Atoi(v); err != nil {
+				return nil, fmt.Errorf(errOTPAuthParam, "period", v)
+			}
+		}
+	}
+	key, err := decodeBase32(secret)
+	if err != nil {
+		return nil, fmt.Errorf(errDecodeSeed, err)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf(errEmptySeed)
+	}
+	p.key = key
+	if p.algorithm == "" {
+		p.algorithm = genv1alpha1.TOTPAlgorithmSHA1
+	}
+	if p.digits == 0 {
+		p.digits = defaultDigits
+	}
+	if p.period == 0 {
+		p.period = defaultPeriod
+	}
+	if p.digits < 6 || p.digits > 8 {
+		return nil, fmt.Errorf(errDigits, p.digits)
+	}
+	if p.period < 0 {
+		return nil, fmt.Errorf(errPeriod, p.period)
+	}
+	return p, nil
+}
+
+// code computes the TOTP code for the given time as described in RFC 6238.
+func (p *params) code(t time.Time) (string, error) {
+	var h func() hash.Hash
+	switch p.algorithm {
+	case genv1alpha1.TOTPAlgorithmSHA1:
+		h = sha1.New
+	case genv1alpha1.TOTPAlgorithmSHA256:
+		h = sha256.New
+	case genv1alpha1.TOTPAlgorithmSHA512:
+		h = sha512.New
+	default:
+		return "", fmt.Errorf(errAlgorithm, p.algorithm)
+	}
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/int64(p.period)))
+	mac := hmac.New(h, p.key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, see RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(math.Pow10(p.digits))
+	return fmt.Sprintf("%0*d", p.digits, value%mod), nil
+}
+
+// decodeBase32 decodes seeds the way authenticator apps accept them:
+// case insensitive, with optional padding and whitespace.
+func decodeBase32(s string) ([]byte, error) {
+	s = strings.ToUpper(strings.Join(strings.Fields(s), ""))
+	s = strings.TrimRight(s, "=")
+	return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+}
+
+// fetchSeed reads the seed from the referenced store
+// using the same rules as an ExternalSecret in the namespace.
+func fetchSeed(ctx context.Context, kube client.Client, spec *genv1alpha1.TOTPSpec, namespace string) ([]byte, error) {
+	mgr := secretstore.NewManager(kube, spec.Controller, false)
+	defer mgr.Close(ctx)
+	c, err := mgr.Get(ctx, spec.SecretStoreRef, namespace, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errGetStore, err)
+	}
+	return c.GetSecret(ctx, spec.SeedRef)
+}
+
+func parseSpec(data []byte) (*genv1alpha1.TOTP, error) {
+	var spec genv1alpha1.TOTP
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.TOTPKind, &Generator{})
+}
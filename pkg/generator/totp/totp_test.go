@@ -0,0 +1,154 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package totp
+
+import (
+	"context"
+	"encoding/base32"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+// test vectors from RFC 6238 appendix B.
+func TestCode(t *testing.T) {
+	seeds := map[genv1alpha1.TOTPAlgorithm]string{
+		genv1alpha1.TOTPAlgorithmSHA1:   "12345678901234567890",
+		genv1alpha1.TOTPAlgorithmSHA256: "12345678901234567890123456789012",
+		genv1alpha1.TOTPAlgorithmSHA512: "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+	tests := []struct {
+		unix      int64
+		algorithm genv1alpha1.TOTPAlgorithm
+		want      string
+	}{
+		{59, genv1alpha1.TOTPAlgorithmSHA1, "94287082"},
+		{59, genv1alpha1.TOTPAlgorithmSHA256, "46119246"},
+		{59, genv1alpha1.TOTPAlgorithmSHA512, "90693936"},
+		{1111111109, genv1alpha1.TOTPAlgorithmSHA1, "07081804"},
+		{1111111109, genv1alpha1.TOTPAlgorithmSHA256, "68084774"},
+		{1234567890, genv1alpha1.TOTPAlgorithmSHA512, "93441116"},
+		{20000000000, genv1alpha1.TOTPAlgorithmSHA1, "65353130"},
+	}
+	for _, tt := range tests {
+		p := &params{
+			key:       []byte(seeds[tt.algorithm]),
+			algorithm: tt.algorithm,
+			digits:    8,
+			period:    30,
+		}
+		got, err := p.code(time.Unix(tt.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%s at %d", tt.algorithm, tt.unix)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	seed := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	now := func() time.Time { return time.Unix(59, 0) }
+	tests := []struct {
+		name     string
+		spec     string
+		seed     string
+		fetchErr error
+		want     map[string][]byte
+		wantErr  string
+	}{
+		{
+			name:    "invalid json spec should result in error",
+			spec:    `no json`,
+			wantErr: "unable to parse spec",
+		},
+		{
+			name:    "missing store ref should result in error",
+			spec:    `{"spec":{"seedRef":{"key":"mfa/api"}}}`,
+			wantErr: errNoStoreRef,
+		},
+		{
+			name:    "missing seed ref should result in error",
+			spec:    `{"spec":{"secretStoreRef":{"name":"chef"}}}`,
+			wantErr: errNoSeedRef,
+		},
+		{
+			name: "default parameters",
+			spec: `{"spec":{"secretStoreRef":{"name":"chef"},"seedRef":{"key":"mfa/api","property":"seed"}}}`,
+			seed: seed,
+			want: map[string][]byte{
+				"token":    []byte("287082"),
+				"timeLeft": []byte("1"),
+			},
+		},
+		{
+			name: "lowercase seed without padding",
+			spec: `{"spec":{"secretStoreRef":{"name":"chef"},"seedRef":{"key":"mfa/api"},"digits":8}}`,
+			seed: "gezd gnbv gy3t qojq gezd gnbv gy3t qojq",
+			want: map[string][]byte{
+				"token":    []byte("94287082"),
+				"timeLeft": []byte("1"),
+			},
+		},
+		{
+			name: "otpauth uri parameters take precedence",
+			spec: `{"spec":{"secretStoreRef":{"name":"chef"},"seedRef":{"key":"mfa/api"}}}`,
+			seed: "otpauth://totp/Example:ops@example.com?secret=" + seed + "&issuer=Example&digits=8&period=60",
+			// period 60 results in counter 0, see RFC 4226 appendix D
+			want: map[string][]byte{
+				"token":    []byte("84755224"),
+				"timeLeft": []byte("1"),
+			},
+		},
+		{
+			name:    "invalid seed should result in error",
+			spec:    `{"spec":{"secretStoreRef":{"name":"chef"},"seedRef":{"key":"mfa/api"}}}`,
+			seed:    "not base32!",
+			wantErr: "unable to decode seed",
+		},
+		{
+			name:    "unsupported digits should result in error",
+			spec:    `{"spec":{"secretStoreRef":{"name":"chef"},"seedRef":{"key":"mfa/api"},"digits":10}}`,
+			seed:    seed,
+			wantErr: "digits must be between 6 and 8, got 10",
+		},
+		{
+			name:     "fetch error should be returned",
+			spec:     `{"spec":{"secretStoreRef":{"name":"chef"},"seedRef":{"key":"mfa/api"}}}`,
+			fetchErr: errors.New("not found"),
+			wantErr:  "unable to get seed: not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			fetch := func(_ context.Context, _ client.Client, spec *genv1alpha1.TOTPSpec, _ string) ([]byte, error) {
+				assert.Equal(t, "mfa/api", spec.SeedRef.Key)
+				return []byte(tt.seed), tt.fetchErr
+			}
+			got, err := g.generate(context.Background(), &apiextensions.JSON{Raw: []byte(tt.spec)}, nil, "default", fetch, now)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
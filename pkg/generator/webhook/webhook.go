@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	provider "github.com/external-secrets/external-secrets/pkg/provider/webhook"
+)
+
+type Generator struct{}
+
+const (
+	errNoSpec    = "no config spec provided"
+	errParseSpec = "unable to parse spec: %w"
+	errNoURL     = "no url in spec"
+	errCall      = "unable to call webhook: %w"
+	errNoData    = "webhook returned no keys"
+)
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	if res.Spec.URL == "" {
+		return nil, fmt.Errorf(errNoURL)
+	}
+	data, err := provider.GetGeneratorData(ctx, kube, webhookProvider(&res.Spec), namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errCall, err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf(errNoData)
+	}
+	return data, nil
+}
+
+// webhookProvider converts the generator spec into the configuration
+// of the webhook provider which performs the request.
+func webhookProvider(spec *genv1alpha1.WebhookSpec) *esv1beta1.WebhookProvider {
+	return &esv1beta1.WebhookProvider{
+		Method:     spec.Method,
+		URL:        spec.URL,
+		Headers:    spec.Headers,
+		Body:       spec.Body,
+		Timeout:    spec.Timeout,
+		Result:     spec.Result,
+		Secrets:    spec.Secrets,
+		CABundle:   spec.CABundle,
+		CAProvider: spec.CAProvider,
+	}
+}
+
+func parseSpec(data []byte) (*genv1alpha1.Webhook, error) {
+	var spec genv1alpha1.Webhook
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.WebhookKind, &Generator{})
+}
@@ -0,0 +1,122 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGenerate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/credentials":
+			fmt.Fprint(w, `{"data":{"username":"app","password":"generated"}}`)
+		case "/number":
+			fmt.Fprint(w, `{"ttl":3600}`)
+		case "/empty":
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "broker-auth",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"token": []byte("s3cr3t"),
+		},
+	}).Build()
+
+	spec := func(path, jsonPath string) *apiextensions.JSON {
+		return &apiextensions.JSON{Raw: []byte(fmt.Sprintf(`{"spec":{
+"url":"%s%s",
+"method":"POST",
+"headers":{"Authorization":"Bearer {{ .auth.token }}"},
+"result":{"jsonPath":"%s"},
+"secrets":[{"name":"auth","secretRef":{"name":"broker-auth"}}]}}`, srv.URL, path, jsonPath))}
+	}
+
+	tests := []struct {
+		name    string
+		spec    *apiextensions.JSON
+		want    map[string][]byte
+		wantErr string
+	}{
+		{
+			name:    "no spec",
+			wantErr: errNoSpec,
+		},
+		{
+			name:    "no url",
+			spec:    &apiextensions.JSON{Raw: []byte(`{"spec":{}}`)},
+			wantErr: errNoURL,
+		},
+		{
+			name: "maps response keys",
+			spec: spec("/credentials", "$.data"),
+			want: map[string][]byte{
+				"username": []byte("app"),
+				"password": []byte("generated"),
+			},
+		},
+		{
+			name:    "non string value",
+			spec:    spec("/number", ""),
+			wantErr: "wrong type in key 'ttl'",
+		},
+		{
+			name:    "empty response",
+			spec:    spec("/empty", ""),
+			wantErr: errNoData,
+		},
+		{
+			name:    "endpoint error",
+			spec:    spec("/fail", ""),
+			wantErr: "endpoint gave error 500",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			got, err := g.Generate(context.Background(), tt.spec, kube, "default")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
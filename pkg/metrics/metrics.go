@@ -15,6 +15,8 @@ limitations under the License.
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
@@ -24,20 +26,137 @@ import (
 const (
 	ExternalSecretSubsystem = "externalsecret"
 	providerAPICalls        = "provider_api_calls_count"
+
+	ProviderSubsystem      = "provider"
+	providerRateLimitLeft  = "ratelimit_remaining"
+	providerThrottleEvents = "throttle_events_count"
+	providerCircuitState   = "circuit_breaker_state"
+	providerCacheFallbacks = "cache_fallback_count"
+	providerHealthCheck    = "health_check_duration_seconds"
+	providerHealthy        = "health_check_healthy"
+)
+
+// CircuitState is the state of the circuit breaker of a store, it is the
+// value of the provider_circuit_breaker_state gauge.
+type CircuitState float64
+
+const (
+	// CircuitClosed lets requests pass.
+	CircuitClosed CircuitState = 0
+	// CircuitOpen fails requests without sending them to the provider.
+	CircuitOpen CircuitState = 1
+	// CircuitHalfOpen lets requests pass after the circuit was open, the
+	// next response decides whether it closes or opens again.
+	CircuitHalfOpen CircuitState = 2
 )
 
+var storeLabelNames = []string{"provider", "store_kind", "namespace", "name"}
+
 var (
 	syncCallsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Subsystem: ExternalSecretSubsystem,
 		Name:      providerAPICalls,
 		Help:      "Number of API calls towards the secret provider",
 	}, []string{"provider", "call", "status"})
+
+	rateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ProviderSubsystem,
+		Name:      providerRateLimitLeft,
+		Help:      "Number of requests left in the current rate-limit window of the provider, as reported by the provider",
+	}, storeLabelNames)
+
+	throttleEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ProviderSubsystem,
+		Name:      providerThrottleEvents,
+		Help:      "Number of requests the provider rejected because of rate limits",
+	}, storeLabelNames)
+
+	circuitState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ProviderSubsystem,
+		Name:      providerCircuitState,
+		Help:      "State of the circuit breaker of the store: 0 closed, 1 open, 2 half-open",
+	}, storeLabelNames)
+
+	cacheFallbacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ProviderSubsystem,
+		Name:      providerCacheFallbacks,
+		Help:      "Number of values served from the provider cache because the provider failed",
+	}, storeLabelNames)
+
+	healthCheckDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: ProviderSubsystem,
+		Name:      providerHealthCheck,
+		Help:      "Duration of the steps of the health checks of the store: auth, validate and canary",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, append(storeLabelNames, "step", "status"))
+
+	healthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ProviderSubsystem,
+		Name:      providerHealthy,
+		Help:      "Result of the last health check of the store: 1 healthy, 0 unhealthy",
+	}, storeLabelNames)
 )
 
 func ObserveAPICall(provider, call string, err error) {
 	syncCallsTotal.WithLabelValues(provider, call, deriveStatus(err)).Inc()
 }
 
+// SetRateLimitRemaining reports the requests left in the current rate-limit
+// window of the provider of a store.
+func SetRateLimitRemaining(provider, storeKind, namespace, name string, remaining float64) {
+	rateLimitRemaining.WithLabelValues(provider, storeKind, namespace, name).Set(remaining)
+}
+
+// ObserveThrottle counts a request the provider of a store rejected because
+// of rate limits.
+func ObserveThrottle(provider, storeKind, namespace, name string) {
+	throttleEvents.WithLabelValues(provider, storeKind, namespace, name).Inc()
+}
+
+// SetCircuitState reports the state of the circuit breaker of a store.
+func SetCircuitState(provider, storeKind, namespace, name string, state CircuitState) {
+	circuitState.WithLabelValues(provider, storeKind, namespace, name).Set(float64(state))
+}
+
+// ObserveCacheFallback counts a value of a store that was served from the
+// provider cache because the provider failed.
+func ObserveCacheFallback(provider, storeKind, namespace, name string) {
+	cacheFallbacks.WithLabelValues(provider, storeKind, namespace, name).Inc()
+}
+
+// ObserveHealthCheckStep records the duration of a step of the health check
+// of a store.
+func ObserveHealthCheckStep(provider, storeKind, namespace, name, step string, d time.Duration, err error) {
+	healthCheckDuration.WithLabelValues(provider, storeKind, namespace, name, step, deriveStatus(err)).Observe(d.Seconds())
+}
+
+// SetHealthy reports the result of the last health check of a store.
+func SetHealthy(provider, storeKind, namespace, name string, ok bool) {
+	var v float64
+	if ok {
+		v = 1
+	}
+	healthy.WithLabelValues(provider, storeKind, namespace, name).Set(v)
+}
+
+// RemoveHealthCheckMetrics deletes the health check metrics of a store that
+// is not checked anymore.
+func RemoveHealthCheckMetrics(storeKind, namespace, name string) {
+	labels := prometheus.Labels{"store_kind": storeKind, "namespace": namespace, "name": name}
+	healthCheckDuration.DeletePartialMatch(labels)
+	healthy.DeletePartialMatch(labels)
+}
+
+// RemoveStoreMetrics deletes the rate-limit metrics of a deleted store.
+func RemoveStoreMetrics(storeKind, namespace, name string) {
+	labels := prometheus.Labels{"store_kind": storeKind, "namespace": namespace, "name": name}
+	rateLimitRemaining.DeletePartialMatch(labels)
+	throttleEvents.DeletePartialMatch(labels)
+	circuitState.DeletePartialMatch(labels)
+	cacheFallbacks.DeletePartialMatch(labels)
+	RemoveHealthCheckMetrics(storeKind, namespace, name)
+}
+
 func deriveStatus(err error) string {
 	if err != nil {
 		return constants.StatusError
@@ -46,5 +165,5 @@ func deriveStatus(err error) string {
 }
 
 func init() {
-	metrics.Registry.MustRegister(syncCallsTotal)
+	metrics.Registry.MustRegister(syncCallsTotal, rateLimitRemaining, throttleEvents, circuitState, cacheFallbacks, healthCheckDuration, healthy)
 }
@@ -0,0 +1,237 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin helps to implement out-of-tree provider plugins in Go.
+// NewServer serves the SecretsClient of a provider over the gRPC protocol
+// of pluginv1, e.g.:
+//
+//	srv := grpc.NewServer()
+//	pluginv1.RegisterProviderServer(srv, plugin.NewServer(newClient))
+//	srv.Serve(lis)
+package plugin
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	pluginv1 "github.com/external-secrets/external-secrets/pkg/plugin/api/v1"
+)
+
+// Store is the store a call is made for.
+type Store struct {
+	// Name of the store.
+	Name string
+	// Kind of the store, SecretStore or ClusterSecretStore.
+	Kind string
+	// Namespace of the resource that uses the store.
+	Namespace string
+	// Config of the store as JSON.
+	Config []byte
+	// Secrets referenced by the store, by their name in the store.
+	Secrets map[string][]byte
+}
+
+// NewClientFunc returns the client for a store. A client is created for
+// every call and closed after the call.
+type NewClientFunc func(ctx context.Context, store Store) (esv1beta1.SecretsClient, error)
+
+type server struct {
+	pluginv1.UnimplementedProviderServer
+	newClient NewClientFunc
+}
+
+// NewServer returns a ProviderServer that serves calls with the clients
+// returned by newClient. A NoSecretError of a client is returned as
+// NOT_FOUND, errors with a gRPC status are returned as is.
+func NewServer(newClient NewClientFunc) pluginv1.ProviderServer {
+	return &server{newClient: newClient}
+}
+
+func (s *server) GetSecret(ctx context.Context, req *pluginv1.GetSecretRequest) (*pluginv1.GetSecretResponse, error) {
+	var value []byte
+	err := s.withClient(ctx, req.GetStore(), func(c esv1beta1.SecretsClient) (err error) {
+		value, err = c.GetSecret(ctx, remoteRef(req.GetRef()))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1.GetSecretResponse{Value: value}, nil
+}
+
+func (s *server) GetSecretMap(ctx context.Context, req *pluginv1.GetSecretMapRequest) (*pluginv1.GetSecretMapResponse, error) {
+	var data map[string][]byte
+	err := s.withClient(ctx, req.GetStore(), func(c esv1beta1.SecretsClient) (err error) {
+		data, err = c.GetSecretMap(ctx, remoteRef(req.GetRef()))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1.GetSecretMapResponse{Data: data}, nil
+}
+
+func (s *server) GetAllSecrets(ctx context.Context, req *pluginv1.GetAllSecretsRequest) (*pluginv1.GetAllSecretsResponse, error) {
+	find := esv1beta1.ExternalSecretFind{
+		Path: req.GetFind().Path,
+		Tags: req.GetFind().GetTags(),
+	}
+	if req.GetFind().GetNameRegexp() != "" {
+		find.Name = &esv1beta1.FindName{RegExp: req.GetFind().GetNameRegexp()}
+	}
+	var data map[string][]byte
+	err := s.withClient(ctx, req.GetStore(), func(c esv1beta1.SecretsClient) (err error) {
+		data, err = c.GetAllSecrets(ctx, find)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1.GetAllSecretsResponse{Data: data}, nil
+}
+
+func (s *server) PushSecret(ctx context.Context, req *pluginv1.PushSecretRequest) (*pluginv1.PushSecretResponse, error) {
+	secret := &corev1.Secret{
+		Type: corev1.SecretType(req.GetSecretType()),
+		Data: req.GetSecretData(),
+	}
+	data := pushSecretData{
+		secretKey: req.GetSecretKey(),
+		remoteKey: req.GetRemoteRef().GetRemoteKey(),
+		property:  req.GetRemoteRef().GetProperty(),
+	}
+	if len(req.GetMetadata()) > 0 {
+		data.metadata = &apiextensionsv1.JSON{Raw: req.GetMetadata()}
+	}
+	policy := esv1beta1.PushSecretUpdatePolicy(req.GetUpdatePolicy())
+	err := s.withClient(ctx, req.GetStore(), func(c esv1beta1.SecretsClient) error {
+		if policy == "" || policy == esv1beta1.PushSecretUpdatePolicyReplace {
+			return c.PushSecret(ctx, secret, data)
+		}
+		pc, ok := c.(esv1beta1.UpdatePolicyClient)
+		if !ok {
+			return status.Errorf(codes.Unimplemented, "update policy %s is not supported", policy)
+		}
+		return pc.PushSecretWithPolicy(ctx, secret, data, policy)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1.PushSecretResponse{}, nil
+}
+
+func (s *server) DeleteSecret(ctx context.Context, req *pluginv1.DeleteSecretRequest) (*pluginv1.DeleteSecretResponse, error) {
+	ref := pushSecretData{
+		remoteKey: req.GetRemoteRef().GetRemoteKey(),
+		property:  req.GetRemoteRef().GetProperty(),
+	}
+	err := s.withClient(ctx, req.GetStore(), func(c esv1beta1.SecretsClient) error {
+		return c.DeleteSecret(ctx, ref)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1.DeleteSecretResponse{}, nil
+}
+
+func (s *server) Validate(ctx context.Context, req *pluginv1.ValidateRequest) (*pluginv1.ValidateResponse, error) {
+	res := &pluginv1.ValidateResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(c esv1beta1.SecretsClient) error {
+		result, err := c.Validate()
+		switch result {
+		case esv1beta1.ValidationResultReady:
+			res.Result = pluginv1.ValidateResponse_READY
+		case esv1beta1.ValidationResultUnknown:
+			res.Result = pluginv1.ValidateResponse_UNKNOWN
+		default:
+			res.Result = pluginv1.ValidateResponse_ERROR
+		}
+		if err != nil {
+			res.Result = pluginv1.ValidateResponse_ERROR
+			res.Message = err.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// withClient calls fn with the client of store and converts the returned
+// error to a gRPC status.
+func (s *server) withClient(ctx context.Context, store *pluginv1.Store, fn func(esv1beta1.SecretsClient) error) error {
+	c, err := s.newClient(ctx, Store{
+		Name:      store.GetName(),
+		Kind:      store.GetKind(),
+		Namespace: store.GetNamespace(),
+		Config:    store.GetConfig(),
+		Secrets:   store.GetSecrets(),
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+	defer c.Close(ctx)
+	return toStatus(fn(c))
+}
+
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, esv1beta1.NoSecretError{}) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+func remoteRef(ref *pluginv1.RemoteRef) esv1beta1.ExternalSecretDataRemoteRef {
+	return esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            ref.GetKey(),
+		Version:        ref.GetVersion(),
+		Property:       ref.GetProperty(),
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicy(ref.GetMetadataPolicy()),
+	}
+}
+
+// pushSecretData implements PushSecretData and PushSecretRemoteRef.
+type pushSecretData struct {
+	metadata  *apiextensionsv1.JSON
+	secretKey string
+	remoteKey string
+	property  string
+}
+
+func (d pushSecretData) GetMetadata() *apiextensionsv1.JSON {
+	return d.metadata
+}
+
+func (d pushSecretData) GetSecretKey() string {
+	return d.secretKey
+}
+
+func (d pushSecretData) GetRemoteKey() string {
+	return d.remoteKey
+}
+
+func (d pushSecretData) GetProperty() string {
+	return d.property
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/find"
 	"github.com/external-secrets/external-secrets/pkg/metrics"
 	"github.com/external-secrets/external-secrets/pkg/provider/aws/util"
+	"github.com/external-secrets/external-secrets/pkg/utils/guarded"
 )
 
 // https://github.com/external-secrets/external-secrets/issues/644
@@ -538,7 +539,12 @@ func (sm *SecretsManager) GetSecretMap(ctx context.Context, ref esv1beta1.Extern
 	return secretData, nil
 }
 
+// Close wipes the binary values of the cached secrets.
 func (sm *SecretsManager) Close(_ context.Context) error {
+	for _, out := range sm.cache {
+		guarded.Wipe(out.SecretBinary)
+	}
+	sm.cache = make(map[string]*awssm.GetSecretValueOutput)
 	return nil
 }
 
@@ -0,0 +1,112 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	// automateTokenHeader is the header Chef Automate reads API tokens from.
+	automateTokenHeader = "api-token"
+
+	errMissingToken       = "missing tokenSecretRef for auth type AutomateToken"
+	errMissingKnifeConfig = "missing knifeConfigSecretRef for auth type KnifeConfig"
+	errInvalidAuthType    = "invalid auth type %q"
+	errGenerateSigningKey = "unable to generate signing key: %w"
+)
+
+var (
+	// go-chef signs every request and can not be created without a key.
+	// With token auth the signature is removed again, so a key generated
+	// once per process is enough.
+	unusedSigningKey     string
+	unusedSigningKeyErr  error
+	unusedSigningKeyOnce sync.Once
+)
+
+// CredentialsRef returns the reference to the secret that holds the
+// credentials of the auth type, the private key, the Automate token or the
+// knife config.
+func CredentialsRef(auth *v1beta1.ChefAuth) esmeta.SecretKeySelector {
+	switch {
+	case auth.Type == v1beta1.ChefAuthTypeAutomateToken && auth.SecretRef.Token != nil:
+		return *auth.SecretRef.Token
+	case auth.Type == v1beta1.ChefAuthTypeKnifeConfig && auth.SecretRef.KnifeConfig != nil:
+		return *auth.SecretRef.KnifeConfig
+	}
+	return auth.SecretRef.SecretKey
+}
+
+func validateAuth(auth *v1beta1.ChefAuth) error {
+	switch auth.Type {
+	case "", v1beta1.ChefAuthTypeKey:
+		if auth.SecretRef.SecretKey.Key == "" {
+			return fmt.Errorf(errMissingSecretKey)
+		}
+	case v1beta1.ChefAuthTypeAutomateToken:
+		if auth.SecretRef.Token == nil || auth.SecretRef.Token.Key == "" {
+			return fmt.Errorf(errMissingToken)
+		}
+	case v1beta1.ChefAuthTypeKnifeConfig:
+		if auth.SecretRef.KnifeConfig == nil || auth.SecretRef.KnifeConfig.Key == "" {
+			return fmt.Errorf(errMissingKnifeConfig)
+		}
+	default:
+		return fmt.Errorf(errInvalidAuthType, auth.Type)
+	}
+	return nil
+}
+
+func getUnusedSigningKey() (string, error) {
+	unusedSigningKeyOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			unusedSigningKeyErr = fmt.Errorf(errGenerateSigningKey, err)
+			return
+		}
+		unusedSigningKey = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	})
+	return unusedSigningKey, unusedSigningKeyErr
+}
+
+// tokenTransport replaces the request signature of go-chef with the
+// Automate API token.
+type tokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name := range req.Header {
+		canonical := http.CanonicalHeaderKey(name)
+		if strings.HasPrefix(canonical, "X-Ops-Authorization-") || canonical == "X-Ops-Sign" || canonical == "X-Ops-Userid" {
+			req.Header.Del(name)
+		}
+	}
+	req.Header.Set(automateTokenHeader, t.token)
+	return t.base.RoundTrip(req)
+}
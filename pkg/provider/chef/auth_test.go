@@ -0,0 +1,122 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	v1 "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func makeTokenAuth(name, key string) *esv1beta1.ChefAuth {
+	return &esv1beta1.ChefAuth{
+		Type: esv1beta1.ChefAuthTypeAutomateToken,
+		SecretRef: esv1beta1.ChefAuthSecretRef{
+			Token: &v1.SecretKeySelector{Name: name, Key: key},
+		},
+	}
+}
+
+func TestValidateAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    *esv1beta1.ChefAuth
+		wantErr string
+	}{
+		{
+			name: "key",
+			auth: makeAuth(authName, authNamespace, authKey),
+		},
+		{
+			name:    "key without private key",
+			auth:    makeAuth(authName, authNamespace, ""),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name: "automate token",
+			auth: makeTokenAuth("automate", "token"),
+		},
+		{
+			name:    "automate token without token",
+			auth:    &esv1beta1.ChefAuth{Type: esv1beta1.ChefAuthTypeAutomateToken},
+			wantErr: errMissingToken,
+		},
+		{
+			name: "knife config",
+			auth: makeKnifeAuth("knife", "knife.rb"),
+		},
+		{
+			name:    "knife config without config",
+			auth:    &esv1beta1.ChefAuth{Type: esv1beta1.ChefAuthTypeKnifeConfig},
+			wantErr: errMissingKnifeConfig,
+		},
+		{
+			name:    "unknown type",
+			auth:    &esv1beta1.ChefAuth{Type: "Password"},
+			wantErr: `invalid auth type "Password"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAuth(tt.auth)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCredentialsRef(t *testing.T) {
+	assert.Equal(t, authKey, CredentialsRef(makeAuth(authName, authNamespace, authKey)).Key)
+	assert.Equal(t, "token", CredentialsRef(makeTokenAuth("automate", "token")).Key)
+	assert.Equal(t, "knife.rb", CredentialsRef(makeKnifeAuth("knife", "knife.rb")).Key)
+}
+
+func TestAutomateTokenAuth(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		_, _ = w.Write([]byte(`{"app":"https://chef/data/app"}`))
+	}))
+	defer server.Close()
+
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "automate", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("automate-token\n")},
+	}).Build()
+	store := makeSecretStore(name, server.URL+"/organizations/org/", makeTokenAuth("automate", "token"))
+
+	client, err := (&Providerchef{}).NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	_, err = client.(*Providerchef).databagService.ListItems("app")
+	require.NoError(t, err)
+
+	assert.Equal(t, "automate-token", header.Get(automateTokenHeader))
+	assert.Empty(t, header.Get("X-Ops-Authorization-1"))
+	assert.Empty(t, header.Get("X-Ops-Sign"))
+	assert.Empty(t, header.Get("X-Ops-Userid"))
+}
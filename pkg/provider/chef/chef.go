@@ -14,17 +14,28 @@ limitations under the License.
 package chef
 
 import (
+	"bytes"
 	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-chef/chef"
 	"github.com/go-logr/logr"
 	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,6 +44,8 @@ import (
 	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/metrics"
 	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/certpin"
+	"github.com/external-secrets/external-secrets/pkg/utils/guarded"
 )
 
 const (
@@ -58,12 +71,47 @@ const (
 	errInvalidFormat                         = "invalid key format in data section. Expected value 'databagName/databagItemName'"
 	errStoreValidateFailed                   = "unable to validate provided store. Check if username, serverUrl and privateKey are correct"
 	errServerURLNoEndSlash                   = "serverurl does not end with slash(/)"
+	errInvalidCABundle                       = "caBundle does not contain a PEM encoded certificate"
 	errInvalidDataform                       = "invalid key format in dataForm section. Expected only 'databagName'"
-
-	ProviderChef             = "Chef"
-	CallChefGetDataBagItem   = "GetDataBagItem"
-	CallChefListDataBagItems = "ListDataBagItems"
-	CallChefGetUser          = "GetUser"
+	errInvalidPushKey                        = "invalid remoteKey format. Expected value 'databagName/databagItemName'"
+	errSecretKeyNotFound                     = "secret key %s not found in secret"
+	errSetProperty                           = "unable to set property %s of data bag item: %w"
+	errGetDatabagItem                        = "unable to get data bag item %s from data bag %s: %w"
+	errCreateDatabag                         = "unable to create data bag %s: %w"
+	errCreateDatabagItem                     = "unable to create data bag item %s in data bag %s: %w"
+	errUpdateDatabagItem                     = "unable to update data bag item %s in data bag %s: %w"
+	errDeleteDatabagItem                     = "unable to delete data bag item %s from data bag %s: %w"
+	errDeleteProperty                        = "unable to delete property %s of data bag item: %w"
+	errPushMetadata                          = "failed to decode PushSecret metadata: %w"
+	errPushValueType                         = "invalid valueType %q in PushSecret metadata, expected string, json or base64"
+	errPushNotUTF8                           = "value of secret key %s is not valid UTF-8, push it with valueType base64"
+	errPushDecodeJSON                        = "unable to decode value of secret key %s as json: %w"
+	errPushNotAnObject                       = "value of secret key %s must be a json object to be pushed without property"
+	errPushUpdatePolicy                      = "unsupported updatePolicy %q"
+	errReadOnlyStrict                        = "store is read only, writes are denied by readOnlyStrict"
+
+	warnDeprecatedName    = "chef.name is deprecated, use chef.username instead"
+	warnDeprecatedBaseURL = "chef.baseUrl is deprecated, use chef.serverUrl instead"
+
+	ProviderChef              = "Chef"
+	CallChefGetDataBagItem    = "GetDataBagItem"
+	CallChefListDataBagItems  = "ListDataBagItems"
+	CallChefGetUser           = "GetUser"
+	CallChefGetClient         = "GetClient"
+	CallChefCreateClient      = "CreateClient"
+	CallChefUpdateClientKey   = "UpdateClientKey"
+	CallChefAddClientKey      = "AddClientKey"
+	CallChefListClientKeys    = "ListClientKeys"
+	CallChefDeleteClientKey   = "DeleteClientKey"
+	CallChefDeleteClient      = "DeleteClient"
+	CallChefCreateDataBag     = "CreateDataBag"
+	CallChefCreateDataBagItem = "CreateDataBagItem"
+	CallChefUpdateDataBagItem = "UpdateDataBagItem"
+	CallChefDeleteDataBagItem = "DeleteDataBagItem"
+	CallChefGetCookbook       = "GetCookbook"
+	CallChefGetCookbookFile   = "GetCookbookFile"
+	CallChefGetClientKey      = "GetClientKey"
+	CallChefGetUserKey        = "GetUserKey"
 )
 
 var contextTimeout = time.Second * 25
@@ -73,19 +121,42 @@ type DatabagFetcher interface {
 	ListItems(name string) (data *chef.DataBagListResult, err error)
 }
 
+// DatabagService is the subset of the Chef data bags API used to read and push secrets.
+type DatabagService interface {
+	DatabagFetcher
+	Create(databag *chef.DataBag) (result *chef.DataBagCreateResult, err error)
+	CreateItem(databagName string, databagItem chef.DataBagItem) (err error)
+	UpdateItem(databagName string, databagItemID string, databagItem chef.DataBagItem) (err error)
+	DeleteItem(databagName string, databagItem string) (err error)
+}
+
 type UserInterface interface {
 	Get(name string) (user chef.User, err error)
 }
 
 type Providerchef struct {
-	clientName     string
-	databagService DatabagFetcher
-	userService    UserInterface
-	log            logr.Logger
+	// config is used to create the clients of other organizations, its
+	// Key is cleared once the client is created.
+	config *chef.Config
+	// key holds the private key of the store, privateKey is the key parsed
+	// by the client. Both are wiped on Close.
+	key             *guarded.Bytes
+	privateKey      *rsa.PrivateKey
+	clientName      string
+	databagService  DatabagService
+	cookbookService CookbookFetcher
+	keyService      KeyFetcher
+	itemSchemas     []v1beta1.ChefItemSchema
+	organizations   []string
+	readOnly        bool
+	userService     UserInterface
+	log             logr.Logger
 }
 
 var _ v1beta1.SecretsClient = &Providerchef{}
 var _ v1beta1.Provider = &Providerchef{}
+var _ v1beta1.OnlineValidator = &Providerchef{}
+var _ v1beta1.PushDenier = &Providerchef{}
 
 func init() {
 	v1beta1.Register(&Providerchef{}, &v1beta1.SecretStoreProvider{
@@ -99,46 +170,150 @@ func (providerchef *Providerchef) NewClient(ctx context.Context, store v1beta1.G
 		return nil, fmt.Errorf(errChefProvider, err)
 	}
 
-	credentialsSecret := &corev1.Secret{}
-	objectKey := types.NamespacedName{
-		Name:      chefProvider.Auth.SecretRef.SecretKey.Name,
-		Namespace: namespace,
-	}
-
+	keyNamespace := namespace
 	if store.GetObjectKind().GroupVersionKind().Kind == v1beta1.ClusterSecretStoreKind {
-		if chefProvider.Auth.SecretRef.SecretKey.Namespace == nil {
+		credentialsRef := CredentialsRef(chefProvider.Auth)
+		if credentialsRef.Namespace == nil {
 			return nil, fmt.Errorf(errInvalidClusterStoreMissingPKNamespace)
 		}
-		objectKey.Namespace = *chefProvider.Auth.SecretRef.SecretKey.Namespace
+		keyNamespace = *credentialsRef.Namespace
+	}
+
+	config, err := newChefConfig(ctx, kube, chefProvider, keyNamespace)
+	if err != nil {
+		return nil, err
+	}
+	config.Client = storeThrottle(store).client(config.Client)
+	client, err := chef.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf(errChefClient, err)
+	}
+
+	// the registered provider is shared by all stores, every store gets
+	// its own client.
+	// go-chef only takes the key as string, which can not be wiped, so
+	// only the copy in guarded memory is kept.
+	storeClient := &Providerchef{
+		config:        config,
+		key:           guarded.New([]byte(config.Key)),
+		privateKey:    client.Auth.PrivateKey,
+		itemSchemas:   chefProvider.ItemSchemas,
+		organizations: chefProvider.Organizations,
+		readOnly:      chefProvider.ReadOnlyStrict,
+		log:           ctrl.Log.WithName("provider").WithName("chef").WithName("secretsmanager"),
+	}
+	config.Key = ""
+	storeClient.setClient(client)
+	return storeClient, nil
+}
+
+// setClient sets the chef API services used by the provider.
+func (providerchef *Providerchef) setClient(client *chef.Client) {
+	providerchef.clientName = client.Auth.ClientName
+	providerchef.databagService = client.DataBags
+	providerchef.cookbookService = &cookbookService{client: client}
+	providerchef.keyService = &keyService{client: client}
+	providerchef.userService = client.Users
+}
+
+// NewGeneratorClient returns a Chef API client for the given provider spec.
+// The private key is read from the given namespace.
+func NewGeneratorClient(ctx context.Context, kube kclient.Client, chefProvider *v1beta1.ChefProvider, namespace string) (*chef.Client, error) {
+	if chefProvider == nil {
+		return nil, fmt.Errorf(errMissingChefProvider)
+	}
+	if chefProvider.Auth == nil {
+		return nil, fmt.Errorf(errMissingAuth)
 	}
+	return newChefClient(ctx, kube, chefProvider, namespace)
+}
+
+func newChefClient(ctx context.Context, kube kclient.Client, chefProvider *v1beta1.ChefProvider, namespace string) (*chef.Client, error) {
+	config, err := newChefConfig(ctx, kube, chefProvider, namespace)
+	if err != nil {
+		return nil, err
+	}
+	client, err := chef.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf(errChefClient, err)
+	}
+	return client, nil
+}
 
+// newChefConfig reads the credentials of the provider and returns the
+// configuration of a chef API client.
+func newChefConfig(ctx context.Context, kube kclient.Client, chefProvider *v1beta1.ChefProvider, namespace string) (*chef.Config, error) {
+	credentialsRef := CredentialsRef(chefProvider.Auth)
+	credentialsSecret := &corev1.Secret{}
+	objectKey := types.NamespacedName{
+		Name:      credentialsRef.Name,
+		Namespace: namespace,
+	}
 	if err := kube.Get(ctx, objectKey, credentialsSecret); err != nil {
 		return nil, fmt.Errorf(errFetchK8sSecret, err)
 	}
 
-	secretKey := credentialsSecret.Data[chefProvider.Auth.SecretRef.SecretKey.Key]
-	if len(secretKey) == 0 {
+	credentials := credentialsSecret.Data[credentialsRef.Key]
+	if len(credentials) == 0 {
 		return nil, fmt.Errorf(errMissingSecretKey)
 	}
 
-	client, err := chef.NewClient(&chef.Config{
-		Name:    chefProvider.UserName,
-		Key:     string(secretKey),
-		BaseURL: chefProvider.ServerURL,
-	})
-	if err != nil {
-		return nil, fmt.Errorf(errChefClient, err)
+	name, serverURL, key, token := chefProvider.UserName, chefProvider.ServerURL, string(credentials), ""
+	var err error
+	switch chefProvider.Auth.Type {
+	case v1beta1.ChefAuthTypeAutomateToken:
+		if key, err = getUnusedSigningKey(); err != nil {
+			return nil, err
+		}
+		token = strings.TrimSpace(string(credentials))
+	case v1beta1.ChefAuthTypeKnifeConfig:
+		if name, serverURL, key, err = knifeCredentials(chefProvider, credentials, credentialsSecret.Data); err != nil {
+			return nil, err
+		}
 	}
+	return &chef.Config{
+		Name:    name,
+		Key:     key,
+		BaseURL: serverURL,
+		Client:  newHTTPClient(chefProvider, token),
+	}, nil
+}
 
-	providerchef.clientName = chefProvider.UserName
-	providerchef.databagService = client.DataBags
-	providerchef.userService = client.Users
-	providerchef.log = ctrl.Log.WithName("provider").WithName("chef").WithName("secretsmanager")
-	return providerchef, nil
+// newHTTPClient returns the http client used to talk to the chef server, or
+// nil to use the default client of go-chef if no CA bundle, pins or Automate
+// token are set.
+func newHTTPClient(chefProvider *v1beta1.ChefProvider, token string) *http.Client {
+	if len(chefProvider.CABundle) == 0 && len(chefProvider.CertificatePins) == 0 && token == "" {
+		return nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:       tls.VersionTLS12,
+		VerifyConnection: certpin.VerifyConnection(chefProvider.CertificatePins),
+	}
+	if len(chefProvider.CABundle) > 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(chefProvider.CABundle)
+	}
+	var transport http.RoundTripper = &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+	if token != "" {
+		transport = &tokenTransport{token: token, base: transport}
+	}
+	return &http.Client{Transport: transport}
 }
 
-// Close closes the client connection.
+// Close wipes the private key of the client.
 func (providerchef *Providerchef) Close(_ context.Context) error {
+	if providerchef.key != nil {
+		providerchef.key.Destroy()
+	}
+	if providerchef.privateKey != nil {
+		guarded.WipePrivateKey(providerchef.privateKey)
+		providerchef.privateKey = nil
+	}
 	return nil
 }
 
@@ -153,13 +328,60 @@ func (providerchef *Providerchef) Validate() (v1beta1.ValidationResult, error) {
 	return v1beta1.ValidationResultReady, nil
 }
 
-// GetAllSecrets Retrieves a map[string][]byte with the Databag names as key and the Databag's Items as secrets.
-func (providerchef *Providerchef) GetAllSecrets(_ context.Context, _ v1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	return nil, fmt.Errorf("dataFrom.find not suppported")
+// GetAllSecrets returns the files of the cookbook version in find.path, format example: cookbook:cookbookName/version,
+// whose paths match find.name. The path may be prefixed with organizations/orgName/.
+// Data bag items have no checksums, finding them is not supported.
+func (providerchef *Providerchef) GetAllSecrets(ctx context.Context, ref v1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Path == nil || *ref.Path == "" {
+		return nil, fmt.Errorf(errInvalidCookbookPath)
+	}
+	if org, path, ok := providerchef.splitOrganization(*ref.Path); ok {
+		orgProvider, err := providerchef.forOrganization(org)
+		if err != nil {
+			return nil, err
+		}
+		defer orgProvider.Close(ctx)
+		ref.Path = &path
+		return orgProvider.GetAllSecrets(ctx, ref)
+	}
+	if !strings.HasPrefix(*ref.Path, cookbookKeyPrefix) {
+		return nil, fmt.Errorf(errInvalidCookbookPath)
+	}
+	if utils.IsNil(providerchef.cookbookService) {
+		return nil, fmt.Errorf(errUninitalizedChefProvider)
+	}
+	return providerchef.findCookbookFiles(ctx, ref)
 }
 
 // GetSecret returns a databagItem present in the databag. format example: databagName/databagItemName.
+// Keys prefixed with cookbook: return a cookbook file instead, format example: cookbook:cookbookName/version/path.
+// Keys of the form clients/clientName/keys/keyName or users/userName/keys/keyName return a public key.
+// Keys prefixed with organizations/orgName/ are read from that organization, if allowed by the store.
 func (providerchef *Providerchef) GetSecret(ctx context.Context, ref v1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if org, key, ok := providerchef.splitOrganization(ref.Key); ok {
+		orgProvider, err := providerchef.forOrganization(org)
+		if err != nil {
+			return nil, err
+		}
+		defer orgProvider.Close(ctx)
+		ref.Key = key
+		return orgProvider.GetSecret(ctx, ref)
+	}
+	if strings.HasPrefix(ref.Key, cookbookKeyPrefix) {
+		if utils.IsNil(providerchef.cookbookService) {
+			return nil, fmt.Errorf(errUninitalizedChefProvider)
+		}
+		if ref.Property != "" {
+			return nil, fmt.Errorf(errCookbookProperty)
+		}
+		return providerchef.getCookbookFile(ctx, ref.Key)
+	}
+	if owner, name, keyName, ok := parseKeyRef(ref.Key); ok {
+		if utils.IsNil(providerchef.keyService) {
+			return nil, fmt.Errorf(errUninitalizedChefProvider)
+		}
+		return providerchef.getPublicKey(owner, name, keyName, ref.Property)
+	}
 	if utils.IsNil(providerchef.databagService) {
 		return nil, fmt.Errorf(errUninitalizedChefProvider)
 	}
@@ -191,15 +413,13 @@ func getSingleDatabagItemWithContext(ctx context.Context, providerchef *Provider
 		resultChan := make(chan result, 1)
 		go func() {
 			defer close(resultChan)
-			ditem, err := providerchef.databagService.GetItem(dataBagName, databagItemName)
-			metrics.ObserveAPICall(ProviderChef, CallChefGetDataBagItem, err)
+			jsonByte, err := providerchef.getDatabagItem(dataBagName, databagItemName)
 			if err != nil {
-				resultChan <- result{err: fmt.Errorf(errNoDatabagItemFound, databagItemName, dataBagName)}
+				resultChan <- result{err: err}
 				return
 			}
-			jsonByte, err := json.Marshal(ditem)
-			if err != nil {
-				resultChan <- result{err: fmt.Errorf(errUnableToConvertToJSON)}
+			if err := checkItemSchemas(providerchef.itemSchemas, dataBagName, databagItemName, jsonByte); err != nil {
+				resultChan <- result{err: err}
 				return
 			}
 			if propertyName != "" {
@@ -246,8 +466,17 @@ func getPropertyFromDatabagItem(jsonByte []byte, propertyName string) ([]byte, e
 
 // GetSecretMap returns multiple k/v pairs from the provider, for dataFrom.extract.key
 // dataFrom.extract.key only accepts dataBagName, example : dataFrom.extract.key: myDatabag
-// databagItemName or Property not expected in key.
+// databagItemName or Property not expected in key. The data bag name may be prefixed with organizations/orgName/.
 func (providerchef *Providerchef) GetSecretMap(ctx context.Context, ref v1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if org, key, ok := providerchef.splitOrganization(ref.Key); ok {
+		orgProvider, err := providerchef.forOrganization(org)
+		if err != nil {
+			return nil, err
+		}
+		defer orgProvider.Close(ctx)
+		ref.Key = key
+		return orgProvider.GetSecretMap(ctx, ref)
+	}
 	if utils.IsNil(providerchef.databagService) {
 		return nil, fmt.Errorf(errUninitalizedChefProvider)
 	}
@@ -260,12 +489,31 @@ func (providerchef *Providerchef) GetSecretMap(ctx context.Context, ref v1beta1.
 	providerchef.log.Info("fetching all items from", "databag:", databagName)
 	dataItems, err := providerchef.databagService.ListItems(databagName)
 	metrics.ObserveAPICall(ProviderChef, CallChefListDataBagItems, err)
+	if isNotFound(err) {
+		return nil, &notFoundError{msg: fmt.Sprintf(errCannotListDataBagItems, databagName)}
+	}
 	if err != nil {
 		return nil, fmt.Errorf(errCannotListDataBagItems, databagName)
 	}
 
+	chunked := chunkedItems(*dataItems)
+	itemNames := make([]string, 0, len(*dataItems))
 	for dataItem := range *dataItems {
+		if m := chunkName.FindStringSubmatch(dataItem); m != nil && chunked[m[1]] != "" {
+			continue
+		}
+		itemNames = append(itemNames, dataItem)
+	}
+	for itemName := range chunked {
+		itemNames = append(itemNames, itemName)
+	}
+
+	for _, dataItem := range itemNames {
 		dItem, err := getSingleDatabagItemWithContext(ctx, providerchef, databagName, dataItem, "")
+		var reasonErr *v1beta1.ReasonError
+		if errors.As(err, &reasonErr) {
+			return nil, err
+		}
 		if err != nil {
 			return nil, fmt.Errorf(errNoDatabagItemFound, dataItem, databagName)
 		}
@@ -281,10 +529,33 @@ func (providerchef *Providerchef) ValidateStore(store v1beta1.GenericStore) (adm
 		return nil, fmt.Errorf(errChefStore, err)
 	}
 	// check namespace compared to kind
-	if err := utils.ValidateSecretSelector(store, chefProvider.Auth.SecretRef.SecretKey); err != nil {
+	if err := utils.ValidateSecretSelector(store, CredentialsRef(chefProvider.Auth)); err != nil {
 		return nil, fmt.Errorf(errChefStore, err)
 	}
-	return nil, nil
+	var warnings admission.Warnings
+	if chefProvider.Name != "" {
+		warnings = append(warnings, warnDeprecatedName)
+	}
+	if chefProvider.BaseURL != "" {
+		warnings = append(warnings, warnDeprecatedBaseURL)
+	}
+	return warnings, nil
+}
+
+// OnlineValidationEnabled returns true if the store opted in to online validation.
+func (providerchef *Providerchef) OnlineValidationEnabled(store v1beta1.GenericStore) bool {
+	chefProvider, err := getChefProvider(store)
+	return err == nil && chefProvider.OnlineValidation
+}
+
+// PushDenied returns true if the store denies all writes with readOnlyStrict.
+// The rest of the store is not validated, so that writes are denied even if
+// the store is invalid.
+func (providerchef *Providerchef) PushDenied(store v1beta1.GenericStore) bool {
+	if store == nil || store.GetSpec() == nil || store.GetSpec().Provider == nil || store.GetSpec().Provider.Chef == nil {
+		return false
+	}
+	return store.GetSpec().Provider.Chef.ReadOnlyStrict
 }
 
 // getChefProvider validates the incoming store and return the chef provider.
@@ -304,40 +575,403 @@ func getChefProvider(store v1beta1.GenericStore) (*v1beta1.ChefProvider, error)
 	if chefProvider == nil {
 		return nil, fmt.Errorf(errMissingChefProvider)
 	}
-	if chefProvider.UserName == "" {
+	// a knife config provides the username and server url
+	knifeConfig := chefProvider.Auth != nil && chefProvider.Auth.Type == v1beta1.ChefAuthTypeKnifeConfig
+	if chefProvider.UserName == "" && !knifeConfig {
 		return chefProvider, fmt.Errorf(errMissingUserName)
 	}
-	if chefProvider.ServerURL == "" {
+	if chefProvider.ServerURL == "" && !knifeConfig {
 		return chefProvider, fmt.Errorf(errMissingServerURL)
 	}
-	if !strings.HasSuffix(chefProvider.ServerURL, "/") {
-		return chefProvider, fmt.Errorf(errServerURLNoEndSlash)
+	if chefProvider.ServerURL != "" {
+		if !strings.HasSuffix(chefProvider.ServerURL, "/") {
+			return chefProvider, fmt.Errorf(errServerURLNoEndSlash)
+		}
+		// check valid URL
+		if _, err := url.ParseRequestURI(chefProvider.ServerURL); err != nil {
+			return chefProvider, fmt.Errorf(errInvalidURL, err)
+		}
+	}
+	if len(chefProvider.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(chefProvider.CABundle) {
+		return chefProvider, fmt.Errorf(errInvalidCABundle)
+	}
+	if err := certpin.Validate(chefProvider.CertificatePins); err != nil {
+		return chefProvider, err
+	}
+	if err := validateItemSchemas(chefProvider.ItemSchemas); err != nil {
+		return chefProvider, err
 	}
-	// check valid URL
-	if _, err := url.ParseRequestURI(chefProvider.ServerURL); err != nil {
-		return chefProvider, fmt.Errorf(errInvalidURL, err)
+	if err := validateOrganizations(chefProvider.Organizations); err != nil {
+		return chefProvider, err
 	}
 	if chefProvider.Auth == nil {
 		return chefProvider, fmt.Errorf(errMissingAuth)
 	}
-	if chefProvider.Auth.SecretRef.SecretKey.Key == "" {
-		return chefProvider, fmt.Errorf(errMissingSecretKey)
+	if err := validateAuth(chefProvider.Auth); err != nil {
+		return chefProvider, err
 	}
 
 	return chefProvider, nil
 }
 
-// Not Implemented DeleteSecret.
-func (providerchef *Providerchef) DeleteSecret(_ context.Context, _ v1beta1.PushSecretRemoteRef) error {
-	return fmt.Errorf("not implemented")
+// DeleteSecret removes a pushed secret from the Chef server. With property
+// only the property is removed from the data bag item, otherwise the whole
+// item is deleted. Missing items and properties are ignored.
+func (providerchef *Providerchef) DeleteSecret(_ context.Context, remoteRef v1beta1.PushSecretRemoteRef) error {
+	if utils.IsNil(providerchef.databagService) {
+		return fmt.Errorf(errUninitalizedChefProvider)
+	}
+	if providerchef.readOnly {
+		return fmt.Errorf(errReadOnlyStrict)
+	}
+	databagName, itemName, err := splitPushKey(remoteRef.GetRemoteKey())
+	if err != nil {
+		return err
+	}
+
+	property := remoteRef.GetProperty()
+	if property == "" {
+		providerchef.log.Info("deleting databag item", "databag Name:", databagName, "databag Item:", itemName)
+		err = providerchef.databagService.DeleteItem(databagName, itemName)
+		metrics.ObserveAPICall(ProviderChef, CallChefDeleteDataBagItem, err)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf(errDeleteDatabagItem, itemName, databagName, err)
+		}
+		return nil
+	}
+
+	item, err := providerchef.databagService.GetItem(databagName, itemName)
+	metrics.ObserveAPICall(ProviderChef, CallChefGetDataBagItem, err)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf(errGetDatabagItem, itemName, databagName, err)
+	}
+	current, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf(errUnableToConvertToJSON)
+	}
+	if !gjson.GetBytes(current, property).Exists() {
+		return nil
+	}
+	updated, err := sjson.DeleteBytes(current, property)
+	if err != nil {
+		return fmt.Errorf(errDeleteProperty, property, err)
+	}
+	var content map[string]any
+	if err := json.Unmarshal(updated, &content); err != nil {
+		return fmt.Errorf(errUnableToConvertToJSON)
+	}
+	providerchef.log.Info("deleting databag item property", "databag Name:", databagName, "databag Item:", itemName, "property:", property)
+	err = providerchef.databagService.UpdateItem(databagName, itemName, content)
+	metrics.ObserveAPICall(ProviderChef, CallChefUpdateDataBagItem, err)
+	if err != nil {
+		return fmt.Errorf(errUpdateDatabagItem, itemName, databagName, err)
+	}
+	return nil
+}
+
+// PushSecret writes the secret into a data bag item, replacing the
+// existing value. See PushSecretWithPolicy.
+func (providerchef *Providerchef) PushSecret(ctx context.Context, secret *corev1.Secret, data v1beta1.PushSecretData) error {
+	return providerchef.PushSecretWithPolicy(ctx, secret, data, v1beta1.PushSecretUpdatePolicyReplace)
+}
+
+// PushSecretWithPolicy writes the secret into a data bag item. The remoteKey
+// has the format databagName/databagItemName, missing data bags and items are
+// created. A single secret key is written to the property, which defaults to
+// the name of the secret key. Without secret key all keys of the secret are
+// written, either into the item itself or as object into the property.
+// The policy decides whether an existing value is replaced, deep merged
+// with the pushed value or left untouched.
+func (providerchef *Providerchef) PushSecretWithPolicy(_ context.Context, secret *corev1.Secret, data v1beta1.PushSecretData, policy v1beta1.PushSecretUpdatePolicy) error {
+	if utils.IsNil(providerchef.databagService) {
+		return fmt.Errorf(errUninitalizedChefProvider)
+	}
+	if providerchef.readOnly {
+		return fmt.Errorf(errReadOnlyStrict)
+	}
+	databagName, itemName, err := splitPushKey(data.GetRemoteKey())
+	if err != nil {
+		return err
+	}
+
+	item, err := providerchef.databagService.GetItem(databagName, itemName)
+	metrics.ObserveAPICall(ProviderChef, CallChefGetDataBagItem, err)
+	exists := err == nil
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf(errGetDatabagItem, itemName, databagName, err)
+	}
+	var current []byte
+	if exists {
+		current, err = json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf(errUnableToConvertToJSON)
+		}
+	}
+
+	path, value, err := pushValue(secret, data)
+	if err != nil {
+		return err
+	}
+	updated, err := applyUpdatePolicy(current, path, value, policy)
+	if err != nil {
+		return err
+	}
+	// the id of an item must match its name
+	updated, err = sjson.SetBytes(updated, "id", itemName)
+	if err != nil {
+		return fmt.Errorf(errSetProperty, "id", err)
+	}
+	if exists && jsonEqual(current, updated) {
+		return nil
+	}
+
+	var content map[string]any
+	if err := json.Unmarshal(updated, &content); err != nil {
+		return fmt.Errorf(errUnableToConvertToJSON)
+	}
+	providerchef.log.Info("pushing secret value", "databag Name:", databagName, "databag Item:", itemName)
+	if exists {
+		err = providerchef.databagService.UpdateItem(databagName, itemName, content)
+		metrics.ObserveAPICall(ProviderChef, CallChefUpdateDataBagItem, err)
+		if err != nil {
+			return fmt.Errorf(errUpdateDatabagItem, itemName, databagName, err)
+		}
+		return nil
+	}
+	return providerchef.createItem(databagName, itemName, content)
+}
+
+// createItem creates the data bag item and the data bag if it does not exist yet.
+func (providerchef *Providerchef) createItem(databagName, itemName string, content map[string]any) error {
+	err := providerchef.databagService.CreateItem(databagName, content)
+	metrics.ObserveAPICall(ProviderChef, CallChefCreateDataBagItem, err)
+	if !isNotFound(err) {
+		if err != nil {
+			return fmt.Errorf(errCreateDatabagItem, itemName, databagName, err)
+		}
+		return nil
+	}
+	_, err = providerchef.databagService.Create(&chef.DataBag{Name: databagName})
+	metrics.ObserveAPICall(ProviderChef, CallChefCreateDataBag, err)
+	if err != nil && !isConflict(err) {
+		return fmt.Errorf(errCreateDatabag, databagName, err)
+	}
+	err = providerchef.databagService.CreateItem(databagName, content)
+	metrics.ObserveAPICall(ProviderChef, CallChefCreateDataBagItem, err)
+	if err != nil {
+		return fmt.Errorf(errCreateDatabagItem, itemName, databagName, err)
+	}
+	return nil
+}
+
+// pushSecretMetadata configures how pushed values are written to a data bag item.
+type pushSecretMetadata struct {
+	// ValueType is either string, the default, json or base64. JSON values
+	// are decoded and written as JSON structure, e.g. a data bag item rendered
+	// by the PushSecret template. Base64 values are encoded first, so that
+	// binary values survive the JSON encoding of data bag items.
+	ValueType string `json:"valueType,omitempty"`
+}
+
+const (
+	valueTypeString = "string"
+	valueTypeJSON   = "json"
+	valueTypeBase64 = "base64"
+)
+
+func parsePushSecretMetadata(raw *apiextensionsv1.JSON) (*pushSecretMetadata, error) {
+	md := &pushSecretMetadata{}
+	if raw == nil {
+		return md, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw.Raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(md); err != nil {
+		return nil, fmt.Errorf(errPushMetadata, err)
+	}
+	switch md.ValueType {
+	case "", valueTypeString, valueTypeJSON, valueTypeBase64:
+		return md, nil
+	default:
+		return nil, fmt.Errorf(errPushValueType, md.ValueType)
+	}
+}
+
+func (md *pushSecretMetadata) decode(key string, value []byte) (any, error) {
+	switch md.ValueType {
+	case valueTypeJSON:
+		var v any
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, fmt.Errorf(errPushDecodeJSON, key, err)
+		}
+		return v, nil
+	case valueTypeBase64:
+		return base64.StdEncoding.EncodeToString(value), nil
+	}
+	// JSON encoding would replace invalid bytes silently
+	if !utf8.Valid(value) {
+		return nil, fmt.Errorf(errPushNotUTF8, key)
+	}
+	return string(value), nil
+}
+
+// pushValue returns the pushed value and the property path it is written
+// to. An empty path refers to the data bag item itself, the value is a JSON
+// object in that case.
+func pushValue(secret *corev1.Secret, data v1beta1.PushSecretData) (string, any, error) {
+	md, err := parsePushSecretMetadata(data.GetMetadata())
+	if err != nil {
+		return "", nil, err
+	}
+	property := data.GetProperty()
+	if key := data.GetSecretKey(); key != "" {
+		raw, ok := secret.Data[key]
+		if !ok {
+			return "", nil, fmt.Errorf(errSecretKeyNotFound, key)
+		}
+		value, err := md.decode(key, raw)
+		if err != nil {
+			return "", nil, err
+		}
+		if property != "" {
+			return property, value, nil
+		}
+		// a JSON object without property is written to the item
+		if md.ValueType == valueTypeJSON {
+			object, ok := value.(map[string]any)
+			if !ok {
+				return "", nil, fmt.Errorf(errPushNotAnObject, key)
+			}
+			return "", object, nil
+		}
+		return escapePath(key), value, nil
+	}
+
+	values := make(map[string]any, len(secret.Data))
+	for k, v := range secret.Data {
+		values[k], err = md.decode(k, v)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	return property, values, nil
+}
+
+// applyUpdatePolicy writes the value to the path of the JSON document of a
+// data bag item according to the update policy. The item is empty if it
+// does not exist yet.
+func applyUpdatePolicy(item []byte, path string, value any, policy v1beta1.PushSecretUpdatePolicy) ([]byte, error) {
+	var existing any
+	if path == "" {
+		existing = gjson.ParseBytes(item).Value()
+	} else if result := gjson.GetBytes(item, path); result.Exists() {
+		existing = result.Value()
+	}
+
+	switch policy {
+	case "", v1beta1.PushSecretUpdatePolicyReplace:
+	case v1beta1.PushSecretUpdatePolicyIfNotExists:
+		if existing != nil {
+			return item, nil
+		}
+	case v1beta1.PushSecretUpdatePolicyMerge:
+		value = mergeValues(existing, value)
+	default:
+		return nil, fmt.Errorf(errPushUpdatePolicy, policy)
+	}
+
+	if path == "" {
+		updated, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf(errUnableToConvertToJSON)
+		}
+		return updated, nil
+	}
+	updated, err := sjson.SetBytes(item, path, value)
+	if err != nil {
+		return nil, fmt.Errorf(errSetProperty, path, err)
+	}
+	return updated, nil
+}
+
+// mergeValues deep merges src into dst. Objects are merged key by key, any
+// other value of src replaces the value of dst.
+func mergeValues(dst, src any) any {
+	dstObject, ok := dst.(map[string]any)
+	if !ok {
+		return src
+	}
+	srcObject, ok := src.(map[string]any)
+	if !ok {
+		return src
+	}
+	merged := make(map[string]any, len(dstObject)+len(srcObject))
+	for k, v := range dstObject {
+		merged[k] = v
+	}
+	for k, v := range srcObject {
+		merged[k] = mergeValues(dstObject[k], v)
+	}
+	return merged
+}
+
+func splitPushKey(key string) (string, string, error) {
+	databagName, itemName, ok := strings.Cut(key, "/")
+	if !ok || databagName == "" || itemName == "" || strings.Contains(itemName, "/") {
+		return "", "", fmt.Errorf(errInvalidPushKey)
+	}
+	return databagName, itemName, nil
+}
+
+// escapePath escapes the characters of a secret key that have a special
+// meaning in a property path.
+func escapePath(key string) string {
+	return pathEscaper.Replace(key)
+}
+
+var pathEscaper = strings.NewReplacer(`\`, `\\`, ".", `\.`, "*", `\*`, "?", `\?`)
+
+func jsonEqual(a, b []byte) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// notFoundError reports a missing data bag or item. It keeps the message of
+// the chef provider while matching v1beta1.NoSecretErr, so the controller
+// applies the deletionPolicy.
+type notFoundError struct {
+	msg string
+}
+
+func (e *notFoundError) Error() string {
+	return e.msg
+}
+
+func (e *notFoundError) Is(target error) bool {
+	_, ok := target.(v1beta1.NoSecretError)
+	return ok
+}
+
+func isNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+func isConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
 }
 
-// Not Implemented PushSecret.
-func (providerchef *Providerchef) PushSecret(_ context.Context, _ *corev1.Secret, _ v1beta1.PushSecretData) error {
-	return fmt.Errorf("not implemented")
+func hasStatus(err error, status int) bool {
+	var cerr *chef.ErrorResponse
+	return errors.As(err, &cerr) && cerr.Response != nil && cerr.StatusCode() == status
 }
 
 // Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
 func (providerchef *Providerchef) Capabilities() v1beta1.SecretStoreCapabilities {
-	return v1beta1.SecretStoreReadOnly
+	return v1beta1.SecretStoreReadWrite
 }
@@ -16,6 +16,7 @@ package chef
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -24,12 +25,14 @@ import (
 
 	"github.com/go-chef/chef"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	v1 "github.com/external-secrets/external-secrets/apis/meta/v1"
 	fake "github.com/external-secrets/external-secrets/pkg/provider/chef/fake"
+	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
@@ -262,6 +265,12 @@ func makeSecretStore(name, baseURL string, auth *esv1beta1.ChefAuth) *esv1beta1.
 	return store
 }
 
+func withTLS(store *esv1beta1.SecretStore, caBundle []byte, pins []string) *esv1beta1.SecretStore {
+	store.Spec.Provider.Chef.CABundle = caBundle
+	store.Spec.Provider.Chef.CertificatePins = pins
+	return store
+}
+
 func makeAuth(name, namespace, key string) *esv1beta1.ChefAuth {
 	return &esv1beta1.ChefAuth{
 		SecretRef: esv1beta1.ChefAuthSecretRef{
@@ -300,6 +309,14 @@ func TestValidateStore(t *testing.T) {
 			store: makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, "")),
 			err:   fmt.Errorf("received invalid Chef SecretStore resource: missing Secret Key"),
 		},
+		{
+			store: withTLS(makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, authKey)), []byte("ca"), nil),
+			err:   fmt.Errorf("received invalid Chef SecretStore resource: caBundle does not contain a PEM encoded certificate"),
+		},
+		{
+			store: withTLS(makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, authKey)), nil, []string{"sha256/abc"}),
+			err:   fmt.Errorf("received invalid Chef SecretStore resource: invalid certificate pin \"sha256/abc\": must be sha256/ followed by the base64 encoded SHA-256 hash of the SubjectPublicKeyInfo"),
+		},
 		{
 			store: makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, authKey)),
 			err:   fmt.Errorf("received invalid Chef SecretStore resource: namespace not allowed with namespaced SecretStore"),
@@ -375,6 +392,47 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClientPerStore(t *testing.T) {
+	key, err := getUnusedSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: authName, Namespace: "default"},
+		Data:       map[string][]byte{authKey: []byte(key)},
+	}).Build()
+	storeA := makeSecretStore("tenant-a", "https://chef.example.com/organizations/a/", makeAuth(authName, authNamespace, authKey))
+	storeA.Spec.Provider.Chef.Organizations = []string{"team_a"}
+	storeB := makeSecretStore("tenant-b", "https://chef.example.com/organizations/b/", makeAuth(authName, authNamespace, authKey))
+
+	pc := &Providerchef{}
+	clientA, err := pc.NewClient(context.Background(), storeA, kube, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientB, err := pc.NewClient(context.Background(), storeB, kube, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := clientA.(*Providerchef), clientB.(*Providerchef)
+	if a == b || a == pc || b == pc {
+		t.Fatalf("every store must get its own client")
+	}
+	if a.config.Name != "tenant-a" || a.config.BaseURL != "https://chef.example.com/organizations/a/" {
+		t.Errorf("client of store a got the config of %s %s", a.config.Name, a.config.BaseURL)
+	}
+	if b.config.Name != "tenant-b" || len(b.organizations) != 0 {
+		t.Errorf("client of store b got the config of %s and organizations %v", b.config.Name, b.organizations)
+	}
+	if len(a.organizations) != 1 || a.organizations[0] != "team_a" {
+		t.Errorf("client of store a lost its organizations: %v", a.organizations)
+	}
+	if pc.config != nil || pc.organizations != nil {
+		t.Errorf("the registered provider must not keep the state of a store")
+	}
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
@@ -403,26 +461,534 @@ func TestCapabilities(t *testing.T) {
 	t.Log(capabilities)
 }
 
-// Test Cases To be added when Close function is implemented.
-func TestClose(_ *testing.T) {
+func TestClose(t *testing.T) {
+	if err := (&Providerchef{}).Close(context.Background()); err != nil {
+		t.Errorf("Close() of an uninitialized provider returned %v", err)
+	}
+
+	key, err := getUnusedSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: authName, Namespace: "default"},
+		Data:       map[string][]byte{authKey: []byte(key)},
+	}).Build()
+	client, err := (&Providerchef{}).NewClient(context.Background(), makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, authKey)), kube, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := client.(*Providerchef)
+	if pc.config.Key != "" {
+		t.Errorf("the key of the config was not cleared")
+	}
+	if !bytes.Equal(pc.key.Copy(), []byte(key)) {
+		t.Errorf("the key was not kept in guarded memory")
+	}
+	privateKey := pc.privateKey
+	if err := pc.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if pc.key.Copy() != nil {
+		t.Errorf("Close() did not destroy the key")
+	}
+	if privateKey.D.Sign() != 0 {
+		t.Errorf("Close() did not wipe the parsed key")
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
 	pc := Providerchef{}
-	pc.Close(context.Background())
+	databag := "databag01"
+	for _, ref := range []esv1beta1.ExternalSecretFind{{}, {Path: &databag}} {
+		_, err := pc.GetAllSecrets(context.Background(), ref)
+		if err == nil || err.Error() != errInvalidCookbookPath {
+			t.Errorf("expected error: %v, got: %v", errInvalidCookbookPath, err)
+		}
+	}
+}
+
+func TestDeleteSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		databags    map[string]map[string]chef.DataBagItem
+		ref         testingfake.PushSecretData
+		wantErr     string
+		wantItem    map[string]any
+		wantUpdates int
+	}{
+		{
+			name:     "invalid remote key",
+			databags: map[string]map[string]chef.DataBagItem{},
+			ref:      testingfake.PushSecretData{RemoteKey: "databag"},
+			wantErr:  errInvalidPushKey,
+		},
+		{
+			name: "deletes the item",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "password": "s3cr3t"}},
+			},
+			ref:         testingfake.PushSecretData{RemoteKey: "app/db"},
+			wantUpdates: 1,
+		},
+		{
+			name:     "missing item is ignored",
+			databags: map[string]map[string]chef.DataBagItem{"app": {}},
+			ref:      testingfake.PushSecretData{RemoteKey: "app/db"},
+		},
+		{
+			name: "deletes the property",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "host": "db.example.com", "credentials": map[string]any{"password": "s3cr3t"}}},
+			},
+			ref: testingfake.PushSecretData{RemoteKey: "app/db", Property: "credentials.password"},
+			wantItem: map[string]any{
+				"id":          "db",
+				"host":        "db.example.com",
+				"credentials": map[string]any{},
+			},
+			wantUpdates: 1,
+		},
+		{
+			name: "missing property is ignored",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "host": "db.example.com"}},
+			},
+			ref: testingfake.PushSecretData{RemoteKey: "app/db", Property: "password"},
+			wantItem: map[string]any{
+				"id":   "db",
+				"host": "db.example.com",
+			},
+		},
+		{
+			name:     "property of missing item is ignored",
+			databags: map[string]map[string]chef.DataBagItem{"app": {}},
+			ref:      testingfake.PushSecretData{RemoteKey: "app/db", Property: "password"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &fake.ChefMockClient{}
+			mockClient.WithDatabags(tt.databags)
+			pc := Providerchef{databagService: mockClient}
+			err := pc.DeleteSecret(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				if !utils.ErrorContains(err, tt.wantErr) {
+					t.Fatalf("expected error: %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			databag, item, _ := strings.Cut(tt.ref.RemoteKey, "/")
+			got, ok := mockClient.Databags[databag][item]
+			if tt.wantItem == nil && ok {
+				t.Errorf("expected item to be deleted, got: %v", got)
+			}
+			if tt.wantItem != nil {
+				gotJSON, _ := json.Marshal(got)
+				want, _ := json.Marshal(tt.wantItem)
+				if !jsonEqual(gotJSON, want) {
+					t.Errorf("expected item: %s, got: %s", want, gotJSON)
+				}
+			}
+			if mockClient.Updates != tt.wantUpdates {
+				t.Errorf("expected %d updates, got: %d", tt.wantUpdates, mockClient.Updates)
+			}
+		})
+	}
 }
 
-// Test Cases To be added when GetAllSecrets function is implemented.
-func TestGetAllSecrets(_ *testing.T) {
+func TestPushSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			"username": []byte("app"),
+			"password": []byte("s3cr3t"),
+		},
+	}
+	tests := []struct {
+		name        string
+		databags    map[string]map[string]chef.DataBagItem
+		secret      *corev1.Secret
+		data        testingfake.PushSecretData
+		policy      esv1beta1.PushSecretUpdatePolicy
+		wantErr     string
+		wantItem    map[string]any
+		wantUpdates int
+	}{
+		{
+			name:     "invalid remote key",
+			databags: map[string]map[string]chef.DataBagItem{},
+			data:     testingfake.PushSecretData{RemoteKey: "databag", SecretKey: "password"},
+			wantErr:  errInvalidPushKey,
+		},
+		{
+			name:     "missing secret key",
+			databags: map[string]map[string]chef.DataBagItem{},
+			data:     testingfake.PushSecretData{RemoteKey: "app/db", SecretKey: "token"},
+			wantErr:  "secret key token not found",
+		},
+		{
+			name:     "creates data bag and item",
+			databags: map[string]map[string]chef.DataBagItem{},
+			data:     testingfake.PushSecretData{RemoteKey: "app/db", SecretKey: "password"},
+			wantItem: map[string]any{
+				"id":       "db",
+				"password": "s3cr3t",
+			},
+			wantUpdates: 1,
+		},
+		{
+			name: "sets nested property of existing item",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "host": "db.example.com"}},
+			},
+			data: testingfake.PushSecretData{RemoteKey: "app/db", SecretKey: "password", Property: "credentials.password"},
+			wantItem: map[string]any{
+				"id":          "db",
+				"host":        "db.example.com",
+				"credentials": map[string]any{"password": "s3cr3t"},
+			},
+			wantUpdates: 1,
+		},
+		{
+			name: "unchanged item is not updated",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "password": "s3cr3t"}},
+			},
+			data: testingfake.PushSecretData{RemoteKey: "app/db", SecretKey: "password"},
+			wantItem: map[string]any{
+				"id":       "db",
+				"password": "s3cr3t",
+			},
+		},
+		{
+			name: "whole secret replaces the item",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "host": "db.example.com", "password": "old"}},
+			},
+			data: testingfake.PushSecretData{RemoteKey: "app/db"},
+			wantItem: map[string]any{
+				"id":       "db",
+				"username": "app",
+				"password": "s3cr3t",
+			},
+			wantUpdates: 1,
+		},
+		{
+			name: "whole secret is merged into the item",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "host": "db.example.com", "password": "old"}},
+			},
+			data:   testingfake.PushSecretData{RemoteKey: "app/db"},
+			policy: esv1beta1.PushSecretUpdatePolicyMerge,
+			wantItem: map[string]any{
+				"id":       "db",
+				"host":     "db.example.com",
+				"username": "app",
+				"password": "s3cr3t",
+			},
+			wantUpdates: 1,
+		},
+		{
+			name: "whole secret is deep merged into a property",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "credentials": map[string]any{"password": "old", "role": "admin"}}},
+			},
+			data:   testingfake.PushSecretData{RemoteKey: "app/db", Property: "credentials"},
+			policy: esv1beta1.PushSecretUpdatePolicyMerge,
+			wantItem: map[string]any{
+				"id":          "db",
+				"credentials": map[string]any{"username": "app", "password": "s3cr3t", "role": "admin"},
+			},
+			wantUpdates: 1,
+		},
+		{
+			name: "existing property is kept with IfNotExists",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "password": "old"}},
+			},
+			data:   testingfake.PushSecretData{RemoteKey: "app/db", SecretKey: "password"},
+			policy: esv1beta1.PushSecretUpdatePolicyIfNotExists,
+			wantItem: map[string]any{
+				"id":       "db",
+				"password": "old",
+			},
+		},
+		{
+			name: "missing property is set with IfNotExists",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "password": "old"}},
+			},
+			data:   testingfake.PushSecretData{RemoteKey: "app/db", SecretKey: "username"},
+			policy: esv1beta1.PushSecretUpdatePolicyIfNotExists,
+			wantItem: map[string]any{
+				"id":       "db",
+				"username": "app",
+				"password": "old",
+			},
+			wantUpdates: 1,
+		},
+		{
+			name: "existing item is kept with IfNotExists",
+			databags: map[string]map[string]chef.DataBagItem{
+				"app": {"db": map[string]any{"id": "db", "host": "db.example.com"}},
+			},
+			data:   testingfake.PushSecretData{RemoteKey: "app/db"},
+			policy: esv1beta1.PushSecretUpdatePolicyIfNotExists,
+			wantItem: map[string]any{
+				"id":   "db",
+				"host": "db.example.com",
+			},
+		},
+		{
+			name:     "missing item is created with IfNotExists",
+			databags: map[string]map[string]chef.DataBagItem{},
+			data:     testingfake.PushSecretData{RemoteKey: "app/db"},
+			policy:   esv1beta1.PushSecretUpdatePolicyIfNotExists,
+			wantItem: map[string]any{
+				"id":       "db",
+				"username": "app",
+				"password": "s3cr3t",
+			},
+			wantUpdates: 1,
+		},
+		{
+			name:     "unknown update policy",
+			databags: map[string]map[string]chef.DataBagItem{"app": {}},
+			data:     testingfake.PushSecretData{RemoteKey: "app/db", SecretKey: "password"},
+			policy:   "Append",
+			wantErr:  `unsupported updatePolicy "Append"`,
+		},
+		{
+			name:     "whole secret is written to a property",
+			databags: map[string]map[string]chef.DataBagItem{"app": {}},
+			data:     testingfake.PushSecretData{RemoteKey: "app/db", Property: "credentials"},
+			wantItem: map[string]any{
+				"id":          "db",
+				"credentials": map[string]any{"username": "app", "password": "s3cr3t"},
+			},
+			wantUpdates: 1,
+		},
+		{
+			name:     "templated json item is merged into the item",
+			databags: map[string]map[string]chef.DataBagItem{"app": {}},
+			secret: &corev1.Secret{Data: map[string][]byte{
+				"item": []byte(`{"id":"db","mysql":{"user":"app","password":"s3cr3t"},"port":3306}`),
+			}},
+			data: testingfake.PushSecretData{
+				RemoteKey: "app/db",
+				SecretKey: "item",
+				Metadata:  &apiextensionsv1.JSON{Raw: []byte(`{"valueType":"json"}`)},
+			},
+			wantItem: map[string]any{
+				"id":    "db",
+				"mysql": map[string]any{"user": "app", "password": "s3cr3t"},
+				"port":  3306,
+			},
+			wantUpdates: 1,
+		},
+		{
+			name:     "json value is written to a property",
+			databags: map[string]map[string]chef.DataBagItem{"app": {}},
+			secret: &corev1.Secret{Data: map[string][]byte{
+				"ports": []byte(`[3306,33060]`),
+			}},
+			data: testingfake.PushSecretData{
+				RemoteKey: "app/db",
+				SecretKey: "ports",
+				Property:  "mysql.ports",
+				Metadata:  &apiextensionsv1.JSON{Raw: []byte(`{"valueType":"json"}`)},
+			},
+			wantItem: map[string]any{
+				"id":    "db",
+				"mysql": map[string]any{"ports": []any{3306, 33060}},
+			},
+			wantUpdates: 1,
+		},
+		{
+			name:     "json value without property must be an object",
+			databags: map[string]map[string]chef.DataBagItem{"app": {}},
+			data: testingfake.PushSecretData{
+				RemoteKey: "app/db",
+				SecretKey: "password",
+				Metadata:  &apiextensionsv1.JSON{Raw: []byte(`{"valueType":"json"}`)},
+			},
+			wantErr: "unable to decode value of secret key password as json",
+		},
+		{
+			name:     "unknown value type",
+			databags: map[string]map[string]chef.DataBagItem{"app": {}},
+			data: testingfake.PushSecretData{
+				RemoteKey: "app/db",
+				SecretKey: "password",
+				Metadata:  &apiextensionsv1.JSON{Raw: []byte(`{"valueType":"yaml"}`)},
+			},
+			wantErr: `invalid valueType "yaml"`,
+		},
+		{
+			name:     "binary value is rejected",
+			databags: map[string]map[string]chef.DataBagItem{"app": {}},
+			secret: &corev1.Secret{Data: map[string][]byte{
+				"keystore": {0xfe, 0xed, 0xfe, 0xed, 0x00, 0xff},
+			}},
+			data:    testingfake.PushSecretData{RemoteKey: "app/db", SecretKey: "keystore"},
+			wantErr: "value of secret key keystore is not valid UTF-8",
+		},
+		{
+			name:     "binary value is written as base64",
+			databags: map[string]map[string]chef.DataBagItem{"app": {}},
+			secret: &corev1.Secret{Data: map[string][]byte{
+				"keystore": {0xfe, 0xed, 0xfe, 0xed, 0x00, 0xff},
+			}},
+			data: testingfake.PushSecretData{
+				RemoteKey: "app/db",
+				SecretKey: "keystore",
+				Metadata:  &apiextensionsv1.JSON{Raw: []byte(`{"valueType":"base64"}`)},
+			},
+			wantItem: map[string]any{
+				"id":       "db",
+				"keystore": "/u3+7QD/",
+			},
+			wantUpdates: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &fake.ChefMockClient{}
+			mockClient.WithDatabags(tt.databags)
+			pc := Providerchef{databagService: mockClient}
+			src := secret
+			if tt.secret != nil {
+				src = tt.secret
+			}
+			var err error
+			if tt.policy != "" {
+				err = pc.PushSecretWithPolicy(context.Background(), src, tt.data, tt.policy)
+			} else {
+				err = pc.PushSecret(context.Background(), src, tt.data)
+			}
+			if tt.wantErr != "" {
+				if !utils.ErrorContains(err, tt.wantErr) {
+					t.Fatalf("expected error: %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			databag, item, _ := strings.Cut(tt.data.RemoteKey, "/")
+			got, err := json.Marshal(mockClient.Databags[databag][item])
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, _ := json.Marshal(tt.wantItem)
+			if !jsonEqual(got, want) {
+				t.Errorf("expected item: %s, got: %s", want, got)
+			}
+			if mockClient.Updates != tt.wantUpdates {
+				t.Errorf("expected %d updates, got: %d", tt.wantUpdates, mockClient.Updates)
+			}
+		})
+	}
+}
+
+func TestValidateStoreDeprecatedFields(t *testing.T) {
+	store := makeSecretStore("", "", nil)
+	spec := `{"name":"` + name + `","baseUrl":"` + baseURL + `","auth":{"secretRef":{"privateKeySecretRef":{"name":"` + authName + `","key":"` + authKey + `"}}}}`
+	if err := json.Unmarshal([]byte(spec), store.Spec.Provider.Chef); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 	pc := Providerchef{}
-	pc.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+	warnings, err := pc.ValidateStore(store)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(warnings, "; ") != warnDeprecatedName+"; "+warnDeprecatedBaseURL {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
 }
 
-// Test Cases To be implemented when DeleteSecret function is implemented.
-func TestDeleteSecret(_ *testing.T) {
+func TestOnlineValidationEnabled(t *testing.T) {
 	pc := Providerchef{}
-	pc.DeleteSecret(context.Background(), nil)
+	store := makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, authKey))
+	if pc.OnlineValidationEnabled(store) {
+		t.Errorf("online validation should be disabled by default")
+	}
+	store.Spec.Provider.Chef.OnlineValidation = true
+	if !pc.OnlineValidationEnabled(store) {
+		t.Errorf("online validation should be enabled")
+	}
 }
 
-// Test Cases To be implemented when PushSecret function is implemented.
-func TestPushSecret(_ *testing.T) {
+func TestReadOnlyStrict(t *testing.T) {
 	pc := Providerchef{}
-	pc.PushSecret(context.Background(), &corev1.Secret{}, nil)
+	store := makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, authKey))
+	if pc.PushDenied(store) {
+		t.Errorf("pushing should be allowed by default")
+	}
+	store.Spec.Provider.Chef.ReadOnlyStrict = true
+	if !pc.PushDenied(store) {
+		t.Errorf("pushing should be denied")
+	}
+
+	mock := &fake.ChefMockClient{}
+	mock.WithDatabags(map[string]map[string]chef.DataBagItem{
+		"app": {"db": map[string]any{"id": "db", "password": "s3cr3t"}},
+	})
+	pc = Providerchef{databagService: mock, readOnly: true}
+	secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("changed")}}
+	data := testingfake.PushSecretData{RemoteKey: "app/db", SecretKey: "password"}
+	if err := pc.PushSecret(context.Background(), secret, data); err == nil || err.Error() != errReadOnlyStrict {
+		t.Errorf("expected push to be denied, got %v", err)
+	}
+	if err := pc.DeleteSecret(context.Background(), data); err == nil || err.Error() != errReadOnlyStrict {
+		t.Errorf("expected delete to be denied, got %v", err)
+	}
+}
+
+func TestReadOnlyStrictPerStore(t *testing.T) {
+	key, err := getUnusedSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: authName, Namespace: "default"},
+		Data:       map[string][]byte{authKey: []byte(key)},
+	}).Build()
+	readOnly := makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, authKey))
+	readOnly.Spec.Provider.Chef.ReadOnlyStrict = true
+	writable := makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, authKey))
+
+	// the client of the writable store is built last, it must not lift
+	// the guard of the read only store.
+	pc := &Providerchef{}
+	readOnlyClient, err := pc.NewClient(context.Background(), readOnly, kube, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writableClient, err := pc.NewClient(context.Background(), writable, kube, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, client := range []esv1beta1.SecretsClient{readOnlyClient, writableClient} {
+		mock := &fake.ChefMockClient{}
+		mock.WithDatabags(map[string]map[string]chef.DataBagItem{
+			"app": {"db": map[string]any{"id": "db", "password": "s3cr3t"}},
+		})
+		client.(*Providerchef).databagService = mock
+	}
+
+	secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("changed")}}
+	data := testingfake.PushSecretData{RemoteKey: "app/db", SecretKey: "password"}
+	if err := readOnlyClient.PushSecret(context.Background(), secret, data); err == nil || err.Error() != errReadOnlyStrict {
+		t.Errorf("expected push to be denied, got %v", err)
+	}
+	if err := readOnlyClient.DeleteSecret(context.Background(), data); err == nil || err.Error() != errReadOnlyStrict {
+		t.Errorf("expected delete to be denied, got %v", err)
+	}
+	if err := writableClient.PushSecret(context.Background(), secret, data); err != nil {
+		t.Errorf("expected push to be allowed, got %v", err)
+	}
 }
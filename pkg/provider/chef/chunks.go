@@ -0,0 +1,132 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	// chunkValueProperty holds the part of the value in every chunk.
+	chunkValueProperty = "value"
+	// chunkCountProperty optionally holds the number of chunks in the first chunk.
+	chunkCountProperty = "parts"
+	// maxChunks bounds the number of chunks of one value.
+	maxChunks = 1000
+
+	errChunkValue   = "chunk %s of data bag %s has no string %s property"
+	errChunkCount   = "chunk %s of data bag %s has an invalid %s property"
+	errChunkMissing = "chunk %s of data bag %s is missing, expected %d chunks"
+	errChunkLimit   = "data bag item %s of data bag %s has more than %d chunks"
+)
+
+// chunkName matches the names of chunk items, e.g. keytab_part3.
+var chunkName = regexp.MustCompile(`^(.+)_part(\d+)$`)
+
+func chunkItemName(itemName string, part int) string {
+	return itemName + "_part" + strconv.Itoa(part)
+}
+
+// getDatabagItem returns the data bag item as JSON. If the item does not
+// exist, but chunks named <item>_part0 to <item>_partN do, the value
+// properties of the chunks are concatenated and returned instead.
+func (providerchef *Providerchef) getDatabagItem(databagName, itemName string) ([]byte, error) {
+	item, err := providerchef.databagService.GetItem(databagName, itemName)
+	metrics.ObserveAPICall(ProviderChef, CallChefGetDataBagItem, err)
+	if err == nil {
+		jsonByte, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf(errUnableToConvertToJSON)
+		}
+		return jsonByte, nil
+	}
+	if !isNotFound(err) {
+		return nil, fmt.Errorf(errNoDatabagItemFound, itemName, databagName)
+	}
+	value, found, err := providerchef.getChunkedValue(databagName, itemName)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &notFoundError{msg: fmt.Sprintf(errNoDatabagItemFound, itemName, databagName)}
+	}
+	return value, nil
+}
+
+// getChunkedValue reassembles the chunks of an item. found is false if the
+// first chunk does not exist. If the first chunk sets parts, every chunk up to
+// parts must exist, otherwise the chunks are read until one is missing.
+func (providerchef *Providerchef) getChunkedValue(databagName, itemName string) (value []byte, found bool, err error) {
+	var buf bytes.Buffer
+	parts := -1
+	for part := 0; parts < 0 || part < parts; part++ {
+		if part >= maxChunks {
+			return nil, true, fmt.Errorf(errChunkLimit, itemName, databagName, maxChunks)
+		}
+		name := chunkItemName(itemName, part)
+		item, err := providerchef.databagService.GetItem(databagName, name)
+		metrics.ObserveAPICall(ProviderChef, CallChefGetDataBagItem, err)
+		if isNotFound(err) && part == 0 {
+			return nil, false, nil
+		}
+		if isNotFound(err) && parts < 0 {
+			break
+		}
+		if isNotFound(err) {
+			return nil, true, fmt.Errorf(errChunkMissing, name, databagName, parts)
+		}
+		if err != nil {
+			return nil, true, fmt.Errorf(errGetDatabagItem, name, databagName, err)
+		}
+		content, _ := item.(map[string]any)
+		chunk, ok := content[chunkValueProperty].(string)
+		if !ok {
+			return nil, true, fmt.Errorf(errChunkValue, name, databagName, chunkValueProperty)
+		}
+		if count, ok := content[chunkCountProperty]; ok && part == 0 {
+			n, ok := count.(float64)
+			if !ok || n < 1 || n != float64(int(n)) {
+				return nil, true, fmt.Errorf(errChunkCount, name, databagName, chunkCountProperty)
+			}
+			parts = int(n)
+		}
+		buf.WriteString(chunk)
+	}
+	return buf.Bytes(), true, nil
+}
+
+// chunkedItems returns the names of the chunked items of a data bag listing,
+// keyed by the name of the reassembled item. Chunks of an item that exists
+// themselves are not reassembled.
+func chunkedItems(items map[string]string) map[string]string {
+	chunked := map[string]string{}
+	for name := range items {
+		m := chunkName.FindStringSubmatch(name)
+		if m == nil || m[2] != "0" {
+			continue
+		}
+		if _, ok := items[m[1]]; ok {
+			continue
+		}
+		chunked[m[1]] = name
+	}
+	return chunked
+}
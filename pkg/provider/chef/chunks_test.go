@@ -0,0 +1,122 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-chef/chef"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	fake "github.com/external-secrets/external-secrets/pkg/provider/chef/fake"
+)
+
+func chunk(id string, value any) chef.DataBagItem {
+	return map[string]any{"id": id, "value": value}
+}
+
+func TestGetSecretChunked(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    map[string]chef.DataBagItem
+		property string
+		want     string
+		wantErr  string
+	}{
+		{
+			name: "chunks",
+			items: map[string]chef.DataBagItem{
+				"keytab_part0": chunk("keytab_part0", "first-"),
+				"keytab_part1": chunk("keytab_part1", "second-"),
+				"keytab_part2": chunk("keytab_part2", "third"),
+			},
+			want: "first-second-third",
+		},
+		{
+			name: "json chunks with property",
+			items: map[string]chef.DataBagItem{
+				"keytab_part0": chunk("keytab_part0", `{"password":`),
+				"keytab_part1": chunk("keytab_part1", `"secret"}`),
+			},
+			property: "password",
+			want:     "secret",
+		},
+		{
+			name: "item takes precedence",
+			items: map[string]chef.DataBagItem{
+				"keytab":       map[string]any{"id": "keytab", "password": "item"},
+				"keytab_part0": chunk("keytab_part0", `{"password":"chunk"}`),
+			},
+			property: "password",
+			want:     "item",
+		},
+		{
+			name: "missing chunk with parts",
+			items: map[string]chef.DataBagItem{
+				"keytab_part0": map[string]any{"id": "keytab_part0", "value": "first-", "parts": float64(3)},
+				"keytab_part1": chunk("keytab_part1", "second-"),
+			},
+			wantErr: "chunk keytab_part2 of data bag secrets is missing, expected 3 chunks",
+		},
+		{
+			name: "chunk without value",
+			items: map[string]chef.DataBagItem{
+				"keytab_part0": chunk("keytab_part0", 42),
+			},
+			wantErr: "chunk keytab_part0 of data bag secrets has no string value property",
+		},
+		{
+			name:    "no item and no chunks",
+			items:   map[string]chef.DataBagItem{},
+			wantErr: "data bag item keytab not found in data bag secrets",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &fake.ChefMockClient{}
+			mock.WithDatabags(map[string]map[string]chef.DataBagItem{"secrets": tt.items})
+			pc := &Providerchef{log: logr.Discard(), databagService: mock}
+			got, err := pc.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "secrets/keytab", Property: tt.property})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestGetSecretMapChunked(t *testing.T) {
+	mock := &fake.ChefMockClient{}
+	mock.WithDatabags(map[string]map[string]chef.DataBagItem{"secrets": {
+		"keytab_part0": chunk("keytab_part0", "first-"),
+		"keytab_part1": chunk("keytab_part1", "second"),
+		"db":           map[string]any{"id": "db", "password": "secret"},
+		"db_part0":     chunk("db_part0", "ignored"),
+	}})
+	pc := &Providerchef{log: logr.Discard(), databagService: mock}
+	got, err := pc.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "secrets"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"keytab":   []byte("first-second"),
+		"db":       []byte(`{"id":"db","password":"secret"}`),
+		"db_part0": []byte(`{"id":"db_part0","value":"ignored"}`),
+	}, got)
+}
@@ -0,0 +1,54 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"testing"
+
+	"github.com/go-chef/chef"
+	"github.com/go-logr/logr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/chef/fake"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Suite{
+		NewClient: func(t *testing.T) esv1beta1.SecretsClient {
+			mock := &fake.ChefMockClient{}
+			mock.WithDatabags(map[string]map[string]chef.DataBagItem{
+				"conformance": {
+					"app": map[string]any{"id": "app", "password": "s3cr3t", "user": "admin", "port": 5432},
+				},
+				"config": {
+					"db": map[string]any{"id": "db", "host": "db.example.com"},
+				},
+			})
+			return &Providerchef{log: logr.Discard(), databagService: mock}
+		},
+		Key:           "conformance/app",
+		Property:      "password",
+		PropertyValue: []byte("s3cr3t"),
+		MissingKey:    "conformance/missing",
+		MapKey:        "config",
+		MapValue: map[string][]byte{
+			"db": []byte(`{"host":"db.example.com","id":"db"}`),
+		},
+		MissingMapKey: "missing",
+		PushKey:       "conformance/pushed",
+		PushProperty:  "value",
+	})
+}
@@ -0,0 +1,246 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // md5 is the checksum chef uses for cookbook files
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chef/chef"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	// cookbookKeyPrefix marks a remoteRef key that addresses a cookbook file
+	// instead of a data bag item: cookbook:<name>/<version>/<path>.
+	// Data bag names can not contain a colon, so the keys do not collide.
+	cookbookKeyPrefix = "cookbook:"
+	latestCookbook    = "_latest"
+	// maxCookbookFileSize matches the maximum size of a Kubernetes Secret.
+	maxCookbookFileSize = 1 << 20
+
+	errInvalidCookbookKey   = "invalid cookbook key format. Expected value 'cookbook:cookbookName/version/path'"
+	errCookbookProperty     = "property is not supported for cookbook files"
+	errGetCookbook          = "unable to get version %s of cookbook %s: %w"
+	errCookbookFileNotFound = "file %s not found in version %s of cookbook %s"
+	errGetCookbookFile      = "unable to get file %s of cookbook %s: %w"
+	errCookbookFileTooLarge = "file %s of cookbook %s exceeds %d bytes"
+	errCookbookFileChecksum = "checksum mismatch of file %s of cookbook %s"
+	errInvalidCookbookPath  = "invalid find.path format. Expected value 'cookbook:cookbookName/version'"
+	errFindTagsNotSupported = "find.tags is not supported for cookbook files"
+)
+
+// CookbookFetcher is the subset of the Chef cookbooks API used to read cookbook files.
+type CookbookFetcher interface {
+	GetFiles(ctx context.Context, name, version string) ([]chef.CookbookItem, error)
+	GetFile(ctx context.Context, item chef.CookbookItem) ([]byte, error)
+}
+
+// cookbookService reads cookbook files with the chef client. The cookbook
+// is decoded by hand because go-chef does not decode the all_files list
+// that the server returns for API version 1.
+type cookbookService struct {
+	client *chef.Client
+}
+
+type cookbookVersion struct {
+	AllFiles []chef.CookbookItem `json:"all_files"`
+	chef.Cookbook
+}
+
+func (s *cookbookService) GetFiles(ctx context.Context, name, version string) ([]chef.CookbookItem, error) {
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("cookbooks/%s/%s", url.PathEscape(name), url.PathEscape(version)), nil)
+	if err != nil {
+		return nil, err
+	}
+	var cookbook cookbookVersion
+	res, err := s.client.Do(req.WithContext(ctx), &cookbook)
+	if res != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	files := cookbook.AllFiles
+	for _, segment := range [][]chef.CookbookItem{
+		cookbook.RootFiles, cookbook.Files, cookbook.Templates, cookbook.Attributes, cookbook.Recipes,
+		cookbook.Definitions, cookbook.Libraries, cookbook.Providers, cookbook.Resources,
+	} {
+		files = append(files, segment...)
+	}
+	return files, nil
+}
+
+func (s *cookbookService) GetFile(ctx context.Context, item chef.CookbookItem) ([]byte, error) {
+	req, err := s.client.NewRequest(http.MethodGet, item.Url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Do(req.WithContext(ctx), nil)
+	if res != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(io.LimitReader(res.Body, maxCookbookFileSize+1))
+}
+
+// parseCookbookKey splits a key of the form cookbook:<name>/<version>/<path>.
+// The version latest or _latest selects the latest version of the cookbook.
+func parseCookbookKey(key string) (name, version, path string, err error) {
+	parts := strings.SplitN(strings.TrimPrefix(key, cookbookKeyPrefix), "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf(errInvalidCookbookKey)
+	}
+	version = parts[1]
+	if version == "latest" {
+		version = latestCookbook
+	}
+	return parts[0], version, parts[2], nil
+}
+
+// getCookbookFile returns the content of a cookbook file, e.g. a certificate
+// that is shipped in files/default of a cookbook.
+func (providerchef *Providerchef) getCookbookFile(ctx context.Context, key string) ([]byte, error) {
+	name, version, path, err := parseCookbookKey(key)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, contextTimeout)
+	defer cancel()
+
+	providerchef.log.Info("fetching cookbook file", "cookbook", name, "version", version, "path", path)
+	files, err := providerchef.cookbookService.GetFiles(ctx, name, version)
+	metrics.ObserveAPICall(ProviderChef, CallChefGetCookbook, err)
+	if err != nil {
+		return nil, fmt.Errorf(errGetCookbook, version, name, err)
+	}
+	var item *chef.CookbookItem
+	for i := range files {
+		if files[i].Path == path {
+			item = &files[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, fmt.Errorf(errCookbookFileNotFound, path, version, name)
+	}
+	return providerchef.readCookbookFile(ctx, name, *item)
+}
+
+// parseCookbookPath splits a find.path of the form cookbook:<name>/<version>.
+func parseCookbookPath(path string) (name, version string, err error) {
+	parts := strings.Split(strings.TrimPrefix(path, cookbookKeyPrefix), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf(errInvalidCookbookPath)
+	}
+	version = parts[1]
+	if version == "latest" {
+		version = latestCookbook
+	}
+	return parts[0], version, nil
+}
+
+// findCookbookFiles returns the files of a cookbook version whose path
+// matches find.name, keyed by path. The manifest of the cookbook lists the
+// checksums of all files, only files with a checksum that is not cached are
+// fetched, so that repeated syncs of large cookbooks stay cheap.
+func (providerchef *Providerchef) findCookbookFiles(ctx context.Context, ref v1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	name, version, err := parseCookbookPath(*ref.Path)
+	if err != nil {
+		return nil, err
+	}
+	if len(ref.Tags) > 0 {
+		return nil, fmt.Errorf(errFindTagsNotSupported)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, contextTimeout)
+	defer cancel()
+
+	providerchef.log.Info("finding cookbook files", "cookbook", name, "version", version)
+	files, err := providerchef.cookbookService.GetFiles(ctx, name, version)
+	metrics.ObserveAPICall(ProviderChef, CallChefGetCookbook, err)
+	if err != nil {
+		return nil, fmt.Errorf(errGetCookbook, version, name, err)
+	}
+	secrets := make(map[string][]byte)
+	for _, item := range files {
+		if matcher != nil && !matcher.MatchName(item.Path) {
+			continue
+		}
+		content, err := providerchef.getCachedCookbookFile(ctx, name, item)
+		if err != nil {
+			return nil, err
+		}
+		secrets[item.Path] = content
+	}
+	return secrets, nil
+}
+
+// getCachedCookbookFile returns the file from the cache, or fetches and
+// caches it. The cache is keyed by server, so a file is only returned from
+// the cache after the credentials of the store listed its checksum.
+func (providerchef *Providerchef) getCachedCookbookFile(ctx context.Context, name string, item chef.CookbookItem) ([]byte, error) {
+	if item.Checksum == "" {
+		return providerchef.readCookbookFile(ctx, name, item)
+	}
+	key := item.Checksum
+	if providerchef.config != nil {
+		key = providerchef.config.BaseURL + key
+	}
+	if content, ok := cookbookFiles.Get(key); ok {
+		return content, nil
+	}
+	content, err := providerchef.readCookbookFile(ctx, name, item)
+	if err != nil {
+		return nil, err
+	}
+	cookbookFiles.Put(key, content)
+	return content, nil
+}
+
+// readCookbookFile fetches a file of a cookbook and verifies its checksum.
+func (providerchef *Providerchef) readCookbookFile(ctx context.Context, name string, item chef.CookbookItem) ([]byte, error) {
+	path := item.Path
+	content, err := providerchef.cookbookService.GetFile(ctx, item)
+	metrics.ObserveAPICall(ProviderChef, CallChefGetCookbookFile, err)
+	if err != nil {
+		return nil, fmt.Errorf(errGetCookbookFile, path, name, err)
+	}
+	if len(content) > maxCookbookFileSize {
+		return nil, fmt.Errorf(errCookbookFileTooLarge, path, name, maxCookbookFileSize)
+	}
+	sum := md5.Sum(content) //nolint:gosec
+	if item.Checksum != "" && hex.EncodeToString(sum[:]) != item.Checksum {
+		return nil, fmt.Errorf(errCookbookFileChecksum, path, name)
+	}
+	return content, nil
+}
@@ -0,0 +1,97 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/external-secrets/external-secrets/pkg/utils/guarded"
+)
+
+// maxCachedCookbookBytes bounds the size of the cookbook files kept between syncs.
+const maxCachedCookbookBytes = 64 << 20
+
+// cookbookFiles keeps the cookbook files synced with dataFrom.find, so that
+// only files whose checksum changed are fetched again. Clients are created
+// for every sync, so the cache is shared by all clients.
+var cookbookFiles = newFileCache(maxCachedCookbookBytes)
+
+// fileCache caches files by server and checksum. The content of a file with
+// a given checksum never changes. Values are kept in guarded memory and
+// wiped on eviction.
+type fileCache struct {
+	mutex    sync.Mutex
+	entries  map[string]*fileCacheEntry
+	lru      list.List
+	size     int
+	maxBytes int
+}
+
+type fileCacheEntry struct {
+	value *guarded.Bytes
+	size  int
+	elem  *list.Element
+}
+
+func newFileCache(maxBytes int) *fileCache {
+	return &fileCache{
+		entries:  map[string]*fileCacheEntry{},
+		maxBytes: maxBytes,
+	}
+}
+
+// Get returns the cached file.
+func (c *fileCache) Get(key string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(entry.elem)
+	return entry.value.Copy(), true
+}
+
+// Put caches the file, files larger than the cache are not cached.
+func (c *fileCache) Put(key string, value []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.entries[key]; ok || len(value) > c.maxBytes {
+		return
+	}
+	for c.size+len(value) > c.maxBytes {
+		c.evictLeastRecentlyUsed()
+	}
+	c.entries[key] = &fileCacheEntry{
+		value: guarded.New(value),
+		size:  len(value),
+		elem:  c.lru.PushFront(key),
+	}
+	c.size += len(value)
+}
+
+func (c *fileCache) evictLeastRecentlyUsed() {
+	elem := c.lru.Back()
+	if elem == nil {
+		return
+	}
+	key := elem.Value.(string)
+	entry := c.entries[key]
+	entry.value.Destroy()
+	c.lru.Remove(elem)
+	c.size -= entry.size
+	delete(c.entries, key)
+}
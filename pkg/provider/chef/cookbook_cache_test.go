@@ -0,0 +1,45 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCache(t *testing.T) {
+	c := newFileCache(4)
+	c.Put("a", []byte("a1"))
+	c.Put("b", []byte("b1"))
+
+	got, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a1"), got)
+
+	// b is the least recently used file
+	c.Put("c", []byte("c1"))
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 4, c.size)
+
+	// files larger than the cache are not cached
+	c.Put("d", []byte("d1234"))
+	_, ok = c.Get("d")
+	assert.False(t, ok)
+	assert.Len(t, c.entries, 2)
+}
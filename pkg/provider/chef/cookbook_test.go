@@ -0,0 +1,176 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chef/chef"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	fake "github.com/external-secrets/external-secrets/pkg/provider/chef/fake"
+)
+
+func TestChefGetCookbookFile(t *testing.T) {
+	cert := []byte("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n")
+	mock := &fake.CookbookMockClient{Cookbooks: map[string]map[string][]byte{
+		"nginx/1.2.0":   {"files/default/cert.pem": cert},
+		"nginx/_latest": {"files/default/cert.pem": []byte("latest")},
+	}}
+	tests := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		want     []byte
+		wantErr  string
+		noClient bool
+	}{
+		{
+			name: "file of version",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "cookbook:nginx/1.2.0/files/default/cert.pem"},
+			want: cert,
+		},
+		{
+			name: "file of latest version",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "cookbook:nginx/latest/files/default/cert.pem"},
+			want: []byte("latest"),
+		},
+		{
+			name:    "missing file",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "cookbook:nginx/1.2.0/files/default/key.pem"},
+			wantErr: "file files/default/key.pem not found in version 1.2.0 of cookbook nginx",
+		},
+		{
+			name:    "missing cookbook",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "cookbook:apache/1.2.0/files/default/cert.pem"},
+			wantErr: "unable to get version 1.2.0 of cookbook apache",
+		},
+		{
+			name:    "missing path",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "cookbook:nginx/1.2.0"},
+			wantErr: errInvalidCookbookKey,
+		},
+		{
+			name:    "property",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "cookbook:nginx/1.2.0/files/default/cert.pem", Property: "foo"},
+			wantErr: errCookbookProperty,
+		},
+		{
+			name:     "uninitialized",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "cookbook:nginx/1.2.0/files/default/cert.pem"},
+			noClient: true,
+			wantErr:  errUninitalizedChefProvider,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &Providerchef{log: logr.Discard()}
+			if !tt.noClient {
+				pc.cookbookService = mock
+			}
+			got, err := pc.GetSecret(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChefFindCookbookFiles(t *testing.T) {
+	mock := &fake.CookbookMockClient{Cookbooks: map[string]map[string][]byte{
+		"haproxy/1.0.0": {
+			"files/default/cert.pem": []byte("haproxy certificate"),
+			"files/default/key.pem":  []byte("haproxy key"),
+			"recipes/default.rb":     []byte("package 'haproxy'"),
+		},
+	}}
+	pc := &Providerchef{log: logr.Discard(), cookbookService: mock}
+	path := "cookbook:haproxy/1.0.0"
+	ref := esv1beta1.ExternalSecretFind{Path: &path, Name: &esv1beta1.FindName{RegExp: "^files/"}}
+
+	got, err := pc.GetAllSecrets(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"files/default/cert.pem": []byte("haproxy certificate"),
+		"files/default/key.pem":  []byte("haproxy key"),
+	}, got)
+	assert.Equal(t, 2, mock.FileCalls)
+
+	// unchanged files are not fetched again
+	_, err = pc.GetAllSecrets(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, 2, mock.FileCalls)
+
+	// only the changed file is fetched
+	mock.Cookbooks["haproxy/1.0.0"]["files/default/cert.pem"] = []byte("haproxy certificate v2")
+	got, err = pc.GetAllSecrets(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("haproxy certificate v2"), got["files/default/cert.pem"])
+	assert.Equal(t, 3, mock.FileCalls)
+
+	invalid := "cookbook:haproxy"
+	_, err = pc.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Path: &invalid})
+	assert.ErrorContains(t, err, errInvalidCookbookPath)
+	_, err = pc.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Path: &path, Tags: map[string]string{"a": "b"}})
+	assert.ErrorContains(t, err, errFindTagsNotSupported)
+}
+
+func TestCookbookServiceGetFiles(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/cookbooks/nginx/1.2.0", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"cookbook_name":"nginx","version":"1.2.0","all_files":[
+			{"name":"files/default/cert.pem","path":"files/default/cert.pem","checksum":"aef6d9a8c41b4e2ba4f8e7f5e1d0c2b3","url":"` + server.URL + `/bookshelf/cert"}
+		]}`))
+	})
+	mux.HandleFunc("/bookshelf/cert", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("certificate"))
+	})
+
+	s := &cookbookService{client: newTestClient(t, server.URL+"/")}
+
+	files, err := s.GetFiles(context.Background(), "nginx", "1.2.0")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "files/default/cert.pem", files[0].Path)
+
+	content, err := s.GetFile(context.Background(), files[0])
+	require.NoError(t, err)
+	assert.Equal(t, []byte("certificate"), content)
+}
+
+// newTestClient returns a chef client for a test server with a generated key.
+func newTestClient(t *testing.T, baseURL string) *chef.Client {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	client, err := chef.NewClient(&chef.Config{Name: "user", Key: string(keyPEM), BaseURL: baseURL})
+	require.NoError(t, err)
+	return client
+}
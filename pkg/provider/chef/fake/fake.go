@@ -14,9 +14,14 @@ limitations under the License.
 package fake
 
 import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"net/http"
 
 	"github.com/go-chef/chef"
 )
@@ -31,6 +36,11 @@ type ChefMockClient struct {
 	getItem   func(databagName string, databagItem string) (item chef.DataBagItem, err error)
 	listItems func(name string) (data *chef.DataBagListResult, err error)
 	getUser   func(name string) (user chef.User, err error)
+
+	// Databags holds the items of a writable mock, see WithDatabags.
+	Databags map[string]map[string]chef.DataBagItem
+	// Updates counts the writes of a writable mock.
+	Updates int
 }
 
 func (mc *ChefMockClient) GetItem(databagName, databagItem string) (item chef.DataBagItem, err error) {
@@ -41,6 +51,103 @@ func (mc *ChefMockClient) ListItems(name string) (data *chef.DataBagListResult,
 	return mc.listItems(name)
 }
 
+func (mc *ChefMockClient) Create(databag *chef.DataBag) (*chef.DataBagCreateResult, error) {
+	if mc.Databags == nil {
+		return nil, errors.New("not implemented")
+	}
+	if _, ok := mc.Databags[databag.Name]; ok {
+		return nil, chefError(http.StatusConflict)
+	}
+	mc.Databags[databag.Name] = make(map[string]chef.DataBagItem)
+	return &chef.DataBagCreateResult{}, nil
+}
+
+func (mc *ChefMockClient) CreateItem(databagName string, databagItem chef.DataBagItem) error {
+	items, ok := mc.Databags[databagName]
+	if !ok {
+		return chefError(http.StatusNotFound)
+	}
+	id, err := itemID(databagItem)
+	if err != nil {
+		return err
+	}
+	if _, ok := items[id]; ok {
+		return chefError(http.StatusConflict)
+	}
+	items[id] = databagItem
+	mc.Updates++
+	return nil
+}
+
+func (mc *ChefMockClient) UpdateItem(databagName, databagItemID string, databagItem chef.DataBagItem) error {
+	items, ok := mc.Databags[databagName]
+	if !ok {
+		return chefError(http.StatusNotFound)
+	}
+	if _, ok := items[databagItemID]; !ok {
+		return chefError(http.StatusNotFound)
+	}
+	items[databagItemID] = databagItem
+	mc.Updates++
+	return nil
+}
+
+func (mc *ChefMockClient) DeleteItem(databagName, databagItem string) error {
+	items, ok := mc.Databags[databagName]
+	if !ok {
+		return chefError(http.StatusNotFound)
+	}
+	if _, ok := items[databagItem]; !ok {
+		return chefError(http.StatusNotFound)
+	}
+	delete(items, databagItem)
+	mc.Updates++
+	return nil
+}
+
+// WithDatabags makes the mock writable, items are read from, listed and written to databags.
+func (mc *ChefMockClient) WithDatabags(databags map[string]map[string]chef.DataBagItem) {
+	if mc != nil {
+		mc.Databags = databags
+		mc.getItem = func(databagName, databagItemName string) (chef.DataBagItem, error) {
+			item, ok := mc.Databags[databagName][databagItemName]
+			if !ok {
+				return nil, chefError(http.StatusNotFound)
+			}
+			return item, nil
+		}
+		mc.listItems = func(databagName string) (*chef.DataBagListResult, error) {
+			items, ok := mc.Databags[databagName]
+			if !ok {
+				return nil, chefError(http.StatusNotFound)
+			}
+			result := chef.DataBagListResult{}
+			for name := range items {
+				result[name] = "https://chef.com/organizations/dev/data/" + databagName + "/" + name
+			}
+			return &result, nil
+		}
+	}
+}
+
+func itemID(databagItem chef.DataBagItem) (string, error) {
+	raw, err := json.Marshal(databagItem)
+	if err != nil {
+		return "", err
+	}
+	var item struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return "", err
+	}
+	return item.ID, nil
+}
+
+func chefError(status int) error {
+	return &chef.ErrorResponse{Response: &http.Response{StatusCode: status}}
+}
+
 func (mc *ChefMockClient) Get(name string) (user chef.User, err error) {
 	if name == CORRECTUSER {
 		user = chef.User{
@@ -102,3 +209,58 @@ func (mc *ChefMockClient) WithUser(_ string, _ error) {
 		}
 	}
 }
+
+// CookbookMockClient serves the files of cookbooks by name, version and path.
+type CookbookMockClient struct {
+	// Cookbooks maps name/version to the files of the cookbook by path.
+	Cookbooks map[string]map[string][]byte
+	// FileCalls counts the files that were fetched.
+	FileCalls int
+}
+
+func (mc *CookbookMockClient) GetFiles(_ context.Context, name, version string) ([]chef.CookbookItem, error) {
+	files, ok := mc.Cookbooks[name+"/"+version]
+	if !ok {
+		return nil, chefError(http.StatusNotFound)
+	}
+	items := make([]chef.CookbookItem, 0, len(files))
+	for path, content := range files {
+		sum := md5.Sum(content) //nolint:gosec
+		items = append(items, chef.CookbookItem{
+			Url:      name + "/" + version + "/" + path,
+			Path:     path,
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+	return items, nil
+}
+
+func (mc *CookbookMockClient) GetFile(_ context.Context, item chef.CookbookItem) ([]byte, error) {
+	mc.FileCalls++
+	for key, files := range mc.Cookbooks {
+		if content, ok := files[item.Path]; ok && item.Url == key+"/"+item.Path {
+			return content, nil
+		}
+	}
+	return nil, chefError(http.StatusNotFound)
+}
+
+// KeyMockClient serves the public keys of clients and users by name/key.
+type KeyMockClient struct {
+	ClientKeys map[string]chef.AccessKey
+	UserKeys   map[string]chef.AccessKey
+}
+
+func (mc *KeyMockClient) GetClientKey(name, key string) (chef.AccessKey, error) {
+	if k, ok := mc.ClientKeys[name+"/"+key]; ok {
+		return k, nil
+	}
+	return chef.AccessKey{}, chefError(http.StatusNotFound)
+}
+
+func (mc *KeyMockClient) GetUserKey(name, key string) (chef.AccessKey, error) {
+	if k, ok := mc.UserKeys[name+"/"+key]; ok {
+		return k, nil
+	}
+	return chef.AccessKey{}, chefError(http.StatusNotFound)
+}
@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chef/chef"
+
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	keyOwnerClients = "clients"
+	keyOwnerUsers   = "users"
+
+	errGetPublicKey = "unable to get key %s of %s %s: %w"
+	errEmptyKey     = "key %s of %s %s has no public key"
+)
+
+// KeyFetcher is the subset of the Chef keys API used to read public keys.
+type KeyFetcher interface {
+	GetClientKey(name, key string) (chef.AccessKey, error)
+	GetUserKey(name, key string) (chef.AccessKey, error)
+}
+
+// keyService reads public keys with the chef client.
+type keyService struct {
+	client *chef.Client
+}
+
+func (s *keyService) GetClientKey(name, key string) (chef.AccessKey, error) {
+	return s.client.Clients.GetKey(name, key)
+}
+
+// GetUserKey reads the key from the server root, users are not scoped to
+// the organization of the server url.
+func (s *keyService) GetUserKey(name, key string) (chef.AccessKey, error) {
+	var accessKey chef.AccessKey
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("/users/%s/keys/%s", url.PathEscape(name), url.PathEscape(key)), nil)
+	if err != nil {
+		return accessKey, err
+	}
+	res, err := s.client.Do(req, &accessKey)
+	if res != nil {
+		defer res.Body.Close()
+	}
+	return accessKey, err
+}
+
+// parseKeyRef returns the owner type, owner name and key name of a key of
+// the form clients/<name>/keys/<key> or users/<name>/keys/<key>.
+func parseKeyRef(key string) (owner, name, keyName string, ok bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 4 || parts[2] != "keys" || parts[1] == "" || parts[3] == "" {
+		return "", "", "", false
+	}
+	if parts[0] != keyOwnerClients && parts[0] != keyOwnerUsers {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], parts[3], true
+}
+
+// getPublicKey returns the PEM encoded public key of a client or user, or
+// the given property of the key, e.g. expiration_date.
+func (providerchef *Providerchef) getPublicKey(owner, name, keyName, property string) ([]byte, error) {
+	var accessKey chef.AccessKey
+	var err error
+	if owner == keyOwnerUsers {
+		accessKey, err = providerchef.keyService.GetUserKey(name, keyName)
+		metrics.ObserveAPICall(ProviderChef, CallChefGetUserKey, err)
+	} else {
+		accessKey, err = providerchef.keyService.GetClientKey(name, keyName)
+		metrics.ObserveAPICall(ProviderChef, CallChefGetClientKey, err)
+	}
+	ownerType := strings.TrimSuffix(owner, "s")
+	if err != nil {
+		return nil, fmt.Errorf(errGetPublicKey, keyName, ownerType, name, err)
+	}
+	if property != "" {
+		jsonByte, err := json.Marshal(accessKey)
+		if err != nil {
+			return nil, fmt.Errorf(errUnableToConvertToJSON)
+		}
+		return getPropertyFromDatabagItem(jsonByte, property)
+	}
+	if accessKey.PublicKey == "" {
+		return nil, fmt.Errorf(errEmptyKey, keyName, ownerType, name)
+	}
+	return []byte(accessKey.PublicKey), nil
+}
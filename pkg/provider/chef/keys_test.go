@@ -0,0 +1,104 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chef/chef"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	fake "github.com/external-secrets/external-secrets/pkg/provider/chef/fake"
+)
+
+func TestChefGetPublicKey(t *testing.T) {
+	mock := &fake.KeyMockClient{
+		ClientKeys: map[string]chef.AccessKey{
+			"node01/default": {Name: "default", PublicKey: "client public key", ExpirationDate: "infinity"},
+		},
+		UserKeys: map[string]chef.AccessKey{
+			"deploy/signing": {Name: "signing", PublicKey: "user public key", ExpirationDate: "2030-01-01T00:00:00Z"},
+		},
+	}
+	tests := []struct {
+		name    string
+		ref     esv1beta1.ExternalSecretDataRemoteRef
+		want    []byte
+		wantErr string
+	}{
+		{
+			name: "client key",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "clients/node01/keys/default"},
+			want: []byte("client public key"),
+		},
+		{
+			name: "user key",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "users/deploy/keys/signing"},
+			want: []byte("user public key"),
+		},
+		{
+			name: "property of key",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "users/deploy/keys/signing", Property: "expiration_date"},
+			want: []byte("2030-01-01T00:00:00Z"),
+		},
+		{
+			name:    "missing key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "clients/node01/keys/other"},
+			wantErr: "unable to get key other of client node01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &Providerchef{log: logr.Discard(), keyService: mock}
+			got, err := pc.GetSecret(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseKeyRef(t *testing.T) {
+	owner, name, key, ok := parseKeyRef("clients/node01/keys/default")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"clients", "node01", "default"}, []string{owner, name, key})
+	for _, ref := range []string{"clients/node01", "databag/item", "nodes/node01/keys/default", "users/deploy/keys/", "users/deploy/other/signing"} {
+		_, _, _, ok := parseKeyRef(ref)
+		assert.False(t, ok, ref)
+	}
+}
+
+func TestKeyServiceGetUserKey(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/users/deploy/keys/signing", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"signing","public_key":"user public key","expiration_date":"infinity"}`))
+	})
+
+	s := &keyService{client: newTestClient(t, server.URL+"/organizations/myorg/")}
+	key, err := s.GetUserKey("deploy", "signing")
+	require.NoError(t, err)
+	assert.Equal(t, "user public key", key.PublicKey)
+}
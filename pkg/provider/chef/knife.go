@@ -0,0 +1,173 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	defaultKnifeProfile = "default"
+
+	errParseCredentials = "unable to parse credentials file: %w"
+	errKnifeProfile     = "credentials file has no %s profile"
+	errKnifeMissing     = "knife config does not set %s"
+	errKnifeKeyNotFound = "client_key %s not found in the knife config secret"
+)
+
+// knifeConfig holds the settings of a knife.rb or credentials file that
+// are needed to connect to the chef server.
+type knifeConfig struct {
+	clientName    string
+	serverURL     string
+	clientKey     string
+	clientKeyPath string
+}
+
+var (
+	// knifeSetting matches a setting with a quoted string value in a knife.rb,
+	// e.g. chef_server_url "https://chef.example.com/organizations/org".
+	knifeSetting = regexp.MustCompile(`(?m)^[ \t]*(\w+)[ \t]*\(?[ \t]*(?:"((?:[^"\\]|\\.)*)"|'((?:[^'\\]|\\.)*)')`)
+	// credentialsProfile matches the table header of a credentials file.
+	credentialsProfile = regexp.MustCompile(`(?m)^[ \t]*\[`)
+
+	doubleQuoted = strings.NewReplacer(`\"`, `"`, `\\`, `\`, `\n`, "\n")
+	singleQuoted = strings.NewReplacer(`\'`, `'`, `\\`, `\`)
+)
+
+// parseKnifeConfig parses a knife.rb or a credentials file. Only settings
+// with literal string values are read, ruby expressions are not evaluated.
+func parseKnifeConfig(data []byte) (*knifeConfig, error) {
+	settings := map[string]string{}
+	if credentialsProfile.Match(data) {
+		profile, err := credentialsSettings(data)
+		if err != nil {
+			return nil, err
+		}
+		settings = profile
+	} else {
+		for _, m := range knifeSetting.FindAllSubmatch(data, -1) {
+			if m[2] != nil {
+				settings[string(m[1])] = doubleQuoted.Replace(string(m[2]))
+			} else {
+				settings[string(m[1])] = singleQuoted.Replace(string(m[3]))
+			}
+		}
+	}
+
+	cfg := &knifeConfig{
+		clientName:    settings["client_name"],
+		serverURL:     settings["chef_server_url"],
+		clientKey:     settings["client_key_contents"],
+		clientKeyPath: settings["client_key"],
+	}
+	// knife.rb files of workstations usually set node_name.
+	if cfg.clientName == "" {
+		cfg.clientName = settings["node_name"]
+	}
+	// credentials files accept the key itself as client_key.
+	if cfg.clientKey == "" && strings.Contains(cfg.clientKeyPath, "-----BEGIN") {
+		cfg.clientKey, cfg.clientKeyPath = cfg.clientKeyPath, ""
+	}
+	if cfg.serverURL != "" && !strings.HasSuffix(cfg.serverURL, "/") {
+		cfg.serverURL += "/"
+	}
+	return cfg, nil
+}
+
+// credentialsSettings returns the default profile of a credentials file, or
+// the only profile if there is no default profile.
+func credentialsSettings(data []byte) (map[string]string, error) {
+	var profiles map[string]map[string]any
+	if err := toml.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf(errParseCredentials, err)
+	}
+	profile, ok := profiles[defaultKnifeProfile]
+	if !ok && len(profiles) == 1 {
+		for _, p := range profiles {
+			profile = p
+		}
+	} else if !ok {
+		return nil, fmt.Errorf(errKnifeProfile, defaultKnifeProfile)
+	}
+	settings := make(map[string]string, len(profile))
+	for name, value := range profile {
+		// other settings, e.g. the knife table, are not needed
+		if s, ok := value.(string); ok {
+			settings[name] = s
+		}
+	}
+	return settings, nil
+}
+
+// privateKey returns the inline key or the key of the secret that is named
+// like the file of client_key, e.g. user.pem for ~/.chef/user.pem.
+func (cfg *knifeConfig) privateKey(secretData map[string][]byte) (string, error) {
+	if cfg.clientKey != "" {
+		return cfg.clientKey, nil
+	}
+	if cfg.clientKeyPath == "" {
+		return "", fmt.Errorf(errKnifeMissing, "client_key")
+	}
+	key, ok := secretData[path.Base(cfg.clientKeyPath)]
+	if !ok || len(key) == 0 {
+		return "", fmt.Errorf(errKnifeKeyNotFound, path.Base(cfg.clientKeyPath))
+	}
+	return string(key), nil
+}
+
+// knifeCredentials returns the user, server url and private key of the
+// knife config. The username and server url of the store take precedence.
+// A server url of the knife config is checked against the allowed provider
+// endpoints, it is not part of the store and not checked at admission.
+func knifeCredentials(chefProvider *v1beta1.ChefProvider, config []byte, secretData map[string][]byte) (name, serverURL, key string, err error) {
+	cfg, err := parseKnifeConfig(config)
+	if err != nil {
+		return "", "", "", err
+	}
+	name = chefProvider.UserName
+	if name == "" {
+		name = cfg.clientName
+	}
+	if name == "" {
+		return "", "", "", fmt.Errorf(errKnifeMissing, "client_name")
+	}
+	serverURL = chefProvider.ServerURL
+	if serverURL == "" {
+		serverURL = cfg.serverURL
+		if serverURL == "" {
+			return "", "", "", fmt.Errorf(errKnifeMissing, "chef_server_url")
+		}
+		if _, err := url.ParseRequestURI(serverURL); err != nil {
+			return "", "", "", fmt.Errorf(errInvalidURL, err)
+		}
+		if err := v1beta1.ValidateEndpoint(serverURL); err != nil {
+			return "", "", "", err
+		}
+	}
+	key, err = cfg.privateKey(secretData)
+	if err != nil {
+		return "", "", "", err
+	}
+	return name, serverURL, key, nil
+}
@@ -0,0 +1,136 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/go-chef/chef"
+
+	"github.com/external-secrets/external-secrets/pkg/utils/guarded"
+)
+
+const (
+	organizationsPathSegment = "organizations"
+
+	errOrganizationPattern    = "organizations: invalid pattern %q: %w"
+	errOrganizationName       = "invalid organization name %q"
+	errOrganizationNotAllowed = "organization %s is not allowed by the store"
+)
+
+// organizationName matches the names chef server accepts for organizations.
+var organizationName = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// validateOrganizations checks the organization patterns of a store.
+func validateOrganizations(patterns []string) error {
+	var errs error
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = errors.Join(errs, fmt.Errorf(errOrganizationPattern, pattern, err))
+		}
+	}
+	return errs
+}
+
+// splitOrganization splits a key of the form organizations/orgName/key.
+// Keys are only split if the store allows other organizations, so that
+// data bags named organizations keep working.
+func (providerchef *Providerchef) splitOrganization(key string) (org, rest string, ok bool) {
+	if len(providerchef.organizations) == 0 {
+		return "", "", false
+	}
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 || parts[0] != organizationsPathSegment || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// organizationURL returns the server url with the organization replaced, or
+// added if the server url does not contain one.
+func organizationURL(serverURL, org string) (string, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf(errInvalidURL, err)
+	}
+	var parts []string
+	for _, part := range strings.Split(u.Path, "/") {
+		if part == organizationsPathSegment {
+			break
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	u.Path = "/" + path.Join(append(parts, organizationsPathSegment, org)...) + "/"
+	return u.String(), nil
+}
+
+// forOrganization returns a provider for the given organization that uses
+// the credentials of the store. It must be closed after use.
+func (providerchef *Providerchef) forOrganization(org string) (*Providerchef, error) {
+	if !organizationName.MatchString(org) {
+		return nil, fmt.Errorf(errOrganizationName, org)
+	}
+	allowed := false
+	for _, pattern := range providerchef.organizations {
+		if ok, _ := path.Match(pattern, org); ok {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf(errOrganizationNotAllowed, org)
+	}
+	if providerchef.config == nil || providerchef.key == nil {
+		return nil, fmt.Errorf(errUninitalizedChefProvider)
+	}
+
+	config := *providerchef.config
+	baseURL, err := organizationURL(config.BaseURL, org)
+	if err != nil {
+		return nil, err
+	}
+	config.BaseURL = baseURL
+	// the client is created with the unused signing key, then signs with
+	// the key of the store parsed from a copy that is wiped right away.
+	if config.Key, err = getUnusedSigningKey(); err != nil {
+		return nil, err
+	}
+	client, err := chef.NewClient(&config)
+	if err != nil {
+		return nil, fmt.Errorf(errChefClient, err)
+	}
+	config.Key = ""
+	key := providerchef.key.Copy()
+	client.Auth.PrivateKey, err = chef.PrivateKeyFromString(key)
+	guarded.Wipe(key)
+	if err != nil {
+		return nil, fmt.Errorf(errChefClient, err)
+	}
+	orgProvider := &Providerchef{
+		config:      &config,
+		privateKey:  client.Auth.PrivateKey,
+		itemSchemas: providerchef.itemSchemas,
+		log:         providerchef.log.WithValues("organization", org),
+	}
+	orgProvider.setClient(client)
+	return orgProvider, nil
+}
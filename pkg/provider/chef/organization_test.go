@@ -0,0 +1,105 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestOrganizationURL(t *testing.T) {
+	tests := []struct {
+		serverURL string
+		want      string
+	}{
+		{serverURL: "https://chef.example.com/organizations/shared/", want: "https://chef.example.com/organizations/tenant/"},
+		{serverURL: "https://chef.example.com/chef/organizations/shared", want: "https://chef.example.com/chef/organizations/tenant/"},
+		{serverURL: "https://chef.example.com/", want: "https://chef.example.com/organizations/tenant/"},
+		{serverURL: "https://chef.example.com", want: "https://chef.example.com/organizations/tenant/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.serverURL, func(t *testing.T) {
+			got, err := organizationURL(tt.serverURL, "tenant")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSplitOrganization(t *testing.T) {
+	pc := &Providerchef{organizations: []string{"team_*"}}
+	org, key, ok := pc.splitOrganization("organizations/team_a/databag/item")
+	assert.True(t, ok)
+	assert.Equal(t, "team_a", org)
+	assert.Equal(t, "databag/item", key)
+
+	_, _, ok = pc.splitOrganization("organizations/item")
+	assert.False(t, ok)
+	_, _, ok = pc.splitOrganization("databag/item")
+	assert.False(t, ok)
+	_, _, ok = (&Providerchef{}).splitOrganization("organizations/team_a/databag/item")
+	assert.False(t, ok)
+}
+
+func TestForOrganization(t *testing.T) {
+	pc := &Providerchef{organizations: []string{"team_*"}}
+	_, err := pc.forOrganization("other")
+	assert.EqualError(t, err, "organization other is not allowed by the store")
+	_, err = pc.forOrganization("..")
+	assert.EqualError(t, err, `invalid organization name ".."`)
+	assert.ErrorContains(t, validateOrganizations([]string{"team_["}), `organizations: invalid pattern "team_["`)
+}
+
+func TestGetSecretOrganization(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"id":"item","password":"tenant-password"}`))
+	}))
+	defer server.Close()
+
+	key, err := getUnusedSigningKey()
+	require.NoError(t, err)
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: authName, Namespace: "default"},
+		Data:       map[string][]byte{authKey: []byte(key)},
+	}).Build()
+	store := makeSecretStore(name, server.URL+"/organizations/shared/", makeAuth(authName, authNamespace, authKey))
+	store.Spec.Provider.Chef.Organizations = []string{"team_*"}
+
+	pc := &Providerchef{}
+	client, err := pc.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+
+	got, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "organizations/team_a/databag/item", Property: "password"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("tenant-password"), got)
+	_, err = client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "databag/item", Property: "password"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/organizations/team_a/data/databag/item", "/organizations/shared/data/databag/item"}, paths)
+
+	_, err = client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "organizations/other/databag/item"})
+	assert.EqualError(t, err, "organization other is not allowed by the store")
+}
@@ -0,0 +1,125 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errSchemaViolation   = "data bag item %s/%s violates the item schema: %s"
+	errSchemaRequired    = "required property %s is missing"
+	errSchemaType        = "property %s must be of type %s, got %s"
+	errSchemaNoDatabags  = "itemSchemas[%d]: at least one data bag is required"
+	errSchemaPattern     = "itemSchemas[%d]: invalid data bag pattern %q: %w"
+	errSchemaInvalidType = "itemSchemas[%d]: invalid type %q of property %s"
+)
+
+// validateItemSchemas checks the schemas of a store.
+func validateItemSchemas(schemas []v1beta1.ChefItemSchema) error {
+	var errs error
+	for i, schema := range schemas {
+		if len(schema.Databags) == 0 {
+			errs = errors.Join(errs, fmt.Errorf(errSchemaNoDatabags, i))
+		}
+		for _, pattern := range schema.Databags {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errs = errors.Join(errs, fmt.Errorf(errSchemaPattern, i, pattern, err))
+			}
+		}
+		for property, t := range schema.Properties {
+			switch t {
+			case v1beta1.ChefItemPropertyString, v1beta1.ChefItemPropertyNumber, v1beta1.ChefItemPropertyBoolean,
+				v1beta1.ChefItemPropertyObject, v1beta1.ChefItemPropertyArray:
+			default:
+				errs = errors.Join(errs, fmt.Errorf(errSchemaInvalidType, i, t, property))
+			}
+		}
+	}
+	return errs
+}
+
+// checkItemSchemas checks the item against the schemas that apply to its data bag.
+// A violation is returned as ReasonError, so that the ExternalSecret shows the
+// ChefItemSchemaViolation reason. The values of the item are never part of the error.
+func checkItemSchemas(schemas []v1beta1.ChefItemSchema, databagName, itemName string, item []byte) error {
+	var violations []string
+	for _, schema := range schemas {
+		if !matchesDatabag(schema.Databags, databagName) {
+			continue
+		}
+		for _, property := range schema.Required {
+			if !gjson.GetBytes(item, property).Exists() {
+				violations = append(violations, fmt.Sprintf(errSchemaRequired, property))
+			}
+		}
+		properties := make([]string, 0, len(schema.Properties))
+		for property := range schema.Properties {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+		for _, property := range properties {
+			result := gjson.GetBytes(item, property)
+			if !result.Exists() {
+				continue
+			}
+			want := schema.Properties[property]
+			if got := propertyType(result); got != want {
+				violations = append(violations, fmt.Sprintf(errSchemaType, property, want, got))
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &v1beta1.ReasonError{
+		Reason: v1beta1.ConditionReasonChefItemSchemaViolation,
+		Err:    fmt.Errorf(errSchemaViolation, databagName, itemName, strings.Join(violations, ", ")),
+	}
+}
+
+func matchesDatabag(patterns []string, databagName string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, databagName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func propertyType(result gjson.Result) v1beta1.ChefItemPropertyType {
+	switch {
+	case result.IsObject():
+		return v1beta1.ChefItemPropertyObject
+	case result.IsArray():
+		return v1beta1.ChefItemPropertyArray
+	case result.IsBool():
+		return v1beta1.ChefItemPropertyBoolean
+	case result.Type == gjson.Number:
+		return v1beta1.ChefItemPropertyNumber
+	case result.Type == gjson.String:
+		return v1beta1.ChefItemPropertyString
+	default:
+		return "null"
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	fake "github.com/external-secrets/external-secrets/pkg/provider/chef/fake"
+)
+
+func TestCheckItemSchemas(t *testing.T) {
+	item := []byte(`{"id":"app","db":{"host":"db.local","port":5432,"tls":true},"hosts":["a","b"],"password":"secret"}`)
+	tests := []struct {
+		name    string
+		schemas []esv1beta1.ChefItemSchema
+		wantErr string
+	}{
+		{
+			name: "valid",
+			schemas: []esv1beta1.ChefItemSchema{{
+				Databags: []string{"app_*"},
+				Required: []string{"password", "db.host"},
+				Properties: map[string]esv1beta1.ChefItemPropertyType{
+					"db":       esv1beta1.ChefItemPropertyObject,
+					"db.port":  esv1beta1.ChefItemPropertyNumber,
+					"db.tls":   esv1beta1.ChefItemPropertyBoolean,
+					"hosts":    esv1beta1.ChefItemPropertyArray,
+					"password": esv1beta1.ChefItemPropertyString,
+					"missing":  esv1beta1.ChefItemPropertyString,
+				},
+			}},
+		},
+		{
+			name: "other data bag",
+			schemas: []esv1beta1.ChefItemSchema{{
+				Databags: []string{"infra"},
+				Required: []string{"username"},
+			}},
+		},
+		{
+			name: "violations",
+			schemas: []esv1beta1.ChefItemSchema{{
+				Databags: []string{"app_prod"},
+				Required: []string{"username"},
+				Properties: map[string]esv1beta1.ChefItemPropertyType{
+					"db.port":  esv1beta1.ChefItemPropertyString,
+					"password": esv1beta1.ChefItemPropertyObject,
+				},
+			}},
+			wantErr: "data bag item app_prod/app violates the item schema: required property username is missing, property db.port must be of type string, got number, property password must be of type object, got string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkItemSchemas(tt.schemas, "app_prod", "app", item)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+			var reasonErr *esv1beta1.ReasonError
+			require.True(t, errors.As(err, &reasonErr))
+			assert.Equal(t, esv1beta1.ConditionReasonChefItemSchemaViolation, reasonErr.Reason)
+			assert.NotContains(t, err.Error(), "secret")
+		})
+	}
+}
+
+func TestValidateItemSchemas(t *testing.T) {
+	assert.NoError(t, validateItemSchemas([]esv1beta1.ChefItemSchema{{Databags: []string{"app_*"}}}))
+	err := validateItemSchemas([]esv1beta1.ChefItemSchema{
+		{},
+		{Databags: []string{"app_["}},
+		{Databags: []string{"app"}, Properties: map[string]esv1beta1.ChefItemPropertyType{"port": "integer"}},
+	})
+	assert.ErrorContains(t, err, "itemSchemas[0]: at least one data bag is required")
+	assert.ErrorContains(t, err, `itemSchemas[1]: invalid data bag pattern "app_["`)
+	assert.ErrorContains(t, err, `itemSchemas[2]: invalid type "integer" of property port`)
+}
+
+func TestGetSecretItemSchema(t *testing.T) {
+	mock := &fake.ChefMockClient{}
+	mock.WithItem("", "", nil)
+	mock.WithListItems("", nil)
+	pc := &Providerchef{
+		log:            logr.Discard(),
+		databagService: mock,
+		itemSchemas: []esv1beta1.ChefItemSchema{{
+			Databags: []string{"databag03"},
+			Required: []string{"someProperty"},
+		}},
+	}
+	_, err := pc.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "databag03/item03", Property: "findProperty"})
+	assert.EqualError(t, err, "data bag item databag03/item03 violates the item schema: required property someProperty is missing")
+
+	pc.itemSchemas[0].Required = []string{"findProperty"}
+	got, err := pc.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "databag03/item03", Property: "findProperty"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("foundProperty"), got)
+}
+
+func TestGetSecretItemSchemaPerStore(t *testing.T) {
+	key, err := getUnusedSigningKey()
+	require.NoError(t, err)
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: authName, Namespace: "default"},
+		Data:       map[string][]byte{authKey: []byte(key)},
+	}).Build()
+	strict := makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, authKey))
+	strict.Spec.Provider.Chef.ItemSchemas = []esv1beta1.ChefItemSchema{{
+		Databags: []string{"databag03"},
+		Required: []string{"someProperty"},
+	}}
+	lax := makeSecretStore(name, baseURL, makeAuth(authName, authNamespace, authKey))
+
+	// the client of the lax store is built last, it must not drop the
+	// schemas of the strict store.
+	pc := &Providerchef{}
+	strictClient, err := pc.NewClient(context.Background(), strict, kube, "default")
+	require.NoError(t, err)
+	laxClient, err := pc.NewClient(context.Background(), lax, kube, "default")
+	require.NoError(t, err)
+
+	ref := esv1beta1.ExternalSecretDataRemoteRef{Key: "databag03/item03", Property: "findProperty"}
+	for _, client := range []esv1beta1.SecretsClient{strictClient, laxClient} {
+		mock := &fake.ChefMockClient{}
+		mock.WithItem("", "", nil)
+		client.(*Providerchef).databagService = mock
+	}
+	_, err = strictClient.GetSecret(context.Background(), ref)
+	assert.EqualError(t, err, "data bag item databag03/item03 violates the item schema: required property someProperty is missing")
+	got, err := laxClient.GetSecret(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("foundProperty"), got)
+}
@@ -0,0 +1,180 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	errCircuitOpen = "chef server is rate limiting requests of the store, retry after %s"
+
+	// defaultRetryAfter is the time the circuit stays open if the chef server
+	// throttles a request without a Retry-After header.
+	defaultRetryAfter = 10 * time.Second
+	maxRetryAfter     = 5 * time.Minute
+)
+
+// rateLimitRemainingHeaders are the headers rate limiting proxies in front
+// of the chef server report the requests left in the current window with.
+var rateLimitRemainingHeaders = []string{"RateLimit-Remaining", "X-RateLimit-Remaining"}
+
+// throttles holds the throttle state of every store, it outlives the
+// clients as a client is created for every reconcile.
+var throttles sync.Map
+
+// throttle is the circuit breaker of a store. It opens when the chef server
+// rejects a request because of rate limits and fails the requests of the
+// store until the server asks to retry, so throttled stores do not add load
+// to the server.
+type throttle struct {
+	kind, namespace, name string
+
+	mu        sync.Mutex
+	state     metrics.CircuitState
+	openUntil time.Time
+	now       func() time.Time
+}
+
+// storeThrottle returns the throttle of store.
+func storeThrottle(store v1beta1.GenericStore) *throttle {
+	kind := store.GetKind()
+	key := kind + "/" + store.GetNamespace() + "/" + store.GetName()
+	t, _ := throttles.LoadOrStore(key, &throttle{
+		kind:      kind,
+		namespace: store.GetNamespace(),
+		name:      store.GetName(),
+		now:       time.Now,
+	})
+	return t.(*throttle)
+}
+
+// client returns an http client that sends requests through the throttle,
+// base is the client of the store or nil for the default client.
+func (t *throttle) client(base *http.Client) *http.Client {
+	if base == nil {
+		base = &http.Client{Transport: http.DefaultTransport.(*http.Transport).Clone()}
+	}
+	client := *base
+	client.Transport = &throttleTransport{throttle: t, base: base.Transport}
+	return &client
+}
+
+// allow returns an error while the circuit is open.
+func (t *throttle) allow() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state != metrics.CircuitOpen {
+		return nil
+	}
+	if t.now().Before(t.openUntil) {
+		return fmt.Errorf(errCircuitOpen, t.openUntil.UTC().Format(time.RFC3339))
+	}
+	t.setState(metrics.CircuitHalfOpen)
+	return nil
+}
+
+// observe updates the state of the throttle with the response of the chef
+// server.
+func (t *throttle) observe(resp *http.Response) {
+	for _, header := range rateLimitRemainingHeaders {
+		if remaining, err := strconv.ParseFloat(resp.Header.Get(header), 64); err == nil {
+			metrics.SetRateLimitRemaining(ProviderChef, t.kind, t.namespace, t.name, remaining)
+			break
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	retryAfter, throttled := throttledFor(resp, now)
+	if !throttled {
+		t.setState(metrics.CircuitClosed)
+		return
+	}
+	metrics.ObserveThrottle(ProviderChef, t.kind, t.namespace, t.name)
+	if until := now.Add(retryAfter); until.After(t.openUntil) {
+		t.openUntil = until
+	}
+	t.setState(metrics.CircuitOpen)
+}
+
+func (t *throttle) setState(state metrics.CircuitState) {
+	t.state = state
+	metrics.SetCircuitState(ProviderChef, t.kind, t.namespace, t.name, state)
+}
+
+// throttledFor returns whether resp rejects the request because of rate
+// limits and the time to wait before retrying. Unavailable responses only
+// count as throttled if they ask to retry later.
+func throttledFor(resp *http.Response, now time.Time) (time.Duration, bool) {
+	retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now)
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+	case resp.StatusCode == http.StatusServiceUnavailable && ok:
+	default:
+		return 0, false
+	}
+	if !ok {
+		retryAfter = defaultRetryAfter
+	}
+	return min(retryAfter, maxRetryAfter), true
+}
+
+// parseRetryAfter parses a Retry-After header, it is either a number of
+// seconds or an http date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
+
+// throttleTransport fails requests while the throttle of the store is open
+// and reports the rate-limit state of the responses.
+type throttleTransport struct {
+	throttle *throttle
+	base     http.RoundTripper
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.throttle.allow(); err != nil {
+		return nil, err
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.throttle.observe(resp)
+	return resp, nil
+}
@@ -0,0 +1,136 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chef
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+func TestThrottle(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	status, retryAfter, requests := http.StatusOK, "", 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	store := &v1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: "chef", Namespace: "throttle-test"}}
+	th := storeThrottle(store)
+	assert.Same(t, th, storeThrottle(store.DeepCopy()))
+	th.now = func() time.Time { return now }
+	client := th.client(nil)
+
+	get := func() error {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	require.NoError(t, get())
+	assert.Equal(t, metrics.CircuitClosed, th.state)
+
+	// the circuit opens for the time the server asks to wait.
+	status, retryAfter = http.StatusTooManyRequests, "30"
+	require.NoError(t, get())
+	assert.Equal(t, metrics.CircuitOpen, th.state)
+	assert.Equal(t, now.Add(30*time.Second), th.openUntil)
+
+	status, retryAfter = http.StatusOK, ""
+	assert.ErrorContains(t, get(), "chef server is rate limiting requests of the store, retry after 2024-01-01T00:00:30Z")
+	assert.Equal(t, 2, requests)
+
+	// the first request after the wait decides whether the circuit closes.
+	now = now.Add(30 * time.Second)
+	require.NoError(t, th.allow())
+	assert.Equal(t, metrics.CircuitHalfOpen, th.state)
+	require.NoError(t, get())
+	assert.Equal(t, metrics.CircuitClosed, th.state)
+	assert.Equal(t, 3, requests)
+}
+
+func TestThrottledFor(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		status        int
+		retryAfter    string
+		wantThrottled bool
+		want          time.Duration
+	}{
+		{
+			name:   "ok is not throttled",
+			status: http.StatusOK,
+		},
+		{
+			name:          "too many requests without retry-after waits the default time",
+			status:        http.StatusTooManyRequests,
+			wantThrottled: true,
+			want:          defaultRetryAfter,
+		},
+		{
+			name:          "retry-after in seconds",
+			status:        http.StatusTooManyRequests,
+			retryAfter:    "120",
+			wantThrottled: true,
+			want:          2 * time.Minute,
+		},
+		{
+			name:          "retry-after is limited",
+			status:        http.StatusTooManyRequests,
+			retryAfter:    "86400",
+			wantThrottled: true,
+			want:          maxRetryAfter,
+		},
+		{
+			name:          "unavailable with retry-after date",
+			status:        http.StatusServiceUnavailable,
+			retryAfter:    "Mon, 01 Jan 2024 00:01:00 GMT",
+			wantThrottled: true,
+			want:          time.Minute,
+		},
+		{
+			name:   "unavailable without retry-after is not throttled",
+			status: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+			got, throttled := throttledFor(resp, now)
+			assert.Equal(t, tt.wantThrottled, throttled)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
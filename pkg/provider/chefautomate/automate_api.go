@@ -0,0 +1,146 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chefautomate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// maxProfiles is the number of profile versions requested by a search.
+const maxProfiles = 100
+
+// node is a node as returned by the config management API.
+type node struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// nodeAttribute holds the attributes of a node. Every precedence level is
+// a JSON encoded object.
+type nodeAttribute struct {
+	NodeID    string `json:"node_id"`
+	Name      string `json:"name"`
+	Default   string `json:"default"`
+	Normal    string `json:"normal"`
+	Override  string `json:"override"`
+	Automatic string `json:"automatic"`
+}
+
+type profileSearchRequest struct {
+	Owner   string          `json:"owner"`
+	Filters []profileFilter `json:"filters"`
+	PerPage int             `json:"per_page"`
+}
+
+type profileFilter struct {
+	Type   string   `json:"type"`
+	Values []string `json:"values"`
+}
+
+type profileSearchResponse struct {
+	Profiles []json.RawMessage `json:"profiles"`
+}
+
+// automateAPI is the subset of the Chef Automate API used by the provider.
+// See https://docs.chef.io/automate/api/ for the full API documentation.
+type automateAPI interface {
+	// ListNodes returns the nodes whose name matches name.
+	ListNodes(ctx context.Context, name string) ([]node, error)
+	// GetNodeAttribute returns the attributes of the node with the given ID.
+	GetNodeAttribute(ctx context.Context, nodeID string) (*nodeAttribute, error)
+	// SearchProfiles returns the metadata of all versions of the profile.
+	SearchProfiles(ctx context.Context, owner, name string) ([]json.RawMessage, error)
+	// Introspect returns an error if the token is not accepted.
+	Introspect(ctx context.Context) error
+}
+
+type httpAutomateAPI struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+var _ automateAPI = &httpAutomateAPI{}
+
+func (a *httpAutomateAPI) ListNodes(ctx context.Context, name string) ([]node, error) {
+	var nodes []node
+	err := a.do(ctx, http.MethodGet, "/api/v0/cfgmgmt/nodes?filter="+url.QueryEscape("name:"+name), nil, &nodes)
+	metrics.ObserveAPICall(constants.ProviderChefAutomate, constants.CallChefAutomateListNodes, err)
+	return nodes, err
+}
+
+func (a *httpAutomateAPI) GetNodeAttribute(ctx context.Context, nodeID string) (*nodeAttribute, error) {
+	var attr nodeAttribute
+	err := a.do(ctx, http.MethodGet, "/api/v0/cfgmgmt/nodes/"+url.PathEscape(nodeID)+"/attribute", nil, &attr)
+	metrics.ObserveAPICall(constants.ProviderChefAutomate, constants.CallChefAutomateGetNodeAttribute, err)
+	if err != nil {
+		return nil, err
+	}
+	return &attr, nil
+}
+
+func (a *httpAutomateAPI) SearchProfiles(ctx context.Context, owner, name string) ([]json.RawMessage, error) {
+	var resp profileSearchResponse
+	err := a.do(ctx, http.MethodPost, "/api/v0/compliance/profiles/search", profileSearchRequest{
+		Owner:   owner,
+		Filters: []profileFilter{{Type: "name", Values: []string{name}}},
+		PerPage: maxProfiles,
+	}, &resp)
+	metrics.ObserveAPICall(constants.ProviderChefAutomate, constants.CallChefAutomateSearchProfiles, err)
+	return resp.Profiles, err
+}
+
+func (a *httpAutomateAPI) Introspect(ctx context.Context) error {
+	var resp map[string]any
+	err := a.do(ctx, http.MethodGet, "/apis/iam/v2/introspect", nil, &resp)
+	metrics.ObserveAPICall(constants.ProviderChefAutomate, constants.CallChefAutomateIntrospect, err)
+	return err
+}
+
+func (a *httpAutomateAPI) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader = http.NoBody
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, a.url+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("api-token", a.token)
+	data, err := utils.DoHTTP(a.client, req, http.StatusNotFound)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf(errDecodeResponse, err)
+	}
+	return nil
+}
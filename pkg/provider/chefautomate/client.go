@@ -0,0 +1,227 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chefautomate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	kindNodes    = "nodes"
+	kindProfiles = "profiles"
+)
+
+type client struct {
+	api automateAPI
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the JSON document referenced by key:
+//   - nodes/<name> returns the attributes of the node, merged by precedence
+//     like Chef does: automatic over override over normal over default.
+//   - profiles/<owner>/<name> returns the metadata of a compliance profile.
+//     The version selects a version of the profile, the highest version is
+//     returned if it is not set.
+//
+// With property only the value of the field is returned, nested fields are
+// selected with a gjson expression.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	kind, name, _ := strings.Cut(ref.Key, "/")
+	var (
+		doc []byte
+		err error
+	)
+	switch kind {
+	case kindNodes:
+		doc, err = c.getNodeAttributes(ctx, name, ref.Version)
+	case kindProfiles:
+		doc, err = c.getProfile(ctx, name, ref.Version)
+	default:
+		return nil, fmt.Errorf(errInvalidKey, ref.Key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return doc, nil
+	}
+	val := gjson.GetBytes(doc, ref.Property)
+	if !val.Exists() {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap returns the fields of the document, or of the object
+// selected by property, as map.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	doc, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return nil, errNotAnObject
+	}
+	secretMap := make(map[string][]byte, len(fields))
+	for k := range fields {
+		secretMap[k], err = utils.GetByteValueFromMap(fields, k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secretMap, nil
+}
+
+func (c *client) GetAllSecrets(_ context.Context, _ esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, errors.New("getting all secrets is not supported by Chef Automate")
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by Chef Automate")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by Chef Automate")
+}
+
+// Validate checks that the token is accepted by Chef Automate.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if err := c.api.Introspect(ctx); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(context.Context) error {
+	return nil
+}
+
+func (c *client) getNodeAttributes(ctx context.Context, name, version string) ([]byte, error) {
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf(errInvalidKey, kindNodes+"/"+name)
+	}
+	if version != "" {
+		return nil, errNodeVersion
+	}
+	nodes, err := c.api.ListNodes(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	// the name filter matches by prefix, so only exact matches are kept
+	var matches []node
+	for _, n := range nodes {
+		if n.Name == name {
+			matches = append(matches, n)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, esv1beta1.NoSecretError{}
+	case 1:
+	default:
+		return nil, fmt.Errorf(errAmbiguousNode, len(matches), name)
+	}
+	attr, err := c.api.GetNodeAttribute(ctx, matches[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	var merged any = map[string]any{}
+	for _, level := range []string{attr.Default, attr.Normal, attr.Override, attr.Automatic} {
+		if level == "" {
+			continue
+		}
+		var attrs map[string]any
+		if err := json.Unmarshal([]byte(level), &attrs); err != nil {
+			return nil, fmt.Errorf(errDecodeAttributes, name, err)
+		}
+		merged = mergeValues(merged, attrs)
+	}
+	return json.Marshal(merged)
+}
+
+// mergeValues deep merges src into dst, values of src take precedence.
+func mergeValues(dst, src any) any {
+	dstObject, ok := dst.(map[string]any)
+	if !ok {
+		return src
+	}
+	srcObject, ok := src.(map[string]any)
+	if !ok {
+		return src
+	}
+	merged := make(map[string]any, len(dstObject)+len(srcObject))
+	for k, v := range dstObject {
+		merged[k] = v
+	}
+	for k, v := range srcObject {
+		merged[k] = mergeValues(dstObject[k], v)
+	}
+	return merged
+}
+
+func (c *client) getProfile(ctx context.Context, ownerName, version string) ([]byte, error) {
+	owner, name, ok := strings.Cut(ownerName, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf(errInvalidKey, kindProfiles+"/"+ownerName)
+	}
+	profiles, err := c.api.SearchProfiles(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		selected []byte
+		highest  *semver.Version
+	)
+	for _, p := range profiles {
+		if gjson.GetBytes(p, "name").String() != name {
+			continue
+		}
+		v := gjson.GetBytes(p, "version").String()
+		if version != "" {
+			if v == version {
+				return p, nil
+			}
+			continue
+		}
+		// profiles without a semantic version are only used if there is no other
+		parsed, err := semver.NewVersion(v)
+		if err != nil {
+			if selected == nil {
+				selected = p
+			}
+			continue
+		}
+		if highest == nil || parsed.GreaterThan(highest) {
+			selected, highest = p, parsed
+		}
+	}
+	if selected == nil {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return selected, nil
+}
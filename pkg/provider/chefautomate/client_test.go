@@ -0,0 +1,190 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chefautomate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const testToken = "token"
+
+func newTestClient(t *testing.T) *client {
+	t.Helper()
+	nodes := map[string][]node{
+		"name:web-1": {{ID: "n1", Name: "web-1"}, {ID: "n10", Name: "web-10"}},
+		"name:db":    {{ID: "n2", Name: "db"}, {ID: "n3", Name: "db"}},
+	}
+	attributes := map[string]nodeAttribute{
+		"n1": {
+			NodeID:    "n1",
+			Name:      "web-1",
+			Default:   `{"app":{"port":80,"db":{"user":"app","password":"default"}}}`,
+			Normal:    `{"app":{"db":{"password":"s3cr3t"}}}`,
+			Override:  `{"app":{"port":8080}}`,
+			Automatic: `{"fqdn":"web-1.example.com"}`,
+		},
+	}
+	profiles := []json.RawMessage{
+		json.RawMessage(`{"name":"linux-baseline","version":"2.2.0","maintainer":"DevSec"}`),
+		json.RawMessage(`{"name":"linux-baseline","version":"2.10.0","maintainer":"DevSec Hardening"}`),
+		json.RawMessage(`{"name":"linux-baseline-extra","version":"9.0.0","maintainer":"Other"}`),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v0/cfgmgmt/nodes", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(append([]node{}, nodes[r.URL.Query().Get("filter")]...))
+	})
+	mux.HandleFunc("/api/v0/cfgmgmt/nodes/n1/attribute", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(attributes["n1"])
+	})
+	mux.HandleFunc("/api/v0/compliance/profiles/search", func(w http.ResponseWriter, r *http.Request) {
+		var req profileSearchRequest
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil || req.Owner != "admin" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(profileSearchResponse{Profiles: profiles})
+	})
+	mux.HandleFunc("/apis/iam/v2/introspect", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"endpoints":{}}`))
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("api-token") != testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return &client{
+		api: &httpAutomateAPI{
+			url:    server.URL,
+			token:  testToken,
+			client: server.Client(),
+		},
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	c := newTestClient(t)
+	tests := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		want     string
+		wantErr  error
+		contains string
+	}{
+		{
+			name: "merged node attributes",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "nodes/web-1", Property: "app"},
+			want: `{"db":{"password":"s3cr3t","user":"app"},"port":8080}`,
+		},
+		{
+			name: "automatic node attribute",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "nodes/web-1", Property: "fqdn"},
+			want: "web-1.example.com",
+		},
+		{
+			name:    "missing node attribute",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "nodes/web-1", Property: "app.token"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing node",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "nodes/web"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:     "ambiguous node",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "nodes/db"},
+			contains: "found 2 nodes named db",
+		},
+		{
+			name:    "node version",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "nodes/web-1", Version: "1"},
+			wantErr: errNodeVersion,
+		},
+		{
+			name: "highest profile version",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "profiles/admin/linux-baseline", Property: "maintainer"},
+			want: "DevSec Hardening",
+		},
+		{
+			name: "profile version",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "profiles/admin/linux-baseline", Version: "2.2.0"},
+			want: `{"name":"linux-baseline","version":"2.2.0","maintainer":"DevSec"}`,
+		},
+		{
+			name:    "missing profile version",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "profiles/admin/linux-baseline", Version: "1.0.0"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:     "profile without owner",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "profiles/linux-baseline"},
+			contains: "invalid key",
+		},
+		{
+			name:     "unknown kind",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "databags/secrets"},
+			contains: "invalid key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c := newTestClient(t)
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "nodes/web-1", Property: "app.db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"user":     []byte("app"),
+		"password": []byte("s3cr3t"),
+	}, got)
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "nodes/web-1", Property: "fqdn"})
+	assert.ErrorIs(t, err, errNotAnObject)
+}
+
+func TestValidate(t *testing.T) {
+	c := newTestClient(t)
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	c.api.(*httpAutomateAPI).token = "other"
+	result, err = c.Validate()
+	assert.ErrorContains(t, err, "unexpected status code 401")
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+}
@@ -0,0 +1,108 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudant
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/external-secrets/external-secrets/pkg/utils/guarded"
+)
+
+// maxCachedDocuments bounds the number of documents kept between syncs.
+const maxCachedDocuments = 1000
+
+// documents keeps the documents synced with dataFrom.find, so that only
+// documents whose revision changed are fetched again. Clients are created
+// for every sync, so the cache is shared by all clients.
+var documents = newDocumentCache(maxCachedDocuments)
+
+// documentCache caches one revision per document. A revision of a document
+// is immutable, the revision id contains a digest of the content. Values are
+// kept in guarded memory and wiped on eviction.
+type documentCache struct {
+	mutex      sync.Mutex
+	entries    map[string]*documentCacheEntry
+	lru        list.List
+	maxEntries int
+}
+
+type documentCacheEntry struct {
+	rev   string
+	value *guarded.Bytes
+	elem  *list.Element
+}
+
+func newDocumentCache(maxEntries int) *documentCache {
+	return &documentCache{
+		entries:    map[string]*documentCacheEntry{},
+		maxEntries: maxEntries,
+	}
+}
+
+// Get returns the cached document if it has the given revision.
+func (c *documentCache) Get(key, rev string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || entry.rev != rev {
+		return nil, false
+	}
+	c.lru.MoveToFront(entry.elem)
+	return entry.value.Copy(), true
+}
+
+// Put caches the revision of the document and wipes the previous one.
+func (c *documentCache) Put(key, rev string, value []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if entry, ok := c.entries[key]; ok {
+		entry.value.Destroy()
+		entry.rev = rev
+		entry.value = guarded.New(value)
+		c.lru.MoveToFront(entry.elem)
+		return
+	}
+	if len(c.entries) >= c.maxEntries {
+		c.evictLeastRecentlyUsed()
+	}
+	c.entries[key] = &documentCacheEntry{
+		rev:   rev,
+		value: guarded.New(value),
+		elem:  c.lru.PushFront(key),
+	}
+}
+
+// Delete wipes and removes the document.
+func (c *documentCache) Delete(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if entry, ok := c.entries[key]; ok {
+		c.remove(key, entry)
+	}
+}
+
+func (c *documentCache) evictLeastRecentlyUsed() {
+	elem := c.lru.Back()
+	if elem == nil {
+		return
+	}
+	key := elem.Value.(string)
+	c.remove(key, c.entries[key])
+}
+
+func (c *documentCache) remove(key string, entry *documentCacheEntry) {
+	entry.value.Destroy()
+	c.lru.Remove(entry.elem)
+	delete(c.entries, key)
+}
@@ -0,0 +1,51 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentCache(t *testing.T) {
+	c := newDocumentCache(2)
+	c.Put("db/a", "1-a", []byte("a1"))
+	c.Put("db/b", "1-b", []byte("b1"))
+
+	got, ok := c.Get("db/a", "1-a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a1"), got)
+	_, ok = c.Get("db/a", "2-a")
+	assert.False(t, ok)
+
+	// a new revision replaces the previous one
+	c.Put("db/a", "2-a", []byte("a2"))
+	got, ok = c.Get("db/a", "2-a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a2"), got)
+	_, ok = c.Get("db/a", "1-a")
+	assert.False(t, ok)
+
+	// db/b is the least recently used document
+	c.Put("db/c", "1-c", []byte("c1"))
+	_, ok = c.Get("db/b", "1-b")
+	assert.False(t, ok)
+	_, ok = c.Get("db/c", "1-c")
+	assert.True(t, ok)
+
+	c.Delete("db/c")
+	_, ok = c.Get("db/c", "1-c")
+	assert.False(t, ok)
+	assert.Len(t, c.entries, 1)
+}
@@ -0,0 +1,190 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+type client struct {
+	api   documentAPI
+	url   string
+	cache *documentCache
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the document referenced by key, in the format
+// database/docID, as JSON. With property only the value of the field is
+// returned, nested fields are selected with a gjson expression. The version
+// selects a revision of the document.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	doc, err := c.getDocument(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return doc, nil
+	}
+	val := gjson.GetBytes(doc, ref.Property)
+	if !val.Exists() {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap returns the fields of the document, or of the object
+// selected by property, as map.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	doc, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return nil, errNotAnObject
+	}
+	secretMap := make(map[string][]byte, len(fields))
+	for k := range fields {
+		secretMap[k], err = utils.GetByteValueFromMap(fields, k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secretMap, nil
+}
+
+// GetAllSecrets returns the documents of the database in find.path whose ids
+// match find.name, keyed by document id. The revisions of all documents are
+// listed first, only documents with a revision that is not cached are
+// fetched, so that repeated syncs of large databases stay cheap.
+// Design documents are skipped.
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Path == nil || *ref.Path == "" {
+		return nil, errFindPathRequired
+	}
+	if len(ref.Tags) > 0 {
+		return nil, errFindTagsNotSupported
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		var err error
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	database := *ref.Path
+	revs, err := c.api.ListRevisions(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make(map[string][]byte)
+	for docID, rev := range revs {
+		if strings.HasPrefix(docID, "_design/") || (matcher != nil && !matcher.MatchName(docID)) {
+			continue
+		}
+		doc, err := c.getCachedDocument(ctx, database, docID, rev)
+		if errors.Is(err, esv1beta1.NoSecretErr) {
+			// deleted after it was listed
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		secrets[docID] = doc
+	}
+	return secrets, nil
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by Cloudant")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by Cloudant")
+}
+
+// Validate checks that the credentials are accepted by the server.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if err := c.api.GetSession(ctx); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(context.Context) error {
+	return nil
+}
+
+// getCachedDocument returns the revision of the document from the cache, or
+// fetches and caches it. The cache is keyed by server, so a document is only
+// returned from the cache after the credentials of the store listed it.
+func (c *client) getCachedDocument(ctx context.Context, database, docID, rev string) ([]byte, error) {
+	key := c.url + "/" + database + "/" + docID
+	if doc, ok := c.cache.Get(key, rev); ok {
+		return doc, nil
+	}
+	raw, err := c.api.GetDocument(ctx, database, docID, rev)
+	if err != nil {
+		if errors.Is(err, esv1beta1.NoSecretErr) {
+			c.cache.Delete(key)
+		}
+		return nil, err
+	}
+	doc, err := withoutMetadata(raw)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Put(key, rev, doc)
+	return doc, nil
+}
+
+// getDocument returns the document without the metadata fields, like _id
+// and _rev, that are maintained by Cloudant.
+func (c *client) getDocument(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	database, docID, ok := strings.Cut(ref.Key, "/")
+	if !ok || database == "" || docID == "" {
+		return nil, errInvalidKey
+	}
+	raw, err := c.api.GetDocument(ctx, database, docID, ref.Version)
+	if err != nil {
+		return nil, err
+	}
+	return withoutMetadata(raw)
+}
+
+func withoutMetadata(raw []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, errInvalidDocument
+	}
+	for k := range doc {
+		if strings.HasPrefix(k, "_") {
+			delete(doc, k)
+		}
+	}
+	return json.Marshal(doc)
+}
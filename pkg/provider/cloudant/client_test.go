@@ -0,0 +1,262 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudant
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	testUser     = "admin"
+	testPassword = "pass"
+)
+
+func newTestClient(t *testing.T) *client {
+	t.Helper()
+	docs := map[string]map[string]any{
+		"/config/app": {
+			"_id":  "app",
+			"_rev": "2-b",
+			"db": map[string]any{
+				"user":     "app",
+				"password": "s3cr3t",
+			},
+			"port": 5432,
+		},
+		"/config/app?rev=1-a": {
+			"_id":  "app",
+			"_rev": "1-a",
+			"db": map[string]any{
+				"user":     "app",
+				"password": "old",
+			},
+		},
+		"/config/_all_docs": {
+			"total_rows": 2,
+			"rows": []any{
+				map[string]any{"id": "_design/app", "key": "_design/app", "value": map[string]any{"rev": "1-d"}},
+				map[string]any{"id": "app", "key": "app", "value": map[string]any{"rev": "2-b"}},
+			},
+		},
+		"/config/_design%2Fapp": {
+			"_id":   "_design/app",
+			"token": "design",
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != testUser || password != testPassword {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/_session" {
+			_, _ = w.Write([]byte(`{"ok":true}`))
+			return
+		}
+		key := r.URL.EscapedPath()
+		if r.URL.RawQuery != "" {
+			key += "?" + r.URL.RawQuery
+		}
+		doc, ok := docs[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found","reason":"missing"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(doc)
+	}))
+	t.Cleanup(server.Close)
+	authenticator, err := core.NewBasicAuthenticator(testUser, testPassword)
+	require.NoError(t, err)
+	return &client{
+		api: &httpDocumentAPI{
+			url:           server.URL,
+			authenticator: authenticator,
+			client:        server.Client(),
+		},
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	c := newTestClient(t)
+	tests := []struct {
+		name    string
+		ref     esv1beta1.ExternalSecretDataRemoteRef
+		want    string
+		wantErr error
+	}{
+		{
+			name: "whole document without metadata",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app"},
+			want: `{"db":{"password":"s3cr3t","user":"app"},"port":5432}`,
+		},
+		{
+			name: "nested field",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app", Property: "db.password"},
+			want: "s3cr3t",
+		},
+		{
+			name: "number field",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app", Property: "port"},
+			want: "5432",
+		},
+		{
+			name: "revision",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app", Property: "db.password", Version: "1-a"},
+			want: "old",
+		},
+		{
+			name: "document id with slash",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "config/_design/app", Property: "token"},
+			want: "design",
+		},
+		{
+			name:    "missing field",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app", Property: "db.token"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing document",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "config/other"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "invalid key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "config"},
+			wantErr: errInvalidKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c := newTestClient(t)
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app", Property: "db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"user": []byte("app"), "password": []byte("s3cr3t")}, got)
+
+	got, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("5432"), got["port"])
+	assert.NotContains(t, got, "_rev")
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app", Property: "port"})
+	assert.ErrorIs(t, err, errNotAnObject)
+}
+
+func TestListRevisions(t *testing.T) {
+	c := newTestClient(t)
+	got, err := c.api.ListRevisions(context.Background(), "config")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"_design/app": "1-d", "app": "2-b"}, got)
+}
+
+func TestValidate(t *testing.T) {
+	c := newTestClient(t)
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	authenticator, err := core.NewBasicAuthenticator(testUser, "wrong")
+	require.NoError(t, err)
+	c.api.(*httpDocumentAPI).authenticator = authenticator
+	result, err = c.Validate()
+	assert.ErrorContains(t, err, "unexpected status code 401")
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+}
+
+type fakeDocumentAPI struct {
+	docs  map[string]map[string]string
+	revs  map[string]string
+	calls []string
+}
+
+func (f *fakeDocumentAPI) GetDocument(_ context.Context, database, docID, rev string) ([]byte, error) {
+	f.calls = append(f.calls, database+"/"+docID+"@"+rev)
+	doc, ok := f.docs[docID+"@"+rev]
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return json.Marshal(doc)
+}
+
+func (f *fakeDocumentAPI) ListRevisions(context.Context, string) (map[string]string, error) {
+	return f.revs, nil
+}
+
+func (f *fakeDocumentAPI) GetSession(context.Context) error {
+	return nil
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	api := &fakeDocumentAPI{
+		docs: map[string]map[string]string{
+			"app-db@1-a":      {"_id": "app-db", "_rev": "1-a", "password": "one"},
+			"app-db@2-b":      {"_id": "app-db", "_rev": "2-b", "password": "two"},
+			"app-cache@1-c":   {"_id": "app-cache", "_rev": "1-c", "password": "cache"},
+			"other@1-d":       {"_id": "other", "_rev": "1-d", "password": "other"},
+			"_design/app@1-e": {"_id": "_design/app", "_rev": "1-e"},
+		},
+		revs: map[string]string{"app-db": "1-a", "app-cache": "1-c", "other": "1-d", "_design/app": "1-e"},
+	}
+	c := &client{api: api, url: "https://cloudant.test", cache: newDocumentCache(10)}
+	ref := esv1beta1.ExternalSecretFind{Path: ptr.To("config"), Name: &esv1beta1.FindName{RegExp: "^app-"}}
+
+	got, err := c.GetAllSecrets(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"app-db":    []byte(`{"password":"one"}`),
+		"app-cache": []byte(`{"password":"cache"}`),
+	}, got)
+	assert.ElementsMatch(t, []string{"config/app-db@1-a", "config/app-cache@1-c"}, api.calls)
+
+	// only the changed document is fetched again
+	api.calls = nil
+	api.revs["app-db"] = "2-b"
+	got, err = c.GetAllSecrets(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"password":"two"}`), got["app-db"])
+	assert.Equal(t, []byte(`{"password":"cache"}`), got["app-cache"])
+	assert.Equal(t, []string{"config/app-db@2-b"}, api.calls)
+
+	// a document deleted after it was listed is skipped
+	api.revs["app-new"] = "1-f"
+	got, err = c.GetAllSecrets(context.Background(), ref)
+	require.NoError(t, err)
+	assert.NotContains(t, got, "app-new")
+
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+	assert.ErrorIs(t, err, errFindPathRequired)
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Path: ptr.To("config"), Tags: map[string]string{"a": "b"}})
+	assert.ErrorIs(t, err, errFindTagsNotSupported)
+}
@@ -0,0 +1,102 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// documentAPI is the subset of the Cloudant and CouchDB API used by the provider.
+// See https://cloud.ibm.com/apidocs/cloudant for the full API documentation.
+type documentAPI interface {
+	// GetDocument returns the JSON document, rev selects a revision if set.
+	GetDocument(ctx context.Context, database, docID, rev string) ([]byte, error)
+	// ListRevisions returns the current revision of every document in the database.
+	ListRevisions(ctx context.Context, database string) (map[string]string, error)
+	// GetSession returns an error if the credentials are not accepted.
+	GetSession(ctx context.Context) error
+}
+
+type allDocsResponse struct {
+	Rows []struct {
+		ID    string `json:"id"`
+		Value struct {
+			Rev string `json:"rev"`
+		} `json:"value"`
+	} `json:"rows"`
+}
+
+type httpDocumentAPI struct {
+	url           string
+	authenticator core.Authenticator
+	client        *http.Client
+}
+
+var _ documentAPI = &httpDocumentAPI{}
+
+func (a *httpDocumentAPI) GetDocument(ctx context.Context, database, docID, rev string) ([]byte, error) {
+	docURL := a.url + "/" + url.PathEscape(database) + "/" + url.PathEscape(docID)
+	if rev != "" {
+		docURL += "?" + url.Values{"rev": []string{rev}}.Encode()
+	}
+	body, err := a.get(ctx, docURL)
+	metrics.ObserveAPICall(constants.ProviderCloudant, constants.CallCloudantGetDocument, err)
+	return body, err
+}
+
+// ListRevisions reads _all_docs without the documents, the response only
+// contains the ids and revisions.
+func (a *httpDocumentAPI) ListRevisions(ctx context.Context, database string) (map[string]string, error) {
+	body, err := a.get(ctx, a.url+"/"+url.PathEscape(database)+"/_all_docs")
+	metrics.ObserveAPICall(constants.ProviderCloudant, constants.CallCloudantListDocuments, err)
+	if err != nil {
+		return nil, err
+	}
+	var resp allDocsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf(errListDocuments, err)
+	}
+	revs := make(map[string]string, len(resp.Rows))
+	for _, row := range resp.Rows {
+		revs[row.ID] = row.Value.Rev
+	}
+	return revs, nil
+}
+
+func (a *httpDocumentAPI) GetSession(ctx context.Context) error {
+	_, err := a.get(ctx, a.url+"/_session")
+	metrics.ObserveAPICall(constants.ProviderCloudant, constants.CallCloudantGetSession, err)
+	return err
+}
+
+func (a *httpDocumentAPI) get(ctx context.Context, reqURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if err := a.authenticator.Authenticate(req); err != nil {
+		return nil, fmt.Errorf(errAuthenticate, err)
+	}
+	return utils.DoHTTP(a.client, req, http.StatusNotFound)
+}
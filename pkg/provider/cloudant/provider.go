@@ -0,0 +1,166 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errAuthenticate  = "unable to authenticate request: %w"
+	errListDocuments = "unable to decode document list: %w"
+
+	requestTimeout  = 30 * time.Second
+	validateTimeout = 10 * time.Second
+)
+
+var (
+	errMissingStore         = errors.New("missing store specification")
+	errInvalidSpec          = errors.New("invalid specification for cloudant provider")
+	errMissingURL           = errors.New("url must be set")
+	errInvalidURL           = errors.New("url must be an absolute http or https url")
+	errAuthMethod           = errors.New("exactly one of auth.iam or auth.basic must be set")
+	errMissingSecretName    = errors.New("must specify a secret name")
+	errMissingSecretKey     = errors.New("must specify a secret key")
+	errInvalidKey           = errors.New("invalid key format, expected 'database/docID'")
+	errInvalidDocument      = errors.New("document is not a json object")
+	errNotAnObject          = errors.New("value is not a json object")
+	errFindPathRequired     = errors.New("find.path must be set to the database")
+	errFindTagsNotSupported = errors.New("find.tags is not supported by Cloudant")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	authenticator, err := newAuthenticator(ctx, store.GetKind(), cfg.Auth, kube, namespace)
+	if err != nil {
+		return nil, err
+	}
+	serverURL := strings.TrimSuffix(cfg.URL, "/")
+	return &client{
+		url:   serverURL,
+		cache: documents,
+		api: &httpDocumentAPI{
+			url:           serverURL,
+			authenticator: authenticator,
+			client:        &http.Client{Timeout: requestTimeout},
+		},
+	}, nil
+}
+
+func newAuthenticator(ctx context.Context, storeKind string, auth esv1beta1.CloudantAuth, kube kclient.Client, namespace string) (core.Authenticator, error) {
+	if auth.IAM != nil {
+		apiKey, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &auth.IAM.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		builder := core.NewIamAuthenticatorBuilder().SetApiKey(apiKey)
+		if auth.IAM.URL != "" {
+			builder.SetURL(auth.IAM.URL)
+		}
+		return builder.Build()
+	}
+	username, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &auth.Basic.Username)
+	if err != nil {
+		return nil, err
+	}
+	password, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &auth.Basic.Password)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewBasicAuthenticator(username, password)
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.CloudantProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Cloudant == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.Cloudant
+
+	if cfg.URL == "" {
+		return nil, errMissingURL
+	}
+	u, err := url.ParseRequestURI(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errInvalidURL
+	}
+
+	if (cfg.Auth.IAM == nil) == (cfg.Auth.Basic == nil) {
+		return nil, errAuthMethod
+	}
+	var refs []esmeta.SecretKeySelector
+	if cfg.Auth.IAM != nil {
+		refs = append(refs, cfg.Auth.IAM.APIKey)
+	} else {
+		refs = append(refs, cfg.Auth.Basic.Username, cfg.Auth.Basic.Password)
+	}
+	for _, ref := range refs {
+		if err := validateSecretRef(store, ref); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func validateSecretRef(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return err
+	}
+	if ref.Name == "" {
+		return errMissingSecretName
+	}
+	if ref.Key == "" {
+		return errMissingSecretKey
+	}
+	return nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Cloudant: &esv1beta1.CloudantProvider{},
+	})
+}
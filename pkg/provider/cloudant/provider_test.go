@@ -0,0 +1,147 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func newStore(provider *esv1beta1.CloudantProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudant", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Cloudant: provider},
+		},
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	namespace := "other"
+	iam := &esv1beta1.CloudantIAMAuth{APIKey: esmeta.SecretKeySelector{Name: "cloudant", Key: "apikey"}}
+	basic := &esv1beta1.CloudantBasicAuth{
+		Username: esmeta.SecretKeySelector{Name: "couchdb", Key: "username"},
+		Password: esmeta.SecretKeySelector{Name: "couchdb", Key: "password"},
+	}
+	tests := []struct {
+		name     string
+		store    esv1beta1.GenericStore
+		wantErr  error
+		contains string
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "missing url",
+			store:   newStore(&esv1beta1.CloudantProvider{Auth: esv1beta1.CloudantAuth{IAM: iam}}),
+			wantErr: errMissingURL,
+		},
+		{
+			name:    "invalid url",
+			store:   newStore(&esv1beta1.CloudantProvider{URL: "ftp://couchdb", Auth: esv1beta1.CloudantAuth{IAM: iam}}),
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "missing auth",
+			store:   newStore(&esv1beta1.CloudantProvider{URL: "https://couchdb"}),
+			wantErr: errAuthMethod,
+		},
+		{
+			name:    "multiple auth methods",
+			store:   newStore(&esv1beta1.CloudantProvider{URL: "https://couchdb", Auth: esv1beta1.CloudantAuth{IAM: iam, Basic: basic}}),
+			wantErr: errAuthMethod,
+		},
+		{
+			name: "missing secret key",
+			store: newStore(&esv1beta1.CloudantProvider{URL: "https://couchdb", Auth: esv1beta1.CloudantAuth{
+				IAM: &esv1beta1.CloudantIAMAuth{APIKey: esmeta.SecretKeySelector{Name: "cloudant"}},
+			}}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name: "namespace in SecretStore",
+			store: newStore(&esv1beta1.CloudantProvider{URL: "https://couchdb", Auth: esv1beta1.CloudantAuth{
+				IAM: &esv1beta1.CloudantIAMAuth{APIKey: esmeta.SecretKeySelector{Name: "cloudant", Key: "apikey", Namespace: &namespace}},
+			}}),
+			contains: "namespace not allowed",
+		},
+		{
+			name:  "iam auth",
+			store: newStore(&esv1beta1.CloudantProvider{URL: "https://account.cloudantnosqldb.appdomain.cloud", Auth: esv1beta1.CloudantAuth{IAM: iam}}),
+		},
+		{
+			name:  "basic auth",
+			store: newStore(&esv1beta1.CloudantProvider{URL: "http://couchdb:5984/", Auth: esv1beta1.CloudantAuth{Basic: basic}}),
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "couchdb", Namespace: "default"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("pass"), "apikey": []byte("key")},
+	}).Build()
+	store := newStore(&esv1beta1.CloudantProvider{
+		URL: "http://couchdb:5984/",
+		Auth: esv1beta1.CloudantAuth{Basic: &esv1beta1.CloudantBasicAuth{
+			Username: esmeta.SecretKeySelector{Name: "couchdb", Key: "username"},
+			Password: esmeta.SecretKeySelector{Name: "couchdb", Key: "password"},
+		}},
+	})
+	p := &Provider{}
+	sc, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	api := sc.(*client).api.(*httpDocumentAPI)
+	assert.Equal(t, "http://couchdb:5984", api.url)
+
+	store.Spec.Provider.Cloudant.Auth = esv1beta1.CloudantAuth{IAM: &esv1beta1.CloudantIAMAuth{
+		APIKey: esmeta.SecretKeySelector{Name: "couchdb", Key: "apikey"},
+	}}
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+
+	store.Spec.Provider.Cloudant.Auth.IAM.APIKey.Key = "missing"
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+type client struct {
+	api objectAPI
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the object referenced by key, in the format
+// bucket/object. With property the object is parsed as JSON and only the
+// value of the field is returned, nested fields are selected with a gjson
+// expression. The version selects a version of the object in versioned buckets.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	bucket, key, ok := strings.Cut(ref.Key, "/")
+	if !ok || bucket == "" || key == "" {
+		return nil, errInvalidKey
+	}
+	obj, err := c.api.GetObject(ctx, bucket, key, ref.Version)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return obj, nil
+	}
+	if !gjson.ValidBytes(obj) {
+		return nil, errObjectNotJSON
+	}
+	val := gjson.GetBytes(obj, ref.Property)
+	if !val.Exists() {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap returns the fields of the JSON object, or of the nested
+// object selected by property, as map.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	obj, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(obj, &fields); err != nil {
+		return nil, errNotAnObject
+	}
+	secretMap := make(map[string][]byte, len(fields))
+	for k := range fields {
+		secretMap[k], err = utils.GetByteValueFromMap(fields, k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secretMap, nil
+}
+
+func (c *client) GetAllSecrets(_ context.Context, _ esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, errors.New("getting all secrets is not supported by IBM Cloud Object Storage")
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by IBM Cloud Object Storage")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by IBM Cloud Object Storage")
+}
+
+// Validate checks that an IAM token can be obtained. Access to the buckets
+// is only checked when objects are fetched, as the store is not bound to a bucket.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	if err := c.api.Authenticate(context.Background()); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(context.Context) error {
+	return nil
+}
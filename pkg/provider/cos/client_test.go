@@ -0,0 +1,165 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const testToken = "token"
+
+func newTestClient(t *testing.T) *client {
+	t.Helper()
+	objects := map[string]string{
+		"/credentials/db.json":              `{"user":"app","password":"s3cr3t","tls":{"ca":"pem"}}`,
+		"/credentials/legacy/token":         "plain-token",
+		"/credentials/db.json?versionId=v1": `{"user":"app","password":"old"}`,
+		"/credentials/large":                strings.Repeat("a", maxObjectSize+1),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+			return
+		}
+		path := r.URL.EscapedPath()
+		if r.URL.RawQuery != "" {
+			path += "?" + r.URL.RawQuery
+		}
+		obj, ok := objects[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(obj))
+	}))
+	t.Cleanup(server.Close)
+	authenticator, err := core.NewBearerTokenAuthenticator(testToken)
+	require.NoError(t, err)
+	return &client{
+		api: &httpObjectAPI{
+			url:           server.URL,
+			authenticator: authenticator,
+			client:        server.Client(),
+		},
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	c := newTestClient(t)
+	tests := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		want     string
+		wantErr  error
+		contains string
+	}{
+		{
+			name: "raw object",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/legacy/token"},
+			want: "plain-token",
+		},
+		{
+			name: "json property",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/db.json", Property: "password"},
+			want: "s3cr3t",
+		},
+		{
+			name: "nested json property",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/db.json", Property: "tls.ca"},
+			want: "pem",
+		},
+		{
+			name: "version",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/db.json", Property: "password", Version: "v1"},
+			want: "old",
+		},
+		{
+			name:    "property of non json object",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/legacy/token", Property: "user"},
+			wantErr: errObjectNotJSON,
+		},
+		{
+			name:    "missing property",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/db.json", Property: "token"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing object",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/other"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing bucket",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "db.json"},
+			wantErr: errInvalidKey,
+		},
+		{
+			name:     "object too large",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/large"},
+			contains: "exceeds the maximum size",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c := newTestClient(t)
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/db.json"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"user":     []byte("app"),
+		"password": []byte("s3cr3t"),
+		"tls":      []byte(`{"ca":"pem"}`),
+	}, got)
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/legacy/token"})
+	assert.ErrorIs(t, err, errNotAnObject)
+}
+
+func TestAccessDenied(t *testing.T) {
+	c := newTestClient(t)
+	c.api.(*httpObjectAPI).authenticator, _ = core.NewBearerTokenAuthenticator("other")
+	_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "credentials/db.json"})
+	assert.ErrorContains(t, err, "unexpected status code 403")
+}
+
+func TestValidate(t *testing.T) {
+	c := newTestClient(t)
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+}
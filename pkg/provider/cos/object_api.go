@@ -0,0 +1,101 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// maxObjectSize is the size limit of a Kubernetes secret, larger objects can
+// not be synced.
+const maxObjectSize = 1 << 20
+
+// objectAPI is the subset of the COS S3 API used by the provider.
+// See https://cloud.ibm.com/docs/cloud-object-storage?topic=cloud-object-storage-compatibility-api
+// for the full API documentation.
+type objectAPI interface {
+	// GetObject returns the content of the object. An empty versionID
+	// returns the current version.
+	GetObject(ctx context.Context, bucket, key, versionID string) ([]byte, error)
+	// Authenticate returns an error if no IAM token can be obtained.
+	Authenticate(ctx context.Context) error
+}
+
+type httpObjectAPI struct {
+	url           string
+	authenticator core.Authenticator
+	client        *http.Client
+}
+
+var _ objectAPI = &httpObjectAPI{}
+
+func (a *httpObjectAPI) GetObject(ctx context.Context, bucket, key, versionID string) ([]byte, error) {
+	obj, err := a.getObject(ctx, bucket, key, versionID)
+	metrics.ObserveAPICall(constants.ProviderIBMCOS, constants.CallIBMCOSGetObject, err)
+	return obj, err
+}
+
+func (a *httpObjectAPI) getObject(ctx context.Context, bucket, key, versionID string) ([]byte, error) {
+	u := a.url + (&url.URL{Path: "/" + bucket + "/" + key}).EscapedPath()
+	if versionID != "" {
+		u += "?versionId=" + url.QueryEscape(versionID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.authenticator.Authenticate(req); err != nil {
+		return nil, fmt.Errorf(errAuthenticate, err)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxObjectSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	if err := utils.CheckHTTPStatus(resp.StatusCode, body); err != nil {
+		return nil, err
+	}
+	if len(body) > maxObjectSize {
+		return nil, fmt.Errorf(errObjectTooLarge, bucket, key, maxObjectSize)
+	}
+	return body, nil
+}
+
+func (a *httpObjectAPI) Authenticate(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	if err := a.authenticator.Authenticate(req); err != nil {
+		return fmt.Errorf(errAuthenticate, err)
+	}
+	return nil
+}
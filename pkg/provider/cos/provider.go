@@ -0,0 +1,166 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errAuthenticate   = "unable to authenticate request: %w"
+	errObjectTooLarge = "object %s/%s exceeds the maximum size of %d bytes"
+
+	defaultTokenLocation = "/var/run/secrets/tokens/vault-token"
+	defaultIAMEndpoint   = "https://iam.cloud.ibm.com"
+	serviceURLTemplate   = "https://s3.%s.cloud-object-storage.appdomain.cloud"
+
+	requestTimeout = 30 * time.Second
+)
+
+var (
+	errMissingStore      = errors.New("missing store specification")
+	errInvalidSpec       = errors.New("invalid specification for cos provider")
+	errMissingRegion     = errors.New("either region or serviceUrl must be set")
+	errInvalidURL        = errors.New("serviceUrl must be an absolute http or https url")
+	errAuthMethod        = errors.New("exactly one of auth.secretRef or auth.containerAuth must be set")
+	errMissingProfile    = errors.New("container auth profile must be set")
+	errMissingSecretName = errors.New("must specify a secret name")
+	errMissingSecretKey  = errors.New("must specify a secret key")
+	errInvalidKey        = errors.New("invalid key format, expected 'bucket/object'")
+	errObjectNotJSON     = errors.New("object is not valid json, omit the property to sync the raw object")
+	errNotAnObject       = errors.New("value is not a json object")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	authenticator, err := newAuthenticator(ctx, store.GetKind(), cfg.Auth, kube, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		api: &httpObjectAPI{
+			url:           serviceURL(cfg),
+			authenticator: authenticator,
+			client:        &http.Client{Timeout: requestTimeout},
+		},
+	}, nil
+}
+
+func newAuthenticator(ctx context.Context, storeKind string, auth esv1beta1.IBMAuth, kube kclient.Client, namespace string) (core.Authenticator, error) {
+	if auth.ContainerAuth != nil {
+		tokenLocation := auth.ContainerAuth.TokenLocation
+		if tokenLocation == "" {
+			tokenLocation = defaultTokenLocation
+		}
+		iamEndpoint := auth.ContainerAuth.IAMEndpoint
+		if iamEndpoint == "" {
+			iamEndpoint = defaultIAMEndpoint
+		}
+		return core.NewContainerAuthenticatorBuilder().
+			SetIAMProfileName(auth.ContainerAuth.Profile).
+			SetCRTokenFilename(tokenLocation).
+			SetURL(iamEndpoint).
+			Build()
+	}
+	apiKey, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &auth.SecretRef.SecretAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewIamAuthenticatorBuilder().SetApiKey(apiKey).Build()
+}
+
+func serviceURL(cfg *esv1beta1.COSProvider) string {
+	if cfg.ServiceURL != "" {
+		return strings.TrimSuffix(cfg.ServiceURL, "/")
+	}
+	return fmt.Sprintf(serviceURLTemplate, cfg.Region)
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.COSProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.COS == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.COS
+
+	if cfg.Region == "" && cfg.ServiceURL == "" {
+		return nil, errMissingRegion
+	}
+	if cfg.ServiceURL != "" {
+		u, err := url.ParseRequestURI(cfg.ServiceURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errInvalidURL
+		}
+	}
+
+	if (cfg.Auth.SecretRef == nil) == (cfg.Auth.ContainerAuth == nil) {
+		return nil, errAuthMethod
+	}
+	if cfg.Auth.ContainerAuth != nil {
+		if cfg.Auth.ContainerAuth.Profile == "" {
+			return nil, errMissingProfile
+		}
+		return cfg, nil
+	}
+	ref := cfg.Auth.SecretRef.SecretAPIKey
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return nil, err
+	}
+	if ref.Name == "" {
+		return nil, errMissingSecretName
+	}
+	if ref.Key == "" {
+		return nil, errMissingSecretKey
+	}
+	return cfg, nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		COS: &esv1beta1.COSProvider{},
+	})
+}
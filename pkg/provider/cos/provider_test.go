@@ -0,0 +1,130 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func newStore(provider *esv1beta1.COSProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "cos", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{COS: provider},
+		},
+	}
+}
+
+func apiKeyAuth(name, key string) esv1beta1.IBMAuth {
+	return esv1beta1.IBMAuth{SecretRef: &esv1beta1.IBMAuthSecretRef{
+		SecretAPIKey: esmeta.SecretKeySelector{Name: name, Key: key},
+	}}
+}
+
+func TestValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "missing region",
+			store:   newStore(&esv1beta1.COSProvider{Auth: apiKeyAuth("ibm", "apikey")}),
+			wantErr: errMissingRegion,
+		},
+		{
+			name:    "invalid service url",
+			store:   newStore(&esv1beta1.COSProvider{ServiceURL: "s3.us-south", Auth: apiKeyAuth("ibm", "apikey")}),
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "missing auth",
+			store:   newStore(&esv1beta1.COSProvider{Region: "us-south"}),
+			wantErr: errAuthMethod,
+		},
+		{
+			name: "missing container auth profile",
+			store: newStore(&esv1beta1.COSProvider{Region: "us-south", Auth: esv1beta1.IBMAuth{
+				ContainerAuth: &esv1beta1.IBMAuthContainerAuth{},
+			}}),
+			wantErr: errMissingProfile,
+		},
+		{
+			name:    "missing secret name",
+			store:   newStore(&esv1beta1.COSProvider{Region: "us-south", Auth: apiKeyAuth("", "apikey")}),
+			wantErr: errMissingSecretName,
+		},
+		{
+			name:  "api key auth",
+			store: newStore(&esv1beta1.COSProvider{Region: "us-south", Auth: apiKeyAuth("ibm", "apikey")}),
+		},
+		{
+			name: "container auth with direct endpoint",
+			store: newStore(&esv1beta1.COSProvider{ServiceURL: "https://s3.direct.us-south.cloud-object-storage.appdomain.cloud", Auth: esv1beta1.IBMAuth{
+				ContainerAuth: &esv1beta1.IBMAuthContainerAuth{Profile: "eso"},
+			}}),
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ibm", Namespace: "default"},
+		Data:       map[string][]byte{"apikey": []byte("key")},
+	}).Build()
+	p := &Provider{}
+	store := newStore(&esv1beta1.COSProvider{Region: "eu-de", Auth: apiKeyAuth("ibm", "apikey")})
+	sc, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "https://s3.eu-de.cloud-object-storage.appdomain.cloud", sc.(*client).api.(*httpObjectAPI).url)
+
+	store.Spec.Provider.COS.ServiceURL = "https://s3.private.eu-de.cloud-object-storage.appdomain.cloud/"
+	sc, err = p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "https://s3.private.eu-de.cloud-object-storage.appdomain.cloud", sc.(*client).api.(*httpObjectAPI).url)
+
+	store.Spec.Provider.COS.Auth = apiKeyAuth("ibm", "missing")
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cyberarkccp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// errorCodeNotFound is returned if no account matches the query.
+const errorCodeNotFound = "APPAP004E"
+
+type errorResponse struct {
+	ErrorCode string `json:"ErrorCode"`
+	ErrorMsg  string `json:"ErrorMsg"`
+}
+
+// ccpAPI is the subset of the Central Credential Provider used by the provider.
+type ccpAPI interface {
+	// GetAccount returns the properties of the account matching params, the
+	// password is returned as Content. A missing account returns a
+	// NoSecretError.
+	GetAccount(ctx context.Context, params url.Values) (map[string]any, error)
+}
+
+type httpCCPAPI struct {
+	url    string
+	appID  string
+	reason string
+	client *http.Client
+}
+
+var _ ccpAPI = &httpCCPAPI{}
+
+func (a *httpCCPAPI) GetAccount(ctx context.Context, params url.Values) (map[string]any, error) {
+	account, err := a.getAccount(ctx, params)
+	metrics.ObserveAPICall(constants.ProviderCyberArkCCP, constants.CallCyberArkCCPGetAccount, err)
+	return account, err
+}
+
+func (a *httpCCPAPI) getAccount(ctx context.Context, params url.Values) (map[string]any, error) {
+	query := url.Values{"AppID": {a.appID}}
+	if a.reason != "" {
+		query.Set("Reason", a.reason)
+	}
+	for k, v := range params {
+		query[k] = v
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.url+"/api/Accounts?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	data, err := utils.DoHTTP(a.client, req)
+	var statusErr *utils.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		var errResp errorResponse
+		if json.Unmarshal(statusErr.Body, &errResp) != nil || errResp.ErrorCode == "" {
+			return nil, err
+		}
+		if errResp.ErrorCode == errorCodeNotFound {
+			return nil, esv1beta1.NoSecretError{}
+		}
+		return nil, fmt.Errorf(errCCP, errResp.ErrorCode, errResp.ErrorMsg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var account map[string]any
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf(errDecodeResponse, err)
+	}
+	return account, nil
+}
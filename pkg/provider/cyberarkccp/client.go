@@ -0,0 +1,146 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cyberarkccp
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// contentField holds the password of an account.
+const contentField = "Content"
+
+type client struct {
+	api  ccpAPI
+	safe string
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the password of the account key, or the account
+// property selected by property, e.g. UserName or Address.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	account, err := c.getAccount(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	field := ref.Property
+	if field == "" {
+		field = contentField
+	}
+	value, ok := account[field]
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return utils.GetByteValue(value)
+}
+
+// GetSecretMap returns all properties of the account key.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Property != "" {
+		return nil, errPropertyNotSupported
+	}
+	account, err := c.getAccount(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	secretMap := make(map[string][]byte, len(account))
+	for k, v := range account {
+		secretMap[k], err = utils.GetByteValue(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secretMap, nil
+}
+
+// GetAllSecrets is not supported, the CCP can not list accounts.
+func (c *client) GetAllSecrets(context.Context, esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, errFindNotSupported
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by CyberArk CCP")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by CyberArk CCP")
+}
+
+// Validate can not check the endpoint, the CCP only answers requests for
+// accounts and every request is recorded in the audit log of the vault.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultUnknown, nil
+}
+
+func (c *client) Close(context.Context) error {
+	return nil
+}
+
+func (c *client) getAccount(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string]any, error) {
+	if ref.Version != "" {
+		return nil, errVersionNotSupported
+	}
+	params, err := c.queryParams(ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	return c.api.GetAccount(ctx, params)
+}
+
+// queryParams returns the request parameters for key. A key containing =
+// is a CCP query, e.g. Safe=Linux;UserName=root;Address=db.example.com,
+// other keys are the name of the account object, optionally prefixed by
+// its safe, e.g. Linux/db-root. The safe of the store is used if the key
+// does not name a safe.
+func (c *client) queryParams(key string) (url.Values, error) {
+	if key == "" {
+		return nil, errMissingKey
+	}
+	if strings.Contains(key, "=") {
+		query := key
+		if c.safe != "" && !hasSafe(query) {
+			query = "Safe=" + c.safe + ";" + query
+		}
+		return url.Values{"Query": {query}, "QueryFormat": {"Exact"}}, nil
+	}
+	safe, object, ok := strings.Cut(key, "/")
+	if !ok {
+		safe, object = c.safe, key
+	}
+	if safe == "" {
+		return nil, errMissingSafe
+	}
+	if object == "" || strings.Contains(object, "/") {
+		return nil, errInvalidKey
+	}
+	return url.Values{"Safe": {safe}, "Object": {object}}, nil
+}
+
+// hasSafe reports whether the query names a safe.
+func hasSafe(query string) bool {
+	for _, cond := range strings.Split(query, ";") {
+		name, _, _ := strings.Cut(cond, "=")
+		if strings.EqualFold(strings.TrimSpace(name), "safe") {
+			return true
+		}
+	}
+	return false
+}
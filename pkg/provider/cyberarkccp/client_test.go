@@ -0,0 +1,219 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cyberarkccp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+var testAccount = map[string]any{
+	"Content":                 "s3cr3t",
+	"UserName":                "root",
+	"Address":                 "db.example.com",
+	"Safe":                    "Linux",
+	"Folder":                  "Root",
+	"Name":                    "db-root",
+	"PolicyID":                "UnixSSH",
+	"PasswordChangeInProcess": "False",
+	"Port":                    22,
+}
+
+// newTestServer returns a CCP that knows the db-root account in the Linux
+// safe and records the query of the last request.
+func newTestServer(t *testing.T, last *url.Values) *httptest.Server {
+	t.Helper()
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*last = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path != "/AIMWebService/api/Accounts":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
+		case last.Get("AppID") != "eso":
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(errorResponse{ErrorCode: "APPAP227E", ErrorMsg: "App failed on authentication"})
+		case (last.Get("Safe") == "Linux" && last.Get("Object") == "db-root") ||
+			last.Get("Query") == "Safe=Linux;UserName=root;Address=db.example.com":
+			_ = json.NewEncoder(w).Encode(testAccount)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errorResponse{ErrorCode: errorCodeNotFound, ErrorMsg: "Password object matching query was not found"})
+		}
+	}))
+}
+
+func newTestClient(server *httptest.Server, appID, safe string) *client {
+	return &client{
+		api: &httpCCPAPI{
+			url:    server.URL + "/AIMWebService",
+			appID:  appID,
+			reason: "sync",
+			client: server.Client(),
+		},
+		safe: safe,
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	var last url.Values
+	server := newTestServer(t, &last)
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		appID     string
+		safe      string
+		ref       esv1beta1.ExternalSecretDataRemoteRef
+		want      string
+		wantQuery url.Values
+		wantErr   error
+		contains  string
+	}{
+		{
+			name:      "object in safe of store",
+			safe:      "Linux",
+			ref:       esv1beta1.ExternalSecretDataRemoteRef{Key: "db-root"},
+			want:      "s3cr3t",
+			wantQuery: url.Values{"AppID": {"eso"}, "Reason": {"sync"}, "Safe": {"Linux"}, "Object": {"db-root"}},
+		},
+		{
+			name: "safe and object",
+			safe: "Windows",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "Linux/db-root", Property: "UserName"},
+			want: "root",
+		},
+		{
+			name:      "query",
+			ref:       esv1beta1.ExternalSecretDataRemoteRef{Key: "Safe=Linux;UserName=root;Address=db.example.com", Property: "Address"},
+			want:      "db.example.com",
+			wantQuery: url.Values{"AppID": {"eso"}, "Reason": {"sync"}, "Query": {"Safe=Linux;UserName=root;Address=db.example.com"}, "QueryFormat": {"Exact"}},
+		},
+		{
+			name: "query with safe of store",
+			safe: "Linux",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "UserName=root;Address=db.example.com"},
+			want: "s3cr3t",
+		},
+		{
+			name: "non string property",
+			safe: "Linux",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "db-root", Property: "Port"},
+			want: "22",
+		},
+		{
+			name:    "missing property",
+			safe:    "Linux",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "db-root", Property: "Database"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing account",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "Linux/missing"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:     "app not authenticated",
+			appID:    "other",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "Linux/db-root"},
+			contains: "APPAP227E",
+		},
+		{
+			name:    "missing safe",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "db-root"},
+			wantErr: errMissingSafe,
+		},
+		{
+			name:    "invalid key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "Linux/db/root"},
+			wantErr: errInvalidKey,
+		},
+		{
+			name:    "missing key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{},
+			wantErr: errMissingKey,
+		},
+		{
+			name:    "version",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "Linux/db-root", Version: "1"},
+			wantErr: errVersionNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appID := tt.appID
+			if appID == "" {
+				appID = "eso"
+			}
+			last = nil
+			got, err := newTestClient(server, appID, tt.safe).GetSecret(context.Background(), tt.ref)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+			if tt.wantQuery != nil {
+				assert.Equal(t, tt.wantQuery, last)
+			}
+		})
+	}
+}
+
+func TestGetSecretUnexpectedStatus(t *testing.T) {
+	var last url.Values
+	server := newTestServer(t, &last)
+	defer server.Close()
+	c := newTestClient(server, "eso", "Linux")
+	c.api.(*httpCCPAPI).url = server.URL + "/Other"
+	_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db-root"})
+	assert.ErrorContains(t, err, "unexpected status code 404: not found")
+}
+
+func TestGetSecretMap(t *testing.T) {
+	var last url.Values
+	server := newTestServer(t, &last)
+	defer server.Close()
+	c := newTestClient(server, "eso", "Linux")
+
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db-root"})
+	require.NoError(t, err)
+	assert.Len(t, got, len(testAccount))
+	assert.Equal(t, "s3cr3t", string(got["Content"]))
+	assert.Equal(t, "root", string(got["UserName"]))
+	assert.Equal(t, "22", string(got["Port"]))
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db-root", Property: "UserName"})
+	assert.ErrorIs(t, err, errPropertyNotSupported)
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretError{})
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	c := &client{}
+	_, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+	assert.ErrorIs(t, err, errFindNotSupported)
+}
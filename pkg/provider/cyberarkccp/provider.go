@@ -0,0 +1,179 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cyberarkccp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errDecodeResponse = "unable to decode response: %w"
+	errCCP            = "ccp returned %s: %s"
+	errClientCert     = "unable to load client certificate: %w"
+
+	defaultServicePath = "AIMWebService"
+	requestTimeout     = 30 * time.Second
+)
+
+var (
+	errMissingStore         = errors.New("missing store specification")
+	errInvalidSpec          = errors.New("invalid specification for cyberarkccp provider")
+	errMissingURL           = errors.New("url must be set")
+	errInvalidURL           = errors.New("url must be an absolute https url")
+	errInvalidCABundle      = errors.New("caBundle does not contain a PEM encoded certificate")
+	errInvalidServicePath   = errors.New("servicePath must be a relative path, e.g. AIMWebService")
+	errMissingAppID         = errors.New("appID must be set")
+	errInvalidSafe          = errors.New("safe must not contain /")
+	errMissingSecretName    = errors.New("must specify a secret name")
+	errMissingSecretKey     = errors.New("must specify a secret key")
+	errMissingKey           = errors.New("key must be set to an account object or query")
+	errMissingSafe          = errors.New("key does not name a safe and the store has no safe, use safe/object")
+	errInvalidKey           = errors.New("key must be an account object, safe/object or a query")
+	errVersionNotSupported  = errors.New("specifying a version is not supported by CyberArk CCP")
+	errPropertyNotSupported = errors.New("property is not supported by dataFrom.extract")
+	errFindNotSupported     = errors.New("find is not supported by CyberArk CCP, the CCP can not list accounts")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(cfg.CABundle) > 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(cfg.CABundle)
+	}
+	if cfg.Auth != nil {
+		cert, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &cfg.Auth.ClientCertSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		key, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &cfg.Auth.ClientKeySecretRef)
+		if err != nil {
+			return nil, err
+		}
+		pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf(errClientCert, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+	servicePath := strings.Trim(cfg.ServicePath, "/")
+	if servicePath == "" {
+		servicePath = defaultServicePath
+	}
+	return &client{
+		api: &httpCCPAPI{
+			url:    strings.TrimSuffix(cfg.URL, "/") + "/" + servicePath,
+			appID:  cfg.AppID,
+			reason: cfg.Reason,
+			client: &http.Client{
+				Timeout: requestTimeout,
+				Transport: &http.Transport{
+					Proxy:           http.ProxyFromEnvironment,
+					TLSClientConfig: tlsConfig,
+				},
+			},
+		},
+		safe: cfg.Safe,
+	}, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.CyberArkCCPProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.CyberArkCCP == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.CyberArkCCP
+
+	if cfg.URL == "" {
+		return nil, errMissingURL
+	}
+	u, err := url.ParseRequestURI(cfg.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, errInvalidURL
+	}
+	if len(cfg.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(cfg.CABundle) {
+		return nil, errInvalidCABundle
+	}
+	if strings.HasPrefix(cfg.ServicePath, "/") || strings.Contains(cfg.ServicePath, "..") || strings.ContainsAny(cfg.ServicePath, "?#") {
+		return nil, errInvalidServicePath
+	}
+	if cfg.AppID == "" {
+		return nil, errMissingAppID
+	}
+	if strings.Contains(cfg.Safe, "/") {
+		return nil, errInvalidSafe
+	}
+	if cfg.Auth != nil {
+		for _, ref := range []esmeta.SecretKeySelector{cfg.Auth.ClientCertSecretRef, cfg.Auth.ClientKeySecretRef} {
+			if err := validateSecretRef(store, ref); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return cfg, nil
+}
+
+func validateSecretRef(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return err
+	}
+	if ref.Name == "" {
+		return errMissingSecretName
+	}
+	if ref.Key == "" {
+		return errMissingSecretKey
+	}
+	return nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		CyberArkCCP: &esv1beta1.CyberArkCCPProvider{},
+	})
+}
@@ -0,0 +1,215 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cyberarkccp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const testCA = `-----BEGIN CERTIFICATE-----
+MIIBhTCCASugAwIBAgIQIRi6zePL6mKjOipn+dNuaTAKBggqhkjOPQQDAjASMRAw
+DgYDVQQKEwdBY21lIENvMB4XDTE3MTAyMDE5NDMwNloXDTE4MTAyMDE5NDMwNlow
+EjEQMA4GA1UEChMHQWNtZSBDbzBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABD0d
+7VNhbWvZLWPuj/RtHFjvtJBEwOkhbN/BnnE8rnZR8+sbwnc/KhCk3FhnpHZnQz7B
+5aETbbIgmuvewdjvSBSjYzBhMA4GA1UdDwEB/wQEAwICpDATBgNVHSUEDDAKBggr
+BgEFBQcDATAPBgNVHRMBAf8EBTADAQH/MCkGA1UdEQQiMCCCDmxvY2FsaG9zdDo1
+NDUzgg4xMjcuMC4wLjE6NTQ1MzAKBggqhkjOPQQDAgNIADBFAiEA2zpJEPQyz6/l
+Wf86aX6PepsntZv2GYlA5UpabfT2EZICICpJ5h/iI+i341gBmLiAFQOyTDT+/wQc
+6MF9+Yw1Yy0t
+-----END CERTIFICATE-----`
+
+func newStore(provider *esv1beta1.CyberArkCCPProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "ccp", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{CyberArkCCP: provider},
+		},
+	}
+}
+
+func certAuth(name, certKey, keyKey string) *esv1beta1.CyberArkCCPAuth {
+	return &esv1beta1.CyberArkCCPAuth{
+		ClientCertSecretRef: esmeta.SecretKeySelector{Name: name, Key: certKey},
+		ClientKeySecretRef:  esmeta.SecretKeySelector{Name: name, Key: keyKey},
+	}
+}
+
+// newClientCert returns a PEM encoded self signed client certificate and
+// its private key.
+func newClientCert(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "external-secrets"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "missing url",
+			store:   newStore(&esv1beta1.CyberArkCCPProvider{AppID: "eso"}),
+			wantErr: errMissingURL,
+		},
+		{
+			name:    "http url",
+			store:   newStore(&esv1beta1.CyberArkCCPProvider{URL: "http://ccp.example.com", AppID: "eso"}),
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "invalid ca bundle",
+			store:   newStore(&esv1beta1.CyberArkCCPProvider{URL: "https://ccp.example.com", AppID: "eso", CABundle: []byte("ca")}),
+			wantErr: errInvalidCABundle,
+		},
+		{
+			name:    "invalid service path",
+			store:   newStore(&esv1beta1.CyberArkCCPProvider{URL: "https://ccp.example.com", AppID: "eso", ServicePath: "../AIMWebService"}),
+			wantErr: errInvalidServicePath,
+		},
+		{
+			name:    "missing app id",
+			store:   newStore(&esv1beta1.CyberArkCCPProvider{URL: "https://ccp.example.com"}),
+			wantErr: errMissingAppID,
+		},
+		{
+			name:    "invalid safe",
+			store:   newStore(&esv1beta1.CyberArkCCPProvider{URL: "https://ccp.example.com", AppID: "eso", Safe: "Linux/Prod"}),
+			wantErr: errInvalidSafe,
+		},
+		{
+			name:    "missing secret name",
+			store:   newStore(&esv1beta1.CyberArkCCPProvider{URL: "https://ccp.example.com", AppID: "eso", Auth: certAuth("", "tls.crt", "tls.key")}),
+			wantErr: errMissingSecretName,
+		},
+		{
+			name:    "missing secret key",
+			store:   newStore(&esv1beta1.CyberArkCCPProvider{URL: "https://ccp.example.com", AppID: "eso", Auth: certAuth("ccp", "tls.crt", "")}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name:  "valid without auth",
+			store: newStore(&esv1beta1.CyberArkCCPProvider{URL: "https://ccp.example.com", AppID: "eso"}),
+		},
+		{
+			name: "valid",
+			store: newStore(&esv1beta1.CyberArkCCPProvider{
+				URL:         "https://ccp.example.com",
+				ServicePath: "AIMWebServiceProd",
+				AppID:       "eso",
+				Safe:        "Linux",
+				CABundle:    []byte(testCA),
+				Auth:        certAuth("ccp", "tls.crt", "tls.key"),
+			}),
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	certPEM, keyPEM := newClientCert(t)
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ccp", Namespace: "default"},
+		Data: map[string][]byte{
+			"tls.crt": certPEM,
+			"tls.key": keyPEM,
+			"invalid": []byte("invalid"),
+		},
+	}).Build()
+	p := &Provider{}
+	store := newStore(&esv1beta1.CyberArkCCPProvider{
+		URL:      "https://ccp.example.com/",
+		AppID:    "eso",
+		Safe:     "Linux",
+		Reason:   "sync",
+		CABundle: []byte(testCA),
+		Auth:     certAuth("ccp", "tls.crt", "tls.key"),
+	})
+	sc, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	c := sc.(*client)
+	assert.Equal(t, "Linux", c.safe)
+	api := c.api.(*httpCCPAPI)
+	assert.Equal(t, "https://ccp.example.com/AIMWebService", api.url)
+	assert.Equal(t, "eso", api.appID)
+	assert.Equal(t, "sync", api.reason)
+	tlsConfig := api.client.Transport.(*http.Transport).TLSClientConfig
+	assert.NotNil(t, tlsConfig.RootCAs)
+	assert.Len(t, tlsConfig.Certificates, 1)
+
+	store.Spec.Provider.CyberArkCCP.ServicePath = "AIMWebServiceProd/"
+	sc, err = p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "https://ccp.example.com/AIMWebServiceProd", sc.(*client).api.(*httpCCPAPI).url)
+
+	store.Spec.Provider.CyberArkCCP.Auth = certAuth("ccp", "tls.crt", "invalid")
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.ErrorContains(t, err, "unable to load client certificate")
+
+	store.Spec.Provider.CyberArkCCP.Auth = certAuth("ccp", "missing", "tls.key")
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package habitat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// originSecret is an origin secret as returned by Builder. The value is
+// encrypted with the encryption key of the origin.
+type originSecret struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// builderAPI is the subset of the Habitat Builder API used by the provider.
+// See https://www.habitat.sh/docs/api/builder-api/ for the full API documentation.
+type builderAPI interface {
+	// ListSecrets returns all secrets of the origin.
+	ListSecrets(ctx context.Context) ([]originSecret, error)
+	// CreateSecret creates a secret with an encrypted value.
+	CreateSecret(ctx context.Context, secret originSecret) error
+	// DeleteSecret deletes the secret with the given name.
+	DeleteSecret(ctx context.Context, name string) error
+	// GetEncryptionKey returns the public encryption key of the origin.
+	GetEncryptionKey(ctx context.Context) (string, error)
+}
+
+type httpBuilderAPI struct {
+	url    string
+	origin string
+	token  string
+	client *http.Client
+}
+
+var _ builderAPI = &httpBuilderAPI{}
+
+func (a *httpBuilderAPI) ListSecrets(ctx context.Context) ([]originSecret, error) {
+	var secrets []originSecret
+	data, err := a.do(ctx, http.MethodGet, "/secret", nil)
+	if err == nil {
+		err = decode(data, &secrets)
+	}
+	metrics.ObserveAPICall(constants.ProviderHabitat, constants.CallHabitatListSecrets, err)
+	return secrets, err
+}
+
+func (a *httpBuilderAPI) CreateSecret(ctx context.Context, secret originSecret) error {
+	_, err := a.do(ctx, http.MethodPost, "/secret", secret)
+	metrics.ObserveAPICall(constants.ProviderHabitat, constants.CallHabitatCreateSecret, err)
+	return err
+}
+
+func (a *httpBuilderAPI) DeleteSecret(ctx context.Context, name string) error {
+	_, err := a.do(ctx, http.MethodDelete, "/secret/"+url.PathEscape(name), nil)
+	metrics.ObserveAPICall(constants.ProviderHabitat, constants.CallHabitatDeleteSecret, err)
+	return err
+}
+
+func (a *httpBuilderAPI) GetEncryptionKey(ctx context.Context) (string, error) {
+	data, err := a.do(ctx, http.MethodGet, "/encryption_key", nil)
+	metrics.ObserveAPICall(constants.ProviderHabitat, constants.CallHabitatGetEncryptionKey, err)
+	return string(data), err
+}
+
+// do sends a request to path below the origin and returns the response body.
+func (a *httpBuilderAPI) do(ctx context.Context, method, path string, in any) ([]byte, error) {
+	var body io.Reader = http.NoBody
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	u := a.url + "/v1/depot/origins/" + url.PathEscape(a.origin) + path
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return utils.DoHTTP(a.client, req, http.StatusNotFound)
+}
+
+func decode(data []byte, out any) error {
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf(errDecodeResponse, err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package habitat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+type client struct {
+	api builderAPI
+	// encryptionKey is the private encryption key of the origin. Without
+	// it secrets can only be pushed.
+	encryptionKey *boxKey
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the decrypted value of the origin secret named key.
+// With property the value is parsed as JSON and only the value of the
+// field is returned, nested fields are selected with a gjson expression.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if ref.Version != "" {
+		return nil, errVersionNotSupported
+	}
+	secrets, err := c.listSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secret, ok := secrets[ref.Key]
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	value, err := decrypt(c.encryptionKey, secret.Value)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptSecret, ref.Key, err)
+	}
+	if ref.Property == "" {
+		return value, nil
+	}
+	if !gjson.ValidBytes(value) {
+		return nil, errValueNotJSON
+	}
+	val := gjson.GetBytes(value, ref.Property)
+	if !val.Exists() {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap returns the fields of a JSON value, or of the object
+// selected by property, as map.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	value, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(value, &fields); err != nil {
+		return nil, errNotAnObject
+	}
+	secretMap := make(map[string][]byte, len(fields))
+	for k := range fields {
+		secretMap[k], err = utils.GetByteValueFromMap(fields, k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secretMap, nil
+}
+
+// GetAllSecrets returns the decrypted values of all origin secrets whose
+// name matches ref.Name.
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Name == nil || len(ref.Tags) > 0 || ref.Path != nil {
+		return nil, errFindNotSupported
+	}
+	matcher, err := find.New(*ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := c.listSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string][]byte)
+	for name, secret := range secrets {
+		if !matcher.MatchName(name) {
+			continue
+		}
+		data[name], err = decrypt(c.encryptionKey, secret.Value)
+		if err != nil {
+			return nil, fmt.Errorf(errDecryptSecret, name, err)
+		}
+	}
+	return data, nil
+}
+
+// PushSecret encrypts the value with the public encryption key of the
+// origin and uploads it as origin secret, replacing an existing secret.
+func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	if data.GetSecretKey() == "" {
+		return errPushWholeSecret
+	}
+	if data.GetProperty() != "" {
+		return errPushProperty
+	}
+	value := secret.Data[data.GetSecretKey()]
+	name := data.GetRemoteKey()
+
+	secrets, err := c.api.ListSecrets(ctx)
+	if err != nil {
+		return err
+	}
+	exists := false
+	for _, s := range secrets {
+		if s.Name != name {
+			continue
+		}
+		exists = true
+		// the value can only be compared if the secret key is available
+		if c.encryptionKey != nil {
+			if current, err := decrypt(c.encryptionKey, s.Value); err == nil && bytes.Equal(current, value) {
+				return nil
+			}
+		}
+	}
+
+	rawKey, err := c.api.GetEncryptionKey(ctx)
+	if err != nil {
+		return err
+	}
+	pub, err := parseBoxKey(rawKey, boxPublicKeyVersion)
+	if err != nil {
+		return err
+	}
+	encrypted, err := encrypt(pub, value)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err := c.api.DeleteSecret(ctx, name); err != nil && !errors.Is(err, esv1beta1.NoSecretError{}) {
+			return err
+		}
+	}
+	return c.api.CreateSecret(ctx, originSecret{Name: name, Value: encrypted})
+}
+
+// DeleteSecret deletes the origin secret, a missing secret is ignored.
+func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	err := c.api.DeleteSecret(ctx, remoteRef.GetRemoteKey())
+	if errors.Is(err, esv1beta1.NoSecretError{}) {
+		return nil
+	}
+	return err
+}
+
+// Validate checks that the token grants access to the secrets of the origin.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if _, err := c.api.ListSecrets(ctx); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(context.Context) error {
+	return nil
+}
+
+// listSecrets returns the secrets of the origin by name.
+func (c *client) listSecrets(ctx context.Context) (map[string]originSecret, error) {
+	if c.encryptionKey == nil {
+		return nil, errMissingEncryptionKey
+	}
+	secrets, err := c.api.ListSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]originSecret, len(secrets))
+	for _, s := range secrets {
+		byName[s.Name] = s
+	}
+	return byName, nil
+}
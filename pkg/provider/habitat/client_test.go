@@ -0,0 +1,282 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package habitat
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	testToken   = "token"
+	testKeyName = "acme-20240101000000"
+)
+
+func formatKey(version string, key *[32]byte) string {
+	return version + "\n" + testKeyName + "\n\n" + base64.StdEncoding.EncodeToString(key[:])
+}
+
+// fakeBuilder stores the secrets of the origin acme.
+type fakeBuilder struct {
+	mu      sync.Mutex
+	pub     string
+	secrets map[string]string
+	created int
+}
+
+func (b *fakeBuilder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	path, ok := strings.CutPrefix(r.URL.Path, "/v1/depot/origins/acme")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && path == "/encryption_key":
+		_, _ = w.Write([]byte(b.pub))
+	case r.Method == http.MethodGet && path == "/secret":
+		secrets := []originSecret{}
+		for name, value := range b.secrets {
+			secrets = append(secrets, originSecret{Name: name, Value: value})
+		}
+		_ = json.NewEncoder(w).Encode(secrets)
+	case r.Method == http.MethodPost && path == "/secret":
+		var secret originSecret
+		if err := json.NewDecoder(r.Body).Decode(&secret); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if _, ok := b.secrets[secret.Name]; ok {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		b.secrets[secret.Name] = secret.Value
+		b.created++
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/secret/"):
+		name := strings.TrimPrefix(path, "/secret/")
+		if _, ok := b.secrets[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(b.secrets, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T) (*client, *fakeBuilder) {
+	t.Helper()
+	pub, sec, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pubKey, err := parseBoxKey(formatKey(boxPublicKeyVersion, pub), boxPublicKeyVersion)
+	require.NoError(t, err)
+	secKey, err := parseBoxKey(formatKey(boxSecretKeyVersion, sec), boxSecretKeyVersion)
+	require.NoError(t, err)
+
+	b := &fakeBuilder{pub: formatKey(boxPublicKeyVersion, pub), secrets: map[string]string{}}
+	for name, value := range map[string]string{
+		"db_password": "s3cr3t",
+		"db_config":   `{"user":"app","password":"s3cr3t"}`,
+		"api_token":   "t0ken",
+	} {
+		b.secrets[name], err = encrypt(pubKey, []byte(value))
+		require.NoError(t, err)
+	}
+	b.secrets["foreign"] = "ANONYMOUS-BOX-1\nother-20240101000000\nAAAA"
+	server := httptest.NewServer(b)
+	t.Cleanup(server.Close)
+	return &client{
+		api: &httpBuilderAPI{
+			url:    server.URL,
+			origin: "acme",
+			token:  testToken,
+			client: server.Client(),
+		},
+		encryptionKey: secKey,
+	}, b
+}
+
+func TestGetSecret(t *testing.T) {
+	c, _ := newTestClient(t)
+	tests := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		want     string
+		wantErr  error
+		contains string
+	}{
+		{
+			name: "value",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "db_password"},
+			want: "s3cr3t",
+		},
+		{
+			name: "json property",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "db_config", Property: "user"},
+			want: "app",
+		},
+		{
+			name:    "property of non json value",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "db_password", Property: "user"},
+			wantErr: errValueNotJSON,
+		},
+		{
+			name:    "missing secret",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "other"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:     "encrypted for another key",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "foreign"},
+			contains: "is encrypted for key other-20240101000000",
+		},
+		{
+			name:    "version",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "db_password", Version: "1"},
+			wantErr: errVersionNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+
+	c.encryptionKey = nil
+	_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db_password"})
+	assert.ErrorIs(t, err, errMissingEncryptionKey)
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c, _ := newTestClient(t)
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db_config"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"user": []byte("app"), "password": []byte("s3cr3t")}, got)
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	c, _ := newTestClient(t)
+	got, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^db_"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"db_password": []byte("s3cr3t"),
+		"db_config":   []byte(`{"user":"app","password":"s3cr3t"}`),
+	}, got)
+
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}})
+	assert.ErrorIs(t, err, errFindNotSupported)
+}
+
+func TestPushSecret(t *testing.T) {
+	c, b := newTestClient(t)
+	secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("n3w")}}
+	data := func(remoteKey string) v1alpha1.PushSecretData {
+		return v1alpha1.PushSecretData{
+			Match: v1alpha1.PushSecretMatch{
+				SecretKey: "password",
+				RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: remoteKey},
+			},
+		}
+	}
+
+	// create
+	require.NoError(t, c.PushSecret(context.Background(), secret, data("new_password")))
+	got, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "new_password"})
+	require.NoError(t, err)
+	assert.Equal(t, "n3w", string(got))
+	assert.Equal(t, 1, b.created)
+
+	// unchanged values are not uploaded again
+	require.NoError(t, c.PushSecret(context.Background(), secret, data("new_password")))
+	assert.Equal(t, 1, b.created)
+
+	// replace
+	require.NoError(t, c.PushSecret(context.Background(), secret, data("db_password")))
+	got, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db_password"})
+	require.NoError(t, err)
+	assert.Equal(t, "n3w", string(got))
+
+	// pushing works without the secret encryption key
+	c.encryptionKey = nil
+	require.NoError(t, c.PushSecret(context.Background(), secret, data("api_token")))
+	assert.Equal(t, 3, b.created)
+
+	err = c.PushSecret(context.Background(), secret, v1alpha1.PushSecretData{Match: v1alpha1.PushSecretMatch{
+		RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "all"},
+	}})
+	assert.ErrorIs(t, err, errPushWholeSecret)
+}
+
+func TestDeleteSecret(t *testing.T) {
+	c, b := newTestClient(t)
+	require.NoError(t, c.DeleteSecret(context.Background(), v1alpha1.PushSecretRemoteRef{RemoteKey: "db_password"}))
+	assert.NotContains(t, b.secrets, "db_password")
+	require.NoError(t, c.DeleteSecret(context.Background(), v1alpha1.PushSecretRemoteRef{RemoteKey: "db_password"}))
+}
+
+func TestValidate(t *testing.T) {
+	c, _ := newTestClient(t)
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	c.api.(*httpBuilderAPI).token = "other"
+	result, err = c.Validate()
+	assert.ErrorContains(t, err, "unexpected status code 401")
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+}
+
+func TestParseBoxKey(t *testing.T) {
+	pub, _, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := parseBoxKey(formatKey(boxPublicKeyVersion, pub)+"\r\n", boxPublicKeyVersion)
+	require.NoError(t, err)
+	assert.Equal(t, testKeyName, key.name)
+	assert.Equal(t, *pub, key.key)
+
+	_, err = parseBoxKey(formatKey(boxPublicKeyVersion, pub), boxSecretKeyVersion)
+	assert.ErrorContains(t, err, "expected a BOX-SEC-1 key")
+	_, err = parseBoxKey("BOX-SEC-1\n"+testKeyName+"\n\nAAAA", boxSecretKeyVersion)
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package habitat
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+const (
+	boxSecretKeyVersion = "BOX-SEC-1"
+	boxPublicKeyVersion = "BOX-PUB-1"
+	anonymousBoxVersion = "ANONYMOUS-BOX-1"
+)
+
+// boxKey is a Habitat encryption key, either the public or the secret half.
+type boxKey struct {
+	// name is the name of the key including its revision, e.g. core-20160810182414.
+	name string
+	key  [32]byte
+}
+
+// parseBoxKey parses a key in the Habitat key file format:
+//
+//	<version>
+//	<name with revision>
+//
+//	<base64 encoded key>
+func parseBoxKey(data, version string) (*boxKey, error) {
+	lines := strings.Split(strings.ReplaceAll(strings.TrimSpace(data), "\r\n", "\n"), "\n")
+	if len(lines) != 4 || lines[0] != version || lines[1] == "" || lines[2] != "" {
+		return nil, fmt.Errorf(errInvalidKeyFormat, version)
+	}
+	raw, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(raw) != 32 {
+		return nil, fmt.Errorf(errInvalidKeyFormat, version)
+	}
+	k := &boxKey{name: lines[1]}
+	copy(k.key[:], raw)
+	return k, nil
+}
+
+// publicKey derives the public half of a secret key.
+func (k *boxKey) publicKey() (*boxKey, error) {
+	pub, err := curve25519.X25519(k.key[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	p := &boxKey{name: k.name}
+	copy(p.key[:], pub)
+	return p, nil
+}
+
+// encrypt seals value for the public key pub like hab origin secret upload does.
+func encrypt(pub *boxKey, value []byte) (string, error) {
+	sealed, err := box.SealAnonymous(nil, value, &pub.key, rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join([]string{anonymousBoxVersion, pub.name, base64.StdEncoding.EncodeToString(sealed)}, "\n"), nil
+}
+
+// decrypt opens a value that was sealed for the public half of sec.
+func decrypt(sec *boxKey, value string) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(value), "\n")
+	if len(lines) == 0 || lines[0] != anonymousBoxVersion {
+		return nil, fmt.Errorf(errUnsupportedEncryption, lines[0])
+	}
+	if len(lines) != 3 {
+		return nil, errInvalidEncryptedValue
+	}
+	if lines[1] != sec.name {
+		return nil, fmt.Errorf(errKeyMismatch, lines[1], sec.name)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(lines[2])
+	if err != nil {
+		return nil, errInvalidEncryptedValue
+	}
+	pub, err := sec.publicKey()
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := box.OpenAnonymous(nil, sealed, &pub.key, &sec.key)
+	if !ok {
+		return nil, errInvalidEncryptedValue
+	}
+	return msg, nil
+}
@@ -0,0 +1,162 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package habitat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errDecodeResponse        = "unable to decode response: %w"
+	errInvalidKeyFormat      = "invalid key, expected a %s key"
+	errUnsupportedEncryption = "unsupported encryption %q, only values encrypted with hab origin secret upload can be decrypted"
+	errKeyMismatch           = "value is encrypted for key %s, but the encryption key is %s"
+	errDecryptSecret         = "unable to decrypt secret %s: %w"
+
+	defaultURL = "https://bldr.habitat.sh"
+
+	requestTimeout  = 30 * time.Second
+	validateTimeout = 10 * time.Second
+)
+
+var (
+	errMissingStore          = errors.New("missing store specification")
+	errInvalidSpec           = errors.New("invalid specification for habitat provider")
+	errInvalidURL            = errors.New("url must be an absolute http or https url")
+	errMissingOrigin         = errors.New("origin must be set")
+	errMissingSecretName     = errors.New("must specify a secret name")
+	errMissingSecretKey      = errors.New("must specify a secret key")
+	errMissingEncryptionKey  = errors.New("reading origin secrets requires auth.encryptionKeySecretRef")
+	errInvalidEncryptedValue = errors.New("invalid encrypted value")
+	errVersionNotSupported   = errors.New("specifying a version is not supported")
+	errFindNotSupported      = errors.New("only find by name is supported")
+	errValueNotJSON          = errors.New("value is not valid json, omit the property to sync the raw value")
+	errNotAnObject           = errors.New("value is not a json object")
+	errPushWholeSecret       = errors.New("pushing the whole secret is not supported, set secretKey")
+	errPushProperty          = errors.New("pushing a property is not supported")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadWrite
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	token, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &cfg.Auth.TokenSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	c := &client{
+		api: &httpBuilderAPI{
+			url:    builderURL(cfg),
+			origin: cfg.Origin,
+			token:  token,
+			client: &http.Client{Timeout: requestTimeout},
+		},
+	}
+	if cfg.Auth.EncryptionKeySecretRef != nil {
+		rawKey, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, cfg.Auth.EncryptionKeySecretRef)
+		if err != nil {
+			return nil, err
+		}
+		c.encryptionKey, err = parseBoxKey(rawKey, boxSecretKeyVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func builderURL(cfg *esv1beta1.HabitatProvider) string {
+	if cfg.URL == "" {
+		return defaultURL
+	}
+	return strings.TrimSuffix(cfg.URL, "/")
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.HabitatProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Habitat == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.Habitat
+
+	if cfg.URL != "" {
+		u, err := url.ParseRequestURI(cfg.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errInvalidURL
+		}
+	}
+	if cfg.Origin == "" {
+		return nil, errMissingOrigin
+	}
+
+	refs := []esmeta.SecretKeySelector{cfg.Auth.TokenSecretRef}
+	if cfg.Auth.EncryptionKeySecretRef != nil {
+		refs = append(refs, *cfg.Auth.EncryptionKeySecretRef)
+	}
+	for _, ref := range refs {
+		if err := validateSecretRef(store, ref); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func validateSecretRef(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return err
+	}
+	if ref.Name == "" {
+		return errMissingSecretName
+	}
+	if ref.Key == "" {
+		return errMissingSecretKey
+	}
+	return nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Habitat: &esv1beta1.HabitatProvider{},
+	})
+}
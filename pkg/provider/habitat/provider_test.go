@@ -0,0 +1,132 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package habitat
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func newStore(provider *esv1beta1.HabitatProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "habitat", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Habitat: provider},
+		},
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	token := esmeta.SecretKeySelector{Name: "habitat", Key: "token"}
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "invalid url",
+			store:   newStore(&esv1beta1.HabitatProvider{URL: "bldr", Origin: "acme", Auth: esv1beta1.HabitatAuth{TokenSecretRef: token}}),
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "missing origin",
+			store:   newStore(&esv1beta1.HabitatProvider{Auth: esv1beta1.HabitatAuth{TokenSecretRef: token}}),
+			wantErr: errMissingOrigin,
+		},
+		{
+			name:    "missing token",
+			store:   newStore(&esv1beta1.HabitatProvider{Origin: "acme"}),
+			wantErr: errMissingSecretName,
+		},
+		{
+			name: "missing encryption key secret key",
+			store: newStore(&esv1beta1.HabitatProvider{Origin: "acme", Auth: esv1beta1.HabitatAuth{
+				TokenSecretRef:         token,
+				EncryptionKeySecretRef: &esmeta.SecretKeySelector{Name: "habitat"},
+			}}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name:  "push only",
+			store: newStore(&esv1beta1.HabitatProvider{Origin: "acme", Auth: esv1beta1.HabitatAuth{TokenSecretRef: token}}),
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	_, sec, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "habitat", Namespace: "default"},
+		Data: map[string][]byte{
+			"token":   []byte("t0ken"),
+			"box-key": []byte(formatKey(boxSecretKeyVersion, sec)),
+			"invalid": []byte("key"),
+		},
+	}).Build()
+	p := &Provider{}
+	store := newStore(&esv1beta1.HabitatProvider{Origin: "acme", Auth: esv1beta1.HabitatAuth{
+		TokenSecretRef: esmeta.SecretKeySelector{Name: "habitat", Key: "token"},
+	}})
+	sc, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	c := sc.(*client)
+	assert.Equal(t, defaultURL, c.api.(*httpBuilderAPI).url)
+	assert.Equal(t, "t0ken", c.api.(*httpBuilderAPI).token)
+	assert.Nil(t, c.encryptionKey)
+
+	store.Spec.Provider.Habitat.URL = "https://builder.example.com/"
+	store.Spec.Provider.Habitat.Auth.EncryptionKeySecretRef = &esmeta.SecretKeySelector{Name: "habitat", Key: "box-key"}
+	sc, err = p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	c = sc.(*client)
+	assert.Equal(t, "https://builder.example.com", c.api.(*httpBuilderAPI).url)
+	require.NotNil(t, c.encryptionKey)
+	assert.Equal(t, *sec, c.encryptionKey.key)
+
+	store.Spec.Provider.Habitat.Auth.EncryptionKeySecretRef.Key = "invalid"
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.ErrorContains(t, err, "expected a BOX-SEC-1 key")
+}
@@ -0,0 +1,190 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jenkins
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+)
+
+type client struct {
+	api jenkinsAPI
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the credential with the id key. With property a single
+// field is returned, e.g. username, password or privateKey. Without property
+// secret text and secret file credentials return the secret and the file
+// content, other types return their fields as JSON.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	cred, err := c.getCredential(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return cred.value()
+	}
+	value, ok := cred.fields()[ref.Property]
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return value, nil
+}
+
+// GetSecretMap returns the fields of the credential with the id key.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Property != "" {
+		return nil, errPropertyNotSupported
+	}
+	cred, err := c.getCredential(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return cred.fields(), nil
+}
+
+// GetAllSecrets returns the credentials whose ids match, the values are the
+// same as GetSecret returns without property.
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 {
+		return nil, errFindTagsNotSupported
+	}
+	if ref.Path != nil {
+		return nil, errFindPathNotSupported
+	}
+	var (
+		matcher *find.Matcher
+		err     error
+	)
+	if ref.Name != nil {
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	creds, err := c.api.Credentials(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	secrets := make(map[string][]byte)
+	for _, cred := range creds {
+		if _, ok := secrets[cred.ID]; ok {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(cred.ID) {
+			continue
+		}
+		secrets[cred.ID], err = cred.value()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secrets, nil
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by Jenkins")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by Jenkins")
+}
+
+// Validate checks that the API token is accepted by Jenkins.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if err := c.api.WhoAmI(ctx); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(context.Context) error {
+	return nil
+}
+
+// getCredential returns the credential with the id key. If a folder
+// overrides the id of a parent folder, the first credential returned by
+// Jenkins is used.
+func (c *client) getCredential(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (*credential, error) {
+	if ref.Version != "" {
+		return nil, errVersionNotSupported
+	}
+	if ref.Key == "" {
+		return nil, errMissingKey
+	}
+	creds, err := c.api.Credentials(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	for i := range creds {
+		if creds[i].ID == ref.Key {
+			return &creds[i], nil
+		}
+	}
+	return nil, esv1beta1.NoSecretError{}
+}
+
+// fields returns the non empty fields of the credential.
+func (c *credential) fields() map[string][]byte {
+	fields := make(map[string][]byte)
+	for k, v := range map[string]string{
+		"username":   c.Username,
+		"password":   c.Password,
+		"secret":     c.Secret,
+		"privateKey": c.PrivateKey,
+		"passphrase": c.Passphrase,
+		"fileName":   c.FileName,
+	} {
+		if v != "" {
+			fields[k] = []byte(v)
+		}
+	}
+	if c.Content != nil {
+		fields["content"] = c.Content
+	}
+	if c.KeyStore != nil {
+		fields["keyStore"] = c.KeyStore
+	}
+	return fields
+}
+
+// value returns the secret of secret text credentials, the content of
+// secret file credentials, and the fields of other types as JSON. Binary
+// fields are base64 encoded in JSON.
+func (c *credential) value() ([]byte, error) {
+	switch {
+	case c.Content != nil:
+		return c.Content, nil
+	case c.Secret != "":
+		return []byte(c.Secret), nil
+	}
+	doc := make(map[string]string)
+	for k, v := range c.fields() {
+		if k == "keyStore" {
+			doc[k] = base64.StdEncoding.EncodeToString(v)
+			continue
+		}
+		doc[k] = string(v)
+	}
+	return json.Marshal(doc)
+}
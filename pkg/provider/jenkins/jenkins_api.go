@@ -0,0 +1,179 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jenkins
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// credentialsScript is run in the script console to read credentials, the
+// REST API of Jenkins never returns secret values. Parameters are passed
+// base64 encoded, so they can not change the script.
+const credentialsScript = `import com.cloudbees.plugins.credentials.CredentialsProvider
+import com.cloudbees.plugins.credentials.common.StandardCredentials
+import groovy.json.JsonOutput
+import groovy.json.JsonSlurper
+import hudson.model.ItemGroup
+import hudson.security.ACL
+import jenkins.model.Jenkins
+
+def params = new JsonSlurper().parseText(new String('@PARAMS@'.decodeBase64(), 'UTF-8'))
+def context = Jenkins.get()
+if (params.folder) {
+  context = Jenkins.get().getItemByFullName(params.folder)
+  if (!(context instanceof ItemGroup)) {
+    print JsonOutput.toJson([error: 'folder ' + params.folder + ' not found'])
+    return
+  }
+}
+def has = { c, name -> c.metaClass.respondsTo(c, name) }
+def plain = { s -> s?.plainText }
+def found = CredentialsProvider.lookupCredentials(StandardCredentials, (ItemGroup) context, ACL.SYSTEM, []).findAll {
+  !params.id || it.id == params.id
+}.collect { c ->
+  def m = [id: c.id, type: c.class.simpleName, description: c.description]
+  if (has(c, 'getUsername')) m.username = c.username
+  if (has(c, 'getPassword')) m.password = plain(c.password)
+  if (has(c, 'getSecret')) m.secret = plain(c.secret)
+  if (has(c, 'getPrivateKeys')) m.privateKey = c.privateKeys.join('\n')
+  if (has(c, 'getPassphrase')) m.passphrase = plain(c.passphrase)
+  if (has(c, 'getFileName')) m.fileName = c.fileName
+  if (has(c, 'getContent')) m.content = c.content.bytes.encodeBase64().toString()
+  if (has(c, 'getKeyStoreSource')) m.keyStore = c.keyStoreSource.keyStoreBytes.encodeBase64().toString()
+  m
+}
+print JsonOutput.toJson([credentials: found])
+`
+
+type scriptParams struct {
+	Folder string `json:"folder,omitempty"`
+	ID     string `json:"id,omitempty"`
+}
+
+type scriptOutput struct {
+	Credentials []credential `json:"credentials"`
+	Error       string       `json:"error"`
+}
+
+// credential holds the fields of the common credential types, fields that
+// do not apply to the type are empty.
+type credential struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	Secret      string `json:"secret"`
+	PrivateKey  string `json:"privateKey"`
+	Passphrase  string `json:"passphrase"`
+	FileName    string `json:"fileName"`
+	Content     []byte `json:"content"`
+	KeyStore    []byte `json:"keyStore"`
+}
+
+type whoAmIResponse struct {
+	Name      string `json:"name"`
+	Anonymous bool   `json:"anonymous"`
+}
+
+// jenkinsAPI is the subset of Jenkins used by the provider.
+type jenkinsAPI interface {
+	// Credentials returns the credentials visible in the folder of the
+	// store, only those with id if it is not empty.
+	Credentials(ctx context.Context, id string) ([]credential, error)
+	// WhoAmI returns an error if the user is not authenticated.
+	WhoAmI(ctx context.Context) error
+}
+
+type httpJenkinsAPI struct {
+	url      string
+	folder   string
+	username string
+	token    string
+	client   *http.Client
+}
+
+var _ jenkinsAPI = &httpJenkinsAPI{}
+
+func (a *httpJenkinsAPI) Credentials(ctx context.Context, id string) ([]credential, error) {
+	creds, err := a.credentials(ctx, id)
+	metrics.ObserveAPICall(constants.ProviderJenkins, constants.CallJenkinsScriptText, err)
+	return creds, err
+}
+
+func (a *httpJenkinsAPI) credentials(ctx context.Context, id string) ([]credential, error) {
+	params, err := json.Marshal(scriptParams{Folder: a.folder, ID: id})
+	if err != nil {
+		return nil, err
+	}
+	script := strings.Replace(credentialsScript, "@PARAMS@", base64.StdEncoding.EncodeToString(params), 1)
+	form := url.Values{"script": {script}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url+"/scriptText", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	data, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+	var out scriptOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		// failing scripts print the stack trace
+		return nil, fmt.Errorf(errScriptFailed, utils.TruncateErrorBody(data))
+	}
+	if out.Error != "" {
+		return nil, fmt.Errorf(errScriptFailed, out.Error)
+	}
+	return out.Credentials, nil
+}
+
+func (a *httpJenkinsAPI) WhoAmI(ctx context.Context) error {
+	err := a.whoAmI(ctx)
+	metrics.ObserveAPICall(constants.ProviderJenkins, constants.CallJenkinsWhoAmI, err)
+	return err
+}
+
+func (a *httpJenkinsAPI) whoAmI(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.url+"/whoAmI/api/json", http.NoBody)
+	if err != nil {
+		return err
+	}
+	data, err := a.do(req)
+	if err != nil {
+		return err
+	}
+	var resp whoAmIResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf(errDecodeResponse, err)
+	}
+	if resp.Anonymous {
+		return errAnonymous
+	}
+	return nil
+}
+
+func (a *httpJenkinsAPI) do(req *http.Request) ([]byte, error) {
+	req.SetBasicAuth(a.username, a.token)
+	return utils.DoHTTP(a.client, req)
+}
@@ -0,0 +1,156 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jenkins
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/certpin"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errDecodeResponse = "unable to decode response: %w"
+	errScriptFailed   = "unable to read credentials: %s"
+
+	requestTimeout  = 30 * time.Second
+	validateTimeout = 10 * time.Second
+)
+
+var (
+	errMissingStore         = errors.New("missing store specification")
+	errInvalidSpec          = errors.New("invalid specification for jenkins provider")
+	errMissingURL           = errors.New("url must be set")
+	errInvalidURL           = errors.New("url must be an absolute http or https url")
+	errInvalidCABundle      = errors.New("caBundle does not contain a PEM encoded certificate")
+	errInvalidFolder        = errors.New("folder must be the full name of a folder, e.g. team-a/builds")
+	errMissingUsername      = errors.New("username must be set")
+	errMissingSecretName    = errors.New("must specify a secret name")
+	errMissingSecretKey     = errors.New("must specify a secret key")
+	errAnonymous            = errors.New("jenkins did not authenticate the user")
+	errMissingKey           = errors.New("key must be set to a credential id")
+	errVersionNotSupported  = errors.New("specifying a version is not supported by jenkins")
+	errPropertyNotSupported = errors.New("property is not supported by dataFrom.extract")
+	errFindTagsNotSupported = errors.New("find by tags is not supported by jenkins")
+	errFindPathNotSupported = errors.New("find by path is not supported by jenkins, set the folder of the store")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	token, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &cfg.Auth.APITokenSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{Timeout: requestTimeout}
+	if len(cfg.CABundle) > 0 || len(cfg.CertificatePins) > 0 {
+		tlsConfig := &tls.Config{
+			MinVersion:       tls.VersionTLS12,
+			VerifyConnection: certpin.VerifyConnection(cfg.CertificatePins),
+		}
+		if len(cfg.CABundle) > 0 {
+			tlsConfig.RootCAs = x509.NewCertPool()
+			tlsConfig.RootCAs.AppendCertsFromPEM(cfg.CABundle)
+		}
+		httpClient.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
+	return &client{
+		api: &httpJenkinsAPI{
+			url:      strings.TrimSuffix(cfg.URL, "/"),
+			folder:   cfg.Folder,
+			username: cfg.Auth.Username,
+			token:    token,
+			client:   httpClient,
+		},
+	}, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.JenkinsProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Jenkins == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.Jenkins
+
+	if cfg.URL == "" {
+		return nil, errMissingURL
+	}
+	u, err := url.ParseRequestURI(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errInvalidURL
+	}
+	if len(cfg.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(cfg.CABundle) {
+		return nil, errInvalidCABundle
+	}
+	if err := certpin.Validate(cfg.CertificatePins); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(cfg.Folder, "/") || strings.HasSuffix(cfg.Folder, "/") || strings.Contains(cfg.Folder, "//") {
+		return nil, errInvalidFolder
+	}
+	if cfg.Auth.Username == "" {
+		return nil, errMissingUsername
+	}
+
+	ref := cfg.Auth.APITokenSecretRef
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return nil, err
+	}
+	if ref.Name == "" {
+		return nil, errMissingSecretName
+	}
+	if ref.Key == "" {
+		return nil, errMissingSecretKey
+	}
+	return cfg, nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Jenkins: &esv1beta1.JenkinsProvider{},
+	})
+}
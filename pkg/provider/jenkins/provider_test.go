@@ -0,0 +1,150 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils/certpin"
+)
+
+const testCA = `-----BEGIN CERTIFICATE-----
+MIIBhTCCASugAwIBAgIQIRi6zePL6mKjOipn+dNuaTAKBggqhkjOPQQDAjASMRAw
+DgYDVQQKEwdBY21lIENvMB4XDTE3MTAyMDE5NDMwNloXDTE4MTAyMDE5NDMwNlow
+EjEQMA4GA1UEChMHQWNtZSBDbzBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABD0d
+7VNhbWvZLWPuj/RtHFjvtJBEwOkhbN/BnnE8rnZR8+sbwnc/KhCk3FhnpHZnQz7B
+5aETbbIgmuvewdjvSBSjYzBhMA4GA1UdDwEB/wQEAwICpDATBgNVHSUEDDAKBggr
+BgEFBQcDATAPBgNVHRMBAf8EBTADAQH/MCkGA1UdEQQiMCCCDmxvY2FsaG9zdDo1
+NDUzgg4xMjcuMC4wLjE6NTQ1MzAKBggqhkjOPQQDAgNIADBFAiEA2zpJEPQyz6/l
+Wf86aX6PepsntZv2GYlA5UpabfT2EZICICpJ5h/iI+i341gBmLiAFQOyTDT+/wQc
+6MF9+Yw1Yy0t
+-----END CERTIFICATE-----`
+
+func newStore(provider *esv1beta1.JenkinsProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Jenkins: provider},
+		},
+	}
+}
+
+func tokenAuth(username, name, key string) esv1beta1.JenkinsAuth {
+	return esv1beta1.JenkinsAuth{Username: username, APITokenSecretRef: esmeta.SecretKeySelector{Name: name, Key: key}}
+}
+
+func TestValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "missing url",
+			store:   newStore(&esv1beta1.JenkinsProvider{Auth: tokenAuth("eso", "jenkins", "token")}),
+			wantErr: errMissingURL,
+		},
+		{
+			name:    "invalid url",
+			store:   newStore(&esv1beta1.JenkinsProvider{URL: "jenkins.example.com", Auth: tokenAuth("eso", "jenkins", "token")}),
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "invalid ca bundle",
+			store:   newStore(&esv1beta1.JenkinsProvider{URL: "https://jenkins.example.com", CABundle: []byte("ca"), Auth: tokenAuth("eso", "jenkins", "token")}),
+			wantErr: errInvalidCABundle,
+		},
+		{
+			name:    "invalid certificate pin",
+			store:   newStore(&esv1beta1.JenkinsProvider{URL: "https://jenkins.example.com", CertificatePins: []string{"sha256/abc"}, Auth: tokenAuth("eso", "jenkins", "token")}),
+			wantErr: certpin.ErrInvalidPin,
+		},
+		{
+			name:    "invalid folder",
+			store:   newStore(&esv1beta1.JenkinsProvider{URL: "https://jenkins.example.com", Folder: "/team-a", Auth: tokenAuth("eso", "jenkins", "token")}),
+			wantErr: errInvalidFolder,
+		},
+		{
+			name:    "missing username",
+			store:   newStore(&esv1beta1.JenkinsProvider{URL: "https://jenkins.example.com", Auth: tokenAuth("", "jenkins", "token")}),
+			wantErr: errMissingUsername,
+		},
+		{
+			name:    "missing secret name",
+			store:   newStore(&esv1beta1.JenkinsProvider{URL: "https://jenkins.example.com", Auth: tokenAuth("eso", "", "token")}),
+			wantErr: errMissingSecretName,
+		},
+		{
+			name:    "missing secret key",
+			store:   newStore(&esv1beta1.JenkinsProvider{URL: "https://jenkins.example.com", Auth: tokenAuth("eso", "jenkins", "")}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name:  "valid",
+			store: newStore(&esv1beta1.JenkinsProvider{URL: "https://jenkins.example.com", Folder: "team-a/builds", CABundle: []byte(testCA), Auth: tokenAuth("eso", "jenkins", "token")}),
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}).Build()
+	p := &Provider{}
+	store := newStore(&esv1beta1.JenkinsProvider{URL: "https://jenkins.example.com/", Folder: "team-a", CABundle: []byte(testCA), Auth: tokenAuth("eso", "jenkins", "token")})
+	sc, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	api := sc.(*client).api.(*httpJenkinsAPI)
+	assert.Equal(t, "https://jenkins.example.com", api.url)
+	assert.Equal(t, "team-a", api.folder)
+	assert.Equal(t, "eso", api.username)
+	assert.Equal(t, "s3cr3t", api.token)
+	require.IsType(t, &http.Transport{}, api.client.Transport)
+	assert.NotNil(t, api.client.Transport.(*http.Transport).TLSClientConfig.RootCAs)
+
+	store.Spec.Provider.Jenkins.Auth = tokenAuth("eso", "jenkins", "missing")
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+)
+
+const (
+	clientIDField     = "clientId"
+	clientSecretField = "clientSecret"
+)
+
+type client struct {
+	api keycloakAPI
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the secret of the client with the client id key.
+// property selects clientSecret or clientId.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	fields, err := c.getFields(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	property := ref.Property
+	if property == "" {
+		property = clientSecretField
+	}
+	value, ok := fields[property]
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return value, nil
+}
+
+// GetSecretMap returns the clientId and clientSecret of the client with the
+// client id key.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Property != "" {
+		return nil, errPropertyNotSupported
+	}
+	return c.getFields(ctx, ref)
+}
+
+// GetAllSecrets returns the secrets of the confidential clients whose client
+// ids match.
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 {
+		return nil, errFindTagsNotSupported
+	}
+	if ref.Path != nil {
+		return nil, errFindPathNotSupported
+	}
+	var (
+		matcher *find.Matcher
+		err     error
+	)
+	if ref.Name != nil {
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	clients, err := c.api.Clients(ctx, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make(map[string][]byte)
+	for _, kc := range clients {
+		if kc.PublicClient {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(kc.ClientID) {
+			continue
+		}
+		secret, err := c.api.ClientSecret(ctx, kc.ID)
+		if err != nil {
+			return nil, err
+		}
+		secrets[kc.ClientID] = []byte(secret)
+	}
+	return secrets, nil
+}
+
+// PushSecret sets the secret of the client with the client id of the remote
+// key, the client must exist.
+func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	if data.GetSecretKey() == "" {
+		return errPushWholeSecret
+	}
+	if p := data.GetProperty(); p != "" && p != clientSecretField {
+		return errPushProperty
+	}
+	value := string(secret.Data[data.GetSecretKey()])
+	if value == "" {
+		return errPushEmptySecret
+	}
+	kc, err := c.getClient(ctx, data.GetRemoteKey())
+	if err != nil {
+		return err
+	}
+	current, err := c.api.ClientSecret(ctx, kc.ID)
+	if err != nil {
+		return err
+	}
+	if current == value {
+		return nil
+	}
+	return c.api.SetClientSecret(ctx, kc.ID, value)
+}
+
+// DeleteSecret regenerates the secret of the client, so the pushed secret
+// can no longer be used. A missing client is ignored.
+func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	kc, err := c.getClient(ctx, remoteRef.GetRemoteKey())
+	if errors.Is(err, esv1beta1.NoSecretError{}) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return c.api.RegenerateClientSecret(ctx, kc.ID)
+}
+
+// Validate checks that the credentials grant access to the clients of the realm.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if _, err := c.api.Clients(ctx, "", 1); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(context.Context) error {
+	return nil
+}
+
+func (c *client) getFields(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Version != "" {
+		return nil, errVersionNotSupported
+	}
+	kc, err := c.getClient(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := c.api.ClientSecret(ctx, kc.ID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		clientIDField:     []byte(kc.ClientID),
+		clientSecretField: []byte(secret),
+	}, nil
+}
+
+// getClient returns the confidential client with clientID. A missing client
+// returns a NoSecretError.
+func (c *client) getClient(ctx context.Context, clientID string) (*clientRepresentation, error) {
+	if clientID == "" {
+		return nil, errMissingKey
+	}
+	clients, err := c.api.Clients(ctx, clientID, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clients {
+		if clients[i].ClientID != clientID {
+			continue
+		}
+		if clients[i].PublicClient {
+			return nil, fmt.Errorf(errPublicClient, clientID)
+		}
+		return &clients[i], nil
+	}
+	return nil, esv1beta1.NoSecretError{}
+}
@@ -0,0 +1,335 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// fakeKeycloak serves the admin REST API of the realm apps with a
+// confidential client web, a confidential client api and a public client spa.
+type fakeKeycloak struct {
+	clients map[string]map[string]any
+	// requests records the method and path of the admin requests
+	requests []string
+}
+
+func newFakeKeycloak() *fakeKeycloak {
+	return &fakeKeycloak{clients: map[string]map[string]any{
+		"1": {"id": "1", "clientId": "web", "secret": "web-secret", "redirectUris": []any{"https://web.example.com/*"}},
+		"2": {"id": "2", "clientId": "api", "secret": "api-secret", "bearerOnly": true},
+		"3": {"id": "3", "clientId": "spa", "publicClient": true},
+	}}
+}
+
+func (f *fakeKeycloak) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/realms/master/protocol/openid-connect/token" {
+		_ = r.ParseForm()
+		if r.PostForm.Get("client_id") != "eso" || r.PostForm.Get("client_secret") != "eso-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"unauthorized_client"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(tokenResponse{AccessToken: "token"})
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	path, ok := strings.CutPrefix(r.URL.Path, "/admin/realms/apps/clients")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	id, sub, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if id == "" {
+		clients := []map[string]any{}
+		for _, key := range []string{"1", "2", "3"} {
+			kc := f.clients[key]
+			if clientID := r.URL.Query().Get("clientId"); clientID != "" && kc["clientId"] != clientID {
+				continue
+			}
+			clients = append(clients, kc)
+		}
+		_ = json.NewEncoder(w).Encode(clients)
+		return
+	}
+	kc, ok := f.clients[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch {
+	case sub == "" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(kc)
+	case sub == "" && r.Method == http.MethodPut:
+		var rep map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&rep)
+		f.clients[id] = rep
+		w.WriteHeader(http.StatusNoContent)
+	case sub == "client-secret" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(credentialRepresentation{Type: "secret", Value: kc["secret"].(string)})
+	case sub == "client-secret" && r.Method == http.MethodPost:
+		kc["secret"] = "regenerated"
+		_ = json.NewEncoder(w).Encode(credentialRepresentation{Type: "secret", Value: "regenerated"})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestClient(server *httptest.Server, clientSecret string) *client {
+	return &client{
+		api: &httpKeycloakAPI{
+			url:        server.URL,
+			realm:      "apps",
+			client:     server.Client(),
+			tokenRealm: "master",
+			tokenForm: url.Values{
+				"grant_type":    {"client_credentials"},
+				"client_id":     {"eso"},
+				"client_secret": {clientSecret},
+			},
+		},
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	server := httptest.NewServer(newFakeKeycloak())
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		clientSecret string
+		ref          esv1beta1.ExternalSecretDataRemoteRef
+		want         string
+		wantErr      error
+		contains     string
+	}{
+		{
+			name: "client secret",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web"},
+			want: "web-secret",
+		},
+		{
+			name: "bearer only client",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "api", Property: "clientSecret"},
+			want: "api-secret",
+		},
+		{
+			name: "client id",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Property: "clientId"},
+			want: "web",
+		},
+		{
+			name:    "missing property",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Property: "redirectUris"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing client",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:     "public client",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "spa"},
+			contains: "client spa is public",
+		},
+		{
+			name:    "missing key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{},
+			wantErr: errMissingKey,
+		},
+		{
+			name:    "version",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Version: "1"},
+			wantErr: errVersionNotSupported,
+		},
+		{
+			name:         "invalid credentials",
+			clientSecret: "wrong",
+			ref:          esv1beta1.ExternalSecretDataRemoteRef{Key: "web"},
+			contains:     "unable to request access token: unexpected status code 401",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientSecret := tt.clientSecret
+			if clientSecret == "" {
+				clientSecret = "eso-secret"
+			}
+			got, err := newTestClient(server, clientSecret).GetSecret(context.Background(), tt.ref)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	server := httptest.NewServer(newFakeKeycloak())
+	defer server.Close()
+	c := newTestClient(server, "eso-secret")
+
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "web"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"clientId": []byte("web"), "clientSecret": []byte("web-secret")}, got)
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Property: "clientSecret"})
+	assert.ErrorIs(t, err, errPropertyNotSupported)
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	server := httptest.NewServer(newFakeKeycloak())
+	defer server.Close()
+	c := newTestClient(server, "eso-secret")
+
+	got, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"web": []byte("web-secret"), "api": []byte("api-secret")}, got)
+
+	got, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^w"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"web": []byte("web-secret")}, got)
+
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Tags: map[string]string{"a": "b"}})
+	assert.ErrorIs(t, err, errFindTagsNotSupported)
+
+	path := "apps"
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Path: &path})
+	assert.ErrorIs(t, err, errFindPathNotSupported)
+}
+
+func TestPushSecret(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{"secret": []byte("new-secret"), "empty": {}}}
+	tests := []struct {
+		name         string
+		data         v1alpha1.PushSecretData
+		wantSecret   string
+		wantRequests []string
+		wantErr      error
+	}{
+		{
+			name:       "set secret",
+			data:       v1alpha1.PushSecretData{Match: v1alpha1.PushSecretMatch{SecretKey: "secret", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "web"}}},
+			wantSecret: "new-secret",
+			wantRequests: []string{
+				"GET /admin/realms/apps/clients",
+				"GET /admin/realms/apps/clients/1/client-secret",
+				"GET /admin/realms/apps/clients/1",
+				"PUT /admin/realms/apps/clients/1",
+			},
+		},
+		{
+			name:       "empty secret",
+			data:       v1alpha1.PushSecretData{Match: v1alpha1.PushSecretMatch{SecretKey: "empty", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "web"}}},
+			wantErr:    errPushEmptySecret,
+			wantSecret: "web-secret",
+		},
+		{
+			name:    "missing client",
+			data:    v1alpha1.PushSecretData{Match: v1alpha1.PushSecretMatch{SecretKey: "secret", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "missing"}}},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "whole secret",
+			data:    v1alpha1.PushSecretData{Match: v1alpha1.PushSecretMatch{RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "web"}}},
+			wantErr: errPushWholeSecret,
+		},
+		{
+			name:    "property",
+			data:    v1alpha1.PushSecretData{Match: v1alpha1.PushSecretMatch{SecretKey: "secret", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "web", Property: "clientId"}}},
+			wantErr: errPushProperty,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeKeycloak()
+			server := httptest.NewServer(fake)
+			defer server.Close()
+			err := newTestClient(server, "eso-secret").PushSecret(context.Background(), secret, tt.data)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantSecret != "" {
+				assert.Equal(t, tt.wantSecret, fake.clients["1"]["secret"])
+				// fields unknown to the provider are preserved
+				assert.Equal(t, []any{"https://web.example.com/*"}, fake.clients["1"]["redirectUris"])
+			}
+			if tt.wantRequests != nil {
+				assert.Equal(t, tt.wantRequests, fake.requests)
+			}
+		})
+	}
+}
+
+func TestPushSecretUnchanged(t *testing.T) {
+	fake := newFakeKeycloak()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	secret := &corev1.Secret{Data: map[string][]byte{"secret": []byte("web-secret")}}
+	data := v1alpha1.PushSecretData{Match: v1alpha1.PushSecretMatch{SecretKey: "secret", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "web", Property: "clientSecret"}}}
+	require.NoError(t, newTestClient(server, "eso-secret").PushSecret(context.Background(), secret, data))
+	assert.Equal(t, []string{
+		"GET /admin/realms/apps/clients",
+		"GET /admin/realms/apps/clients/1/client-secret",
+	}, fake.requests)
+}
+
+func TestDeleteSecret(t *testing.T) {
+	fake := newFakeKeycloak()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	c := newTestClient(server, "eso-secret")
+
+	require.NoError(t, c.DeleteSecret(context.Background(), v1alpha1.PushSecretRemoteRef{RemoteKey: "web"}))
+	assert.Equal(t, "regenerated", fake.clients["1"]["secret"])
+
+	assert.NoError(t, c.DeleteSecret(context.Background(), v1alpha1.PushSecretRemoteRef{RemoteKey: "missing"}))
+}
+
+func TestValidate(t *testing.T) {
+	server := httptest.NewServer(newFakeKeycloak())
+	defer server.Close()
+
+	result, err := newTestClient(server, "eso-secret").Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	result, err = newTestClient(server, "wrong").Validate()
+	assert.Error(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+}
@@ -0,0 +1,207 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// clientRepresentation holds the fields of a Keycloak client used by the
+// provider.
+type clientRepresentation struct {
+	ID           string `json:"id"`
+	ClientID     string `json:"clientId"`
+	PublicClient bool   `json:"publicClient"`
+	BearerOnly   bool   `json:"bearerOnly"`
+}
+
+type credentialRepresentation struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+// keycloakAPI is the subset of the admin REST API used by the provider.
+// Clients are identified by their internal id, not by their client id.
+type keycloakAPI interface {
+	// Clients returns the clients of the realm, only the client with
+	// clientID if it is not empty. At most max clients are returned if max
+	// is positive.
+	Clients(ctx context.Context, clientID string, max int) ([]clientRepresentation, error)
+	// ClientSecret returns the secret of a confidential client.
+	ClientSecret(ctx context.Context, id string) (string, error)
+	// SetClientSecret sets the secret of a confidential client.
+	SetClientSecret(ctx context.Context, id, secret string) error
+	// RegenerateClientSecret generates a new secret for a confidential client.
+	RegenerateClientSecret(ctx context.Context, id string) error
+}
+
+type httpKeycloakAPI struct {
+	url    string
+	realm  string
+	client *http.Client
+	// tokenRealm and tokenForm are used to request an access token
+	tokenRealm string
+	tokenForm  url.Values
+
+	mu    sync.Mutex
+	token string
+}
+
+var _ keycloakAPI = &httpKeycloakAPI{}
+
+func (a *httpKeycloakAPI) Clients(ctx context.Context, clientID string, max int) ([]clientRepresentation, error) {
+	clients, err := a.clients(ctx, clientID, max)
+	metrics.ObserveAPICall(constants.ProviderKeycloak, constants.CallKeycloakGetClients, err)
+	return clients, err
+}
+
+func (a *httpKeycloakAPI) clients(ctx context.Context, clientID string, max int) ([]clientRepresentation, error) {
+	query := url.Values{}
+	if clientID != "" {
+		query.Set("clientId", clientID)
+	}
+	if max > 0 {
+		query.Set("max", fmt.Sprint(max))
+	}
+	var clients []clientRepresentation
+	err := a.do(ctx, http.MethodGet, "/clients?"+query.Encode(), nil, &clients)
+	return clients, err
+}
+
+func (a *httpKeycloakAPI) ClientSecret(ctx context.Context, id string) (string, error) {
+	var cred credentialRepresentation
+	err := a.do(ctx, http.MethodGet, "/clients/"+url.PathEscape(id)+"/client-secret", nil, &cred)
+	metrics.ObserveAPICall(constants.ProviderKeycloak, constants.CallKeycloakGetClientSecret, err)
+	return cred.Value, err
+}
+
+// SetClientSecret updates the whole representation of the client, so fields
+// unknown to the provider are preserved.
+func (a *httpKeycloakAPI) SetClientSecret(ctx context.Context, id, secret string) error {
+	var rep map[string]json.RawMessage
+	err := a.do(ctx, http.MethodGet, "/clients/"+url.PathEscape(id), nil, &rep)
+	metrics.ObserveAPICall(constants.ProviderKeycloak, constants.CallKeycloakGetClient, err)
+	if err != nil {
+		return err
+	}
+	rep["secret"], err = json.Marshal(secret)
+	if err != nil {
+		return err
+	}
+	err = a.do(ctx, http.MethodPut, "/clients/"+url.PathEscape(id), rep, nil)
+	metrics.ObserveAPICall(constants.ProviderKeycloak, constants.CallKeycloakUpdateClient, err)
+	return err
+}
+
+func (a *httpKeycloakAPI) RegenerateClientSecret(ctx context.Context, id string) error {
+	err := a.do(ctx, http.MethodPost, "/clients/"+url.PathEscape(id)+"/client-secret", nil, nil)
+	metrics.ObserveAPICall(constants.ProviderKeycloak, constants.CallKeycloakRegenerateClientSecret, err)
+	return err
+}
+
+// do sends a request to the admin REST API of the realm. A missing
+// resource returns a NoSecretError.
+func (a *httpKeycloakAPI) do(ctx context.Context, method, path string, in, out any) error {
+	token, err := a.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	var body io.Reader = http.NoBody
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, a.url+"/admin/realms/"+url.PathEscape(a.realm)+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	data, err := utils.DoHTTP(a.client, req, http.StatusNotFound)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf(errDecodeResponse, err)
+	}
+	return nil
+}
+
+// accessToken returns the access token of the client, it is requested on
+// first use. Tokens are short lived, but so is the client.
+func (a *httpKeycloakAPI) accessToken(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.token != "" {
+		return a.token, nil
+	}
+	token, err := a.requestToken(ctx)
+	metrics.ObserveAPICall(constants.ProviderKeycloak, constants.CallKeycloakToken, err)
+	if err != nil {
+		return "", err
+	}
+	a.token = token
+	return token, nil
+}
+
+func (a *httpKeycloakAPI) requestToken(ctx context.Context) (string, error) {
+	endpoint := a.url + "/realms/" + url.PathEscape(a.tokenRealm) + "/protocol/openid-connect/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(a.tokenForm.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	data, err := utils.DoHTTP(a.client, req)
+	var statusErr *utils.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return "", fmt.Errorf(errToken, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	var resp tokenResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return "", fmt.Errorf(errToken, fmt.Errorf(errDecodeResponse, err))
+	}
+	if resp.AccessToken == "" {
+		return "", fmt.Errorf(errToken, errEmptyToken)
+	}
+	return resp.AccessToken, nil
+}
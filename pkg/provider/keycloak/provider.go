@@ -0,0 +1,202 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/certpin"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errDecodeResponse = "unable to decode response: %w"
+	errToken          = "unable to request access token: %w"
+	errPublicClient   = "client %s is public and has no secret"
+
+	defaultPasswordClientID = "admin-cli"
+	requestTimeout          = 30 * time.Second
+	validateTimeout         = 10 * time.Second
+)
+
+var (
+	errMissingStore         = errors.New("missing store specification")
+	errInvalidSpec          = errors.New("invalid specification for keycloak provider")
+	errMissingURL           = errors.New("url must be set")
+	errInvalidURL           = errors.New("url must be an absolute http or https url")
+	errInvalidCABundle      = errors.New("caBundle does not contain a PEM encoded certificate")
+	errMissingRealm         = errors.New("realm must be set")
+	errInvalidAuth          = errors.New("exactly one of auth.clientCredentials or auth.password must be set")
+	errMissingClientID      = errors.New("auth.clientCredentials.clientID must be set")
+	errMissingUsername      = errors.New("auth.password.username must be set")
+	errMissingSecretName    = errors.New("must specify a secret name")
+	errMissingSecretKey     = errors.New("must specify a secret key")
+	errEmptyToken           = errors.New("response contains no access token")
+	errMissingKey           = errors.New("key must be set to a client id")
+	errVersionNotSupported  = errors.New("specifying a version is not supported by keycloak")
+	errPropertyNotSupported = errors.New("property is not supported by dataFrom.extract")
+	errFindTagsNotSupported = errors.New("find by tags is not supported by keycloak")
+	errFindPathNotSupported = errors.New("find by path is not supported by keycloak")
+	errPushWholeSecret      = errors.New("pushing a whole secret is not supported, set secretKey")
+	errPushProperty         = errors.New("only the clientSecret property can be pushed")
+	errPushEmptySecret      = errors.New("client secret must not be empty")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadWrite
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	var form url.Values
+	if creds := cfg.Auth.ClientCredentials; creds != nil {
+		secret, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &creds.ClientSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		form = url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {creds.ClientID},
+			"client_secret": {secret},
+		}
+	} else {
+		auth := cfg.Auth.Password
+		password, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &auth.PasswordSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		clientID := auth.ClientID
+		if clientID == "" {
+			clientID = defaultPasswordClientID
+		}
+		form = url.Values{
+			"grant_type": {"password"},
+			"client_id":  {clientID},
+			"username":   {auth.Username},
+			"password":   {password},
+		}
+	}
+	tokenRealm := cfg.Auth.Realm
+	if tokenRealm == "" {
+		tokenRealm = cfg.Realm
+	}
+	httpClient := &http.Client{Timeout: requestTimeout}
+	if len(cfg.CABundle) > 0 || len(cfg.CertificatePins) > 0 {
+		tlsConfig := &tls.Config{
+			MinVersion:       tls.VersionTLS12,
+			VerifyConnection: certpin.VerifyConnection(cfg.CertificatePins),
+		}
+		if len(cfg.CABundle) > 0 {
+			tlsConfig.RootCAs = x509.NewCertPool()
+			tlsConfig.RootCAs.AppendCertsFromPEM(cfg.CABundle)
+		}
+		httpClient.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
+	return &client{
+		api: &httpKeycloakAPI{
+			url:        strings.TrimSuffix(cfg.URL, "/"),
+			realm:      cfg.Realm,
+			client:     httpClient,
+			tokenRealm: tokenRealm,
+			tokenForm:  form,
+		},
+	}, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.KeycloakProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Keycloak == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.Keycloak
+
+	if cfg.URL == "" {
+		return nil, errMissingURL
+	}
+	u, err := url.ParseRequestURI(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errInvalidURL
+	}
+	if len(cfg.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(cfg.CABundle) {
+		return nil, errInvalidCABundle
+	}
+	if err := certpin.Validate(cfg.CertificatePins); err != nil {
+		return nil, err
+	}
+	if cfg.Realm == "" {
+		return nil, errMissingRealm
+	}
+	var ref esmeta.SecretKeySelector
+	switch auth := cfg.Auth; {
+	case (auth.ClientCredentials == nil) == (auth.Password == nil):
+		return nil, errInvalidAuth
+	case auth.ClientCredentials != nil:
+		if auth.ClientCredentials.ClientID == "" {
+			return nil, errMissingClientID
+		}
+		ref = auth.ClientCredentials.ClientSecretRef
+	default:
+		if auth.Password.Username == "" {
+			return nil, errMissingUsername
+		}
+		ref = auth.Password.PasswordSecretRef
+	}
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return nil, err
+	}
+	if ref.Name == "" {
+		return nil, errMissingSecretName
+	}
+	if ref.Key == "" {
+		return nil, errMissingSecretKey
+	}
+	return cfg, nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Keycloak: &esv1beta1.KeycloakProvider{},
+	})
+}
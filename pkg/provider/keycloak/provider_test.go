@@ -0,0 +1,182 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const testCA = `-----BEGIN CERTIFICATE-----
+MIIBhTCCASugAwIBAgIQIRi6zePL6mKjOipn+dNuaTAKBggqhkjOPQQDAjASMRAw
+DgYDVQQKEwdBY21lIENvMB4XDTE3MTAyMDE5NDMwNloXDTE4MTAyMDE5NDMwNlow
+EjEQMA4GA1UEChMHQWNtZSBDbzBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABD0d
+7VNhbWvZLWPuj/RtHFjvtJBEwOkhbN/BnnE8rnZR8+sbwnc/KhCk3FhnpHZnQz7B
+5aETbbIgmuvewdjvSBSjYzBhMA4GA1UdDwEB/wQEAwICpDATBgNVHSUEDDAKBggr
+BgEFBQcDATAPBgNVHRMBAf8EBTADAQH/MCkGA1UdEQQiMCCCDmxvY2FsaG9zdDo1
+NDUzgg4xMjcuMC4wLjE6NTQ1MzAKBggqhkjOPQQDAgNIADBFAiEA2zpJEPQyz6/l
+Wf86aX6PepsntZv2GYlA5UpabfT2EZICICpJ5h/iI+i341gBmLiAFQOyTDT+/wQc
+6MF9+Yw1Yy0t
+-----END CERTIFICATE-----`
+
+func newStore(provider *esv1beta1.KeycloakProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "keycloak", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Keycloak: provider},
+		},
+	}
+}
+
+func clientCredentials(clientID, name, key string) esv1beta1.KeycloakAuth {
+	return esv1beta1.KeycloakAuth{ClientCredentials: &esv1beta1.KeycloakClientCredentials{
+		ClientID:        clientID,
+		ClientSecretRef: esmeta.SecretKeySelector{Name: name, Key: key},
+	}}
+}
+
+func passwordAuth(username, name, key string) esv1beta1.KeycloakAuth {
+	return esv1beta1.KeycloakAuth{Password: &esv1beta1.KeycloakPasswordAuth{
+		Username:          username,
+		PasswordSecretRef: esmeta.SecretKeySelector{Name: name, Key: key},
+	}}
+}
+
+func TestValidateStore(t *testing.T) {
+	both := clientCredentials("eso", "keycloak", "secret")
+	both.Password = passwordAuth("admin", "keycloak", "password").Password
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "missing url",
+			store:   newStore(&esv1beta1.KeycloakProvider{Realm: "apps", Auth: clientCredentials("eso", "keycloak", "secret")}),
+			wantErr: errMissingURL,
+		},
+		{
+			name:    "invalid url",
+			store:   newStore(&esv1beta1.KeycloakProvider{URL: "keycloak.example.com", Realm: "apps", Auth: clientCredentials("eso", "keycloak", "secret")}),
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "invalid ca bundle",
+			store:   newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com", Realm: "apps", CABundle: []byte("ca"), Auth: clientCredentials("eso", "keycloak", "secret")}),
+			wantErr: errInvalidCABundle,
+		},
+		{
+			name:    "missing realm",
+			store:   newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com", Auth: clientCredentials("eso", "keycloak", "secret")}),
+			wantErr: errMissingRealm,
+		},
+		{
+			name:    "missing auth",
+			store:   newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com", Realm: "apps"}),
+			wantErr: errInvalidAuth,
+		},
+		{
+			name:    "multiple auth",
+			store:   newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com", Realm: "apps", Auth: both}),
+			wantErr: errInvalidAuth,
+		},
+		{
+			name:    "missing client id",
+			store:   newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com", Realm: "apps", Auth: clientCredentials("", "keycloak", "secret")}),
+			wantErr: errMissingClientID,
+		},
+		{
+			name:    "missing username",
+			store:   newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com", Realm: "apps", Auth: passwordAuth("", "keycloak", "password")}),
+			wantErr: errMissingUsername,
+		},
+		{
+			name:    "missing secret name",
+			store:   newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com", Realm: "apps", Auth: clientCredentials("eso", "", "secret")}),
+			wantErr: errMissingSecretName,
+		},
+		{
+			name:    "missing secret key",
+			store:   newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com", Realm: "apps", Auth: passwordAuth("admin", "keycloak", "")}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name:  "valid",
+			store: newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com/auth", Realm: "apps", CABundle: []byte(testCA), Auth: clientCredentials("eso", "keycloak", "secret")}),
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keycloak", Namespace: "default"},
+		Data:       map[string][]byte{"secret": []byte("eso-secret"), "password": []byte("admin-password")},
+	}).Build()
+	p := &Provider{}
+
+	store := newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com/", Realm: "apps", CABundle: []byte(testCA), Auth: clientCredentials("eso", "keycloak", "secret")})
+	sc, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	api := sc.(*client).api.(*httpKeycloakAPI)
+	assert.Equal(t, "https://keycloak.example.com", api.url)
+	assert.Equal(t, "apps", api.realm)
+	assert.Equal(t, "apps", api.tokenRealm)
+	assert.Equal(t, url.Values{"grant_type": {"client_credentials"}, "client_id": {"eso"}, "client_secret": {"eso-secret"}}, api.tokenForm)
+	require.IsType(t, &http.Transport{}, api.client.Transport)
+	assert.NotNil(t, api.client.Transport.(*http.Transport).TLSClientConfig.RootCAs)
+
+	auth := passwordAuth("admin", "keycloak", "password")
+	auth.Realm = "master"
+	store = newStore(&esv1beta1.KeycloakProvider{URL: "https://keycloak.example.com", Realm: "apps", Auth: auth})
+	sc, err = p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	api = sc.(*client).api.(*httpKeycloakAPI)
+	assert.Equal(t, "master", api.tokenRealm)
+	assert.Equal(t, url.Values{"grant_type": {"password"}, "client_id": {"admin-cli"}, "username": {"admin"}, "password": {"admin-password"}}, api.tokenForm)
+
+	store.Spec.Provider.Keycloak.Auth = passwordAuth("admin", "keycloak", "missing")
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyprotect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+type client struct {
+	api keyAPI
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the payload of the standard key referenced by its ID or
+// alias. With property the payload is parsed as JSON and the value of the
+// property is returned, nested values are selected with a gjson expression.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if ref.Version != "" {
+		return nil, errVersionNotSupported
+	}
+	k, err := c.api.GetKey(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := k.payload()
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return payload, nil
+	}
+	if !gjson.ValidBytes(payload) {
+		return nil, errPayloadNotJSON
+	}
+	val := gjson.GetBytes(payload, ref.Property)
+	if !val.Exists() {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap returns the fields of a JSON object payload as map.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	payload, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, errPayloadNotObject
+	}
+	secretMap := make(map[string][]byte, len(fields))
+	for k := range fields {
+		secretMap[k], err = utils.GetByteValueFromMap(fields, k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secretMap, nil
+}
+
+func (c *client) GetAllSecrets(_ context.Context, _ esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, errors.New("getting all secrets is not supported by Key Protect")
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by Key Protect")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by Key Protect")
+}
+
+// Validate checks that the instance can be accessed with the credentials.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if err := c.api.ListKeys(ctx); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(context.Context) error {
+	return nil
+}
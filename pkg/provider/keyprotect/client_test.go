@@ -0,0 +1,180 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyprotect
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+)
+
+const (
+	testInstance = "4f5e8a2b-instance"
+	testToken    = "token"
+)
+
+func newTestClient(t *testing.T) *client {
+	t.Helper()
+	keys := map[string]key{
+		"db-password": {
+			ID:          "12e8c9c2-a162-472d-b7d6-8b9a86b815a6",
+			Aliases:     []string{"db-password"},
+			Extractable: true,
+			Payload:     base64.StdEncoding.EncodeToString([]byte("s3cr3t")),
+		},
+		"db-config": {
+			ID:          "2291e4ae-a14c-4af9-88f0-27c0cb2739e2",
+			Extractable: true,
+			Payload:     base64.StdEncoding.EncodeToString([]byte(`{"user":"app","password":"s3cr3t","port":5432}`)),
+		},
+		"root": {
+			ID:          "5e2e8f3b-ae43-4b5f-a6e4-9b7c8e6a4d1c",
+			Extractable: false,
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken || r.Header.Get("Bluemix-Instance") != testInstance {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/api/v2/keys" {
+			_ = json.NewEncoder(w).Encode(keysResponse{})
+			return
+		}
+		k, ok := keys[strings.TrimPrefix(r.URL.Path, "/api/v2/keys/")]
+		switch {
+		case r.URL.Path == "/api/v2/keys/destroyed":
+			w.WriteHeader(http.StatusGone)
+			return
+		case !ok:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(keysResponse{Resources: []key{k}})
+	}))
+	t.Cleanup(server.Close)
+	authenticator, err := core.NewBearerTokenAuthenticator(testToken)
+	require.NoError(t, err)
+	return &client{
+		api: &httpKeyAPI{
+			provider:      constants.ProviderIBMKP,
+			url:           server.URL,
+			instanceID:    testInstance,
+			authenticator: authenticator,
+			client:        server.Client(),
+		},
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	c := newTestClient(t)
+	tests := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		want     string
+		wantErr  error
+		contains string
+	}{
+		{
+			name: "payload by alias",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "db-password"},
+			want: "s3cr3t",
+		},
+		{
+			name: "json property",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "db-config", Property: "port"},
+			want: "5432",
+		},
+		{
+			name:    "property of non json payload",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "db-password", Property: "user"},
+			wantErr: errPayloadNotJSON,
+		},
+		{
+			name:    "missing property",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "db-config", Property: "token"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:     "root key",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "root"},
+			contains: "is a root key",
+		},
+		{
+			name:    "missing key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "other"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "destroyed key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "destroyed"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "version",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "db-password", Version: "2"},
+			wantErr: errVersionNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c := newTestClient(t)
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db-config"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"user":     []byte("app"),
+		"password": []byte("s3cr3t"),
+		"port":     []byte("5432"),
+	}, got)
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db-password"})
+	assert.ErrorIs(t, err, errPayloadNotObject)
+}
+
+func TestValidate(t *testing.T) {
+	c := newTestClient(t)
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	c.api.(*httpKeyAPI).instanceID = "other"
+	result, err = c.Validate()
+	assert.ErrorContains(t, err, "unexpected status code 401")
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+}
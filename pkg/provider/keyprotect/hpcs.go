@@ -0,0 +1,69 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyprotect
+
+import (
+	"context"
+
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+)
+
+// HPCSProvider syncs standard keys from the key management service of
+// IBM Hyper Protect Crypto Services, which implements the Key Protect API.
+type HPCSProvider struct{}
+
+var _ esv1beta1.Provider = &HPCSProvider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *HPCSProvider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *HPCSProvider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getHPCSConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(ctx, store, kube, namespace, &httpKeyAPI{
+		provider:   constants.ProviderIBMHPCS,
+		url:        serviceURL(cfg.ServiceURL, hpcsURLTemplate, cfg.InstanceID, cfg.Region),
+		instanceID: cfg.InstanceID,
+	}, cfg.Auth)
+}
+
+func (p *HPCSProvider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getHPCSConfig(store)
+	return nil, err
+}
+
+func getHPCSConfig(store esv1beta1.GenericStore) (*esv1beta1.HPCSProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.HPCS == nil {
+		return nil, errInvalidHPCSSpec
+	}
+	cfg := storeSpec.Provider.HPCS
+	if err := validateInstance(cfg.InstanceID, cfg.Region, cfg.ServiceURL); err != nil {
+		return nil, err
+	}
+	if err := validateAuth(store, cfg.Auth); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,105 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyprotect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+)
+
+func newHPCSStore(provider *esv1beta1.HPCSProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "hpcs", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{HPCS: provider},
+		},
+	}
+}
+
+func TestHPCSValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "key protect store",
+			store:   newStore(&esv1beta1.KeyProtectProvider{InstanceID: "id", Region: "us-south", Auth: apiKeyAuth("ibm", "apikey")}),
+			wantErr: errInvalidHPCSSpec,
+		},
+		{
+			name:    "missing instance",
+			store:   newHPCSStore(&esv1beta1.HPCSProvider{Region: "us-south", Auth: apiKeyAuth("ibm", "apikey")}),
+			wantErr: errMissingInstanceID,
+		},
+		{
+			name:    "missing region",
+			store:   newHPCSStore(&esv1beta1.HPCSProvider{InstanceID: "id", Auth: apiKeyAuth("ibm", "apikey")}),
+			wantErr: errMissingRegion,
+		},
+		{
+			name:    "missing auth",
+			store:   newHPCSStore(&esv1beta1.HPCSProvider{InstanceID: "id", Region: "us-south"}),
+			wantErr: errAuthMethod,
+		},
+		{
+			name:  "service url with port",
+			store: newHPCSStore(&esv1beta1.HPCSProvider{InstanceID: "id", ServiceURL: "https://api.us-south.hs-crypto.cloud.ibm.com:8389", Auth: apiKeyAuth("ibm", "apikey")}),
+		},
+	}
+	p := &HPCSProvider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHPCSNewClient(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ibm", Namespace: "default"},
+		Data:       map[string][]byte{"apikey": []byte("key")},
+	}).Build()
+	p := &HPCSProvider{}
+	store := newHPCSStore(&esv1beta1.HPCSProvider{InstanceID: "id", Region: "us-south", Auth: apiKeyAuth("ibm", "apikey")})
+	sc, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	api := sc.(*client).api.(*httpKeyAPI)
+	assert.Equal(t, "https://id.api.us-south.hs-crypto.appdomain.cloud", api.url)
+	assert.Equal(t, "id", api.instanceID)
+	assert.Equal(t, constants.ProviderIBMHPCS, api.provider)
+
+	store.Spec.Provider.HPCS.ServiceURL = "https://api.us-south.hs-crypto.cloud.ibm.com:8389/"
+	sc, err = p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.us-south.hs-crypto.cloud.ibm.com:8389", sc.(*client).api.(*httpKeyAPI).url)
+}
@@ -0,0 +1,121 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyprotect
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// key is a Key Protect key as returned by the API.
+type key struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases"`
+	Extractable bool     `json:"extractable"`
+	State       int      `json:"state"`
+	// Payload is the base64 encoded key material of standard keys.
+	Payload string `json:"payload"`
+}
+
+type keysResponse struct {
+	Resources []key `json:"resources"`
+}
+
+// keyAPI is the subset of the Key Protect API used by the provider. The key management
+// service of Hyper Protect Crypto Services implements the same API.
+// See https://cloud.ibm.com/apidocs/key-protect for the full API documentation.
+type keyAPI interface {
+	// GetKey returns the key with the given ID or alias.
+	GetKey(ctx context.Context, idOrAlias string) (*key, error)
+	// ListKeys returns an error if the instance can not be accessed.
+	ListKeys(ctx context.Context) error
+}
+
+type httpKeyAPI struct {
+	// provider is the name of the provider in metrics.
+	provider      string
+	url           string
+	instanceID    string
+	authenticator core.Authenticator
+	client        *http.Client
+}
+
+var _ keyAPI = &httpKeyAPI{}
+
+func (a *httpKeyAPI) GetKey(ctx context.Context, idOrAlias string) (*key, error) {
+	var resp keysResponse
+	err := a.get(ctx, "/api/v2/keys/"+url.PathEscape(idOrAlias), &resp)
+	metrics.ObserveAPICall(a.provider, constants.CallIBMKPGetKey, err)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Resources) == 0 {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return &resp.Resources[0], nil
+}
+
+func (a *httpKeyAPI) ListKeys(ctx context.Context) error {
+	var resp keysResponse
+	err := a.get(ctx, "/api/v2/keys?limit=1", &resp)
+	metrics.ObserveAPICall(a.provider, constants.CallIBMKPListKeys, err)
+	return err
+}
+
+func (a *httpKeyAPI) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.url+path, http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.ibm.collection+json")
+	req.Header.Set("Bluemix-Instance", a.instanceID)
+	if err := a.authenticator.Authenticate(req); err != nil {
+		return fmt.Errorf(errAuthenticate, err)
+	}
+	// destroyed keys are gone
+	body, err := utils.DoHTTP(a.client, req, http.StatusNotFound, http.StatusGone)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf(errDecodeResponse, err)
+	}
+	return nil
+}
+
+// payload returns the decoded key material of a standard key.
+func (k *key) payload() ([]byte, error) {
+	if !k.Extractable {
+		return nil, fmt.Errorf(errNotExtractable, k.ID)
+	}
+	if k.Payload == "" {
+		return nil, fmt.Errorf(errNoPayload, k.ID)
+	}
+	payload, err := base64.StdEncoding.DecodeString(k.Payload)
+	if err != nil {
+		return nil, fmt.Errorf(errDecodePayload, k.ID, err)
+	}
+	return payload, nil
+}
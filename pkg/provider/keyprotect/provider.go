@@ -0,0 +1,198 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyprotect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errAuthenticate   = "unable to authenticate request: %w"
+	errDecodeResponse = "unable to decode response: %w"
+	errNotExtractable = "key %s is a root key, only the payload of standard keys can be synced"
+	errNoPayload      = "key %s has no payload"
+	errDecodePayload  = "unable to decode payload of key %s: %w"
+
+	defaultTokenLocation = "/var/run/secrets/tokens/vault-token"
+	defaultIAMEndpoint   = "https://iam.cloud.ibm.com"
+	serviceURLTemplate   = "https://%s.kms.cloud.ibm.com"
+	hpcsURLTemplate      = "https://%s.api.%s.hs-crypto.appdomain.cloud"
+
+	requestTimeout  = 30 * time.Second
+	validateTimeout = 10 * time.Second
+)
+
+var (
+	errMissingStore        = errors.New("missing store specification")
+	errInvalidSpec         = errors.New("invalid specification for keyprotect provider")
+	errInvalidHPCSSpec     = errors.New("invalid specification for hpcs provider")
+	errMissingInstanceID   = errors.New("instanceId must be set")
+	errMissingRegion       = errors.New("either region or serviceUrl must be set")
+	errInvalidURL          = errors.New("serviceUrl must be an absolute http or https url")
+	errAuthMethod          = errors.New("exactly one of auth.secretRef or auth.containerAuth must be set")
+	errMissingProfile      = errors.New("container auth profile must be set")
+	errMissingSecretName   = errors.New("must specify a secret name")
+	errMissingSecretKey    = errors.New("must specify a secret key")
+	errVersionNotSupported = errors.New("specifying a version is not supported")
+	errPayloadNotJSON      = errors.New("payload is not valid json, omit the property to sync the raw payload")
+	errPayloadNotObject    = errors.New("payload is not a json object")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(ctx, store, kube, namespace, &httpKeyAPI{
+		provider:   constants.ProviderIBMKP,
+		url:        serviceURL(cfg.ServiceURL, serviceURLTemplate, cfg.Region),
+		instanceID: cfg.InstanceID,
+	}, cfg.Auth)
+}
+
+// newClient completes api with an authenticator for auth and returns a client using it.
+func newClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string, api *httpKeyAPI, auth esv1beta1.IBMAuth) (*client, error) {
+	authenticator, err := newAuthenticator(ctx, store.GetKind(), auth, kube, namespace)
+	if err != nil {
+		return nil, err
+	}
+	api.authenticator = authenticator
+	api.client = &http.Client{Timeout: requestTimeout}
+	return &client{api: api}, nil
+}
+
+func newAuthenticator(ctx context.Context, storeKind string, auth esv1beta1.IBMAuth, kube kclient.Client, namespace string) (core.Authenticator, error) {
+	if auth.ContainerAuth != nil {
+		tokenLocation := auth.ContainerAuth.TokenLocation
+		if tokenLocation == "" {
+			tokenLocation = defaultTokenLocation
+		}
+		iamEndpoint := auth.ContainerAuth.IAMEndpoint
+		if iamEndpoint == "" {
+			iamEndpoint = defaultIAMEndpoint
+		}
+		return core.NewContainerAuthenticatorBuilder().
+			SetIAMProfileName(auth.ContainerAuth.Profile).
+			SetCRTokenFilename(tokenLocation).
+			SetURL(iamEndpoint).
+			Build()
+	}
+	apiKey, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &auth.SecretRef.SecretAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewIamAuthenticatorBuilder().SetApiKey(apiKey).Build()
+}
+
+// serviceURL returns the configured service url or otherwise the url derived from template.
+func serviceURL(configured, template string, args ...any) string {
+	if configured != "" {
+		return strings.TrimSuffix(configured, "/")
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.KeyProtectProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.KeyProtect == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.KeyProtect
+	if err := validateInstance(cfg.InstanceID, cfg.Region, cfg.ServiceURL); err != nil {
+		return nil, err
+	}
+	if err := validateAuth(store, cfg.Auth); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func validateInstance(instanceID, region, serviceURL string) error {
+	if instanceID == "" {
+		return errMissingInstanceID
+	}
+	if region == "" && serviceURL == "" {
+		return errMissingRegion
+	}
+	if serviceURL != "" {
+		u, err := url.ParseRequestURI(serviceURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errInvalidURL
+		}
+	}
+	return nil
+}
+
+func validateAuth(store esv1beta1.GenericStore, auth esv1beta1.IBMAuth) error {
+	if (auth.SecretRef == nil) == (auth.ContainerAuth == nil) {
+		return errAuthMethod
+	}
+	if auth.ContainerAuth != nil {
+		if auth.ContainerAuth.Profile == "" {
+			return errMissingProfile
+		}
+		return nil
+	}
+	ref := auth.SecretRef.SecretAPIKey
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return err
+	}
+	if ref.Name == "" {
+		return errMissingSecretName
+	}
+	if ref.Key == "" {
+		return errMissingSecretKey
+	}
+	return nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		KeyProtect: &esv1beta1.KeyProtectProvider{},
+	})
+	esv1beta1.Register(&HPCSProvider{}, &esv1beta1.SecretStoreProvider{
+		HPCS: &esv1beta1.HPCSProvider{},
+	})
+}
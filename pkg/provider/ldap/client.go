@@ -0,0 +1,159 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+type client struct {
+	dial   func() (ldapAPI, error)
+	baseDN *ldap.DN
+	// conn is opened on first use and closed by Close.
+	conn ldapAPI
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the attribute property of the entry with the DN key.
+// Attributes with multiple values are returned as JSON array. Without
+// property all attributes of the entry are returned as JSON.
+func (c *client) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	var attributes []string
+	if ref.Property != "" {
+		attributes = []string{ref.Property}
+	}
+	entry, err := c.getEntry(ref, attributes)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		obj := make(map[string]any, len(entry.Attributes))
+		for _, attr := range entry.Attributes {
+			obj[attr.Name] = jsonValue(attr)
+		}
+		return json.Marshal(obj)
+	}
+	for _, attr := range entry.Attributes {
+		if strings.EqualFold(attr.Name, ref.Property) && len(attr.ByteValues) > 0 {
+			return attributeBytes(attr)
+		}
+	}
+	return nil, esv1beta1.NoSecretError{}
+}
+
+// GetSecretMap returns all attributes of the entry with the DN key.
+func (c *client) GetSecretMap(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Property != "" {
+		return nil, errPropertyNotSupported
+	}
+	entry, err := c.getEntry(ref, nil)
+	if err != nil {
+		return nil, err
+	}
+	secretMap := make(map[string][]byte, len(entry.Attributes))
+	for _, attr := range entry.Attributes {
+		secretMap[attr.Name], err = attributeBytes(attr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secretMap, nil
+}
+
+// GetAllSecrets is not supported.
+func (c *client) GetAllSecrets(context.Context, esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, errFindNotSupported
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by ldap")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by ldap")
+}
+
+// Validate checks that the directory accepts the bind credentials.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	if _, err := c.connect(); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(context.Context) error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+func (c *client) getEntry(ref esv1beta1.ExternalSecretDataRemoteRef, attributes []string) (*ldap.Entry, error) {
+	if ref.Version != "" {
+		return nil, errVersionNotSupported
+	}
+	if ref.Key == "" {
+		return nil, errMissingKey
+	}
+	dn, err := ldap.ParseDN(ref.Key)
+	if err != nil {
+		return nil, errInvalidKey
+	}
+	if c.baseDN != nil && !c.baseDN.EqualFold(dn) && !c.baseDN.AncestorOfFold(dn) {
+		return nil, errOutsideBaseDN
+	}
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	return conn.Entry(ref.Key, attributes)
+}
+
+func (c *client) connect() (ldapAPI, error) {
+	if c.conn != nil {
+		return c.conn, nil
+	}
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	return conn, nil
+}
+
+// attributeBytes returns a single value as is and multiple values as JSON
+// array.
+func attributeBytes(attr *ldap.EntryAttribute) ([]byte, error) {
+	if len(attr.ByteValues) == 1 {
+		return attr.ByteValues[0], nil
+	}
+	return json.Marshal(attr.Values)
+}
+
+func jsonValue(attr *ldap.EntryAttribute) any {
+	if len(attr.Values) == 1 {
+		return attr.Values[0]
+	}
+	return attr.Values
+}
@@ -0,0 +1,204 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	userDN  = "uid=app,ou=services,dc=example,dc=com"
+	otherDN = "uid=app,ou=people,dc=example,dc=org"
+)
+
+type fakeLDAP struct {
+	entries map[string]map[string][]string
+	closed  bool
+}
+
+func (f *fakeLDAP) Entry(dn string, attributes []string) (*ldap.Entry, error) {
+	attrs, ok := f.entries[dn]
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	entry := &ldap.Entry{DN: dn}
+	for name, values := range attrs {
+		if len(attributes) > 0 && !strings.EqualFold(name, attributes[0]) {
+			continue
+		}
+		entry.Attributes = append(entry.Attributes, ldap.NewEntryAttribute(name, values))
+	}
+	return entry, nil
+}
+
+func (f *fakeLDAP) Close() error {
+	f.closed = true
+	return nil
+}
+
+func newClient(t *testing.T, conn *fakeLDAP, dialErr error) *client {
+	t.Helper()
+	baseDN, err := ldap.ParseDN("ou=services,dc=example,dc=com")
+	require.NoError(t, err)
+	return &client{
+		dial: func() (ldapAPI, error) {
+			if dialErr != nil {
+				return nil, dialErr
+			}
+			return conn, nil
+		},
+		baseDN: baseDN,
+	}
+}
+
+func newFakeLDAP() *fakeLDAP {
+	return &fakeLDAP{entries: map[string]map[string][]string{
+		userDN: {
+			"userPassword": {"s3cr3t"},
+			"mail":         {"app@example.com", "ops@example.com"},
+		},
+	}}
+}
+
+func TestGetSecret(t *testing.T) {
+	errDial := errors.New("connection refused")
+	tests := []struct {
+		name    string
+		ref     esv1beta1.ExternalSecretDataRemoteRef
+		dialErr error
+		want    string
+		wantErr error
+	}{
+		{
+			name: "single value",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: userDN, Property: "userPassword"},
+			want: "s3cr3t",
+		},
+		{
+			name: "case insensitive property",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: userDN, Property: "userpassword"},
+			want: "s3cr3t",
+		},
+		{
+			name: "multiple values",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: userDN, Property: "mail"},
+			want: `["app@example.com","ops@example.com"]`,
+		},
+		{
+			name: "all attributes",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: userDN},
+			want: `{"mail":["app@example.com","ops@example.com"],"userPassword":"s3cr3t"}`,
+		},
+		{
+			name:    "base dn is case insensitive",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "uid=other,OU=Services,DC=example,DC=com", Property: "userPassword"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing attribute",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: userDN, Property: "description"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing entry",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "uid=missing,ou=services,dc=example,dc=com"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{},
+			wantErr: errMissingKey,
+		},
+		{
+			name:    "invalid key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "app"},
+			wantErr: errInvalidKey,
+		},
+		{
+			name:    "outside base dn",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: otherDN},
+			wantErr: errOutsideBaseDN,
+		},
+		{
+			name:    "version",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: userDN, Version: "1"},
+			wantErr: errVersionNotSupported,
+		},
+		{
+			name:    "dial error",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: userDN},
+			dialErr: errDial,
+			wantErr: errDial,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, newFakeLDAP(), tt.dialErr)
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c := newClient(t, newFakeLDAP(), nil)
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: userDN})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"userPassword": []byte("s3cr3t"),
+		"mail":         []byte(`["app@example.com","ops@example.com"]`),
+	}, got)
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: userDN, Property: "mail"})
+	assert.ErrorIs(t, err, errPropertyNotSupported)
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: otherDN})
+	assert.ErrorIs(t, err, errOutsideBaseDN)
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	c := newClient(t, newFakeLDAP(), nil)
+	_, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+	assert.ErrorIs(t, err, errFindNotSupported)
+}
+
+func TestValidateAndClose(t *testing.T) {
+	conn := newFakeLDAP()
+	c := newClient(t, conn, nil)
+	res, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, res)
+	require.NoError(t, c.Close(context.Background()))
+	assert.True(t, conn.closed)
+	assert.Nil(t, c.conn)
+
+	c = newClient(t, conn, errors.New("invalid credentials"))
+	res, err = c.Validate()
+	assert.ErrorContains(t, err, "invalid credentials")
+	assert.Equal(t, esv1beta1.ValidationResultError, res)
+	assert.NoError(t, c.Close(context.Background()))
+}
@@ -0,0 +1,103 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/go-ldap/ldap/v3"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+// ldapAPI is the subset of an LDAP connection used by the provider.
+type ldapAPI interface {
+	// Entry returns the attributes of the entry dn, all user attributes if
+	// attributes is empty. A missing entry returns a NoSecretError.
+	Entry(dn string, attributes []string) (*ldap.Entry, error)
+	Close() error
+}
+
+// connection is an LDAP connection that is bound as the bind DN.
+type connection struct {
+	conn *ldap.Conn
+}
+
+var _ ldapAPI = &connection{}
+
+// dialer opens a bound connection to the directory.
+type dialer struct {
+	url       string
+	startTLS  bool
+	tlsConfig *tls.Config
+	bindDN    string
+	password  string
+}
+
+func (d *dialer) Dial() (ldapAPI, error) {
+	conn, err := ldap.DialURL(d.url,
+		ldap.DialWithTLSConfig(d.tlsConfig),
+		ldap.DialWithDialer(&net.Dialer{Timeout: requestTimeout}))
+	if err != nil {
+		return nil, fmt.Errorf(errConnect, d.url, err)
+	}
+	conn.SetTimeout(requestTimeout)
+	if d.startTLS {
+		if err := conn.StartTLS(d.tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf(errStartTLS, err)
+		}
+	}
+	err = conn.Bind(d.bindDN, d.password)
+	metrics.ObserveAPICall(constants.ProviderLDAP, constants.CallLDAPBind, err)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf(errBind, d.bindDN, err)
+	}
+	return &connection{conn: conn}, nil
+}
+
+func (c *connection) Entry(dn string, attributes []string) (*ldap.Entry, error) {
+	entry, err := c.entry(dn, attributes)
+	metrics.ObserveAPICall(constants.ProviderLDAP, constants.CallLDAPSearch, err)
+	return entry, err
+}
+
+func (c *connection) entry(dn string, attributes []string) (*ldap.Entry, error) {
+	req := ldap.NewSearchRequest(dn, ldap.ScopeBaseObject, ldap.NeverDerefAliases,
+		1, int(requestTimeout.Seconds()), false, "(objectClass=*)", attributes, nil)
+	res, err := c.conn.Search(req)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errSearch, dn, err)
+	}
+	if len(res.Entries) == 0 {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return res.Entries[0], nil
+}
+
+func (c *connection) Close() error {
+	err := c.conn.Close()
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errConnect  = "unable to connect to %s: %w"
+	errStartTLS = "unable to start tls: %w"
+	errBind     = "unable to bind as %s: %w"
+	errSearch   = "unable to read %s: %w"
+
+	requestTimeout = 10 * time.Second
+)
+
+var (
+	errMissingStore         = errors.New("missing store specification")
+	errInvalidSpec          = errors.New("invalid specification for ldap provider")
+	errMissingURL           = errors.New("url must be set")
+	errInvalidURL           = errors.New("url must be an ldap:// or ldaps:// url")
+	errStartTLSWithLDAPS    = errors.New("startTLS can only be used with ldap:// urls")
+	errInvalidBaseDN        = errors.New("baseDN is not a valid DN")
+	errInvalidCABundle      = errors.New("caBundle does not contain a PEM encoded certificate")
+	errMissingBindDN        = errors.New("auth.bindDN must be set")
+	errMissingSecretName    = errors.New("must specify a secret name")
+	errMissingSecretKey     = errors.New("must specify a secret key")
+	errMissingKey           = errors.New("key must be set to the DN of an entry")
+	errInvalidKey           = errors.New("key is not a valid DN")
+	errOutsideBaseDN        = errors.New("key is not inside the baseDN of the store")
+	errVersionNotSupported  = errors.New("specifying a version is not supported by ldap")
+	errPropertyNotSupported = errors.New("property is not supported by dataFrom.extract")
+	errFindNotSupported     = errors.New("find is not supported by ldap")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	password, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &cfg.Auth.BindPasswordSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	u, _ := url.Parse(cfg.URL)
+	tlsConfig := &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	if len(cfg.CABundle) > 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(cfg.CABundle)
+	}
+	d := &dialer{
+		url:       cfg.URL,
+		startTLS:  cfg.StartTLS,
+		tlsConfig: tlsConfig,
+		bindDN:    cfg.Auth.BindDN,
+		password:  password,
+	}
+	c := &client{dial: d.Dial}
+	if cfg.BaseDN != "" {
+		c.baseDN, _ = ldap.ParseDN(cfg.BaseDN)
+	}
+	return c, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.LDAPProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.LDAP == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.LDAP
+
+	if cfg.URL == "" {
+		return nil, errMissingURL
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+		return nil, errInvalidURL
+	}
+	if cfg.StartTLS && u.Scheme != "ldap" {
+		return nil, errStartTLSWithLDAPS
+	}
+	if cfg.BaseDN != "" {
+		if _, err := ldap.ParseDN(cfg.BaseDN); err != nil {
+			return nil, errInvalidBaseDN
+		}
+	}
+	if len(cfg.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(cfg.CABundle) {
+		return nil, errInvalidCABundle
+	}
+	if cfg.Auth.BindDN == "" {
+		return nil, errMissingBindDN
+	}
+
+	ref := cfg.Auth.BindPasswordSecretRef
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return nil, err
+	}
+	if ref.Name == "" {
+		return nil, errMissingSecretName
+	}
+	if ref.Key == "" {
+		return nil, errMissingSecretKey
+	}
+	return cfg, nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		LDAP: &esv1beta1.LDAPProvider{},
+	})
+}
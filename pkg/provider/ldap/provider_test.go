@@ -0,0 +1,181 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const testCA = `-----BEGIN CERTIFICATE-----
+MIIBhTCCASugAwIBAgIQIRi6zePL6mKjOipn+dNuaTAKBggqhkjOPQQDAjASMRAw
+DgYDVQQKEwdBY21lIENvMB4XDTE3MTAyMDE5NDMwNloXDTE4MTAyMDE5NDMwNlow
+EjEQMA4GA1UEChMHQWNtZSBDbzBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABD0d
+7VNhbWvZLWPuj/RtHFjvtJBEwOkhbN/BnnE8rnZR8+sbwnc/KhCk3FhnpHZnQz7B
+5aETbbIgmuvewdjvSBSjYzBhMA4GA1UdDwEB/wQEAwICpDATBgNVHSUEDDAKBggr
+BgEFBQcDATAPBgNVHRMBAf8EBTADAQH/MCkGA1UdEQQiMCCCDmxvY2FsaG9zdDo1
+NDUzgg4xMjcuMC4wLjE6NTQ1MzAKBggqhkjOPQQDAgNIADBFAiEA2zpJEPQyz6/l
+Wf86aX6PepsntZv2GYlA5UpabfT2EZICICpJ5h/iI+i341gBmLiAFQOyTDT+/wQc
+6MF9+Yw1Yy0t
+-----END CERTIFICATE-----`
+
+func newStore(provider *esv1beta1.LDAPProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{LDAP: provider},
+		},
+	}
+}
+
+func validProvider() *esv1beta1.LDAPProvider {
+	return &esv1beta1.LDAPProvider{
+		URL:    "ldaps://ldap.example.com:636",
+		BaseDN: "ou=services,dc=example,dc=com",
+		Auth: esv1beta1.LDAPAuth{
+			BindDN:                "cn=eso,ou=services,dc=example,dc=com",
+			BindPasswordSecretRef: esmeta.SecretKeySelector{Name: "ldap", Key: "password"},
+		},
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*esv1beta1.LDAPProvider)
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "missing url",
+			modify:  func(p *esv1beta1.LDAPProvider) { p.URL = "" },
+			wantErr: errMissingURL,
+		},
+		{
+			name:    "invalid url scheme",
+			modify:  func(p *esv1beta1.LDAPProvider) { p.URL = "https://ldap.example.com" },
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "url without host",
+			modify:  func(p *esv1beta1.LDAPProvider) { p.URL = "ldap.example.com" },
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "starttls with ldaps",
+			modify:  func(p *esv1beta1.LDAPProvider) { p.StartTLS = true },
+			wantErr: errStartTLSWithLDAPS,
+		},
+		{
+			name:    "invalid base dn",
+			modify:  func(p *esv1beta1.LDAPProvider) { p.BaseDN = "example.com" },
+			wantErr: errInvalidBaseDN,
+		},
+		{
+			name:    "invalid ca bundle",
+			modify:  func(p *esv1beta1.LDAPProvider) { p.CABundle = []byte("ca") },
+			wantErr: errInvalidCABundle,
+		},
+		{
+			name:    "missing bind dn",
+			modify:  func(p *esv1beta1.LDAPProvider) { p.Auth.BindDN = "" },
+			wantErr: errMissingBindDN,
+		},
+		{
+			name:    "missing secret name",
+			modify:  func(p *esv1beta1.LDAPProvider) { p.Auth.BindPasswordSecretRef.Name = "" },
+			wantErr: errMissingSecretName,
+		},
+		{
+			name:    "missing secret key",
+			modify:  func(p *esv1beta1.LDAPProvider) { p.Auth.BindPasswordSecretRef.Key = "" },
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name:   "valid",
+			modify: func(p *esv1beta1.LDAPProvider) {},
+		},
+		{
+			name: "valid starttls",
+			modify: func(p *esv1beta1.LDAPProvider) {
+				p.URL = "ldap://ldap.example.com"
+				p.StartTLS = true
+				p.BaseDN = ""
+				p.CABundle = []byte(testCA)
+			},
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := tt.store
+			if tt.modify != nil {
+				provider := validProvider()
+				tt.modify(provider)
+				store = newStore(provider)
+			}
+			_, err := p.ValidateStore(store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}).Build()
+	p := &Provider{}
+	cfg := validProvider()
+	cfg.CABundle = []byte(testCA)
+	sc, err := p.NewClient(context.Background(), newStore(cfg), kube, "default")
+	require.NoError(t, err)
+	c := sc.(*client)
+	require.NotNil(t, c.baseDN)
+	assert.Equal(t, "ou=services,dc=example,dc=com", c.baseDN.String())
+	assert.Nil(t, c.conn)
+
+	// an unreachable directory fails on first use, not on client creation.
+	cfg.URL = "ldap://127.0.0.1:1"
+	sc, err = p.NewClient(context.Background(), newStore(cfg), kube, "default")
+	require.NoError(t, err)
+	_, err = sc.Validate()
+	assert.ErrorContains(t, err, "unable to connect to ldap://127.0.0.1:1")
+
+	cfg.Auth.BindPasswordSecretRef.Key = "missing"
+	_, err = p.NewClient(context.Background(), newStore(cfg), kube, "default")
+	assert.Error(t, err)
+}
@@ -0,0 +1,161 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	pluginv1 "github.com/external-secrets/external-secrets/pkg/plugin/api/v1"
+)
+
+type client struct {
+	// name of the ProviderPlugin, used in error messages.
+	name    string
+	plugin  pluginv1.ProviderClient
+	store   *pluginv1.Store
+	timeout time.Duration
+	close   func() error
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+var _ esv1beta1.UpdatePolicyClient = &client{}
+
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	res, err := c.plugin.GetSecret(ctx, &pluginv1.GetSecretRequest{Store: c.store, Ref: remoteRef(ref)})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginGetSecret, err)
+	if err != nil {
+		return nil, c.fromStatus(err)
+	}
+	return res.GetValue(), nil
+}
+
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	res, err := c.plugin.GetSecretMap(ctx, &pluginv1.GetSecretMapRequest{Store: c.store, Ref: remoteRef(ref)})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginGetSecretMap, err)
+	if err != nil {
+		return nil, c.fromStatus(err)
+	}
+	return res.GetData(), nil
+}
+
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	find := &pluginv1.Find{Path: ref.Path, Tags: ref.Tags}
+	if ref.Name != nil {
+		find.NameRegexp = ref.Name.RegExp
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	res, err := c.plugin.GetAllSecrets(ctx, &pluginv1.GetAllSecretsRequest{Store: c.store, Find: find})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginGetAllSecrets, err)
+	if err != nil {
+		return nil, c.fromStatus(err)
+	}
+	return res.GetData(), nil
+}
+
+func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	return c.PushSecretWithPolicy(ctx, secret, data, "")
+}
+
+// PushSecretWithPolicy passes the update policy on to the plugin, plugins
+// that do not support it return UNIMPLEMENTED.
+func (c *client) PushSecretWithPolicy(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData, policy esv1beta1.PushSecretUpdatePolicy) error {
+	req := &pluginv1.PushSecretRequest{
+		Store:        c.store,
+		SecretData:   secret.Data,
+		SecretType:   string(secret.Type),
+		SecretKey:    data.GetSecretKey(),
+		RemoteRef:    &pluginv1.PushRemoteRef{RemoteKey: data.GetRemoteKey(), Property: data.GetProperty()},
+		UpdatePolicy: string(policy),
+	}
+	if data.GetMetadata() != nil {
+		req.Metadata = data.GetMetadata().Raw
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	_, err := c.plugin.PushSecret(ctx, req)
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginPushSecret, err)
+	return c.fromStatus(err)
+}
+
+func (c *client) DeleteSecret(ctx context.Context, ref esv1beta1.PushSecretRemoteRef) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	_, err := c.plugin.DeleteSecret(ctx, &pluginv1.DeleteSecretRequest{
+		Store:     c.store,
+		RemoteRef: &pluginv1.PushRemoteRef{RemoteKey: ref.GetRemoteKey(), Property: ref.GetProperty()},
+	})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginDeleteSecret, err)
+	return c.fromStatus(err)
+}
+
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	res, err := c.plugin.Validate(ctx, &pluginv1.ValidateRequest{Store: c.store})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginValidate, err)
+	if err != nil {
+		return esv1beta1.ValidationResultError, c.fromStatus(err)
+	}
+	switch res.GetResult() {
+	case pluginv1.ValidateResponse_READY:
+		return esv1beta1.ValidationResultReady, nil
+	case pluginv1.ValidateResponse_UNKNOWN:
+		return esv1beta1.ValidationResultUnknown, nil
+	default:
+		return esv1beta1.ValidationResultError, fmt.Errorf(errPluginInvalid, c.name, res.GetMessage())
+	}
+}
+
+func (c *client) Close(context.Context) error {
+	return c.close()
+}
+
+// fromStatus converts the gRPC status of a failed call: NOT_FOUND is a
+// NoSecretError, other codes keep the message of the plugin.
+func (c *client) fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.NotFound:
+		return esv1beta1.NoSecretError{}
+	case codes.Unimplemented:
+		return fmt.Errorf(errPluginUnsupported, c.name, st.Message())
+	default:
+		return fmt.Errorf(errPluginCall, c.name, st.Code(), st.Message())
+	}
+}
+
+func remoteRef(ref esv1beta1.ExternalSecretDataRemoteRef) *pluginv1.RemoteRef {
+	return &pluginv1.RemoteRef{
+		Key:            ref.Key,
+		Version:        ref.Version,
+		Property:       ref.Property,
+		MetadataPolicy: string(ref.MetadataPolicy),
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esplugin "github.com/external-secrets/external-secrets/pkg/plugin"
+	pluginv1 "github.com/external-secrets/external-secrets/pkg/plugin/api/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+// memoryClient is the SecretsClient of a plugin that keeps secrets in memory.
+type memoryClient struct {
+	store   esplugin.Store
+	secrets map[string][]byte
+	pushed  *corev1.Secret
+	data    esv1beta1.PushSecretData
+	policy  esv1beta1.PushSecretUpdatePolicy
+	closed  bool
+}
+
+func (m *memoryClient) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if ref.Version != "" {
+		return nil, errors.New("versions are not supported")
+	}
+	value, ok := m.secrets[ref.Key]
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return value, nil
+}
+
+func (m *memoryClient) GetSecretMap(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	return map[string][]byte{ref.Key: m.secrets[ref.Key], "config": m.store.Config}, nil
+}
+
+func (m *memoryClient) GetAllSecrets(_ context.Context, find esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return map[string][]byte{"path": []byte(*find.Path), "name": []byte(find.Name.RegExp), "env": []byte(find.Tags["env"])}, nil
+}
+
+func (m *memoryClient) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	return m.PushSecretWithPolicy(ctx, secret, data, esv1beta1.PushSecretUpdatePolicyReplace)
+}
+
+func (m *memoryClient) PushSecretWithPolicy(_ context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData, policy esv1beta1.PushSecretUpdatePolicy) error {
+	m.pushed, m.data, m.policy = secret, data, policy
+	return nil
+}
+
+func (m *memoryClient) DeleteSecret(_ context.Context, ref esv1beta1.PushSecretRemoteRef) error {
+	if _, ok := m.secrets[ref.GetRemoteKey()]; !ok {
+		return esv1beta1.NoSecretError{}
+	}
+	delete(m.secrets, ref.GetRemoteKey())
+	return nil
+}
+
+func (m *memoryClient) Validate() (esv1beta1.ValidationResult, error) {
+	if string(m.store.Secrets["token"]) != "s3cr3t" {
+		return esv1beta1.ValidationResultError, errors.New("invalid token")
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (m *memoryClient) Close(context.Context) error {
+	m.closed = true
+	return nil
+}
+
+// readOnlyClient does not support update policies.
+type readOnlyClient struct {
+	esv1beta1.SecretsClient
+}
+
+// newTestClient serves newClient through a plugin server on an in-memory
+// connection and returns a client of the provider for it.
+func newTestClient(t *testing.T, newClient esplugin.NewClientFunc) *client {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pluginv1.RegisterProviderServer(srv, esplugin.NewServer(newClient))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	return &client{
+		name:   "memory",
+		plugin: pluginv1.NewProviderClient(conn),
+		store: &pluginv1.Store{
+			Name:      "memory",
+			Kind:      esv1beta1.SecretStoreKind,
+			Namespace: "default",
+			Config:    []byte(`{"region":"eu"}`),
+			Secrets:   map[string][]byte{"token": []byte("s3cr3t")},
+		},
+		timeout: time.Second,
+		close:   conn.Close,
+	}
+}
+
+func TestClient(t *testing.T) {
+	var mem *memoryClient
+	c := newTestClient(t, func(_ context.Context, store esplugin.Store) (esv1beta1.SecretsClient, error) {
+		mem = &memoryClient{store: store, secrets: map[string][]byte{"db": []byte("password")}}
+		return mem, nil
+	})
+	ctx := context.Background()
+
+	got, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db"})
+	require.NoError(t, err)
+	assert.Equal(t, "password", string(got))
+	assert.Equal(t, esplugin.Store{
+		Name:      "memory",
+		Kind:      esv1beta1.SecretStoreKind,
+		Namespace: "default",
+		Config:    []byte(`{"region":"eu"}`),
+		Secrets:   map[string][]byte{"token": []byte("s3cr3t")},
+	}, mem.store)
+	assert.True(t, mem.closed)
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretError{})
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Version: "1"})
+	assert.EqualError(t, err, "plugin memory failed with Unknown: versions are not supported")
+
+	secretMap, err := c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"db": []byte("password"), "config": []byte(`{"region":"eu"}`)}, secretMap)
+
+	path := "apps/"
+	all, err := c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Path: &path, Name: &esv1beta1.FindName{RegExp: "^db"}, Tags: map[string]string{"env": "prod"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"path": []byte("apps/"), "name": []byte("^db"), "env": []byte("prod")}, all)
+
+	secret := &corev1.Secret{Type: corev1.SecretTypeOpaque, Data: map[string][]byte{"key": []byte("value")}}
+	data := fake.PushSecretData{
+		Metadata:  &apiextensionsv1.JSON{Raw: []byte(`{"labels":{"team":"a"}}`)},
+		SecretKey: "key",
+		RemoteKey: "remote",
+		Property:  "prop",
+	}
+	require.NoError(t, c.PushSecretWithPolicy(ctx, secret, data, esv1beta1.PushSecretUpdatePolicyIfNotExists))
+	assert.Equal(t, secret.Data, mem.pushed.Data)
+	assert.Equal(t, secret.Type, mem.pushed.Type)
+	assert.Equal(t, esv1beta1.PushSecretUpdatePolicyIfNotExists, mem.policy)
+	assert.Equal(t, "key", mem.data.GetSecretKey())
+	assert.Equal(t, "remote", mem.data.GetRemoteKey())
+	assert.Equal(t, "prop", mem.data.GetProperty())
+	assert.JSONEq(t, `{"labels":{"team":"a"}}`, string(mem.data.GetMetadata().Raw))
+
+	require.NoError(t, c.PushSecret(ctx, secret, fake.PushSecretData{RemoteKey: "remote"}))
+	assert.Equal(t, esv1beta1.PushSecretUpdatePolicyReplace, mem.policy)
+	assert.Nil(t, mem.data.GetMetadata())
+
+	require.NoError(t, c.DeleteSecret(ctx, fake.PushSecretData{RemoteKey: "db"}))
+	assert.ErrorIs(t, c.DeleteSecret(ctx, fake.PushSecretData{RemoteKey: "missing"}), esv1beta1.NoSecretError{})
+
+	res, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, res)
+
+	c.store.Secrets["token"] = []byte("wrong")
+	res, err = c.Validate()
+	assert.EqualError(t, err, "plugin memory reports an invalid store: invalid token")
+	assert.Equal(t, esv1beta1.ValidationResultError, res)
+
+	assert.NoError(t, c.Close(ctx))
+}
+
+func TestClientUnsupported(t *testing.T) {
+	c := newTestClient(t, func(_ context.Context, store esplugin.Store) (esv1beta1.SecretsClient, error) {
+		if len(store.Config) == 0 {
+			return nil, errors.New("missing config")
+		}
+		return readOnlyClient{&memoryClient{store: store}}, nil
+	})
+	ctx := context.Background()
+	secret := &corev1.Secret{Data: map[string][]byte{"key": []byte("value")}}
+
+	err := c.PushSecretWithPolicy(ctx, secret, fake.PushSecretData{RemoteKey: "remote"}, esv1beta1.PushSecretUpdatePolicyMerge)
+	assert.EqualError(t, err, "plugin memory does not support the call: update policy Merge is not supported")
+
+	c.store.Config = nil
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db"})
+	assert.EqualError(t, err, "plugin memory failed with Unknown: missing config")
+}
+
+func TestClientUnavailable(t *testing.T) {
+	conn, err := dial(&esv1alpha1.ProviderPluginSpec{Address: "unix://" + t.TempDir() + "/missing.sock"})
+	require.NoError(t, err)
+	c := &client{
+		name:    "memory",
+		plugin:  pluginv1.NewProviderClient(conn),
+		store:   &pluginv1.Store{},
+		timeout: 100 * time.Millisecond,
+		close:   conn.Close,
+	}
+	_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db"})
+	assert.ErrorContains(t, err, "plugin memory failed with Unavailable")
+	assert.NoError(t, c.Close(context.Background()))
+}
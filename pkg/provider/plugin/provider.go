@@ -0,0 +1,176 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	pluginv1 "github.com/external-secrets/external-secrets/pkg/plugin/api/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errGetPlugin         = "unable to get ProviderPlugin %s: %w"
+	errDialPlugin        = "unable to connect to plugin %s: %w"
+	errPluginCall        = "plugin %s failed with %s: %s"
+	errPluginUnsupported = "plugin %s does not support the call: %s"
+	errPluginInvalid     = "plugin %s reports an invalid store: %s"
+
+	defaultTimeout = 30 * time.Second
+)
+
+var (
+	errMissingStore         = errors.New("missing store specification")
+	errInvalidSpec          = errors.New("invalid specification for plugin provider")
+	errMissingName          = errors.New("name of the ProviderPlugin must be set")
+	errMissingSecretName    = errors.New("must specify a secret name")
+	errMissingSecretKey     = errors.New("must specify a secret key")
+	errUnnamedSecret        = errors.New("secrets must have a name")
+	errDuplicateSecretName  = errors.New("secret names must be unique")
+	errInvalidPluginCA      = errors.New("caBundle of the ProviderPlugin does not contain a PEM encoded certificate")
+	errMissingPluginAddress = errors.New("address of the ProviderPlugin must be set")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+// Plugins reject the calls they do not support.
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadWrite
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	plugin := &esv1alpha1.ProviderPlugin{}
+	if err := kube.Get(ctx, kclient.ObjectKey{Name: cfg.Name}, plugin); err != nil {
+		return nil, fmt.Errorf(errGetPlugin, cfg.Name, err)
+	}
+
+	pluginStore := &pluginv1.Store{
+		Name:      store.GetName(),
+		Kind:      store.GetKind(),
+		Namespace: namespace,
+		Secrets:   make(map[string][]byte, len(cfg.Secrets)),
+	}
+	if cfg.Config != nil {
+		pluginStore.Config = cfg.Config.Raw
+	}
+	for _, secret := range cfg.Secrets {
+		value, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &secret.SecretRef)
+		if err != nil {
+			return nil, err
+		}
+		pluginStore.Secrets[secret.Name] = []byte(value)
+	}
+
+	conn, err := dial(&plugin.Spec)
+	if err != nil {
+		return nil, fmt.Errorf(errDialPlugin, cfg.Name, err)
+	}
+	c := &client{
+		name:    cfg.Name,
+		plugin:  pluginv1.NewProviderClient(conn),
+		store:   pluginStore,
+		timeout: defaultTimeout,
+		close:   conn.Close,
+	}
+	if plugin.Spec.Timeout != nil {
+		c.timeout = plugin.Spec.Timeout.Duration
+	}
+	return c, nil
+}
+
+// dial returns a connection to the plugin. The connection is established on
+// the first call.
+func dial(spec *esv1alpha1.ProviderPluginSpec) (*grpc.ClientConn, error) {
+	if spec.Address == "" {
+		return nil, errMissingPluginAddress
+	}
+	creds := insecure.NewCredentials()
+	if len(spec.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(spec.CABundle) {
+			return nil, errInvalidPluginCA
+		}
+		creds = credentials.NewTLS(&tls.Config{
+			RootCAs:    pool,
+			ServerName: spec.ServerName,
+			MinVersion: tls.VersionTLS12,
+		})
+	}
+	return grpc.Dial(spec.Address, grpc.WithTransportCredentials(creds))
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.PluginProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Plugin == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.Plugin
+	if cfg.Name == "" {
+		return nil, errMissingName
+	}
+	names := make(map[string]bool, len(cfg.Secrets))
+	for _, secret := range cfg.Secrets {
+		if secret.Name == "" {
+			return nil, errUnnamedSecret
+		}
+		if names[secret.Name] {
+			return nil, errDuplicateSecretName
+		}
+		names[secret.Name] = true
+		if err := utils.ValidateReferentSecretSelector(store, secret.SecretRef); err != nil {
+			return nil, err
+		}
+		if secret.SecretRef.Name == "" {
+			return nil, errMissingSecretName
+		}
+		if secret.SecretRef.Key == "" {
+			return nil, errMissingSecretKey
+		}
+	}
+	return cfg, nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Plugin: &esv1beta1.PluginProvider{},
+	})
+}
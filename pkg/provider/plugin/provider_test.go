@@ -0,0 +1,164 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func newStore(provider *esv1beta1.PluginProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "vendor", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Plugin: provider},
+		},
+	}
+}
+
+func pluginSecret(name, secretName, key string) esv1beta1.PluginSecret {
+	return esv1beta1.PluginSecret{Name: name, SecretRef: esmeta.SecretKeySelector{Name: secretName, Key: key}}
+}
+
+func TestValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "missing name",
+			store:   newStore(&esv1beta1.PluginProvider{}),
+			wantErr: errMissingName,
+		},
+		{
+			name:    "unnamed secret",
+			store:   newStore(&esv1beta1.PluginProvider{Name: "vendor", Secrets: []esv1beta1.PluginSecret{pluginSecret("", "vendor", "token")}}),
+			wantErr: errUnnamedSecret,
+		},
+		{
+			name: "duplicate secret name",
+			store: newStore(&esv1beta1.PluginProvider{Name: "vendor", Secrets: []esv1beta1.PluginSecret{
+				pluginSecret("token", "vendor", "token"),
+				pluginSecret("token", "vendor", "other"),
+			}}),
+			wantErr: errDuplicateSecretName,
+		},
+		{
+			name:    "missing secret name",
+			store:   newStore(&esv1beta1.PluginProvider{Name: "vendor", Secrets: []esv1beta1.PluginSecret{pluginSecret("token", "", "token")}}),
+			wantErr: errMissingSecretName,
+		},
+		{
+			name:    "missing secret key",
+			store:   newStore(&esv1beta1.PluginProvider{Name: "vendor", Secrets: []esv1beta1.PluginSecret{pluginSecret("token", "vendor", "")}}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name: "valid",
+			store: newStore(&esv1beta1.PluginProvider{
+				Name:    "vendor",
+				Config:  &apiextensionsv1.JSON{Raw: []byte(`{"region":"eu"}`)},
+				Secrets: []esv1beta1.PluginSecret{pluginSecret("token", "vendor", "token")},
+			}),
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1alpha1.AddToScheme(scheme))
+	kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&esv1alpha1.ProviderPlugin{
+			ObjectMeta: metav1.ObjectMeta{Name: "vendor"},
+			Spec: esv1alpha1.ProviderPluginSpec{
+				Address: "unix:///plugins/vendor.sock",
+				Timeout: &metav1.Duration{Duration: 5 * time.Second},
+			},
+		},
+		&esv1alpha1.ProviderPlugin{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-ca"},
+			Spec:       esv1alpha1.ProviderPluginSpec{Address: "dns:///vendor:8080", CABundle: []byte("ca")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vendor", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("s3cr3t")},
+		},
+	).Build()
+	p := &Provider{}
+	cfg := &esv1beta1.PluginProvider{
+		Name:    "vendor",
+		Config:  &apiextensionsv1.JSON{Raw: []byte(`{"region":"eu"}`)},
+		Secrets: []esv1beta1.PluginSecret{pluginSecret("token", "vendor", "token")},
+	}
+	sc, err := p.NewClient(context.Background(), newStore(cfg), kube, "default")
+	require.NoError(t, err)
+	c := sc.(*client)
+	assert.Equal(t, "vendor", c.name)
+	assert.Equal(t, 5*time.Second, c.timeout)
+	assert.Equal(t, "vendor", c.store.GetName())
+	assert.Equal(t, esv1beta1.SecretStoreKind, c.store.GetKind())
+	assert.Equal(t, "default", c.store.GetNamespace())
+	assert.Equal(t, `{"region":"eu"}`, string(c.store.GetConfig()))
+	assert.Equal(t, map[string][]byte{"token": []byte("s3cr3t")}, c.store.GetSecrets())
+	assert.NoError(t, sc.Close(context.Background()))
+
+	cfg.Name = "invalid-ca"
+	_, err = p.NewClient(context.Background(), newStore(cfg), kube, "default")
+	assert.ErrorIs(t, err, errInvalidPluginCA)
+
+	cfg.Name = "missing"
+	_, err = p.NewClient(context.Background(), newStore(cfg), kube, "default")
+	assert.ErrorContains(t, err, "unable to get ProviderPlugin missing")
+
+	cfg.Name = "vendor"
+	cfg.Secrets = []esv1beta1.PluginSecret{pluginSecret("token", "vendor", "missing")}
+	_, err = p.NewClient(context.Background(), newStore(cfg), kube, "default")
+	assert.Error(t, err)
+}
@@ -0,0 +1,219 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package puppet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/guarded"
+)
+
+// dataFileExtensions are the extensions of hiera data files, key without
+// extension refer to a .yaml file.
+var dataFileExtensions = []string{".yaml", ".yml", ".eyaml", ".json"}
+
+type client struct {
+	source dataSource
+	// decrypter is nil if no eyaml keypair is configured.
+	decrypter *eyamlDecrypter
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the value of the hiera key property from the data file
+// key, e.g. common or nodes/web-1.example.com, relative to the datadir.
+// Nested values are selected with the dotted key syntax of hiera, e.g.
+// profile::db.password. Without property the whole data file is returned as
+// JSON. Values encrypted with hiera-eyaml are decrypted.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, err := c.getValue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return utils.GetByteValue(value)
+}
+
+// GetSecretMap returns the keys of the data file, or of the hash selected by
+// property, as map.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	value, err := c.getValue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	hash, ok := value.(map[string]any)
+	if !ok {
+		return nil, errNotAHash
+	}
+	return toSecretMap(hash)
+}
+
+// GetAllSecrets returns the keys of the data file ref.Path whose name
+// matches ref.Name.
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Path == nil || *ref.Path == "" {
+		return nil, errFindPathRequired
+	}
+	if len(ref.Tags) > 0 {
+		return nil, errFindTagsNotSupported
+	}
+	data, err := c.readDataFile(ctx, *ref.Path)
+	if err != nil {
+		return nil, err
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	matches := make(map[string]any)
+	for k, v := range data {
+		if matcher != nil && !matcher.MatchName(k) {
+			continue
+		}
+		dec, err := decryptValues(c.decrypter, v)
+		if err != nil {
+			return nil, fmt.Errorf(errDecryptKey, k, err)
+		}
+		matches[k] = dec
+	}
+	return toSecretMap(matches)
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by Puppet")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by Puppet")
+}
+
+// Validate checks that the source can be accessed.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	return c.source.Validate(ctx)
+}
+
+// Close wipes the eyaml private key.
+func (c *client) Close(context.Context) error {
+	if c.decrypter != nil {
+		guarded.WipePrivateKey(c.decrypter.key)
+	}
+	return nil
+}
+
+// getValue returns the decrypted value referenced by ref.
+func (c *client) getValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (any, error) {
+	if ref.Version != "" {
+		return nil, errVersionNotSupported
+	}
+	data, err := c.readDataFile(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	var value any = data
+	if ref.Property != "" {
+		var ok bool
+		if value, ok = lookup(data, ref.Property); !ok {
+			return nil, esv1beta1.NoSecretError{}
+		}
+	}
+	value, err = decryptValues(c.decrypter, value)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptKey, ref.Property, err)
+	}
+	return value, nil
+}
+
+// readDataFile returns the parsed data file, key is the path relative to
+// the datadir with or without extension.
+func (c *client) readDataFile(ctx context.Context, key string) (map[string]any, error) {
+	name := path.Clean(key)
+	if key == "" || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return nil, fmt.Errorf(errInvalidDataFile, key)
+	}
+	hasExtension := false
+	for _, ext := range dataFileExtensions {
+		if strings.HasSuffix(name, ext) {
+			hasExtension = true
+			break
+		}
+	}
+	if !hasExtension {
+		name += ".yaml"
+	}
+	raw, err := c.source.ReadFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]any
+	if err := yaml.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf(errParseDataFile, name, err)
+	}
+	if data == nil {
+		data = map[string]any{}
+	}
+	return data, nil
+}
+
+// lookup returns the value of a hiera key. Keys that do not exist at the top
+// level are split at dots to dig into hashes and arrays, like hiera does.
+func lookup(data map[string]any, key string) (any, bool) {
+	if v, ok := data[key]; ok {
+		return v, true
+	}
+	var value any = data
+	for _, segment := range strings.Split(key, ".") {
+		switch v := value.(type) {
+		case map[string]any:
+			var ok bool
+			if value, ok = v[segment]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func toSecretMap(hash map[string]any) (map[string][]byte, error) {
+	secretMap := make(map[string][]byte, len(hash))
+	for k, v := range hash {
+		var err error
+		secretMap[k], err = utils.GetByteValue(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secretMap, nil
+}
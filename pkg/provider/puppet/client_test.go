@@ -0,0 +1,277 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package puppet
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mozilla.org/pkcs7"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// newKeypair returns a PEM encoded certificate and private key like eyaml createkeys.
+func newKeypair(t *testing.T) (*x509.Certificate, []byte, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "/"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return cert, certPEM, keyPEM
+}
+
+func eyamlEncrypt(t *testing.T, cert *x509.Certificate, value string) string {
+	t.Helper()
+	pkcs7.ContentEncryptionAlgorithm = pkcs7.EncryptionAlgorithmAES256CBC
+	der, err := pkcs7.Encrypt([]byte(value), []*x509.Certificate{cert})
+	require.NoError(t, err)
+	return "ENC[PKCS7," + base64.StdEncoding.EncodeToString(der) + "]"
+}
+
+// dataFiles returns the files of a datadir with encrypted values.
+func dataFiles(t *testing.T, cert *x509.Certificate) map[string]string {
+	t.Helper()
+	encrypted := eyamlEncrypt(t, cert, "s3cr3t")
+	// eyaml edit writes long values as folded block scalars
+	folded := encrypted[:40] + "\n    " + encrypted[40:]
+	return map[string]string{
+		"common.yaml": `---
+profile::db::user: app
+profile::db::password: ` + encrypted + `
+profile::app::settings:
+  port: 8080
+  api_key: >
+    ` + folded + `
+  hosts:
+    - web-1
+    - web-2
+`,
+		"nodes/web-1.example.com.yaml": "profile::app::settings:\n  port: 9090\n",
+		"gpg.yaml":                     "profile::db::password: ENC[GPG,aGVsbG8=]\n",
+	}
+}
+
+func newGitRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	for name, content := range files {
+		p := filepath.Join(dir, "data", name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+	_, err = wt.Add("data")
+	require.NoError(t, err)
+	_, err = wt.Commit("hiera data", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	return dir
+}
+
+func TestGetSecret(t *testing.T) {
+	cert, certPEM, keyPEM := newKeypair(t)
+	decrypter, err := newEYAMLDecrypter(certPEM, keyPEM)
+	require.NoError(t, err)
+	files := dataFiles(t, cert)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		content, ok := files[r.URL.Path[1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	sources := map[string]dataSource{
+		"git": &gitSource{url: newGitRepo(t, files), dataDir: "data"},
+		"http": &httpSource{url: server.URL, client: server.Client(), authenticate: func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer token")
+		}},
+	}
+	tests := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		want     string
+		wantErr  error
+		contains string
+	}{
+		{
+			name: "plain value",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "common", Property: "profile::db::user"},
+			want: "app",
+		},
+		{
+			name: "encrypted value",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "common.yaml", Property: "profile::db::password"},
+			want: "s3cr3t",
+		},
+		{
+			name: "nested folded encrypted value",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "common", Property: "profile::app::settings.api_key"},
+			want: "s3cr3t",
+		},
+		{
+			name: "array element",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "common", Property: "profile::app::settings.hosts.1"},
+			want: "web-2",
+		},
+		{
+			name: "hash with encrypted value",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "common", Property: "profile::app::settings"},
+			want: `{"api_key":"s3cr3t","hosts":["web-1","web-2"],"port":8080}`,
+		},
+		{
+			name: "data file in subdirectory",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "nodes/web-1.example.com", Property: "profile::app::settings.port"},
+			want: "9090",
+		},
+		{
+			name:    "missing key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "common", Property: "profile::db::token"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing data file",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "nodes/web-2.example.com", Property: "profile::db::user"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:     "data file outside of datadir",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "../secrets", Property: "password"},
+			contains: "invalid data file",
+		},
+		{
+			name:     "unsupported encryption",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "gpg", Property: "profile::db::password"},
+			contains: "unsupported eyaml encryption method GPG",
+		},
+		{
+			name:    "version",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "common", Property: "profile::db::user", Version: "1"},
+			wantErr: errVersionNotSupported,
+		},
+	}
+	for sourceName, source := range sources {
+		c := &client{source: source, decrypter: decrypter}
+		for _, tt := range tests {
+			t.Run(sourceName+"/"+tt.name, func(t *testing.T) {
+				got, err := c.GetSecret(context.Background(), tt.ref)
+				switch {
+				case tt.wantErr != nil:
+					assert.ErrorIs(t, err, tt.wantErr)
+				case tt.contains != "":
+					assert.ErrorContains(t, err, tt.contains)
+				default:
+					require.NoError(t, err)
+					assert.Equal(t, tt.want, string(got))
+				}
+			})
+		}
+	}
+
+	c := &client{source: sources["git"]}
+	_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "common", Property: "profile::db::password"})
+	assert.ErrorIs(t, err, errMissingEYAMLKeys)
+}
+
+func TestGetSecretMap(t *testing.T) {
+	cert, certPEM, keyPEM := newKeypair(t)
+	decrypter, err := newEYAMLDecrypter(certPEM, keyPEM)
+	require.NoError(t, err)
+	c := &client{source: &gitSource{url: newGitRepo(t, dataFiles(t, cert)), dataDir: "data"}, decrypter: decrypter}
+
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "common", Property: "profile::app::settings"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"port":    []byte("8080"),
+		"api_key": []byte("s3cr3t"),
+		"hosts":   []byte(`["web-1","web-2"]`),
+	}, got)
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "common", Property: "profile::db::user"})
+	assert.ErrorIs(t, err, errNotAHash)
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	cert, certPEM, keyPEM := newKeypair(t)
+	decrypter, err := newEYAMLDecrypter(certPEM, keyPEM)
+	require.NoError(t, err)
+	c := &client{source: &gitSource{url: newGitRepo(t, dataFiles(t, cert)), dataDir: "data"}, decrypter: decrypter}
+
+	path := "common"
+	got, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Path: &path,
+		Name: &esv1beta1.FindName{RegExp: "^profile::db::"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"profile::db::user":     []byte("app"),
+		"profile::db::password": []byte("s3cr3t"),
+	}, got)
+
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}})
+	assert.ErrorIs(t, err, errFindPathRequired)
+}
+
+func TestValidate(t *testing.T) {
+	c := &client{source: &gitSource{url: newGitRepo(t, map[string]string{"common.yaml": "a: b\n"}), dataDir: "data"}}
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	c = &client{source: &gitSource{url: filepath.Join(t.TempDir(), "missing"), dataDir: "data"}}
+	result, err = c.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+
+	c = &client{source: &httpSource{url: "https://puppet.example.com/data"}}
+	result, err = c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultUnknown, result)
+}
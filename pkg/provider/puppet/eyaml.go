@@ -0,0 +1,118 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package puppet
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mozilla.org/pkcs7"
+)
+
+// encryptedValue matches values encrypted by hiera-eyaml. Multiline values,
+// as written by eyaml edit, contain whitespace in the encoded data.
+var encryptedValue = regexp.MustCompile(`^ENC\[(\w+),([A-Za-z0-9+/=\s]+)\]$`)
+
+// eyamlDecrypter decrypts values encrypted with the PKCS7 encryptor of hiera-eyaml.
+type eyamlDecrypter struct {
+	cert *x509.Certificate
+	key  crypto.PrivateKey
+}
+
+func newEYAMLDecrypter(certPEM, keyPEM []byte) (*eyamlDecrypter, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errInvalidEYAMLPublicKey
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf(errParseEYAMLKey, err)
+	}
+	block, _ = pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errInvalidEYAMLPrivateKey
+	}
+	var key crypto.PrivateKey
+	key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errParseEYAMLKey, err)
+	}
+	return &eyamlDecrypter{cert: cert, key: key}, nil
+}
+
+// decryptValues returns v with all encrypted strings, also those nested in
+// objects and arrays, replaced by their plaintext.
+func decryptValues(d *eyamlDecrypter, v any) (any, error) {
+	switch val := v.(type) {
+	case string:
+		return d.decrypt(val)
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			dec, err := decryptValues(d, item)
+			if err != nil {
+				return nil, fmt.Errorf(errDecryptKey, k, err)
+			}
+			out[k] = dec
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			dec, err := decryptValues(d, item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = dec
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// decrypt returns the plaintext of an encrypted value, other values are
+// returned unchanged.
+func (d *eyamlDecrypter) decrypt(value string) (string, error) {
+	match := encryptedValue.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return value, nil
+	}
+	if match[1] != "PKCS7" {
+		return "", fmt.Errorf(errUnsupportedEncryptor, match[1])
+	}
+	if d == nil {
+		return "", errMissingEYAMLKeys
+	}
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(match[2]), ""))
+	if err != nil {
+		return "", fmt.Errorf(errDecrypt, err)
+	}
+	p7, err := pkcs7.Parse(der)
+	if err != nil {
+		return "", fmt.Errorf(errDecrypt, err)
+	}
+	plaintext, err := p7.Decrypt(d.cert, d.key)
+	if err != nil {
+		return "", fmt.Errorf(errDecrypt, err)
+	}
+	return string(plaintext), nil
+}
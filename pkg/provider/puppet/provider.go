@@ -0,0 +1,255 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package puppet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errGitClone             = "unable to clone %s: %w"
+	errGitList              = "unable to list references of %s: %w"
+	errInvalidDataFile      = "invalid data file %q, expected a path relative to the datadir"
+	errParseDataFile        = "unable to parse data file %s: %w"
+	errParseEYAMLKey        = "unable to parse eyaml key: %w"
+	errUnsupportedEncryptor = "unsupported eyaml encryption method %s, only PKCS7 is supported"
+	errDecrypt              = "unable to decrypt eyaml value: %w"
+	errDecryptKey           = "%s: %w"
+
+	defaultDataDir = "data"
+
+	requestTimeout  = 30 * time.Second
+	validateTimeout = 10 * time.Second
+)
+
+var (
+	errMissingStore           = errors.New("missing store specification")
+	errInvalidSpec            = errors.New("invalid specification for puppet provider")
+	errSource                 = errors.New("exactly one of git or http must be set")
+	errMissingURL             = errors.New("url must be set")
+	errInvalidURL             = errors.New("url must be an absolute url")
+	errInvalidDataDir         = errors.New("dataDir must be a relative path inside the repository")
+	errHTTPAuth               = errors.New("at most one of auth.basic or auth.tokenSecretRef must be set")
+	errMissingSecretName      = errors.New("must specify a secret name")
+	errMissingSecretKey       = errors.New("must specify a secret key")
+	errInvalidEYAMLPublicKey  = errors.New("eyaml public key is not a PEM encoded certificate")
+	errInvalidEYAMLPrivateKey = errors.New("eyaml private key is not PEM encoded")
+	errMissingEYAMLKeys       = errors.New("value is encrypted, but no eyaml keypair is configured")
+	errVersionNotSupported    = errors.New("specifying a version is not supported")
+	errNotAHash               = errors.New("value is not a hash")
+	errFindPathRequired       = errors.New("find requires the path of a data file")
+	errFindTagsNotSupported   = errors.New("find by tags is not supported")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	resolve := func(ref esmeta.SecretKeySelector) (string, error) {
+		return resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &ref)
+	}
+	c := &client{}
+	switch {
+	case cfg.Git != nil:
+		c.source, err = newGitSource(cfg.Git, resolve)
+	default:
+		c.source, err = newHTTPSource(cfg.HTTP, resolve)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.EYAML != nil {
+		cert, err := resolve(cfg.EYAML.PublicKeySecretRef)
+		if err != nil {
+			return nil, err
+		}
+		key, err := resolve(cfg.EYAML.PrivateKeySecretRef)
+		if err != nil {
+			return nil, err
+		}
+		c.decrypter, err = newEYAMLDecrypter([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func newGitSource(cfg *esv1beta1.PuppetGitSource, resolve func(esmeta.SecretKeySelector) (string, error)) (*gitSource, error) {
+	s := &gitSource{
+		url:     cfg.URL,
+		ref:     cfg.Ref,
+		dataDir: cfg.DataDir,
+	}
+	if s.dataDir == "" {
+		s.dataDir = defaultDataDir
+	}
+	if cfg.Auth != nil {
+		username, password, err := resolveBasicAuth(cfg.Auth, resolve)
+		if err != nil {
+			return nil, err
+		}
+		s.auth = &githttp.BasicAuth{Username: username, Password: password}
+	}
+	return s, nil
+}
+
+func newHTTPSource(cfg *esv1beta1.PuppetHTTPSource, resolve func(esmeta.SecretKeySelector) (string, error)) (*httpSource, error) {
+	s := &httpSource{
+		url:    strings.TrimSuffix(cfg.URL, "/"),
+		client: &http.Client{Timeout: requestTimeout},
+	}
+	switch {
+	case cfg.Auth == nil:
+	case cfg.Auth.Basic != nil:
+		username, password, err := resolveBasicAuth(cfg.Auth.Basic, resolve)
+		if err != nil {
+			return nil, err
+		}
+		s.authenticate = func(req *http.Request) {
+			req.SetBasicAuth(username, password)
+		}
+	case cfg.Auth.TokenSecretRef != nil:
+		token, err := resolve(*cfg.Auth.TokenSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		s.authenticate = func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+	return s, nil
+}
+
+func resolveBasicAuth(auth *esv1beta1.PuppetBasicAuth, resolve func(esmeta.SecretKeySelector) (string, error)) (string, string, error) {
+	username, err := resolve(auth.UsernameSecretRef)
+	if err != nil {
+		return "", "", err
+	}
+	password, err := resolve(auth.PasswordSecretRef)
+	if err != nil {
+		return "", "", err
+	}
+	return username, password, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.PuppetProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Puppet == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.Puppet
+
+	if (cfg.Git == nil) == (cfg.HTTP == nil) {
+		return nil, errSource
+	}
+	var refs []esmeta.SecretKeySelector
+	if cfg.Git != nil {
+		if err := validateURL(cfg.Git.URL); err != nil {
+			return nil, err
+		}
+		if dataDir := path.Clean(cfg.Git.DataDir); path.IsAbs(dataDir) || dataDir == ".." || strings.HasPrefix(dataDir, "../") {
+			return nil, errInvalidDataDir
+		}
+		if cfg.Git.Auth != nil {
+			refs = append(refs, cfg.Git.Auth.UsernameSecretRef, cfg.Git.Auth.PasswordSecretRef)
+		}
+	} else {
+		if err := validateURL(cfg.HTTP.URL); err != nil {
+			return nil, err
+		}
+		if auth := cfg.HTTP.Auth; auth != nil {
+			if auth.Basic != nil && auth.TokenSecretRef != nil {
+				return nil, errHTTPAuth
+			}
+			if auth.Basic != nil {
+				refs = append(refs, auth.Basic.UsernameSecretRef, auth.Basic.PasswordSecretRef)
+			}
+			if auth.TokenSecretRef != nil {
+				refs = append(refs, *auth.TokenSecretRef)
+			}
+		}
+	}
+	if cfg.EYAML != nil {
+		refs = append(refs, cfg.EYAML.PublicKeySecretRef, cfg.EYAML.PrivateKeySecretRef)
+	}
+	for _, ref := range refs {
+		if err := validateSecretRef(store, ref); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func validateURL(rawURL string) error {
+	if rawURL == "" {
+		return errMissingURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" {
+		return errInvalidURL
+	}
+	return nil
+}
+
+func validateSecretRef(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return err
+	}
+	if ref.Name == "" {
+		return errMissingSecretName
+	}
+	if ref.Key == "" {
+		return errMissingSecretKey
+	}
+	return nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Puppet: &esv1beta1.PuppetProvider{},
+	})
+}
@@ -0,0 +1,173 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package puppet
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func newStore(provider *esv1beta1.PuppetProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "puppet", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Puppet: provider},
+		},
+	}
+}
+
+func ref(key string) esmeta.SecretKeySelector {
+	return esmeta.SecretKeySelector{Name: "puppet", Key: key}
+}
+
+func TestValidateStore(t *testing.T) {
+	repo := "https://git.example.com/puppet/control-repo.git"
+	basic := &esv1beta1.PuppetBasicAuth{UsernameSecretRef: ref("username"), PasswordSecretRef: ref("password")}
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "missing source",
+			store:   newStore(&esv1beta1.PuppetProvider{}),
+			wantErr: errSource,
+		},
+		{
+			name: "both sources",
+			store: newStore(&esv1beta1.PuppetProvider{
+				Git:  &esv1beta1.PuppetGitSource{URL: repo},
+				HTTP: &esv1beta1.PuppetHTTPSource{URL: "https://puppet.example.com/data"},
+			}),
+			wantErr: errSource,
+		},
+		{
+			name:    "missing git url",
+			store:   newStore(&esv1beta1.PuppetProvider{Git: &esv1beta1.PuppetGitSource{}}),
+			wantErr: errMissingURL,
+		},
+		{
+			name:    "invalid http url",
+			store:   newStore(&esv1beta1.PuppetProvider{HTTP: &esv1beta1.PuppetHTTPSource{URL: "puppet.example.com"}}),
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "datadir outside of repository",
+			store:   newStore(&esv1beta1.PuppetProvider{Git: &esv1beta1.PuppetGitSource{URL: repo, DataDir: "data/../../etc"}}),
+			wantErr: errInvalidDataDir,
+		},
+		{
+			name: "basic and token auth",
+			store: newStore(&esv1beta1.PuppetProvider{HTTP: &esv1beta1.PuppetHTTPSource{
+				URL:  "https://puppet.example.com/data",
+				Auth: &esv1beta1.PuppetHTTPAuth{Basic: basic, TokenSecretRef: &esmeta.SecretKeySelector{Name: "puppet", Key: "token"}},
+			}}),
+			wantErr: errHTTPAuth,
+		},
+		{
+			name: "missing eyaml key",
+			store: newStore(&esv1beta1.PuppetProvider{
+				Git:   &esv1beta1.PuppetGitSource{URL: repo},
+				EYAML: &esv1beta1.PuppetEYAML{PublicKeySecretRef: ref("public"), PrivateKeySecretRef: ref("")},
+			}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name: "git with auth and eyaml",
+			store: newStore(&esv1beta1.PuppetProvider{
+				Git:   &esv1beta1.PuppetGitSource{URL: repo, Ref: "production", Auth: basic},
+				EYAML: &esv1beta1.PuppetEYAML{PublicKeySecretRef: ref("public"), PrivateKeySecretRef: ref("private")},
+			}),
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	_, certPEM, keyPEM := newKeypair(t)
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "puppet", Namespace: "default"},
+		Data: map[string][]byte{
+			"username": []byte("puppet"),
+			"password": []byte("t0ken"),
+			"token":    []byte("bearer"),
+			"public":   certPEM,
+			"private":  keyPEM,
+		},
+	}).Build()
+	p := &Provider{}
+
+	store := newStore(&esv1beta1.PuppetProvider{
+		Git: &esv1beta1.PuppetGitSource{
+			URL:  "https://git.example.com/puppet/control-repo.git",
+			Ref:  "production",
+			Auth: &esv1beta1.PuppetBasicAuth{UsernameSecretRef: ref("username"), PasswordSecretRef: ref("password")},
+		},
+		EYAML: &esv1beta1.PuppetEYAML{PublicKeySecretRef: ref("public"), PrivateKeySecretRef: ref("private")},
+	})
+	sc, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	c := sc.(*client)
+	assert.NotNil(t, c.decrypter)
+	git := c.source.(*gitSource)
+	assert.Equal(t, defaultDataDir, git.dataDir)
+	assert.Equal(t, &githttp.BasicAuth{Username: "puppet", Password: "t0ken"}, git.auth)
+	assert.Equal(t, "refs/heads/production", string(referenceName(git.ref)))
+
+	store = newStore(&esv1beta1.PuppetProvider{HTTP: &esv1beta1.PuppetHTTPSource{
+		URL:  "https://puppet.example.com/data/",
+		Auth: &esv1beta1.PuppetHTTPAuth{TokenSecretRef: &esmeta.SecretKeySelector{Name: "puppet", Key: "token"}},
+	}})
+	sc, err = p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	src := sc.(*client).source.(*httpSource)
+	assert.Equal(t, "https://puppet.example.com/data", src.url)
+	req, _ := http.NewRequest(http.MethodGet, src.url, http.NoBody)
+	src.authenticate(req)
+	assert.Equal(t, "Bearer bearer", req.Header.Get("Authorization"))
+
+	store.Spec.Provider.Puppet.EYAML = &esv1beta1.PuppetEYAML{PublicKeySecretRef: ref("private"), PrivateKeySecretRef: ref("private")}
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.ErrorContains(t, err, "unable to parse eyaml key")
+}
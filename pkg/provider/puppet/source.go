@@ -0,0 +1,170 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package puppet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// dataSource reads files of a hiera datadir.
+type dataSource interface {
+	// ReadFile returns the content of the file at the given path relative
+	// to the datadir. A missing file returns a NoSecretError.
+	ReadFile(ctx context.Context, name string) ([]byte, error)
+	// Validate returns an error if the source can not be accessed.
+	Validate(ctx context.Context) (esv1beta1.ValidationResult, error)
+}
+
+// gitSource reads the datadir from a shallow in-memory clone of a
+// repository. The repository is cloned once on first use.
+type gitSource struct {
+	url     string
+	ref     string
+	dataDir string
+	auth    transport.AuthMethod
+
+	mu sync.Mutex
+	fs billy.Filesystem
+}
+
+var _ dataSource = &gitSource{}
+
+func (s *gitSource) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	fs, err := s.clone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := util.ReadFile(fs, path.Join(s.dataDir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return data, err
+}
+
+func (s *gitSource) Validate(ctx context.Context) (esv1beta1.ValidationResult, error) {
+	remote := git.NewRemote(memory.NewStorage(), &gitconfig.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{s.url},
+	})
+	_, err := remote.ListContext(ctx, &git.ListOptions{Auth: s.auth})
+	metrics.ObserveAPICall(constants.ProviderPuppet, constants.CallPuppetGitList, err)
+	if err != nil {
+		return esv1beta1.ValidationResultError, fmt.Errorf(errGitList, s.url, err)
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (s *gitSource) clone(ctx context.Context) (billy.Filesystem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fs != nil {
+		return s.fs, nil
+	}
+	fs := memfs.New()
+	_, err := git.CloneContext(ctx, memory.NewStorage(), fs, &git.CloneOptions{
+		URL:           s.url,
+		Auth:          s.auth,
+		ReferenceName: referenceName(s.ref),
+		SingleBranch:  true,
+		Depth:         1,
+		Tags:          git.NoTags,
+	})
+	metrics.ObserveAPICall(constants.ProviderPuppet, constants.CallPuppetGitClone, err)
+	if err != nil {
+		return nil, fmt.Errorf(errGitClone, s.url, err)
+	}
+	s.fs = fs
+	return fs, nil
+}
+
+// referenceName returns the full name of ref, short names are considered
+// branches.
+func referenceName(ref string) plumbing.ReferenceName {
+	switch {
+	case ref == "":
+		return ""
+	case strings.HasPrefix(ref, "refs/"):
+		return plumbing.ReferenceName(ref)
+	default:
+		return plumbing.NewBranchReferenceName(ref)
+	}
+}
+
+// httpSource reads the files of the datadir from an HTTP endpoint.
+type httpSource struct {
+	url    string
+	client *http.Client
+	// authenticate adds the credentials to a request.
+	authenticate func(*http.Request)
+
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+var _ dataSource = &httpSource{}
+
+func (s *httpSource) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if data, ok := s.files[name]; ok {
+		return data, nil
+	}
+	data, err := s.get(ctx, name)
+	metrics.ObserveAPICall(constants.ProviderPuppet, constants.CallPuppetHTTPGet, err)
+	if err != nil {
+		return nil, err
+	}
+	if s.files == nil {
+		s.files = make(map[string][]byte)
+	}
+	s.files[name] = data
+	return data, nil
+}
+
+// Validate can not check the endpoint, as the files of the datadir are not known.
+func (s *httpSource) Validate(context.Context) (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultUnknown, nil
+}
+
+func (s *httpSource) get(ctx context.Context, name string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url+(&url.URL{Path: "/" + name}).EscapedPath(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if s.authenticate != nil {
+		s.authenticate(req)
+	}
+	return utils.DoHTTP(s.client, req, http.StatusNotFound)
+}
@@ -12,32 +12,14 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package register registers the providers to the controller schema. Every
+// provider is imported in its own file, so binaries can be built with only
+// selected providers: with the build tag select_providers only the providers
+// of the tags provider_<name> are registered, e.g.
+//
+//	go build -tags select_providers,provider_chef,provider_kubernetes
+//
+// Without select_providers all providers are registered.
+//
 //nolint:revive
 package register
-
-// packages imported here are registered to the controller schema.
-
-import (
-	_ "github.com/external-secrets/external-secrets/pkg/provider/akeyless"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/alibaba"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/aws"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/chef"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/conjur"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/delinea"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/doppler"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/fake"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/gcp/secretmanager"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/gitlab"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/ibm"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/keepersecurity"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/kubernetes"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/onepassword"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/oracle"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/scaleway"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/senhasegura"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/vault"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/webhook"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/yandex/certificatemanager"
-	_ "github.com/external-secrets/external-secrets/pkg/provider/yandex/lockbox"
-)
@@ -0,0 +1,188 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package salt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// delimiter separates the segments of nested pillar keys, like in pillar.get.
+const delimiter = ":"
+
+type client struct {
+	api saltAPI
+	// pillars caches the pillar of each minion for the lifetime of the client.
+	pillars map[string]map[string]any
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the value of the pillar key property of the minion key.
+// Nested values are selected with the colon delimited syntax of pillar.get,
+// e.g. mysql:users:app:password. Without property the whole pillar is
+// returned as JSON.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, err := c.getValue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return utils.GetByteValue(value)
+}
+
+// GetSecretMap returns the values of the pillar, or of the dict selected by
+// property, as map.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	value, err := c.getValue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	dict, ok := value.(map[string]any)
+	if !ok {
+		return nil, errNotADict
+	}
+	return toSecretMap(dict)
+}
+
+// GetAllSecrets returns the top level pillar keys of the minion path whose
+// names match.
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Path == nil || *ref.Path == "" {
+		return nil, errFindPathRequired
+	}
+	if len(ref.Tags) > 0 {
+		return nil, errFindTagsNotSupported
+	}
+	pillar, err := c.getPillar(ctx, *ref.Path)
+	if err != nil {
+		return nil, err
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	matches := make(map[string]any)
+	for k, v := range pillar {
+		if matcher != nil && !matcher.MatchName(k) {
+			continue
+		}
+		matches[k] = v
+	}
+	return toSecretMap(matches)
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by Salt")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by Salt")
+}
+
+// Validate checks that the credentials are accepted by salt-api.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if err := c.api.Login(ctx); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(context.Context) error {
+	return nil
+}
+
+func (c *client) getValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (any, error) {
+	if ref.Version != "" {
+		return nil, errVersionNotSupported
+	}
+	pillar, err := c.getPillar(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return pillar, nil
+	}
+	value, ok := lookup(pillar, ref.Property)
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return value, nil
+}
+
+func (c *client) getPillar(ctx context.Context, minion string) (map[string]any, error) {
+	if minion == "" || strings.ContainsAny(minion, "*?[], ") {
+		return nil, fmt.Errorf(errInvalidMinion, minion)
+	}
+	if pillar, ok := c.pillars[minion]; ok {
+		return pillar, nil
+	}
+	pillar, err := c.api.ShowPillar(ctx, minion)
+	if err != nil {
+		return nil, err
+	}
+	if c.pillars == nil {
+		c.pillars = make(map[string]map[string]any)
+	}
+	c.pillars[minion] = pillar
+	return pillar, nil
+}
+
+// lookup returns the value of a pillar key, dicts and lists are traversed
+// at each delimiter like pillar.get does.
+func lookup(pillar map[string]any, key string) (any, bool) {
+	var value any = pillar
+	for _, segment := range strings.Split(key, delimiter) {
+		switch v := value.(type) {
+		case map[string]any:
+			var ok bool
+			if value, ok = v[segment]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func toSecretMap(dict map[string]any) (map[string][]byte, error) {
+	secretMap := make(map[string][]byte, len(dict))
+	for k, v := range dict {
+		var err error
+		secretMap[k], err = utils.GetByteValue(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secretMap, nil
+}
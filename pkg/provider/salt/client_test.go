@@ -0,0 +1,205 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package salt
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+var testCredentials = credentials{Username: "eso", Password: "s3cr3t", Eauth: "pam"}
+
+func newTestClient(t *testing.T) (*client, *int) {
+	t.Helper()
+	pillars := map[string]string{
+		"web1": `{"mysql":{"users":{"app":{"password":"hunter2","grants":["SELECT","INSERT"]}}},"api_key":"abc","port":8080}`,
+		"db1":  `{"_errors":["Rendering SLS 'db' failed"]}`,
+		"app1": `"No permission to access runner pillar.show_pillar"`,
+	}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Accept") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/login":
+			var req credentials
+			if json.NewDecoder(r.Body).Decode(&req) != nil || req != testCredentials {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"return":[{"token":"t","user":"eso","eauth":"pam"}]}`))
+		case "/run":
+			var req []lowstate
+			if json.NewDecoder(r.Body).Decode(&req) != nil || len(req) != 1 || req[0].credentials != testCredentials {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if req[0].Client != "runner" || req[0].Fun != "pillar.show_pillar" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			calls++
+			pillar, ok := pillars[req[0].Kwarg["minion"].(string)]
+			if !ok {
+				pillar = "{}"
+			}
+			_, _ = w.Write([]byte(`{"return":[` + pillar + `]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return &client{
+		api: &httpSaltAPI{
+			url:         server.URL,
+			credentials: testCredentials,
+			client:      server.Client(),
+		},
+	}, &calls
+}
+
+func TestGetSecret(t *testing.T) {
+	c, calls := newTestClient(t)
+	tests := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		want     string
+		wantErr  error
+		contains string
+	}{
+		{
+			name: "top level key",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web1", Property: "api_key"},
+			want: "abc",
+		},
+		{
+			name: "nested key",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web1", Property: "mysql:users:app:password"},
+			want: "hunter2",
+		},
+		{
+			name: "list index",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web1", Property: "mysql:users:app:grants:1"},
+			want: "INSERT",
+		},
+		{
+			name: "non string value",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web1", Property: "port"},
+			want: "8080",
+		},
+		{
+			name: "whole pillar",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web1"},
+			want: `{"api_key":"abc","mysql":{"users":{"app":{"grants":["SELECT","INSERT"],"password":"hunter2"}}},"port":8080}`,
+		},
+		{
+			name:    "missing key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "web1", Property: "mysql:users:root"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "unknown minion",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "web2", Property: "api_key"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:     "pillar render errors",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db1"},
+			contains: "Rendering SLS 'db' failed",
+		},
+		{
+			name:     "runner error",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "app1"},
+			contains: "No permission",
+		},
+		{
+			name:     "glob target",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "web*"},
+			contains: "invalid minion id",
+		},
+		{
+			name:    "version",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "web1", Version: "1"},
+			wantErr: errVersionNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+	// the pillar of each minion is only rendered once
+	assert.Equal(t, 4, *calls)
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c, _ := newTestClient(t)
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "web1", Property: "mysql:users:app"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"password": []byte("hunter2"),
+		"grants":   []byte(`["SELECT","INSERT"]`),
+	}, got)
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "web1", Property: "api_key"})
+	assert.ErrorIs(t, err, errNotADict)
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	c, _ := newTestClient(t)
+	minion := "web1"
+	name := "^api_"
+	got, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Path: &minion,
+		Name: &esv1beta1.FindName{RegExp: name},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"api_key": []byte("abc")}, got)
+
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: name}})
+	assert.ErrorIs(t, err, errFindPathRequired)
+
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Path: &minion, Tags: map[string]string{"a": "b"}})
+	assert.ErrorIs(t, err, errFindTagsNotSupported)
+}
+
+func TestValidate(t *testing.T) {
+	c, _ := newTestClient(t)
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	c.api.(*httpSaltAPI).credentials.Password = "other"
+	result, err = c.Validate()
+	assert.ErrorContains(t, err, "unexpected status code 401")
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+}
@@ -0,0 +1,168 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package salt
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/certpin"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errDecodeResponse = "unable to decode response: %w"
+	errShowPillar     = "unable to render pillar of minion %s: %v"
+	errInvalidMinion  = "invalid minion id %q, targeting multiple minions is not supported"
+
+	defaultEauth    = "pam"
+	requestTimeout  = 30 * time.Second
+	validateTimeout = 10 * time.Second
+)
+
+var (
+	errMissingStore         = errors.New("missing store specification")
+	errInvalidSpec          = errors.New("invalid specification for salt provider")
+	errMissingURL           = errors.New("url must be set")
+	errInvalidURL           = errors.New("url must be an absolute http or https url")
+	errInvalidCABundle      = errors.New("caBundle does not contain a PEM encoded certificate")
+	errMissingSecretName    = errors.New("must specify a secret name")
+	errMissingSecretKey     = errors.New("must specify a secret key")
+	errEmptyReturn          = errors.New("empty return")
+	errVersionNotSupported  = errors.New("specifying a version is not supported by salt")
+	errNotADict             = errors.New("value is not a dict")
+	errFindPathRequired     = errors.New("find requires path to be set to a minion id")
+	errFindTagsNotSupported = errors.New("find by tags is not supported by salt")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	username, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &cfg.Auth.UsernameSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	password, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &cfg.Auth.PasswordSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	eauth := cfg.Auth.Eauth
+	if eauth == "" {
+		eauth = defaultEauth
+	}
+	httpClient := &http.Client{Timeout: requestTimeout}
+	if len(cfg.CABundle) > 0 || len(cfg.CertificatePins) > 0 {
+		tlsConfig := &tls.Config{
+			MinVersion:       tls.VersionTLS12,
+			VerifyConnection: certpin.VerifyConnection(cfg.CertificatePins),
+		}
+		if len(cfg.CABundle) > 0 {
+			tlsConfig.RootCAs = x509.NewCertPool()
+			tlsConfig.RootCAs.AppendCertsFromPEM(cfg.CABundle)
+		}
+		httpClient.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
+	return &client{
+		api: &httpSaltAPI{
+			url: strings.TrimSuffix(cfg.URL, "/"),
+			credentials: credentials{
+				Username: username,
+				Password: password,
+				Eauth:    eauth,
+			},
+			client: httpClient,
+		},
+	}, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.SaltProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Salt == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.Salt
+
+	if cfg.URL == "" {
+		return nil, errMissingURL
+	}
+	u, err := url.ParseRequestURI(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errInvalidURL
+	}
+	if len(cfg.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(cfg.CABundle) {
+		return nil, errInvalidCABundle
+	}
+	if err := certpin.Validate(cfg.CertificatePins); err != nil {
+		return nil, err
+	}
+	if err := validateSecretRef(store, cfg.Auth.UsernameSecretRef); err != nil {
+		return nil, err
+	}
+	if err := validateSecretRef(store, cfg.Auth.PasswordSecretRef); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func validateSecretRef(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return err
+	}
+	if ref.Name == "" {
+		return errMissingSecretName
+	}
+	if ref.Key == "" {
+		return errMissingSecretKey
+	}
+	return nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Salt: &esv1beta1.SaltProvider{},
+	})
+}
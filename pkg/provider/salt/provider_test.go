@@ -0,0 +1,145 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package salt
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const testCA = `-----BEGIN CERTIFICATE-----
+MIIBhTCCASugAwIBAgIQIRi6zePL6mKjOipn+dNuaTAKBggqhkjOPQQDAjASMRAw
+DgYDVQQKEwdBY21lIENvMB4XDTE3MTAyMDE5NDMwNloXDTE4MTAyMDE5NDMwNlow
+EjEQMA4GA1UEChMHQWNtZSBDbzBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABD0d
+7VNhbWvZLWPuj/RtHFjvtJBEwOkhbN/BnnE8rnZR8+sbwnc/KhCk3FhnpHZnQz7B
+5aETbbIgmuvewdjvSBSjYzBhMA4GA1UdDwEB/wQEAwICpDATBgNVHSUEDDAKBggr
+BgEFBQcDATAPBgNVHRMBAf8EBTADAQH/MCkGA1UdEQQiMCCCDmxvY2FsaG9zdDo1
+NDUzgg4xMjcuMC4wLjE6NTQ1MzAKBggqhkjOPQQDAgNIADBFAiEA2zpJEPQyz6/l
+Wf86aX6PepsntZv2GYlA5UpabfT2EZICICpJ5h/iI+i341gBmLiAFQOyTDT+/wQc
+6MF9+Yw1Yy0t
+-----END CERTIFICATE-----`
+
+func newStore(provider *esv1beta1.SaltProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "salt", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Salt: provider},
+		},
+	}
+}
+
+func saltAuth(name, usernameKey, passwordKey string) esv1beta1.SaltAuth {
+	return esv1beta1.SaltAuth{
+		UsernameSecretRef: esmeta.SecretKeySelector{Name: name, Key: usernameKey},
+		PasswordSecretRef: esmeta.SecretKeySelector{Name: name, Key: passwordKey},
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "missing url",
+			store:   newStore(&esv1beta1.SaltProvider{Auth: saltAuth("salt", "username", "password")}),
+			wantErr: errMissingURL,
+		},
+		{
+			name:    "invalid url",
+			store:   newStore(&esv1beta1.SaltProvider{URL: "salt.example.com:8000", Auth: saltAuth("salt", "username", "password")}),
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "invalid ca bundle",
+			store:   newStore(&esv1beta1.SaltProvider{URL: "https://salt.example.com:8000", CABundle: []byte("ca"), Auth: saltAuth("salt", "username", "password")}),
+			wantErr: errInvalidCABundle,
+		},
+		{
+			name:    "missing secret name",
+			store:   newStore(&esv1beta1.SaltProvider{URL: "https://salt.example.com:8000", Auth: saltAuth("", "username", "password")}),
+			wantErr: errMissingSecretName,
+		},
+		{
+			name:    "missing username key",
+			store:   newStore(&esv1beta1.SaltProvider{URL: "https://salt.example.com:8000", Auth: saltAuth("salt", "", "password")}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name:    "missing password key",
+			store:   newStore(&esv1beta1.SaltProvider{URL: "https://salt.example.com:8000", Auth: saltAuth("salt", "username", "")}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name:  "valid",
+			store: newStore(&esv1beta1.SaltProvider{URL: "https://salt.example.com:8000", CABundle: []byte(testCA), Auth: saltAuth("salt", "username", "password")}),
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "salt", Namespace: "default"},
+		Data:       map[string][]byte{"username": []byte("eso"), "password": []byte("s3cr3t")},
+	}).Build()
+	p := &Provider{}
+	store := newStore(&esv1beta1.SaltProvider{URL: "https://salt.example.com:8000/", CABundle: []byte(testCA), Auth: saltAuth("salt", "username", "password")})
+	sc, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	api := sc.(*client).api.(*httpSaltAPI)
+	assert.Equal(t, "https://salt.example.com:8000", api.url)
+	assert.Equal(t, credentials{Username: "eso", Password: "s3cr3t", Eauth: "pam"}, api.credentials)
+	require.IsType(t, &http.Transport{}, api.client.Transport)
+	assert.NotNil(t, api.client.Transport.(*http.Transport).TLSClientConfig.RootCAs)
+
+	store.Spec.Provider.Salt.Auth.Eauth = "ldap"
+	sc, err = p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "ldap", sc.(*client).api.(*httpSaltAPI).credentials.Eauth)
+
+	store.Spec.Provider.Salt.Auth = saltAuth("salt", "username", "missing")
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package salt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// credentials are passed with every request, salt-api then does not need
+// a session.
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Eauth    string `json:"eauth"`
+}
+
+type lowstate struct {
+	credentials
+	Client string         `json:"client"`
+	Fun    string         `json:"fun"`
+	Kwarg  map[string]any `json:"kwarg,omitempty"`
+}
+
+type runResponse struct {
+	Return []json.RawMessage `json:"return"`
+}
+
+// saltAPI is the subset of salt-api used by the provider.
+// See https://docs.saltproject.io/en/latest/ref/netapi/all/salt.netapi.rest_cherrypy.html
+// for the full API documentation.
+type saltAPI interface {
+	// ShowPillar returns the pillar of the minion, rendered on the master.
+	ShowPillar(ctx context.Context, minion string) (map[string]any, error)
+	// Login returns an error if the credentials are not accepted.
+	Login(ctx context.Context) error
+}
+
+type httpSaltAPI struct {
+	url         string
+	credentials credentials
+	client      *http.Client
+}
+
+var _ saltAPI = &httpSaltAPI{}
+
+func (a *httpSaltAPI) ShowPillar(ctx context.Context, minion string) (map[string]any, error) {
+	pillar, err := a.showPillar(ctx, minion)
+	metrics.ObserveAPICall(constants.ProviderSalt, constants.CallSaltShowPillar, err)
+	return pillar, err
+}
+
+func (a *httpSaltAPI) showPillar(ctx context.Context, minion string) (map[string]any, error) {
+	data, err := a.post(ctx, "/run", []lowstate{{
+		credentials: a.credentials,
+		Client:      "runner",
+		Fun:         "pillar.show_pillar",
+		Kwarg:       map[string]any{"minion": minion},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	var resp runResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf(errDecodeResponse, err)
+	}
+	if len(resp.Return) == 0 {
+		return nil, fmt.Errorf(errDecodeResponse, errEmptyReturn)
+	}
+	var pillar map[string]any
+	if err := json.Unmarshal(resp.Return[0], &pillar); err != nil {
+		// runner errors are returned as string
+		return nil, fmt.Errorf(errShowPillar, minion, strings.Trim(string(resp.Return[0]), `"`))
+	}
+	if errs, ok := pillar["_errors"]; ok {
+		return nil, fmt.Errorf(errShowPillar, minion, errs)
+	}
+	return pillar, nil
+}
+
+func (a *httpSaltAPI) Login(ctx context.Context) error {
+	_, err := a.post(ctx, "/login", a.credentials)
+	metrics.ObserveAPICall(constants.ProviderSalt, constants.CallSaltLogin, err)
+	return err
+}
+
+func (a *httpSaltAPI) post(ctx context.Context, path string, in any) ([]byte, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	return utils.DoHTTP(a.client, req)
+}
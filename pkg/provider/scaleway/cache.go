@@ -18,17 +18,21 @@ import (
 	"container/list"
 	"fmt"
 	"sync"
+
+	"github.com/external-secrets/external-secrets/pkg/utils/guarded"
 )
 
 // cache is for caching values of the secrets. Secret versions are immutable, thus there is no need
-// for time-based expiration.
+// for time-based expiration. Values are kept in guarded memory and wiped on eviction.
 type cache interface {
 	Get(secretID string, revision uint32) ([]byte, bool)
 	Put(secretID string, revision uint32, value []byte)
+	// Purge wipes and removes all values.
+	Purge()
 }
 
 type cacheEntry struct {
-	value []byte
+	value *guarded.Bytes
 	elem  *list.Element
 }
 
@@ -59,7 +63,7 @@ func (c *cacheImpl) Get(secretID string, revision uint32) ([]byte, bool) {
 
 	c.entryKeysByLastUsage.MoveToFront(entry.elem)
 
-	return entry.value, true
+	return entry.value.Copy(), true
 }
 
 func (c *cacheImpl) Put(secretID string, revision uint32, value []byte) {
@@ -80,15 +84,28 @@ func (c *cacheImpl) Put(secretID string, revision uint32, value []byte) {
 	entry := c.entryKeysByLastUsage.PushFront(key)
 
 	c.entries[key] = cacheEntry{
-		value: value,
+		value: guarded.New(value),
 		elem:  entry,
 	}
 }
 
+func (c *cacheImpl) Purge() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, entry := range c.entries {
+		entry.value.Destroy()
+	}
+	c.entries = map[string]cacheEntry{}
+	c.entryKeysByLastUsage.Init()
+}
+
 func (c *cacheImpl) evictLeastRecentlyUsed() {
 	elem := c.entryKeysByLastUsage.Back()
 
-	delete(c.entries, elem.Value.(string))
+	key := elem.Value.(string)
+	c.entries[key].value.Destroy()
+	delete(c.entries, key)
 
 	c.entryKeysByLastUsage.Remove(elem)
 }
@@ -62,3 +62,19 @@ func TestCacheLeastRecentlyUsedIsRemovedFirst(t *testing.T) {
 	_, ok = cache.Get(secretID, uint32(maxEntryCount+2))
 	assert.True(t, ok)
 }
+
+func TestCachePurge(t *testing.T) {
+	cache := newCache()
+	secretID := "5b9e3bd4-6f36-4a65-9d4a-1f2a5b0d3e11"
+
+	cache.Put(secretID, 1, []byte("some value"))
+	cache.Purge()
+
+	_, ok := cache.Get(secretID, 1)
+	assert.False(t, ok)
+
+	cache.Put(secretID, 1, []byte("other value"))
+	value, ok := cache.Get(secretID, 1)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("other value"), value)
+}
@@ -365,6 +365,7 @@ func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecret
 }
 
 func (c *client) Close(context.Context) error {
+	c.cache.Purge()
 	return nil
 }
 
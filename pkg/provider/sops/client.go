@@ -0,0 +1,261 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	"github.com/getsops/sops/v3/cmd/sops/common"
+	"github.com/getsops/sops/v3/cmd/sops/formats"
+	"github.com/getsops/sops/v3/keyservice"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+type client struct {
+	source *gitSource
+	keys   *keyService
+	// files caches the decrypted files for the lifetime of the client.
+	files map[string]any
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret returns the value of property from the decrypted file key, e.g.
+// apps/web/secrets.yaml, relative to the path of the store. Nested values are
+// selected with dots, e.g. database.password. Without property the whole
+// file is returned as JSON, binary files are returned as is.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, err := c.getValue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return utils.GetByteValue(value)
+}
+
+// GetSecretMap returns the values of the file, or of the object selected by
+// property, as map.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	value, err := c.getValue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return nil, errNotAnObject
+	}
+	return toSecretMap(obj)
+}
+
+// GetAllSecrets returns the top level keys of the file path whose names
+// match.
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Path == nil || *ref.Path == "" {
+		return nil, errFindPathRequired
+	}
+	if len(ref.Tags) > 0 {
+		return nil, errFindTagsNotSupported
+	}
+	value, err := c.decryptFile(ctx, *ref.Path)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return nil, errNotAnObject
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	matches := make(map[string]any)
+	for k, v := range obj {
+		if matcher != nil && !matcher.MatchName(k) {
+			continue
+		}
+		matches[k] = v
+	}
+	return toSecretMap(matches)
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by sops")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by sops")
+}
+
+// Validate checks that the repository can be accessed.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	return c.source.Validate(ctx)
+}
+
+// Close wipes the private keys of the store.
+func (c *client) Close(context.Context) error {
+	c.keys.wipe()
+	return nil
+}
+
+func (c *client) getValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (any, error) {
+	if ref.Version != "" {
+		return nil, errVersionNotSupported
+	}
+	value, err := c.decryptFile(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return value, nil
+	}
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return nil, errNotAnObject
+	}
+	value, ok = lookup(obj, ref.Property)
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return value, nil
+}
+
+// decryptFile returns the decrypted content of the file: a map for YAML,
+// JSON, dotenv and INI files and a string for other files.
+// The format is derived from the extension like sops does.
+func (c *client) decryptFile(ctx context.Context, key string) (any, error) {
+	name := path.Clean(key)
+	if key == "" || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return nil, fmt.Errorf(errInvalidFile, key)
+	}
+	if value, ok := c.files[name]; ok {
+		return value, nil
+	}
+	data, err := c.source.ReadFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	format := formats.FormatForPath(name)
+	tree, err := common.StoreForFormat(format).LoadEncryptedFile(data)
+	if err != nil {
+		return nil, fmt.Errorf(errLoadFile, name, err)
+	}
+	keyErrs := &keyErrors{keyService: c.keys}
+	dataKey, err := tree.Metadata.GetDataKeyWithKeyServices([]keyservice.KeyServiceClient{keyErrs})
+	if err != nil {
+		return nil, fmt.Errorf(errDataKey, name, keyErrs.err(err))
+	}
+	cipher := aes.NewCipher()
+	mac, err := tree.Decrypt(dataKey, cipher)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptFile, name, err)
+	}
+	originalMac, err := cipher.Decrypt(tree.Metadata.MessageAuthenticationCode, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil || originalMac != mac {
+		return nil, fmt.Errorf(errDecryptFile, name, errMACMismatch)
+	}
+	if len(tree.Branches) != 1 {
+		return nil, fmt.Errorf(errMultipleDocuments, name)
+	}
+	value := toValue(tree.Branches[0])
+	if format == formats.Binary {
+		// binary files are stored as single data key
+		value = value.(map[string]any)["data"]
+	}
+	if c.files == nil {
+		c.files = make(map[string]any)
+	}
+	c.files[name] = value
+	return value, nil
+}
+
+// toValue converts a decrypted sops tree to maps and slices, comments are
+// dropped.
+func toValue(in any) any {
+	switch in := in.(type) {
+	case sops.TreeBranch:
+		obj := make(map[string]any, len(in))
+		for _, item := range in {
+			if _, ok := item.Key.(sops.Comment); ok {
+				continue
+			}
+			obj[fmt.Sprint(item.Key)] = toValue(item.Value)
+		}
+		return obj
+	case []any:
+		list := make([]any, 0, len(in))
+		for _, item := range in {
+			if _, ok := item.(sops.Comment); ok {
+				continue
+			}
+			list = append(list, toValue(item))
+		}
+		return list
+	default:
+		return in
+	}
+}
+
+// lookup returns the value of key. Keys that do not exist at the top level
+// are split at dots to dig into objects and arrays.
+func lookup(obj map[string]any, key string) (any, bool) {
+	if v, ok := obj[key]; ok {
+		return v, true
+	}
+	var value any = obj
+	for _, segment := range strings.Split(key, ".") {
+		switch v := value.(type) {
+		case map[string]any:
+			var ok bool
+			if value, ok = v[segment]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func toSecretMap(obj map[string]any) (map[string][]byte, error) {
+	secretMap := make(map[string][]byte, len(obj))
+	for k, v := range obj {
+		var err error
+		secretMap[k], err = utils.GetByteValue(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secretMap, nil
+}
@@ -0,0 +1,365 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/cmd/sops/common"
+	"github.com/getsops/sops/v3/cmd/sops/formats"
+	"github.com/getsops/sops/v3/keys"
+	"github.com/getsops/sops/v3/kms"
+	"github.com/getsops/sops/v3/pgp"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const secretsYAML = `database:
+    user: app
+    password: s3cr3t
+    port: 5432
+# rotated every month
+api_key: abc
+tokens:
+    - t1
+    - t2
+`
+
+// encryptFile encrypts plain like sops would with a new data key that is
+// encrypted with each of the master keys returned by newKeys.
+func encryptFile(t *testing.T, name, plain string, newKeys func(dataKey []byte) []keys.MasterKey) string {
+	t.Helper()
+	dataKey := make([]byte, 32)
+	_, err := rand.Read(dataKey)
+	require.NoError(t, err)
+	store := common.StoreForFormat(formats.FormatForPath(name))
+	branches, err := store.LoadPlainFile([]byte(plain))
+	require.NoError(t, err)
+	tree := sops.Tree{
+		Branches: branches,
+		Metadata: sops.Metadata{
+			KeyGroups:         []sops.KeyGroup{newKeys(dataKey)},
+			UnencryptedSuffix: "_unencrypted",
+			Version:           "3.8.1",
+		},
+	}
+	require.NoError(t, common.EncryptTree(common.EncryptTreeOpts{
+		Tree:    &tree,
+		Cipher:  aes.NewCipher(),
+		DataKey: dataKey,
+	}))
+	out, err := store.EmitEncryptedFile(tree)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func ageKeys(t *testing.T, identity *age.X25519Identity) func([]byte) []keys.MasterKey {
+	return func(dataKey []byte) []keys.MasterKey {
+		mk, err := sopsage.MasterKeyFromRecipient(identity.Recipient().String())
+		require.NoError(t, err)
+		require.NoError(t, mk.Encrypt(dataKey))
+		return []keys.MasterKey{mk}
+	}
+}
+
+func pgpKeys(t *testing.T, entity *openpgp.Entity) func([]byte) []keys.MasterKey {
+	return func(dataKey []byte) []keys.MasterKey {
+		var buf bytes.Buffer
+		w, err := armor.Encode(&buf, "PGP MESSAGE", nil)
+		require.NoError(t, err)
+		pt, err := openpgp.Encrypt(w, []*openpgp.Entity{entity}, nil, nil, nil)
+		require.NoError(t, err)
+		_, err = pt.Write(dataKey)
+		require.NoError(t, err)
+		require.NoError(t, pt.Close())
+		require.NoError(t, w.Close())
+		mk := pgp.NewMasterKeyFromFingerprint(fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint))
+		mk.EncryptedKey = buf.String()
+		return []keys.MasterKey{mk}
+	}
+}
+
+// kmsKeys returns a kms key that cannot be decrypted, the data key is
+// never sent to AWS in tests.
+func kmsKeys([]byte) []keys.MasterKey {
+	mk := kms.NewMasterKeyFromArn("arn:aws:kms:us-east-1:123456789012:key/test", nil, "")
+	mk.EncryptedKey = "AAAA"
+	return []keys.MasterKey{mk}
+}
+
+// tamper replaces the message authentication code of the file with the
+// one of another file.
+func tamper(t *testing.T, encrypted, other string) string {
+	t.Helper()
+	store := common.StoreForFormat(formats.Yaml)
+	tree, err := store.LoadEncryptedFile([]byte(encrypted))
+	require.NoError(t, err)
+	otherTree, err := store.LoadEncryptedFile([]byte(other))
+	require.NoError(t, err)
+	tree.Metadata.MessageAuthenticationCode = otherTree.Metadata.MessageAuthenticationCode
+	out, err := store.EmitEncryptedFile(tree)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func newPGPEntity(t *testing.T) *openpgp.Entity {
+	t.Helper()
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	return entity
+}
+
+func newAgeIdentity(t *testing.T) *age.X25519Identity {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	return identity
+}
+
+func newGitRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	for name, content := range files {
+		p := filepath.Join(dir, "secrets", name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+	_, err = wt.Add("secrets")
+	require.NoError(t, err)
+	_, err = wt.Commit("sops files", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	return dir
+}
+
+func newTestClient(t *testing.T) *client {
+	t.Helper()
+	identity := newAgeIdentity(t)
+	entity := newPGPEntity(t)
+	secrets := encryptFile(t, "secrets.yaml", secretsYAML, ageKeys(t, identity))
+	dir := newGitRepo(t, map[string]string{
+		"apps/web/secrets.yaml": secrets,
+		"apps/web/config.json":  encryptFile(t, "config.json", `{"token": "json-token", "replicas": 2}`, pgpKeys(t, entity)),
+		"apps/web/app.env":      encryptFile(t, "app.env", "A=1\nB=two\n", ageKeys(t, identity)),
+		"apps/web/cert.pem":     encryptFile(t, "cert.pem", "-----BEGIN CERTIFICATE-----\n", ageKeys(t, identity)),
+		"apps/web/kms.yaml":     encryptFile(t, "kms.yaml", "key: value\n", kmsKeys),
+		"apps/web/tampered.yaml": tamper(t, secrets,
+			encryptFile(t, "other.yaml", "key: value\n", ageKeys(t, identity))),
+		"apps/web/plain.yaml": "key: value\n",
+	})
+	return &client{
+		source: &gitSource{url: dir, dir: "secrets"},
+		keys: &keyService{
+			ageIdentities: sopsage.ParsedIdentities{identity},
+			pgpKeys:       openpgp.EntityList{entity},
+		},
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	c := newTestClient(t)
+	tests := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		want     string
+		wantErr  error
+		contains string
+	}{
+		{
+			name: "property",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/secrets.yaml", Property: "api_key"},
+			want: "abc",
+		},
+		{
+			name: "nested property",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/secrets.yaml", Property: "database.password"},
+			want: "s3cr3t",
+		},
+		{
+			name: "array element",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/secrets.yaml", Property: "tokens.1"},
+			want: "t2",
+		},
+		{
+			name: "object as json",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/secrets.yaml", Property: "database"},
+			want: `{"password":"s3cr3t","port":5432,"user":"app"}`,
+		},
+		{
+			name: "whole file without comments",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/secrets.yaml"},
+			want: `{"api_key":"abc","database":{"password":"s3cr3t","port":5432,"user":"app"},"tokens":["t1","t2"]}`,
+		},
+		{
+			name: "json with pgp key",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/config.json", Property: "token"},
+			want: "json-token",
+		},
+		{
+			name: "dotenv",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/app.env", Property: "B"},
+			want: "two",
+		},
+		{
+			name: "binary",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/cert.pem"},
+			want: "-----BEGIN CERTIFICATE-----\n",
+		},
+		{
+			name:    "missing property",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/secrets.yaml", Property: "database.host"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing file",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/missing.yaml"},
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "property of binary file",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/cert.pem", Property: "data"},
+			wantErr: errNotAnObject,
+		},
+		{
+			name:     "file outside of path",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "../secrets.yaml"},
+			contains: "invalid file",
+		},
+		{
+			name:    "version",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/secrets.yaml", Version: "1"},
+			wantErr: errVersionNotSupported,
+		},
+		{
+			name:    "kms without credentials",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/kms.yaml"},
+			wantErr: errNoKMSCredentials,
+		},
+		{
+			name:    "tampered file",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/tampered.yaml"},
+			wantErr: errMACMismatch,
+		},
+		{
+			name:     "unencrypted file",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/plain.yaml"},
+			contains: "unable to load sops file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestGetSecretWithoutKey(t *testing.T) {
+	c := newTestClient(t)
+	c.keys = &keyService{}
+	_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/secrets.yaml"})
+	assert.ErrorIs(t, err, errNoAgeKey)
+	_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/config.json"})
+	assert.ErrorIs(t, err, errNoPGPKey)
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c := newTestClient(t)
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/secrets.yaml", Property: "database"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"user":     []byte("app"),
+		"password": []byte("s3cr3t"),
+		"port":     []byte("5432"),
+	}, got)
+
+	got, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/app.env"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"A": []byte("1"), "B": []byte("two")}, got)
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/web/secrets.yaml", Property: "api_key"})
+	assert.ErrorIs(t, err, errNotAnObject)
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	c := newTestClient(t)
+	file := "apps/web/secrets.yaml"
+	got, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Path: &file,
+		Name: &esv1beta1.FindName{RegExp: "^(api|tok)"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"api_key": []byte("abc"),
+		"tokens":  []byte(`["t1","t2"]`),
+	}, got)
+
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+	assert.ErrorIs(t, err, errFindPathRequired)
+
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Path: &file, Tags: map[string]string{"a": "b"}})
+	assert.ErrorIs(t, err, errFindTagsNotSupported)
+}
+
+func TestValidate(t *testing.T) {
+	c := newTestClient(t)
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	c.source = &gitSource{url: filepath.Join(t.TempDir(), "missing")}
+	result, err = c.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+}
+
+func TestClose(t *testing.T) {
+	c := newTestClient(t)
+	key, ok := c.keys.pgpKeys[0].PrivateKey.PrivateKey.(*rsa.PrivateKey)
+	require.True(t, ok)
+	require.NoError(t, c.Close(context.Background()))
+	assert.Zero(t, key.D.Sign())
+	assert.Empty(t, c.keys.pgpKeys)
+	assert.Empty(t, c.keys.ageIdentities)
+}
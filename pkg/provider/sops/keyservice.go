@@ -0,0 +1,181 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/kms"
+	"google.golang.org/grpc"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils/guarded"
+)
+
+// keyService decrypts the data key of SOPS files with the keys of the
+// store. Unlike the local key service of sops it never uses keys or
+// credentials from the environment of the controller.
+type keyService struct {
+	ageIdentities  sopsage.ParsedIdentities
+	pgpKeys        openpgp.EntityList
+	awsCredentials aws.CredentialsProvider
+}
+
+var _ keyservice.KeyServiceClient = &keyService{}
+
+// wipe overwrites the pgp private keys with zeros and drops the age
+// identities, whose key material is not accessible. The key service is
+// unusable afterwards.
+func (ks *keyService) wipe() {
+	for _, entity := range ks.pgpKeys {
+		if entity.PrivateKey != nil {
+			guarded.WipePrivateKey(entity.PrivateKey.PrivateKey)
+		}
+		for _, subkey := range entity.Subkeys {
+			if subkey.PrivateKey != nil {
+				guarded.WipePrivateKey(subkey.PrivateKey.PrivateKey)
+			}
+		}
+	}
+	ks.pgpKeys = nil
+	ks.ageIdentities = nil
+}
+
+func (ks *keyService) Encrypt(context.Context, *keyservice.EncryptRequest, ...grpc.CallOption) (*keyservice.EncryptResponse, error) {
+	return nil, errors.New("encrypting is not supported")
+}
+
+func (ks *keyService) Decrypt(_ context.Context, req *keyservice.DecryptRequest, _ ...grpc.CallOption) (*keyservice.DecryptResponse, error) {
+	var (
+		plaintext []byte
+		err       error
+	)
+	switch key := req.GetKey().GetKeyType().(type) {
+	case *keyservice.Key_AgeKey:
+		plaintext, err = ks.decryptAge(key.AgeKey, req.GetCiphertext())
+	case *keyservice.Key_PgpKey:
+		plaintext, err = ks.decryptPGP(req.GetCiphertext())
+	case *keyservice.Key_KmsKey:
+		plaintext, err = ks.decryptKMS(key.KmsKey, req.GetCiphertext())
+	default:
+		err = errUnsupportedKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &keyservice.DecryptResponse{Plaintext: plaintext}, nil
+}
+
+// keyErrors records the errors of the key service while decrypting the data
+// key of a single file, sops only reports how many key groups failed.
+type keyErrors struct {
+	*keyService
+	errs []error
+}
+
+func (k *keyErrors) Decrypt(ctx context.Context, req *keyservice.DecryptRequest, opts ...grpc.CallOption) (*keyservice.DecryptResponse, error) {
+	res, err := k.keyService.Decrypt(ctx, req, opts...)
+	if err != nil {
+		k.errs = append(k.errs, err)
+	}
+	return res, err
+}
+
+// err returns the recorded errors, or fallback if there are none.
+func (k *keyErrors) err(fallback error) error {
+	if len(k.errs) == 0 {
+		return fallback
+	}
+	return errors.Join(k.errs...)
+}
+
+func (ks *keyService) decryptAge(key *keyservice.AgeKey, ciphertext []byte) ([]byte, error) {
+	if len(ks.ageIdentities) == 0 {
+		return nil, errNoAgeKey
+	}
+	mk := &sopsage.MasterKey{Recipient: key.GetRecipient()}
+	ks.ageIdentities.ApplyToMasterKey(mk)
+	mk.SetEncryptedDataKey(ciphertext)
+	return mk.Decrypt()
+}
+
+func (ks *keyService) decryptPGP(ciphertext []byte) ([]byte, error) {
+	if len(ks.pgpKeys) == 0 {
+		return nil, errNoPGPKey
+	}
+	block, err := armor.Decode(bytes.NewReader(ciphertext))
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptPGP, err)
+	}
+	md, err := openpgp.ReadMessage(block.Body, ks.pgpKeys, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptPGP, err)
+	}
+	return io.ReadAll(md.UnverifiedBody)
+}
+
+func (ks *keyService) decryptKMS(key *keyservice.KmsKey, ciphertext []byte) ([]byte, error) {
+	if ks.awsCredentials == nil {
+		return nil, errNoKMSCredentials
+	}
+	encryptionContext := make(map[string]*string, len(key.GetContext()))
+	for k, v := range key.GetContext() {
+		encryptionContext[k] = aws.String(v)
+	}
+	mk := kms.NewMasterKey(key.GetArn(), key.GetRole(), encryptionContext)
+	kms.NewCredentialsProvider(ks.awsCredentials).ApplyToMasterKey(mk)
+	mk.SetEncryptedDataKey(ciphertext)
+	plaintext, err := mk.Decrypt()
+	metrics.ObserveAPICall(constants.ProviderSOPS, constants.CallSOPSKMSDecrypt, err)
+	return plaintext, err
+}
+
+// parsePGPKey parses an armored private key and decrypts it with the
+// passphrase if it is protected.
+func parsePGPKey(armored, passphrase string) (openpgp.EntityList, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader([]byte(armored)))
+	if err != nil {
+		return nil, fmt.Errorf(errParsePGPKey, err)
+	}
+	for _, entity := range entities {
+		if entity.PrivateKey == nil {
+			return nil, fmt.Errorf(errParsePGPKey, errNotAPrivateKey)
+		}
+		keys := []*openpgp.Key{{PrivateKey: entity.PrivateKey}}
+		for i := range entity.Subkeys {
+			keys = append(keys, &openpgp.Key{PrivateKey: entity.Subkeys[i].PrivateKey})
+		}
+		for _, key := range keys {
+			if key.PrivateKey == nil || !key.PrivateKey.Encrypted {
+				continue
+			}
+			if passphrase == "" {
+				return nil, fmt.Errorf(errParsePGPKey, errMissingPassphrase)
+			}
+			if err := key.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+				return nil, fmt.Errorf(errParsePGPKey, err)
+			}
+		}
+	}
+	return entities, nil
+}
@@ -0,0 +1,241 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/getsops/sops/v3/logging"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/sirupsen/logrus"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errGitClone          = "unable to clone %s: %w"
+	errGitList           = "unable to list references of %s: %w"
+	errInvalidFile       = "invalid file %q, expected a path relative to the path of the store"
+	errLoadFile          = "unable to load sops file %s: %w"
+	errDataKey           = "unable to decrypt the data key of %s: %w"
+	errDecryptFile       = "unable to decrypt %s: %w"
+	errMultipleDocuments = "%s contains multiple documents"
+	errParseAgeKey       = "unable to parse age key: %w"
+	errParsePGPKey       = "unable to parse pgp key: %w"
+	errDecryptPGP        = "unable to decrypt with pgp key: %w"
+
+	validateTimeout = 10 * time.Second
+)
+
+var (
+	errMissingStore         = errors.New("missing store specification")
+	errInvalidSpec          = errors.New("invalid specification for sops provider")
+	errMissingURL           = errors.New("git.url must be set")
+	errInvalidURL           = errors.New("git.url must be an absolute url")
+	errInvalidPath          = errors.New("git.path must be a relative path inside the repository")
+	errMissingKeys          = errors.New("at least one of keys.ageSecretRef, keys.pgp or keys.kms must be set")
+	errMissingSecretName    = errors.New("must specify a secret name")
+	errMissingSecretKey     = errors.New("must specify a secret key")
+	errNotAPrivateKey       = errors.New("key is not a private key")
+	errMissingPassphrase    = errors.New("key is protected by a passphrase, but no passphrase is configured")
+	errUnsupportedKey       = errors.New("key type is not supported, use age, pgp or kms")
+	errNoAgeKey             = errors.New("no age key configured")
+	errNoPGPKey             = errors.New("no pgp key configured")
+	errNoKMSCredentials     = errors.New("no kms credentials configured")
+	errMACMismatch          = errors.New("message authentication code does not match, the file has been tampered with")
+	errVersionNotSupported  = errors.New("specifying a version is not supported by sops")
+	errNotAnObject          = errors.New("value is not an object")
+	errFindPathRequired     = errors.New("find requires the path of a file")
+	errFindTagsNotSupported = errors.New("find by tags is not supported by sops")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	resolve := func(ref esmeta.SecretKeySelector) (string, error) {
+		return resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &ref)
+	}
+	source := &gitSource{
+		url: cfg.Git.URL,
+		ref: cfg.Git.Ref,
+		dir: cfg.Git.Path,
+	}
+	if cfg.Git.Auth != nil {
+		username, err := resolve(cfg.Git.Auth.UsernameSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		password, err := resolve(cfg.Git.Auth.PasswordSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		source.auth = &githttp.BasicAuth{Username: username, Password: password}
+	}
+	keys, err := newKeyService(&cfg.Keys, resolve)
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		source: source,
+		keys:   keys,
+	}, nil
+}
+
+func newKeyService(cfg *esv1beta1.SOPSKeys, resolve func(esmeta.SecretKeySelector) (string, error)) (*keyService, error) {
+	ks := &keyService{}
+	if cfg.AgeSecretRef != nil {
+		identities, err := resolve(*cfg.AgeSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		if err := ks.ageIdentities.Import(identities); err != nil {
+			return nil, fmt.Errorf(errParseAgeKey, err)
+		}
+	}
+	if cfg.PGP != nil {
+		key, err := resolve(cfg.PGP.PrivateKeySecretRef)
+		if err != nil {
+			return nil, err
+		}
+		var passphrase string
+		if cfg.PGP.PassphraseSecretRef != nil {
+			passphrase, err = resolve(*cfg.PGP.PassphraseSecretRef)
+			if err != nil {
+				return nil, err
+			}
+		}
+		ks.pgpKeys, err = parsePGPKey(key, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.KMS != nil {
+		accessKeyID, err := resolve(cfg.KMS.AccessKeyIDSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		secretAccessKey, err := resolve(cfg.KMS.SecretAccessKeySecretRef)
+		if err != nil {
+			return nil, err
+		}
+		var sessionToken string
+		if cfg.KMS.SessionTokenSecretRef != nil {
+			sessionToken, err = resolve(*cfg.KMS.SessionTokenSecretRef)
+			if err != nil {
+				return nil, err
+			}
+		}
+		ks.awsCredentials = credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, sessionToken)
+	}
+	return ks, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.SOPSProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.SOPS == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.SOPS
+
+	if cfg.Git.URL == "" {
+		return nil, errMissingURL
+	}
+	u, err := url.Parse(cfg.Git.URL)
+	if err != nil || u.Scheme == "" {
+		return nil, errInvalidURL
+	}
+	if dir := path.Clean(cfg.Git.Path); path.IsAbs(dir) || dir == ".." || strings.HasPrefix(dir, "../") {
+		return nil, errInvalidPath
+	}
+	var refs []esmeta.SecretKeySelector
+	if cfg.Git.Auth != nil {
+		refs = append(refs, cfg.Git.Auth.UsernameSecretRef, cfg.Git.Auth.PasswordSecretRef)
+	}
+	keys := cfg.Keys
+	if keys.AgeSecretRef == nil && keys.PGP == nil && keys.KMS == nil {
+		return nil, errMissingKeys
+	}
+	if keys.AgeSecretRef != nil {
+		refs = append(refs, *keys.AgeSecretRef)
+	}
+	if keys.PGP != nil {
+		refs = append(refs, keys.PGP.PrivateKeySecretRef)
+		if keys.PGP.PassphraseSecretRef != nil {
+			refs = append(refs, *keys.PGP.PassphraseSecretRef)
+		}
+	}
+	if keys.KMS != nil {
+		refs = append(refs, keys.KMS.AccessKeyIDSecretRef, keys.KMS.SecretAccessKeySecretRef)
+		if keys.KMS.SessionTokenSecretRef != nil {
+			refs = append(refs, *keys.KMS.SessionTokenSecretRef)
+		}
+	}
+	for _, ref := range refs {
+		if err := validateSecretRef(store, ref); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func validateSecretRef(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return err
+	}
+	if ref.Name == "" {
+		return errMissingSecretName
+	}
+	if ref.Key == "" {
+		return errMissingSecretKey
+	}
+	return nil
+}
+
+func init() {
+	// sops logs every decryption of a data key
+	logging.SetLevel(logrus.WarnLevel)
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		SOPS: &esv1beta1.SOPSProvider{},
+	})
+}
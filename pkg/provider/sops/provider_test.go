@@ -0,0 +1,241 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func newStore(provider *esv1beta1.SOPSProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "sops", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{SOPS: provider},
+		},
+	}
+}
+
+func ref(name, key string) *esmeta.SecretKeySelector {
+	return &esmeta.SecretKeySelector{Name: name, Key: key}
+}
+
+func ageKeyRef(name, key string) esv1beta1.SOPSKeys {
+	return esv1beta1.SOPSKeys{AgeSecretRef: ref(name, key)}
+}
+
+// armoredPrivateKey returns the armored private key of entity, protected by
+// passphrase if it is not empty.
+func armoredPrivateKey(t *testing.T, entity *openpgp.Entity, passphrase string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	if passphrase != "" {
+		require.NoError(t, entity.SerializePrivate(w, nil))
+		require.NoError(t, w.Close())
+		entities, err := openpgp.ReadArmoredKeyRing(&buf)
+		require.NoError(t, err)
+		require.NoError(t, entities[0].EncryptPrivateKeys([]byte(passphrase), nil))
+		buf.Reset()
+		w, err = armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, entities[0].SerializePrivateWithoutSigning(w, nil))
+	} else {
+		require.NoError(t, entity.SerializePrivate(w, nil))
+	}
+	require.NoError(t, w.Close())
+	return buf.String()
+}
+
+func TestValidateStore(t *testing.T) {
+	git := esv1beta1.SOPSGitSource{URL: "https://git.example.com/secrets.git"}
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "missing url",
+			store:   newStore(&esv1beta1.SOPSProvider{Keys: ageKeyRef("sops", "age")}),
+			wantErr: errMissingURL,
+		},
+		{
+			name:    "invalid url",
+			store:   newStore(&esv1beta1.SOPSProvider{Git: esv1beta1.SOPSGitSource{URL: "git.example.com/secrets.git"}, Keys: ageKeyRef("sops", "age")}),
+			wantErr: errInvalidURL,
+		},
+		{
+			name:    "absolute path",
+			store:   newStore(&esv1beta1.SOPSProvider{Git: esv1beta1.SOPSGitSource{URL: git.URL, Path: "/secrets"}, Keys: ageKeyRef("sops", "age")}),
+			wantErr: errInvalidPath,
+		},
+		{
+			name:    "path outside of repository",
+			store:   newStore(&esv1beta1.SOPSProvider{Git: esv1beta1.SOPSGitSource{URL: git.URL, Path: "secrets/../.."}, Keys: ageKeyRef("sops", "age")}),
+			wantErr: errInvalidPath,
+		},
+		{
+			name:    "missing keys",
+			store:   newStore(&esv1beta1.SOPSProvider{Git: git}),
+			wantErr: errMissingKeys,
+		},
+		{
+			name:    "missing secret name",
+			store:   newStore(&esv1beta1.SOPSProvider{Git: git, Keys: ageKeyRef("", "age")}),
+			wantErr: errMissingSecretName,
+		},
+		{
+			name: "missing secret key",
+			store: newStore(&esv1beta1.SOPSProvider{Git: git, Keys: esv1beta1.SOPSKeys{
+				PGP: &esv1beta1.SOPSPGPKey{PrivateKeySecretRef: *ref("sops", "pgp"), PassphraseSecretRef: ref("sops", "")},
+			}}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name: "missing git auth secret key",
+			store: newStore(&esv1beta1.SOPSProvider{
+				Git: esv1beta1.SOPSGitSource{URL: git.URL, Auth: &esv1beta1.SOPSGitAuth{
+					UsernameSecretRef: *ref("git", "username"),
+					PasswordSecretRef: *ref("git", ""),
+				}},
+				Keys: ageKeyRef("sops", "age"),
+			}),
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name: "valid",
+			store: newStore(&esv1beta1.SOPSProvider{
+				Git: esv1beta1.SOPSGitSource{URL: git.URL, Ref: "main", Path: "clusters/prod"},
+				Keys: esv1beta1.SOPSKeys{
+					AgeSecretRef: ref("sops", "age"),
+					KMS: &esv1beta1.SOPSKMSAuth{
+						AccessKeyIDSecretRef:     *ref("aws", "access-key-id"),
+						SecretAccessKeySecretRef: *ref("aws", "secret-access-key"),
+					},
+				},
+			}),
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ValidateStore(tt.store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	identity := newAgeIdentity(t)
+	entity := newPGPEntity(t)
+	kube := clientfake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "sops", Namespace: "default"},
+			Data: map[string][]byte{
+				"age":        []byte("# created: 2024-01-01\n" + identity.String() + "\n"),
+				"pgp":        []byte(armoredPrivateKey(t, entity, "passphrase")),
+				"passphrase": []byte("passphrase"),
+				"invalid":    []byte("invalid"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "git", Namespace: "default"},
+			Data:       map[string][]byte{"username": []byte("eso"), "password": []byte("token")},
+		},
+	).Build()
+	p := &Provider{}
+	store := newStore(&esv1beta1.SOPSProvider{
+		Git: esv1beta1.SOPSGitSource{
+			URL:  "https://git.example.com/secrets.git",
+			Ref:  "main",
+			Path: "clusters/prod",
+			Auth: &esv1beta1.SOPSGitAuth{UsernameSecretRef: *ref("git", "username"), PasswordSecretRef: *ref("git", "password")},
+		},
+		Keys: esv1beta1.SOPSKeys{
+			AgeSecretRef: ref("sops", "age"),
+			PGP:          &esv1beta1.SOPSPGPKey{PrivateKeySecretRef: *ref("sops", "pgp"), PassphraseSecretRef: ref("sops", "passphrase")},
+			KMS:          &esv1beta1.SOPSKMSAuth{AccessKeyIDSecretRef: *ref("git", "username"), SecretAccessKeySecretRef: *ref("git", "password")},
+		},
+	})
+	sc, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	c := sc.(*client)
+	assert.Equal(t, "https://git.example.com/secrets.git", c.source.url)
+	assert.Equal(t, "main", c.source.ref)
+	assert.Equal(t, "clusters/prod", c.source.dir)
+	assert.Equal(t, &githttp.BasicAuth{Username: "eso", Password: "token"}, c.source.auth)
+	assert.Len(t, c.keys.ageIdentities, 1)
+	require.Len(t, c.keys.pgpKeys, 1)
+	assert.False(t, c.keys.pgpKeys[0].PrivateKey.Encrypted)
+	assert.NotNil(t, c.keys.awsCredentials)
+
+	store.Spec.Provider.SOPS.Keys.PGP.PassphraseSecretRef = nil
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.ErrorIs(t, err, errMissingPassphrase)
+
+	store.Spec.Provider.SOPS.Keys.PGP = nil
+	store.Spec.Provider.SOPS.Keys.AgeSecretRef = ref("sops", "invalid")
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.ErrorContains(t, err, "unable to parse age key")
+
+	store.Spec.Provider.SOPS.Keys.AgeSecretRef = ref("sops", "missing")
+	_, err = p.NewClient(context.Background(), store, kube, "default")
+	assert.Error(t, err)
+}
+
+func TestParsePGPKey(t *testing.T) {
+	entity := newPGPEntity(t)
+	keys, err := parsePGPKey(armoredPrivateKey(t, entity, ""), "")
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	protected := armoredPrivateKey(t, entity, "passphrase")
+	_, err = parsePGPKey(protected, "wrong")
+	assert.ErrorContains(t, err, "unable to parse pgp key")
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	_, err = parsePGPKey(buf.String(), "")
+	assert.ErrorIs(t, err, errNotAPrivateKey)
+}
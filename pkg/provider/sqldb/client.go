@@ -0,0 +1,180 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+type client struct {
+	db    *sql.DB
+	query string
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+// GetSecret runs the query of the store with key as parameter. property
+// selects a column, without property the value of a single column is
+// returned as is and multiple columns are returned as JSON.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	row, columns, err := c.getRow(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property != "" {
+		value, ok := row[ref.Property]
+		if !ok {
+			return nil, esv1beta1.NoSecretError{}
+		}
+		return value, nil
+	}
+	if len(columns) == 1 {
+		value, ok := row[columns[0]]
+		if !ok {
+			return nil, esv1beta1.NoSecretError{}
+		}
+		return value, nil
+	}
+	obj := make(map[string]string, len(row))
+	for k, v := range row {
+		obj[k] = string(v)
+	}
+	return json.Marshal(obj)
+}
+
+// GetSecretMap returns the columns of the row returned for key.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Property != "" {
+		return nil, errPropertyNotSupported
+	}
+	row, _, err := c.getRow(ctx, ref)
+	return row, err
+}
+
+// GetAllSecrets is not supported, the query returns the row of a single key.
+func (c *client) GetAllSecrets(context.Context, esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, errFindNotSupported
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("pushing secrets is not supported by sql")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New("deleting secrets is not supported by sql")
+}
+
+// Validate checks that the database accepts the credentials.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	err := c.db.PingContext(ctx)
+	metrics.ObserveAPICall(constants.ProviderSQL, constants.CallSQLPing, err)
+	if err != nil {
+		return esv1beta1.ValidationResultError, fmt.Errorf(errConnect, err)
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(context.Context) error {
+	return c.db.Close()
+}
+
+// getRow returns the non null columns of the row returned for the key and
+// the names of all columns in the order of the query. No row returns a
+// NoSecretError.
+func (c *client) getRow(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, []string, error) {
+	if ref.Version != "" {
+		return nil, nil, errVersionNotSupported
+	}
+	if ref.Key == "" {
+		return nil, nil, errMissingKey
+	}
+	row, columns, err := c.queryRow(ctx, ref.Key)
+	metrics.ObserveAPICall(constants.ProviderSQL, constants.CallSQLQuery, err)
+	return row, columns, err
+}
+
+// queryRow runs the query in a read-only transaction, which is always
+// rolled back.
+func (c *client) queryRow(ctx context.Context, key string) (map[string][]byte, []string, error) {
+	tx, err := c.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf(errConnect, err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	rows, err := tx.QueryContext(ctx, c.query, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errQuery, err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, fmt.Errorf(errQuery, err)
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, nil, fmt.Errorf(errQuery, err)
+		}
+		return nil, nil, esv1beta1.NoSecretError{}
+	}
+	values := make([]any, len(columns))
+	ptrs := make([]any, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return nil, nil, fmt.Errorf(errQuery, err)
+	}
+	if rows.Next() {
+		return nil, nil, errMultipleRows
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf(errQuery, err)
+	}
+	row := make(map[string][]byte, len(columns))
+	for i, column := range columns {
+		if values[i] == nil {
+			continue
+		}
+		row[column] = valueBytes(values[i])
+	}
+	return row, columns, nil
+}
+
+// valueBytes converts a column value as returned by the drivers.
+func valueBytes(v any) []byte {
+	switch v := v.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	case time.Time:
+		return []byte(v.Format(time.RFC3339Nano))
+	default:
+		return []byte(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const testQuery = "SELECT username, password, port, rotated_at, comment FROM credentials WHERE app = $1"
+
+func newTestClient(t *testing.T) (*client, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual), sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return &client{db: db, query: testQuery}, mock
+}
+
+func credentialRows() *sqlmock.Rows {
+	rotated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return sqlmock.NewRows([]string{"username", "password", "port", "rotated_at", "comment"}).
+		AddRow("app", []byte("s3cr3t"), int64(5432), rotated, nil)
+}
+
+func TestGetSecret(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		query    string
+		rows     *sqlmock.Rows
+		queryErr error
+		want     string
+		wantErr  error
+		contains string
+	}{
+		{
+			name: "property",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Property: "password"},
+			rows: credentialRows(),
+			want: "s3cr3t",
+		},
+		{
+			name: "integer column",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Property: "port"},
+			rows: credentialRows(),
+			want: "5432",
+		},
+		{
+			name: "time column",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Property: "rotated_at"},
+			rows: credentialRows(),
+			want: "2024-01-02T03:04:05Z",
+		},
+		{
+			name:    "null column",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Property: "comment"},
+			rows:    credentialRows(),
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "missing column",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Property: "host"},
+			rows:    credentialRows(),
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name: "all columns as json",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "web"},
+			rows: credentialRows(),
+			want: `{"password":"s3cr3t","port":"5432","rotated_at":"2024-01-02T03:04:05Z","username":"app"}`,
+		},
+		{
+			name:  "single column",
+			ref:   esv1beta1.ExternalSecretDataRemoteRef{Key: "web"},
+			query: "SELECT password FROM credentials WHERE app = $1",
+			rows:  sqlmock.NewRows([]string{"password"}).AddRow("s3cr3t"),
+			want:  "s3cr3t",
+		},
+		{
+			name:    "no row",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "web"},
+			rows:    sqlmock.NewRows([]string{"username", "password"}),
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "multiple rows",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "web"},
+			rows:    sqlmock.NewRows([]string{"username", "password"}).AddRow("a", "b").AddRow("c", "d"),
+			wantErr: errMultipleRows,
+		},
+		{
+			name:     "query error",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "web"},
+			queryErr: errors.New("permission denied for table credentials"),
+			contains: "unable to run the query: permission denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mock := newTestClient(t)
+			if tt.query != "" {
+				c.query = tt.query
+			}
+			mock.ExpectBegin()
+			exp := mock.ExpectQuery(c.query).WithArgs(tt.ref.Key)
+			if tt.queryErr != nil {
+				exp.WillReturnError(tt.queryErr)
+			} else {
+				exp.WillReturnRows(tt.rows)
+			}
+			mock.ExpectRollback()
+
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+			// the transaction is always rolled back
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetSecretInvalidRef(t *testing.T) {
+	c, mock := newTestClient(t)
+	_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{})
+	assert.ErrorIs(t, err, errMissingKey)
+	_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Version: "1"})
+	assert.ErrorIs(t, err, errVersionNotSupported)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c, mock := newTestClient(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(testQuery).WithArgs("web").WillReturnRows(credentialRows())
+	mock.ExpectRollback()
+
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "web"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"username":   []byte("app"),
+		"password":   []byte("s3cr3t"),
+		"port":       []byte("5432"),
+		"rotated_at": []byte("2024-01-02T03:04:05Z"),
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "web", Property: "password"})
+	assert.ErrorIs(t, err, errPropertyNotSupported)
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	c, _ := newTestClient(t)
+	_, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+	assert.ErrorIs(t, err, errFindNotSupported)
+}
+
+func TestValidate(t *testing.T) {
+	c, mock := newTestClient(t)
+	mock.ExpectPing()
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	mock.ExpectPing().WillReturnError(errors.New("password authentication failed"))
+	result, err = c.Validate()
+	assert.ErrorContains(t, err, "unable to connect to the database")
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+}
@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldb
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	defaultPostgresPort = 5432
+	defaultMySQLPort    = 3306
+)
+
+// newConnector returns a connector for the database of the store, no
+// connection is opened.
+func newConnector(cfg *esv1beta1.SQLProvider, password string) (driver.Connector, error) {
+	switch cfg.Driver {
+	case esv1beta1.SQLDriverPostgres:
+		return pq.NewConnector(postgresDSN(cfg, password))
+	case esv1beta1.SQLDriverMySQL:
+		return mysql.NewConnector(mysqlConfig(cfg, password))
+	default:
+		return nil, fmt.Errorf(errUnsupportedDriver, cfg.Driver)
+	}
+}
+
+// postgresDSN returns the connection URL for lib/pq. The CA bundle is
+// passed inline, so it does not have to be written to a file.
+func postgresDSN(cfg *esv1beta1.SQLProvider, password string) string {
+	query := url.Values{
+		"sslmode":         {string(tlsMode(cfg))},
+		"connect_timeout": {strconv.Itoa(int(connectTimeout.Seconds()))},
+	}
+	if len(cfg.CABundle) > 0 && tlsMode(cfg) == esv1beta1.SQLTLSModeVerifyFull {
+		query.Set("sslinline", "true")
+		query.Set("sslrootcert", string(cfg.CABundle))
+	}
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.Auth.Username, password),
+		Host:     net.JoinHostPort(cfg.Host, strconv.Itoa(port(cfg))),
+		Path:     "/" + cfg.Database,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func mysqlConfig(cfg *esv1beta1.SQLProvider, password string) *mysql.Config {
+	c := mysql.NewConfig()
+	c.Net = "tcp"
+	c.Addr = net.JoinHostPort(cfg.Host, strconv.Itoa(port(cfg)))
+	c.DBName = cfg.Database
+	c.User = cfg.Auth.Username
+	c.Passwd = password
+	c.Timeout = connectTimeout
+	switch tlsMode(cfg) {
+	case esv1beta1.SQLTLSModeRequire:
+		//nolint:gosec // require does not verify the certificate of the server
+		c.TLS = &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12}
+	case esv1beta1.SQLTLSModeVerifyFull:
+		c.TLS = &tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12}
+		if len(cfg.CABundle) > 0 {
+			c.TLS.RootCAs = x509.NewCertPool()
+			c.TLS.RootCAs.AppendCertsFromPEM(cfg.CABundle)
+		}
+	}
+	return c
+}
+
+func tlsMode(cfg *esv1beta1.SQLProvider) esv1beta1.SQLTLSMode {
+	if cfg.TLSMode == "" {
+		return esv1beta1.SQLTLSModeVerifyFull
+	}
+	return cfg.TLSMode
+}
+
+func port(cfg *esv1beta1.SQLProvider) int {
+	switch {
+	case cfg.Port != 0:
+		return int(cfg.Port)
+	case cfg.Driver == esv1beta1.SQLDriverMySQL:
+		return defaultMySQLPort
+	default:
+		return defaultPostgresPort
+	}
+}
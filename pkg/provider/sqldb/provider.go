@@ -0,0 +1,150 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldb
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errUnsupportedDriver = "unsupported driver %q, use postgres or mysql"
+	errConnect           = "unable to connect to the database: %w"
+	errQuery             = "unable to run the query: %w"
+
+	connectTimeout  = 10 * time.Second
+	validateTimeout = 10 * time.Second
+)
+
+var (
+	errMissingStore         = errors.New("missing store specification")
+	errInvalidSpec          = errors.New("invalid specification for sql provider")
+	errInvalidDriver        = errors.New("driver must be postgres or mysql")
+	errMissingHost          = errors.New("host must be set")
+	errInvalidPort          = errors.New("port must be between 1 and 65535")
+	errMissingDatabase      = errors.New("database must be set")
+	errMissingUsername      = errors.New("auth.username must be set")
+	errInvalidTLSMode       = errors.New("tlsMode must be disable, require or verify-full")
+	errInvalidCABundle      = errors.New("caBundle does not contain a PEM encoded certificate")
+	errMissingQuery         = errors.New("query must be set")
+	errMissingSecretName    = errors.New("must specify a secret name")
+	errMissingSecretKey     = errors.New("must specify a secret key")
+	errMissingKey           = errors.New("key must be set to the parameter of the query")
+	errMultipleRows         = errors.New("query returned more than one row")
+	errVersionNotSupported  = errors.New("specifying a version is not supported by sql")
+	errPropertyNotSupported = errors.New("property is not supported by dataFrom.extract")
+	errFindNotSupported     = errors.New("find is not supported by sql")
+)
+
+type Provider struct{}
+
+var _ esv1beta1.Provider = &Provider{}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	cfg, err := getConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	password, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &cfg.Auth.PasswordSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	connector, err := newConnector(cfg, password)
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(connector)
+	// the client is used for a single reconcile
+	db.SetMaxOpenConns(1)
+	return &client{
+		db:    db,
+		query: cfg.Query,
+	}, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	_, err := getConfig(store)
+	return nil, err
+}
+
+func getConfig(store esv1beta1.GenericStore) (*esv1beta1.SQLProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.SQL == nil {
+		return nil, errInvalidSpec
+	}
+	cfg := storeSpec.Provider.SQL
+
+	if cfg.Driver != esv1beta1.SQLDriverPostgres && cfg.Driver != esv1beta1.SQLDriverMySQL {
+		return nil, errInvalidDriver
+	}
+	if cfg.Host == "" {
+		return nil, errMissingHost
+	}
+	if cfg.Port < 0 || cfg.Port > 65535 {
+		return nil, errInvalidPort
+	}
+	if cfg.Database == "" {
+		return nil, errMissingDatabase
+	}
+	if cfg.Auth.Username == "" {
+		return nil, errMissingUsername
+	}
+	switch cfg.TLSMode {
+	case "", esv1beta1.SQLTLSModeDisable, esv1beta1.SQLTLSModeRequire, esv1beta1.SQLTLSModeVerifyFull:
+	default:
+		return nil, errInvalidTLSMode
+	}
+	if len(cfg.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(cfg.CABundle) {
+		return nil, errInvalidCABundle
+	}
+	if strings.TrimSpace(cfg.Query) == "" {
+		return nil, errMissingQuery
+	}
+
+	ref := cfg.Auth.PasswordSecretRef
+	if err := utils.ValidateReferentSecretSelector(store, ref); err != nil {
+		return nil, err
+	}
+	if ref.Name == "" {
+		return nil, errMissingSecretName
+	}
+	if ref.Key == "" {
+		return nil, errMissingSecretKey
+	}
+	return cfg, nil
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		SQL: &esv1beta1.SQLProvider{},
+	})
+}
@@ -0,0 +1,233 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldb
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const testCA = `-----BEGIN CERTIFICATE-----
+MIIBhTCCASugAwIBAgIQIRi6zePL6mKjOipn+dNuaTAKBggqhkjOPQQDAjASMRAw
+DgYDVQQKEwdBY21lIENvMB4XDTE3MTAyMDE5NDMwNloXDTE4MTAyMDE5NDMwNlow
+EjEQMA4GA1UEChMHQWNtZSBDbzBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABD0d
+7VNhbWvZLWPuj/RtHFjvtJBEwOkhbN/BnnE8rnZR8+sbwnc/KhCk3FhnpHZnQz7B
+5aETbbIgmuvewdjvSBSjYzBhMA4GA1UdDwEB/wQEAwICpDATBgNVHSUEDDAKBggr
+BgEFBQcDATAPBgNVHRMBAf8EBTADAQH/MCkGA1UdEQQiMCCCDmxvY2FsaG9zdDo1
+NDUzgg4xMjcuMC4wLjE6NTQ1MzAKBggqhkjOPQQDAgNIADBFAiEA2zpJEPQyz6/l
+Wf86aX6PepsntZv2GYlA5UpabfT2EZICICpJ5h/iI+i341gBmLiAFQOyTDT+/wQc
+6MF9+Yw1Yy0t
+-----END CERTIFICATE-----`
+
+func newStore(provider *esv1beta1.SQLProvider) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "sql", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{SQL: provider},
+		},
+	}
+}
+
+func validProvider() *esv1beta1.SQLProvider {
+	return &esv1beta1.SQLProvider{
+		Driver:   esv1beta1.SQLDriverPostgres,
+		Host:     "db.example.com",
+		Database: "apps",
+		Auth: esv1beta1.SQLAuth{
+			Username:          "eso",
+			PasswordSecretRef: esmeta.SecretKeySelector{Name: "db", Key: "password"},
+		},
+		Query: "SELECT username, password FROM credentials WHERE app = $1",
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*esv1beta1.SQLProvider)
+		store   esv1beta1.GenericStore
+		wantErr error
+	}{
+		{
+			name:    "missing store",
+			wantErr: errMissingStore,
+		},
+		{
+			name:    "missing provider",
+			store:   newStore(nil),
+			wantErr: errInvalidSpec,
+		},
+		{
+			name:    "invalid driver",
+			modify:  func(p *esv1beta1.SQLProvider) { p.Driver = "sqlite" },
+			wantErr: errInvalidDriver,
+		},
+		{
+			name:    "missing host",
+			modify:  func(p *esv1beta1.SQLProvider) { p.Host = "" },
+			wantErr: errMissingHost,
+		},
+		{
+			name:    "invalid port",
+			modify:  func(p *esv1beta1.SQLProvider) { p.Port = 70000 },
+			wantErr: errInvalidPort,
+		},
+		{
+			name:    "missing database",
+			modify:  func(p *esv1beta1.SQLProvider) { p.Database = "" },
+			wantErr: errMissingDatabase,
+		},
+		{
+			name:    "missing username",
+			modify:  func(p *esv1beta1.SQLProvider) { p.Auth.Username = "" },
+			wantErr: errMissingUsername,
+		},
+		{
+			name:    "invalid tls mode",
+			modify:  func(p *esv1beta1.SQLProvider) { p.TLSMode = "prefer" },
+			wantErr: errInvalidTLSMode,
+		},
+		{
+			name:    "invalid ca bundle",
+			modify:  func(p *esv1beta1.SQLProvider) { p.CABundle = []byte("ca") },
+			wantErr: errInvalidCABundle,
+		},
+		{
+			name:    "missing query",
+			modify:  func(p *esv1beta1.SQLProvider) { p.Query = " " },
+			wantErr: errMissingQuery,
+		},
+		{
+			name:    "missing secret name",
+			modify:  func(p *esv1beta1.SQLProvider) { p.Auth.PasswordSecretRef.Name = "" },
+			wantErr: errMissingSecretName,
+		},
+		{
+			name:    "missing secret key",
+			modify:  func(p *esv1beta1.SQLProvider) { p.Auth.PasswordSecretRef.Key = "" },
+			wantErr: errMissingSecretKey,
+		},
+		{
+			name:   "valid",
+			modify: func(p *esv1beta1.SQLProvider) {},
+		},
+		{
+			name: "valid mysql",
+			modify: func(p *esv1beta1.SQLProvider) {
+				p.Driver = esv1beta1.SQLDriverMySQL
+				p.Port = 3307
+				p.TLSMode = esv1beta1.SQLTLSModeRequire
+				p.CABundle = []byte(testCA)
+				p.Query = "SELECT password FROM credentials WHERE app = ?"
+			},
+		},
+	}
+	p := &Provider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := tt.store
+			if tt.modify != nil {
+				provider := validProvider()
+				tt.modify(provider)
+				store = newStore(provider)
+			}
+			_, err := p.ValidateStore(store)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPostgresDSN(t *testing.T) {
+	cfg := validProvider()
+	dsn, err := url.Parse(postgresDSN(cfg, "p@ss:word"))
+	require.NoError(t, err)
+	assert.Equal(t, "db.example.com:5432", dsn.Host)
+	assert.Equal(t, "/apps", dsn.Path)
+	password, _ := dsn.User.Password()
+	assert.Equal(t, "eso", dsn.User.Username())
+	assert.Equal(t, "p@ss:word", password)
+	assert.Equal(t, url.Values{"sslmode": {"verify-full"}, "connect_timeout": {"10"}}, dsn.Query())
+
+	cfg.Port = 5433
+	cfg.CABundle = []byte(testCA)
+	dsn, err = url.Parse(postgresDSN(cfg, "password"))
+	require.NoError(t, err)
+	assert.Equal(t, "db.example.com:5433", dsn.Host)
+	assert.Equal(t, "true", dsn.Query().Get("sslinline"))
+	assert.Equal(t, testCA, dsn.Query().Get("sslrootcert"))
+
+	cfg.TLSMode = esv1beta1.SQLTLSModeDisable
+	dsn, err = url.Parse(postgresDSN(cfg, "password"))
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{"sslmode": {"disable"}, "connect_timeout": {"10"}}, dsn.Query())
+}
+
+func TestMySQLConfig(t *testing.T) {
+	cfg := validProvider()
+	cfg.Driver = esv1beta1.SQLDriverMySQL
+	c := mysqlConfig(cfg, "password")
+	assert.Equal(t, "db.example.com:3306", c.Addr)
+	assert.Equal(t, "apps", c.DBName)
+	assert.Equal(t, "eso", c.User)
+	assert.Equal(t, "password", c.Passwd)
+	require.NotNil(t, c.TLS)
+	assert.Equal(t, "db.example.com", c.TLS.ServerName)
+	assert.False(t, c.TLS.InsecureSkipVerify)
+	assert.Nil(t, c.TLS.RootCAs)
+
+	cfg.CABundle = []byte(testCA)
+	assert.NotNil(t, mysqlConfig(cfg, "password").TLS.RootCAs)
+
+	cfg.TLSMode = esv1beta1.SQLTLSModeRequire
+	assert.True(t, mysqlConfig(cfg, "password").TLS.InsecureSkipVerify)
+
+	cfg.TLSMode = esv1beta1.SQLTLSModeDisable
+	assert.Nil(t, mysqlConfig(cfg, "password").TLS)
+}
+
+func TestNewClient(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}).Build()
+	p := &Provider{}
+	for _, driver := range []esv1beta1.SQLDriver{esv1beta1.SQLDriverPostgres, esv1beta1.SQLDriverMySQL} {
+		cfg := validProvider()
+		cfg.Driver = driver
+		// no connection is opened until the first query
+		sc, err := p.NewClient(context.Background(), newStore(cfg), kube, "default")
+		require.NoError(t, err)
+		assert.Equal(t, cfg.Query, sc.(*client).query)
+		assert.NoError(t, sc.Close(context.Background()))
+	}
+
+	cfg := validProvider()
+	cfg.Auth.PasswordSecretRef.Key = "missing"
+	_, err := p.NewClient(context.Background(), newStore(cfg), kube, "default")
+	assert.Error(t, err)
+}
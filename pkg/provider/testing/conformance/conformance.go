@@ -0,0 +1,232 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance tests that a SecretsClient behaves the way the
+// controllers expect from every provider, e.g. that missing secrets are
+// reported with a NoSecretError so the deletionPolicy applies.
+//
+// A provider runs the suite from its tests with a client that serves the
+// fixtures of the Suite:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, conformance.Suite{
+//			NewClient: newFakeClient,
+//			Key:       "app",
+//			...
+//		})
+//	}
+package conformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+// pushSecretKey is the key of the Kind=Secret the push tests push.
+const pushSecretKey = "conformance"
+
+// Suite describes the secrets the client under test serves. Keys are in
+// the format of the provider.
+type Suite struct {
+	// NewClient returns the client under test. It is called for every test,
+	// so the writes of a test are not seen by the others.
+	NewClient func(t *testing.T) esv1beta1.SecretsClient
+
+	// Key refers to an existing secret whose value is a JSON object.
+	Key string
+	// Property is a property of Key with the string value PropertyValue.
+	Property      string
+	PropertyValue []byte
+	// MissingKey refers to a secret that does not exist.
+	MissingKey string
+
+	// MapKey refers to the secret GetSecretMap returns MapValue for, and
+	// MissingMapKey to one that does not exist. The GetSecretMap tests are
+	// skipped if MapKey is empty.
+	MapKey        string
+	MapValue      map[string][]byte
+	MissingMapKey string
+
+	// PushKey and PushProperty are the remoteKey and property the push
+	// tests write to, it must not exist before. The push tests are skipped
+	// if PushKey is empty.
+	PushKey      string
+	PushProperty string
+}
+
+type test struct {
+	name string
+	// skip reports whether the test does not apply to the suite.
+	skip func(s *Suite) bool
+	run  func(t *testing.T, s *Suite, c esv1beta1.SecretsClient)
+}
+
+var tests = []test{
+	{
+		name: "GetSecret of a missing secret returns NoSecretError",
+		run: func(t *testing.T, s *Suite, c esv1beta1.SecretsClient) {
+			_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: s.MissingKey})
+			assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+		},
+	},
+	{
+		name: "GetSecret of a property of a missing secret returns NoSecretError",
+		run: func(t *testing.T, s *Suite, c esv1beta1.SecretsClient) {
+			_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: s.MissingKey, Property: s.Property})
+			assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+		},
+	},
+	{
+		name: "GetSecret returns the same value on every call",
+		run: func(t *testing.T, s *Suite, c esv1beta1.SecretsClient) {
+			ref := esv1beta1.ExternalSecretDataRemoteRef{Key: s.Key}
+			first, err := c.GetSecret(context.Background(), ref)
+			require.NoError(t, err)
+			require.NotEmpty(t, first)
+			// values that are serialized from maps must not change their order,
+			// otherwise every sync rewrites the Kind=Secret.
+			for i := 0; i < 10; i++ {
+				value, err := c.GetSecret(context.Background(), ref)
+				require.NoError(t, err)
+				require.Equal(t, string(first), string(value))
+			}
+		},
+	},
+	{
+		name: "GetSecret of a property returns the raw string value",
+		run: func(t *testing.T, s *Suite, c esv1beta1.SecretsClient) {
+			value, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: s.Key, Property: s.Property})
+			require.NoError(t, err)
+			assert.Equal(t, string(s.PropertyValue), string(value))
+		},
+	},
+	{
+		name: "GetSecret of a missing property returns an error",
+		run: func(t *testing.T, s *Suite, c esv1beta1.SecretsClient) {
+			_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: s.Key, Property: "conformance-missing-property"})
+			assert.Error(t, err)
+		},
+	},
+	{
+		name: "GetSecretMap returns the values of the secret",
+		skip: func(s *Suite) bool { return s.MapKey == "" },
+		run: func(t *testing.T, s *Suite, c esv1beta1.SecretsClient) {
+			ref := esv1beta1.ExternalSecretDataRemoteRef{Key: s.MapKey}
+			first, err := c.GetSecretMap(context.Background(), ref)
+			require.NoError(t, err)
+			assert.Equal(t, stringMap(s.MapValue), stringMap(first))
+			for i := 0; i < 10; i++ {
+				values, err := c.GetSecretMap(context.Background(), ref)
+				require.NoError(t, err)
+				require.Equal(t, stringMap(first), stringMap(values))
+			}
+		},
+	},
+	{
+		name: "GetSecretMap of a missing secret returns NoSecretError",
+		skip: func(s *Suite) bool { return s.MapKey == "" },
+		run: func(t *testing.T, s *Suite, c esv1beta1.SecretsClient) {
+			_, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: s.MissingMapKey})
+			assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+		},
+	},
+	{
+		name: "PushSecret writes a value GetSecret reads",
+		skip: skipPush,
+		run: func(t *testing.T, s *Suite, c esv1beta1.SecretsClient) {
+			require.NoError(t, c.PushSecret(context.Background(), pushSecret("v1"), s.pushData()))
+			assert.Equal(t, "v1", string(getPushed(t, s, c)))
+
+			require.NoError(t, c.PushSecret(context.Background(), pushSecret("v2"), s.pushData()))
+			assert.Equal(t, "v2", string(getPushed(t, s, c)))
+		},
+	},
+	{
+		name: "PushSecret of the same value is idempotent",
+		skip: skipPush,
+		run: func(t *testing.T, s *Suite, c esv1beta1.SecretsClient) {
+			for i := 0; i < 3; i++ {
+				require.NoError(t, c.PushSecret(context.Background(), pushSecret("v1"), s.pushData()))
+			}
+			assert.Equal(t, "v1", string(getPushed(t, s, c)))
+		},
+	},
+	{
+		name: "DeleteSecret removes the pushed value and is idempotent",
+		skip: skipPush,
+		run: func(t *testing.T, s *Suite, c esv1beta1.SecretsClient) {
+			require.NoError(t, c.PushSecret(context.Background(), pushSecret("v1"), s.pushData()))
+			require.NoError(t, c.DeleteSecret(context.Background(), s.pushData()))
+			_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: s.PushKey, Property: s.PushProperty})
+			assert.Error(t, err)
+			assert.NoError(t, c.DeleteSecret(context.Background(), s.pushData()))
+		},
+	},
+}
+
+// Run runs the conformance tests against the clients of s.
+func Run(t *testing.T, s Suite) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.skip != nil && tt.skip(&s) {
+				t.Skip("not supported by the provider")
+			}
+			tt.run(t, &s, s.NewClient(t))
+		})
+	}
+}
+
+func skipPush(s *Suite) bool {
+	return s.PushKey == ""
+}
+
+func (s *Suite) pushData() fake.PushSecretData {
+	return fake.PushSecretData{
+		SecretKey: pushSecretKey,
+		RemoteKey: s.PushKey,
+		Property:  s.PushProperty,
+	}
+}
+
+func pushSecret(value string) *corev1.Secret {
+	return &corev1.Secret{Data: map[string][]byte{pushSecretKey: []byte(value)}}
+}
+
+func getPushed(t *testing.T, s *Suite, c esv1beta1.SecretsClient) []byte {
+	t.Helper()
+	value, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: s.PushKey, Property: s.PushProperty})
+	if errors.Is(err, esv1beta1.NoSecretErr) {
+		t.Fatalf("pushed secret %s not found", s.PushKey)
+	}
+	require.NoError(t, err)
+	return value
+}
+
+// stringMap makes the values of m readable in failures.
+func stringMap(m map[string][]byte) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = string(v)
+	}
+	return out
+}
@@ -0,0 +1,203 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	defaultSignatureHeader = "X-Signature"
+	defaultTimestampHeader = "X-Timestamp"
+)
+
+// authenticator adds credentials to a request before it is sent.
+type authenticator interface {
+	authenticate(req *http.Request, body []byte) error
+}
+
+// bearerAuth sends a static token.
+type bearerAuth struct {
+	token string
+}
+
+func (a *bearerAuth) authenticate(req *http.Request, _ []byte) error {
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+// oauth2Auth sends an access token of the client credentials grant.
+// The token is requested on first use and reused until it expires.
+type oauth2Auth struct {
+	source oauth2.TokenSource
+}
+
+func (a *oauth2Auth) authenticate(req *http.Request, _ []byte) error {
+	token, err := a.source.Token()
+	metrics.ObserveAPICall(constants.ProviderWebhook, constants.CallWebhookOAuth2Token, err)
+	if err != nil {
+		return fmt.Errorf("failed to get oauth2 token: %w", err)
+	}
+	token.SetAuthHeader(req)
+	return nil
+}
+
+// hmacAuth signs the timestamp, method, request URI and body of a request.
+type hmacAuth struct {
+	key             []byte
+	hash            func() hash.Hash
+	signatureHeader string
+	timestampHeader string
+	now             func() time.Time
+}
+
+func (a *hmacAuth) authenticate(req *http.Request, body []byte) error {
+	timestamp := strconv.FormatInt(a.now().Unix(), 10)
+	mac := hmac.New(a.hash, a.key)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n", timestamp, req.Method, req.URL.RequestURI())
+	mac.Write(body)
+	req.Header.Set(a.timestampHeader, timestamp)
+	req.Header.Set(a.signatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+func validateAuth(auth *esv1beta1.WebhookAuth) error {
+	if auth == nil {
+		return nil
+	}
+	methods := 0
+	var refs []esmeta.SecretKeySelector
+	if auth.OAuth2 != nil {
+		methods++
+		if _, err := url.ParseRequestURI(auth.OAuth2.TokenURL); err != nil {
+			return fmt.Errorf("invalid oauth2 token url: %w", err)
+		}
+		if auth.OAuth2.ClientID == "" {
+			return fmt.Errorf("missing oauth2 client id")
+		}
+		refs = append(refs, auth.OAuth2.ClientSecretRef)
+	}
+	if auth.HMAC != nil {
+		methods++
+		if _, err := hmacHash(auth.HMAC.Algorithm); err != nil {
+			return err
+		}
+		refs = append(refs, auth.HMAC.SecretRef)
+	}
+	if auth.JWT != nil {
+		methods++
+		refs = append(refs, auth.JWT.TokenSecretRef)
+	}
+	if methods != 1 {
+		return fmt.Errorf("exactly one auth method must be set")
+	}
+	for _, ref := range refs {
+		if ref.Name == "" || ref.Key == "" {
+			return fmt.Errorf("auth secret references must set name and key")
+		}
+	}
+	return nil
+}
+
+func (w *WebHook) getAuth(ctx context.Context, auth *esv1beta1.WebhookAuth) (authenticator, error) {
+	if auth == nil {
+		return nil, nil
+	}
+	if err := validateAuth(auth); err != nil {
+		return nil, err
+	}
+	switch {
+	case auth.OAuth2 != nil:
+		secret, err := w.getAuthSecret(ctx, auth.OAuth2.ClientSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		cfg := &clientcredentials.Config{
+			ClientID:     auth.OAuth2.ClientID,
+			ClientSecret: secret,
+			TokenURL:     auth.OAuth2.TokenURL,
+			Scopes:       auth.OAuth2.Scopes,
+		}
+		if auth.OAuth2.Audience != "" {
+			cfg.EndpointParams = url.Values{"audience": {auth.OAuth2.Audience}}
+		}
+		// the token request uses the timeout and CA bundle of the webhook
+		tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, w.http)
+		return &oauth2Auth{source: cfg.TokenSource(tokenCtx)}, nil
+	case auth.HMAC != nil:
+		key, err := w.getAuthSecret(ctx, auth.HMAC.SecretRef)
+		if err != nil {
+			return nil, err
+		}
+		h, _ := hmacHash(auth.HMAC.Algorithm)
+		a := &hmacAuth{
+			key:             []byte(key),
+			hash:            h,
+			signatureHeader: auth.HMAC.SignatureHeader,
+			timestampHeader: auth.HMAC.TimestampHeader,
+			now:             time.Now,
+		}
+		if a.signatureHeader == "" {
+			a.signatureHeader = defaultSignatureHeader
+		}
+		if a.timestampHeader == "" {
+			a.timestampHeader = defaultTimestampHeader
+		}
+		return a, nil
+	default:
+		token, err := w.getAuthSecret(ctx, auth.JWT.TokenSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		return &bearerAuth{token: token}, nil
+	}
+}
+
+func (w *WebHook) getAuthSecret(ctx context.Context, ref esmeta.SecretKeySelector) (string, error) {
+	if w.storeKind == esv1beta1.ClusterSecretStoreKind && ref.Namespace == nil {
+		return "", fmt.Errorf("no namespace on ClusterSecretStore webhook auth secret %s", ref.Name)
+	}
+	return resolvers.SecretKeyRef(ctx, w.kube, w.storeKind, w.namespace, &ref)
+}
+
+func hmacHash(algorithm esv1beta1.WebhookHMACAlgorithm) (func() hash.Hash, error) {
+	switch algorithm {
+	case "", esv1beta1.WebhookHMACSHA256:
+		return sha256.New, nil
+	case esv1beta1.WebhookHMACSHA512:
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unknown hmac algorithm: %s", algorithm)
+	}
+}
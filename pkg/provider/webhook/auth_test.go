@@ -0,0 +1,224 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func authStore(url string, auth *esv1beta1.WebhookAuth) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Webhook: &esv1beta1.WebhookProvider{
+					URL:    url + "/api/secrets/{{ .remoteRef.key }}",
+					Method: http.MethodPost,
+					Body:   `{"key":"{{ .remoteRef.key }}"}`,
+					Auth:   auth,
+				},
+			},
+		},
+	}
+}
+
+func authSecretRef(key string) esmeta.SecretKeySelector {
+	return esmeta.SecretKeySelector{Name: "webhook-auth", Key: key}
+}
+
+func getSecretWithAuth(t *testing.T, url string, auth *esv1beta1.WebhookAuth) ([]byte, error) {
+	t.Helper()
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook-auth", Namespace: "default"},
+		Data: map[string][]byte{
+			"client-secret": []byte("client-secret"),
+			"hmac":          []byte("hmac-key"),
+			"jwt":           []byte("eyJhbGciOiJub25lIn0.e30."),
+		},
+	}).Build()
+	c, err := (&Provider{}).NewClient(context.Background(), authStore(url, auth), kube, "default")
+	require.NoError(t, err)
+	return c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db"})
+}
+
+func TestOAuth2Auth(t *testing.T) {
+	tokenRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			tokenRequests++
+			require.NoError(t, req.ParseForm())
+			user, pass, _ := req.BasicAuth()
+			assert.Equal(t, "eso", user)
+			assert.Equal(t, "client-secret", pass)
+			assert.Equal(t, "client_credentials", req.Form.Get("grant_type"))
+			assert.Equal(t, "secrets:read", req.Form.Get("scope"))
+			assert.Equal(t, "credential-api", req.Form.Get("audience"))
+			rw.Header().Set("Content-Type", "application/json")
+			rw.Write([]byte(`{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer access-token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		rw.Write([]byte("secret-value"))
+	}))
+	defer ts.Close()
+
+	got, err := getSecretWithAuth(t, ts.URL, &esv1beta1.WebhookAuth{OAuth2: &esv1beta1.WebhookOAuth2{
+		TokenURL:        ts.URL + "/token",
+		ClientID:        "eso",
+		ClientSecretRef: authSecretRef("client-secret"),
+		Scopes:          []string{"secrets:read"},
+		Audience:        "credential-api",
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "secret-value", string(got))
+	assert.Equal(t, 1, tokenRequests)
+
+	_, err = getSecretWithAuth(t, ts.URL, &esv1beta1.WebhookAuth{OAuth2: &esv1beta1.WebhookOAuth2{
+		TokenURL:        ts.URL + "/missing",
+		ClientID:        "eso",
+		ClientSecretRef: authSecretRef("client-secret"),
+	}})
+	assert.ErrorContains(t, err, "failed to get oauth2 token")
+}
+
+func TestHMACAuth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		mac := hmac.New(sha256.New, []byte("hmac-key"))
+		mac.Write([]byte(req.Header.Get("X-Request-Time") + "\nPOST\n/api/secrets/db\n"))
+		mac.Write(body)
+		if req.Header.Get("X-Request-Time") == "" || req.Header.Get("X-Signature") != hex.EncodeToString(mac.Sum(nil)) {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+		rw.Write([]byte("secret-value"))
+	}))
+	defer ts.Close()
+
+	got, err := getSecretWithAuth(t, ts.URL, &esv1beta1.WebhookAuth{HMAC: &esv1beta1.WebhookHMAC{
+		SecretRef:       authSecretRef("hmac"),
+		TimestampHeader: "X-Request-Time",
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "secret-value", string(got))
+
+	_, err = getSecretWithAuth(t, ts.URL, &esv1beta1.WebhookAuth{HMAC: &esv1beta1.WebhookHMAC{
+		SecretRef:       authSecretRef("hmac"),
+		Algorithm:       esv1beta1.WebhookHMACSHA512,
+		TimestampHeader: "X-Request-Time",
+	}})
+	assert.ErrorContains(t, err, "endpoint gave error 403")
+}
+
+func TestJWTAuth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer eyJhbGciOiJub25lIn0.e30." {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		rw.Write([]byte("secret-value"))
+	}))
+	defer ts.Close()
+
+	got, err := getSecretWithAuth(t, ts.URL, &esv1beta1.WebhookAuth{JWT: &esv1beta1.WebhookJWT{TokenSecretRef: authSecretRef("jwt")}})
+	require.NoError(t, err)
+	assert.Equal(t, "secret-value", string(got))
+
+	_, err = getSecretWithAuth(t, ts.URL, &esv1beta1.WebhookAuth{JWT: &esv1beta1.WebhookJWT{TokenSecretRef: authSecretRef("missing")}})
+	assert.Error(t, err)
+}
+
+func TestValidateStoreAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     *esv1beta1.WebhookAuth
+		contains string
+	}{
+		{
+			name: "no auth",
+		},
+		{
+			name:     "no method",
+			auth:     &esv1beta1.WebhookAuth{},
+			contains: "exactly one auth method must be set",
+		},
+		{
+			name: "multiple methods",
+			auth: &esv1beta1.WebhookAuth{
+				JWT:  &esv1beta1.WebhookJWT{TokenSecretRef: authSecretRef("jwt")},
+				HMAC: &esv1beta1.WebhookHMAC{SecretRef: authSecretRef("hmac")},
+			},
+			contains: "exactly one auth method must be set",
+		},
+		{
+			name:     "invalid token url",
+			auth:     &esv1beta1.WebhookAuth{OAuth2: &esv1beta1.WebhookOAuth2{ClientID: "eso", ClientSecretRef: authSecretRef("client-secret")}},
+			contains: "invalid oauth2 token url",
+		},
+		{
+			name:     "missing client id",
+			auth:     &esv1beta1.WebhookAuth{OAuth2: &esv1beta1.WebhookOAuth2{TokenURL: "https://auth.example.com/token", ClientSecretRef: authSecretRef("client-secret")}},
+			contains: "missing oauth2 client id",
+		},
+		{
+			name:     "unknown hmac algorithm",
+			auth:     &esv1beta1.WebhookAuth{HMAC: &esv1beta1.WebhookHMAC{SecretRef: authSecretRef("hmac"), Algorithm: "MD5"}},
+			contains: "unknown hmac algorithm",
+		},
+		{
+			name:     "missing secret key",
+			auth:     &esv1beta1.WebhookAuth{JWT: &esv1beta1.WebhookJWT{TokenSecretRef: authSecretRef("")}},
+			contains: "auth secret references must set name and key",
+		},
+		{
+			name: "valid",
+			auth: &esv1beta1.WebhookAuth{OAuth2: &esv1beta1.WebhookOAuth2{
+				TokenURL:        "https://auth.example.com/token",
+				ClientID:        "eso",
+				ClientSecretRef: authSecretRef("client-secret"),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (&Provider{}).ValidateStore(authStore("https://example.com", tt.auth))
+			if tt.contains != "" {
+				assert.ErrorContains(t, err, tt.contains)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
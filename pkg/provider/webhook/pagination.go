@@ -0,0 +1,159 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PaesslerAG/jsonpath"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const defaultMaxPages = 10
+
+// fetchPage requests a single page and returns its body and headers.
+type fetchPage func(pageURL string) ([]byte, http.Header, error)
+
+// paginate requests the pages starting at firstURL and merges their items,
+// arrays are concatenated and objects merged.
+func paginate(pagination *esv1beta1.WebhookPagination, firstURL string, fetch fetchPage) ([]byte, error) {
+	maxPages := pagination.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+	first, err := url.Parse(firstURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+	var merged any
+	pageURL := first
+	for page := 1; ; page++ {
+		body, header, err := fetch(pageURL.String())
+		if err != nil {
+			return nil, err
+		}
+		var jsondata any
+		if err := json.Unmarshal(body, &jsondata); err != nil {
+			return nil, fmt.Errorf("failed to parse page %d json: %w", page, err)
+		}
+		items := jsondata
+		if pagination.ItemsJSONPath != "" {
+			items, err = jsonpath.Get(pagination.ItemsJSONPath, jsondata)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get items of page %d: %w", page, err)
+			}
+		}
+		merged, err = mergeItems(merged, items)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge page %d: %w", page, err)
+		}
+
+		next := nextPageURL(pagination, jsondata, header)
+		if next == "" {
+			return json.Marshal(merged)
+		}
+		if page == maxPages {
+			return nil, fmt.Errorf("response has more than %d pages", maxPages)
+		}
+		nextURL, err := pageURL.Parse(next)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse next page url: %w", err)
+		}
+		// credentials of the webhook must not be sent to other servers
+		if nextURL.Scheme != first.Scheme || nextURL.Host != first.Host {
+			return nil, fmt.Errorf("next page url %s is not on %s://%s", nextURL.Redacted(), first.Scheme, first.Host)
+		}
+		pageURL = nextURL
+	}
+}
+
+func mergeItems(merged, items any) (any, error) {
+	switch val := items.(type) {
+	case []any:
+		if merged == nil {
+			return val, nil
+		}
+		list, ok := merged.([]any)
+		if !ok {
+			return nil, fmt.Errorf("items are an array, previous items are %T", merged)
+		}
+		return append(list, val...), nil
+	case map[string]any:
+		if merged == nil {
+			return val, nil
+		}
+		obj, ok := merged.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("items are an object, previous items are %T", merged)
+		}
+		for k, v := range val {
+			obj[k] = v
+		}
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("items must be an array or object, got %T", items)
+	}
+}
+
+// nextPageURL returns the URL of the next page, or an empty string on the
+// last page.
+func nextPageURL(pagination *esv1beta1.WebhookPagination, jsondata any, header http.Header) string {
+	if pagination.NextJSONPath == "" {
+		return linkNext(header)
+	}
+	// the last page usually omits the next link or sets it to null
+	next, err := jsonpath.Get(pagination.NextJSONPath, jsondata)
+	if err != nil {
+		return ""
+	}
+	if list, ok := next.([]any); ok && len(list) > 0 {
+		next = list[0]
+	}
+	nextStr, _ := next.(string)
+	return nextStr
+}
+
+// linkNext returns the target of the rel="next" link of a RFC 8288 Link header.
+func linkNext(header http.Header) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			target = strings.TrimSpace(target)
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(val, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
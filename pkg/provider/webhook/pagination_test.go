@@ -0,0 +1,135 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+var pages = map[string]string{
+	"/api/secrets":        `{"data":[{"name":"db","value":"db-password"}],"links":{"next":"/api/secrets?page=2"}}`,
+	"/api/secrets?page=2": `{"data":[{"name":"api","value":"api-token"}],"links":{"next":null}}`,
+	"/api/config":         `{"db":"db-password"}`,
+	"/api/config?page=2":  `{"api":"api-token"}`,
+	"/api/loop":           `{"data":[],"links":{"next":"/api/loop"}}`,
+	"/api/foreign":        `{"data":[],"links":{"next":"https://attacker.example.com/api/secrets"}}`,
+}
+
+func paginationServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/config" && req.URL.RawQuery == "" {
+			rw.Header().Add("Link", `</api/config?page=1>; rel="prev", </api/config?page=2>; rel="next"`)
+		}
+		page, ok := pages[req.URL.RequestURI()]
+		if !ok {
+			t.Errorf("unexpected page %s", req.URL.RequestURI())
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rw.Write([]byte(page))
+	}))
+}
+
+func paginationClient(t *testing.T, url string, pagination *esv1beta1.WebhookPagination, jsonPath string) esv1beta1.SecretsClient {
+	t.Helper()
+	store := &esv1beta1.SecretStore{
+		TypeMeta: metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Webhook: &esv1beta1.WebhookProvider{
+					URL:        url,
+					Pagination: pagination,
+					Result:     esv1beta1.WebhookResult{JSONPath: jsonPath},
+				},
+			},
+		},
+	}
+	c, err := (&Provider{}).NewClient(context.Background(), store, nil, "default")
+	require.NoError(t, err)
+	return c
+}
+
+func TestPaginationJSONPath(t *testing.T) {
+	ts := paginationServer(t)
+	defer ts.Close()
+	pagination := &esv1beta1.WebhookPagination{ItemsJSONPath: "$.data", NextJSONPath: "$.links.next"}
+
+	c := paginationClient(t, ts.URL+"/api/secrets", pagination, `$[?(@.name=="{{ .remoteRef.key }}")].value`)
+	got, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "api"})
+	require.NoError(t, err)
+	assert.Equal(t, "api-token", string(got))
+
+	c = paginationClient(t, ts.URL+"/api/loop", pagination, "")
+	_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "api"})
+	assert.ErrorContains(t, err, fmt.Sprintf("response has more than %d pages", defaultMaxPages))
+
+	c = paginationClient(t, ts.URL+"/api/foreign", pagination, "")
+	_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "api"})
+	assert.ErrorContains(t, err, "next page url https://attacker.example.com/api/secrets is not on")
+}
+
+func TestPaginationLinkHeader(t *testing.T) {
+	ts := paginationServer(t)
+	defer ts.Close()
+
+	c := paginationClient(t, ts.URL+"/api/config", &esv1beta1.WebhookPagination{}, "")
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "config"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"db": []byte("db-password"), "api": []byte("api-token")}, got)
+
+	c = paginationClient(t, ts.URL+"/api/config", &esv1beta1.WebhookPagination{MaxPages: 1}, "")
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "config"})
+	assert.ErrorContains(t, err, "response has more than 1 pages")
+}
+
+func TestMergeItems(t *testing.T) {
+	merged, err := mergeItems(nil, []any{"a"})
+	require.NoError(t, err)
+	merged, err = mergeItems(merged, []any{"b"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a", "b"}, merged)
+
+	_, err = mergeItems(merged, map[string]any{"c": "d"})
+	assert.ErrorContains(t, err, "items are an object")
+	_, err = mergeItems(nil, "a")
+	assert.ErrorContains(t, err, "items must be an array or object")
+}
+
+func TestLinkNext(t *testing.T) {
+	tests := map[string]string{
+		``:                                  "",
+		`<https://example.com/2>; rel=next`: "https://example.com/2",
+		`<https://example.com/1>; rel="prev", <https://example.com/3>; rel="last next"`: "https://example.com/3",
+		`<https://example.com/1>; rel="prev"`:                                           "",
+		`https://example.com/2; rel="next"`:                                             "",
+	}
+	for link, want := range tests {
+		header := http.Header{}
+		if link != "" {
+			header.Set("Link", link)
+		}
+		assert.Equal(t, want, linkNext(header), link)
+	}
+}
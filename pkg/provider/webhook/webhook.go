@@ -39,6 +39,7 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/metrics"
 	"github.com/external-secrets/external-secrets/pkg/template/v2"
 	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/certpin"
 	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
 
@@ -89,8 +90,15 @@ func (p *Provider) NewClient(_ context.Context, store esv1beta1.GenericStore, ku
 	return whClient, nil
 }
 
-func (p *Provider) ValidateStore(_ esv1beta1.GenericStore) (admission.Warnings, error) {
-	return nil, nil
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if err := certpin.Validate(provider.CertificatePins); err != nil {
+		return nil, err
+	}
+	return nil, validateAuth(provider.Auth)
 }
 
 func getProvider(store esv1beta1.GenericStore) (*esv1beta1.WebhookProvider, error) {
@@ -211,17 +219,43 @@ func (w *WebHook) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecret
 	if err != nil {
 		return nil, err
 	}
+	return parseSecretMap(result, provider.Result.JSONPath)
+}
+
+// GetGeneratorData calls the webhook on behalf of a generator and returns
+// the key-value pairs of the JSON response.
+// Secrets referenced by the provider are read from the given namespace.
+func GetGeneratorData(ctx context.Context, kube client.Client, provider *esv1beta1.WebhookProvider, namespace string) (map[string][]byte, error) {
+	w := &WebHook{
+		kube:      kube,
+		namespace: namespace,
+		storeKind: esv1beta1.SecretStoreKind,
+		url:       provider.URL,
+	}
+	var err error
+	w.http, err = w.getHTTPClient(provider)
+	if err != nil {
+		return nil, err
+	}
+	result, err := w.getWebhookData(ctx, provider, esv1beta1.ExternalSecretDataRemoteRef{})
+	if err != nil {
+		return nil, err
+	}
+	return parseSecretMap(result, provider.Result.JSONPath)
+}
 
+func parseSecretMap(result []byte, jsonPath string) (map[string][]byte, error) {
 	// We always want json here, so just parse it out
 	jsondata := interface{}(nil)
 	if err := json.Unmarshal(result, &jsondata); err != nil {
 		return nil, fmt.Errorf("failed to parse response json: %w", err)
 	}
 	// Get subdata via jsonpath, if given
-	if provider.Result.JSONPath != "" {
-		jsondata, err = jsonpath.Get(provider.Result.JSONPath, jsondata)
+	if jsonPath != "" {
+		var err error
+		jsondata, err = jsonpath.Get(jsonPath, jsondata)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get response path %s: %w", provider.Result.JSONPath, err)
+			return nil, fmt.Errorf("failed to get response path %s: %w", jsonPath, err)
 		}
 	}
 	// If the value is a string, try to parse it as json
@@ -295,31 +329,54 @@ func (w *WebHook) getWebhookData(ctx context.Context, provider *esv1beta1.Webhoo
 		return nil, fmt.Errorf("failed to parse body: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, &body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	headers := make(http.Header)
 	for hKey, hValueTpl := range provider.Headers {
 		hValue, err := executeTemplateString(hValueTpl, data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse header %s: %w", hKey, err)
 		}
-		req.Header.Add(hKey, hValue)
+		headers.Add(hKey, hValue)
+	}
+	auth, err := w.getAuth(ctx, provider.Auth)
+	if err != nil {
+		return nil, err
+	}
+	fetch := func(pageURL string) ([]byte, http.Header, error) {
+		return w.doRequest(ctx, method, pageURL, body.Bytes(), headers, auth)
+	}
+	if provider.Pagination != nil {
+		return paginate(provider.Pagination, url, fetch)
+	}
+	result, _, err := fetch(url)
+	return result, err
+}
+
+func (w *WebHook) doRequest(ctx context.Context, method, url string, body []byte, headers http.Header, auth authenticator) ([]byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header = headers.Clone()
+	if auth != nil {
+		if err := auth.authenticate(req, body); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	resp, err := w.http.Do(req)
 	metrics.ObserveAPICall(constants.ProviderWebhook, constants.CallWebhookHTTPReq, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call endpoint: %w", err)
+		return nil, nil, fmt.Errorf("failed to call endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode == 404 {
-		return nil, esv1beta1.NoSecretError{}
+		return nil, nil, esv1beta1.NoSecretError{}
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("endpoint gave error %s", resp.Status)
+		return nil, nil, fmt.Errorf("endpoint gave error %s", resp.Status)
 	}
-	return io.ReadAll(resp.Body)
+	result, err := io.ReadAll(resp.Body)
+	return result, resp.Header, err
 }
 
 func (w *WebHook) getHTTPClient(provider *esv1beta1.WebhookProvider) (*http.Client, error) {
@@ -327,18 +384,20 @@ func (w *WebHook) getHTTPClient(provider *esv1beta1.WebhookProvider) (*http.Clie
 	if provider.Timeout != nil {
 		client.Timeout = provider.Timeout.Duration
 	}
-	if len(provider.CABundle) == 0 && provider.CAProvider == nil {
+	if len(provider.CABundle) == 0 && provider.CAProvider == nil && len(provider.CertificatePins) == 0 {
 		// No need to process ca stuff if it is not there
 		return client, nil
 	}
-	caCertPool, err := w.getCACertPool(provider)
-	if err != nil {
-		return nil, err
-	}
-
 	tlsConf := &tls.Config{
-		RootCAs:    caCertPool,
-		MinVersion: tls.VersionTLS12,
+		MinVersion:       tls.VersionTLS12,
+		VerifyConnection: certpin.VerifyConnection(provider.CertificatePins),
+	}
+	if len(provider.CABundle) > 0 || provider.CAProvider != nil {
+		caCertPool, err := w.getCACertPool(provider)
+		if err != nil {
+			return nil, err
+		}
+		tlsConf.RootCAs = caCertPool
 	}
 	client.Transport = &http.Transport{TLSClientConfig: tlsConf}
 	return client, nil
@@ -14,6 +14,8 @@ limitations under the License.
 package template
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
@@ -35,3 +37,39 @@ func EngineForVersion(version esapi.TemplateEngineVersion) (ExecFunc, error) {
 	// we must return v1 as default
 	return v1.Execute, nil
 }
+
+// Keys of the metadata exposed to v2 templates through the `metadata` function.
+const (
+	MetadataStoreName  = "storeName"
+	MetadataStoreKind  = "storeKind"
+	MetadataProvider   = "provider"
+	MetadataRemoteKeys = "remoteKeys"
+	MetadataFetchedAt  = "fetchedAt"
+)
+
+// EngineForTemplate returns the ExecFunc for the engine version
+// of the given template, configured with the template's engine options.
+// metadata is exposed to v2 templates and ignored by v1.
+func EngineForTemplate(tpl *esapi.ExternalSecretTemplate, metadata map[string]string) (ExecFunc, error) {
+	if tpl.EngineVersion == esapi.TemplateEngineV2 {
+		return v2.Options{
+			MissingKey: missingKeyOption(tpl.MissingKeyPolicy),
+			Metadata:   metadata,
+		}.Execute, nil
+	}
+	if missingKeyOption(tpl.MissingKeyPolicy) != "" {
+		return nil, fmt.Errorf("missingKeyPolicy %s requires engineVersion %s", tpl.MissingKeyPolicy, esapi.TemplateEngineV2)
+	}
+	return EngineForVersion(tpl.EngineVersion)
+}
+
+func missingKeyOption(policy esapi.TemplateMissingKeyPolicy) string {
+	switch policy {
+	case esapi.MissingKeyPolicyZero:
+		return "zero"
+	case esapi.MissingKeyPolicyError:
+		return "error"
+	default:
+		return ""
+	}
+}
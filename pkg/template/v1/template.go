@@ -70,19 +70,32 @@ const (
 	errDecodeBase64         = "unable to decode base64: %s"
 	errUnmarshalJSON        = "unable to unmarshal json: %s"
 	errMarshalJSON          = "unable to marshal json: %s"
+	errUnsupportedScope     = "template scope %s is not supported by engine v1, use engineVersion v2"
 )
 
 // Execute renders the secret data as template. If an error occurs processing is stopped immediately.
-func Execute(tpl, data map[string][]byte, _ esapi.TemplateScope, _ esapi.TemplateTarget, secret *corev1.Secret) error {
+// The v1 engine only supports the Values scope. The target is honored so that
+// templated labels and annotations do not end up in the Secret data.
+func Execute(tpl, data map[string][]byte, scope esapi.TemplateScope, target esapi.TemplateTarget, secret *corev1.Secret) error {
 	if tpl == nil {
 		return nil
 	}
+	if scope == esapi.TemplateScopeKeysAndValues {
+		return fmt.Errorf(errUnsupportedScope, scope)
+	}
 	for k, v := range tpl {
 		val, err := execute(k, string(v), data)
 		if err != nil {
 			return fmt.Errorf(errExecute, k, err)
 		}
-		secret.Data[k] = val
+		switch target {
+		case esapi.TemplateTargetAnnotations:
+			secret.Annotations[k] = string(val)
+		case esapi.TemplateTargetLabels:
+			secret.Labels[k] = string(val)
+		default:
+			secret.Data[k] = val
+		}
 	}
 	return nil
 }
@@ -21,6 +21,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 )
 
 const (
@@ -371,3 +373,21 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func TestExecuteTarget(t *testing.T) {
+	sec := &corev1.Secret{
+		Data:       make(map[string][]byte),
+		ObjectMeta: v1.ObjectMeta{Labels: make(map[string]string), Annotations: make(map[string]string)},
+	}
+	tpl := map[string][]byte{"foo": []byte(`{{ .secret | toString | upper }}`)}
+	data := map[string][]byte{"secret": []byte("bar")}
+
+	assert.NoError(t, Execute(tpl, data, esapi.TemplateScopeValues, esapi.TemplateTargetLabels, sec))
+	assert.NoError(t, Execute(tpl, data, esapi.TemplateScopeValues, esapi.TemplateTargetAnnotations, sec))
+	assert.Equal(t, map[string]string{"foo": "BAR"}, sec.Labels)
+	assert.Equal(t, map[string]string{"foo": "BAR"}, sec.Annotations)
+	assert.Empty(t, sec.Data)
+
+	err := Execute(tpl, data, esapi.TemplateScopeKeysAndValues, esapi.TemplateTargetData, sec)
+	assert.ErrorContains(t, err, "not supported by engine v1")
+}
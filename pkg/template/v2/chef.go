@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	errChefJSON         = "unable to decode chef json: %s"
+	errChefItemNotFound = "databag item %q not found"
+	errChefNotAnObject  = "databag item %q is not a json object"
+	errChefDatabagType  = "unsupported databag type %T"
+)
+
+// fromChefJSON decodes a JSON document and every string inside of it that
+// contains a JSON object or array. Chef databag items fetched through the
+// provider are frequently encoded more than once, e.g. a databag rendered as
+// `{"item":"{\"id\":\"item\"}"}`.
+func fromChefJSON(str string) (any, error) {
+	var v any
+	if err := json.Unmarshal([]byte(str), &v); err != nil {
+		return nil, fmt.Errorf(errChefJSON, err)
+	}
+	return decodeNestedJSON(v), nil
+}
+
+// databagItem returns the decoded databag item with the given name.
+// The databag is either the template data itself, e.g. `databagItem "item01" .`
+// or a JSON document mapping item names to items.
+func databagItem(name string, databag any) (map[string]any, error) {
+	var item any
+	switch d := databag.(type) {
+	case map[string]string:
+		raw, ok := d[name]
+		if !ok {
+			return nil, fmt.Errorf(errChefItemNotFound, name)
+		}
+		v, err := fromChefJSON(raw)
+		if err != nil {
+			return nil, err
+		}
+		item = v
+	case string:
+		v, err := fromChefJSON(d)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf(errChefNotAnObject, name)
+		}
+		i, ok := m[name]
+		if !ok {
+			return nil, fmt.Errorf(errChefItemNotFound, name)
+		}
+		item = i
+	default:
+		return nil, fmt.Errorf(errChefDatabagType, databag)
+	}
+	m, ok := item.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf(errChefNotAnObject, name)
+	}
+	return m, nil
+}
+
+func decodeNestedJSON(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			t[k] = decodeNestedJSON(val)
+		}
+		return t
+	case []any:
+		for i, val := range t {
+			t[i] = decodeNestedJSON(val)
+		}
+		return t
+	case string:
+		s := strings.TrimSpace(t)
+		if !strings.HasPrefix(s, "{") && !strings.HasPrefix(s, "[") {
+			return t
+		}
+		var nested any
+		if err := json.Unmarshal([]byte(s), &nested); err != nil {
+			return t
+		}
+		return decodeNestedJSON(nested)
+	default:
+		return v
+	}
+}
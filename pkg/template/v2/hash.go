@@ -0,0 +1,53 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec // md5sum is offered for checksums, not for security purposes
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+)
+
+// md5sum returns the hex encoded md5 digest of the input.
+// sprig only ships sha1sum, sha256sum and adler32sum.
+func md5sum(input string) string {
+	sum := md5.Sum([]byte(input)) //nolint:gosec
+	return hex.EncodeToString(sum[:])
+}
+
+// sha512sum returns the hex encoded sha512 digest of the input.
+func sha512sum(input string) string {
+	sum := sha512.Sum512([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSha256 returns the hex encoded HMAC-SHA256 of input keyed with key.
+func hmacSha256(key, input string) string {
+	return hmacSum(sha256.New, key, input)
+}
+
+// hmacSha512 returns the hex encoded HMAC-SHA512 of input keyed with key.
+func hmacSha512(key, input string) string {
+	return hmacSum(sha512.New, key, input)
+}
+
+func hmacSum(h func() hash.Hash, key, input string) string {
+	mac := hmac.New(h, []byte(key))
+	mac.Write([]byte(input))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,98 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+)
+
+const (
+	jksKeyAlias = "key"
+	jksCertType = "X509"
+)
+
+// pemToJks takes a PEM encoded certificate chain and private key and
+// returns a base64 encoded Java KeyStore. The keystore and the key entry
+// are both protected with pass, which must not be empty.
+func pemToJks(cert, key, pass string) (string, error) {
+	if pass == "" {
+		return "", errors.New(errJKSNoPassword)
+	}
+	certs, err := parseCertificates(cert)
+	if err != nil {
+		return "", err
+	}
+	parsedKey, err := parsePEMPrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(parsedKey)
+	if err != nil {
+		return "", fmt.Errorf(errEncodeJKS, err)
+	}
+	chain := make([]keystore.Certificate, 0, len(certs))
+	for _, c := range certs {
+		chain = append(chain, keystore.Certificate{Type: jksCertType, Content: c.Raw})
+	}
+	ks := keystore.New()
+	err = ks.SetPrivateKeyEntry(jksKeyAlias, keystore.PrivateKeyEntry{
+		CreationTime:     time.Now(),
+		PrivateKey:       pkcs8,
+		CertificateChain: chain,
+	}, []byte(pass))
+	if err != nil {
+		return "", fmt.Errorf(errEncodeJKS, err)
+	}
+	return storeJks(ks, pass)
+}
+
+// pemToJksTruststore takes one or more PEM encoded certificates and returns
+// a base64 encoded Java KeyStore holding them as trusted certificate entries.
+func pemToJksTruststore(cert, pass string) (string, error) {
+	if pass == "" {
+		return "", errors.New(errJKSNoPassword)
+	}
+	certs, err := parseCertificates(cert)
+	if err != nil {
+		return "", err
+	}
+	ks := keystore.New()
+	for i, c := range certs {
+		err = ks.SetTrustedCertificateEntry(strconv.Itoa(i), keystore.TrustedCertificateEntry{
+			CreationTime: time.Now(),
+			Certificate:  keystore.Certificate{Type: jksCertType, Content: c.Raw},
+		})
+		if err != nil {
+			return "", fmt.Errorf(errEncodeJKS, err)
+		}
+	}
+	return storeJks(ks, pass)
+}
+
+func storeJks(ks keystore.KeyStore, pass string) (string, error) {
+	var buf bytes.Buffer
+	if err := ks.Store(&buf, []byte(pass)); err != nil {
+		return "", fmt.Errorf(errEncodeJKS, err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -15,7 +15,12 @@ limitations under the License.
 package template
 
 import (
+	"crypto"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 
 	"github.com/lestrrat-go/jwx/v2/jwk"
 )
@@ -54,3 +59,108 @@ func jwkPrivateKeyPem(jwkjson string) (string, error) {
 	}
 	return pemEncode(string(mpk), "PRIVATE KEY")
 }
+
+// jwkToPem takes a json-serialized JWK and returns a PEM block. Private keys
+// are encoded as PKCS#8 `PRIVATE KEY`, public keys as PKIX `PUBLIC KEY`.
+func jwkToPem(jwkjson string) (string, error) {
+	k, err := jwk.ParseKey([]byte(jwkjson))
+	if err != nil {
+		return "", err
+	}
+	switch k.(type) {
+	case jwk.RSAPrivateKey, jwk.ECDSAPrivateKey, jwk.OKPPrivateKey:
+		return jwkPrivateKeyPem(jwkjson)
+	case jwk.SymmetricKey:
+		return "", errors.New(errJWKSymmetric)
+	}
+	return jwkPublicKeyPem(jwkjson)
+}
+
+// pemToJwk takes the first PEM block of the input and returns it as
+// json-serialized JWK. Supported blocks are certificates, public keys and
+// PKCS#1, PKCS#8 or EC private keys. The key id (kid) is set to the
+// RFC 7638 thumbprint of the key.
+func pemToJwk(input string) (string, error) {
+	keys, err := pemToJwkKeys(input)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(keys[0])
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// pemToJwks takes one or more PEM blocks and returns a json-serialized JWK set
+// containing only the public keys, ready to be served to OIDC relying parties.
+func pemToJwks(input string) (string, error) {
+	keys, err := pemToJwkKeys(input)
+	if err != nil {
+		return "", err
+	}
+	set := jwk.NewSet()
+	for _, k := range keys {
+		pub, err := jwk.PublicKeyOf(k)
+		if err != nil {
+			return "", err
+		}
+		if err := set.AddKey(pub); err != nil {
+			return "", err
+		}
+	}
+	out, err := json.Marshal(set)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func pemToJwkKeys(input string) ([]jwk.Key, error) {
+	var keys []jwk.Key
+	rest := []byte(input)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		raw, err := pemBlockToRawKey(block)
+		if err != nil {
+			return nil, err
+		}
+		k, err := jwk.FromRaw(raw)
+		if err != nil {
+			return nil, err
+		}
+		tp, err := k.Thumbprint(crypto.SHA256)
+		if err != nil {
+			return nil, err
+		}
+		if err := k.Set(jwk.KeyIDKey, base64.RawURLEncoding.EncodeToString(tp)); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New(errNoPEMKey)
+	}
+	return keys, nil
+}
+
+func pemBlockToRawKey(block *pem.Block) (interface{}, error) {
+	switch block.Type {
+	case pemTypeCertificate:
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return parsePrivateKey(block.Bytes)
+	}
+}
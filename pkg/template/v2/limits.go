@@ -0,0 +1,113 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	tpl "text/template"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/external-secrets/external-secrets/pkg/feature"
+)
+
+const (
+	// defaultMaxSize matches the maximum size of a Kubernetes Secret.
+	defaultMaxSize = 1 << 20
+	defaultTimeout = 10 * time.Second
+
+	errTimeout = "template did not finish within %s"
+)
+
+var (
+	maxSize = defaultMaxSize
+	timeout = defaultTimeout
+
+	errOutputTooLarge = errors.New("rendered template exceeds the maximum size")
+)
+
+func init() {
+	fs := pflag.NewFlagSet("template", pflag.ExitOnError)
+	fs.DurationVar(&timeout, "template-timeout", defaultTimeout, "Maximum duration a single template may take to render. Zero disables the limit.")
+	fs.IntVar(&maxSize, "template-max-size", defaultMaxSize, "Maximum size in bytes of a single rendered template. Zero disables the limit.")
+	feature.Register(feature.Feature{
+		Flags: fs,
+	})
+}
+
+// limitWriter fails writes once more than max bytes have been written.
+type limitWriter struct {
+	w   io.Writer
+	n   int
+	max int
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if l.max > 0 && l.n+len(p) > l.max {
+		return 0, errOutputTooLarge
+	}
+	l.n += len(p)
+	return l.w.Write(p)
+}
+
+// render executes t and bounds its output size and wall-clock time.
+// text/template can not be interrupted, a template that runs into the timeout
+// keeps running in the background until it finishes or hits the size limit,
+// but the reconcile worker is released.
+func (o Options) render(t *tpl.Template, data any) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	w := &limitWriter{w: buf, max: o.maxSize()}
+	d := o.timeout()
+	if d <= 0 {
+		if err := t.Execute(w, data); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- t.Execute(w, data)
+	}()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case <-timer.C:
+		return nil, fmt.Errorf(errTimeout, d)
+	}
+}
+
+func (o Options) maxSize() int {
+	if o.MaxSize != 0 {
+		return o.MaxSize
+	}
+	return maxSize
+}
+
+func (o Options) timeout() time.Duration {
+	if o.Timeout != 0 {
+		return o.Timeout
+	}
+	return timeout
+}
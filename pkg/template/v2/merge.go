@@ -0,0 +1,115 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	errMergeJSON        = "unable to decode merge argument %d: %s"
+	errMergeNotAnObject = "merge argument %d is not a json object"
+	errMergeType        = "unsupported type %T of merge argument %d"
+)
+
+// mergeDeep merges maps recursively into a new map, the first map that
+// contains a key takes precedence, e.g. `mergeDeep $override $base`.
+// Unlike sprig's merge none of the arguments is modified.
+func mergeDeep(maps ...any) (map[string]any, error) {
+	args, err := mergeArguments(maps)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]any)
+	for i := len(args) - 1; i >= 0; i-- {
+		mergeInto(out, args[i])
+	}
+	return out, nil
+}
+
+// mergeOverwriteDeep merges maps recursively into a new map, the last map
+// that contains a key takes precedence, e.g. `mergeOverwriteDeep $base $override`.
+// Nested maps are merged, all other values including lists are replaced.
+// Arguments are maps or strings that contain a JSON object, so the values
+// of dataFrom entries can be merged without calling fromJson first.
+func mergeOverwriteDeep(maps ...any) (map[string]any, error) {
+	args, err := mergeArguments(maps)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]any)
+	for _, m := range args {
+		mergeInto(out, m)
+	}
+	return out, nil
+}
+
+func mergeArguments(maps []any) ([]map[string]any, error) {
+	args := make([]map[string]any, 0, len(maps))
+	for i, m := range maps {
+		arg, err := mergeArgument(i, m)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+func mergeArgument(i int, v any) (map[string]any, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return t, nil
+	case map[string]string:
+		m := make(map[string]any, len(t))
+		for k, val := range t {
+			m[k] = val
+		}
+		return m, nil
+	case string:
+		var decoded any
+		if err := json.Unmarshal([]byte(t), &decoded); err != nil {
+			return nil, fmt.Errorf(errMergeJSON, i, err)
+		}
+		m, ok := decoded.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf(errMergeNotAnObject, i)
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf(errMergeType, v, i)
+	}
+}
+
+// mergeInto copies src into dst, merging nested maps. Nested maps of src
+// are copied, so later merges do not modify the arguments.
+func mergeInto(dst, src map[string]any) {
+	for k, v := range src {
+		srcMap, ok := v.(map[string]any)
+		if !ok {
+			dst[k] = v
+			continue
+		}
+		dstMap, ok := dst[k].(map[string]any)
+		if !ok {
+			dstMap = make(map[string]any, len(srcMap))
+			dst[k] = dstMap
+		}
+		mergeInto(dstMap, srcMap)
+	}
+}
@@ -17,10 +17,12 @@ package template
 import (
 	"bytes"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
+	"errors"
 	"fmt"
 
-	"golang.org/x/crypto/pkcs12"
+	"software.sslmate.com/src/go-pkcs12"
 )
 
 func pkcs12keyPass(pass, input string) (string, error) {
@@ -108,3 +110,79 @@ func pkcs12certPass(pass, input string) (string, error) {
 func pkcs12cert(input string) (string, error) {
 	return pkcs12certPass("", input)
 }
+
+// pemToPkcs12 takes a PEM encoded certificate and private key
+// and returns a base64 encoded PKCS#12 archive without password.
+// Only the first certificate of the input is used.
+func pemToPkcs12(cert, key string) (string, error) {
+	return pemToPkcs12Pass(cert, key, "")
+}
+
+// pemToPkcs12Pass works like pemToPkcs12 but protects the archive with the given password.
+func pemToPkcs12Pass(cert, key, pass string) (string, error) {
+	certs, err := parseCertificates(cert)
+	if err != nil {
+		return "", err
+	}
+	return certsToPkcs12(certs[0], key, nil, pass)
+}
+
+// fullPemToPkcs12 takes a PEM encoded certificate chain and private key
+// and returns a base64 encoded PKCS#12 archive without password.
+// The first certificate is used as leaf, all following certificates are stored as CA certificates.
+func fullPemToPkcs12(cert, key string) (string, error) {
+	return fullPemToPkcs12Pass(cert, key, "")
+}
+
+// fullPemToPkcs12Pass works like fullPemToPkcs12 but protects the archive with the given password.
+func fullPemToPkcs12Pass(cert, key, pass string) (string, error) {
+	certs, err := parseCertificates(cert)
+	if err != nil {
+		return "", err
+	}
+	return certsToPkcs12(certs[0], key, certs[1:], pass)
+}
+
+func certsToPkcs12(cert *x509.Certificate, key string, caCerts []*x509.Certificate, pass string) (string, error) {
+	parsedKey, err := parsePEMPrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	pfx, err := pkcs12.Modern.Encode(parsedKey, cert, caCerts, pass)
+	if err != nil {
+		return "", fmt.Errorf(errEncodePKCS12, err)
+	}
+	return base64.StdEncoding.EncodeToString(pfx), nil
+}
+
+func parseCertificates(input string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(input)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != pemTypeCertificate {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New(errNoCertificate)
+	}
+	return certs, nil
+}
+
+func parsePEMPrivateKey(input string) (interface{}, error) {
+	block, _ := pem.Decode([]byte(input))
+	if block == nil {
+		return nil, errors.New(errNoPrivateKey)
+	}
+	return parsePrivateKey(block.Bytes)
+}
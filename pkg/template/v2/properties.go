@@ -0,0 +1,171 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// toProperties takes a map and renders it as a Java properties file.
+// Nested maps are flattened using dots (`db.user=foo`), lists are joined with
+// a comma. Keys are sorted so the output is stable across reconciles.
+// Non-map input results in an empty string.
+//
+// This is designed to be called from a template.
+func toProperties(v interface{}) string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	flat := make(map[string]string)
+	flatten("", m, flat)
+	var sb strings.Builder
+	for _, k := range sortedKeys(flat) {
+		sb.WriteString(escapeProperty(k, true))
+		sb.WriteString("=")
+		sb.WriteString(escapeProperty(flat[k], false))
+		sb.WriteString("\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// toINI takes a map and renders it as an INI file. Scalar values at the top
+// level are written before the first section, nested maps become sections.
+// Deeper nesting is expressed with dotted section names (`[a.b]`).
+// Non-map input results in an empty string.
+//
+// This is designed to be called from a template.
+func toINI(v interface{}) string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	var sb strings.Builder
+	writeINISection(&sb, "", m)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func writeINISection(sb *strings.Builder, name string, m map[string]interface{}) {
+	var sections []string
+	var values []string
+	for k, v := range m {
+		if _, ok := v.(map[string]interface{}); ok {
+			sections = append(sections, k)
+			continue
+		}
+		values = append(values, k)
+	}
+	sort.Strings(sections)
+	sort.Strings(values)
+	if name != "" && (len(values) > 0 || len(sections) == 0) {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(sb, "[%s]\n", name)
+	}
+	for _, k := range values {
+		fmt.Fprintf(sb, "%s = %s\n", k, scalarString(m[k]))
+	}
+	for _, k := range sections {
+		child := k
+		if name != "" {
+			child = name + "." + k
+		}
+		writeINISection(sb, child, m[k].(map[string]interface{}))
+	}
+}
+
+func flatten(prefix string, m map[string]interface{}, out map[string]string) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flatten(key, nested, out)
+			continue
+		}
+		out[key] = scalarString(v)
+	}
+}
+
+func scalarString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case []interface{}:
+		parts := make([]string, 0, len(t))
+		for _, e := range t {
+			parts = append(parts, scalarString(e))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapeProperty escapes a key or value according to the
+// java.util.Properties format.
+func escapeProperty(s string, isKey bool) string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case ' ':
+			if isKey || i == 0 {
+				sb.WriteRune('\\')
+			}
+			sb.WriteRune(r)
+		default:
+			if r > 0xffff {
+				r1, r2 := utf16.EncodeRune(r)
+				fmt.Fprintf(&sb, `\u%04x\u%04x`, r1, r2)
+				continue
+			}
+			if r < 0x20 || r > 0x7e {
+				fmt.Fprintf(&sb, `\u%04x`, r)
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
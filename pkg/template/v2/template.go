@@ -15,12 +15,14 @@ limitations under the License.
 package template
 
 import (
-	"bytes"
 	"fmt"
+	"strings"
 	tpl "text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
@@ -32,13 +34,46 @@ var tplFuncs = tpl.FuncMap{
 	"pkcs12cert":     pkcs12cert,
 	"pkcs12certPass": pkcs12certPass,
 
+	"pemToPkcs12":         pemToPkcs12,
+	"pemToPkcs12Pass":     pemToPkcs12Pass,
+	"fullPemToPkcs12":     fullPemToPkcs12,
+	"fullPemToPkcs12Pass": fullPemToPkcs12Pass,
+	"pemToJks":            pemToJks,
+	"pemToJksTruststore":  pemToJksTruststore,
+
 	"filterPEM": filterPEM,
 
 	"jwkPublicKeyPem":  jwkPublicKeyPem,
 	"jwkPrivateKeyPem": jwkPrivateKeyPem,
+	"jwkToPem":         jwkToPem,
+	"pemToJwk":         pemToJwk,
+	"pemToJwks":        pemToJwks,
+
+	"toYaml":       toYAML,
+	"fromYaml":     fromYAML,
+	"toToml":       toTOML,
+	"toIni":        toINI,
+	"toProperties": toProperties,
+
+	"md5sum":     md5sum,
+	"sha512sum":  sha512sum,
+	"hmacSha256": hmacSha256,
+	"hmacSha512": hmacSha512,
+
+	"urlQueryEscape":   urlQueryEscape,
+	"urlQueryUnescape": urlQueryUnescape,
+	"urlPathEscape":    urlPathEscape,
+	"urlPathUnescape":  urlPathUnescape,
+
+	"gzipBase64": gzipBase64,
+
+	"databagItem":  databagItem,
+	"fromChefJSON": fromChefJSON,
 
-	"toYaml":   toYAML,
-	"fromYaml": fromYAML,
+	"mergeDeep":          mergeDeep,
+	"mergeOverwriteDeep": mergeOverwriteDeep,
+
+	"metadata": Options{}.metadata,
 }
 
 // So other templating calls can use the same extra functions.
@@ -52,6 +87,16 @@ const (
 	errDecodePKCS12WithPass = "unable to decode pkcs12 with password: %s"
 	errDecodeCertWithPass   = "unable to decode pkcs12 certificate with password: %s"
 	errParsePrivKey         = "unable to parse private key type"
+	errInvalidLabel         = "rendered label %q is invalid: %s"
+	errInvalidAnnotation    = "rendered annotation %q is invalid: %s"
+	errEncodePKCS12         = "unable to encode pkcs12: %s"
+	errEncodeJKS            = "unable to encode jks: %s"
+	errJKSNoPassword        = "jks keystores require a password"
+	errNoCertificate        = "no certificate found in input"
+	errNoPrivateKey         = "no private key found in input"
+	errNoPEMKey             = "no key found in pem input"
+	errJWKSymmetric         = "symmetric jwk can not be encoded as pem"
+	errNoMetadata           = "metadata %q is not available"
 
 	pemTypeCertificate = "CERTIFICATE"
 )
@@ -66,31 +111,69 @@ func init() {
 	}
 }
 
-func applyToTarget(k, val string, target esapi.TemplateTarget, secret *corev1.Secret) {
+func applyToTarget(k, val string, target esapi.TemplateTarget, secret *corev1.Secret) error {
 	switch target {
 	case esapi.TemplateTargetAnnotations:
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf(errInvalidAnnotation, k, strings.Join(errs, ", "))
+		}
 		secret.Annotations[k] = val
 	case esapi.TemplateTargetLabels:
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf(errInvalidLabel, k, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(val); len(errs) > 0 {
+			return fmt.Errorf(errInvalidLabel, k, strings.Join(errs, ", "))
+		}
 		secret.Labels[k] = val
 	case esapi.TemplateTargetData:
 		secret.Data[k] = []byte(val)
 	default:
 	}
+	return nil
 }
 
-func valueScopeApply(tplMap, data map[string][]byte, target esapi.TemplateTarget, secret *corev1.Secret) error {
+// Options configures the behavior of the template engine.
+// The zero value uses the text/template defaults and the limits
+// configured with the --template-timeout and --template-max-size flags.
+type Options struct {
+	// MissingKey is passed to text/template as `missingkey` option,
+	// e.g. `error` to fail on references to keys that do not exist.
+	MissingKey string
+	// Timeout bounds the time a single template may take to render.
+	// A negative value disables the limit.
+	Timeout time.Duration
+	// MaxSize bounds the size in bytes of a single rendered template.
+	// A negative value disables the limit.
+	MaxSize int
+	// Metadata is exposed to templates through the `metadata` function,
+	// e.g. `{{ metadata "storeName" }}`.
+	Metadata map[string]string
+}
+
+func (o Options) metadata(key string) (string, error) {
+	v, ok := o.Metadata[key]
+	if !ok {
+		return "", fmt.Errorf(errNoMetadata, key)
+	}
+	return v, nil
+}
+
+func (o Options) valueScopeApply(tplMap, data map[string][]byte, target esapi.TemplateTarget, secret *corev1.Secret) error {
 	for k, v := range tplMap {
-		val, err := execute(k, string(v), data)
+		val, err := o.execute(k, string(v), data)
 		if err != nil {
 			return fmt.Errorf(errExecute, k, err)
 		}
-		applyToTarget(k, string(val), target, secret)
+		if err := applyToTarget(k, string(val), target, secret); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func mapScopeApply(tpl string, data map[string][]byte, target esapi.TemplateTarget, secret *corev1.Secret) error {
-	val, err := execute(tpl, tpl, data)
+func (o Options) mapScopeApply(tpl string, data map[string][]byte, target esapi.TemplateTarget, secret *corev1.Secret) error {
+	val, err := o.execute(tpl, tpl, data)
 	if err != nil {
 		return fmt.Errorf(errExecute, tpl, err)
 	}
@@ -100,26 +183,35 @@ func mapScopeApply(tpl string, data map[string][]byte, target esapi.TemplateTarg
 		return fmt.Errorf("could not unmarshal template to 'map[string][]byte': %w", err)
 	}
 	for k, val := range src {
-		applyToTarget(k, val, target, secret)
+		if err := applyToTarget(k, val, target, secret); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// Execute renders the secret data as template. If an error occurs processing is stopped immediately.
+// Execute renders the secret data as template using the default options.
+// If an error occurs processing is stopped immediately.
 func Execute(tpl, data map[string][]byte, scope esapi.TemplateScope, target esapi.TemplateTarget, secret *corev1.Secret) error {
+	return Options{}.Execute(tpl, data, scope, target, secret)
+}
+
+// Execute renders the secret data as template using the configured options.
+// If an error occurs processing is stopped immediately.
+func (o Options) Execute(tpl, data map[string][]byte, scope esapi.TemplateScope, target esapi.TemplateTarget, secret *corev1.Secret) error {
 	if tpl == nil {
 		return nil
 	}
 	switch scope {
 	case esapi.TemplateScopeKeysAndValues:
 		for _, v := range tpl {
-			err := mapScopeApply(string(v), data, target, secret)
+			err := o.mapScopeApply(string(v), data, target, secret)
 			if err != nil {
 				return err
 			}
 		}
 	case esapi.TemplateScopeValues:
-		err := valueScopeApply(tpl, data, target, secret)
+		err := o.valueScopeApply(tpl, data, target, secret)
 		if err != nil {
 			return err
 		}
@@ -129,22 +221,23 @@ func Execute(tpl, data map[string][]byte, scope esapi.TemplateScope, target esap
 	return nil
 }
 
-func execute(k, val string, data map[string][]byte) ([]byte, error) {
+func (o Options) execute(k, val string, data map[string][]byte) ([]byte, error) {
 	strValData := make(map[string]string, len(data))
 	for k := range data {
 		strValData[k] = string(data[k])
 	}
 
-	t, err := tpl.New(k).
-		Funcs(tplFuncs).
-		Parse(val)
+	t := tpl.New(k).Funcs(tplFuncs).Funcs(tpl.FuncMap{"metadata": o.metadata})
+	if o.MissingKey != "" {
+		t = t.Option("missingkey=" + o.MissingKey)
+	}
+	t, err := t.Parse(val)
 	if err != nil {
 		return nil, fmt.Errorf(errParse, k, err)
 	}
-	buf := bytes.NewBuffer(nil)
-	err = t.Execute(buf, strValData)
+	out, err := o.render(t, strValData)
 	if err != nil {
 		return nil, fmt.Errorf(errExecute, k, err)
 	}
-	return buf.Bytes(), nil
+	return out, nil
 }
@@ -15,11 +15,18 @@ limitations under the License.
 package template
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -222,6 +229,54 @@ func TestExecute(t *testing.T) {
 				"foo": []byte(`{"foo":"bar"}`),
 			},
 		},
+		{
+			name: "fromJson & toToml func",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ .secret | fromJson | toToml }}`),
+			},
+			data: map[string][]byte{
+				"secret": []byte(`{"user": "admin", "db": {"host": "db.local", "port": 5432}}`),
+			},
+			expectedData: map[string][]byte{
+				"foo": []byte("user = 'admin'\n\n[db]\nhost = 'db.local'\nport = 5432"),
+			},
+		},
+		{
+			name: "fromJson & toIni func",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ .secret | fromJson | toIni }}`),
+			},
+			data: map[string][]byte{
+				"secret": []byte(`{"user": "admin", "db": {"host": "db.local", "port": 5432, "replica": {"host": "ro.local"}}, "cache": {"hosts": ["a", "b"]}}`),
+			},
+			expectedData: map[string][]byte{
+				"foo": []byte("user = admin\n\n[cache]\nhosts = a,b\n\n[db]\nhost = db.local\nport = 5432\n\n[db.replica]\nhost = ro.local"),
+			},
+		},
+		{
+			name: "fromJson & toProperties func",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ .secret | fromJson | toProperties }}`),
+			},
+			data: map[string][]byte{
+				"secret": []byte(`{"user": "admin", "db": {"url": "jdbc:postgresql://db:5432/app", "password": " p=ss\nword"}, "greeting": "grüße"}`),
+			},
+			expectedData: map[string][]byte{
+				"foo": []byte(`db.password=\ p\=ss\nword` + "\n" + `db.url=jdbc\:postgresql\://db\:5432/app` + "\n" + `greeting=gr\u00fc\u00dfe` + "\n" + `user=admin`),
+			},
+		},
+		{
+			name: "toProperties with non-map input",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ .secret | toProperties }}`),
+			},
+			data: map[string][]byte{
+				"secret": []byte(`plain`),
+			},
+			expectedData: map[string][]byte{
+				"foo": []byte(``),
+			},
+		},
 		{
 			name: "use sprig functions",
 			tpl: map[string][]byte{
@@ -258,6 +313,130 @@ func TestExecute(t *testing.T) {
 				"foo": []byte(`USERNAME`),
 			},
 		},
+		{
+			name: "use sprig regexReplaceAll, urlParse and semverCompare functions",
+			tpl: map[string][]byte{
+				"regex":  []byte(`{{ regexReplaceAll "[0-9]+" .value "X" }}`),
+				"host":   []byte(`{{ (urlParse .url).host }}`),
+				"semver": []byte(`{{ semverCompare ">=1.2.0" .version }}`),
+			},
+			data: map[string][]byte{
+				"value":   []byte(`abc123def45`),
+				"url":     []byte(`https://chef.example.com:8443/organizations/dev/`),
+				"version": []byte(`1.4.2`),
+			},
+			expectedData: map[string][]byte{
+				"regex":  []byte(`abcXdefX`),
+				"host":   []byte(`chef.example.com:8443`),
+				"semver": []byte(`true`),
+			},
+		},
+		{
+			name: "hash functions",
+			tpl: map[string][]byte{
+				"md5":     []byte(`{{ .value | md5sum }}`),
+				"sha512":  []byte(`{{ .value | sha512sum }}`),
+				"hmac":    []byte(`{{ .value | hmacSha256 "key" }}`),
+				"hmac512": []byte(`{{ .value | hmacSha512 "key" }}`),
+			},
+			data: map[string][]byte{
+				"value": []byte(`The quick brown fox jumps over the lazy dog`),
+			},
+			expectedData: map[string][]byte{
+				"md5":     []byte(`9e107d9d372bb6826bd81d3542a419d6`),
+				"sha512":  []byte(`07e547d9586f6a73f73fbac0435ed76951218fb7d0c8d788a309d785436bbb642e93a252a954f23912547d1e8a3b5ed6e1bfd7097821233fa0538f3db854fee6`),
+				"hmac":    []byte(`f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8`),
+				"hmac512": []byte(`b42af09057bac1e2d41708e48a902e09b5ff7f12ab428a4fe86653c73dd248fb82f948a549f7b791a5b41915ee4d1ec3935357e4e2317250d0372afa2ebeeb3a`),
+			},
+		},
+		{
+			name: "chef databag helpers",
+			tpl: map[string][]byte{
+				"username": []byte(`{{ (databagItem "item01" .).some_username }}`),
+				"password": []byte(`{{ (.databag | databagItem "item01").some_password }}`),
+				"port":     []byte(`{{ (fromChefJSON .item02).db.port }}`),
+				"hosts":    []byte(`{{ (fromChefJSON .item02).db.hosts | join "," }}`),
+			},
+			data: map[string][]byte{
+				"item01":  []byte(`{"id":"databag01-item01","some_password":"dolphin_123zc","some_username":"testuser"}`),
+				"item02":  []byte(`"{\"id\":\"item02\",\"db\":\"{\\\"port\\\":5432,\\\"hosts\\\":[\\\"a\\\",\\\"b\\\"]}\"}"`),
+				"databag": []byte(`{"item01":"{\"id\":\"databag01-item01\",\"some_password\":\"dolphin_123zc\",\"some_username\":\"testuser\"}"}`),
+			},
+			expectedData: map[string][]byte{
+				"username": []byte(`testuser`),
+				"password": []byte(`dolphin_123zc`),
+				"port":     []byte(`5432`),
+				"hosts":    []byte(`a,b`),
+			},
+		},
+		{
+			name: "chef databag item not found",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ (databagItem "missing" .).id }}`),
+			},
+			data: map[string][]byte{
+				"item01": []byte(`{"id":"item01"}`),
+			},
+			expErr: `databag item "missing" not found`,
+		},
+		{
+			name: "merge base and override items",
+			tpl: map[string][]byte{
+				"overwrite": []byte(`{{ (mergeOverwriteDeep .base .prod).db | toJson }}`),
+				"deep":      []byte(`{{ (mergeDeep .prod .base).db | toJson }}`),
+				"first":     []byte(`{{ (mergeDeep .base .prod).db.host }}`),
+				"item":      []byte(`{{ (mergeOverwriteDeep (databagItem "base" .) (databagItem "prod" .)).db.port }}`),
+			},
+			data: map[string][]byte{
+				"base": []byte(`{"db":{"host":"localhost","port":5432,"hosts":["a","b"]}}`),
+				"prod": []byte(`{"db":{"host":"db.prod","hosts":["c"]}}`),
+			},
+			expectedData: map[string][]byte{
+				"overwrite": []byte(`{"host":"db.prod","hosts":["c"],"port":5432}`),
+				"deep":      []byte(`{"host":"db.prod","hosts":["c"],"port":5432}`),
+				"first":     []byte(`localhost`),
+				"item":      []byte(`5432`),
+			},
+		},
+		{
+			name: "merge non object",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ mergeOverwriteDeep .base .list | toJson }}`),
+			},
+			data: map[string][]byte{
+				"base": []byte(`{"a":1}`),
+				"list": []byte(`[1,2]`),
+			},
+			expErr: "merge argument 1 is not a json object",
+		},
+		{
+			name: "url escape functions",
+			tpl: map[string][]byte{
+				"query":   []byte(`{{ .value | urlQueryEscape }}`),
+				"path":    []byte(`{{ .value | urlPathEscape }}`),
+				"unquery": []byte(`{{ .value | urlQueryEscape | urlQueryUnescape }}`),
+				"unpath":  []byte(`{{ .value | urlPathEscape | urlPathUnescape }}`),
+			},
+			data: map[string][]byte{
+				"value": []byte(`p@ss word/&=`),
+			},
+			expectedData: map[string][]byte{
+				"query":   []byte(`p%40ss+word%2F%26%3D`),
+				"path":    []byte(`p@ss%20word%2F&=`),
+				"unquery": []byte(`p@ss word/&=`),
+				"unpath":  []byte(`p@ss word/&=`),
+			},
+		},
+		{
+			name: "url unescape error",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ .value | urlQueryUnescape }}`),
+			},
+			data: map[string][]byte{
+				"value": []byte(`%zz`),
+			},
+			expErr: "invalid URL escape",
+		},
 		{
 			name: "multiline template",
 			tpl: map[string][]byte{
@@ -417,6 +596,58 @@ func TestExecute(t *testing.T) {
 				"fn": []byte(jwkPrivECPKCS8),
 			},
 		},
+		{
+			name: "jwk to pem",
+			tpl: map[string][]byte{
+				"pub":  []byte(`{{ .pub | jwkToPem }}`),
+				"priv": []byte(`{{ .priv | jwkToPem }}`),
+			},
+			data: map[string][]byte{
+				"pub":  []byte(jwkPubRSA),
+				"priv": []byte(jwkPrivEC),
+			},
+			expectedData: map[string][]byte{
+				"pub":  []byte(jwkPubRSAPKIX),
+				"priv": []byte(jwkPrivECPKCS8),
+			},
+		},
+		{
+			name: "pem to jwk roundtrip",
+			tpl: map[string][]byte{
+				"rsa": []byte(`{{ .rsa | pemToJwk | jwkToPem }}`),
+				"ec":  []byte(`{{ .ec | pemToJwk | jwkToPem }}`),
+			},
+			data: map[string][]byte{
+				"rsa": []byte(jwkPrivRSAPKCS8),
+				"ec":  []byte(jwkPubECPKIX),
+			},
+			expectedData: map[string][]byte{
+				"rsa": []byte(jwkPrivRSAPKCS8),
+				"ec":  []byte(jwkPubECPKIX),
+			},
+		},
+		{
+			name: "pem to jwks only exposes public keys",
+			tpl: map[string][]byte{
+				"kty": []byte(`{{ $set := .secret | pemToJwks | fromJson }}{{ range $set.keys }}{{ .kty }},{{ hasKey . "d" }},{{ hasKey . "kid" }};{{ end }}`),
+			},
+			data: map[string][]byte{
+				"secret": []byte(jwkPrivRSAPKCS8 + jwkPubECPKIX),
+			},
+			expectedData: map[string][]byte{
+				"kty": []byte(`RSA,false,true;EC,false,true;`),
+			},
+		},
+		{
+			name: "pem to jwk without key",
+			tpl: map[string][]byte{
+				"fn": []byte(`{{ .secret | pemToJwk }}`),
+			},
+			data: map[string][]byte{
+				"secret": []byte(`not a pem`),
+			},
+			expErr: errNoPEMKey,
+		},
 		{
 			name: "filter pem certificate",
 			tpl: map[string][]byte{
@@ -522,6 +753,117 @@ func TestExecuteInvalidTemplateScope(t *testing.T) {
 	assert.ErrorContains(t, err, "expected 'Values' or 'KeysAndValues'")
 }
 
+func TestExecuteMissingKey(t *testing.T) {
+	tbl := []struct {
+		name         string
+		missingKey   string
+		tpl          map[string][]byte
+		expectedData map[string][]byte
+		expErr       string
+	}{
+		{
+			name:         "default renders no value",
+			tpl:          map[string][]byte{"foo": []byte("{{ .missing }}")},
+			expectedData: map[string][]byte{"foo": []byte("<no value>")},
+		},
+		{
+			name:         "zero renders empty string",
+			missingKey:   "zero",
+			tpl:          map[string][]byte{"foo": []byte("{{ .missing }}")},
+			expectedData: map[string][]byte{"foo": []byte("")},
+		},
+		{
+			name:       "error fails on missing key",
+			missingKey: "error",
+			tpl:        map[string][]byte{"foo": []byte("{{ .missing }}")},
+			expErr:     `map has no entry for key "missing"`,
+		},
+		{
+			name:         "error renders existing key",
+			missingKey:   "error",
+			tpl:          map[string][]byte{"foo": []byte("{{ .bar }}")},
+			expectedData: map[string][]byte{"foo": []byte("baz")},
+		},
+	}
+	for i := range tbl {
+		row := tbl[i]
+		t.Run(row.name, func(t *testing.T) {
+			sec := &corev1.Secret{Data: make(map[string][]byte)}
+			opts := Options{MissingKey: row.missingKey}
+			err := opts.Execute(row.tpl, map[string][]byte{"bar": []byte("baz")}, esapi.TemplateScopeValues, esapi.TemplateTargetData, sec)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %s, expected: %s", err, row.expErr)
+			}
+			if row.expectedData != nil {
+				assert.EqualValues(t, row.expectedData, sec.Data)
+			}
+		})
+	}
+}
+
+func TestExecuteMetadata(t *testing.T) {
+	opts := Options{Metadata: map[string]string{"storeName": "chef-store", "provider": "chef"}}
+
+	sec := &corev1.Secret{Data: make(map[string][]byte)}
+	err := opts.Execute(map[string][]byte{"foo": []byte(`{{ metadata "provider" }}/{{ metadata "storeName" }}`)}, nil, esapi.TemplateScopeValues, esapi.TemplateTargetData, sec)
+	require.NoError(t, err)
+	assert.Equal(t, "chef/chef-store", string(sec.Data["foo"]))
+
+	err = opts.Execute(map[string][]byte{"foo": []byte(`{{ metadata "unknown" }}`)}, nil, esapi.TemplateScopeValues, esapi.TemplateTargetData, sec)
+	assert.ErrorContains(t, err, `metadata "unknown" is not available`)
+
+	err = Execute(map[string][]byte{"foo": []byte(`{{ metadata "provider" }}`)}, nil, esapi.TemplateScopeValues, esapi.TemplateTargetData, sec)
+	assert.ErrorContains(t, err, `metadata "provider" is not available`)
+}
+
+func TestExecuteLimits(t *testing.T) {
+	tbl := []struct {
+		name         string
+		opts         Options
+		tpl          map[string][]byte
+		expectedData map[string][]byte
+		expErr       string
+	}{
+		{
+			name:         "output within max size",
+			opts:         Options{MaxSize: 10},
+			tpl:          map[string][]byte{"foo": []byte(`{{ repeat 10 "a" }}`)},
+			expectedData: map[string][]byte{"foo": []byte("aaaaaaaaaa")},
+		},
+		{
+			name:   "output exceeds max size",
+			opts:   Options{MaxSize: 10},
+			tpl:    map[string][]byte{"foo": []byte(`{{ repeat 11 "a" }}`)},
+			expErr: "rendered template exceeds the maximum size",
+		},
+		{
+			name:         "negative max size disables the limit",
+			opts:         Options{MaxSize: -1},
+			tpl:          map[string][]byte{"foo": []byte(`{{ repeat 11 "a" }}`)},
+			expectedData: map[string][]byte{"foo": []byte("aaaaaaaaaaa")},
+		},
+		{
+			name:   "execution exceeds timeout",
+			opts:   Options{Timeout: 10 * time.Millisecond},
+			tpl:    map[string][]byte{"foo": []byte(`{{ range until 10000 }}{{ range until 10000 }}{{ end }}{{ end }}`)},
+			expErr: "template did not finish within 10ms",
+		},
+	}
+	for i := range tbl {
+		row := tbl[i]
+		t.Run(row.name, func(t *testing.T) {
+			sec := &corev1.Secret{Data: make(map[string][]byte)}
+			err := row.opts.Execute(row.tpl, nil, esapi.TemplateScopeValues, esapi.TemplateTargetData, sec)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %s, expected: %s", err, row.expErr)
+			}
+			if row.expectedData != nil {
+				assert.EqualValues(t, row.expectedData, sec.Data)
+			}
+		})
+	}
+}
+
 func TestScopeKeysAndValues(t *testing.T) {
 	tbl := []struct {
 		name               string
@@ -574,6 +916,26 @@ func TestScopeKeysAndValues(t *testing.T) {
 				"foo": "bar",
 			},
 		},
+		{
+			name:   "test invalid label value",
+			tpl:    map[string][]byte{"literal": []byte("{{ .key }}: {{ .value }}")},
+			target: esapi.TemplateTargetLabels,
+			data: map[string][]byte{
+				"key":   []byte("foo"),
+				"value": []byte("not a valid label"),
+			},
+			expErr: `rendered label "foo" is invalid`,
+		},
+		{
+			name:   "test invalid annotation key",
+			tpl:    map[string][]byte{"literal": []byte("{{ .key }}: {{ .value }}")},
+			target: esapi.TemplateTargetAnnotations,
+			data: map[string][]byte{
+				"key":   []byte("foo/bar/baz"),
+				"value": []byte("bar"),
+			},
+			expErr: `rendered annotation "foo/bar/baz" is invalid`,
+		},
 	}
 	for i := range tbl {
 		row := tbl[i]
@@ -737,3 +1099,160 @@ func TestPkcs12certPass(t *testing.T) {
 		})
 	}
 }
+
+func TestPemToPkcs12(t *testing.T) {
+	const (
+		leafCertPath         = "_testdata/foo.crt"
+		leafKeyPath          = "_testdata/foo.key"
+		intermediateCertPath = "_testdata/intermediate-ca.crt"
+		rootCertPath         = "_testdata/root-ca.crt"
+	)
+	read := func(paths ...string) string {
+		var out []byte
+		for _, p := range paths {
+			c, err := os.ReadFile(p)
+			require.NoError(t, err)
+			out = append(out, c...)
+		}
+		return string(out)
+	}
+	leaf := read(leafCertPath)
+	chain := read(leafCertPath, intermediateCertPath, rootCertPath)
+	key := read(leafKeyPath)
+
+	tests := []struct {
+		name     string
+		encode   func() (string, error)
+		pass     string
+		wantCert string
+		wantErr  string
+	}{
+		{
+			name:     "leaf without password",
+			encode:   func() (string, error) { return pemToPkcs12(chain, key) },
+			wantCert: leaf,
+		},
+		{
+			name:     "leaf with password",
+			encode:   func() (string, error) { return pemToPkcs12Pass(chain, key, "1234") },
+			pass:     "1234",
+			wantCert: leaf,
+		},
+		{
+			name:     "full chain without password",
+			encode:   func() (string, error) { return fullPemToPkcs12(chain, key) },
+			wantCert: chain,
+		},
+		{
+			name:     "full chain with password",
+			encode:   func() (string, error) { return fullPemToPkcs12Pass(chain, key, "1234") },
+			pass:     "1234",
+			wantCert: chain,
+		},
+		{
+			name:    "missing certificate",
+			encode:  func() (string, error) { return pemToPkcs12(key, key) },
+			wantErr: errNoCertificate,
+		},
+		{
+			name:    "missing key",
+			encode:  func() (string, error) { return pemToPkcs12(leaf, leaf) },
+			wantErr: errParsePrivKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.encode()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			archive, err := base64.StdEncoding.DecodeString(out)
+			require.NoError(t, err)
+			gotCert, err := pkcs12certPass(tt.pass, string(archive))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCert, gotCert)
+			gotKey, err := pkcs12keyPass(tt.pass, string(archive))
+			require.NoError(t, err)
+			assert.Contains(t, gotKey, "PRIVATE KEY")
+		})
+	}
+}
+
+func TestPemToJks(t *testing.T) {
+	cert, err := os.ReadFile("_testdata/foo.crt")
+	require.NoError(t, err)
+	key, err := os.ReadFile("_testdata/foo.key")
+	require.NoError(t, err)
+
+	out, err := pemToJks(string(cert), string(key), "changeit")
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(out)
+	require.NoError(t, err)
+	ks := keystore.New()
+	require.NoError(t, ks.Load(bytes.NewReader(raw), []byte("changeit")))
+	entry, err := ks.GetPrivateKeyEntry(jksKeyAlias, []byte("changeit"))
+	require.NoError(t, err)
+	require.Len(t, entry.CertificateChain, 1)
+	block, _ := pem.Decode(cert)
+	assert.Equal(t, block.Bytes, entry.CertificateChain[0].Content)
+
+	out, err = pemToJksTruststore(string(cert), "changeit")
+	require.NoError(t, err)
+	raw, err = base64.StdEncoding.DecodeString(out)
+	require.NoError(t, err)
+	ts := keystore.New()
+	require.NoError(t, ts.Load(bytes.NewReader(raw), []byte("changeit")))
+	assert.True(t, ts.IsTrustedCertificateEntry("0"))
+
+	_, err = pemToJks(string(cert), string(key), "")
+	assert.ErrorContains(t, err, errJKSNoPassword)
+}
+
+func TestGzipBase64(t *testing.T) {
+	input := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 100)
+	out, err := gzipBase64(input)
+	require.NoError(t, err)
+	compressed, err := base64.StdEncoding.DecodeString(out)
+	require.NoError(t, err)
+	assert.Less(t, len(compressed), len(input))
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	decompressed, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, input, string(decompressed))
+}
+
+func TestMergeOverwriteDeep(t *testing.T) {
+	base := map[string]any{
+		"db":      map[string]any{"host": "localhost", "port": 5432},
+		"replace": map[string]any{"a": 1},
+	}
+	override := map[string]any{
+		"db":      map[string]any{"host": "db.prod"},
+		"replace": "scalar",
+		"extra":   true,
+	}
+	got, err := mergeOverwriteDeep(base, nil, override)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"db":      map[string]any{"host": "db.prod", "port": 5432},
+		"replace": "scalar",
+		"extra":   true,
+	}, got)
+
+	// the arguments are not modified.
+	assert.Equal(t, map[string]any{"host": "localhost", "port": 5432}, base["db"])
+	got["db"].(map[string]any)["port"] = 1
+	assert.Equal(t, 5432, base["db"].(map[string]any)["port"])
+
+	got, err = mergeDeep(override, base)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"host": "db.prod", "port": 5432}, got["db"])
+
+	_, err = mergeOverwriteDeep(base, 1)
+	assert.EqualError(t, err, "unsupported type int of merge argument 1")
+	_, err = mergeDeep(base, "{")
+	assert.ErrorContains(t, err, "unable to decode merge argument 1")
+}
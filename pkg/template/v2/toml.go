@@ -0,0 +1,62 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"math"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// toTOML takes an interface, marshals it to toml, and returns a string. It will
+// always return a string, even on marshal error (empty string).
+//
+// This is designed to be called from a template.
+func toTOML(v interface{}) string {
+	data, err := toml.Marshal(integralFloatsToInt(v))
+	if err != nil {
+		// Swallow errors inside of a template.
+		return ""
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// integralFloatsToInt converts float64 values without fractional part to int64.
+// Numbers decoded by fromJson are always float64, which toml would otherwise
+// render as `5432.0`.
+func integralFloatsToInt(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			out[k] = integralFloatsToInt(e)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(t))
+		for _, e := range t {
+			out = append(out, integralFloatsToInt(e))
+		}
+		return out
+	case float64:
+		if t == math.Trunc(t) && math.Abs(t) < math.MaxInt64 {
+			return int64(t)
+		}
+		return t
+	default:
+		return v
+	}
+}
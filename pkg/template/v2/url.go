@@ -0,0 +1,39 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"net/url"
+)
+
+// urlQueryEscape escapes the input so it can be safely placed inside a URL query.
+func urlQueryEscape(input string) string {
+	return url.QueryEscape(input)
+}
+
+// urlQueryUnescape is the inverse of urlQueryEscape.
+func urlQueryUnescape(input string) (string, error) {
+	return url.QueryUnescape(input)
+}
+
+// urlPathEscape escapes the input so it can be safely placed inside a URL path segment.
+func urlPathEscape(input string) string {
+	return url.PathEscape(input)
+}
+
+// urlPathUnescape is the inverse of urlPathEscape.
+func urlPathUnescape(input string) (string, error) {
+	return url.PathUnescape(input)
+}
@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certpin pins server certificates by the SHA-256 hash of their
+// SubjectPublicKeyInfo (SPKI), in addition to the validation against the
+// trusted CAs.
+package certpin
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Prefix is the prefix of pins, a pin is the base64 encoded SHA-256 hash of
+// the SPKI of a certificate, e.g. sha256/YLh1dUR9y6Kja30RrAn7JKnbQG/uEtLMkBgFF2Fuihg=.
+const Prefix = "sha256/"
+
+var (
+	// ErrInvalidPin is returned by Validate for malformed pins.
+	ErrInvalidPin = errors.New("invalid certificate pin")
+
+	errNoMatch = errors.New("certificate pinning: no certificate of the server matches a pin")
+)
+
+// Pin returns the pin of cert.
+func Pin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return Prefix + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Validate returns an error if one of the pins is malformed.
+func Validate(pins []string) error {
+	for _, pin := range pins {
+		sum, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(pin, Prefix))
+		if !strings.HasPrefix(pin, Prefix) || err != nil || len(sum) != sha256.Size {
+			return fmt.Errorf("%w %q: must be %s followed by the base64 encoded SHA-256 hash of the SubjectPublicKeyInfo", ErrInvalidPin, pin, Prefix)
+		}
+	}
+	return nil
+}
+
+// VerifyConnection returns a tls.Config VerifyConnection func that accepts
+// the connection only if a certificate of the verified chains matches one of
+// the pins. It returns nil if pins is empty, so it can be assigned to a
+// tls.Config unconditionally. VerifyConnection is called after the regular
+// certificate validation, so pinning never weakens it.
+func VerifyConnection(pins []string) func(tls.ConnectionState) error {
+	if len(pins) == 0 {
+		return nil
+	}
+	return func(cs tls.ConnectionState) error {
+		// only the leaf and the verified chains are considered, the server
+		// may send additional certificates that were not verified.
+		var certs []*x509.Certificate
+		if len(cs.PeerCertificates) > 0 {
+			certs = append(certs, cs.PeerCertificates[0])
+		}
+		for _, chain := range cs.VerifiedChains {
+			certs = append(certs, chain...)
+		}
+		for _, cert := range certs {
+			pin := Pin(cert)
+			for _, p := range pins {
+				if p == pin {
+					return nil
+				}
+			}
+		}
+		return errNoMatch
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certpin
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	sum := sha256.Sum256([]byte("spki"))
+	valid := Prefix + base64.StdEncoding.EncodeToString(sum[:])
+
+	assert.NoError(t, Validate(nil))
+	assert.NoError(t, Validate([]string{valid}))
+	assert.ErrorIs(t, Validate([]string{base64.StdEncoding.EncodeToString(sum[:])}), ErrInvalidPin)
+	assert.Error(t, Validate([]string{"sha256/not-base64"}))
+	assert.Error(t, Validate([]string{Prefix + base64.StdEncoding.EncodeToString(sum[:16])}))
+	assert.Error(t, Validate([]string{valid, "sha1/abc"}))
+}
+
+func TestVerifyConnection(t *testing.T) {
+	assert.Nil(t, VerifyConnection(nil))
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	pin := Pin(srv.Certificate())
+	other := Prefix + base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+
+	get := func(pins []string) error {
+		pool := x509.NewCertPool()
+		pool.AddCert(srv.Certificate())
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:          pool,
+			MinVersion:       tls.VersionTLS12,
+			VerifyConnection: VerifyConnection(pins),
+		}}}
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	assert.NoError(t, get([]string{pin}))
+	assert.NoError(t, get([]string{other, pin}))
+	err := get([]string{other})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, errNoMatch.Error())
+}
@@ -0,0 +1,118 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package guarded keeps secret material that is held in memory for longer
+// than a single call out of swap and core dumps, and wipes it when it is no
+// longer needed.
+package guarded
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// Bytes holds a secret value in memory that is allocated outside of the Go
+// heap. Where supported the memory is locked into RAM, so it is never
+// written to swap, and excluded from core dumps. Destroy wipes the memory.
+//
+// Locking is best effort, e.g. it fails if RLIMIT_MEMLOCK is exhausted, the
+// value is still wiped on Destroy.
+type Bytes struct {
+	mu  sync.Mutex
+	mem *memory
+}
+
+// New copies value into guarded memory. Callers should Wipe their copy of
+// value as soon as they do not need it anymore.
+func New(value []byte) *Bytes {
+	b := &Bytes{mem: alloc(len(value))}
+	copy(b.mem.data, value)
+	// release the memory of values that are dropped without Destroy
+	runtime.SetFinalizer(b, (*Bytes).Destroy)
+	return b
+}
+
+// Copy returns a copy of the value on the heap, or nil if b was destroyed.
+func (b *Bytes) Copy() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mem == nil {
+		return nil
+	}
+	return append([]byte{}, b.mem.data...)
+}
+
+// Locked reports whether the value is locked into RAM.
+func (b *Bytes) Locked() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.mem != nil && b.mem.locked
+}
+
+// Destroy wipes and releases the value. It is safe to call Destroy more
+// than once.
+func (b *Bytes) Destroy() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mem == nil {
+		return
+	}
+	b.mem.free()
+	b.mem = nil
+	runtime.SetFinalizer(b, nil)
+}
+
+// Wipe overwrites b with zeros.
+func Wipe(b []byte) {
+	clear(b)
+}
+
+// WipePrivateKey overwrites the exported secret parts of a parsed private key
+// with zeros. It is best effort: values the crypto packages keep in
+// unexported fields, e.g. the precomputed moduli of rsa keys, are left
+// unchanged, as are keys of unknown types. The key must not be used
+// afterwards.
+func WipePrivateKey(key any) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		wipeInt(k.D)
+		for _, p := range k.Primes {
+			wipeInt(p)
+		}
+		wipeInt(k.Precomputed.Dp)
+		wipeInt(k.Precomputed.Dq)
+		wipeInt(k.Precomputed.Qinv)
+		for _, crt := range k.Precomputed.CRTValues {
+			wipeInt(crt.Exp)
+			wipeInt(crt.Coeff)
+			wipeInt(crt.R)
+		}
+	case *ecdsa.PrivateKey:
+		wipeInt(k.D)
+	case ed25519.PrivateKey:
+		Wipe(k)
+	case *ed25519.PrivateKey:
+		Wipe(*k)
+	}
+}
+
+func wipeInt(i *big.Int) {
+	if i == nil {
+		return
+	}
+	clear(i.Bits())
+	i.SetInt64(0)
+}
@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guarded
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytes(t *testing.T) {
+	value := []byte("secret")
+	b := New(value)
+	Wipe(value)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0}, value)
+	assert.Equal(t, []byte("secret"), b.Copy())
+
+	// copies are independent of the guarded value
+	c := b.Copy()
+	c[0] = 'S'
+	assert.Equal(t, []byte("secret"), b.Copy())
+
+	b.Destroy()
+	assert.Nil(t, b.Copy())
+	assert.False(t, b.Locked())
+	b.Destroy()
+}
+
+func TestBytesEmpty(t *testing.T) {
+	b := New(nil)
+	assert.Empty(t, b.Copy())
+	b.Destroy()
+	assert.Nil(t, b.Copy())
+}
+
+func TestWipePrivateKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	primes := rsaKey.Primes
+	WipePrivateKey(rsaKey)
+	assert.Zero(t, rsaKey.D.Sign())
+	for _, p := range primes {
+		assert.Zero(t, p.Sign())
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	WipePrivateKey(ecKey)
+	assert.Zero(t, ecKey.D.Sign())
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	WipePrivateKey(edKey)
+	assert.Equal(t, make(ed25519.PrivateKey, ed25519.PrivateKeySize), edKey)
+
+	// unknown and nil keys are ignored
+	WipePrivateKey("key")
+	WipePrivateKey(nil)
+}
@@ -0,0 +1,58 @@
+//go:build linux
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guarded
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// memory is an anonymous mapping that is excluded from core dumps and
+// locked into RAM if possible.
+type memory struct {
+	data    []byte
+	mapping []byte
+	locked  bool
+}
+
+func alloc(size int) *memory {
+	if size == 0 {
+		return &memory{data: []byte{}}
+	}
+	pageSize := os.Getpagesize()
+	mapping, err := unix.Mmap(-1, 0, (size+pageSize-1)/pageSize*pageSize,
+		unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANONYMOUS)
+	if err != nil {
+		return &memory{data: make([]byte, size)}
+	}
+	_ = unix.Madvise(mapping, unix.MADV_DONTDUMP)
+	return &memory{
+		data:    mapping[:size:size],
+		mapping: mapping,
+		locked:  unix.Mlock(mapping) == nil,
+	}
+}
+
+func (m *memory) free() {
+	Wipe(m.data)
+	if m.mapping == nil {
+		return
+	}
+	if m.locked {
+		_ = unix.Munlock(m.mapping)
+	}
+	_ = unix.Munmap(m.mapping)
+}
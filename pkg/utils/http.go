@@ -0,0 +1,82 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// MaxErrorBody limits how much of an error response is included in errors.
+const MaxErrorBody = 512
+
+const errUnexpectedStatus = "unexpected status code %d: %s"
+
+// HTTPStatusError is returned for responses with a status outside of 2xx.
+type HTTPStatusError struct {
+	StatusCode int
+	// Body is the complete body of the response, the error message only
+	// includes its first MaxErrorBody bytes.
+	Body []byte
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf(errUnexpectedStatus, e.StatusCode, TruncateErrorBody(e.Body))
+}
+
+// TruncateErrorBody returns the first MaxErrorBody bytes of a response, to
+// be included in an error.
+func TruncateErrorBody(body []byte) string {
+	if len(body) > MaxErrorBody {
+		body = body[:MaxErrorBody]
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// CheckHTTPStatus returns an HTTPStatusError if the status is outside of 2xx.
+func CheckHTTPStatus(statusCode int, body []byte) error {
+	if statusCode < 200 || statusCode >= 300 {
+		return &HTTPStatusError{StatusCode: statusCode, Body: body}
+	}
+	return nil
+}
+
+// DoHTTP sends the request and returns the body of the response. The
+// statuses of notFound are returned as NoSecretError, other statuses outside
+// of 2xx as HTTPStatusError.
+func DoHTTP(client *http.Client, req *http.Request, notFound ...int) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range notFound {
+		if resp.StatusCode == status {
+			return nil, esv1beta1.NoSecretError{}
+		}
+	}
+	if err := CheckHTTPStatus(resp.StatusCode, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestDoHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		notFound   []int
+		want       string
+		wantStatus int
+		noSecret   bool
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			body:   "value",
+			want:   "value",
+		},
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			notFound: []int{http.StatusNotFound, http.StatusGone},
+			noSecret: true,
+		},
+		{
+			name:       "not found without notFound statuses",
+			status:     http.StatusNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error",
+			status:     http.StatusInternalServerError,
+			body:       strings.Repeat("a", MaxErrorBody+1),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			req, err := http.NewRequest(http.MethodGet, srv.URL, http.NoBody)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := DoHTTP(srv.Client(), req, tt.notFound...)
+			var statusErr *HTTPStatusError
+			switch {
+			case tt.noSecret:
+				if !errors.Is(err, esv1beta1.NoSecretError{}) {
+					t.Errorf("DoHTTP() error = %v, want NoSecretError", err)
+				}
+			case tt.wantStatus != 0:
+				if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.wantStatus {
+					t.Fatalf("DoHTTP() error = %v, want status %d", err, tt.wantStatus)
+				}
+				if string(statusErr.Body) != tt.body {
+					t.Errorf("HTTPStatusError.Body = %q, want %q", statusErr.Body, tt.body)
+				}
+				if strings.Contains(err.Error(), strings.Repeat("a", MaxErrorBody+1)) {
+					t.Errorf("DoHTTP() error is not truncated")
+				}
+			default:
+				if err != nil || string(body) != tt.want {
+					t.Errorf("DoHTTP() = %q, %v, want %q", body, err, tt.want)
+				}
+			}
+		})
+	}
+}
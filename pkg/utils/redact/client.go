@@ -0,0 +1,99 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"context"
+
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// Client wraps c so that every error it returns is scrubbed. Errors of
+// PushSecret are additionally scrubbed of the pushed values. The returned
+// client implements esv1beta1.UpdatePolicyClient if c does.
+func Client(c esv1beta1.SecretsClient) esv1beta1.SecretsClient {
+	if pc, ok := c.(esv1beta1.UpdatePolicyClient); ok {
+		return &policyClient{client: client{c}, policy: pc}
+	}
+	return &client{c}
+}
+
+// Unwrap returns the client wrapped by Client, or c if it is not wrapped.
+func Unwrap(c esv1beta1.SecretsClient) esv1beta1.SecretsClient {
+	switch rc := c.(type) {
+	case *client:
+		return rc.c
+	case *policyClient:
+		return rc.c
+	}
+	return c
+}
+
+type client struct {
+	c esv1beta1.SecretsClient
+}
+
+var _ esv1beta1.SecretsClient = &client{}
+
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, err := c.c.GetSecret(ctx, ref)
+	return value, Error(err)
+}
+
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	secretMap, err := c.c.GetSecretMap(ctx, ref)
+	return secretMap, Error(err)
+}
+
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	secretMap, err := c.c.GetAllSecrets(ctx, ref)
+	return secretMap, Error(err)
+}
+
+func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	return Error(c.c.PushSecret(ctx, secret, data), pushedValues(secret)...)
+}
+
+func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	return Error(c.c.DeleteSecret(ctx, remoteRef))
+}
+
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	result, err := c.c.Validate()
+	return result, Error(err)
+}
+
+func (c *client) Close(ctx context.Context) error {
+	return Error(c.c.Close(ctx))
+}
+
+type policyClient struct {
+	client
+	policy esv1beta1.UpdatePolicyClient
+}
+
+var _ esv1beta1.UpdatePolicyClient = &policyClient{}
+
+func (c *policyClient) PushSecretWithPolicy(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData, policy esv1beta1.PushSecretUpdatePolicy) error {
+	return Error(c.policy.PushSecretWithPolicy(ctx, secret, data, policy), pushedValues(secret)...)
+}
+
+func pushedValues(secret *corev1.Secret) [][]byte {
+	if secret == nil {
+		return nil
+	}
+	return maps.Values(secret.Data)
+}
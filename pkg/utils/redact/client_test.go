@@ -0,0 +1,108 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+var errLeak = errors.New(`unexpected response {"token":"abc123"}`)
+
+const redactedLeak = `unexpected response {"token":"[REDACTED]"}`
+
+type fakeClient struct {
+	err error
+}
+
+func (c *fakeClient) GetSecret(context.Context, esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	return []byte("value"), c.err
+}
+
+func (c *fakeClient) GetSecretMap(context.Context, esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	return nil, c.err
+}
+
+func (c *fakeClient) GetAllSecrets(context.Context, esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, c.err
+}
+
+func (c *fakeClient) PushSecret(_ context.Context, secret *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New("invalid value " + string(secret.Data["key"]))
+}
+
+func (c *fakeClient) DeleteSecret(context.Context, esv1beta1.PushSecretRemoteRef) error {
+	return c.err
+}
+
+func (c *fakeClient) Validate() (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultError, c.err
+}
+
+func (c *fakeClient) Close(context.Context) error {
+	return nil
+}
+
+type fakePolicyClient struct {
+	fakeClient
+}
+
+func (c *fakePolicyClient) PushSecretWithPolicy(_ context.Context, secret *corev1.Secret, _ esv1beta1.PushSecretData, _ esv1beta1.PushSecretUpdatePolicy) error {
+	return errors.New("invalid value " + string(secret.Data["key"]))
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeClient{err: errLeak}
+	c := Client(fake)
+	_, ok := c.(esv1beta1.UpdatePolicyClient)
+	assert.False(t, ok)
+	assert.Same(t, fake, Unwrap(c))
+
+	value, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{})
+	assert.Equal(t, []byte("value"), value)
+	assert.EqualError(t, err, redactedLeak)
+	_, err = c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{})
+	assert.EqualError(t, err, redactedLeak)
+	_, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{})
+	assert.EqualError(t, err, redactedLeak)
+	assert.EqualError(t, c.DeleteSecret(ctx, nil), redactedLeak)
+	result, err := c.Validate()
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+	assert.EqualError(t, err, redactedLeak)
+	assert.NoError(t, c.Close(ctx))
+
+	secret := &corev1.Secret{Data: map[string][]byte{"key": []byte("hunter2")}}
+	assert.EqualError(t, c.PushSecret(ctx, secret, nil), "invalid value [REDACTED]")
+
+	fake.err = esv1beta1.NoSecretErr
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+}
+
+func TestPolicyClient(t *testing.T) {
+	fake := &fakePolicyClient{}
+	c := Client(fake)
+	assert.Same(t, fake, Unwrap(c))
+	pc, ok := c.(esv1beta1.UpdatePolicyClient)
+	assert.True(t, ok)
+	secret := &corev1.Secret{Data: map[string][]byte{"key": []byte("hunter2")}}
+	err := pc.PushSecretWithPolicy(context.Background(), secret, nil, esv1beta1.PushSecretUpdatePolicyMerge)
+	assert.EqualError(t, err, "invalid value [REDACTED]")
+}
@@ -16,11 +16,13 @@ package utils
 
 import (
 	"bytes"
+	"compress/gzip"
 	"crypto/md5" //nolint:gosec
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/url"
 	"reflect"
@@ -37,8 +39,15 @@ import (
 )
 
 const (
+	// maxGunzipSize matches the maximum size of a Kubernetes Secret.
+	maxGunzipSize = 1 << 20
+
 	errParse   = "unable to parse transform template: %s"
 	errExecute = "unable to execute transform template: %s"
+
+	errSecretKeyParse   = "unable to parse secretKey template: %s"
+	errSecretKeyExecute = "unable to execute secretKey template: %s"
+	errSecretKeyInvalid = "rendered secretKey %q is not a valid key"
 )
 
 // JSONMarshal takes an interface and returns a new escaped and encoded byte slice.
@@ -131,6 +140,39 @@ func transform(val string, data map[string][]byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// RenderSecretKey renders a secretKey that contains a template, e.g.
+// `{{ .remoteRef.property | upper }}`. The key, property and version of the
+// remoteRef the value is read from are available as .remoteRef.
+// Keys without template are returned unchanged.
+func RenderSecretKey(secretKey string, ref esv1beta1.ExternalSecretDataRemoteRef) (string, error) {
+	if !strings.Contains(secretKey, "{{") {
+		return secretKey, nil
+	}
+	t, err := tpl.New("secretKey").
+		Funcs(template.FuncMap()).
+		Option("missingkey=error").
+		Parse(secretKey)
+	if err != nil {
+		return "", fmt.Errorf(errSecretKeyParse, err)
+	}
+	data := map[string]any{
+		"remoteRef": map[string]string{
+			"key":      ref.Key,
+			"property": ref.Property,
+			"version":  ref.Version,
+		},
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := t.Execute(buf, data); err != nil {
+		return "", fmt.Errorf(errSecretKeyExecute, err)
+	}
+	key := buf.String()
+	if key == "" || !ValidateKeys(map[string][]byte{key: nil}) {
+		return "", fmt.Errorf(errSecretKeyInvalid, key)
+	}
+	return key, nil
+}
+
 // DecodeValues decodes values from a secretMap.
 func DecodeMap(strategy esv1beta1.ExternalSecretDecodingStrategy, in map[string][]byte) (map[string][]byte, error) {
 	out := make(map[string][]byte, len(in))
@@ -158,6 +200,12 @@ func Decode(strategy esv1beta1.ExternalSecretDecodingStrategy, in []byte) ([]byt
 			return nil, err
 		}
 		return out, nil
+	case esv1beta1.ExternalSecretDecodeBase64Gzip:
+		out, err := base64.StdEncoding.DecodeString(string(in))
+		if err != nil {
+			return nil, err
+		}
+		return gunzip(out)
 	case esv1beta1.ExternalSecretDecodeNone:
 		return in, nil
 	// default when stored version is v1alpha1
@@ -178,6 +226,24 @@ func Decode(strategy esv1beta1.ExternalSecretDecodingStrategy, in []byte) ([]byt
 	}
 }
 
+// gunzip decompresses in. The output is limited to the size of a Secret,
+// so that a small compressed value can not exhaust the memory of the controller.
+func gunzip(in []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(in))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, maxGunzipSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > maxGunzipSize {
+		return nil, fmt.Errorf("decompressed value exceeds %d bytes", maxGunzipSize)
+	}
+	return out, nil
+}
+
 func ValidateKeys(in map[string][]byte) bool {
 	for key := range in {
 		for _, v := range key {
@@ -263,6 +329,13 @@ func GetByteValue(v interface{}) ([]byte, error) {
 	// also covers int and float32 due to json.Marshal
 	case float64:
 		return []byte(strconv.FormatFloat(t, 'f', -1, 64)), nil
+	// integers are decoded from YAML
+	case int:
+		return []byte(strconv.Itoa(t)), nil
+	case int64:
+		return []byte(strconv.FormatInt(t, 10)), nil
+	case uint64:
+		return []byte(strconv.FormatUint(t, 10)), nil
 	case json.Number:
 		return []byte(t.String()), nil
 	case []interface{}:
@@ -232,6 +232,63 @@ func TestConvertKeys(t *testing.T) {
 	}
 }
 
+func TestRenderSecretKey(t *testing.T) {
+	ref := esv1beta1.ExternalSecretDataRemoteRef{Key: "app/db", Property: "db_password", Version: "2"}
+	tests := []struct {
+		name      string
+		secretKey string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "plain key",
+			secretKey: "password",
+			want:      "password",
+		},
+		{
+			name:      "property",
+			secretKey: "{{ .remoteRef.property | upper }}",
+			want:      "DB_PASSWORD",
+		},
+		{
+			name:      "key and version",
+			secretKey: `{{ .remoteRef.key | replace "/" "-" }}-v{{ .remoteRef.version }}`,
+			want:      "app-db-v2",
+		},
+		{
+			name:      "unknown field",
+			secretKey: "{{ .remoteRef.name }}",
+			wantErr:   true,
+		},
+		{
+			name:      "invalid rendered key",
+			secretKey: "{{ .remoteRef.key }}",
+			wantErr:   true,
+		},
+		{
+			name:      "empty rendered key",
+			secretKey: "{{ .remoteRef.version | trimAll \"2\" }}",
+			wantErr:   true,
+		},
+		{
+			name:      "invalid template",
+			secretKey: "{{ .remoteRef.property",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderSecretKey(tt.secretKey, ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderSecretKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RenderSecretKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDecode(t *testing.T) {
 	type args struct {
 		strategy esv1beta1.ExternalSecretDecodingStrategy
@@ -287,6 +344,28 @@ func TestDecode(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "base64 gzip decoded",
+			args: args{
+				strategy: esv1beta1.ExternalSecretDecodeBase64Gzip,
+				in: map[string][]byte{
+					"foo": []byte("H4sIAAAAAAAA/wADAPz/YmFyAwCqjP92AwAAAA=="),
+				},
+			},
+			want: map[string][]byte{
+				"foo": []byte("bar"),
+			},
+		},
+		{
+			name: "invalid gzip",
+			args: args{
+				strategy: esv1beta1.ExternalSecretDecodeBase64Gzip,
+				in: map[string][]byte{
+					"foo": []byte("YmFy"),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "none",
 			args: args{